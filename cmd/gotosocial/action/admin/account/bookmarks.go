@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ExportBookmarks writes the bookmarks of the given
+// local account to a csv file of status URIs.
+var ExportBookmarks action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	file, err := os.Create(config.GetAdminTransPath())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var exported int
+
+	w := csv.NewWriter(file)
+	for _, bookmark := range bookmarks {
+		if bookmark.Status == nil {
+			continue
+		}
+		if err := w.Write([]string{bookmark.Status.URI}); err != nil {
+			return err
+		}
+		exported++
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	fmt.Printf("exported %d bookmarks\n", exported)
+	return nil
+}
+
+// ImportBookmarks bookmarks statuses for the given local account
+// from a csv file of status URIs. As the server is not running, only
+// statuses already known to the database can be bookmarked; any
+// others are reported, and can be imported again via the API.
+var ImportBookmarks action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(config.GetAdminTransPath())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	uris, err := typeutils.CSVToBookmarks(file)
+	if err != nil {
+		return err
+	}
+
+	var imported int
+	for _, uri := range uris {
+		status, err := state.DB.GetStatusByURI(ctx, uri)
+		if errors.Is(err, db.ErrNoEntries) {
+			status, err = state.DB.GetStatusByURL(ctx, uri)
+		}
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return err
+			}
+			fmt.Printf("status %s not known locally, skipping\n", uri)
+			continue
+		}
+
+		bookmarked, err := state.DB.IsStatusBookmarkedBy(ctx, account.ID, status.ID)
+		if err != nil {
+			return err
+		}
+
+		if bookmarked {
+			continue
+		}
+
+		if err := state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+			ID:              id.NewULID(),
+			AccountID:       account.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+		}); err != nil {
+			return err
+		}
+
+		imported++
+	}
+
+	fmt.Printf("imported %d bookmarks\n", imported)
+	return nil
+}
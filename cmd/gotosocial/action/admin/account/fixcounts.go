@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// FixCounts recomputes followers, following and statuses
+// counts from the database for all local accounts, or
+// only the given account if a username is provided.
+var FixCounts action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	var accounts []*gtsmodel.Account
+
+	if username := config.GetAdminAccountUsername(); username != "" {
+		account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, account)
+	} else {
+		users, err := state.DB.GetAllUsers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			accounts = append(accounts, u.Account)
+		}
+	}
+
+	fmtCount := func(before, after *int) string {
+		if before == nil || *before == *after {
+			return fmt.Sprint(*after)
+		}
+		return fmt.Sprintf("%d -> %d", *before, *after)
+	}
+
+	var fixed int
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "account\tfollowers\tfollowing\tstatuses")
+	for _, account := range accounts {
+		// Load currently stored stats, if any.
+		if err := state.DB.PopulateAccountStats(ctx, account); err != nil {
+			return err
+		}
+		before := *account.Stats
+
+		// Recount from source tables and store.
+		if err := state.DB.RegenerateAccountStats(ctx, account); err != nil {
+			return err
+		}
+		after := account.Stats
+
+		if *before.FollowersCount != *after.FollowersCount ||
+			*before.FollowingCount != *after.FollowingCount ||
+			*before.StatusesCount != *after.StatusesCount {
+			fixed++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			account.Username,
+			fmtCount(before.FollowersCount, after.FollowersCount),
+			fmtCount(before.FollowingCount, after.FollowingCount),
+			fmtCount(before.StatusesCount, after.StatusesCount),
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nfixed counts for %d of %d accounts\n", fixed, len(accounts))
+	return nil
+}
@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+)
+
+// Status prints applied and pending database migrations,
+// and any indexes missing from the database schema.
+var Status action.GTSAction = func(ctx context.Context) error {
+	status, err := bundb.GetSchemaStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "migration\tstatus")
+	for _, name := range status.Applied {
+		fmt.Fprintf(w, "%s\t%s\n", name, "applied")
+	}
+	for _, name := range status.Pending {
+		fmt.Fprintf(w, "%s\t%s\n", name, "pending")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d applied, %d pending\n", len(status.Applied), len(status.Pending))
+
+	if len(status.MissingIndexes) == 0 {
+		fmt.Println("all expected indexes present")
+		return nil
+	}
+
+	fmt.Printf("\n%d expected indexes missing:\n", len(status.MissingIndexes))
+	for _, index := range status.MissingIndexes {
+		fmt.Println(index)
+	}
+
+	if len(status.Pending) > 0 {
+		// Missing indexes are expected if
+		// there are still migrations to run.
+		fmt.Println("\nsome indexes may be created by pending migrations")
+	}
+
+	return nil
+}
+
+// Migrate performs any pending database migrations.
+var Migrate action.GTSAction = func(ctx context.Context) error {
+	return bundb.Migrate(ctx)
+}
@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/setup"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+// failedDelivery wraps a persisted delivery
+// retry with its decoded delivery.
+type failedDelivery struct {
+	retry        *gtsmodel.DeliveryRetry
+	dlv          *delivery.Delivery
+	activityType string
+}
+
+// List prints all failed deliveries currently
+// persisted in the retry queue, filtered by domain
+// and activity type if these flags are set.
+var List action.GTSAction = func(ctx context.Context) error {
+	return withFailed(ctx, func(ctx context.Context, _ *state.State, dlvs []*failedDelivery) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		fmt.Fprintln(w, "id\ttype\turl\tattempts\tnext attempt\tlast error")
+		for _, f := range dlvs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+				f.retry.ID,
+				f.activityType,
+				f.dlv.Request.URL,
+				f.retry.Attempts,
+				f.retry.NextAttemptAt.Format(time.RFC3339),
+				f.retry.LastError,
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Printf("%d failed deliveries\n", len(dlvs))
+		return nil
+	})
+}
+
+// Retry marks all matching failed deliveries as due now, so they
+// are re-attempted the next time a running instance drains its
+// retry queue (at most one minute later).
+var Retry action.GTSAction = func(ctx context.Context) error {
+	return withFailed(ctx, func(ctx context.Context, state *state.State, dlvs []*failedDelivery) error {
+		now := time.Now()
+		for _, f := range dlvs {
+			f.retry.NextAttemptAt = now
+			if err := state.DB.UpdateDeliveryRetry(ctx,
+				f.retry,
+				"next_attempt_at",
+			); err != nil {
+				return fmt.Errorf("error updating delivery retry %s: %w", f.retry.ID, err)
+			}
+		}
+
+		fmt.Printf("%d failed deliveries marked for retry\n", len(dlvs))
+		return nil
+	})
+}
+
+// Purge deletes all matching failed
+// deliveries from the retry queue.
+var Purge action.GTSAction = func(ctx context.Context) error {
+	return withFailed(ctx, func(ctx context.Context, state *state.State, dlvs []*failedDelivery) error {
+		for _, f := range dlvs {
+			if err := state.DB.DeleteDeliveryRetryByID(ctx, f.retry.ID); err != nil {
+				return fmt.Errorf("error deleting delivery retry %s: %w", f.retry.ID, err)
+			}
+		}
+
+		fmt.Printf("%d failed deliveries purged\n", len(dlvs))
+		return nil
+	})
+}
+
+// withFailed sets up state, loads failed deliveries matching
+// configured domain and activity type filters, and passes them
+// to given function, before stopping the state again.
+func withFailed(ctx context.Context, fn func(context.Context, *state.State, []*failedDelivery) error) error {
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	domain := config.GetAdminDeliveryDomain()
+	activityType := config.GetAdminDeliveryType()
+
+	retries, err := state.DB.GetDeliveryRetries(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("error getting delivery retries: %w", err)
+	}
+
+	matched := make([]*failedDelivery, 0, len(retries))
+	for _, retry := range retries {
+		f, err := decode(retry)
+		if err != nil {
+			log.Warnf(ctx, "error decoding delivery retry %s: %v", retry.ID, err)
+			continue
+		}
+
+		if activityType != "" &&
+			!strings.EqualFold(activityType, f.activityType) {
+			continue
+		}
+
+		matched = append(matched, f)
+	}
+
+	return fn(ctx, state, matched)
+}
+
+// decode deserializes the delivery stored in given retry,
+// and extracts the activity type from the request body.
+func decode(retry *gtsmodel.DeliveryRetry) (*failedDelivery, error) {
+	dlv := new(delivery.Delivery)
+	if err := dlv.Deserialize(retry.Data); err != nil {
+		return nil, err
+	}
+
+	f := &failedDelivery{retry: retry, dlv: dlv}
+
+	if dlv.Request.GetBody == nil {
+		// No body to
+		// get type from.
+		return f, nil
+	}
+
+	body, err := dlv.Request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(b, &activity); err != nil {
+		return nil, err
+	}
+
+	f.activityType = activity.Type
+	return f, nil
+}
@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package domain
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/setup"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// ImportBlocks applies domain blocks from the given CSV or JSON
+// blocklist file, in the same way as the admin API import would.
+//
+// Unless dry run is disabled, only a summary of the accounts
+// and statuses that would be affected is printed.
+var ImportBlocks action.GTSAction = func(ctx context.Context) error {
+	blocks, err := parseBlocksFile(config.GetAdminTransPath())
+	if err != nil {
+		return err
+	}
+
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	if config.GetAdminMediaPruneDryRun() {
+		return summarizeBlocks(ctx, state, blocks)
+	}
+
+	processor, err := setup.Processor(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	// Blocks are created by the instance account,
+	// as with blocks from permission subscriptions,
+	// so ensure it exists (e.g. on a fresh install).
+	if err := state.DB.CreateInstanceAccount(ctx); err != nil {
+		return fmt.Errorf("error creating instance account: %w", err)
+	}
+
+	instanceAcct, err := state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error getting instance account: %w", err)
+	}
+
+	var failed int
+
+	for _, block := range blocks {
+		if _, _, errWithCode := processor.Admin().DomainPermissionCreate(
+			ctx,
+			gtsmodel.DomainPermissionBlock,
+			instanceAcct,
+			block.Domain.Domain,
+			block.Obfuscate,
+			block.PublicComment,
+			block.PrivateComment,
+			block.Severity,
+			"", // No sub ID for imports.
+		); errWithCode != nil {
+			log.Errorf(ctx, "error blocking %s: %v", block.Domain.Domain, errWithCode)
+			failed++
+			continue
+		}
+
+		log.Infof(ctx, "blocked %s", block.Domain.Domain)
+	}
+
+	// Block side effects run asynchronously
+	// as admin actions, so wait for these to
+	// finish before shutting everything down.
+	log.Info(ctx, "waiting for domain block side effects to finish...")
+	for len(processor.Admin().Actions().GetRunning()) > 0 {
+		time.Sleep(time.Second)
+	}
+
+	log.Infof(ctx, "applied %d domain blocks, %d failed", len(blocks)-failed, failed)
+	return nil
+}
+
+// summarizeBlocks prints the domains that would be blocked,
+// along with counts of known accounts and statuses on each.
+func summarizeBlocks(ctx context.Context, state *state.State, blocks []*apimodel.DomainPermission) error {
+	var blocksTotal, accountsTotal, statusesTotal int
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "domain\tseverity\tobfuscate\taccounts\tstatuses\tnote")
+	for _, block := range blocks {
+		domain := block.Domain.Domain
+
+		accounts, err := state.DB.CountInstanceUsers(ctx, domain)
+		if err != nil {
+			return err
+		}
+
+		statuses, err := state.DB.CountInstanceStatuses(ctx, domain)
+		if err != nil {
+			return err
+		}
+
+		var note string
+
+		existing, err := state.DB.GetDomainBlock(ctx, domain)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if existing != nil {
+			note = "already blocked"
+		} else {
+			blocksTotal++
+			accountsTotal += accounts
+			statusesTotal += statuses
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%s\n", domain,
+			cmp.Or(block.Severity, string(gtsmodel.DomainBlockSeveritySuspend)),
+			block.Obfuscate, accounts, statuses, note)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\ndry run: %d new domain blocks would affect %d accounts and %d statuses; "+
+		"run again with --dry-run=false to apply\n", blocksTotal, accountsTotal, statusesTotal)
+	return nil
+}
+
+// parseBlocksFile parses the file at given path as a JSON
+// array of domain permissions (as exported by the admin API),
+// or a CSV file (either Mastodon export format with header
+// row, or simply one domain per line), based on extension.
+func parseBlocksFile(filePath string) ([]*apimodel.DomainPermission, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var blocks []*apimodel.DomainPermission
+
+	switch ext := strings.ToLower(path.Ext(filePath)); ext {
+	case ".json":
+		if err := json.NewDecoder(file).Decode(&blocks); err != nil {
+			return nil, fmt.Errorf("error parsing %s as json: %w", filePath, err)
+		}
+	case ".csv":
+		blocks, err = parseBlocksCSV(file)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s as csv: %w", filePath, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported blocklist file extension %s, must be .csv or .json", ext)
+	}
+
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no domain blocks found in %s", filePath)
+	}
+
+	return blocks, nil
+}
+
+// parseBlocksCSV parses CSV domain blocks, using column
+// names from a header row like "#domain,#severity,..."
+// if present, otherwise taking first column as domain.
+func parseBlocksCSV(r io.Reader) ([]*apimodel.DomainPermission, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // allow variable fields.
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Default column indices,
+	// where -1 = not present.
+	columns := map[string]int{
+		"domain":         0,
+		"severity":       -1,
+		"public_comment": -1,
+		"obfuscate":      -1,
+	}
+
+	if len(records) > 0 && strings.HasPrefix(records[0][0], "#") {
+		// Parse header row.
+		columns["domain"] = -1
+		for i, name := range records[0] {
+			name = strings.TrimPrefix(name, "#")
+			if _, ok := columns[name]; ok {
+				columns[name] = i
+			}
+		}
+		records = records[1:]
+
+		if columns["domain"] == -1 {
+			return nil, errors.New("no #domain column in header")
+		}
+	}
+
+	// field returns value of named column in
+	// record, or empty string if not present.
+	field := func(record []string, name string) string {
+		i := columns[name]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	blocks := make([]*apimodel.DomainPermission, 0, len(records))
+	for _, record := range records {
+		domain := field(record, "domain")
+		if domain == "" {
+			continue
+		}
+
+		severity := field(record, "severity")
+		if severity == "noop" {
+			// Mastodon uses noop for entries
+			// that only reject media/reports,
+			// which we have no equivalent for.
+			continue
+		}
+
+		var obfuscate bool
+		if v := field(record, "obfuscate"); v != "" {
+			obfuscate, err = strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid obfuscate value for %s: %w", domain, err)
+			}
+		}
+
+		blocks = append(blocks, &apimodel.DomainPermission{
+			Domain: apimodel.Domain{
+				Domain:        domain,
+				PublicComment: field(record, "public_comment"),
+			},
+			Obfuscate: obfuscate,
+			Severity:  severity,
+		})
+	}
+
+	return blocks, nil
+}
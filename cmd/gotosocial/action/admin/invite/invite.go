@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/setup"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Create creates a new sign-up invite code, as the given
+// local account or the instance account, and prints it.
+var Create action.GTSAction = func(ctx context.Context) error {
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	maxUses := config.GetAdminInviteMaxUses()
+	if maxUses < 0 {
+		return errors.New("max-uses must be 0 (unlimited) or greater")
+	}
+
+	expiresIn := config.GetAdminInviteExpiresIn()
+	if expiresIn < 0 {
+		return errors.New("expires-in must be 0 (never) or greater")
+	}
+
+	var account *gtsmodel.Account
+	if username := config.GetAdminAccountUsername(); username != "" {
+		account, err = state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	} else {
+		// Ensure instance account
+		// exists on a fresh install.
+		if err := state.DB.CreateInstanceAccount(ctx); err != nil {
+			return fmt.Errorf("error creating instance account: %w", err)
+		}
+		account, err = state.DB.GetInstanceAccount(ctx, "")
+	}
+	if err != nil {
+		return fmt.Errorf("error getting account: %w", err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:                 id.NewULID(),
+		Code:               id.NewInviteCode(),
+		CreatedByAccountID: account.ID,
+		MaxUses:            maxUses,
+	}
+
+	if expiresIn > 0 {
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := state.DB.PutInvite(ctx, invite); err != nil {
+		return fmt.Errorf("error putting invite: %w", err)
+	}
+
+	fmt.Printf("created invite code %s\n", invite.Code)
+	fmt.Printf("sign-up link: %s://%s/signup?invite=%s\n", config.GetProtocol(), config.GetHost(), invite.Code)
+	return nil
+}
+
+// List prints all sign-up invites on the instance, newest first.
+var List action.GTSAction = func(ctx context.Context) error {
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	invites, err := state.DB.GetInvites(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error getting invites: %w", err)
+	}
+
+	now := time.Now()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "code\tcreated by\tcreated at\tuses\texpires at\tusable")
+	for _, invite := range invites {
+		createdBy := invite.CreatedByAccountID
+		if account, err := state.DB.GetAccountByID(ctx, createdBy); err == nil {
+			createdBy = account.Username
+		}
+
+		uses := fmt.Sprint(invite.Uses)
+		if invite.MaxUses > 0 {
+			uses += fmt.Sprintf("/%d", invite.MaxUses)
+		}
+
+		expiresAt := "never"
+		if !invite.ExpiresAt.IsZero() {
+			expiresAt = util.FormatISO8601(invite.ExpiresAt)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n",
+			invite.Code,
+			createdBy,
+			util.FormatISO8601(invite.CreatedAt),
+			uses,
+			expiresAt,
+			invite.Usable(now),
+		)
+	}
+
+	return w.Flush()
+}
+
+// Revoke expires the sign-up invite with the
+// given code, so it can no longer be used.
+var Revoke action.GTSAction = func(ctx context.Context) error {
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	code := config.GetAdminInviteCode()
+
+	invite, err := state.DB.GetInviteByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("no invite found with code %s", code)
+		}
+		return fmt.Errorf("error getting invite: %w", err)
+	}
+
+	if now := time.Now(); !invite.Expired(now) {
+		invite.ExpiresAt = now
+		if err := state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+			return fmt.Errorf("error updating invite: %w", err)
+		}
+	}
+
+	fmt.Printf("revoked invite code %s\n", invite.Code)
+	return nil
+}
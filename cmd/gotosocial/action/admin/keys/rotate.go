@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package keys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/setup"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Rotate generates new RSA keypairs for the instance account, and
+// optionally all local user accounts, sending Update activities
+// for rotated user accounts if federate is set.
+var Rotate action.GTSAction = func(ctx context.Context) error {
+	state, err := setup.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := setup.Stop(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	federate := config.GetAdminKeysRotateFederate()
+	if federate {
+		// Processor + workers are only
+		// needed to federate updates.
+		if _, err := setup.Processor(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	instanceAcct, err := state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("error getting instance account: %w", err)
+	}
+
+	accounts := []*gtsmodel.Account{instanceAcct}
+
+	if config.GetAdminKeysRotateUsers() {
+		users, err := state.DB.GetAllUsers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			accounts = append(accounts, u.Account)
+		}
+	}
+
+	for _, account := range accounts {
+		if err := state.DB.RotateAccountKeys(ctx, account); err != nil {
+			return fmt.Errorf("error rotating keys for %s: %w", account.Username, err)
+		}
+
+		log.Infof(ctx, "rotated keys for %s", account.Username)
+
+		if !federate ||
+			account.ID == instanceAcct.ID ||
+			account.IsSuspended() {
+			// Only federate for users that
+			// can federate. The instance account
+			// has no followers to update, remote
+			// servers fetch its key when they next
+			// see the new key ID in a signature.
+			continue
+		}
+
+		// Federate the account update, which
+		// includes the new public key, to the
+		// account's followers.
+		if err := state.Workers.Client.Process(ctx, &messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       account,
+			Origin:         account,
+		}); err != nil {
+			log.Errorf(ctx, "error federating update for %s: %v", account.Username, err)
+		}
+	}
+
+	log.Infof(ctx, "rotated keys for %d accounts", len(accounts))
+
+	if federate {
+		// Any deliveries not yet sent when workers are
+		// stopped get persisted to the retry queue
+		// and will be sent when the server next starts.
+		log.Info(ctx, "undelivered updates will be sent when GoToSocial is next started")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// ExportEmojis writes local custom emojis to an emoji pack at the given path.
+var ExportEmojis action.GTSAction = func(ctx context.Context) error {
+	return withPacker(ctx, func(packer *emojipack.Packer) error {
+		path := config.GetAdminTransPath()
+		if path == "" {
+			return fmt.Errorf("no path set")
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", path, err)
+		}
+
+		count, err := packer.Export(ctx, file, config.GetAdminEmojiCategory())
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("error writing emoji pack: %w", err)
+		}
+
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("error closing %s: %w", path, err)
+		}
+
+		log.Infof(ctx, "exported %d emojis to %s", count, path)
+		return nil
+	})
+}
+
+// ImportEmojis imports the emojis of the emoji pack at the given path as local custom emojis.
+var ImportEmojis action.GTSAction = func(ctx context.Context) error {
+	return withPacker(ctx, func(packer *emojipack.Packer) error {
+		path := config.GetAdminTransPath()
+		if path == "" {
+			return fmt.Errorf("no path set")
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error opening %s: %w", path, err)
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("error getting size of %s: %w", path, err)
+		}
+
+		result, err := packer.Import(ctx, file, info.Size(), emojipack.ImportOptions{
+			Category:   config.GetAdminEmojiCategory(),
+			OnConflict: config.GetAdminEmojiOnConflict(),
+		})
+		if err != nil {
+			return fmt.Errorf("error importing emoji pack: %w", err)
+		}
+
+		log.Infof(ctx, "imported %d emojis, replaced %d, skipped %d, %d failed",
+			len(result.Imported), len(result.Replaced), len(result.Skipped), len(result.Failed))
+		return nil
+	})
+}
+
+// withPacker sets up the database and storage required
+// for an emoji pack Packer, and passes one to the given func.
+func withPacker(ctx context.Context, fn func(*emojipack.Packer) error) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	defer func() {
+		// Ensure database gets closed on exit.
+		if err := dbService.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	//nolint:contextcheck
+	manager := media.NewManager(&state)
+
+	return fn(emojipack.NewPacker(&state, manager))
+}
@@ -0,0 +1,291 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type migrate struct {
+	dbService db.DB
+	state     *state.State
+	src       *gtsstorage.Driver
+	dst       *gtsstorage.Driver
+
+	// counts of keys
+	// by copy outcome.
+	copied  int
+	skipped int
+	missing int
+}
+
+func setupMigrate(ctx context.Context) (*migrate, error) {
+	var (
+		from  = config.GetStorageBackend()
+		to    = config.GetAdminMediaMigrateTo()
+		state state.State
+		dst   *gtsstorage.Driver
+		err   error
+	)
+
+	// Validate flags.
+	if from == to {
+		return nil, fmt.Errorf(
+			"storage-backend is already %s; set the to flag "+
+				"to the backend you want to copy media to", to,
+		)
+	}
+
+	//nolint:contextcheck
+	src, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error creating source storage backend: %w", err)
+	}
+
+	switch to {
+	case "s3":
+		//nolint:contextcheck
+		dst, err = gtsstorage.NewS3Storage()
+	case "local":
+		//nolint:contextcheck
+		dst, err = gtsstorage.NewFileStorage()
+	default:
+		err = fmt.Errorf("invalid storage backend: %s", to)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating destination storage backend: %w", err)
+	}
+
+	state.Caches.Init()
+	state.Caches.Start()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	return &migrate{
+		dbService: dbService,
+		state:     &state,
+		src:       src,
+		dst:       dst,
+	}, nil
+}
+
+func (m *migrate) shutdown() error {
+	err := m.dbService.Close()
+	m.state.Caches.Stop()
+	return err
+}
+
+// copyKey copies the object at key from source to destination storage.
+// Objects already in the destination with matching size are skipped,
+// so that an interrupted migration can be resumed by running it again.
+func (m *migrate) copyKey(ctx context.Context, key string) error {
+	if key == "" {
+		// Nothing
+		// to copy.
+		return nil
+	}
+
+	srcStat, err := m.src.Storage.Stat(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error checking source %s: %w", key, err)
+	}
+
+	if srcStat == nil {
+		// Can't copy what doesn't exist,
+		// but don't let that stop us.
+		log.Warnf(ctx, "missing from source storage: %s", key)
+		m.missing++
+		return nil
+	}
+
+	dstStat, err := m.dst.Storage.Stat(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error checking destination %s: %w", key, err)
+	}
+
+	if dstStat != nil {
+		if dstStat.Size == srcStat.Size {
+			// Already copied,
+			// e.g. on previous run.
+			m.skipped++
+			return nil
+		}
+
+		// Size mismatch means this was only
+		// partially copied; remove and retry.
+		if err := m.dst.Delete(ctx, key); err != nil {
+			return gtserror.Newf("error removing partial %s: %w", key, err)
+		}
+	}
+
+	rc, err := m.src.GetStream(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error reading %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	if _, err := m.dst.PutStream(ctx, key, rc); err != nil {
+		return gtserror.Newf("error writing %s: %w", key, err)
+	}
+
+	m.copied++
+	return nil
+}
+
+// copyAttachments copies all media attachment files and thumbnails.
+func (m *migrate) copyAttachments(ctx context.Context) error {
+	page := paging.Page{Limit: 200}
+
+	for {
+		// Get the next page of media attachments up to max ID.
+		attachments, err := m.dbService.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("failed to retrieve media metadata from database: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, a := range attachments {
+			if !*a.Cached {
+				// No files
+				// to copy.
+				continue
+			}
+
+			if err := m.copyKey(ctx, a.File.Path); err != nil {
+				return err
+			}
+
+			if err := m.copyKey(ctx, a.Thumbnail.Path); err != nil {
+				return err
+			}
+		}
+
+		log.Infof(ctx, "progress: copied %d, skipped %d, missing %d", m.copied, m.skipped, m.missing)
+	}
+
+	return nil
+}
+
+// copyEmojis copies all emoji images and static images.
+func (m *migrate) copyEmojis(ctx context.Context) error {
+	page := paging.Page{Limit: 200}
+
+	for {
+		// Get the next page of emoji media up to max ID.
+		emojis, err := m.dbService.GetEmojis(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("failed to retrieve emoji metadata from database: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no emojis or the same group is returned, we reached the end.
+		if len(emojis) == 0 || maxID == emojis[len(emojis)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = emojis[len(emojis)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, e := range emojis {
+			if !*e.Cached {
+				// No files
+				// to copy.
+				continue
+			}
+
+			if err := m.copyKey(ctx, e.ImagePath); err != nil {
+				return err
+			}
+
+			if err := m.copyKey(ctx, e.ImageStaticPath); err != nil {
+				return err
+			}
+		}
+
+		log.Infof(ctx, "progress: copied %d, skipped %d, missing %d", m.copied, m.skipped, m.missing)
+	}
+
+	return nil
+}
+
+// MigrateStorage copies all attachment and emoji media from
+// the configured storage backend to the given target backend.
+var MigrateStorage action.GTSAction = func(ctx context.Context) error {
+	migrate, err := setupMigrate(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure migrator gets shutdown on exit.
+		if err := migrate.shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	if err := migrate.copyAttachments(ctx); err != nil {
+		return err
+	}
+
+	if err := migrate.copyEmojis(ctx); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "done: copied %d, skipped %d, missing %d", migrate.copied, migrate.skipped, migrate.missing)
+
+	to := config.GetAdminMediaMigrateTo()
+	fmt.Printf(
+		"all media has been copied to %s storage; to start using it, "+
+			"set storage-backend to %q in your config and restart GoToSocial. "+
+			"Once you've confirmed everything works, you can remove "+
+			"media from your %s storage.\n",
+		to, to, config.GetStorageBackend(),
+	)
+
+	return nil
+}
@@ -26,7 +26,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
-// Orphaned prunes orphaned media from storage.
+// Orphaned prunes orphaned media from storage, and
+// reports media entries with files missing from storage.
 var Orphaned action.GTSAction = func(ctx context.Context) error {
 	// Setup pruning utilities.
 	prune, err := setupPrune(ctx)
@@ -49,6 +50,10 @@ var Orphaned action.GTSAction = func(ctx context.Context) error {
 	// Perform the actual pruning with logging.
 	prune.cleaner.Media().LogPruneOrphaned(ctx)
 
+	// Report entries missing media in storage.
+	prune.cleaner.Media().LogMissingFiles(ctx)
+	prune.cleaner.Emoji().LogMissingFiles(ctx)
+
 	// Perform a cleanup of storage (for removed local dirs).
 	if err := prune.storage.Storage.Clean(ctx); err != nil {
 		log.Error(ctx, "error cleaning storage: %v", err)
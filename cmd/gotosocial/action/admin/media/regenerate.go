@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// RegenerateThumbnails walks all media attachments, and re-derives
+// thumbnails and blurhashes for those missing them or produced by
+// older processing pipelines.
+var RegenerateThumbnails action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	defer func() {
+		// Ensure database gets closed on exit.
+		if err := dbService.Close(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	//nolint:contextcheck
+	manager := media.NewManager(&state)
+
+	var (
+		page        = paging.Page{Limit: 200}
+		regenerated int
+		failed      int
+	)
+
+	for {
+		// Get the next page of media attachments up to max ID.
+		attachments, err := dbService.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("failed to retrieve media metadata from database: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, a := range attachments {
+			ok, err := manager.RegenerateThumbnail(ctx, a)
+			if err != nil {
+				// Log and carry on, one broken
+				// file shouldn't halt the rest.
+				log.Errorf(ctx, "error regenerating thumbnail for media %s: %v", a.ID, err)
+				failed++
+				continue
+			}
+
+			if ok {
+				log.Debugf(ctx, "regenerated thumbnail for media %s", a.ID)
+				regenerated++
+			}
+		}
+	}
+
+	log.Infof(ctx, "regenerated %d thumbnails, %d failed", regenerated, failed)
+	return nil
+}
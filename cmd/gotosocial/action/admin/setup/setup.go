@@ -0,0 +1,200 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package setup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	tlprocessor "github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+// State prepares a new state with caches
+// and a database connection initialized.
+func State(ctx context.Context) (*state.State, error) {
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+
+	// Set the state DB connection
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dbConn: %w", err)
+	}
+	state.DB = dbConn
+
+	return &state, nil
+}
+
+// Processor prepares the given state with everything needed to
+// process admin actions, side effects and federation the same
+// way the running server would, and returns a processor using it.
+func Processor(ctx context.Context, state *state.State) (*processing.Processor, error) {
+	var err error
+
+	//nolint:contextcheck
+	state.Storage, err = gtsstorage.AutoConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error opening storage backend: %w", err)
+	}
+
+	// Prepare wrapped httpclient with config.
+	client := httpclient.New(httpclient.Config{
+		AllowRanges:           config.MustParseIPPrefixes(config.GetHTTPClientAllowIPs()),
+		BlockRanges:           config.MustParseIPPrefixes(config.GetHTTPClientBlockIPs()),
+		Timeout:               config.GetHTTPClientTimeout(),
+		TLSInsecureSkipVerify: config.GetHTTPClientTLSInsecureSkipVerify(),
+		HostRequestBudget:     config.GetHTTPClientHostRequestBudget(),
+		HostRequestWindow:     config.GetHTTPClientHostRequestWindow(),
+		Delivery: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientDeliveryTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientDeliveryMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Dereference: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientDereferenceTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientDereferenceMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Media: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientMediaTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientMediaMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Proxy:           config.MustParseURL(config.GetHTTPClientProxy()),
+		OnionProxy:      config.MustParseURL(config.GetHTTPClientOnionProxy()),
+		I2PProxy:        config.MustParseURL(config.GetHTTPClientI2PProxy()),
+		BreakerFailures: config.GetHTTPClientCircuitBreakerFailures(),
+		BreakerCooldown: config.GetHTTPClientCircuitBreakerCooldown(),
+	})
+
+	// Build handlers needed by the processor.
+	//
+	//nolint:contextcheck
+	mediaManager := media.NewManager(state)
+	oauthServer := oauth.New(ctx, state.DB)
+	typeConverter := typeutils.NewConverter(state)
+	visFilter := visibility.NewFilter(state)
+	spamFilter := spam.NewFilter(state)
+	federatingDB := federatingdb.New(state, typeConverter, visFilter, spamFilter)
+	transportController := transport.NewController(state, federatingDB, &federation.Clock{}, client)
+	federator := federation.NewFederator(state, federatingDB, transportController, typeConverter, visFilter, mediaManager)
+
+	// No emails from the CLI.
+	emailSender, err := email.NewNoopSender(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating noop email sender: %w", err)
+	}
+
+	// Initialize both home / list timelines,
+	// which account deletion side effects touch.
+	state.Timelines.Home = timeline.NewManager(
+		tlprocessor.HomeTimelineGrab(state),
+		tlprocessor.HomeTimelineFilter(state, visFilter),
+		tlprocessor.HomeTimelineStatusPrepare(state, typeConverter),
+		tlprocessor.SkipInsert(),
+	)
+	if err := state.Timelines.Home.Start(); err != nil {
+		return nil, fmt.Errorf("error starting home timeline: %w", err)
+	}
+	state.Timelines.List = timeline.NewManager(
+		tlprocessor.ListTimelineGrab(state),
+		tlprocessor.ListTimelineFilter(state, visFilter),
+		tlprocessor.ListTimelineStatusPrepare(state, typeConverter),
+		tlprocessor.SkipInsert(),
+	)
+	if err := state.Timelines.List.Start(); err != nil {
+		return nil, fmt.Errorf("error starting list timeline: %w", err)
+	}
+
+	// Scheduler is required for
+	// the cleaner, but no jobs
+	// are scheduled by the CLI.
+	state.Workers.StartScheduler()
+
+	//nolint:contextcheck
+	processor := processing.NewProcessor(
+		cleaner.New(state),
+		typeConverter,
+		federator,
+		oauthServer,
+		mediaManager,
+		state,
+		emailSender,
+	)
+
+	// Initialize and start the worker pools.
+	state.Workers.Client.Init(messages.ClientMsgIndices(), workers.ClientMsgLane)
+	state.Workers.Federator.Init(messages.FederatorMsgIndices(), workers.FederatorMsgLane)
+	state.Workers.Delivery.Init(client)
+	state.Workers.Delivery.Retries.DB = state.DB
+	state.Workers.Delivery.Retries.Sign = transportController.SignDelivery
+	state.Workers.Delivery.Retries.MaxAttempts = config.GetAdvancedDeliveryMaxAttempts()
+	state.Workers.Delivery.DeadHosts.After = config.GetAdvancedDeliveryDeadHostAfter()
+	state.Workers.Client.Process = processor.Workers().ProcessFromClientAPI
+	state.Workers.Federator.Process = processor.Workers().ProcessFromFediAPI
+	state.Workers.Start()
+
+	return processor, nil
+}
+
+// Stop stops everything started in given state by
+// State() and Processor(), and closes the database.
+func Stop(state *state.State) error {
+	errs := gtserror.NewMultiError(3)
+
+	// Stops scheduler
+	// if started.
+	state.Workers.Stop()
+
+	if state.Timelines.Home != nil {
+		if err := state.Timelines.Home.Stop(); err != nil {
+			errs.Appendf("error stopping home timeline: %w", err)
+		}
+	}
+
+	if state.Timelines.List != nil {
+		if err := state.Timelines.List.Stop(); err != nil {
+			errs.Appendf("error stopping list timeline: %w", err)
+		}
+	}
+
+	if err := state.DB.Close(); err != nil {
+		errs.Appendf("error stopping database: %w", err)
+	}
+
+	state.Caches.Stop()
+	return errs.Combine()
+}
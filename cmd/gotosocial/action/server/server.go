@@ -56,12 +56,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/redis"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
 // Start creates and starts a gotosocial server
@@ -83,8 +85,13 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// depending on what services were
 		// managed to be started.
 
-		state = new(state.State)
-		route *router.Router
+		state     = new(state.State)
+		route     *router.Router
+		processor *processing.Processor
+
+		// Redis client, shared between
+		// replicas if configured.
+		redisClient *redis.Client
 	)
 
 	defer func() {
@@ -106,6 +113,18 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// tasks from being executed.
 		state.Workers.Stop()
 
+		if processor != nil {
+			// Stop fanning out any
+			// streaming messages.
+			processor.Stream().Stop()
+
+			// Processor was setup, store any worker
+			// messages left queued so they aren't lost.
+			if err := processor.Workers().PersistWorkerQueues(ctx); err != nil {
+				log.Errorf(ctx, "error persisting worker queues: %v", err)
+			}
+		}
+
 		if state.Timelines.Home != nil {
 			// Home timeline mgr was setup, ensure it gets stopped.
 			if err := state.Timelines.Home.Stop(); err != nil {
@@ -139,6 +158,20 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// Initialize caches
 	state.Caches.Init()
+
+	if url := config.GetRedisURL(); url != "" {
+		var err error
+
+		// Share cache invalidations with
+		// other replicas over redis pub/sub.
+		redisClient, err = redis.New(url)
+		if err != nil {
+			return fmt.Errorf("error creating redis client: %w", err)
+		}
+		channel := "gotosocial:" + config.GetHost() + ":cache-invalidation"
+		state.Caches.SetInvalidationBus(redisClient.Channel(channel))
+	}
+
 	state.Caches.Start()
 
 	// Open connection to the database now caches started.
@@ -179,6 +212,25 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		BlockRanges:           config.MustParseIPPrefixes(config.GetHTTPClientBlockIPs()),
 		Timeout:               config.GetHTTPClientTimeout(),
 		TLSInsecureSkipVerify: config.GetHTTPClientTLSInsecureSkipVerify(),
+		HostRequestBudget:     config.GetHTTPClientHostRequestBudget(),
+		HostRequestWindow:     config.GetHTTPClientHostRequestWindow(),
+		Delivery: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientDeliveryTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientDeliveryMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Dereference: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientDereferenceTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientDereferenceMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Media: httpclient.ClassLimits{
+			Timeout:     config.GetHTTPClientMediaTimeout(),
+			MaxBodySize: int64(config.GetHTTPClientMediaMaxBodySize()), // #nosec G115 -- won't overflow
+		},
+		Proxy:           config.MustParseURL(config.GetHTTPClientProxy()),
+		OnionProxy:      config.MustParseURL(config.GetHTTPClientOnionProxy()),
+		I2PProxy:        config.MustParseURL(config.GetHTTPClientI2PProxy()),
+		BreakerFailures: config.GetHTTPClientCircuitBreakerFailures(),
+		BreakerCooldown: config.GetHTTPClientCircuitBreakerCooldown(),
 	})
 
 	// Build handlers used in later initializations.
@@ -229,7 +281,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	// Start the job scheduler
-	// (this is required for cleaner).
+	// (this is required for cleaner),
+	// using the database to elect one
+	// process to run exclusive tasks.
+	state.Workers.Scheduler.Locker = dbService
 	state.Workers.StartScheduler()
 
 	// Add a task to the scheduler to sweep caches.
@@ -256,7 +311,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// Create the processor using all the
 	// other services we've created so far.
-	processor := processing.NewProcessor(
+	processor = processing.NewProcessor(
 		cleaner,
 		typeConverter,
 		federator,
@@ -266,23 +321,72 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		emailSender,
 	)
 
+	if redisClient != nil {
+		// Fan out streaming messages between
+		// replicas over redis pub/sub too.
+		channel := "gotosocial:" + config.GetHost() + ":streaming"
+		processor.Stream().Start(redisClient.Channel(channel))
+	}
+
 	// Initialize the specialized workers pools.
-	state.Workers.Client.Init(messages.ClientMsgIndices())
-	state.Workers.Federator.Init(messages.FederatorMsgIndices())
+	state.Workers.Client.Init(messages.ClientMsgIndices(), workers.ClientMsgLane)
+	state.Workers.Federator.Init(messages.FederatorMsgIndices(), workers.FederatorMsgLane)
 	state.Workers.Delivery.Init(client)
+	state.Workers.Delivery.Retries.DB = dbService
+	state.Workers.Delivery.Retries.Sign = transportController.SignDelivery
+	state.Workers.Delivery.Retries.MaxAttempts = config.GetAdvancedDeliveryMaxAttempts()
+	state.Workers.Delivery.DeadHosts.After = config.GetAdvancedDeliveryDeadHostAfter()
 	state.Workers.Client.Process = processor.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = processor.Workers().ProcessFromFediAPI
 
 	// Now start workers!
 	state.Workers.Start()
 
+	// Re-queue any worker messages
+	// persisted on last shutdown.
+	if err := processor.Workers().FillWorkerQueues(ctx); err != nil {
+		log.Errorf(ctx, "error filling worker queues: %v", err)
+	}
+
+	// Enable spooling of incoming
+	// activities, if configured.
+	if err := processor.Workers().ScheduleSpooling(ctx); err != nil {
+		return fmt.Errorf("error scheduling federator spooling: %w", err)
+	}
+
 	// Schedule notif tasks for all existing poll expiries.
 	if err := processor.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule timeline invalidation for all existing mute expiries.
+	if err := processor.Account().ScheduleMuteExpiries(ctx); err != nil {
+		return fmt.Errorf("error scheduling mute expiries: %w", err)
+	}
+
+	// Schedule recurring task for status expiry.
+	if err := processor.Account().ScheduleStatusExpiry(); err != nil {
+		return fmt.Errorf("error scheduling status expiry: %w", err)
+	}
+
+	// Clear up old account exports, and schedule
+	// a recurring task to keep doing so.
+	if err := processor.Account().ScheduleExportSweep(ctx); err != nil {
+		return fmt.Errorf("error scheduling account export sweep: %w", err)
+	}
+
+	// Schedule recurring task for filter subscription sync.
+	if err := processor.FiltersV2().ScheduleSubscriptionSync(); err != nil {
+		return fmt.Errorf("error scheduling filter subscription sync: %w", err)
+	}
+
+	// Schedule recurring task for chunked media upload expiry.
+	if err := processor.Media().ScheduleUploadExpiry(); err != nil {
+		return fmt.Errorf("error scheduling media upload expiry: %w", err)
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -315,6 +419,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// note: hooks adding ctx fields must be ABOVE
 		// the logger, otherwise won't be accessible.
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(state),
 		middleware.HeaderFilter(state),
 		middleware.UserAgent(),
 		middleware.CORS(),
@@ -200,7 +200,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -222,6 +222,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	middlewares = append(middlewares, []gin.HandlerFunc{
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(state),
 		middleware.HeaderFilter(state),
 		middleware.UserAgent(),
 		middleware.CORS(),
@@ -20,6 +20,11 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/db"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/delivery"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/domain"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/invite"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/keys"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
@@ -146,6 +151,88 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountFixCountsCmd := &cobra.Command{
+		Use:   "fix-counts",
+		Short: "recompute followers, following and statuses counts for all local accounts, or the given one",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.FixCounts)
+		},
+	}
+	config.AddAdminAccountFixCounts(adminAccountFixCountsCmd)
+	adminAccountCmd.AddCommand(adminAccountFixCountsCmd)
+
+	adminAccountExportBookmarksCmd := &cobra.Command{
+		Use:   "export-bookmarks",
+		Short: "export bookmarks of the given local account to a csv file of status URIs at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ExportBookmarks)
+		},
+	}
+	config.AddAdminAccount(adminAccountExportBookmarksCmd)
+	config.AddAdminTrans(adminAccountExportBookmarksCmd)
+	adminAccountCmd.AddCommand(adminAccountExportBookmarksCmd)
+
+	adminAccountImportBookmarksCmd := &cobra.Command{
+		Use:   "import-bookmarks",
+		Short: "import bookmarks for the given local account from a csv file of status URIs at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ImportBookmarks)
+		},
+	}
+	config.AddAdminAccount(adminAccountImportBookmarksCmd)
+	config.AddAdminTrans(adminAccountImportBookmarksCmd)
+	adminAccountCmd.AddCommand(adminAccountImportBookmarksCmd)
+
+	adminAccountExportArchiveCmd := &cobra.Command{
+		Use:   "export-archive",
+		Short: "export an archive of the data of the given local account to a zip file at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ExportArchive)
+		},
+	}
+	config.AddAdminAccount(adminAccountExportArchiveCmd)
+	config.AddAdminTrans(adminAccountExportArchiveCmd)
+	adminAccountCmd.AddCommand(adminAccountExportArchiveCmd)
+
+	adminAccountExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export the profile, keys, relationships and statuses of the given local account to a file at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Export)
+		},
+	}
+	config.AddAdminAccount(adminAccountExportCmd)
+	config.AddAdminTrans(adminAccountExportCmd)
+	adminAccountCmd.AddCommand(adminAccountExportCmd)
+
+	adminAccountImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "import a local account previously exported with 'admin account export' from a file at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Import)
+		},
+	}
+	config.AddAdminTrans(adminAccountImportCmd)
+	adminAccountCmd.AddCommand(adminAccountImportCmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -217,6 +304,57 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaList(adminMediaListEmojisLocalCmd)
 	adminMediaCmd.AddCommand(adminMediaListEmojisLocalCmd)
 
+	adminMediaRegenerateCmd := &cobra.Command{
+		Use:   "regenerate-thumbnails",
+		Short: "regenerate missing or outdated attachment thumbnails and blurhashes",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.RegenerateThumbnails)
+		},
+	}
+	adminMediaCmd.AddCommand(adminMediaRegenerateCmd)
+
+	adminMediaMigrateCmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "copy all media from the configured storage backend to another one",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.MigrateStorage)
+		},
+	}
+	config.AddAdminMediaMigrate(adminMediaMigrateCmd)
+	adminMediaCmd.AddCommand(adminMediaMigrateCmd)
+
+	adminMediaExportEmojisCmd := &cobra.Command{
+		Use:   "export-emojis",
+		Short: "export local custom emojis as an emoji pack (zip archive with manifest)",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.ExportEmojis)
+		},
+	}
+	config.AddAdminMediaEmojis(adminMediaExportEmojisCmd)
+	adminMediaCmd.AddCommand(adminMediaExportEmojisCmd)
+
+	adminMediaImportEmojisCmd := &cobra.Command{
+		Use:   "import-emojis",
+		Short: "import the emojis of an emoji pack as local custom emojis",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.ImportEmojis)
+		},
+	}
+	config.AddAdminMediaEmojis(adminMediaImportEmojisCmd)
+	adminMediaCmd.AddCommand(adminMediaImportEmojisCmd)
+
 	/*
 		ADMIN MEDIA PRUNE COMMANDS
 	*/
@@ -268,5 +406,187 @@ func adminCommands() *cobra.Command {
 
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN DOMAIN COMMANDS
+	*/
+
+	adminDomainCmd := &cobra.Command{
+		Use:   "domain",
+		Short: "admin commands related to domain permissions",
+	}
+
+	adminDomainImportBlocksCmd := &cobra.Command{
+		Use:   "import-blocks",
+		Short: "apply domain blocks from a csv or json blocklist file",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), domain.ImportBlocks)
+		},
+	}
+	config.AddAdminDomainImport(adminDomainImportBlocksCmd)
+	adminDomainCmd.AddCommand(adminDomainImportBlocksCmd)
+
+	adminCmd.AddCommand(adminDomainCmd)
+
+	/*
+		ADMIN KEYS COMMANDS
+	*/
+
+	adminKeysCmd := &cobra.Command{
+		Use:   "keys",
+		Short: "admin commands related to account signing keys",
+	}
+
+	adminKeysRotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "rotate the rsa keypair of the instance account, and optionally all local users",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), keys.Rotate)
+		},
+	}
+	config.AddAdminKeysRotate(adminKeysRotateCmd)
+	adminKeysCmd.AddCommand(adminKeysRotateCmd)
+
+	adminCmd.AddCommand(adminKeysCmd)
+
+	/*
+		ADMIN DELIVERY COMMANDS
+	*/
+
+	adminDeliveryCmd := &cobra.Command{
+		Use:   "delivery",
+		Short: "admin commands related to failed outgoing federation deliveries",
+	}
+
+	adminDeliveryListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list failed deliveries waiting in the retry queue",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), delivery.List)
+		},
+	}
+	config.AddAdminDelivery(adminDeliveryListCmd)
+	adminDeliveryCmd.AddCommand(adminDeliveryListCmd)
+
+	adminDeliveryRetryCmd := &cobra.Command{
+		Use:   "retry",
+		Short: "mark failed deliveries in the retry queue as due to be re-attempted now",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), delivery.Retry)
+		},
+	}
+	config.AddAdminDelivery(adminDeliveryRetryCmd)
+	adminDeliveryCmd.AddCommand(adminDeliveryRetryCmd)
+
+	adminDeliveryPurgeCmd := &cobra.Command{
+		Use:   "purge",
+		Short: "delete failed deliveries from the retry queue",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), delivery.Purge)
+		},
+	}
+	config.AddAdminDelivery(adminDeliveryPurgeCmd)
+	adminDeliveryCmd.AddCommand(adminDeliveryPurgeCmd)
+
+	adminCmd.AddCommand(adminDeliveryCmd)
+
+	/*
+		ADMIN INVITE COMMANDS
+	*/
+
+	adminInviteCmd := &cobra.Command{
+		Use:   "invite",
+		Short: "admin commands related to sign-up invite codes",
+	}
+
+	adminInviteCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a new sign-up invite code",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), invite.Create)
+		},
+	}
+	config.AddAdminInviteCreate(adminInviteCreateCmd)
+	adminInviteCmd.AddCommand(adminInviteCreateCmd)
+
+	adminInviteListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list all sign-up invite codes",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), invite.List)
+		},
+	}
+	adminInviteCmd.AddCommand(adminInviteListCmd)
+
+	adminInviteRevokeCmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "revoke a sign-up invite code, so it can no longer be used",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), invite.Revoke)
+		},
+	}
+	config.AddAdminInviteRevoke(adminInviteRevokeCmd)
+	adminInviteCmd.AddCommand(adminInviteRevokeCmd)
+
+	adminCmd.AddCommand(adminInviteCmd)
+
+	/*
+		ADMIN DATABASE COMMANDS
+	*/
+
+	adminDBCmd := &cobra.Command{
+		Use:   "db",
+		Short: "admin commands related to the database schema",
+	}
+
+	adminDBStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "list applied and pending migrations, and verify expected indexes exist",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), db.Status)
+		},
+	}
+	adminDBCmd.AddCommand(adminDBStatusCmd)
+
+	adminDBMigrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "perform any pending database migrations",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), db.Migrate)
+		},
+	}
+	adminDBCmd.AddCommand(adminDBMigrateCmd)
+
+	adminCmd.AddCommand(adminDBCmd)
+
 	return adminCmd
 }
@@ -682,12 +682,19 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 		return nil, gtserror.Newf("error extracting attachment URL: %w", err)
 	}
 
-	return &gtsmodel.MediaAttachment{
+	attachment := &gtsmodel.MediaAttachment{
 		RemoteURL:   remoteURL.String(),
 		Description: ExtractDescription(i),
 		Blurhash:    ExtractBlurhash(i),
 		Processing:  gtsmodel.ProcessingStatusReceived,
-	}, nil
+	}
+
+	// Set the focal point, if any.
+	x, y := GetFocalPoint(i)
+	attachment.FileMeta.Focus.X = x
+	attachment.FileMeta.Focus.Y = y
+
+	return attachment, nil
 }
 
 // ExtractDescription extracts the image description
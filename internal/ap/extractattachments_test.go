@@ -77,6 +77,50 @@ func (suite *ExtractAttachmentsTestSuite) TestExtractDescription() {
 	suite.Equal("A very large panel that is entirely twist switches", attachment.Description)
 }
 
+func (suite *ExtractAttachmentsTestSuite) TestExtractFocalPoint() {
+	attachmentableJSON := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "focalPoint": [-0.5, 0.75],
+  "mediaType": "image/jpeg",
+  "type": "Document",
+  "url": "https://example.org/d/XzKw4M2Sc1pBxj3hY4.jpg"
+}`
+
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(attachmentableJSON), &raw); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachmentable, ok := t.(ap.Attachmentable)
+	if !ok {
+		suite.FailNow("type was not Attachmentable")
+	}
+
+	attachment, err := ap.ExtractAttachment(attachmentable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.EqualValues(-0.5, attachment.FileMeta.Focus.X)
+	suite.EqualValues(0.75, attachment.FileMeta.Focus.Y)
+}
+
+func (suite *ExtractAttachmentsTestSuite) TestExtractFocalPointInvalid() {
+	d1 := suite.document1
+
+	// Out of range values should be ignored.
+	d1.GetUnknownProperties()["focalPoint"] = []interface{}{2.0, 0.5}
+
+	x, y := ap.GetFocalPoint(d1)
+	suite.Zero(x)
+	suite.Zero(y)
+}
+
 func TestExtractAttachmentsTestSuite(t *testing.T) {
 	suite.Run(t, &ExtractAttachmentsTestSuite{})
 }
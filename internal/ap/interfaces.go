@@ -226,6 +226,7 @@ type Attachmentable interface {
 	WithName
 	WithSummary
 	WithBlurhash
+	WithFocalPoint
 }
 
 // Hashtaggable represents the minimum activitypub interface for representing a 'hashtag' tag.
@@ -564,9 +565,13 @@ type WithBlurhash interface {
 	SetTootBlurhash(vocab.TootBlurhashProperty)
 }
 
-// type withFocalPoint interface {
-// 	// TODO
-// }
+// WithFocalPoint represents an activity with a toot:focalPoint property.
+//
+// This property isn't generated by go-fed, so it's
+// accessed via the type's map of unknown properties.
+type WithFocalPoint interface {
+	GetUnknownProperties() map[string]interface{}
+}
 
 // WithHref represents an activity with ActivityStreamsHrefProperty
 type WithHref interface {
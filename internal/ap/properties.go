@@ -520,6 +520,38 @@ func SetManuallyApprovesFollowers(with WithManuallyApprovesFollowers, manuallyAp
 	mafProp.Set(manuallyApprovesFollowers)
 }
 
+// focalPointProp is the JSON key of the toot:focalPoint
+// property, an [x, y] array of coordinates in -1.0 to 1.0.
+const focalPointProp = "focalPoint"
+
+// GetFocalPoint returns the x and y coordinates contained in the FocalPoint property of 'with'.
+//
+// Returns default '0, 0' (center) if property unusable or not set.
+func GetFocalPoint(with WithFocalPoint) (x, y float32) {
+	raw, ok := with.GetUnknownProperties()[focalPointProp].([]interface{})
+	if !ok || len(raw) != 2 {
+		return 0, 0
+	}
+
+	fx, ok1 := raw[0].(float64)
+	fy, ok2 := raw[1].(float64)
+	if !ok1 || !ok2 ||
+		fx < -1 || fx > 1 ||
+		fy < -1 || fy > 1 {
+		return 0, 0
+	}
+
+	return float32(fx), float32(fy)
+}
+
+// SetFocalPoint sets the given x and y coordinates on the FocalPoint property of 'with'.
+func SetFocalPoint(with WithFocalPoint, x, y float32) {
+	with.GetUnknownProperties()[focalPointProp] = []interface{}{
+		float64(x),
+		float64(y),
+	}
+}
+
 // extractIRIs extracts just the AP IRIs from an iterable
 // property that may contain types (with IRIs) or just IRIs.
 //
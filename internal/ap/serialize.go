@@ -18,6 +18,9 @@
 package ap
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -39,21 +42,121 @@ import (
 //   - Any Accountable type:    'attachment' property will always be made into an array.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
+//
+// For all types, '@context' entries are sorted for a stable output, and term definitions
+// are added for any extension properties set that go-fed doesn't know of (e.g. focalPoint).
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch tn := t.GetTypeName(); {
 	case tn == ObjectOrderedCollection ||
 		tn == ObjectOrderedCollectionPage:
-		return serializeWithOrderedItems(t)
+		m, e = serializeWithOrderedItems(t)
 	case IsAccountable(tn):
-		return serializeAccountable(t, true)
+		m, e = serializeAccountable(t, true)
 	case IsStatusable(tn):
-		return serializeStatusable(t, true)
+		m, e = serializeStatusable(t, true)
 	case IsActivityable(tn):
-		return serializeActivityable(t, true)
+		m, e = serializeActivityable(t, true)
 	default:
 		// No custom serializer necessary.
-		return streams.Serialize(t)
+		m, e = streams.Serialize(t)
+	}
+
+	if e != nil {
+		return nil, e
+	}
+
+	normalizeContext(m)
+	return m, nil
+}
+
+// activityStreamsContext is the
+// JSON-LD context of activitystreams.
+const activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+
+// focalPointContext is the JSON-LD context
+// defining the toot:focalPoint property, as
+// used by Mastodon, see SetFocalPoint().
+var focalPointContext = map[string]interface{}{
+	"toot": "http://joinmastodon.org/ns#",
+	focalPointProp: map[string]interface{}{
+		"@container": "@list",
+		"@id":        "toot:focalPoint",
+	},
+}
+
+// normalizeContext sorts the entries of the '@context' of serialized data,
+// activitystreams first then other vocabularies then term definitions, as
+// go-fed generates them in random order, and adds the focalPoint term
+// definition if the property is set anywhere in data.
+func normalizeContext(data map[string]interface{}) {
+	context, ok := data["@context"]
+	if !ok {
+		return
+	}
+
+	var entries []interface{}
+	switch c := context.(type) {
+	case []interface{}:
+		entries = c
+	default:
+		entries = []interface{}{c}
+	}
+
+	if hasProperty(data, focalPointProp) {
+		entries = append(entries, focalPointContext)
+	}
+
+	if len(entries) == 1 {
+		// Nothing to sort.
+		data["@context"] = entries[0]
+		return
+	}
+
+	// rank returns sort rank of context entry.
+	rank := func(entry interface{}) int {
+		switch e, ok := entry.(string); {
+		case e == activityStreamsContext:
+			return 0
+		case ok:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b interface{}) int {
+		if c := rank(a) - rank(b); c != 0 {
+			return c
+		}
+		as, _ := a.(string)
+		bs, _ := b.(string)
+		return strings.Compare(as, bs)
+	})
+
+	data["@context"] = entries
+}
+
+// hasProperty returns whether property
+// with key is set anywhere in data.
+func hasProperty(data interface{}, key string) bool {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		if _, ok := d[key]; ok {
+			return true
+		}
+		for _, v := range d {
+			if hasProperty(v, key) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range d {
+			if hasProperty(v, key) {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // serializeWithOrderedItems is a custom serializer
@@ -271,6 +271,102 @@ func (suite *InboxPostTestSuite) TestPostBlock() {
 	}
 }
 
+// TestPostDuplicateBlock verifies that a replayed activity
+// (ie., same ID to same inbox) is accepted but not processed.
+func (suite *InboxPostTestSuite) TestPostDuplicateBlock() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		activityID        = requestingAccount.URI + "/some-new-activity/01J1XKR3CZDC4JNNVJPHAWPW7B"
+	)
+
+	block := suite.newBlock(activityID, requestingAccount, targetAccount)
+
+	// Block.
+	suite.inboxPost(
+		block,
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// Block should be created in the database.
+	dbBlock, err := suite.db.GetBlock(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remove block, so we
+	// can tell if the replay
+	// gets processed again.
+	if err := suite.db.DeleteBlockByID(ctx, dbBlock.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Replay the same block.
+	suite.inboxPost(
+		block,
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// Block should not have been recreated.
+	_, err = suite.db.GetBlock(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+// TestPostDuplicateIDOtherSigner verifies that an activity sent
+// by one actor doesn't cause another actor's activity with the
+// same ID to be dropped as a duplicate.
+func (suite *InboxPostTestSuite) TestPostDuplicateIDOtherSigner() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		otherAccount      = suite.testAccounts["remote_account_2"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		activityID        = requestingAccount.URI + "/some-new-activity/01J1XKR3CZDC4JNNVJPHAWPW7B"
+	)
+
+	// Other account gets in first
+	// with the predictable ID.
+	suite.inboxPost(
+		suite.newBlock(activityID, otherAccount, targetAccount),
+		otherAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// Remove any block that resulted, so the
+	// real block's URI doesn't clash with it.
+	if dbBlock, err := suite.db.GetBlock(ctx, otherAccount.ID, targetAccount.ID); err == nil {
+		if err := suite.db.DeleteBlockByID(ctx, dbBlock.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Real block from the real sender.
+	suite.inboxPost(
+		suite.newBlock(activityID, requestingAccount, targetAccount),
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// Block should have been processed.
+	_, err := suite.db.GetBlock(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+}
+
 // TestPostUnblock verifies that a remote account who blocks
 // one of our instance users should be able to undo that block.
 func (suite *InboxPostTestSuite) TestPostUnblock() {
@@ -18,11 +18,10 @@
 package api
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
@@ -33,7 +32,9 @@ import (
 	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
 	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/importdata"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
@@ -47,6 +48,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -60,6 +62,7 @@ type Client struct {
 
 	accounts       *accounts.Module       // api/v1/accounts
 	admin          *admin.Module          // api/v1/admin
+	announcements  *announcements.Module  // api/v1/announcements
 	apps           *apps.Module           // api/v1/apps
 	blocks         *blocks.Module         // api/v1/blocks
 	bookmarks      *bookmarks.Module      // api/v1/bookmarks
@@ -70,7 +73,9 @@ type Client struct {
 	filtersV1      *filtersV1.Module      // api/v1/filters
 	filtersV2      *filtersV2.Module      // api/v2/filters
 	followRequests *followrequests.Module // api/v1/follow_requests
+	importData     *importdata.Module     // api/v1/import
 	instance       *instance.Module       // api/v1/instance
+	invites        *invites.Module        // api/v1/invites
 	lists          *lists.Module          // api/v1/lists
 	markers        *markers.Module        // api/v1/markers
 	media          *media.Module          // api/v1/media, api/v2/media
@@ -105,6 +110,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	h := apiGroup.Handle
 	c.accounts.Route(h)
 	c.admin.Route(h)
+	c.announcements.Route(h)
 	c.apps.Route(h)
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
@@ -115,7 +121,9 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.filtersV1.Route(h)
 	c.filtersV2.Route(h)
 	c.followRequests.Route(h)
+	c.importData.Route(h)
 	c.instance.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
@@ -138,6 +146,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 
 		accounts:       accounts.New(p),
 		admin:          admin.New(state, p),
+		announcements:  announcements.New(p),
 		apps:           apps.New(p),
 		blocks:         blocks.New(p),
 		bookmarks:      bookmarks.New(p),
@@ -148,7 +157,9 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		filtersV1:      filtersV1.New(p),
 		filtersV2:      filtersV2.New(p),
 		followRequests: followrequests.New(p),
+		importData:     importdata.New(p),
 		instance:       instance.New(p),
+		invites:        invites.New(p),
 		lists:          lists.New(p),
 		markers:        markers.New(p),
 		media:          media.New(p),
@@ -159,7 +170,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		reports:        reports.New(p),
 		search:         search.New(p),
 		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
+		streaming:      streaming.New(p, config.GetAdvancedStreamingPingInterval(), config.GetAdvancedStreamingIdleTimeout(), 4096),
 		timelines:      timelines.New(p),
 		user:           user.New(p),
 	}
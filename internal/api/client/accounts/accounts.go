@@ -40,6 +40,8 @@ const (
 
 	BlockPath         = BasePathWithID + "/block"
 	DeletePath        = BasePath + "/delete"
+	ExportPath        = BasePath + "/export"
+	ExportArchivePath = ExportPath + "/archive"
 	FollowersPath     = BasePathWithID + "/followers"
 	FollowingPath     = BasePathWithID + "/following"
 	FollowPath        = BasePathWithID + "/follow"
@@ -57,6 +59,7 @@ const (
 	VerifyPath        = BasePath + "/verify_credentials"
 	MovePath          = BasePath + "/move"
 	AliasPath         = BasePath + "/alias"
+	MigrationPath     = BasePath + "/migration"
 	ThemesPath        = BasePath + "/themes"
 
 	// ProfileBasePath for the profile API, an extension of the account update API with a different path.
@@ -130,6 +133,13 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// migration handlers
 	attachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
 	attachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+	attachHandler(http.MethodGet, MigrationPath, m.AccountMigrationGETHandler)
+	attachHandler(http.MethodPost, MigrationPath, m.AccountMigrationPOSTHandler)
+
+	// account data export
+	attachHandler(http.MethodPost, ExportPath, m.AccountExportPOSTHandler)
+	attachHandler(http.MethodGet, ExportPath, m.AccountExportGETHandler)
+	attachHandler(http.MethodGet, ExportArchivePath, m.AccountExportArchiveGETHandler)
 
 	// account themes
 	attachHandler(http.MethodGet, ThemesPath, m.AccountThemesGETHandler)
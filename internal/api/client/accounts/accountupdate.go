@@ -108,6 +108,44 @@ import (
 //		description: Default content type to use for authored statuses (text/plain or text/markdown).
 //		type: string
 //	-
+//		name: source[boostable]
+//		in: formData
+//		description: >-
+//			Whether authored statuses can be boosted by default, when not set per-status.
+//			Only applies to unlisted statuses, as public statuses are always boostable.
+//		type: boolean
+//	-
+//		name: source[replyable]
+//		in: formData
+//		description: >-
+//			Whether authored statuses can be replied to by default, when not set per-status.
+//			Only applies to unlisted, followers-only and mutuals-only statuses.
+//		type: boolean
+//	-
+//		name: source[likeable]
+//		in: formData
+//		description: >-
+//			Whether authored statuses can be liked by default, when not set per-status.
+//			Only applies to unlisted, followers-only and mutuals-only statuses.
+//		type: boolean
+//	-
+//		name: source[status_expiry_days]
+//		in: formData
+//		description: >-
+//			Automatically delete authored public statuses once they are older than this many days.
+//			Boosts, and statuses of other visibilities, are not deleted. 0 disables automatic deletion.
+//		type: integer
+//	-
+//		name: source[status_expiry_keep_pinned]
+//		in: formData
+//		description: Keep pinned statuses when automatically deleting expired statuses.
+//		type: boolean
+//	-
+//		name: source[status_expiry_keep_faved]
+//		in: formData
+//		description: Keep statuses faved by this account when automatically deleting expired statuses.
+//		type: boolean
+//	-
 //		name: theme
 //		in: formData
 //		description: >-
@@ -321,6 +359,12 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Source.Sensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.Boostable == nil &&
+			form.Source.Replyable == nil &&
+			form.Source.Likeable == nil &&
+			form.Source.StatusExpiryDays == nil &&
+			form.Source.StatusExpiryKeepPinned == nil &&
+			form.Source.StatusExpiryKeepFaved == nil &&
 			form.FieldsAttributes == nil &&
 			form.Theme == nil &&
 			form.CustomCSS == nil &&
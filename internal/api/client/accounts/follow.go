@@ -36,7 +36,7 @@ import (
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
 // If you already follow (request) the given account, then the follow (request) will be updated instead using the
-// `reblogs` and `notify` parameters.
+// `reblogs`, `notify` and `languages` parameters.
 //
 //	---
 //	tags:
@@ -66,6 +66,16 @@ import (
 //		default: false
 //		description: Notify when this account posts.
 //		in: formData
+//	-
+//		name: languages[]
+//		type: array
+//		items:
+//			type: string
+//		description: >-
+//			Only show posts from this account in these languages (ISO 639-1 codes) in home and list timelines.
+//			If not provided when creating a follow, posts in all languages are shown. When updating an existing
+//			follow, provide a single empty value to go back to showing posts in all languages.
+//		in: formData
 //
 //	produces:
 //	- application/json
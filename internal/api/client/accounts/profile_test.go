@@ -18,6 +18,7 @@
 package accounts_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,9 +29,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -94,6 +97,9 @@ func (suite *AccountProfileTestSuite) TestDeleteAvatar() {
 		// An empty URL is legal *only* in the test environment, which may have no default avatars.
 		suite.True(account.Avatar == "" || strings.HasPrefix(account.Avatar, "http://localhost:8080/assets/default_avatars/"))
 	}
+
+	// The account update should be federated.
+	suite.checkUpdateFederated("local_account_1")
 }
 
 // Delete the avatar of a user that doesn't have an avatar. Should succeed.
@@ -123,6 +129,35 @@ func (suite *AccountProfileTestSuite) TestDeleteHeader() {
 	}
 }
 
+// Delete the header attachment of a user that has one.
+// Should clear it from the account, delete it, and federate.
+func (suite *AccountProfileTestSuite) TestDeleteHeaderAttachment() {
+	attachmentID := suite.testAccounts["local_account_1"].HeaderMediaAttachmentID
+
+	account, err := suite.deleteProfileAttachment(
+		"local_account_1",
+		"header",
+		suite.accountsModule.AccountHeaderDELETEHandler,
+		http.StatusOK,
+	)
+	if suite.NoError(err) {
+		suite.Equal("http://localhost:8080/assets/default_header.png", account.Header)
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAccount.HeaderMediaAttachmentID)
+
+	// The attachment itself should be gone.
+	_, err = suite.db.GetAttachmentByID(context.Background(), attachmentID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// The account update should be federated.
+	suite.checkUpdateFederated("local_account_1")
+}
+
 // Delete the header of a user that doesn't have a header. Should succeed.
 func (suite *AccountProfileTestSuite) TestDeleteNonexistentHeader() {
 	account, err := suite.deleteProfileAttachment(
@@ -134,6 +169,22 @@ func (suite *AccountProfileTestSuite) TestDeleteNonexistentHeader() {
 	if suite.NoError(err) {
 		suite.Equal("http://localhost:8080/assets/default_header.png", account.Header)
 	}
+
+	// Nothing changed, so there's nothing to federate.
+	suite.Zero(suite.state.Workers.Client.Queue.Len())
+}
+
+// checkUpdateFederated checks that an account Update
+// for the given test account was queued for federation.
+func (suite *AccountProfileTestSuite) checkUpdateFederated(testAccountFixtureName string) {
+	msg, ok := suite.state.Workers.Client.Queue.Pop()
+	if !suite.True(ok) {
+		return
+	}
+
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(suite.testAccounts[testAccountFixtureName].ID, msg.Origin.ID)
 }
 
 func TestAccountProfileTestSuite(t *testing.T) {
@@ -53,7 +53,9 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken, one of `disable`, `silence`, `unsilence`, or `suspend`.
+//			Only local accounts can be disabled.
 //		type: string
 //		required: true
 //	-
@@ -18,11 +18,14 @@
 package admin
 
 import (
+	"fmt"
 	"net/http"
 
 	"codeberg.org/gruf/go-debug"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
@@ -32,11 +35,16 @@ const (
 	EmojiPath               = BasePath + "/custom_emojis"
 	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
 	EmojiCategoriesPath     = EmojiPath + "/categories"
+	EmojiPackExportPath     = EmojiPath + "/export"
+	EmojiPackImportPath     = EmojiPath + "/import"
 	DomainBlocksPath        = BasePath + "/domain_blocks"
 	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
 	DomainAllowsPath        = BasePath + "/domain_allows"
 	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
 	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
+	CanonicalEmailsPath     = BasePath + "/canonical_email_blocks"
+	CanonicalEmailsPathID   = CanonicalEmailsPath + "/:" + apiutil.IDKey
+	CanonicalEmailsTestPath = CanonicalEmailsPath + "/test"
 	HeaderAllowsPath        = BasePath + "/header_allows"
 	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
 	HeaderBlocksPath        = BasePath + "/header_blocks"
@@ -52,10 +60,28 @@ const (
 	ReportsPath             = BasePath + "/reports"
 	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
 	ReportsResolvePath      = ReportsPathWithID + "/resolve"
+	ReportsAssignPath       = ReportsPathWithID + "/assign_to_self"
+	ReportsUnassignPath     = ReportsPathWithID + "/unassign"
 	EmailPath               = BasePath + "/email"
+	EmailBlocksPath         = BasePath + "/email_domain_blocks"
+	EmailBlocksPathWithID   = EmailBlocksPath + "/:" + apiutil.IDKey
 	EmailTestPath           = EmailPath + "/test"
+	IPBlocksPath            = BasePath + "/ip_blocks"
+	IPBlocksPathWithID      = IPBlocksPath + "/:" + apiutil.IDKey
+	MediaPoliciesPath       = BasePath + "/domain_media_policies"
+	MediaPoliciesPathWithID = MediaPoliciesPath + "/:" + apiutil.IDKey
+	AnnouncementsPath       = BasePath + "/announcements"
+	AnnouncementsPathWithID = AnnouncementsPath + "/:" + apiutil.IDKey
 	InstanceRulesPath       = BasePath + "/instance/rules"
 	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
+	HTTPClientPath          = BasePath + "/http_client"
+	HTTPClientRangesPath    = HTTPClientPath + "/ip_ranges"
+	HTTPClientDialCheckPath = HTTPClientPath + "/dial_check"
+	HTTPClientHostsPath     = HTTPClientPath + "/hosts"
+	DeliveryPath            = BasePath + "/delivery"
+	DeliveryPausedHostsPath = DeliveryPath + "/paused_hosts"
+	CachesPath              = BasePath + "/caches"
+	CachesPathWithName      = CachesPath + "/:" + CacheNameKey
 	DebugPath               = BasePath + "/debug"
 	DebugAPUrlPath          = DebugPath + "/apurl"
 	DebugClearCachesPath    = DebugPath + "/caches/clear"
@@ -64,6 +90,9 @@ const (
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	DomainQueryKey        = "domain"
+	TargetQueryKey        = "target"
+	CategoryQueryKey      = "category"
+	CacheNameKey          = "name"
 )
 
 type Module struct {
@@ -86,6 +115,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
 	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
 	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodGet, EmojiPackExportPath, m.EmojiPackExportGETHandler)
+	attachHandler(http.MethodPost, EmojiPackImportPath, m.EmojiPackImportPOSTHandler)
 
 	// domain block stuff
 	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
@@ -99,6 +130,25 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// canonical email block stuff
+	attachHandler(http.MethodPost, CanonicalEmailsPath, m.CanonicalEmailBlockPOSTHandler)
+	attachHandler(http.MethodGet, CanonicalEmailsPath, m.CanonicalEmailBlocksGETHandler)
+	attachHandler(http.MethodPost, CanonicalEmailsTestPath, m.CanonicalEmailBlocksTestPOSTHandler)
+	attachHandler(http.MethodGet, CanonicalEmailsPathID, m.CanonicalEmailBlockGETHandler)
+	attachHandler(http.MethodDelete, CanonicalEmailsPathID, m.CanonicalEmailBlockDELETEHandler)
+
+	// ip block stuff
+	attachHandler(http.MethodPost, IPBlocksPath, m.IPBlockPOSTHandler)
+	attachHandler(http.MethodGet, IPBlocksPath, m.IPBlocksGETHandler)
+	attachHandler(http.MethodGet, IPBlocksPathWithID, m.IPBlockGETHandler)
+	attachHandler(http.MethodDelete, IPBlocksPathWithID, m.IPBlockDELETEHandler)
+
+	// domain media policy stuff
+	attachHandler(http.MethodPost, MediaPoliciesPath, m.DomainMediaPolicyPOSTHandler)
+	attachHandler(http.MethodGet, MediaPoliciesPath, m.DomainMediaPoliciesGETHandler)
+	attachHandler(http.MethodGet, MediaPoliciesPathWithID, m.DomainMediaPolicyGETHandler)
+	attachHandler(http.MethodDelete, MediaPoliciesPathWithID, m.DomainMediaPolicyDELETEHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -128,10 +178,25 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
 	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
 	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodPost, ReportsAssignPath, m.ReportAssignPOSTHandler)
+	attachHandler(http.MethodPost, ReportsUnassignPath, m.ReportUnassignPOSTHandler)
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
 
+	// email domain block stuff
+	attachHandler(http.MethodGet, EmailBlocksPath, m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodGet, EmailBlocksPathWithID, m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodPost, EmailBlocksPath, m.EmailDomainBlockPOSTHandler)
+	attachHandler(http.MethodDelete, EmailBlocksPathWithID, m.EmailDomainBlockDELETEHandler)
+
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, m.AnnouncementGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodPatch, AnnouncementsPathWithID, m.AnnouncementPATCHHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, m.AnnouncementDELETEHandler)
+
 	// instance rules stuff
 	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
 	attachHandler(http.MethodGet, InstanceRulesPathWithID, m.RuleGETHandler)
@@ -139,9 +204,45 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// http client stuff
+	attachHandler(http.MethodGet, HTTPClientRangesPath, m.HTTPClientRangesGETHandler)
+	attachHandler(http.MethodPut, HTTPClientRangesPath, m.HTTPClientRangesPUTHandler)
+	attachHandler(http.MethodGet, HTTPClientDialCheckPath, m.HTTPClientDialCheckGETHandler)
+	attachHandler(http.MethodGet, HTTPClientHostsPath, m.HTTPClientHostsGETHandler)
+
+	// delivery stuff
+	attachHandler(http.MethodGet, DeliveryPausedHostsPath, m.DeliveryPausedHostsGETHandler)
+
+	// cache stuff
+	attachHandler(http.MethodGet, CachesPath, m.CachesGETHandler)
+	attachHandler(http.MethodPatch, CachesPathWithName, m.CachePATCHHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
 		attachHandler(http.MethodPost, DebugClearCachesPath, m.DebugClearCachesHandler)
 	}
 }
+
+// adminAuthed performs the common auth checks of admin
+// handlers, returning false if a response was written.
+func (m *Module) adminAuthed(c *gin.Context) bool {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return false
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return false
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return false
+	}
+
+	return true
+}
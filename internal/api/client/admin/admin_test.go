@@ -64,6 +64,7 @@ type AdminStandardTestSuite struct {
 	testEmojis          map[string]*gtsmodel.Emoji
 	testEmojiCategories map[string]*gtsmodel.EmojiCategory
 	testReports         map[string]*gtsmodel.Report
+	testAnnouncements   map[string]*gtsmodel.Announcement
 
 	// module being tested
 	adminModule *admin.Module
@@ -80,6 +81,7 @@ func (suite *AdminStandardTestSuite) SetupSuite() {
 	suite.testEmojis = testrig.NewTestEmojis()
 	suite.testEmojiCategories = testrig.NewTestEmojiCategories()
 	suite.testReports = testrig.NewTestReports()
+	suite.testAnnouncements = testrig.NewTestAnnouncements()
 }
 
 func (suite *AdminStandardTestSuite) SetupTest() {
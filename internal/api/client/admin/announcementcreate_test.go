@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AnnouncementCreateTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementCreateTestSuite) TestAnnouncementCreate() {
+	recorder := httptest.NewRecorder()
+
+	body := []byte(`{"text":"Welcome to our *shiny* new instance :rainbow:"}`)
+	ctx := suite.newContext(recorder, http.MethodPost, body, admin.AnnouncementsPath, "application/json")
+
+	suite.adminModule.AnnouncementPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal(b, announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(announcement.ID)
+	suite.Equal("<p>Welcome to our <em>shiny</em> new instance :rainbow:</p>", announcement.Content)
+	suite.True(announcement.Published)
+	suite.False(announcement.AllDay)
+	suite.Empty(announcement.StartsAt)
+	suite.Empty(announcement.EndsAt)
+	suite.Len(announcement.Emojis, 1)
+	suite.Equal("rainbow", announcement.Emojis[0].Shortcode)
+
+	// announcement should now be in the db
+	dbAnnouncement, err := suite.db.GetAnnouncementByID(context.Background(), announcement.ID)
+	suite.NoError(err)
+	suite.Equal("Welcome to our *shiny* new instance :rainbow:", dbAnnouncement.Text)
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbAnnouncement.CreatedByAccountID)
+}
+
+func (suite *AnnouncementCreateTestSuite) TestAnnouncementCreateScheduled() {
+	recorder := httptest.NewRecorder()
+
+	body := []byte(`{"text":"Maintenance tomorrow","starts_at":"2099-01-01T00:00:00Z","ends_at":"2099-01-02T00:00:00Z","all_day":true}`)
+	ctx := suite.newContext(recorder, http.MethodPost, body, admin.AnnouncementsPath, "application/json")
+
+	suite.adminModule.AnnouncementPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal(b, announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(announcement.AllDay)
+	suite.Equal("2099-01-01T00:00:00.000Z", announcement.StartsAt)
+	suite.Equal("2099-01-02T00:00:00.000Z", announcement.EndsAt)
+}
+
+func (suite *AnnouncementCreateTestSuite) TestAnnouncementCreateNoText() {
+	recorder := httptest.NewRecorder()
+
+	body := []byte(`{"text":""}`)
+	ctx := suite.newContext(recorder, http.MethodPost, body, admin.AnnouncementsPath, "application/json")
+
+	suite.adminModule.AnnouncementPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: announcement text is empty"}`, string(b))
+}
+
+func (suite *AnnouncementCreateTestSuite) TestAnnouncementCreateNotAdmin() {
+	recorder := httptest.NewRecorder()
+
+	body := []byte(`{"text":"Hello"}`)
+	ctx := suite.newContext(recorder, http.MethodPost, body, admin.AnnouncementsPath, "application/json")
+
+	// Requester is not an admin.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	suite.adminModule.AnnouncementPOSTHandler(ctx)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func TestAnnouncementCreateTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementCreateTestSuite{})
+}
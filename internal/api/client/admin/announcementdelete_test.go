@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type AnnouncementDeleteTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementDeleteTestSuite) TestAnnouncementDelete() {
+	recorder := httptest.NewRecorder()
+	testAnnouncement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.AnnouncementsPathWithID, "application/json")
+	ctx.AddParam(apiutil.IDKey, testAnnouncement.ID)
+
+	suite.adminModule.AnnouncementDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	// the deleted announcement is returned
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal(b, announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testAnnouncement.ID, announcement.ID)
+	suite.Equal(testAnnouncement.Content, announcement.Content)
+
+	// announcement should no longer be in the db
+	dbAnnouncement, err := suite.db.GetAnnouncementByID(context.Background(), testAnnouncement.ID)
+	suite.Nil(dbAnnouncement)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AnnouncementDeleteTestSuite) TestAnnouncementDeleteNotFound() {
+	recorder := httptest.NewRecorder()
+
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.AnnouncementsPathWithID, "application/json")
+	ctx.AddParam(apiutil.IDKey, "01GF8VRXX1R00X7XH8973Z29R1")
+
+	suite.adminModule.AnnouncementDELETEHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func TestAnnouncementDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementDeleteTestSuite{})
+}
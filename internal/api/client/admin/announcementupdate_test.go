@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+type AnnouncementUpdateTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementUpdateTestSuite) TestAnnouncementUpdate() {
+	recorder := httptest.NewRecorder()
+	testAnnouncement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	body := []byte(`{"text":"Maintenance is postponed until next weekend","ends_at":"2099-01-01T00:00:00Z"}`)
+	ctx := suite.newContext(recorder, http.MethodPatch, body, admin.AnnouncementsPathWithID, "application/json")
+	ctx.AddParam(apiutil.IDKey, testAnnouncement.ID)
+
+	suite.adminModule.AnnouncementPATCHHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal(b, announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(testAnnouncement.ID, announcement.ID)
+	suite.Equal("<p>Maintenance is postponed until next weekend</p>", announcement.Content)
+	suite.Equal("2099-01-01T00:00:00.000Z", announcement.EndsAt)
+	suite.Empty(announcement.Emojis)
+
+	// announcement should be updated in the db
+	dbAnnouncement, err := suite.db.GetAnnouncementByID(context.Background(), testAnnouncement.ID)
+	suite.NoError(err)
+	suite.Equal("Maintenance is postponed until next weekend", dbAnnouncement.Text)
+	suite.Empty(dbAnnouncement.EmojiIDs)
+}
+
+func (suite *AnnouncementUpdateTestSuite) TestAnnouncementUpdateNoText() {
+	recorder := httptest.NewRecorder()
+	testAnnouncement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	body := []byte(`{"text":""}`)
+	ctx := suite.newContext(recorder, http.MethodPatch, body, admin.AnnouncementsPathWithID, "application/json")
+	ctx.AddParam(apiutil.IDKey, testAnnouncement.ID)
+
+	suite.adminModule.AnnouncementPATCHHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: announcement text is empty"}`, string(b))
+
+	// announcement should be unchanged in the db
+	dbAnnouncement, err := suite.db.GetAnnouncementByID(context.Background(), testAnnouncement.ID)
+	suite.NoError(err)
+	suite.Equal(testAnnouncement.Text, dbAnnouncement.Text)
+}
+
+func (suite *AnnouncementUpdateTestSuite) TestAnnouncementUpdateNotFound() {
+	recorder := httptest.NewRecorder()
+
+	body := []byte(`{"text":"Hello"}`)
+	ctx := suite.newContext(recorder, http.MethodPatch, body, admin.AnnouncementsPathWithID, "application/json")
+	ctx.AddParam(apiutil.IDKey, "01GF8VRXX1R00X7XH8973Z29R1")
+
+	suite.adminModule.AnnouncementPATCHHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func TestAnnouncementUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementUpdateTestSuite{})
+}
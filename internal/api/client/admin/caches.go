@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// CachesGETHandler swagger:operation GET /api/v1/admin/caches cachesGet
+//
+// View usage statistics and capacities of the instance's database caches.
+//
+// Hit ratios and eviction counts can be used to tune cache sizes,
+// either at runtime using the PATCH endpoint for a cache, or in
+// configuration using the matching cache-*-mem-ratio option.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Database caches, sorted by name.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminCache"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) CachesGETHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	caches, errWithCode := m.processor.Admin().CachesGet(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, caches)
+}
+
+// CachePATCHHandler swagger:operation PATCH /api/v1/admin/caches/{name} cacheUpdate
+//
+// Resize one of the instance's database caches.
+//
+// The cache is emptied when resized, and refills from the database on demand.
+// Changes take effect immediately, but are not persisted: on restart, cache
+// sizes are calculated from the configured cache memory target and ratios.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: name
+//		in: path
+//		description: Name of the cache.
+//		type: string
+//		required: true
+//	-
+//		name: capacity
+//		in: formData
+//		description: >-
+//			New maximum number of cached items. Must be between the
+//			min_capacity and max_capacity of the cache (inclusive).
+//		type: integer
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The resized cache.
+//			schema:
+//				"$ref": "#/definitions/adminCache"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) CachePATCHHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	form := &apimodel.AdminCacheUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	cache, errWithCode := m.processor.Admin().CacheUpdate(
+		c.Request.Context(),
+		c.Param(CacheNameKey),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, cache)
+}
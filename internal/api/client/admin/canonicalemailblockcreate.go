@@ -0,0 +1,205 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// CanonicalEmailBlockPOSTHandler swagger:operation POST /api/v1/admin/canonical_email_blocks canonicalEmailBlockCreate
+//
+// Block new sign-ups using the given email address or canonical email hash, or import a list of canonical email blocks.
+//
+// Email addresses are canonicalized before hashing by lowercasing them, and removing
+// any dots and "+tag" suffix from the local part, so that common aliases of one
+// mailbox are all blocked together. Only the hash is stored.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: import
+//		in: query
+//		description: >-
+//			Signal that a list of canonical email blocks is being imported as a file.
+//			If set to `true`, then 'hashes' must be present as a JSON-formatted file.
+//			If set to `false`, then either 'email' or 'canonical_email_hash' must be present.
+//		type: boolean
+//		default: false
+//	-
+//		name: hashes
+//		in: formData
+//		description: >-
+//			JSON-formatted list of canonical email blocks to import, as returned by an export.
+//			Only used if `import=true` is specified.
+//		type: file
+//	-
+//		name: email
+//		in: formData
+//		description: >-
+//			Email address to canonicalize, hash, and block.
+//			Only used if `import` is not `true`.
+//		type: string
+//	-
+//		name: canonical_email_hash
+//		in: formData
+//		description: >-
+//			Hex-encoded SHA256 hash of an already-canonicalized email address to block.
+//			Only used if `import` is not `true`, and `email` is not set.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: >-
+//				The newly created canonical email block, if `import` != `true`.
+//				If a list has been imported, then an `array` of newly created canonical email blocks will be returned instead.
+//			schema:
+//				"$ref": "#/definitions/adminCanonicalEmailBlock"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (block already exists for this hash)
+//		'422':
+//			description: one or more errors importing canonical email blocks
+//		'500':
+//			description: internal server error
+func (m *Module) CanonicalEmailBlockPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	importing, errWithCode := apiutil.ParseCanonicalEmailBlockImport(c.Query(apiutil.CanonicalEmailBlockImportKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminCanonicalEmailBlockCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if importing && (form.Hashes == nil || form.Hashes.Size == 0) {
+		err = errors.New("import was specified but list of hashes is empty")
+	} else if !importing && form.Email == "" && form.CanonicalEmailHash == "" {
+		err = errors.New("one of email or canonical_email_hash must be provided")
+	}
+
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !importing {
+		// Single canonical email block creation.
+		block, errWithCode := m.processor.Admin().CanonicalEmailBlockCreate(
+			c.Request.Context(),
+			authed.Account,
+			form.Email,
+			form.CanonicalEmailHash,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusOK, block)
+		return
+	}
+
+	// We're importing multiple canonical email
+	// blocks, so we're looking at a multi-status.
+	multiStatus, errWithCode := m.processor.Admin().CanonicalEmailBlocksImport(
+		c.Request.Context(),
+		authed.Account,
+		form.Hashes, // Pass the file through.
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if multiStatus.Metadata.Failure != 0 {
+		failures := make(map[string]any, multiStatus.Metadata.Failure)
+		for _, entry := range multiStatus.Data {
+			if entry.Status != http.StatusOK {
+				failures[entry.Resource.(string)] = entry.Message
+			}
+		}
+
+		err := fmt.Errorf("one or more errors importing canonical email blocks: %+v", failures)
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Success, return slice of newly-created blocks.
+	blocks := make([]any, 0, multiStatus.Metadata.Success)
+	for _, entry := range multiStatus.Data {
+		blocks = append(blocks, entry.Resource)
+	}
+
+	apiutil.JSON(c, http.StatusOK, blocks)
+}
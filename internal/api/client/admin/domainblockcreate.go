@@ -90,6 +90,16 @@ import (
 //			is a useful way of internally keeping track of why a certain domain ended up blocked.
 //			Used only if `import` is not `true`.
 //		type: string
+//	-
+//		name: severity
+//		in: formData
+//		description: >-
+//			Severity of the block; one of `suspend` (default) or `silence`.
+//			Suspend cuts off federation with the domain entirely. Silence keeps the domain's
+//			posts out of public timelines, and means follows from the domain must be approved.
+//			Used only if `import` is not `true`.
+//		type: string
+//		default: suspend
 //
 //	security:
 //	- OAuth2 Bearer:
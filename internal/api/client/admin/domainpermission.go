@@ -40,6 +40,7 @@ type singleDomainPermCreate func(
 	bool, // obfuscate
 	string, // publicComment
 	string, // privateComment
+	string, // severity
 	string, // subscriptionID
 ) (*apimodel.DomainPermission, string, gtserror.WithCode)
 
@@ -119,6 +120,7 @@ func (m *Module) createDomainPermissions(
 			form.Obfuscate,
 			form.PublicComment,
 			form.PrivateComment,
+			form.Severity,
 			"", // No sub ID for single perm creation.
 		)
 
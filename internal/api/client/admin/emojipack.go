@@ -0,0 +1,225 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// EmojiPackExportGETHandler swagger:operation GET /api/v1/admin/custom_emojis/export emojiPackExport
+//
+// Download this instance's local custom emojis as an emoji pack.
+//
+// The pack is a zip archive containing the image of each emoji under `emojis/`,
+// along with a `manifest.json` file giving the shortcode and category of each emoji.
+// Packs exported this way can be imported into another instance.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/zip
+//
+//	parameters:
+//	-
+//		name: category
+//		in: query
+//		description: Only export emojis in this category.
+//		type: string
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Zip archive of emojis.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiPackExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.AppZip); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	category := c.Query(CategoryQueryKey)
+	if err := validate.EmojiCategory(category); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	pack, size, errWithCode := m.processor.Admin().EmojiPackExport(c.Request.Context(), authed.Account, category)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	defer pack.Close()
+
+	c.DataFromReader(http.StatusOK, size, apiutil.AppZip, pack, map[string]string{
+		"Content-Disposition": `attachment; filename="emojis.zip"`,
+	})
+}
+
+// EmojiPackImportPOSTHandler swagger:operation POST /api/v1/admin/custom_emojis/import emojiPackImport
+//
+// Import an emoji pack as local custom emojis of this instance.
+//
+// The pack should be a zip archive as produced by the emoji pack export endpoint.
+// Packs without a `manifest.json` are also accepted, in which case each png or
+// gif image in the archive is imported using its file name as shortcode.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: pack
+//		in: formData
+//		description: Zip archive of the emoji pack.
+//		type: file
+//		required: true
+//	-
+//		name: category
+//		in: formData
+//		description: >-
+//			Category in which to place all imported emojis, overriding any categories
+//			given in the pack. If a category with the given name doesn't exist yet,
+//			it will be created.
+//		type: string
+//		maximumLength: 64
+//		required: false
+//	-
+//		name: on_conflict
+//		in: formData
+//		description: >-
+//			How to handle emojis whose shortcode is already in use on this instance.
+//			`skip` leaves the existing emoji alone, `replace` replaces its image and category,
+//			and `rename` imports the emoji under a new shortcode with a numeric suffix.
+//		type: string
+//		enum:
+//			- skip
+//			- replace
+//			- rename
+//		default: skip
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The outcome of the import.
+//			schema:
+//				"$ref": "#/definitions/emojiPackImportResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiPackImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.EmojiPackImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Pack == nil || form.Pack.Size == 0 {
+		err := errors.New("no emoji pack given")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validate.EmojiCategory(form.CategoryName); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	result, errWithCode := m.processor.Admin().EmojiPackImport(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, result)
+}
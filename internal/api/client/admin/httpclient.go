@@ -0,0 +1,269 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// HTTPClientRangesGETHandler swagger:operation GET /api/v1/admin/http_client/ip_ranges httpClientRangesGet
+//
+// View IP ranges currently explicitly allowed / blocked for outgoing HTTP requests.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Currently allowed / blocked IP ranges.
+//			schema:
+//				"$ref": "#/definitions/httpClientRanges"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) HTTPClientRangesGETHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	ranges, errWithCode := m.processor.Admin().HTTPClientRangesGet(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, ranges)
+}
+
+// HTTPClientRangesPUTHandler swagger:operation PUT /api/v1/admin/http_client/ip_ranges httpClientRangesUpdate
+//
+// Replace IP ranges explicitly allowed / blocked for outgoing HTTP requests.
+//
+// Changes take effect immediately, but are not persisted: on restart, the
+// values of `http-client.allow-ips` and `http-client.block-ips` are used.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: allow_ips[]
+//		in: formData
+//		description: IP ranges (CIDR) to explicitly allow, overriding blocked and reserved ranges.
+//		type: array
+//		items:
+//			type: string
+//		collectionFormat: multi
+//	-
+//		name: block_ips[]
+//		in: formData
+//		description: IP ranges (CIDR) to explicitly block.
+//		type: array
+//		items:
+//			type: string
+//		collectionFormat: multi
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Updated allowed / blocked IP ranges.
+//			schema:
+//				"$ref": "#/definitions/httpClientRanges"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) HTTPClientRangesPUTHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	form := &apimodel.HTTPClientRangesRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	ranges, errWithCode := m.processor.Admin().HTTPClientRangesUpdate(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, ranges)
+}
+
+// HTTPClientDialCheckGETHandler swagger:operation GET /api/v1/admin/http_client/dial_check httpClientDialCheck
+//
+// Check whether a URL, hostname or IP address would be dialable for outgoing
+// HTTP requests under the currently allowed / blocked IP ranges.
+//
+// This is useful for debugging server-side request forgery protection
+// rejecting requests to hosts with unusual hosting setups.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: target
+//		in: query
+//		description: |-
+//			URL, hostname or IP address to check.
+//			Sample: https://example.org/users/someone
+//		type: string
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Result of checking the target.
+//			schema:
+//				"$ref": "#/definitions/httpClientDialCheck"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: target host could not be resolved
+//		'500':
+//			description: internal server error
+func (m *Module) HTTPClientDialCheckGETHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	target := c.Query(TargetQueryKey)
+	if target == "" {
+		const text = "no target given"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	check, errWithCode := m.processor.Admin().HTTPClientDialCheck(c.Request.Context(), target)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, check)
+}
+
+// HTTPClientHostsGETHandler swagger:operation GET /api/v1/admin/http_client/hosts httpClientHosts
+//
+// View statistics of outgoing HTTP requests made to each remote host since startup,
+// busiest hosts first. This is useful for diagnosing federation problems with a host.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Request statistics per host.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/httpClientHostStats"
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) HTTPClientHostsGETHandler(c *gin.Context) {
+	if !m.adminAuthed(c) {
+		return
+	}
+
+	stats, errWithCode := m.processor.Admin().HTTPClientHostsGet(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, stats)
+}
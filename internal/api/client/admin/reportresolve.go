@@ -62,6 +62,13 @@ import (
 //
 //			Sample: The reported account was suspended.
 //		type: string
+//	-
+//		name: resolution_category
+//		in: formData
+//		description: >-
+//			Optional category of the outcome of this report.
+//			One of: no_action, actioned, duplicate, other.
+//		type: string
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -118,7 +125,7 @@ func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form.ActionTakenComment)
+	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form.ActionTakenComment, form.ResolutionCategory)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
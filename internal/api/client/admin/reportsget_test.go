@@ -338,7 +338,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
     },
     "statuses": [],
     "rules": [],
-    "action_taken_comment": "user was warned not to be a turtle anymore"
+    "action_taken_comment": "user was warned not to be a turtle anymore",
+    "resolution_category": "actioned"
   },
   {
     "id": "01GP3AWY4CRDVRNZKW0TEAMB5R",
@@ -541,7 +542,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "text": "Do crime"
       }
     ],
-    "action_taken_comment": null
+    "action_taken_comment": null,
+    "resolution_category": null
   }
 ]`, string(b))
 
@@ -763,7 +765,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "text": "Do crime"
       }
     ],
-    "action_taken_comment": null
+    "action_taken_comment": null,
+    "resolution_category": null
   }
 ]`, string(b))
 
@@ -985,7 +988,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "text": "Do crime"
       }
     ],
-    "action_taken_comment": null
+    "action_taken_comment": null,
+    "resolution_category": null
   }
 ]`, string(b))
 
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AnnouncementDismissTestSuite struct {
+	AnnouncementsStandardTestSuite
+}
+
+func (suite *AnnouncementDismissTestSuite) dismiss(requestingAccount string, announcementID string) int {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+announcements.BasePath+"/"+announcementID+"/dismiss", nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{{Key: apiutil.IDKey, Value: announcementID}}
+
+	// trigger the handler
+	suite.announcementsModule.AnnouncementDismissPOSTHandler(ctx)
+
+	return recorder.Code
+}
+
+func (suite *AnnouncementDismissTestSuite) TestDismiss() {
+	announcement := suite.testAnnouncements["admin_account_announcement_1"]
+	account := suite.testAccounts["local_account_1"]
+
+	suite.Equal(http.StatusOK, suite.dismiss("local_account_1", announcement.ID))
+
+	read, err := suite.db.IsAnnouncementRead(context.Background(), announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.True(read)
+
+	// Dismissing again should be fine.
+	suite.Equal(http.StatusOK, suite.dismiss("local_account_1", announcement.ID))
+}
+
+func (suite *AnnouncementDismissTestSuite) TestDismissNotFound() {
+	suite.Equal(http.StatusNotFound, suite.dismiss("local_account_1", "01J23R2Y8N6PT4S6Y2ZJ4F9H0Q"))
+}
+
+func TestAnnouncementDismissTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementDismissTestSuite{})
+}
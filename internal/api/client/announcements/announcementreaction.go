@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AnnouncementReactionPUTHandler swagger:operation PUT /api/v1/announcements/{id}/reactions/{name} announcementReactionAdd
+//
+// React to the announcement with the given id, with the given emoji.
+//
+//	---
+//	tags:
+//	- announcements
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the announcement.
+//		in: path
+//		required: true
+//	-
+//		name: name
+//		type: string
+//		description: Unicode emoji, or the shortcode of a local custom emoji.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:favourites
+//
+//	responses:
+//		'200':
+//			description: Reaction added, empty object returned.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: announcement already has the maximum number of different reactions
+//		'500':
+//			description: internal server error
+func (m *Module) AnnouncementReactionPUTHandler(c *gin.Context) {
+	m.announcementReaction(c, m.processor.Announcements().ReactionAdd)
+}
+
+// AnnouncementReactionDELETEHandler swagger:operation DELETE /api/v1/announcements/{id}/reactions/{name} announcementReactionRemove
+//
+// Remove the requesting account's reaction with the given emoji from the announcement with the given id.
+//
+//	---
+//	tags:
+//	- announcements
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the announcement.
+//		in: path
+//		required: true
+//	-
+//		name: name
+//		type: string
+//		description: Unicode emoji, or the shortcode of a local custom emoji.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:favourites
+//
+//	responses:
+//		'200':
+//			description: Reaction removed, empty object returned.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AnnouncementReactionDELETEHandler(c *gin.Context) {
+	m.announcementReaction(c, m.processor.Announcements().ReactionRemove)
+}
+
+// announcementReaction handles adding or removing
+// an announcement reaction with the given function.
+func (m *Module) announcementReaction(
+	c *gin.Context,
+	react func(context.Context, *gtsmodel.Account, string, string) gtserror.WithCode,
+) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	announcementID, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	name := c.Param(apiutil.AnnouncementReactionNameKey)
+	if name == "" {
+		const text = "no reaction name specified"
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(gtserror.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := react(c.Request.Context(), authed.Account, announcementID, name); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.EmptyJSONObject)
+}
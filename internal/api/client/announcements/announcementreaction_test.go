@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AnnouncementReactionTestSuite struct {
+	AnnouncementsStandardTestSuite
+}
+
+func (suite *AnnouncementReactionTestSuite) react(method string, requestingAccount string, announcementID string, name string) (int, string) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api/"+announcements.BasePath+"/"+announcementID+"/reactions/"+name, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		{Key: apiutil.IDKey, Value: announcementID},
+		{Key: apiutil.AnnouncementReactionNameKey, Value: name},
+	}
+
+	// trigger the handler
+	if method == http.MethodPut {
+		suite.announcementsModule.AnnouncementReactionPUTHandler(ctx)
+	} else {
+		suite.announcementsModule.AnnouncementReactionDELETEHandler(ctx)
+	}
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+// openStream opens a user stream for the given account,
+// returning a func to receive the next message from it.
+func (suite *AnnouncementReactionTestSuite) openStream(account string) func() (stream.Message, bool) {
+	str, errWithCode := suite.processor.Stream().Open(context.Background(), suite.testAccounts[account], stream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.T().Cleanup(str.Close)
+
+	return func() (stream.Message, bool) {
+		ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cncl()
+		return str.Recv(ctx)
+	}
+}
+
+func (suite *AnnouncementReactionTestSuite) TestAddReaction() {
+	recv := suite.openStream("local_account_2")
+	announcement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	code, body := suite.react(http.MethodPut, "local_account_1", announcement.ID, "👍")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("{}", body)
+
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"👍","count":1,"me":false,"announcement_id":"01J23QDKXNW5Z9SB6A9BJ9T4YV"}`, msg.Payload)
+
+	// Adding the same reaction again is a no-op.
+	code, _ = suite.react(http.MethodPut, "local_account_1", announcement.ID, "👍")
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AnnouncementReactionTestSuite) TestAddReactionCustomEmoji() {
+	recv := suite.openStream("local_account_2")
+	announcement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	code, _ := suite.react(http.MethodPut, "local_account_2", announcement.ID, "rainbow")
+	suite.Equal(http.StatusOK, code)
+
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"rainbow","count":3,"me":false,"url":"http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png","static_url":"http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/static/01F8MH9H8E4VG3KDYJR9EGPXCQ.png","announcement_id":"01J23QDKXNW5Z9SB6A9BJ9T4YV"}`, msg.Payload)
+}
+
+func (suite *AnnouncementReactionTestSuite) TestAddReactionInvalid() {
+	announcement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	// No such custom emoji.
+	code, body := suite.react(http.MethodPut, "local_account_1", announcement.ID, "nonexistent_emoji")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: no custom emoji with that shortcode"}`, body)
+
+	// Not an emoji at all.
+	code, body = suite.react(http.MethodPut, "local_account_1", announcement.ID, "lol!")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: reaction must be a unicode emoji or custom emoji shortcode"}`, body)
+}
+
+func (suite *AnnouncementReactionTestSuite) TestAddReactionEndedAnnouncement() {
+	announcement := suite.testAnnouncements["admin_account_announcement_ended"]
+
+	code, _ := suite.react(http.MethodPut, "local_account_1", announcement.ID, "👍")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *AnnouncementReactionTestSuite) TestRemoveReaction() {
+	recv := suite.openStream("local_account_2")
+	announcement := suite.testAnnouncements["admin_account_announcement_1"]
+
+	code, body := suite.react(http.MethodDelete, "local_account_1", announcement.ID, "🐢")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("{}", body)
+
+	// Last reaction with this name removed, so count is 0.
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"🐢","count":0,"me":false,"announcement_id":"01J23QDKXNW5Z9SB6A9BJ9T4YV"}`, msg.Payload)
+
+	reactions, err := suite.db.GetAnnouncementReactions(context.Background(), announcement.ID)
+	suite.NoError(err)
+	suite.Len(reactions, 2)
+}
+
+func TestAnnouncementReactionTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementReactionTestSuite{})
+}
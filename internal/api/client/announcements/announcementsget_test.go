@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/announcements"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AnnouncementsGetTestSuite struct {
+	AnnouncementsStandardTestSuite
+}
+
+func (suite *AnnouncementsGetTestSuite) getAnnouncements(requestingAccount string, query string) ([]*apimodel.Announcement, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api/"+announcements.BasePath+query, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// trigger the handler
+	suite.announcementsModule.AnnouncementsGETHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []*apimodel.Announcement{}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *AnnouncementsGetTestSuite) TestGetAnnouncements() {
+	// Only the active announcement should be returned.
+	announcements, err := suite.getAnnouncements("local_account_1", "")
+	suite.NoError(err)
+	suite.Len(announcements, 1)
+
+	b, err := json.MarshalIndent(announcements, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`[
+  {
+    "id": "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+    "content": "\u003cp\u003eScheduled maintenance this weekend, expect some downtime :rainbow:\u003c/p\u003e",
+    "starts_at": "",
+    "ends_at": "",
+    "all_day": false,
+    "published_at": "2024-07-05T08:31:12.000Z",
+    "updated_at": "2024-07-05T08:31:12.000Z",
+    "published": true,
+    "read": false,
+    "mentions": [],
+    "statuses": [],
+    "tags": [],
+    "emoji": [
+      {
+        "shortcode": "rainbow",
+        "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+        "static_url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/static/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+        "visible_in_picker": true,
+        "category": "reactions"
+      }
+    ],
+    "reactions": [
+      {
+        "name": "rainbow",
+        "count": 2,
+        "me": true,
+        "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+        "static_url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/static/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+      },
+      {
+        "name": "🐢",
+        "count": 1,
+        "me": true
+      }
+    ]
+  }
+]`, string(b))
+}
+
+func (suite *AnnouncementsGetTestSuite) TestGetAnnouncementsDismissed() {
+	// Admin has already read the active announcement.
+	announcements, err := suite.getAnnouncements("admin_account", "")
+	suite.NoError(err)
+	suite.Empty(announcements)
+
+	announcements, err = suite.getAnnouncements("admin_account", "?with_dismissed=true")
+	suite.NoError(err)
+	suite.Len(announcements, 1)
+	suite.True(announcements[0].Read)
+}
+
+func TestAnnouncementsGetTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementsGetTestSuite{})
+}
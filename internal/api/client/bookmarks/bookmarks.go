@@ -27,6 +27,10 @@ import (
 const (
 	// BasePath is the base path for serving the bookmarks API, minus the 'api' prefix
 	BasePath = "/v1/bookmarks"
+	// ExportPath is the path for exporting bookmarks as csv.
+	ExportPath = BasePath + "/export"
+	// ImportPath is the path for importing bookmarks from csv.
+	ImportPath = BasePath + "/import"
 )
 
 type Module struct {
@@ -41,4 +45,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.BookmarksGETHandler)
+	attachHandler(http.MethodGet, ExportPath, m.BookmarksExportGETHandler)
+	attachHandler(http.MethodPost, ImportPath, m.BookmarksImportPOSTHandler)
 }
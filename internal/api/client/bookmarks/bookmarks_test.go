@@ -18,12 +18,16 @@
 package bookmarks_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -335,6 +339,72 @@ func (suite *BookmarkTestSuite) TestGetBookmarksNonexistentStatus() {
 	suite.Equal(`<http://localhost:8080/api/v1/bookmarks?limit=10&max_id=01F8MHD2QCZSZ6WQS2ATVPEYJ9>; rel="next", <http://localhost:8080/api/v1/bookmarks?limit=10&min_id=01GSZPGHY3ACEN11D512V6MR0M>; rel="prev"`, linkHeader)
 }
 
+func (suite *BookmarkTestSuite) TestExportBookmarks() {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api/" + bookmarks.ExportPath
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestURI, nil)
+	ctx.Request.Header.Set("accept", "text/csv")
+
+	suite.bookmarkModule.BookmarksExportGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R\n", string(b))
+}
+
+func (suite *BookmarkTestSuite) TestImportBookmarks() {
+	testAccount := suite.testAccounts["local_account_2"]
+
+	// Write bookmarks csv to import.
+	path := filepath.Join(suite.T().TempDir(), "bookmarks.csv")
+	data := "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY\nnot a uri\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	requestBody, w, err := testrig.CreateMultipartFormData("data", path, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_2"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api/" + bookmarks.ImportPath
+	ctx.Request = httptest.NewRequest(http.MethodPost, requestURI, bytes.NewReader(requestBody.Bytes()))
+	ctx.Request.Header.Set("content-type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.bookmarkModule.BookmarksImportPOSTHandler(ctx)
+	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	// Run the queued import job.
+	job, ok := suite.state.Workers.Dereference.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected import job to be queued")
+	}
+	job(context.Background())
+
+	// The status should now be bookmarked.
+	bookmarked, err := suite.db.IsStatusBookmarkedBy(context.Background(), testAccount.ID, "01F8MHAMCHF6Y650WCRSCP4WMY")
+	suite.NoError(err)
+	suite.True(bookmarked)
+}
+
 func TestBookmarkTestSuite(t *testing.T) {
 	suite.Run(t, new(BookmarkTestSuite))
 }
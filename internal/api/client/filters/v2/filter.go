@@ -44,6 +44,15 @@ const (
 	StatusPath = BasePath + "/statuses"
 	// StatusPathWithStatusID is the path for operations on an existing filter status.
 	StatusPathWithStatusID = StatusPath + "/:" + apiutil.IDKey
+
+	// ExportPath is the path for exporting all filters as a filter list.
+	ExportPath = BasePath + "/export"
+	// ImportPath is the path for importing a filter list.
+	ImportPath = BasePath + "/import"
+	// SubscriptionPath is the base path for operations on filter subscriptions.
+	SubscriptionPath = BasePath + "/subscriptions"
+	// SubscriptionPathWithID is the path for operations on a single filter subscription.
+	SubscriptionPathWithID = SubscriptionPath + "/:" + apiutil.IDKey
 )
 
 // Module implements APIs for client-side aka "v1" filtering.
@@ -77,4 +86,10 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	attachHandler(http.MethodGet, StatusPathWithStatusID, m.FilterStatusGETHandler)
 	attachHandler(http.MethodDelete, StatusPathWithStatusID, m.FilterStatusDELETEHandler)
+
+	attachHandler(http.MethodGet, ExportPath, m.FilterListExportGETHandler)
+	attachHandler(http.MethodPost, ImportPath, m.FilterListImportPOSTHandler)
+
+	attachHandler(http.MethodGet, SubscriptionPath, m.FilterSubscriptionsGETHandler)
+	attachHandler(http.MethodDelete, SubscriptionPathWithID, m.FilterSubscriptionDELETEHandler)
 }
@@ -72,6 +72,15 @@ import (
 //			Sample: true
 //		type: boolean
 //		default: false
+//	-
+//		name: regex
+//		in: formData
+//		description: |-
+//			Should the keyword be interpreted as a case-insensitive regular expression?
+//
+//			Sample: false
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -148,6 +157,13 @@ func validateNormalizeCreateUpdateFilterKeyword(form *apimodel.FilterKeywordCrea
 	}
 
 	form.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+	form.Regex = util.Ptr(util.PtrValueOr(form.Regex, false))
+
+	if *form.Regex {
+		if err := validate.FilterKeywordRegex(form.Keyword); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
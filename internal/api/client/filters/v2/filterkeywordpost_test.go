@@ -143,6 +143,39 @@ func (suite *FiltersTestSuite) TestPostFilterKeywordFullJSON() {
 	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
 }
 
+func (suite *FiltersTestSuite) TestPostFilterKeywordRegexJSON() {
+	homeStream := suite.openHomeStream(suite.testAccounts["local_account_1"])
+
+	filterID := suite.testFilters["local_account_1_filter_1"].ID
+	requestJson := `{
+		"keyword": "fn(o|u)rds?",
+		"whole_word": true,
+		"regex": true
+	}`
+	filterKeyword, err := suite.postFilterKeyword(filterID, nil, nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("fn(o|u)rds?", filterKeyword.Keyword)
+	suite.True(filterKeyword.WholeWord)
+	suite.True(filterKeyword.Regex)
+
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterKeywordInvalidRegexJSON() {
+	filterID := suite.testFilters["local_account_1_filter_1"].ID
+	requestJson := `{
+		"keyword": "fn(ords",
+		"regex": true
+	}`
+	_, err := suite.postFilterKeyword(filterID, nil, nil, &requestJson, http.StatusUnprocessableEntity, `{"error":"Unprocessable Entity: filter keyword is not a valid regular expression: error parsing regexp: missing closing ): `+"`fn(ords`"+`"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *FiltersTestSuite) TestPostFilterKeywordMinimal() {
 	homeStream := suite.openHomeStream(suite.testAccounts["local_account_1"])
 
@@ -155,6 +188,7 @@ func (suite *FiltersTestSuite) TestPostFilterKeywordMinimal() {
 
 	suite.Equal(keyword, filterKeyword.Keyword)
 	suite.False(filterKeyword.WholeWord)
+	suite.False(filterKeyword.Regex)
 
 	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
 }
@@ -69,6 +69,14 @@ import (
 //
 //			Sample: true
 //		type: boolean
+//	-
+//		name: regex
+//		in: formData
+//		description: |-
+//			Should the keyword be interpreted as a case-insensitive regular expression?
+//
+//			Sample: false
+//		type: boolean
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -0,0 +1,380 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const testFilterListURL = "https://example.org/filters/spoilers.json"
+
+// filterListProcessor returns a processor and filters module whose
+// HTTP client serves the current value of document at testFilterListURL.
+func (suite *FiltersTestSuite) filterListProcessor(document *string) (*processing.Processor, *filtersV2.Module) {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != testFilterListURL {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"error":"404 not found"}`)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(*document)),
+			ContentLength: int64(len(*document)),
+		}, nil
+	}, "")
+	federator := testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, httpClient), suite.mediaManager)
+	processor := testrig.NewTestProcessor(&suite.state, federator, suite.emailSender, suite.mediaManager)
+	return processor, filtersV2.New(processor)
+}
+
+func (suite *FiltersTestSuite) filterListRequest(
+	handler gin.HandlerFunc,
+	accountKey string,
+	method string,
+	path string,
+	requestJson *string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) ([]byte, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api/"+path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if requestJson != nil {
+		ctx.Request.Header.Set("content-type", "application/json")
+		ctx.Request.Body = io.NopCloser(strings.NewReader(*requestJson))
+	}
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+		if expectedBody == "" {
+			return nil, errs.Combine()
+		}
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	return b, nil
+}
+
+func (suite *FiltersTestSuite) exportFilterList(accountKey string) *apimodel.FilterList {
+	b, err := suite.filterListRequest(
+		suite.filtersModule.FilterListExportGETHandler,
+		accountKey, http.MethodGet, filtersV2.ExportPath,
+		nil, http.StatusOK, "",
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	list := &apimodel.FilterList{}
+	if err := json.Unmarshal(b, list); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return list
+}
+
+func (suite *FiltersTestSuite) importFilterList(
+	module *filtersV2.Module,
+	accountKey string,
+	requestJson string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) *apimodel.FilterListImportResult {
+	b, err := suite.filterListRequest(
+		module.FilterListImportPOSTHandler,
+		accountKey, http.MethodPost, filtersV2.ImportPath,
+		&requestJson, expectedHTTPStatus, expectedBody,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if expectedBody != "" {
+		return nil
+	}
+
+	result := &apimodel.FilterListImportResult{}
+	if err := json.Unmarshal(b, result); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return result
+}
+
+func (suite *FiltersTestSuite) TestExportFilterList() {
+	list := suite.exportFilterList("local_account_1")
+
+	suite.Equal(apimodel.FilterListVersion, list.Version)
+	suite.Len(list.Filters, 4)
+
+	var fnord *apimodel.FilterListFilter
+	for i := range list.Filters {
+		if list.Filters[i].Title == "fnord" {
+			fnord = &list.Filters[i]
+		}
+	}
+	if suite.NotNil(fnord) {
+		suite.Equal(apimodel.FilterActionWarn, fnord.FilterAction)
+		suite.ElementsMatch([]apimodel.FilterContext{apimodel.FilterContextHome, apimodel.FilterContextPublic}, fnord.Context)
+		suite.Equal([]apimodel.FilterListKeyword{{Keyword: "fnord", WholeWord: true}}, fnord.Keywords)
+	}
+}
+
+// Another account should be able to import an exported
+// filter list, even though the titles are already in use.
+func (suite *FiltersTestSuite) TestImportFilterListFromOtherAccount() {
+	homeStream := suite.openHomeStream(suite.testAccounts["local_account_2"])
+
+	list := suite.exportFilterList("local_account_1")
+	b, err := json.Marshal(map[string]any{"list": list})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	result := suite.importFilterList(suite.filtersModule, "local_account_2", string(b), http.StatusOK, "")
+	suite.Len(result.Filters, 4)
+	suite.Nil(result.Subscription)
+	for _, filter := range result.Filters {
+		suite.Empty(filter.SubscriptionID)
+	}
+
+	filters, err := suite.db.GetFiltersForAccountID(context.Background(), suite.testAccounts["local_account_2"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(filters, 4)
+
+	suite.checkStreamed(homeStream, true, "", stream.EventTypeFiltersChanged)
+}
+
+func (suite *FiltersTestSuite) TestImportFilterListDuplicateTitle() {
+	requestJson := `{
+		"list": {
+			"version": 1,
+			"filters": [
+				{"title": "new filter", "context": ["home"], "filter_action": "hide", "keywords": [{"keyword": "new"}]},
+				{"title": "fnord", "context": ["home"], "filter_action": "warn", "keywords": []}
+			]
+		}
+	}`
+	suite.importFilterList(suite.filtersModule, "local_account_1", requestJson, http.StatusConflict, `{"error":"Conflict: duplicate title: fnord"}`)
+
+	// Nothing should have been imported.
+	filters, err := suite.db.GetFiltersForAccountID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(filters, 4)
+}
+
+func (suite *FiltersTestSuite) TestImportFilterListInvalid() {
+	for _, test := range []struct {
+		requestJson  string
+		expectedBody string
+	}{
+		{
+			requestJson:  `{}`,
+			expectedBody: `{"error":"Unprocessable Entity: exactly one of url or list must be provided"}`,
+		},
+		{
+			requestJson:  `{"list": {"version": 1, "filters": []}, "subscribe": true}`,
+			expectedBody: `{"error":"Unprocessable Entity: subscribe requires a url"}`,
+		},
+		{
+			requestJson:  `{"list": {"version": 2, "filters": []}}`,
+			expectedBody: `{"error":"Unprocessable Entity: unsupported filter list version 2"}`,
+		},
+		{
+			requestJson:  `{"list": {"version": 1, "filters": [{"title": "x", "context": ["home"], "filter_action": "warn", "keywords": [{"keyword": "(", "regex": true}]}]}}`,
+			expectedBody: `{"error":"Unprocessable Entity: filter keyword is not a valid regular expression: error parsing regexp: missing closing ): ` + "`(`" + `"}`,
+		},
+	} {
+		suite.importFilterList(suite.filtersModule, "local_account_1", test.requestJson, http.StatusUnprocessableEntity, test.expectedBody)
+	}
+}
+
+func (suite *FiltersTestSuite) TestFilterListSubscription() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	document := `{
+		"version": 1,
+		"title": "Spoilers",
+		"filters": [
+			{"title": "show", "context": ["home"], "filter_action": "warn", "keywords": [{"keyword": "finale"}, {"keyword": "season \\d+", "regex": true}]},
+			{"title": "book", "context": ["home", "public"], "filter_action": "hide", "keywords": [{"keyword": "chapter", "whole_word": true}]}
+		]
+	}`
+	processor, module := suite.filterListProcessor(&document)
+
+	result := suite.importFilterList(module, "local_account_1", `{"url": "`+testFilterListURL+`", "subscribe": true}`, http.StatusOK, "")
+	suite.Len(result.Filters, 2)
+	if !suite.NotNil(result.Subscription) {
+		suite.FailNow("")
+	}
+	subscriptionID := result.Subscription.ID
+	suite.Equal(testFilterListURL, result.Subscription.URL)
+	suite.Equal("Spoilers", result.Subscription.Title)
+	suite.NotNil(result.Subscription.SyncedAt)
+	suite.Len(result.Subscription.FilterIDs, 2)
+	for _, filter := range result.Filters {
+		suite.Equal(subscriptionID, filter.SubscriptionID)
+	}
+
+	// Subscribing again to the same URL should fail.
+	suite.importFilterList(module, "local_account_1", `{"url": "`+testFilterListURL+`", "subscribe": true}`, http.StatusConflict, `{"error":"Conflict: duplicate title: show"}`)
+
+	// The filter list changes: "book" is removed, "show" is
+	// changed, and a new filter with a title that's already
+	// used by one of the account's own filters is added.
+	document = `{
+		"version": 1,
+		"title": "More Spoilers",
+		"filters": [
+			{"title": "show", "context": ["home", "thread"], "filter_action": "hide", "keywords": [{"keyword": "finale", "whole_word": true}, {"keyword": "cliffhanger"}]},
+			{"title": "fnord", "context": ["home"], "filter_action": "hide", "keywords": [{"keyword": "discordia"}]}
+		]
+	}`
+	suite.Equal(1, processor.FiltersV2().SyncSubscriptions(ctx))
+
+	filters, err := suite.db.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	titles := make(map[string]int)
+	for _, filter := range filters {
+		titles[filter.Title]++
+		switch filter.Title {
+		case "show":
+			suite.Equal(subscriptionID, filter.SubscriptionID)
+			suite.True(*filter.ContextHome)
+			suite.True(*filter.ContextThread)
+			suite.False(*filter.ContextPublic)
+			keywords := make(map[string]bool)
+			for _, keyword := range filter.Keywords {
+				keywords[keyword.Keyword] = *keyword.WholeWord
+			}
+			suite.Equal(map[string]bool{"finale": true, "cliffhanger": false}, keywords)
+
+		case "fnord":
+			// The account's own filter wasn't touched.
+			suite.Empty(filter.SubscriptionID)
+			suite.Len(filter.Keywords, 1)
+			suite.Equal("fnord", filter.Keywords[0].Keyword)
+		}
+	}
+	suite.Equal(map[string]int{
+		"show":                    1,
+		"fnord":                   1,
+		"metasyntactic variables": 1,
+		"puppies":                 1,
+		"empty filter with no keywords or statuses": 1,
+	}, titles)
+
+	apiSubscriptions, errWithCode := processor.FiltersV2().Subscriptions(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(apiSubscriptions, 1) {
+		suite.Equal("More Spoilers", apiSubscriptions[0].Title)
+		suite.Len(apiSubscriptions[0].FilterIDs, 1)
+		suite.Empty(apiSubscriptions[0].Error)
+	}
+
+	// A broken filter list leaves filters alone, and records the error.
+	document = `{"version": 1, "filters": [{"title": "", "context": ["home"], "filter_action": "warn", "keywords": []}]}`
+	suite.Equal(0, processor.FiltersV2().SyncSubscriptions(ctx))
+
+	apiSubscriptions, errWithCode = processor.FiltersV2().Subscriptions(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(apiSubscriptions, 1) {
+		suite.Equal("filter title must be provided, and must be no more than 200 chars", apiSubscriptions[0].Error)
+		suite.Len(apiSubscriptions[0].FilterIDs, 1)
+	}
+
+	// Unsubscribe, keeping the filters.
+	if errWithCode := processor.FiltersV2().SubscriptionDelete(ctx, account, subscriptionID, true); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	filters, err = suite.db.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(filters, 5)
+	for _, filter := range filters {
+		suite.Empty(filter.SubscriptionID)
+	}
+
+	apiSubscriptions, errWithCode = processor.FiltersV2().Subscriptions(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiSubscriptions)
+}
@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterListImportPOSTHandler swagger:operation POST /api/v2/filters/import filterListImport
+//
+// Import a filter list document, creating a filter for each of its entries.
+//
+// The document can be given directly, as a file upload, or as a URL to fetch it from.
+// When importing from a URL, set subscribe to keep the imported filters in sync with
+// the document: it will be re-fetched periodically, and filters managed by the
+// subscription will be created, updated, and removed to match it.
+//
+// Fails with 409 if any filter in the list has the same title as an existing filter.
+//
+//	---
+//	tags:
+//	- filters
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: url
+//		in: formData
+//		description: |-
+//			URL of a filter list document to import.
+//
+//			Sample: https://example.org/filters/spoilers.json
+//		type: string
+//	-
+//		name: list
+//		in: formData
+//		description: Filter list document to import, as JSON.
+//		type: file
+//	-
+//		name: subscribe
+//		in: formData
+//		description: Keep imported filters in sync with the filter list at url.
+//		type: boolean
+//		default: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:filters
+//
+//	responses:
+//		'200':
+//			name: filterListImportResult
+//			description: Imported filters, and subscription if requested.
+//			schema:
+//				"$ref": "#/definitions/filterListImportResult"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden to moved accounts
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (duplicate title, or already subscribed)
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) FilterListImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.FilterListImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateFilterListImport(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	result, errWithCode := m.processor.FiltersV2().Import(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, result)
+}
+
+func validateFilterListImport(form *apimodel.FilterListImportRequest) error {
+	var sources int
+	if form.URL != "" {
+		sources++
+	}
+	if form.List != nil {
+		sources++
+	}
+	if form.ListFile != nil {
+		sources++
+	}
+
+	if sources != 1 {
+		return errors.New("exactly one of url or list must be provided")
+	}
+
+	if form.URL != "" {
+		u, err := url.Parse(form.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return errors.New("invalid url: scheme must be http(s)")
+		}
+	} else if form.Subscribe {
+		return errors.New("subscribe requires a url")
+	}
+
+	return nil
+}
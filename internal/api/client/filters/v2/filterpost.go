@@ -117,6 +117,14 @@ import (
 //		description: Should each keyword consider word boundaries?
 //		collectionFormat: multi
 //	-
+//		name: keywords_attributes[][regex]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Should each keyword be interpreted as a case-insensitive regular expression?
+//		collectionFormat: multi
+//	-
 //		name: statuses_attributes[][status_id]
 //		in: formData
 //		type: array
@@ -210,6 +218,9 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 			if i < len(form.KeywordsAttributesWholeWord) {
 				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
 			}
+			if i < len(form.KeywordsAttributesRegex) {
+				formKeyword.Regex = &form.KeywordsAttributesRegex[i]
+			}
 			form.Keywords = append(form.Keywords, formKeyword)
 		}
 	}
@@ -254,6 +265,12 @@ func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
 			return err
 		}
 		form.Keywords[i].WholeWord = util.Ptr(util.PtrValueOr(formKeyword.WholeWord, false))
+		form.Keywords[i].Regex = util.Ptr(util.PtrValueOr(formKeyword.Regex, false))
+		if *form.Keywords[i].Regex {
+			if err := validate.FilterKeywordRegex(formKeyword.Keyword); err != nil {
+				return err
+			}
+		}
 	}
 	for _, formStatus := range form.Statuses {
 		if err := validate.ULID(formStatus.StatusID, "status_id"); err != nil {
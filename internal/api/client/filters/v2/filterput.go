@@ -85,6 +85,14 @@ import (
 //		description: Should each keyword consider word boundaries?
 //		collectionFormat: multi
 //	-
+//		name: keywords_attributes[][regex]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Should each keyword be interpreted as a case-insensitive regular expression?
+//		collectionFormat: multi
+//	-
 //		name: statuses_attributes[][status_id]
 //		in: formData
 //		type: array
@@ -214,6 +222,7 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 		len(form.KeywordsAttributesID),
 		len(form.KeywordsAttributesKeyword),
 		len(form.KeywordsAttributesWholeWord),
+		len(form.KeywordsAttributesRegex),
 		len(form.KeywordsAttributesDestroy),
 	)
 	if numFormKeywords > 0 {
@@ -229,6 +238,9 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 			if i < len(form.KeywordsAttributesWholeWord) {
 				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
 			}
+			if i < len(form.KeywordsAttributesRegex) {
+				formKeyword.Regex = &form.KeywordsAttributesRegex[i]
+			}
 			if i < len(form.KeywordsAttributesDestroy) {
 				formKeyword.Destroy = &form.KeywordsAttributesDestroy[i]
 			}
@@ -287,6 +299,11 @@ func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
 			if err := validate.FilterKeyword(*formKeyword.Keyword); err != nil {
 				return err
 			}
+			if util.PtrValueOr(formKeyword.Regex, false) {
+				if err := validate.FilterKeywordRegex(*formKeyword.Keyword); err != nil {
+					return err
+				}
+			}
 		}
 
 		destroy := util.PtrValueOr(formKeyword.Destroy, false)
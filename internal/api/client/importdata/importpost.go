@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importdata
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ImportPOSTHandler swagger:operation POST /api/v1/import importPost
+//
+// Import follows, blocks, or mutes from a CSV file, in the format exported by Mastodon.
+//
+// The file is checked straight away, but the accounts within are resolved
+// and followed, blocked, or muted in the background, so the import may take
+// a while to complete after this request returns. Accounts that can't be
+// resolved are skipped.
+//
+//	---
+//	tags:
+//	- import
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data
+//		in: formData
+//		description: CSV file of accounts to import.
+//		type: file
+//		required: true
+//	-
+//		name: type
+//		in: formData
+//		description: Type of the import.
+//		type: string
+//		enum:
+//			- following
+//			- blocks
+//			- mutes
+//		required: true
+//	-
+//		name: mode
+//		in: formData
+//		description: >-
+//			Mode of the import. In "merge" mode, existing follows,
+//			blocks, or mutes are left as-is. In "overwrite" mode,
+//			existing follows, blocks, or mutes of accounts not
+//			contained in the file are removed.
+//		type: string
+//		enum:
+//			- merge
+//			- overwrite
+//		default: merge
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write
+//
+//	responses:
+//		'202':
+//			description: The import has been accepted and will be processed in the background.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.ImportRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Data == nil || form.Data.Size == 0 {
+		err := errors.New("no import data provided")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Account().Import(
+		c.Request.Context(),
+		authed.Account,
+		form.Data,
+		form.Type,
+		form.Mode,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusAccepted, apiutil.AppJSON, apiutil.StatusAcceptedJSON)
+}
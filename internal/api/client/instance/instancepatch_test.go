@@ -107,7 +107,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -131,7 +134,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -228,7 +231,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -252,7 +258,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -349,7 +355,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -373,7 +382,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -521,7 +530,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -545,7 +557,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -664,7 +676,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -688,7 +703,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -754,7 +769,11 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
   "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/attachment/original/`+instanceAccount.AvatarMediaAttachment.ID+`.gif",`+`
   "thumbnail_type": "image/gif",
   "thumbnail_description": "A bouncing little green peglin.",
-  "blurhash": "LG9t;qRS4YtO.4WDRlt5IXoxtPj["
+  "blurhash": "LG9t;qRS4YtO.4WDRlt5IXoxtPj[",
+  "versions": {
+    "@1x": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/attachment/small/`+instanceAccount.AvatarMediaAttachment.ID+`.jpg",
+    "@2x": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/attachment/original/`+instanceAccount.AvatarMediaAttachment.ID+`.gif"
+  }
 }`, string(instanceV2ThumbnailJson))
 
 	// double extra special bonus: now update the image description without changing the image
@@ -822,7 +841,10 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -846,7 +868,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
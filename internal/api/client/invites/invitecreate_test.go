@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteCreateTestSuite struct {
+	InvitesStandardTestSuite
+}
+
+func (suite *InviteCreateTestSuite) createInvite(
+	requestingAccount string,
+	form url.Values,
+	expectedHTTPStatus int,
+	expectedBody string,
+) (*apimodel.Invite, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+invites.BasePath, strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// trigger the handler
+	suite.invitesModule.InvitePOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	resp := &apimodel.Invite{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInvite() {
+	invite, err := suite.createInvite("admin_account", url.Values{
+		"max_uses":   {"5"},
+		"expires_in": {"3600"},
+	}, http.StatusOK, "")
+	suite.NoError(err)
+
+	suite.NotEmpty(invite.ID)
+	suite.Len(invite.Code, 8)
+	suite.Equal(suite.testAccounts["admin_account"].ID, invite.CreatedBy)
+	suite.Equal(5, *invite.MaxUses)
+	suite.Zero(invite.Uses)
+	suite.NotNil(invite.ExpiresAt)
+	suite.False(invite.Expired)
+
+	// Invite should now be in the database.
+	dbInvite, err := suite.db.GetInviteByCode(context.Background(), invite.Code)
+	suite.NoError(err)
+	suite.Equal(invite.ID, dbInvite.ID)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteUnlimited() {
+	invite, err := suite.createInvite("admin_account", url.Values{}, http.StatusOK, "")
+	suite.NoError(err)
+	suite.Nil(invite.MaxUses)
+	suite.Nil(invite.ExpiresAt)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteNegativeMaxUses() {
+	_, err := suite.createInvite("admin_account", url.Values{
+		"max_uses": {"-1"},
+	}, http.StatusBadRequest, `{"error":"Bad Request: max_uses must be 0 (unlimited) or greater"}`)
+	suite.NoError(err)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteUserForbidden() {
+	_, err := suite.createInvite("local_account_1", url.Values{}, http.StatusForbidden, `{"error":"Forbidden: only admins and moderators may create invites on this instance"}`)
+	suite.NoError(err)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteUserAllowed() {
+	config.SetAccountsAllowUserInvites(true)
+
+	invite, err := suite.createInvite("local_account_1", url.Values{}, http.StatusOK, "")
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, invite.CreatedBy)
+}
+
+func TestInviteCreateTestSuite(t *testing.T) {
+	suite.Run(t, &InviteCreateTestSuite{})
+}
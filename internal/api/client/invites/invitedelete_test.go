@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteDeleteTestSuite struct {
+	InvitesStandardTestSuite
+}
+
+func (suite *InviteDeleteTestSuite) deleteInvite(requestingAccount string, inviteID string, expectedHTTPStatus int) *apimodel.Invite {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodDelete, config.GetProtocol()+"://"+config.GetHost()+"/api/"+invites.BasePath+"/"+inviteID, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam("id", inviteID)
+
+	// trigger the handler
+	suite.invitesModule.InviteDELETEHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := &apimodel.Invite{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *InviteDeleteTestSuite) TestRevokeInvite() {
+	testInvite := suite.testInvites["admin_account_invite_1"]
+
+	invite := suite.deleteInvite("admin_account", testInvite.ID, http.StatusOK)
+	suite.True(invite.Expired)
+	suite.NotNil(invite.ExpiresAt)
+
+	// Revoked invite should no longer be usable.
+	dbInvite, err := suite.db.GetInviteByID(context.Background(), testInvite.ID)
+	suite.NoError(err)
+	suite.False(dbInvite.Usable(time.Now()))
+}
+
+func (suite *InviteDeleteTestSuite) TestRevokeInviteNotOwned() {
+	testInvite := suite.testInvites["admin_account_invite_1"]
+
+	// A regular user can't revoke another account's invite.
+	suite.deleteInvite("local_account_1", testInvite.ID, http.StatusNotFound)
+}
+
+func TestInviteDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &InviteDeleteTestSuite{})
+}
@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InvitesGetTestSuite struct {
+	InvitesStandardTestSuite
+}
+
+func (suite *InvitesGetTestSuite) getInvites(requestingAccount string) ([]*apimodel.Invite, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requestingAccount])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requestingAccount]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requestingAccount])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api/"+invites.BasePath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// trigger the handler
+	suite.invitesModule.InvitesGETHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []*apimodel.Invite{}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *InvitesGetTestSuite) TestGetInvitesAdmin() {
+	// Admin should see all invites.
+	invites, err := suite.getInvites("admin_account")
+	suite.NoError(err)
+	suite.Len(invites, 3)
+
+	b, err := json.MarshalIndent(invites, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`[
+  {
+    "id": "01J1XW6CVGY3DQ8P3N06ZRN2XH",
+    "code": "mN4ha9Ue",
+    "created_at": "2024-06-30T14:05:09.000Z",
+    "created_by": "01F8MH1H7YV1Z7D2C8K2730QBF",
+    "max_uses": 1,
+    "uses": 1,
+    "expires_at": null,
+    "expired": true
+  },
+  {
+    "id": "01J1XW3N8WZ0AE8EJ2H1Q7GZ5F",
+    "code": "8Xwq3KtB",
+    "created_at": "2024-06-30T14:03:40.000Z",
+    "created_by": "01F8MH17FWEB39HZJ76B6VXSKF",
+    "max_uses": 10,
+    "uses": 0,
+    "expires_at": "2024-07-01T14:03:40.000Z",
+    "expired": true
+  },
+  {
+    "id": "01J1XW0YB6J5S3ZVG0Y57NMQTK",
+    "code": "SZnV7r2Q",
+    "created_at": "2024-06-30T14:02:11.000Z",
+    "created_by": "01F8MH17FWEB39HZJ76B6VXSKF",
+    "max_uses": null,
+    "uses": 0,
+    "expires_at": null,
+    "expired": false
+  }
+]`, string(b))
+}
+
+func (suite *InvitesGetTestSuite) TestGetInvitesUser() {
+	// Regular user should only see their own invite.
+	invites, err := suite.getInvites("local_account_1")
+	suite.NoError(err)
+	suite.Len(invites, 1)
+	suite.Equal("01J1XW6CVGY3DQ8P3N06ZRN2XH", invites[0].ID)
+}
+
+func TestInvitesGetTestSuite(t *testing.T) {
+	suite.Run(t, &InvitesGetTestSuite{})
+}
@@ -34,6 +34,12 @@ import (
 //
 // Upload a new media attachment.
 //
+// When using `v1` of the API, the media is processed before the request returns.
+//
+// When using `v2` of the API, the media is processed asynchronously, and a `202`
+// is returned with an attachment that has no `url` or `preview_url` set yet. Use
+// `GET /api/v1/media/{id}` to check on the attachment until processing is done.
+//
 //	---
 //	tags:
 //	- media
@@ -81,7 +87,11 @@ import (
 //
 //	responses:
 //		'200':
-//			description: The newly-created media attachment.
+//			description: The newly-created media attachment (v1).
+//			schema:
+//				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: The newly-created media attachment, still processing (v2).
 //			schema:
 //				"$ref": "#/definitions/attachment"
 //		'400':
@@ -129,21 +139,26 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if apiVersion == apiutil.APIv2 {
+		// The v2 media API processes media asynchronously,
+		// and the client should then call /api/v1/media/:id
+		// to check whether the attachment is ready yet.
+		apiAttachment, errWithCode := m.processor.Media().CreateAsync(c.Request.Context(), authed.Account, form)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+		return
+	}
+
 	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if apiVersion == apiutil.APIv2 {
-		// the mastodon v2 media API specifies that the URL should be null
-		// and that the client should call /api/v1/media/:id to get the URL
-		//
-		// so even though we have the URL already, remove it now to comply
-		// with the api
-		apiAttachment.URL = nil
-	}
-
 	apiutil.JSON(c, http.StatusOK, apiAttachment)
 }
 
@@ -19,6 +19,7 @@ package media_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -28,6 +29,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	mediamodule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -249,7 +251,37 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 	// do the actual request
 	suite.mediaModule.MediaCreatePOSTHandler(ctx)
 
-	// check what's in storage *after* the request
+	// check response, media should still be processing
+	suite.EqualValues(http.StatusAccepted, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	fmt.Println(string(b))
+
+	attachmentReply := &apimodel.Attachment{}
+	err = json.Unmarshal(b, attachmentReply)
+	suite.NoError(err)
+
+	suite.Equal("this is a test image -- a cool background from somewhere", *attachmentReply.Description)
+	suite.Equal("unknown", attachmentReply.Type)
+	suite.NotEmpty(attachmentReply.ID)
+	suite.Nil(attachmentReply.URL)
+	suite.Nil(attachmentReply.PreviewURL)
+
+	// poll the attachment, it should be partial content
+	// until the queued processing job has been run
+	recorder, ctx = suite.getMedia(attachmentReply.ID)
+	suite.EqualValues(http.StatusPartialContent, recorder.Code)
+
+	job, ok := suite.state.Workers.Dereference.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected media processing job to be queued")
+	}
+	job(context.Background())
+
+	// check what's in storage *after* processing
 	var storageKeysAfterRequest []string
 	if err := suite.storage.WalkKeys(ctx, func(key string) error {
 		storageKeysAfterRequest = append(storageKeysAfterRequest, key)
@@ -258,16 +290,16 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		panic(err)
 	}
 
-	// check response
+	// poll the attachment again, it should now be done
+	recorder, _ = suite.getMedia(attachmentReply.ID)
 	suite.EqualValues(http.StatusOK, recorder.Code)
 
-	result := recorder.Result()
+	result = recorder.Result()
 	defer result.Body.Close()
-	b, err := ioutil.ReadAll(result.Body)
+	b, err = ioutil.ReadAll(result.Body)
 	suite.NoError(err)
-	fmt.Println(string(b))
 
-	attachmentReply := &apimodel.Attachment{}
+	attachmentReply = &apimodel.Attachment{}
 	err = json.Unmarshal(b, attachmentReply)
 	suite.NoError(err)
 
@@ -292,12 +324,30 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		},
 	}, *attachmentReply.Meta)
 	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", *attachmentReply.Blurhash)
-	suite.NotEmpty(attachmentReply.ID)
-	suite.Nil(attachmentReply.URL)
+	suite.NotEmpty(attachmentReply.URL)
 	suite.NotEmpty(attachmentReply.PreviewURL)
 	suite.Equal(len(storageKeysBeforeRequest)+2, len(storageKeysAfterRequest)) // 2 images should be added to storage: the original and the thumbnail
 }
 
+// getMedia performs a GET /api/v1/media/:id request for the given attachment.
+func (suite *MediaCreateTestSuite) getMedia(id string) (*httptest.ResponseRecorder, *gin.Context) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/media/"+id, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.APIVersionKey, apiutil.APIv1)
+	ctx.AddParam(mediamodule.IDKey, id)
+
+	suite.mediaModule.MediaGETHandler(ctx)
+	return recorder, ctx
+}
+
 func (suite *MediaCreateTestSuite) TestMediaCreateLongDescription() {
 	// set up the context for the request
 	t := suite.testTokens["local_account_1"]
@@ -31,6 +31,9 @@ import (
 //
 // Get a media attachment that you own.
 //
+// If the attachment is still being processed, a `206` is returned,
+// and the attachment will not have a `url` or `preview_url` set yet.
+//
 //	---
 //	tags:
 //	- media
@@ -55,6 +58,10 @@ import (
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: The requested media attachment, still processing.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -63,6 +70,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: media could not be processed
 //		'500':
 //		   description: internal server error
 func (m *Module) MediaGETHandler(c *gin.Context) {
@@ -98,5 +107,11 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if attachment.URL == nil {
+		// Still processing.
+		apiutil.JSON(c, http.StatusPartialContent, attachment)
+		return
+	}
+
 	apiutil.JSON(c, http.StatusOK, attachment)
 }
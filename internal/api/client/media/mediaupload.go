@@ -0,0 +1,467 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// UploadOffsetHeader is the header used to
+// give the offset of an uploaded chunk of data.
+const UploadOffsetHeader = "Upload-Offset"
+
+// MediaUploadPOSTHandler swagger:operation POST /api/{api_version}/media/uploads mediaUploadCreate
+//
+// Start a new chunked upload of a media file.
+//
+// This allows large media files (such as videos) to be uploaded in
+// several chunks, resuming from where it left off if the connection drops.
+//
+// The file data should then be sent in chunks using `PATCH /api/{api_version}/media/uploads/{id}`,
+// and the upload completed using `POST /api/{api_version}/media/uploads/{id}/finish`.
+//
+// Upload sessions which receive no data for 24 hours are expired and discarded.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: size
+//		in: formData
+//		description: Total size in bytes of the media file to be uploaded.
+//		type: integer
+//		required: true
+//	-
+//		name: description
+//		in: formData
+//		description: >-
+//			Image or media description to use as alt-text on the attachment.
+//			This is very useful for users of screenreaders!
+//			May or may not be required, depending on your instance settings.
+//		type: string
+//	-
+//		name: focus
+//		in: formData
+//		description: >-
+//			Focus of the media file.
+//			If present, it should be in the form of two comma-separated floats between -1 and 1.
+//			For example: `-0.5,0.25`.
+//		type: string
+//		default: "0,0"
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The newly-created upload session.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: too many uploads in progress
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPOSTHandler(c *gin.Context) {
+	authed, errWithCode := m.uploadAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.MediaUploadRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
+	if length := len([]rune(form.Description)); length > maxDescriptionChars {
+		err := fmt.Errorf("image description length must be between %d and %d characters (inclusive), but provided image description was %d chars", config.GetMediaDescriptionMinChars(), maxDescriptionChars, length)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadGETHandler swagger:operation GET /api/{api_version}/media/uploads/{id} mediaUploadGet
+//
+// Get the state of a chunked media upload.
+//
+// Use this to find the `offset` to resume an interrupted upload from.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		description: id of the upload session
+//		type: string
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The requested upload session.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadGETHandler(c *gin.Context) {
+	authed, errWithCode := m.uploadAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadGet(c.Request.Context(), authed.Account, uploadID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadPATCHHandler swagger:operation PATCH /api/{api_version}/media/uploads/{id} mediaUploadWrite
+//
+// Upload a chunk of a media file.
+//
+// The request body should be the raw bytes of the chunk, and the `Upload-Offset`
+// header the offset in the file at which the chunk starts. This must match the
+// `offset` of the upload session, otherwise a `409` is returned.
+//
+// If the connection drops part way through a chunk, any data received is kept,
+// and the upload can be resumed from the new `offset` of the upload session.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/offset+octet-stream
+//	- application/octet-stream
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		description: id of the upload session
+//		type: string
+//		in: path
+//		required: true
+//	-
+//		name: Upload-Offset
+//		description: Offset in bytes of the chunk in the media file.
+//		type: integer
+//		in: header
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The updated upload session.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: offset does not match upload session
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPATCHHandler(c *gin.Context) {
+	authed, errWithCode := m.uploadAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		err := errors.New(UploadOffsetHeader + " header must be provided as a non-negative integer")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadWrite(
+		c.Request.Context(),
+		authed.Account,
+		uploadID,
+		offset,
+		c.Request.Body,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadFinishPOSTHandler swagger:operation POST /api/{api_version}/media/uploads/{id}/finish mediaUploadFinish
+//
+// Finish a chunked media upload, creating a media attachment from the uploaded file.
+//
+// As with `v2` of `POST /api/{api_version}/media`, the media is processed asynchronously,
+// and a `202` is returned with an attachment that has no `url` or `preview_url` set yet.
+// Use `GET /api/v1/media/{id}` to check on the attachment until processing is done.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		description: id of the upload session
+//		type: string
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'202':
+//			description: The newly-created media attachment, still processing.
+//			schema:
+//				"$ref": "#/definitions/attachment"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: upload incomplete
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadFinishPOSTHandler(c *gin.Context) {
+	authed, errWithCode := m.uploadAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiAttachment, errWithCode := m.processor.Media().UploadFinish(c.Request.Context(), authed.Account, uploadID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+}
+
+// MediaUploadDELETEHandler swagger:operation DELETE /api/{api_version}/media/uploads/{id} mediaUploadDelete
+//
+// Cancel a chunked media upload, discarding any data uploaded so far.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		description: id of the upload session
+//		type: string
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: upload cancelled
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadDELETEHandler(c *gin.Context) {
+	authed, errWithCode := m.uploadAuthed(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Media().UploadDelete(c.Request.Context(), authed.Account, uploadID); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONObject)
+}
+
+// uploadAuthed performs the API version, authorization and
+// accept header checks common to all chunked upload handlers.
+func (m *Module) uploadAuthed(c *gin.Context) (*oauth.Auth, gtserror.WithCode) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	if authed.Account.IsMoving() {
+		const text = "your account has Moved or is currently Moving; you cannot take create or update type actions"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		return nil, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	return authed, nil
+}
@@ -49,6 +49,7 @@ import (
 //			Version of the API to use. Must be either `v1` or `v2`.
 //			If v1 is used, Hashtag results will be a slice of strings.
 //			If v2 is used, Hashtag results will be a slice of apimodel tags.
+//			v1 is deprecated, and only kept for older clients; use v2.
 //		required: true
 //	-
 //		name: max_id
@@ -81,11 +82,11 @@ import (
 //		name: offset
 //		type: integer
 //		description: >-
-//			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Number of results of each type to skip, for paging through results.
+//			If 'type' is set to a specific type, paging using max_id and min_id
+//			is more efficient and should be preferred.
 //		default: 0
-//		maximum: 10
+//		maximum: 400
 //		minimum: 0
 //		in: query
 //		required: false
@@ -207,7 +208,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 10, 0)
+	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 400, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -1178,6 +1178,124 @@ func (suite *SearchGetTestSuite) TestSearchAccountsLimit1() {
 	suite.Len(searchResult.Hashtags, 0)
 }
 
+func (suite *SearchGetTestSuite) TestSearchAccountsOffset() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = func() *int { i := 2; return &i }()
+		offset             *int    = func() *int { i := 3; return &i }()
+		resolve            *bool   = func() *bool { i := true; return &i }()
+		query                      = "a"
+		queryType          *string = func() *string { i := "accounts"; return &i }() // Only accounts.
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	// Get all results first, to compare against.
+	allResults, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		nil,
+		nil,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(allResults.Accounts, 5) {
+		suite.FailNow("")
+	}
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should skip the first 3 results.
+	if !suite.Len(searchResult.Accounts, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal(allResults.Accounts[3].ID, searchResult.Accounts[0].ID)
+	suite.Equal(allResults.Accounts[4].ID, searchResult.Accounts[1].ID)
+	suite.Len(searchResult.Statuses, 0)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchLocalAccountByURIOffset() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = func() *int { i := 1; return &i }()
+		resolve            *bool   = nil
+		query                      = "http://localhost:8080/users/the_mighty_zork"
+		queryType          *string = func() *string { i := "accounts"; return &i }()
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ""
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The one exact match for a URI
+	// is on the first page, not this.
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 0)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
 func (suite *SearchGetTestSuite) TestSearchLocalInstanceAccountByURI() {
 	var (
 		requestingAccount          = suite.testAccounts["local_account_1"]
@@ -105,6 +105,13 @@ import (
 //		default: true
 //		in: formData
 //	-
+//		name: poll[vote_change]
+//		x-go-name: PollVoteChange
+//		description: Allow voters to change their vote until the poll ends.
+//		type: boolean
+//		default: false
+//		in: formData
+//	-
 //		name: in_reply_to_id
 //		x-go-name: InReplyToID
 //		description: ID of the status being replied to, if status is a reply.
@@ -34,6 +34,10 @@ import (
 // The deleted status will be returned in the response. The `text` field will contain the original text of the status as it was submitted.
 // This is useful when doing a 'delete and redraft' type operation.
 //
+// Unless `delete_media` is set to true, any media attachments of the status will be unattached
+// rather than deleted, so they can be reused in a new status (for example, a redraft). They will
+// then be cleaned up like any other unused media, if not reattached within 24 hours.
+//
 //	---
 //	tags:
 //	- statuses
@@ -48,6 +52,14 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: delete_media
+//		type: boolean
+//		description: >-
+//			Immediately delete the status' media attachments,
+//			instead of keeping them available for reuse.
+//		in: query
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -89,7 +101,13 @@ func (m *Module) StatusDELETEHandler(c *gin.Context) {
 		return
 	}
 
-	apiStatus, errWithCode := m.processor.Status().Delete(c.Request.Context(), authed.Account, targetStatusID)
+	deleteMedia, errWithCode := apiutil.ParseStatusDeleteMedia(c.Query(apiutil.StatusDeleteMediaKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiStatus, errWithCode := m.processor.Status().Delete(c.Request.Context(), authed.Account, targetStatusID, deleteMedia)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -16,6 +16,7 @@
 package statuses_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -86,6 +87,85 @@ func (suite *StatusDeleteTestSuite) TestPostDelete() {
 
 }
 
+func (suite *StatusDeleteTestSuite) deleteStatus(targetStatusID string, query string) *apimodel.Status {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("http://localhost:8080%s?%s", strings.Replace(statuses.BasePathWithID, ":id", targetStatusID, 1), query), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	suite.statusModule.StatusDELETEHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &apimodel.Status{}
+	if err := json.Unmarshal(b, statusReply); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetStatusByID(ctx, targetStatusID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("time out waiting for status to be deleted")
+	}
+
+	return statusReply
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteRedraft() {
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+
+	statusReply := suite.deleteStatus(targetStatus.ID, "")
+
+	// Source text and attachments should
+	// be returned for the client to redraft.
+	suite.Equal(targetStatus.Text, statusReply.Text)
+	suite.Len(statusReply.MediaAttachments, len(targetStatus.AttachmentIDs))
+
+	// Attachments should still exist, unattached.
+	for _, id := range targetStatus.AttachmentIDs {
+		attachment, err := suite.db.GetAttachmentByID(context.Background(), id)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Empty(attachment.StatusID)
+	}
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteMedia() {
+	targetStatus := suite.testStatuses["local_account_1_status_4"]
+
+	suite.deleteStatus(targetStatus.ID, "delete_media=true")
+
+	// Attachments should be deleted along with status.
+	for _, id := range targetStatus.AttachmentIDs {
+		if !testrig.WaitFor(func() bool {
+			_, err := suite.db.GetAttachmentByID(context.Background(), id)
+			return errors.Is(err, db.ErrNoEntries)
+		}) {
+			suite.FailNow("time out waiting for attachment to be deleted")
+		}
+	}
+}
+
 func TestStatusDeleteTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusDeleteTestSuite))
 }
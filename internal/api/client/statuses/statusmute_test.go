@@ -19,6 +19,7 @@ package statuses_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,7 +31,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -212,6 +215,44 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 }`, unmuted)
 }
 
+func (suite *StatusMuteTestSuite) TestMuteUnthreadedStatus() {
+	var (
+		ctx          = context.Background()
+		targetStatus = new(gtsmodel.Status)
+	)
+
+	// Remove the thread ID from the status, as
+	// if it was deref'd before being threaded.
+	*targetStatus = *suite.testStatuses["local_account_1_status_1"]
+	targetStatus.ThreadID = ""
+	if err := suite.db.UpdateStatus(ctx, targetStatus, "thread_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	path := fmt.Sprintf("http://localhost:8080/api%s", strings.ReplaceAll(statuses.MutePath, ":id", targetStatus.ID))
+
+	// Mute the status, ensure `muted` is `true`.
+	code, muted := suite.post(path, suite.statusModule.StatusMutePOSTHandler, targetStatus.ID)
+	suite.Equal(http.StatusOK, code)
+
+	apiStatus := &apimodel.Status{}
+	if err := json.Unmarshal([]byte(muted), apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(apiStatus.Muted)
+
+	// The status should now have a new, muted thread.
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(dbStatus.ThreadID)
+
+	threadMute, err := suite.db.GetThreadMutedByAccount(ctx, dbStatus.ThreadID, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.NotNil(threadMute)
+}
+
 func TestStatusMuteTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusMuteTestSuite))
 }
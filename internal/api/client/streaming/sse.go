@@ -0,0 +1,245 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package streaming
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// serveSSE hijacks the underlying connection of the incoming
+// HTTP request, and writes a server-sent events response into it.
+//
+// As with websockets, hijacking the connection lets the handler
+// return, so it doesn't hold open any throttle / rate-limit request
+// tokens, and it also bypasses any compression middleware that would
+// otherwise buffer events. This does mean that server-sent events
+// are only supported over HTTP/1.x connections, as HTTP/2 ones
+// cannot be hijacked (the same as websockets in net/http).
+func (m *Module) serveSSE(c *gin.Context, l *log.Entry, stream *streampkg.Stream) {
+	if c.Request.ProtoMajor != 1 {
+		const text = "server-sent events streaming requires HTTP/1.1"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		stream.Close()
+		return
+	}
+
+	// Take the response headers set so far
+	// (eg., by CORS middleware), dropping
+	// any set by compression middleware.
+	hdr := c.Writer.Header().Clone()
+	hdr.Del("Content-Encoding")
+	hdr.Del("Content-Length")
+	hdr.Set("Content-Type", "text/event-stream")
+	hdr.Set("Cache-Control", "no-store")
+	hdr.Set("Transfer-Encoding", "chunked")
+	hdr.Set("Connection", "close")
+	hdr.Set("X-Accel-Buffering", "no") // disable nginx buffering
+
+	conn, rw, err := c.Writer.Hijack()
+	if err != nil {
+		l.Errorf("error hijacking server-sent events connection: %v", err)
+		stream.Close()
+		return
+	}
+
+	// Clear any deadlines set by the
+	// http server on hijacked connection.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		l.Errorf("error clearing server-sent events connection deadline: %v", err)
+		stream.Close()
+		_ = conn.Close()
+		return
+	}
+
+	// Write the response status line and headers.
+	_, _ = rw.WriteString("HTTP/1.1 200 OK\r\n")
+	_ = hdr.Write(rw)
+	_, _ = rw.WriteString("\r\n")
+	if err := rw.Flush(); err != nil {
+		l.Debugf("error writing server-sent events response: %v", err)
+		stream.Close()
+		_ = conn.Close()
+		return
+	}
+
+	// Perform the main rw loops in separate
+	// goroutine, so this handler can return.
+	go m.handleSSEConn(l, conn, rw, stream)
+}
+
+// handleSSEConn handles a (one-way) server-sent events streaming
+// connection. It will push messages into the connection until an
+// error is encountered while writing, the stream is closed, or the
+// client leaves, at which point the connection will be closed.
+func (m *Module) handleSSEConn(l *log.Entry, conn net.Conn, rw *bufio.ReadWriter, stream *streampkg.Stream) {
+	l.Info("opened server-sent events connection")
+
+	// Create new async context with cancel.
+	ctx, cncl := context.WithCancel(context.Background())
+
+	go func() {
+		defer cncl()
+
+		// Clients don't send anything over server-sent
+		// events connections, so reading is only to
+		// detect when the client has closed the conn.
+		_, _ = io.Copy(io.Discard, rw.Reader)
+	}()
+
+	go func() {
+		defer cncl()
+
+		// Write messages from processor in sse conn.
+		m.writeToSSEConn(ctx, conn, rw.Writer, stream, m.pingInterval, l)
+	}()
+
+	// Wait for ctx
+	// to be closed.
+	<-ctx.Done()
+
+	// Close stream
+	// straightaway.
+	stream.Close()
+
+	// Tidy up underlying connection.
+	if err := conn.Close(); err != nil {
+		l.Errorf("error closing server-sent events connection: %v", err)
+	}
+
+	l.Info("closed server-sent events connection")
+}
+
+// writeToSSEConn receives messages coming from the processor via the given
+// stream, and writes them as (chunk encoded) events into the given writer.
+// This function also handles writing comments into the connection to keep
+// it alive when no other activity occurs.
+//
+// This is a blocking function; will return only on write error or
+// if the given context is canceled.
+func (m *Module) writeToSSEConn(
+	ctx context.Context,
+	conn net.Conn,
+	bw *bufio.Writer,
+	stream *streampkg.Stream,
+	ping time.Duration,
+	l *log.Entry,
+) {
+	cw := httputil.NewChunkedWriter(bw)
+	var buf bytes.Buffer
+
+	for {
+		// Wrap context with timeout to send a ping.
+		pingctx, cncl := context.WithTimeout(ctx, ping)
+
+		// Block on receipt of msg.
+		msg, ok := stream.Recv(pingctx)
+
+		// Check if cancel because ping.
+		pinged := (pingctx.Err() != nil)
+		cncl()
+
+		buf.Reset()
+
+		switch {
+		case !ok && pinged:
+			if ctx.Err() != nil {
+				// Parent context
+				// was cancelled.
+				return
+			}
+
+			// The ping context timed out! Send a keep-alive
+			// comment, which clients will ignore. As with
+			// Mastodon, this is a friendly heart"thump".
+			l.Trace("writing server-sent events ping")
+			buf.WriteString(":thump\n\n")
+
+		case !ok:
+			// Stream was closed, write
+			// the terminating chunk.
+			_ = cw.Close()
+			_, _ = bw.WriteString("\r\n")
+			_ = bw.Flush()
+			return
+
+		default:
+			// Received a new message from the processor.
+			l.Tracef("writing server-sent events message: %+v", msg)
+			writeSSEMessage(&buf, msg)
+		}
+
+		// Clients don't respond over server-sent
+		// events, but don't block forever on one
+		// that has stopped reading from conn.
+		_ = conn.SetWriteDeadline(m.deadline())
+
+		if _, err := cw.Write(buf.Bytes()); err != nil {
+			l.Debugf("error writing server-sent events message: %v", err)
+			break
+		}
+
+		if err := bw.Flush(); err != nil {
+			l.Debugf("error writing server-sent events message: %v", err)
+			break
+		}
+	}
+
+	l.Debug("finished server-sent events write")
+}
+
+// writeSSEMessage writes given stream message
+// to buffer in the server-sent event format.
+func writeSSEMessage(buf *bytes.Buffer, msg streampkg.Message) {
+	payload := msg.Payload
+	if payload == "" {
+		// Clients won't dispatch an event with
+		// empty data, so (as Mastodon does) use
+		// a placeholder for events without a
+		// payload, eg., "filters_changed".
+		payload = "undefined"
+	}
+
+	buf.WriteString("event: ")
+	buf.WriteString(msg.Event)
+	buf.WriteByte('\n')
+
+	// Payloads *should* be single line (it's
+	// JSON), but they must be split over multiple
+	// data fields if not, which clients re-join.
+	for _, line := range strings.Split(payload, "\n") {
+		buf.WriteString("data: ")
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteByte('\n')
+}
@@ -19,7 +19,9 @@ package streaming
 
 import (
 	"context"
-	"slices"
+	"errors"
+	"net"
+	"strings"
 	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -44,7 +46,11 @@ import (
 //
 // As long as the connection is open, various message types will be streamed into it.
 //
-// GoToSocial will ping the connection every 30 seconds to check whether the client is still receiving.
+// GoToSocial will ping the connection every 30 seconds (by default) to check whether the client is still receiving, and will close the connection if nothing, including a pong in response to these pings, is received from the client for 90 seconds (by default).
+//
+// Once connected, further streams may be subscribed to (or unsubscribed from) over the same websocket connection, by sending messages in the form `{"type":"subscribe","stream":"list","list":"01H3YF48G8B7KTPQFS8D2QBVG8"}` (or `"type":"unsubscribe"`). The `stream` field of each streamed message indicates which subscription it belongs to, with any list ID given as a separate element, eg., `["list","01H3YF48G8B7KTPQFS8D2QBVG8"]`. As such, the `stream` parameter may be omitted when initiating the connection, to start with no subscriptions.
+//
+// If the request is not a websocket upgrade request, then messages will instead be streamed as server-sent events (`text/event-stream`), with a code `200`, each event consisting of an `event` line followed by a `data` line containing the payload. In this case GoToSocial will write a comment line into the stream every 30 seconds (by default) to keep the connection alive. For compatibility with Mastodon, server-sent events may also be requested by giving the stream type in the path instead of the query, eg., `/api/v1/streaming/public/local`, or `/api/v1/streaming/list?list=01H3YF48G8B7KTPQFS8D2QBVG8`.
 //
 // If the ping fails, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
 //
@@ -81,7 +87,6 @@ import (
 //			`list`: receive updates for a certain list of accounts.
 //			`direct`: receive updates for direct messages.
 //		in: query
-//		required: true
 //	-
 //		name: list
 //		type: string
@@ -126,12 +131,14 @@ import (
 //							`notification`: a new notification has been received.
 //							`delete`: a status has been deleted.
 //							`filters_changed`: filters (including keywords and statuses) have changed.
+//							`conversation`: a direct conversation has been updated.
 //						type: string
 //						enum:
 //						- update
 //						- notification
 //						- delete
 //						- filters_changed
+//						- conversation
 //					payload:
 //						description: |-
 //							The payload of the streamed message.
@@ -143,12 +150,15 @@ import (
 //							If `event` = `notification`, then the payload will be a JSON string of a notification.
 //							If `event` = `delete`, then the payload will be a status ID.
 //							If `event` = `filters_changed`, then there is no payload.
+//							If `event` = `conversation`, then the payload will be a JSON string of a conversation.
 //						type: string
 //						example: "{\"id\":\"01FC3TZ5CFG6H65GCKCJRKA669\",\"created_at\":\"2021-08-02T16:25:52Z\",\"sensitive\":false,\"spoiler_text\":\"\",\"visibility\":\"public\",\"language\":\"en\",\"uri\":\"https://gts.superseriousbusiness.org/users/dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"url\":\"https://gts.superseriousbusiness.org/@dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"replies_count\":0,\"reblogs_count\":0,\"favourites_count\":0,\"favourited\":false,\"reblogged\":false,\"muted\":false,\"bookmarked\":fals…//gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/original/019036W043D8FXPJKSKCX7G965.png\",\"header_static\":\"https://gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/small/019036W043D8FXPJKSKCX7G965.png\",\"followers_count\":33,\"following_count\":28,\"statuses_count\":126,\"last_status_at\":\"2021-08-02T16:25:52Z\",\"emojis\":[],\"fields\":[]},\"media_attachments\":[],\"mentions\":[],\"tags\":[],\"emojis\":[],\"card\":null,\"poll\":null,\"text\":\"a\"}"
 //		'401':
 //			description: unauthorized
 //		'400':
 //			description: bad request
+//		'429':
+//			description: too many open streaming connections for this account or instance
 func (m *Module) StreamGETHandler(c *gin.Context) {
 	var (
 		account     *gtsmodel.Account
@@ -193,8 +203,15 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Get the initial requested stream type, if there is one.
-	streamType := c.Query(StreamQueryKey)
+	// Get the initial requested stream type, if there is one,
+	// either from the path (for server-sent events), eg.,
+	// `/api/v1/streaming/public/local`, or from the query.
+	streamType := c.Param(StreamTypeKey)
+	if streamType == "" {
+		streamType = c.Query(StreamQueryKey)
+	} else if subType := c.Param(StreamSubTypeKey); subType != "" {
+		streamType += ":" + subType
+	}
 
 	// By appending other query params to the streamType, we
 	// can allow streaming for specific list IDs or hashtags.
@@ -224,6 +241,13 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		WithField("streamID", id.NewULID()).
 		WithField("username", account.Username)
 
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		// Not a websocket upgrade request,
+		// stream as server-sent events instead.
+		m.serveSSE(c, &l, stream)
+		return
+	}
+
 	// Upgrade the incoming HTTP request. This hijacks the
 	// underlying connection and reuses it for the websocket
 	// (non-http) protocol.
@@ -242,7 +266,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// This prevents the upgrade handler from holding open any
 	// throttle / rate-limit request tokens which could become
 	// problematic on instances with multiple users.
-	go m.handleWSConn(&l, wsConn, stream)
+	go m.handleWSConn(&l, wsConn, account, stream)
 }
 
 // handleWSConn handles a two-way websocket streaming connection.
@@ -250,9 +274,17 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 // into the connection. If any errors are encountered while reading
 // or writing (including expected errors like clients leaving), the
 // connection will be closed.
-func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream) {
+func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, account *gtsmodel.Account, stream *streampkg.Stream) {
 	l.Info("opened websocket connection")
 
+	// Consider the connection dead if nothing,
+	// including pongs in response to our pings,
+	// is received from the client before deadline.
+	_ = wsConn.SetReadDeadline(m.deadline())
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(m.deadline())
+	})
+
 	// Create new async context with cancel.
 	ctx, cncl := context.WithCancel(context.Background())
 
@@ -260,14 +292,14 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 		defer cncl()
 
 		// Read messages from websocket to server.
-		m.readFromWSConn(ctx, wsConn, stream, l)
+		m.readFromWSConn(ctx, wsConn, account, stream, l)
 	}()
 
 	go func() {
 		defer cncl()
 
 		// Write messages from processor in websocket conn.
-		m.writeToWSConn(ctx, wsConn, stream, m.dTicker, l)
+		m.writeToWSConn(ctx, wsConn, stream, m.pingInterval, l)
 	}()
 
 	// Wait for ctx
@@ -289,12 +321,14 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 // readFromWSConn reads control messages coming in from the given
 // websockets connection, and modifies the subscription StreamTypes
 // of the given stream accordingly after acquiring a lock on it.
+// This allows clients to multiplex many streams over one connection.
 //
 // This is a blocking function; will return only on read error or
 // if the given context is canceled.
 func (m *Module) readFromWSConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
+	account *gtsmodel.Account,
 	stream *streampkg.Stream,
 	l *log.Entry,
 ) {
@@ -304,13 +338,18 @@ func (m *Module) readFromWSConn(
 			Type   string `json:"type"`
 			Stream string `json:"stream"`
 			List   string `json:"list,omitempty"`
+			Tag    string `json:"tag,omitempty"`
 		}
 
 		// Read JSON objects from the client and act on them.
 		if err := wsConn.ReadJSON(&msg); err != nil {
+			var netErr net.Error
+
 			// Only log an error if something weird happened.
 			// See: https://www.rfc-editor.org/rfc/rfc6455.html#section-11.7
-			if !websocket.IsCloseError(err, []int{
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				l.Info("closing idle websocket connection")
+			} else if !websocket.IsCloseError(err, []int{
 				websocket.CloseNormalClosure,
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived,
@@ -323,29 +362,39 @@ func (m *Module) readFromWSConn(
 			break
 		}
 
+		// Client is alive, extend read deadline.
+		_ = wsConn.SetReadDeadline(m.deadline())
+
 		// Messages *from* the WS connection are infrequent
 		// and usually interesting, so log this at info.
 		l.Infof("received websocket message: %+v", msg)
 
-		// Ignore if the updateStreamType is unknown (or missing),
-		// so a bad client can't cause extra memory allocations
-		if !slices.Contains(streampkg.AllStatusTimelines, msg.Stream) {
-			l.Warnf("unknown 'stream' field: %v", msg)
-			continue
-		}
-
 		if msg.List != "" {
 			// If a list is given, add this to
 			// the stream name as this is how we
 			// we track stream types internally.
 			msg.Stream += ":" + msg.List
+		} else if msg.Tag != "" {
+			// Same for a hashtag.
+			msg.Stream += ":" + msg.Tag
 		}
 
 		switch msg.Type {
 		case "subscribe":
-			stream.Subscribe(msg.Stream)
+			// Subscribe via processor, which checks the
+			// stream type is known (so a bad client can't
+			// cause extra memory allocations), and that
+			// the account is permitted to stream it.
+			errWithCode := m.processor.Stream().Subscribe(ctx,
+				account,
+				stream,
+				msg.Stream,
+			)
+			if errWithCode != nil {
+				l.Warnf("error subscribing to %s: %v", msg.Stream, errWithCode)
+			}
 		case "unsubscribe":
-			stream.Unsubscribe(msg.Stream)
+			m.processor.Stream().Unsubscribe(stream, msg.Stream)
 		default:
 			l.Warnf("invalid 'type' field: %v", msg)
 		}
@@ -385,11 +434,15 @@ func (m *Module) writeToWSConn(
 			l.Trace("writing websocket ping")
 
 			// Wrapped context time-out, send a keep-alive "ping".
-			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Time{}); err != nil {
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, m.deadline()); err != nil {
 				l.Debugf("error writing websocket ping: %v", err)
-				break
+				return
 			}
 
+			// Nothing
+			// else to write.
+			continue
+
 		case !ok:
 			// Stream was
 			// closed.
@@ -398,6 +451,17 @@ func (m *Module) writeToWSConn(
 
 		l.Trace("writing websocket message: %+v", msg)
 
+		if len(msg.Stream) == 1 {
+			// Give stream type in the form multiplexing
+			// clients expect, so they can tell which of
+			// their subscriptions this message is for.
+			msg.Stream = splitStreamType(msg.Stream[0])
+		}
+
+		// Don't block forever on a client
+		// that has stopped reading from conn.
+		_ = wsConn.SetWriteDeadline(m.deadline())
+
 		// Received a new message from the processor.
 		if err := wsConn.WriteJSON(msg); err != nil {
 			l.Debugf("error writing websocket message: %v", err)
@@ -407,3 +471,21 @@ func (m *Module) writeToWSConn(
 
 	l.Debug("finished websocket write")
 }
+
+// splitStreamType splits given stream type into its
+// name and any parameter (eg., a list ID or hashtag), as clients
+// expect to find in the 'stream' field of messages, eg.,
+// `list:01H3YF48G8B7KTPQFS8D2QBVG8` becomes
+// ["list", "01H3YF48G8B7KTPQFS8D2QBVG8"].
+func splitStreamType(streamType string) []string {
+	if listID, ok := strings.CutPrefix(streamType, streampkg.TimelineList+":"); ok {
+		return []string{streampkg.TimelineList, listID}
+	}
+	if tagName, ok := strings.CutPrefix(streamType, streampkg.TimelineHashtagLocal+":"); ok {
+		return []string{streampkg.TimelineHashtagLocal, tagName}
+	}
+	if tagName, ok := strings.CutPrefix(streamType, streampkg.TimelineHashtag+":"); ok {
+		return []string{streampkg.TimelineHashtag, tagName}
+	}
+	return []string{streamType}
+}
@@ -29,6 +29,8 @@ import (
 const (
 	BasePath            = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
 	StreamQueryKey      = "stream"                 // type of stream being requested
+	StreamTypeKey       = "stream_type"            // path param for type of stream being requested, for server-sent events
+	StreamSubTypeKey    = "stream_subtype"         // path param for sub type of stream being requested, for server-sent events
 	StreamListKey       = "list"                   // id of list being requested
 	StreamTagKey        = "tag"                    // name of tag being requested
 	AccessTokenQueryKey = "access_token"           // oauth access token
@@ -36,20 +38,25 @@ const (
 )
 
 type Module struct {
-	processor *processing.Processor
-	dTicker   time.Duration
-	wsUpgrade websocket.Upgrader
+	processor    *processing.Processor
+	pingInterval time.Duration
+	idleTimeout  time.Duration
+	wsUpgrade    websocket.Upgrader
 }
 
-func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Module {
+// New returns a new streaming module, which sends heartbeats over
+// quiet connections at pingInterval, and closes those that appear
+// dead for idleTimeout (<= 0 disables), with given websocket buffer size.
+func New(processor *processing.Processor, pingInterval time.Duration, idleTimeout time.Duration, wsBuf int) *Module {
 	// We expect CORS requests for websockets,
 	// (via eg., semaphore.social) so be lenient.
 	// TODO: make this customizable?
 	checkOrigin := func(r *http.Request) bool { return true }
 
 	return &Module{
-		processor: processor,
-		dTicker:   dTicker,
+		processor:    processor,
+		pingInterval: pingInterval,
+		idleTimeout:  idleTimeout,
 		wsUpgrade: websocket.Upgrader{
 			ReadBufferSize:  wsBuf,
 			WriteBufferSize: wsBuf,
@@ -60,4 +67,21 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
+
+	// Server-sent events streaming paths, as
+	// used by Mastodon, where the stream type is
+	// given in the path instead of the query, e.g.
+	// `/api/v1/streaming/public/local?only_media=false`.
+	attachHandler(http.MethodGet, BasePath+"/:"+StreamTypeKey, m.StreamGETHandler)
+	attachHandler(http.MethodGet, BasePath+"/:"+StreamTypeKey+"/:"+StreamSubTypeKey, m.StreamGETHandler)
+}
+
+// deadline returns the deadline for
+// connection activity starting now,
+// or zero time if timeouts disabled.
+func (m *Module) deadline() time.Time {
+	if m.idleTimeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(m.idleTimeout)
 }
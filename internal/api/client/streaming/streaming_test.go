@@ -19,6 +19,7 @@ package streaming_test
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -26,12 +27,15 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -110,7 +114,7 @@ func (suite *StreamingTestSuite) SetupTest() {
 	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
 	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
 	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
-	suite.streamingModule = streaming.New(suite.processor, 1, 4096)
+	suite.streamingModule = streaming.New(suite.processor, 1, 0, 4096)
 }
 
 func (suite *StreamingTestSuite) TearDownTest() {
@@ -245,6 +249,211 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+func (suite *StreamingTestSuite) TestServerSentEvents() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		token   = suite.testTokens["local_account_1"]
+		engine  = gin.New()
+	)
+
+	// Serve the streaming module over a real
+	// connection, as the response is hijacked.
+	suite.streamingModule.Route(engine.Group("/api").Handle)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	// Request a server-sent events stream, with
+	// stream type given in the path (as Mastodon).
+	rsp, err := http.Get(server.URL + "/api" + streaming.BasePath + "/user?access_token=" + token.Access)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer rsp.Body.Close()
+
+	suite.Equal(http.StatusOK, rsp.StatusCode)
+	suite.Equal("text/event-stream", rsp.Header.Get("Content-Type"))
+
+	// Stream an event to the account.
+	suite.processor.Stream().FiltersChanged(ctx, account)
+
+	// Read event lines from the stream, skipping
+	// over the keep-alive comments inbetween.
+	var lines []string
+	r := bufio.NewReader(rsp.Body)
+	for len(lines) < 2 {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || strings.HasPrefix(line, ":") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	suite.Equal([]string{
+		"event: filters_changed",
+		"data: undefined",
+	}, lines)
+}
+
+func (suite *StreamingTestSuite) TestWebsocketIdleTimeout() {
+	var (
+		token  = suite.testTokens["local_account_1"]
+		engine = gin.New()
+	)
+
+	// Ping often, and time out soon after.
+	module := streaming.New(suite.processor, 20*time.Millisecond, 200*time.Millisecond, 4096)
+	module.Route(engine.Group("/api").Handle)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api" + streaming.BasePath + "?stream=user&access_token=" + token.Access
+
+	// dial opens a websocket, reading from it in
+	// the background (which responds to pings) and
+	// returning a channel closed when conn closes.
+	dial := func(pong bool) (*websocket.Conn, chan struct{}) {
+		wsConn, rsp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		rsp.Body.Close()
+
+		if !pong {
+			// Ignore pings, like a dead client would.
+			wsConn.SetPingHandler(func(string) error { return nil })
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := wsConn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		return wsConn, closed
+	}
+
+	alive, aliveClosed := dial(true)
+	defer alive.Close()
+
+	dead, deadClosed := dial(false)
+	defer dead.Close()
+
+	// Connection not responding to
+	// pings should be closed by server.
+	select {
+	case <-deadClosed:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for idle connection to be closed")
+	}
+
+	// Whereas one responding to pings should
+	// be kept open beyond the idle timeout.
+	select {
+	case <-aliveClosed:
+		suite.FailNow("responsive connection was closed")
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func (suite *StreamingTestSuite) TestWebsocketMultiplexed() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		token   = suite.testTokens["local_account_1"]
+		listID  = "01H0G8E4Q2J3FE3JDWJVWEDCD1" // local_account_1_list_1
+		engine  = gin.New()
+	)
+
+	suite.streamingModule.Route(engine.Group("/api").Handle)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	// Open websocket without any initial stream type.
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api" + streaming.BasePath + "?access_token=" + token.Access
+	wsConn, rsp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer rsp.Body.Close()
+	defer wsConn.Close()
+
+	// Subscribe to home and list streams over the one connection.
+	for _, msg := range []string{
+		`{"type":"subscribe","stream":"user"}`,
+		`{"type":"subscribe","stream":"list","list":"` + listID + `"}`,
+	} {
+		if err := wsConn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Read messages from websocket in the background.
+	msgs := make(chan map[string]any)
+	go func() {
+		defer close(msgs)
+		for {
+			var msg map[string]any
+			if err := wsConn.ReadJSON(&msg); err != nil {
+				return
+			}
+			msgs <- msg
+		}
+	}()
+
+	// Subscriptions are handled asynchronously, so keep
+	// posting to each stream until message is received.
+	for _, test := range []struct {
+		post   func()
+		stream []any
+	}{
+		{
+			post: func() {
+				suite.processor.Stream().Update(ctx, account, &apimodel.Status{ID: "01J1SE5MC8D4SJPZAS5TKQQGNZ"}, "list:"+listID)
+			},
+			stream: []any{"list", listID},
+		},
+		{
+			post: func() {
+				suite.processor.Stream().FiltersChanged(ctx, account)
+			},
+			stream: []any{"user"},
+		},
+	} {
+		var received bool
+		for i := 0; i < 100 && !received; i++ {
+			test.post()
+
+			select {
+			case msg := <-msgs:
+				received = suite.Equal(test.stream, msg["stream"])
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+
+		if !received {
+			suite.FailNow("", "expected a message on %v", test.stream)
+		}
+
+		// Drain any duplicates.
+		for drained := false; !drained; {
+			select {
+			case <-msgs:
+			case <-time.After(100 * time.Millisecond):
+				drained = true
+			}
+		}
+	}
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -79,11 +80,19 @@ func (m *Module) ServeFile(c *gin.Context) {
 	// Acquire context from gin request.
 	ctx := c.Request.Context()
 
+	// Served content may depend on whether
+	// a WebP variant is acceptable to caller.
+	webp := config.GetMediaDerivedFormat() == config.MediaDerivedFormatWebP
+	if webp {
+		c.Header("Vary", "Accept")
+	}
+
 	content, errWithCode := m.processor.Media().GetFile(ctx, authed.Account, &apimodel.GetContentRequestForm{
-		AccountID: accountID,
-		MediaType: mediaType,
-		MediaSize: mediaSize,
-		FileName:  fileName,
+		AccountID:  accountID,
+		MediaType:  mediaType,
+		MediaSize:  mediaSize,
+		FileName:   fileName,
+		AcceptWebP: webp && acceptsWebP(c),
 	})
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -142,6 +151,36 @@ func (m *Module) ServeFile(c *gin.Context) {
 	)
 }
 
+// acceptsWebP returns whether the request Accept header(s) explicitly
+// list image/webp as acceptable, i.e. without a quality value of zero.
+// Wildcards are deliberately ignored, as many clients send "*/*"
+// without actually supporting every image format under the sun.
+func acceptsWebP(c *gin.Context) bool {
+	for _, hdr := range c.Request.Header.Values("Accept") {
+		for _, part := range strings.Split(hdr, ",") {
+			mime, params, _ := strings.Cut(part, ";")
+			if !strings.EqualFold(strings.TrimSpace(mime), "image/webp") {
+				continue
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				k, v, _ := strings.Cut(param, "=")
+				if strings.TrimSpace(k) != "q" {
+					continue
+				}
+
+				if q, err := strconv.ParseFloat(strings.TrimSpace(v), 32); err == nil && q == 0 {
+					// Explicitly not acceptable.
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+	return false
+}
+
 // serveFileRange serves the range of a file from a given source reader, without the
 // need for implementation of io.Seeker. Instead we read the first 'start' many bytes
 // into a discard reader. Code is adapted from https://codeberg.org/gruf/simplehttp.
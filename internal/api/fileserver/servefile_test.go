@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/fileserver"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -44,12 +45,23 @@ func (suite *ServeFileTestSuite) GetFile(
 	mediaType media.Type,
 	mediaSize media.Size,
 	filename string,
+) (code int, headers http.Header, body []byte) {
+	return suite.GetFileAccept("*/*", accountID, mediaType, mediaSize, filename)
+}
+
+// GetFileAccept is like GetFile, but with the given accept header.
+func (suite *ServeFileTestSuite) GetFileAccept(
+	accept string,
+	accountID string,
+	mediaType media.Type,
+	mediaSize media.Size,
+	filename string,
 ) (code int, headers http.Header, body []byte) {
 	recorder := httptest.NewRecorder()
 
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
 	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/whatever", nil)
-	ctx.Request.Header.Set("accept", "*/*")
+	ctx.Request.Header.Set("accept", accept)
 	ctx.AddParam(fileserver.AccountIDKey, accountID)
 	ctx.AddParam(fileserver.MediaTypeKey, string(mediaType))
 	ctx.AddParam(fileserver.MediaSizeKey, string(mediaSize))
@@ -130,6 +142,63 @@ func (suite *ServeFileTestSuite) TestServeSmallLocalFileOK() {
 	suite.Equal(fileInStorage, body)
 }
 
+func (suite *ServeFileTestSuite) TestServeSmallLocalFileWebPVariant() {
+	config.SetMediaDerivedFormat(config.MediaDerivedFormatWebP)
+	defer config.SetMediaDerivedFormat(config.MediaDerivedFormatDisabled)
+
+	targetAttachment := &gtsmodel.MediaAttachment{}
+	*targetAttachment = *suite.testAttachments["admin_account_status_1_attachment_1"]
+	fileInStorage, err := suite.storage.Get(context.Background(), targetAttachment.Thumbnail.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Store a webp variant alongside the thumbnail.
+	webpPath := media.WebPVariantPath(targetAttachment.Thumbnail.Path)
+	webpInStorage := []byte("webp variant")
+	if _, err := suite.storage.Put(context.Background(), webpPath, webpInStorage); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer func() {
+		if err := suite.storage.Delete(context.Background(), webpPath); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}()
+
+	// Callers explicitly accepting webp get the variant.
+	code, headers, body := suite.GetFileAccept(
+		"image/webp,*/*",
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeSmall,
+		targetAttachment.ID+".jpg",
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("image/webp", headers.Get("content-type"))
+	suite.Equal("Accept", headers.Get("vary"))
+	suite.Equal(webpInStorage, body)
+
+	// Anyone else gets the original thumbnail.
+	for _, accept := range []string{
+		"*/*",
+		"image/webp;q=0,*/*",
+	} {
+		code, headers, body = suite.GetFileAccept(
+			accept,
+			targetAttachment.AccountID,
+			media.TypeAttachment,
+			media.SizeSmall,
+			targetAttachment.ID+".jpg",
+		)
+
+		suite.Equal(http.StatusOK, code)
+		suite.Equal("image/jpeg", headers.Get("content-type"))
+		suite.Equal("Accept", headers.Get("vary"))
+		suite.Equal(fileInStorage, body)
+	}
+}
+
 func (suite *ServeFileTestSuite) TestServeOriginalRemoteFileOK() {
 	targetAttachment := &gtsmodel.MediaAttachment{}
 	*targetAttachment = *suite.testAttachments["remote_account_1_status_1_attachment_1"]
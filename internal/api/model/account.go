@@ -148,6 +148,10 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code allowing sign-up while registrations are closed.
+	// swagger:parameters
+	// example: SZnV7r2Q
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -202,6 +206,18 @@ type UpdateSource struct {
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Default boostable flag for authored statuses.
+	Boostable *bool `form:"boostable" json:"boostable"`
+	// Default replyable flag for authored statuses.
+	Replyable *bool `form:"replyable" json:"replyable"`
+	// Default likeable flag for authored statuses.
+	Likeable *bool `form:"likeable" json:"likeable"`
+	// Delete authored public statuses once older than this many days (0 = never).
+	StatusExpiryDays *int `form:"status_expiry_days" json:"status_expiry_days"`
+	// Keep pinned statuses when deleting expired statuses.
+	StatusExpiryKeepPinned *bool `form:"status_expiry_keep_pinned" json:"status_expiry_keep_pinned"`
+	// Keep self-faved statuses when deleting expired statuses.
+	StatusExpiryKeepFaved *bool `form:"status_expiry_keep_faved" json:"status_expiry_keep_faved"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -228,6 +244,10 @@ type AccountFollowRequest struct {
 	Reblogs *bool `form:"reblogs" json:"reblogs" xml:"reblogs"`
 	// Notify when this account posts.
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
+	// Only show posts from this account in these
+	// languages (ISO 639-1) in home / list timelines.
+	// Provide an empty value to show all languages.
+	Languages []string `form:"languages[]" json:"languages" xml:"languages"`
 }
 
 // AccountDeleteRequest models a request to delete an account.
@@ -255,6 +275,50 @@ type AccountAliasRequest struct {
 	AlsoKnownAsURIs []string `form:"also_known_as_uris" json:"also_known_as_uris" xml:"also_known_as_uris"`
 }
 
+// AccountMigration models the alsoKnownAs
+// aliases and Move state of an account.
+//
+// swagger:model accountMigration
+type AccountMigration struct {
+	// ActivityPub URIs of any accounts that this one is aliased to.
+	AlsoKnownAsURIs []string `json:"also_known_as_uris"`
+	// ActivityPub URI of the account that this one has Moved, or is Moving, to.
+	MovedToURI string `json:"moved_to_uri,omitempty"`
+	// Account that this one can be Moved to.
+	// Only set in response to a migration check.
+	Target *Account `json:"target,omitempty"`
+}
+
+// AccountMigrationRequest models a request to check
+// whether an account can be Moved to another account.
+//
+// swagger:ignore
+type AccountMigrationRequest struct {
+	// ActivityPub URI of the account to check.
+	MovedToURI string `form:"moved_to_uri" json:"moved_to_uri" xml:"moved_to_uri"`
+}
+
+// AccountExport models the state of an
+// archive of an account's data being
+// generated, or available for download.
+//
+// swagger:model accountExport
+type AccountExport struct {
+	// State of the export.
+	// One of "in_progress", "complete", or "failed".
+	State string `json:"state"`
+	// Progress of the export, as a percentage.
+	Progress int `json:"progress"`
+	// When the export was started (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the export was completed (ISO 8601 Datetime).
+	// Only set once no longer in progress.
+	CompletedAt string `json:"completed_at,omitempty"`
+	// Until when the completed archive
+	// can be downloaded (ISO 8601 Datetime).
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
 // AccountRole models the role of an account.
 //
 // swagger:model accountRole
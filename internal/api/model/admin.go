@@ -17,6 +17,8 @@
 
 package model
 
+import "mime/multipart"
+
 // AdminAccountInfo models the admin view of an account's details.
 //
 // swagger:model adminAccountInfo
@@ -124,6 +126,11 @@ type AdminReport struct {
 	// Will be null if not set / no action yet taken.
 	// example: Account was suspended.
 	ActionTakenComment *string `json:"action_taken_comment"`
+	// If the report was resolved, under which category was it resolved?
+	// One of: no_action, actioned, duplicate, other.
+	// Will be null if not set / no action yet taken.
+	// example: actioned
+	ResolutionCategory *string `json:"resolution_category"`
 }
 
 // AdminReportResolveRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/resolve
@@ -132,6 +139,9 @@ type AdminReport struct {
 type AdminReportResolveRequest struct {
 	// Comment to show to the creator of the report when an admin marks it as resolved.
 	ActionTakenComment *string `form:"action_taken_comment" json:"action_taken_comment" xml:"action_taken_comment"`
+	// Category of the outcome of this report.
+	// One of: no_action, actioned, duplicate, other.
+	ResolutionCategory *string `form:"resolution_category" json:"resolution_category" xml:"resolution_category"`
 }
 
 // AdminEmoji models the admin view of a custom emoji.
@@ -213,6 +223,163 @@ type AdminInstanceRule struct {
 	Text      string `json:"text"`       // text content of the rule
 }
 
+// AdminEmailDomainBlock models a blocked email domain.
+//
+// swagger:model adminEmailDomainBlock
+type AdminEmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The blocked email domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Time at which the block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this email domain block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminEmailDomainBlockCreateRequest models
+// a request to block a new email domain.
+//
+// swagger:ignore
+type AdminEmailDomainBlockCreateRequest struct {
+	// The email domain to block.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
+
+// AdminCanonicalEmailBlock models a block on
+// sign-ups using a canonicalized email hash.
+//
+// swagger:model adminCanonicalEmailBlock
+type AdminCanonicalEmailBlock struct {
+	// The ID of the canonical email block.
+	// Omitted for exports.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id,omitempty"`
+	// Hex-encoded SHA256 hash of the canonicalized, blocked email address.
+	// example: b344e55d11b3fc25d0d53194e0475838bf17e9be67ce3e6469956222d9a34f9c
+	CanonicalEmailHash string `json:"canonical_email_hash"`
+	// Time at which the block was created (ISO 8601 Datetime).
+	// Omitted for exports.
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID of the account that created this canonical email block.
+	// Omitted for exports.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// AdminCanonicalEmailBlockCreateRequest models a request
+// to create one canonical email block, or import many.
+//
+// swagger:ignore
+type AdminCanonicalEmailBlockCreateRequest struct {
+	// Email address to canonicalize, hash and block.
+	Email string `form:"email" json:"email" xml:"email"`
+	// Hash of an already canonicalized email address to block.
+	CanonicalEmailHash string `form:"canonical_email_hash" json:"canonical_email_hash" xml:"canonical_email_hash"`
+	// JSON-formatted list of canonical email blocks to import.
+	Hashes *multipart.FileHeader `form:"hashes" json:"-" xml:"-"`
+}
+
+// AdminCanonicalEmailBlockTestRequest models a request to
+// test which canonical email blocks match an email address.
+//
+// swagger:ignore
+type AdminCanonicalEmailBlockTestRequest struct {
+	// Email address to test.
+	Email string `form:"email" json:"email" xml:"email"`
+}
+
+// AdminIPBlock models a blocked IP address or range.
+//
+// swagger:model adminIPBlock
+type AdminIPBlock struct {
+	// The ID of the IP block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The blocked IP range in CIDR notation.
+	// example: 192.0.2.0/24
+	IP string `json:"ip"`
+	// Severity of the block.
+	//
+	// `sign_up_block`: new sign-ups from this IP range are blocked.
+	// `no_access`: all requests from this IP range are blocked.
+	// example: no_access
+	Severity string `json:"severity"`
+	// Private comment about this block, viewable to admins.
+	// example: spam wave
+	Comment string `json:"comment"`
+	// Time at which the block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this IP block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminIPBlockCreateRequest models
+// a request to block a new IP range.
+//
+// swagger:ignore
+type AdminIPBlockCreateRequest struct {
+	// The IP address or range to block.
+	IP string `form:"ip" json:"ip" xml:"ip"`
+	// Severity of the block.
+	Severity string `form:"severity" json:"severity" xml:"severity"`
+	// Private comment about this block.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+}
+
+// AdminDomainMediaPolicy models a per-domain
+// override of remote media caching behaviour.
+//
+// swagger:model adminDomainMediaPolicy
+type AdminDomainMediaPolicy struct {
+	// The ID of the domain media policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The domain this policy applies to,
+	// including all of its subdomains.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Never cache media from this domain,
+	// redirect requests to the remote instead.
+	// example: false
+	NeverCache bool `json:"never_cache"`
+	// Uncache media from this domain after this many days.
+	// 0 means the instance default is used.
+	// example: 7
+	RemoteCacheDays int `json:"remote_cache_days"`
+	// Private comment on this policy, viewable to admins.
+	// example: huge media files
+	PrivateComment string `json:"private_comment"`
+	// Time at which the policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminDomainMediaPolicyCreateRequest models a
+// request to create a new domain media policy.
+//
+// swagger:ignore
+type AdminDomainMediaPolicyCreateRequest struct {
+	// The domain to create the policy for.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// Never cache media from this domain.
+	NeverCache bool `form:"never_cache" json:"never_cache" xml:"never_cache"`
+	// Uncache media from this domain after this many days.
+	RemoteCacheDays int `form:"remote_cache_days" json:"remote_cache_days" xml:"remote_cache_days"`
+	// Private comment on this policy.
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
+
 // DebugAPUrlResponse provides detailed debug
 // information for an AP URL dereference request.
 //
@@ -19,7 +19,7 @@ package model
 
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -60,3 +60,20 @@ type Announcement struct {
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementCreateRequest models a request to create
+// or update an instance announcement via the admin API.
+//
+// swagger:ignore
+type AnnouncementCreateRequest struct {
+	// Text of the announcement, formatted as markdown.
+	Text string `form:"text" json:"text" xml:"text"`
+	// When the announcement should begin to be displayed (ISO 8601 Datetime).
+	// If not set, the announcement will be displayed immediately.
+	StartsAt string `form:"starts_at" json:"starts_at" xml:"starts_at"`
+	// When the announcement should stop being displayed (ISO 8601 Datetime).
+	// If not set, the announcement will be displayed until deleted.
+	EndsAt string `form:"ends_at" json:"ends_at" xml:"ends_at"`
+	// Announcement doesn't have begin time and end time, but begin day and end day.
+	AllDay bool `form:"all_day" json:"all_day" xml:"all_day"`
+}
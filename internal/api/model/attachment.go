@@ -49,6 +49,40 @@ type AttachmentUpdateRequest struct {
 	Focus *string `form:"focus" json:"focus" xml:"focus"`
 }
 
+// MediaUploadRequest models chunked media upload session creation parameters.
+//
+// swagger:ignore
+type MediaUploadRequest struct {
+	// Total size in bytes of the media file to be uploaded.
+	Size int64 `form:"size" json:"size" xml:"size"`
+	// Description of the media file. Optional.
+	// This will be used as alt-text for users of screenreaders etc.
+	Description string `form:"description" json:"description" xml:"description"`
+	// Focus of the media file. Optional.
+	// If present, it should be in the form of two comma-separated floats between -1 and 1.
+	Focus string `form:"focus" json:"focus" xml:"focus"`
+}
+
+// MediaUpload models an in-progress chunked media upload session.
+//
+// swagger:model mediaUpload
+type MediaUpload struct {
+	// The ID of the upload session.
+	// example: 01FC31DZT1AYWDZ8XTCRWRBYRK
+	ID string `json:"id"`
+	// Total size in bytes of the media file being uploaded.
+	// example: 104857600
+	Size int64 `json:"size"`
+	// Number of bytes received so far. The next
+	// chunk of the file should start at this offset.
+	// example: 5242880
+	Offset int64 `json:"offset"`
+	// Time at which the upload session will expire
+	// and be discarded, unless more data is received (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt string `json:"expires_at"`
+}
+
 // Attachment models a media attachment.
 //
 // swagger:model attachment
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminCache models the usage statistics and
+// capacity of one of the instance's database caches.
+//
+// swagger:model adminCache
+type AdminCache struct {
+	// Name of the cache, as used in its cache-*-mem-ratio configuration option.
+	// example: account
+	Name string `json:"name"`
+	// Number of lookups served directly from the cache since startup.
+	// example: 10204
+	Hits uint64 `json:"hits"`
+	// Number of lookups that required loading from the database since startup.
+	// example: 1337
+	Misses uint64 `json:"misses"`
+	// Fraction of lookups served directly from the cache, between 0 and 1.
+	// example: 0.88
+	HitRatio float64 `json:"hit_ratio"`
+	// Number of items dropped to make room for others since startup.
+	// example: 12
+	Evictions uint64 `json:"evictions"`
+	// Current number of cached items.
+	// example: 420
+	Size int `json:"size"`
+	// Maximum number of cached items.
+	// example: 2048
+	Capacity int `json:"capacity"`
+	// Minimum capacity this cache may be resized to.
+	// example: 204
+	MinCapacity int `json:"min_capacity"`
+	// Maximum capacity this cache may be resized to.
+	// example: 20480
+	MaxCapacity int `json:"max_capacity"`
+}
+
+// AdminCacheUpdateRequest models a
+// request to resize a database cache.
+//
+// swagger:ignore
+type AdminCacheUpdateRequest struct {
+	Capacity int `form:"capacity" json:"capacity" xml:"capacity"`
+}
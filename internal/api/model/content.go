@@ -48,4 +48,7 @@ type GetContentRequestForm struct {
 	MediaSize string
 	// Filename of the content
 	FileName string
+	// AcceptWebP indicates the caller accepts a WebP
+	// variant of derived (small / static) content.
+	AcceptWebP bool
 }
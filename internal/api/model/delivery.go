@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DeliveryPausedHost models a destination host to which
+// outgoing deliveries are currently paused, as it has
+// been consistently failing to receive them.
+//
+// swagger:model deliveryPausedHost
+type DeliveryPausedHost struct {
+	// Host to which deliveries are paused.
+	// example: example.org
+	Host string `json:"host"`
+	// Number of consecutive failed deliveries to host.
+	// example: 42
+	Failures int `json:"failures"`
+	// Time of the first of these failed deliveries (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FailingSince string `json:"failing_since"`
+	// Time at which the next probe delivery to host will be allowed (ISO 8601 Datetime).
+	// example: 2021-07-31T09:20:25+00:00
+	NextProbeAt string `json:"next_probe_at"`
+}
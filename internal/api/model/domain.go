@@ -52,6 +52,10 @@ type DomainPermission struct {
 	// Private comment for this permission entry, visible to this instance's admins only.
 	// example: they are poopoo
 	PrivateComment string `json:"private_comment,omitempty"`
+	// Severity of this domain permission entry, if it's a block.
+	// One of: suspend, silence.
+	// example: suspend
+	Severity string `json:"severity,omitempty"`
 	// If applicable, the ID of the subscription that caused this domain permission entry to be created.
 	// example: 01FBW25TF5J67JW3HFHZCSD23K
 	SubscriptionID string `json:"subscription_id,omitempty"`
@@ -85,6 +89,10 @@ type DomainPermissionRequest struct {
 	// Will be visible to requesters at /api/v1/instance/peers if this endpoint is exposed.
 	// example: foss dorks 😫
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+	// Severity of the domain block; one of suspend, silence.
+	// Only used for blocks. If not set, suspend is assumed.
+	// example: silence
+	Severity string `form:"severity" json:"severity" xml:"severity"`
 }
 
 // DomainKeysExpireRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
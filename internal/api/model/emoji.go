@@ -78,3 +78,34 @@ const (
 	EmojiUpdateDisable EmojiUpdateType = "disable" // disable remote emoji
 	EmojiUpdateCopy    EmojiUpdateType = "copy"    // copy remote emoji -> local
 )
+
+// EmojiPackImportRequest represents a request to import
+// a pack of custom emojis, made through the admin API.
+//
+// swagger:ignore
+type EmojiPackImportRequest struct {
+	// Zip file of the emoji pack to import.
+	Pack *multipart.FileHeader `form:"pack" validation:"required"`
+	// Category in which to place all imported emojis,
+	// overriding any categories given in the pack.
+	CategoryName string `form:"category"`
+	// How to handle emojis whose shortcode is already
+	// in use. One of skip (default), replace, rename.
+	OnConflict string `form:"on_conflict"`
+}
+
+// EmojiPackImportResult represents the outcome of importing a pack of custom emojis.
+//
+// swagger:model emojiPackImportResult
+type EmojiPackImportResult struct {
+	// Shortcodes of newly imported emojis.
+	// Emojis renamed due to a shortcode conflict are listed under their new shortcode.
+	// example: ["blobcat_uwu","blobcat_uwu_2"]
+	Imported []string `json:"imported"`
+	// Shortcodes of existing emojis whose image was replaced.
+	Replaced []string `json:"replaced"`
+	// Shortcodes of emojis skipped as their shortcode was already in use.
+	Skipped []string `json:"skipped"`
+	// Shortcodes of emojis which could not be imported.
+	Failed []string `json:"failed"`
+}
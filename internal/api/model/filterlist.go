@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// FilterListVersion is the current version
+// of the filter list document format.
+const FilterListVersion = 1
+
+// FilterList is a portable document containing a set of v2 filters,
+// which can be exported by one account and imported by another.
+// Expiry and filtered statuses are instance / time specific, so
+// only titles, contexts, actions and keywords are included.
+//
+// swagger:model filterList
+//
+// ---
+// tags:
+// - filters
+type FilterList struct {
+	// Version of the filter list document format.
+	//
+	// Example: 1
+	Version int `json:"version"`
+	// Optional name of the filter list as a whole.
+	//
+	// Example: Spoilers
+	Title string `json:"title,omitempty"`
+	// The filters in this list.
+	Filters []FilterListFilter `json:"filters"`
+}
+
+// FilterListFilter is a single filter within a filter list document.
+//
+// swagger:model filterListFilter
+//
+// ---
+// tags:
+// - filters
+type FilterListFilter struct {
+	// The name of the filter.
+	//
+	// Example: Linux Words
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	//
+	// Example: ["home", "public"]
+	Context []FilterContext `json:"context"`
+	// The action to be taken when a status matches this filter.
+	//
+	// Example: warn
+	FilterAction FilterAction `json:"filter_action"`
+	// The keywords grouped under this filter.
+	Keywords []FilterListKeyword `json:"keywords"`
+}
+
+// FilterListKeyword is a single keyword within a filter list document.
+//
+// swagger:model filterListKeyword
+//
+// ---
+// tags:
+// - filters
+type FilterListKeyword struct {
+	// The text to be filtered.
+	//
+	// Example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter keyword consider word boundaries?
+	//
+	// Example: true
+	WholeWord bool `json:"whole_word"`
+	// Should the keyword be interpreted as a regular expression?
+	//
+	// Example: false
+	Regex bool `json:"regex"`
+}
+
+// FilterListImportRequest captures params for importing a filter list.
+//
+// swagger:ignore
+type FilterListImportRequest struct {
+	// URL to fetch a filter list document from.
+	// Exactly one of URL, List or ListFile must be provided.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Filter list document to import directly.
+	List *FilterList `form:"-" json:"list" xml:"list"`
+	// Filter list document to import, as a file upload.
+	ListFile *multipart.FileHeader `form:"list" json:"-" xml:"-"`
+	// Subscribe to the filter list at URL, so that imported
+	// filters are periodically re-synced from it.
+	Subscribe bool `form:"subscribe" json:"subscribe" xml:"subscribe"`
+}
+
+// FilterSubscription represents a subscription to a filter list URL.
+//
+// swagger:model filterSubscription
+//
+// ---
+// tags:
+// - filters
+type FilterSubscription struct {
+	// The ID of the filter subscription in the database.
+	ID string `json:"id"`
+	// URL of the subscribed filter list.
+	//
+	// Example: https://example.org/filters/spoilers.json
+	URL string `json:"url"`
+	// Title of the filter list, as of the last sync.
+	//
+	// Example: Spoilers
+	Title string `json:"title,omitempty"`
+	// When the subscription was created (ISO 8601 Datetime).
+	//
+	// Example: 2024-02-01T02:57:49.000Z
+	CreatedAt string `json:"created_at"`
+	// When the filter list was last successfully synced (ISO 8601 Datetime).
+	// Null if it has never been synced.
+	//
+	// Example: 2024-02-01T02:57:49.000Z
+	SyncedAt *string `json:"synced_at"`
+	// Error encountered on the last sync attempt, if it failed.
+	Error string `json:"error,omitempty"`
+	// IDs of the filters managed by this subscription.
+	FilterIDs []string `json:"filter_ids"`
+}
+
+// FilterListImportResult is the result of importing a filter list.
+//
+// swagger:model filterListImportResult
+//
+// ---
+// tags:
+// - filters
+type FilterListImportResult struct {
+	// Filters created by the import.
+	Filters []FilterV2 `json:"filters"`
+	// Subscription created by the import, if requested.
+	Subscription *FilterSubscription `json:"subscription,omitempty"`
+}
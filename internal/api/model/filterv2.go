@@ -57,6 +57,9 @@ type FilterV2 struct {
 	Keywords []FilterKeyword `json:"keywords"`
 	// The statuses grouped under this filter.
 	Statuses []FilterStatus `json:"statuses"`
+	// ID of the filter subscription this filter was imported from, if any.
+	// Changes made to such a filter are overwritten on the next sync.
+	SubscriptionID string `json:"subscription_id,omitempty"`
 }
 
 // FilterAction is the action to apply to statuses matching a filter.
@@ -89,6 +92,10 @@ type FilterKeyword struct {
 	//
 	// Example: true
 	WholeWord bool `json:"whole_word"`
+	// Should the keyword be interpreted as a regular expression?
+	//
+	// Example: false
+	Regex bool `json:"regex"`
 }
 
 // FilterStatus represents a single status to filter within a v2 filter.
@@ -142,6 +149,8 @@ type FilterCreateRequestV2 struct {
 	KeywordsAttributesKeyword []string `form:"keywords_attributes[][keyword]" json:"-" xml:"-"`
 	// Form data version of Keywords[].WholeWord.
 	KeywordsAttributesWholeWord []bool `form:"keywords_attributes[][whole_word]" json:"-" xml:"-"`
+	// Form data version of Keywords[].Regex.
+	KeywordsAttributesRegex []bool `form:"keywords_attributes[][regex]" json:"-" xml:"-"`
 
 	// Statuses to be added to the newly created filter.
 	Statuses []FilterStatusCreateRequest `form:"-" json:"statuses_attributes" xml:"statuses_attributes"`
@@ -162,6 +171,10 @@ type FilterKeywordCreateUpdateRequest struct {
 	//
 	// Example: true
 	WholeWord *bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// Should the keyword be interpreted as a regular expression?
+	//
+	// Example: false
+	Regex *bool `form:"regex" json:"regex" xml:"regex"`
 }
 
 // FilterStatusCreateRequest captures params for a status while creating a v2 filter or filter status.
@@ -209,6 +222,8 @@ type FilterUpdateRequestV2 struct {
 	KeywordsAttributesKeyword []string `form:"keywords_attributes[][keyword]" json:"-" xml:"-"`
 	// Form data version of Keywords[].WholeWord.
 	KeywordsAttributesWholeWord []bool `form:"keywords_attributes[][whole_word]" json:"-" xml:"-"`
+	// Form data version of Keywords[].Regex.
+	KeywordsAttributesRegex []bool `form:"keywords_attributes[][regex]" json:"-" xml:"-"`
 	// Form data version of Keywords[].Destroy.
 	KeywordsAttributesDestroy []bool `form:"keywords_attributes[][_destroy]" json:"-" xml:"-"`
 
@@ -238,6 +253,10 @@ type FilterKeywordCreateUpdateDeleteRequest struct {
 	//
 	// Example: true
 	WholeWord *bool `json:"whole_word" xml:"whole_word"`
+	// Should the keyword be interpreted as a regular expression?
+	//
+	// Example: false
+	Regex *bool `json:"regex" xml:"regex"`
 	// Remove this filter keyword. Requires an ID.
 	Destroy *bool `json:"_destroy" xml:"_destroy"`
 }
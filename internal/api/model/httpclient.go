@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// HTTPClientRanges models the IP ranges explicitly
+// allowed / blocked for outgoing HTTP requests.
+//
+// swagger:model httpClientRanges
+type HTTPClientRanges struct {
+	// IP ranges (CIDR) explicitly allowed to be dialed,
+	// overriding both blocked and reserved IP ranges.
+	// example: ["192.168.1.0/24"]
+	AllowIPs []string `json:"allow_ips"`
+	// IP ranges (CIDR) explicitly blocked from being dialed.
+	// example: ["203.0.113.0/24"]
+	BlockIPs []string `json:"block_ips"`
+}
+
+// HTTPClientRangesRequest models a request
+// to update the allowed / blocked IP ranges.
+//
+// swagger:ignore
+type HTTPClientRangesRequest struct {
+	AllowIPs []string `form:"allow_ips[]" json:"allow_ips" xml:"allow_ips"`
+	BlockIPs []string `form:"block_ips[]" json:"block_ips" xml:"block_ips"`
+}
+
+// HTTPClientDialCheck models the result of checking whether
+// a host would be dialable under current IP range rules.
+//
+// swagger:model httpClientDialCheck
+type HTTPClientDialCheck struct {
+	// Host that was checked.
+	// example: example.org
+	Host string `json:"host"`
+	// Whether all addresses of the host are dialable.
+	// example: true
+	Dialable bool `json:"dialable"`
+	// Results of checking each address the host resolved to.
+	Addresses []HTTPClientDialCheckAddress `json:"addresses"`
+}
+
+// HTTPClientDialCheckAddress models the result
+// of checking whether an IP address is dialable.
+//
+// swagger:model httpClientDialCheckAddress
+type HTTPClientDialCheckAddress struct {
+	// IP address that was checked.
+	// example: 198.51.100.1
+	IP string `json:"ip"`
+	// Whether this IP address is dialable.
+	// example: false
+	Dialable bool `json:"dialable"`
+	// Reason for IP address (not) being dialable.
+	// One of: allowed, blocked, reserved, public.
+	// example: reserved
+	Reason string `json:"reason"`
+	// The configured allow / block IP range that
+	// was matched by this IP address, if any.
+	// example: 198.51.100.0/24
+	Range string `json:"range,omitempty"`
+}
+
+// HTTPClientHostStats models statistics of
+// outgoing HTTP requests made to one host.
+//
+// swagger:model httpClientHostStats
+type HTTPClientHostStats struct {
+	// Host requests were made to.
+	// example: example.org
+	Host string `json:"host"`
+	// Number of requests made to host.
+	// example: 120
+	Requests uint64 `json:"requests"`
+	// Number of requests that failed without any
+	// response, e.g. connection refused or timeout.
+	// example: 3
+	Errors uint64 `json:"errors"`
+	// Number of responses by status class.
+	// example: {"2xx": 110, "4xx": 2, "5xx": 5}
+	Statuses map[string]uint64 `json:"statuses"`
+	// Average time in milliseconds spent
+	// waiting on response headers.
+	// example: 250
+	AvgLatencyMS int64 `json:"avg_latency_ms"`
+	// Total request body bytes sent.
+	// example: 524288
+	BytesSent uint64 `json:"bytes_sent"`
+	// Total response body bytes received.
+	// example: 1048576
+	BytesReceived uint64 `json:"bytes_received"`
+}
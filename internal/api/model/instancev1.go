@@ -109,6 +109,7 @@ type InstanceV1 struct {
 // swagger:model instanceV1URLs
 type InstanceV1URLs struct {
 	// Websockets address for status and notification streaming.
+	// Uses ws:// scheme if instance is served over http, else wss://.
 	// example: wss://example.org
 	StreamingAPI string `json:"streaming_api"`
 }
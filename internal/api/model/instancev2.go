@@ -86,8 +86,8 @@ type InstanceV2Usage struct {
 // swagger:model instanceV2Users
 type InstanceV2Users struct {
 	// The number of active users in the past 4 weeks.
-	// Currently not implemented: will always be 0.
-	// example: 0
+	// Users are considered active if they have posted a status in that time.
+	// example: 5
 	ActiveMonth int `json:"active_month"`
 }
 
@@ -132,6 +132,7 @@ type InstanceV2ThumbnailVersions struct {
 // swagger:model instanceV2URLs
 type InstanceV2URLs struct {
 	// Websockets address for status and notification streaming.
+	// Uses ws:// scheme if instance is served over http, else wss://.
 	// example: wss://example.org
 	Streaming string `json:"streaming"`
 }
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite models an invite code that can be
+// used to sign up to this instance, even if
+// registrations are otherwise closed.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Code to be entered on the sign-up form.
+	// example: SZnV7r2Q
+	Code string `json:"code"`
+	// Time at which the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this invite.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Number of sign-ups this invite may be used for.
+	// Null means unlimited.
+	// example: 10
+	MaxUses *int `json:"max_uses"`
+	// Number of sign-ups this invite has been used for so far.
+	// example: 2
+	Uses int `json:"uses"`
+	// Time after which the invite can no longer be used (ISO 8601 Datetime).
+	// Null means the invite never expires.
+	// example: 2021-08-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Invite has expired, been revoked, or reached its max uses.
+	Expired bool `json:"expired"`
+}
+
+// InviteCreateRequest models a request to create an invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Number of sign-ups the invite may be used for.
+	// 0 means unlimited.
+	MaxUses int `form:"max_uses" json:"max_uses" xml:"max_uses"`
+	// Number of seconds from now after which the invite expires.
+	// 0 means the invite never expires.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
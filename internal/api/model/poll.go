@@ -36,6 +36,11 @@ type Poll struct {
 	// Does the poll allow multiple-choice answers?
 	Multiple bool `json:"multiple"`
 
+	// Does the poll allow voters to change their vote before it ends?
+	//
+	// Omitted when false.
+	VoteChange bool `json:"vote_change,omitempty"`
+
 	// How many votes have been received.
 	VotesCount int `json:"votes_count"`
 
@@ -93,6 +98,9 @@ type PollRequest struct {
 
 	// Hide vote counts until the poll ends.
 	HideTotals bool `form:"poll[hide_totals]" json:"hide_totals" xml:"hide_totals"`
+
+	// Allow voters to change their vote until the poll ends.
+	VoteChange bool `form:"poll[vote_change]" json:"vote_change" xml:"vote_change"`
 }
 
 // PollVoteRequest models a request to vote in a poll.
@@ -30,6 +30,8 @@ type Relationship struct {
 	ShowingReblogs bool `json:"showing_reblogs"`
 	// You are seeing notifications when this account posts.
 	Notifying bool `json:"notifying"`
+	// Languages you're seeing posts from this account in, if filtered.
+	Languages []string `json:"languages,omitempty"`
 	// This account follows you.
 	FollowedBy bool `json:"followed_by"`
 	// You are blocking this account.
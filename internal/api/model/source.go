@@ -32,6 +32,23 @@ type Source struct {
 	Language string `json:"language"`
 	// The default posting content type for new statuses.
 	StatusContentType string `json:"status_content_type"`
+	// Whether new statuses can be boosted by default,
+	// where not set per-status and the visibility allows.
+	Boostable bool `json:"boostable"`
+	// Whether new statuses can be replied to by default,
+	// where not set per-status and the visibility allows.
+	Replyable bool `json:"replyable"`
+	// Whether new statuses can be liked by default,
+	// where not set per-status and the visibility allows.
+	Likeable bool `json:"likeable"`
+	// Delete public statuses once they're older than
+	// this many days. 0 means statuses are never deleted.
+	ExpiryDays int `json:"status_expiry_days"`
+	// Keep pinned statuses when deleting expired statuses.
+	ExpiryKeepPinned bool `json:"status_expiry_keep_pinned"`
+	// Keep statuses faved by the account itself
+	// when deleting expired statuses.
+	ExpiryKeepFaved bool `json:"status_expiry_keep_faved"`
 	// Profile bio.
 	Note string `json:"note"`
 	// Metadata about the account.
@@ -35,6 +35,8 @@ const (
 	TextXML           = `text/xml`
 	TextHTML          = `text/html`
 	TextCSS           = `text/css`
+	TextCSV           = `text/csv`
+	AppZip            = `application/zip`
 )
 
 // JSONContentType returns whether is application/json(;charset=utf-8)? content-type.
@@ -67,11 +67,29 @@ const (
 
 	WebStatusIDKey = "status"
 
+	/* Status keys */
+
+	StatusDeleteMediaKey = "delete_media"
+
+	/* Filter keys */
+
+	FilterSubscriptionKeepFiltersKey = "keep_filters"
+
+	/* Announcement keys */
+
+	AnnouncementWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey  = "name"
+
 	/* Domain permission keys */
 
 	DomainPermissionExportKey = "export"
 	DomainPermissionImportKey = "import"
 
+	/* Canonical email block keys */
+
+	CanonicalEmailBlockExportKey = "export"
+	CanonicalEmailBlockImportKey = "import"
+
 	/* Admin query keys */
 
 	AdminRemoteKey      = "remote"
@@ -154,6 +172,18 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
 
+func ParseStatusDeleteMedia(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, StatusDeleteMediaKey)
+}
+
+func ParseFilterSubscriptionKeepFilters(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, FilterSubscriptionKeepFiltersKey)
+}
+
+func ParseAnnouncementWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementWithDismissedKey)
+}
+
 func ParseDomainPermissionExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, DomainPermissionExportKey)
 }
@@ -162,6 +192,14 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseCanonicalEmailBlockExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, CanonicalEmailBlockExportKey)
+}
+
+func ParseCanonicalEmailBlockImport(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, CanonicalEmailBlockImportKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
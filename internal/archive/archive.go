@@ -0,0 +1,418 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Names of the files contained in an account data archive.
+const (
+	FollowingFile = "following_accounts.csv"
+	FollowersFile = "followers.csv"
+	BlocksFile    = "blocked_accounts.csv"
+	MutesFile     = "muted_accounts.csv"
+	ListsFile     = "lists.csv"
+	BookmarksFile = "bookmarks.csv"
+	OutboxFile    = "outbox.json"
+)
+
+// outboxPageSize is the number of statuses
+// to select from the database at a time
+// when writing the outbox of an archive.
+const outboxPageSize = 100
+
+// Progress describes how far along
+// the writing of an archive is, in
+// terms of files written to it.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// Writer wraps functionality for writing
+// a zip archive of a local account's data.
+type Writer struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+// NewWriter returns a new archive Writer
+// that will use the given state and converter.
+func NewWriter(state *state.State, converter *typeutils.Converter) *Writer {
+	return &Writer{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// Write writes a zip archive of the data of the given local
+// account to out. This consists of CSV files of follows,
+// followers, blocks, mutes, lists and bookmarks, in the
+// formats also used by Mastodon where one exists, and an
+// ActivityPub OrderedCollection of the account's statuses.
+//
+// If progress is not nil, it will be called after each file
+// of the archive has been written.
+func (w *Writer) Write(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	out io.Writer,
+	progress func(Progress),
+) error {
+	if !account.IsLocal() {
+		return gtserror.Newf("account %s is not local", account.ID)
+	}
+
+	files := []struct {
+		name  string
+		write func(context.Context, *gtsmodel.Account, io.Writer) error
+	}{
+		{FollowingFile, w.writeFollowing},
+		{FollowersFile, w.writeFollowers},
+		{BlocksFile, w.writeBlocks},
+		{MutesFile, w.writeMutes},
+		{ListsFile, w.writeLists},
+		{BookmarksFile, w.writeBookmarks},
+		{OutboxFile, w.writeOutbox},
+	}
+
+	zw := zip.NewWriter(out)
+
+	for i, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return gtserror.Newf("error creating %s: %w", file.name, err)
+		}
+
+		if err := file.write(ctx, account, fw); err != nil {
+			return gtserror.Newf("error writing %s: %w", file.name, err)
+		}
+
+		if progress != nil {
+			progress(Progress{
+				Done:  i + 1,
+				Total: len(files),
+			})
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return gtserror.Newf("error closing archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeFollowing writes the accounts followed by
+// account as CSV, with the same header and columns
+// as a Mastodon following_accounts.csv file.
+func (w *Writer) writeFollowing(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	follows, err := w.state.DB.GetAccountFollows(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	records := [][]string{{
+		"Account address",
+		"Show boosts",
+		"Notify on new posts",
+		"Languages",
+	}}
+
+	for _, follow := range follows {
+		if follow.TargetAccount == nil {
+			continue
+		}
+
+		records = append(records, []string{
+			address(follow.TargetAccount),
+			strconv.FormatBool(util.PtrValueOr(follow.ShowReblogs, true)),
+			strconv.FormatBool(util.PtrValueOr(follow.Notify, false)),
+			strings.Join(follow.Languages, ", "),
+		})
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeFollowers writes the
+// addresses of accounts
+// following account as CSV.
+func (w *Writer) writeFollowers(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	follows, err := w.state.DB.GetAccountFollowers(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	records := [][]string{{"Account address"}}
+
+	for _, follow := range follows {
+		if follow.Account == nil {
+			continue
+		}
+
+		records = append(records, []string{
+			address(follow.Account),
+		})
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeBlocks writes the addresses of
+// accounts blocked by account as CSV,
+// with one address per line and no header.
+func (w *Writer) writeBlocks(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	blocks, err := w.state.DB.GetAccountBlocks(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	records := make([][]string, 0, len(blocks))
+
+	for _, block := range blocks {
+		if block.TargetAccount == nil {
+			continue
+		}
+
+		records = append(records, []string{
+			address(block.TargetAccount),
+		})
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeMutes writes the accounts muted by
+// account as CSV, with the same header and
+// columns as a Mastodon muted_accounts.csv file.
+func (w *Writer) writeMutes(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	mutes, err := w.state.DB.GetAccountMutes(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	records := [][]string{{
+		"Account address",
+		"Hide notifications",
+	}}
+
+	for _, mute := range mutes {
+		if mute.TargetAccount == nil {
+			continue
+		}
+
+		records = append(records, []string{
+			address(mute.TargetAccount),
+			strconv.FormatBool(util.PtrValueOr(mute.Notifications, false)),
+		})
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeLists writes the lists owned by account
+// as CSV, with one list title and account address
+// per line and no header, as in a Mastodon lists.csv file.
+func (w *Writer) writeLists(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	lists, err := w.state.DB.GetListsForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	var records [][]string
+
+	for _, list := range lists {
+		entries, err := w.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.Follow == nil {
+				continue
+			}
+
+			// Entry follows are only barebones
+			// populated, so fetch target account.
+			target, err := w.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				entry.Follow.TargetAccountID,
+			)
+			if err != nil {
+				if errors.Is(err, db.ErrNoEntries) {
+					continue
+				}
+				return err
+			}
+
+			records = append(records, []string{
+				list.Title,
+				address(target),
+			})
+		}
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeBookmarks writes the URIs of statuses
+// bookmarked by account as CSV, with one URI
+// per line and no header, as also produced by
+// the bookmarks export API endpoint.
+func (w *Writer) writeBookmarks(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	bookmarks, err := w.state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	records := make([][]string, 0, len(bookmarks))
+
+	for _, bookmark := range bookmarks {
+		if bookmark.Status == nil {
+			continue
+		}
+
+		records = append(records, []string{
+			bookmark.Status.URI,
+		})
+	}
+
+	return writeCSV(out, records)
+}
+
+// writeOutbox writes the statuses of account as an ActivityPub
+// OrderedCollection of Create activities, newest first. Boosts
+// are not included. The collection is written a page of statuses
+// at a time, rather than being built up in memory all at once.
+func (w *Writer) writeOutbox(ctx context.Context, account *gtsmodel.Account, out io.Writer) error {
+	head, err := json.Marshal(map[string]any{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id":       account.OutboxURI,
+		"type":     ap.ObjectOrderedCollection,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Leave the object open
+	// to append orderedItems.
+	head = head[:len(head)-1]
+	if _, err := io.WriteString(out, string(head)+`,"orderedItems":[`); err != nil {
+		return err
+	}
+
+	var (
+		maxID string
+		total int
+	)
+
+	for {
+		statuses, err := w.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			outboxPageSize,
+			false, // excludeReplies
+			true,  // excludeReblogs
+			maxID,
+			"",    // minID
+			false, // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Page down for next query.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			statusable, err := w.converter.StatusToAS(ctx, status)
+			if err != nil {
+				return gtserror.Newf("error converting status %s: %w", status.ID, err)
+			}
+
+			create := typeutils.WrapStatusableInCreate(statusable, false)
+
+			data, err := ap.Serialize(create)
+			if err != nil {
+				return gtserror.Newf("error serializing status %s: %w", status.ID, err)
+			}
+
+			b, err := json.Marshal(data)
+			if err != nil {
+				return gtserror.Newf("error marshaling status %s: %w", status.ID, err)
+			}
+
+			if total > 0 {
+				b = append([]byte{','}, b...)
+			}
+
+			if _, err := out.Write(b); err != nil {
+				return err
+			}
+
+			total++
+		}
+	}
+
+	_, err = io.WriteString(out, `],"totalItems":`+strconv.Itoa(total)+`}`)
+	return err
+}
+
+// writeCSV writes the given records to out as CSV.
+func writeCSV(out io.Writer, records [][]string) error {
+	cw := csv.NewWriter(out)
+	if err := cw.WriteAll(records); err != nil {
+		return gtserror.Newf("error writing csv: %w", err)
+	}
+	return nil
+}
+
+// address returns the username@domain
+// address of the given account, using
+// the configured account domain for
+// local accounts.
+func address(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
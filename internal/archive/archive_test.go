@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/archive"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ArchiveTestSuite struct {
+	suite.Suite
+	state        state.State
+	writer       *archive.Writer
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *ArchiveTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *ArchiveTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.writer = archive.NewWriter(&suite.state, typeutils.NewConverter(&suite.state))
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *ArchiveTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StopWorkers(&suite.state)
+}
+
+// readArchive returns the contents
+// of each file in the given zip data.
+func (suite *ArchiveTestSuite) readArchive(data []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		b, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		files[f.Name] = b
+	}
+
+	return files
+}
+
+// readCSV parses the given csv data.
+func (suite *ArchiveTestSuite) readCSV(data []byte) [][]string {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return records
+}
+
+func (suite *ArchiveTestSuite) TestWrite() {
+	var (
+		ctx      = context.Background()
+		account  = suite.testAccounts["local_account_1"]
+		buf      bytes.Buffer
+		progress []archive.Progress
+	)
+
+	if err := suite.writer.Write(ctx, account, &buf, func(p archive.Progress) {
+		progress = append(progress, p)
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Progress should have been
+	// reported once for each file.
+	suite.Len(progress, 7)
+	suite.Equal(archive.Progress{Done: 7, Total: 7}, progress[len(progress)-1])
+
+	files := suite.readArchive(buf.Bytes())
+	suite.Len(files, 7)
+
+	following := suite.readCSV(files[archive.FollowingFile])
+	suite.Equal([]string{"Account address", "Show boosts", "Notify on new posts", "Languages"}, following[0])
+	suite.ElementsMatch([][]string{
+		{"admin@localhost:8080", "true", "false", ""},
+		{"1happyturtle@localhost:8080", "true", "false", ""},
+	}, following[1:])
+
+	followers := suite.readCSV(files[archive.FollowersFile])
+	suite.Equal([]string{"Account address"}, followers[0])
+	suite.ElementsMatch([][]string{
+		{"admin@localhost:8080"},
+		{"1happyturtle@localhost:8080"},
+	}, followers[1:])
+
+	lists := suite.readCSV(files[archive.ListsFile])
+	suite.ElementsMatch([][]string{
+		{"Cool Ass Posters From This Instance", "admin@localhost:8080"},
+		{"Cool Ass Posters From This Instance", "1happyturtle@localhost:8080"},
+	}, lists)
+
+	// The outbox should be an OrderedCollection
+	// of Creates, one for each non-boost status.
+	var outbox struct {
+		ID           string           `json:"id"`
+		Type         string           `json:"type"`
+		TotalItems   int              `json:"totalItems"`
+		OrderedItems []map[string]any `json:"orderedItems"`
+	}
+	if err := json.Unmarshal(files[archive.OutboxFile], &outbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(account.OutboxURI, outbox.ID)
+	suite.Equal("OrderedCollection", outbox.Type)
+	suite.NotZero(outbox.TotalItems)
+	suite.Len(outbox.OrderedItems, outbox.TotalItems)
+	for _, item := range outbox.OrderedItems {
+		suite.Equal("Create", item["type"])
+		suite.IsType(map[string]any{}, item["object"])
+	}
+}
+
+func (suite *ArchiveTestSuite) TestWriteRemoteAccount() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["remote_account_1"]
+		buf     bytes.Buffer
+	)
+
+	err := suite.writer.Write(ctx, account, &buf, nil)
+	suite.ErrorContains(err, "is not local")
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
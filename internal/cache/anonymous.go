@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"strconv"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// anonymousTTL is the time for which API responses
+// are cached for unauthenticated requests. This is
+// kept short, as (other than timeline events and
+// instance updates) nothing invalidates these.
+const anonymousTTL = 30 * time.Second
+
+// AnonymousCaches provides access to short-lived caches
+// of API responses that don't depend on the requester,
+// so that crawler + logged-out guest traffic is served
+// from memory instead of hitting the database each time.
+type AnonymousCaches struct {
+
+	// PublicTimeline caches pages of the public timeline
+	// as served to unauthenticated requests, keyed by
+	// the paging parameters. See PublicTimelineKey().
+	PublicTimeline *ttl.Cache[string, *apimodel.PageableResponse]
+
+	// InstanceV1 caches the v1 instance model, under "".
+	InstanceV1 *ttl.Cache[string, *apimodel.InstanceV1]
+
+	// InstanceV2 caches the v2 instance model, under "".
+	InstanceV2 *ttl.Cache[string, *apimodel.InstanceV2]
+}
+
+// PublicTimelineKey returns the AnonymousCaches{}.PublicTimeline
+// key for a page of the public timeline with given parameters.
+func PublicTimelineKey(maxID, sinceID, minID string, limit int, local bool) string {
+	return maxID + ":" + sinceID + ":" + minID + ":" +
+		strconv.Itoa(limit) + ":" + strconv.FormatBool(local)
+}
+
+// ClearTimelines clears all cached timeline responses,
+// to be called on creation, edit or deletion of a status
+// that may appear in (or vanish from) the public timeline.
+func (c *AnonymousCaches) ClearTimelines() {
+	c.PublicTimeline.Clear()
+}
+
+// ClearInstance clears all cached instance responses,
+// to be called on any update to the instance itself.
+func (c *AnonymousCaches) ClearInstance() {
+	c.InstanceV1.Clear()
+	c.InstanceV2.Clear()
+}
+
+func (c *Caches) initAnonymous() {
+	// Only a handful of pages
+	// are ever requested anonymously
+	// with any frequency, use a fixed
+	// size rather than a memory ratio.
+	const cap = 100
+
+	c.Anonymous.PublicTimeline = new(ttl.Cache[string, *apimodel.PageableResponse])
+	c.Anonymous.PublicTimeline.Init(0, cap, anonymousTTL)
+
+	c.Anonymous.InstanceV1 = new(ttl.Cache[string, *apimodel.InstanceV1])
+	c.Anonymous.InstanceV1.Init(0, 1, anonymousTTL)
+
+	c.Anonymous.InstanceV2 = new(ttl.Cache[string, *apimodel.InstanceV2])
+	c.Anonymous.InstanceV2.Init(0, 1, anonymousTTL)
+}
@@ -0,0 +1,382 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// InvalidationBus is a message bus over which cache invalidations
+// are shared between multiple replicas of GoToSocial, all running
+// against the same database, e.g. a Redis pub/sub channel.
+type InvalidationBus interface {
+	// Publish publishes message to all subscribers of the bus.
+	Publish(ctx context.Context, message []byte) error
+
+	// Subscribe passes each message published on the bus
+	// to recv, blocking until the context is cancelled.
+	Subscribe(ctx context.Context, recv func(message []byte))
+}
+
+// SetInvalidationBus sets the bus over which invalidations of the database
+// caches are shared with other replicas, so that each drops any entries
+// made stale by writes from the others. The bus is started and stopped
+// along with the caches.
+//
+// NOTE: the cache MUST NOT be in use anywhere, this is not thread-safe.
+func (c *Caches) SetInvalidationBus(bus InvalidationBus) {
+	c.bus = &invalidator{
+		bus:    bus,
+		caches: c,
+		origin: id.NewULID(),
+		queue:  make(chan []byte, 1024),
+	}
+	for name, cache := range c.sharedCaches() {
+		cache.share(name, c.bus)
+	}
+}
+
+// ClearShared clears each of the given caches, which must be one of the
+// caches only ever invalidated as a whole (e.g. c.GTS.DomainBlock), and
+// has any other replicas sharing the invalidation bus do the same.
+func (c *Caches) ClearShared(caches ...interface{ Clear() }) {
+	clearable := c.clearableCaches()
+	for _, cache := range caches {
+		cache.Clear()
+
+		if c.bus == nil {
+			continue
+		}
+
+		for name, named := range clearable {
+			if named == cache {
+				c.bus.publish(&invalidation{
+					Cache: name,
+					Clear: true,
+				})
+			}
+		}
+	}
+}
+
+// sharedCaches returns the database struct and slice caches
+// keyed by name, as used in the cache-*-mem-ratio options.
+//
+// The visibility cache is deliberately absent, as it is
+// invalidated by each replica's own invalidation hooks.
+func (c *Caches) sharedCaches() map[string]sharedCache {
+	return map[string]sharedCache{
+		"account":             &c.GTS.Account,
+		"account-note":        &c.GTS.AccountNote,
+		"account-settings":    &c.GTS.AccountSettings,
+		"account-stats":       &c.GTS.AccountStats,
+		"application":         &c.GTS.Application,
+		"block":               &c.GTS.Block,
+		"block-ids":           &c.GTS.BlockIDs,
+		"boost-of-ids":        &c.GTS.BoostOfIDs,
+		"client":              &c.GTS.Client,
+		"emoji":               &c.GTS.Emoji,
+		"emoji-category":      &c.GTS.EmojiCategory,
+		"filter":              &c.GTS.Filter,
+		"filter-keyword":      &c.GTS.FilterKeyword,
+		"filter-status":       &c.GTS.FilterStatus,
+		"follow":              &c.GTS.Follow,
+		"follow-ids":          &c.GTS.FollowIDs,
+		"follow-request":      &c.GTS.FollowRequest,
+		"follow-request-ids":  &c.GTS.FollowRequestIDs,
+		"in-reply-to-ids":     &c.GTS.InReplyToIDs,
+		"instance":            &c.GTS.Instance,
+		"list":                &c.GTS.List,
+		"list-entry":          &c.GTS.ListEntry,
+		"marker":              &c.GTS.Marker,
+		"media":               &c.GTS.Media,
+		"mention":             &c.GTS.Mention,
+		"move":                &c.GTS.Move,
+		"notification":        &c.GTS.Notification,
+		"poll":                &c.GTS.Poll,
+		"poll-vote":           &c.GTS.PollVote,
+		"poll-vote-ids":       &c.GTS.PollVoteIDs,
+		"report":              &c.GTS.Report,
+		"status":              &c.GTS.Status,
+		"status-bookmark":     &c.GTS.StatusBookmark,
+		"status-bookmark-ids": &c.GTS.StatusBookmarkIDs,
+		"status-fave":         &c.GTS.StatusFave,
+		"status-fave-ids":     &c.GTS.StatusFaveIDs,
+		"tag":                 &c.GTS.Tag,
+		"thread-mute":         &c.GTS.ThreadMute,
+		"token":               &c.GTS.Token,
+		"tombstone":           &c.GTS.Tombstone,
+		"user":                &c.GTS.User,
+		"user-mute":           &c.GTS.UserMute,
+		"user-mute-ids":       &c.GTS.UserMuteIDs,
+	}
+}
+
+// clearableCaches returns the caches that are only
+// ever invalidated as a whole, keyed by name.
+func (c *Caches) clearableCaches() map[string]interface{ Clear() } {
+	return map[string]interface{ Clear() }{
+		"domain-allow":          c.GTS.DomainAllow,
+		"domain-block":          c.GTS.DomainBlock,
+		"domain-silence":        c.GTS.DomainSilence,
+		"email-domain-block":    c.GTS.EmailDomainBlock,
+		"allow-header-filters":  &c.AllowHeaderFilters,
+		"block-header-filters":  &c.BlockHeaderFilters,
+		"ip-blocks":             &c.IPBlocks,
+		"domain-media-policies": &c.DomainMediaPolicies,
+		"visibility":            &c.Visibility,
+	}
+}
+
+// sharedCache is a cache whose
+// invalidations may be shared.
+type sharedCache interface {
+	sizedCache
+
+	// share sets the invalidator to publish
+	// invalidations to, under the given name.
+	share(name string, bus *invalidator)
+
+	// invalidateShared drops entries under
+	// keys received from another replica.
+	invalidateShared(keys map[string][]json.RawMessage) error
+}
+
+// invalidation is a cache invalidation
+// message, as sent over the bus.
+type invalidation struct {
+	// Origin is the
+	// sending replica.
+	Origin string `json:"origin"`
+
+	// Cache is the
+	// cache name.
+	Cache string `json:"cache"`
+
+	// Keys contains JSON encoded
+	// key parts, keyed by index name.
+	Keys map[string][]json.RawMessage `json:"keys,omitempty"`
+
+	// Clear indicates the
+	// whole cache is cleared.
+	Clear bool `json:"clear,omitempty"`
+}
+
+// invalidator handles publishing and
+// receiving invalidations on a bus.
+type invalidator struct {
+	bus    InvalidationBus
+	caches *Caches
+	origin string
+	queue  chan []byte
+	cancel context.CancelFunc
+	wait   sync.WaitGroup
+}
+
+// start starts the publishing and subscribing routines.
+func (i *invalidator) start() {
+	var ctx context.Context
+	ctx, i.cancel = context.WithCancel(context.Background())
+
+	i.wait.Add(2)
+
+	go func() {
+		defer i.wait.Done()
+		i.publishing(ctx)
+	}()
+
+	go func() {
+		defer i.wait.Done()
+		i.bus.Subscribe(ctx, i.receive)
+	}()
+}
+
+// stop stops the publishing and subscribing routines.
+func (i *invalidator) stop() {
+	if i.cancel != nil {
+		i.cancel()
+		i.wait.Wait()
+	}
+}
+
+// publish queues invalidation for publishing on the bus. This never blocks,
+// so invalidations are dropped (and logged) if the bus cannot keep up.
+func (i *invalidator) publish(msg *invalidation) {
+	msg.Origin = i.origin
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		log.Errorf(nil, "error encoding %s cache invalidation: %v", msg.Cache, err)
+		return
+	}
+
+	select {
+	case i.queue <- b:
+	default:
+		log.Warnf(nil, "invalidation queue full, dropping %s cache invalidation", msg.Cache)
+	}
+}
+
+// publishing publishes queued
+// invalidations until cancelled.
+func (i *invalidator) publishing(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case b := <-i.queue:
+			if err := i.bus.Publish(ctx, b); err != nil {
+				log.Errorf(ctx, "error publishing cache invalidation: %v", err)
+			}
+		}
+	}
+}
+
+// receive handles an invalidation received from the bus,
+// ignoring any that were published by this replica itself.
+//
+// Note that invalidation hooks triggered here will in turn
+// publish their own invalidations, which the other replicas
+// will mostly find already dropped, so this soon dies out.
+func (i *invalidator) receive(b []byte) {
+	var msg invalidation
+	if err := json.Unmarshal(b, &msg); err != nil {
+		log.Errorf(nil, "error decoding cache invalidation: %v", err)
+		return
+	}
+
+	if msg.Origin == i.origin {
+		return
+	}
+
+	if msg.Clear {
+		cache, ok := i.caches.clearableCaches()[msg.Cache]
+		if !ok {
+			log.Warnf(nil, "received invalidation for unknown cache: %s", msg.Cache)
+			return
+		}
+		cache.Clear()
+		return
+	}
+
+	cache, ok := i.caches.sharedCaches()[msg.Cache]
+	if !ok {
+		log.Warnf(nil, "received invalidation for unknown cache: %s", msg.Cache)
+		return
+	}
+
+	if err := cache.invalidateShared(msg.Keys); err != nil {
+		log.Errorf(nil, "error handling %s cache invalidation: %v", msg.Cache, err)
+	}
+}
+
+// indexField describes a (possibly nested) struct
+// field making up part of a structr index key.
+type indexField struct {
+	path  []int
+	rtype reflect.Type
+}
+
+// newIndexFields returns the struct fields of type
+// t named in fields, as in structr.IndexConfig{}.
+func newIndexFields(t reflect.Type, fields string) []indexField {
+	names := strings.Split(fields, ",")
+	ifields := make([]indexField, len(names))
+
+	for x, name := range names {
+		ftype := t
+		for _, name := range strings.Split(name, ".") {
+			for ftype.Kind() == reflect.Pointer {
+				ftype = ftype.Elem()
+			}
+
+			// Fields are already checked
+			// by structr on cache init.
+			sfield, _ := ftype.FieldByName(name)
+			ifields[x].path = append(ifields[x].path, sfield.Index...)
+			ftype = sfield.Type
+		}
+		ifields[x].rtype = ftype
+	}
+
+	return ifields
+}
+
+// extract returns the values of fields in value, JSON encoded as
+// invalidation key parts, or false if these could not be extracted.
+func extract(value any, fields []indexField) (json.RawMessage, bool) {
+	parts := make([]any, len(fields))
+	zero := true
+
+	for x, field := range fields {
+		v := reflect.ValueOf(value)
+		for _, idx := range field.path {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return nil, false
+				}
+				v = v.Elem()
+			}
+			v = v.Field(idx)
+		}
+
+		zero = zero && v.IsZero()
+		parts[x] = v.Interface()
+	}
+
+	if zero {
+		// Never indexed
+		// under zero key.
+		return nil, false
+	}
+
+	b, err := json.Marshal(parts)
+	return b, err == nil
+}
+
+// decode decodes the JSON encoded invalidation
+// key parts into values of the fields' types.
+func decode(key json.RawMessage, fields []indexField) ([]any, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(key, &raw); err != nil {
+		return nil, err
+	}
+
+	if len(raw) != len(fields) {
+		return nil, gtserror.Newf("expected %d key parts, got %d", len(fields), len(raw))
+	}
+
+	parts := make([]any, len(fields))
+	for x, field := range fields {
+		ptr := reflect.New(field.rtype)
+		if err := json.Unmarshal(raw[x], ptr.Interface()); err != nil {
+			return nil, err
+		}
+		parts[x] = ptr.Elem().Interface()
+	}
+
+	return parts, nil
+}
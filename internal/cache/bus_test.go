@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestInvalidationBus(t *testing.T) {
+	config.Config(func(cfg *config.Configuration) {
+		*cfg = config.Defaults
+	})
+
+	// Two "replicas" sharing a bus.
+	bus := new(memoryBus)
+	var c1, c2 cache.Caches
+	for _, c := range []*cache.Caches{&c1, &c2} {
+		c.Init()
+		c.SetInvalidationBus(bus)
+		c.Start()
+		defer c.Stop()
+	}
+
+	// Wait for both to subscribe.
+	bus.waitSubscribers(t, 2)
+
+	account := &gtsmodel.Account{
+		ID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		URI:      "http://localhost:8080/users/the_mighty_zork",
+		Username: "the_mighty_zork",
+	}
+
+	// Replica 2 caches the account, and
+	// that one doesn't exist by username.
+	c2.GTS.Account.Put(account)
+	if _, err := c2.GTS.Account.LoadOne("Username,Domain", func() (*gtsmodel.Account, error) {
+		return nil, db.ErrNoEntries
+	}, "someone_else", ""); err != db.ErrNoEntries {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Replica 1 updates the account.
+	updated := *account
+	updated.Note = "hello world"
+	c1.GTS.Account.Put(&updated)
+
+	// Replica 2 should drop its stale copy.
+	eventually(t, func() bool {
+		_, ok := c2.GTS.Account.GetOne("ID", account.ID)
+		return !ok
+	})
+
+	// Replica 1 creates the other account.
+	c1.GTS.Account.Put(&gtsmodel.Account{
+		ID:       "01F8MH17FWEB39HZJ76B6VXSKF",
+		URI:      "http://localhost:8080/users/someone_else",
+		Username: "someone_else",
+	})
+
+	// Replica 2 should drop its cached error.
+	eventually(t, func() bool {
+		loaded := false
+		_, _ = c2.GTS.Account.LoadOne("Username,Domain", func() (*gtsmodel.Account, error) {
+			loaded = true
+			return nil, db.ErrNoEntries
+		}, "someone_else", "")
+		return loaded
+	})
+
+	// Slice caches are shared too.
+	c2.GTS.FollowIDs.Load(">"+account.ID, func() ([]string, error) { return []string{"a"}, nil })
+	c1.GTS.FollowIDs.Invalidate(">" + account.ID)
+	eventually(t, func() bool {
+		return c2.GTS.FollowIDs.Len() == 0
+	})
+
+	// As are whole cache clears.
+	_, _ = c2.GTS.DomainBlock.Matches("example.org", func() ([]string, error) {
+		return nil, nil
+	})
+	c1.ClearShared(c1.GTS.DomainBlock)
+	eventually(t, func() bool {
+		loaded := false
+		_, _ = c2.GTS.DomainBlock.Matches("example.org", func() ([]string, error) {
+			loaded = true
+			return nil, nil
+		})
+		return loaded
+	})
+}
+
+// eventually fails the test if
+// check does not soon return true.
+func eventually(t *testing.T, check func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if check() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for invalidation")
+}
+
+// memoryBus is an in-memory cache.InvalidationBus.
+type memoryBus struct {
+	mu   sync.Mutex
+	subs []func([]byte)
+}
+
+func (b *memoryBus) Publish(_ context.Context, message []byte) error {
+	b.mu.Lock()
+	subs := b.subs
+	b.mu.Unlock()
+	for _, recv := range subs {
+		recv(message)
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(ctx context.Context, recv func([]byte)) {
+	b.mu.Lock()
+	b.subs = append(b.subs, recv)
+	b.mu.Unlock()
+	<-ctx.Done()
+}
+
+func (b *memoryBus) waitSubscribers(t *testing.T, n int) {
+	eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.subs) == n
+	})
+}
@@ -20,7 +20,10 @@ package cache
 import (
 	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/ipblock"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/mediapolicy"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -38,10 +41,39 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// IPBlocks provides access to
+	// the parsed IP block prefixes cache.
+	IPBlocks ipblock.Cache
+
+	// DomainMediaPolicies provides access
+	// to the domain media policies cache.
+	DomainMediaPolicies mediapolicy.Cache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// Unretrievable provides access to the cache of remote
+	// URIs recently found gone (404 / 410) on dereference,
+	// mapped to the returned HTTP status code. 410s are keyed
+	// by URI, 404s by "requesting username + URI".
+	Unretrievable *ttl.Cache[string, int] // TTL=1hr, sweep=5min
+
+	// InboxActivities provides access to the cache of
+	// recently received inbox activity IDs (keyed with
+	// the receiving inbox), used to drop duplicates.
+	InboxActivities *ttl.Cache[string, struct{}] // TTL=1hr, sweep=5min
+
+	// Anonymous provides access to the short-lived
+	// caches of API responses to unauthenticated
+	// requests, e.g. pages of the public timeline.
+	Anonymous AnonymousCaches // TTL=30s, sweep=1min
+
+	// bus is the (optional) invalidator
+	// sharing cache invalidations with
+	// other replicas of this instance.
+	bus *invalidator
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -62,6 +94,8 @@ func (c *Caches) Init() {
 	c.initClient()
 	c.initDomainAllow()
 	c.initDomainBlock()
+	c.initDomainSilence()
+	c.initEmailDomainBlock()
 	c.initEmoji()
 	c.initEmojiCategory()
 	c.initFilter()
@@ -98,6 +132,9 @@ func (c *Caches) Init() {
 	c.initUserMuteIDs()
 	c.initWebfinger()
 	c.initVisibility()
+	c.initUnretrievable()
+	c.initInboxActivities()
+	c.initAnonymous()
 }
 
 // Start will start any caches that require a background
@@ -108,6 +145,30 @@ func (c *Caches) Start() {
 	tryUntil("starting webfinger cache", 5, func() bool {
 		return c.GTS.Webfinger.Start(5 * time.Minute)
 	})
+
+	tryUntil("starting unretrievable cache", 5, func() bool {
+		return c.Unretrievable.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting inbox activities cache", 5, func() bool {
+		return c.InboxActivities.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting anonymous public timeline cache", 5, func() bool {
+		return c.Anonymous.PublicTimeline.Start(time.Minute)
+	})
+
+	tryUntil("starting anonymous instance v1 cache", 5, func() bool {
+		return c.Anonymous.InstanceV1.Start(time.Minute)
+	})
+
+	tryUntil("starting anonymous instance v2 cache", 5, func() bool {
+		return c.Anonymous.InstanceV2.Start(time.Minute)
+	})
+
+	if c.bus != nil {
+		c.bus.start()
+	}
 }
 
 // Stop will stop any caches that require a background
@@ -116,6 +177,15 @@ func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
 	tryUntil("stopping webfinger cache", 5, c.GTS.Webfinger.Stop)
+	tryUntil("stopping unretrievable cache", 5, c.Unretrievable.Stop)
+	tryUntil("stopping inbox activities cache", 5, c.InboxActivities.Stop)
+	tryUntil("stopping anonymous public timeline cache", 5, c.Anonymous.PublicTimeline.Stop)
+	tryUntil("stopping anonymous instance v1 cache", 5, c.Anonymous.InstanceV1.Stop)
+	tryUntil("stopping anonymous instance v2 cache", 5, c.Anonymous.InstanceV2.Stop)
+
+	if c.bus != nil {
+		c.bus.stop()
+	}
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -170,3 +240,15 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.UserMuteIDs.Trim(threshold)
 	c.Visibility.Trim(threshold)
 }
+
+// Stats returns the current usage statistics of the database
+// struct and slice caches, keyed by cache name as used in the
+// matching cache-*-mem-ratio configuration option.
+func (c *Caches) Stats() map[string]Stats {
+	sized := c.sizedCaches()
+	stats := make(map[string]Stats, len(sized))
+	for name, cache := range sized {
+		stats[name] = cache.Stats()
+	}
+	return stats
+}
@@ -19,6 +19,7 @@ package cache
 
 import (
 	"time"
+	"unsafe"
 
 	"codeberg.org/gruf/go-cache/v3/ttl"
 	"codeberg.org/gruf/go-structr"
@@ -62,6 +63,12 @@ type GTSCaches struct {
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainSilence provides access to the silenced domain database cache.
+	DomainSilence *domain.Cache
+
+	// EmailDomainBlock provides access to the email domain block database cache.
+	EmailDomainBlock *domain.Cache
+
 	// Emoji provides access to the gtsmodel Emoji database cache.
 	Emoji StructCache[*gtsmodel.Emoji]
 
@@ -431,6 +438,14 @@ func (c *Caches) initDomainBlock() {
 	c.GTS.DomainBlock = new(domain.Cache)
 }
 
+func (c *Caches) initDomainSilence() {
+	c.GTS.DomainSilence = new(domain.Cache)
+}
+
+func (c *Caches) initEmailDomainBlock() {
+	c.GTS.EmailDomainBlock = new(domain.Cache)
+}
+
 func (c *Caches) initEmoji() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1414,3 +1429,37 @@ func (c *Caches) initWebfinger() {
 		24*time.Hour,
 	)
 }
+
+func (c *Caches) initUnretrievable() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		sizeofURIStr, unsafe.Sizeof(int(0)),
+		config.GetCacheUnretrievableMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.Unretrievable = new(ttl.Cache[string, int])
+	c.Unretrievable.Init(
+		0,
+		cap,
+		time.Hour,
+	)
+}
+
+func (c *Caches) initInboxActivities() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		sizeofURIStr+sizeofURIStr, 0,
+		config.GetCacheInboxActivityMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.InboxActivities = new(ttl.Cache[string, struct{}])
+	c.InboxActivities.Init(
+		0,
+		cap,
+		time.Hour,
+	)
+}
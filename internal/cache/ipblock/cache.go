@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ipblock
+
+import (
+	"fmt"
+	"net/netip"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching parsed IP block
+// prefixes in memory to reduce load on an underlying
+// storage mechanism, and to allow fast matching.
+type Cache struct {
+	// current cached ip blocks slice.
+	ptr atomic.Pointer[[]block]
+}
+
+// block is a parsed IP block
+// prefix with its severity.
+type block struct {
+	prefix   netip.Prefix
+	severity gtsmodel.IPBlockSeverity
+}
+
+// Match returns the most severe IPBlockSeverity of cached IP
+// blocks containing the given address, loading using callback
+// if necessary. An empty severity is returned on no match.
+func (c *Cache) Match(addr netip.Addr, load func() ([]*gtsmodel.IPBlock, error)) (gtsmodel.IPBlockSeverity, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load blocks from callback.
+		blocks, err := loadBlocks(load)
+		if err != nil {
+			return "", err
+		}
+
+		// Store the new
+		// ip blocks.
+		ptr = &blocks
+		c.ptr.Store(ptr)
+	}
+
+	// Ensure IPv4-mapped IPv6 addresses
+	// match against plain IPv4 prefixes.
+	addr = addr.Unmap()
+
+	var severity gtsmodel.IPBlockSeverity
+	for _, b := range *ptr {
+		if !b.prefix.Contains(addr) {
+			continue
+		}
+
+		if b.severity == gtsmodel.IPBlockSeverityNoAccess {
+			// Can't get more
+			// severe than this.
+			return b.severity, nil
+		}
+
+		severity = b.severity
+	}
+
+	return severity, nil
+}
+
+// Clear will drop the currently loaded blocks,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
+
+// loadBlocks will load IP blocks from given load callback, parsing their CIDR prefixes.
+func loadBlocks(load func() ([]*gtsmodel.IPBlock, error)) ([]block, error) {
+	// Load blocks from callback.
+	ipBlocks, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error reloading cache: %w", err)
+	}
+
+	// Allocate new block slice to store parsed prefixes.
+	blocks := make([]block, 0, len(ipBlocks))
+
+	for _, ipBlock := range ipBlocks {
+		prefix, err := netip.ParsePrefix(ipBlock.IP)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ip block %s: %w", ipBlock.IP, err)
+		}
+
+		blocks = append(blocks, block{
+			prefix:   prefix.Masked(),
+			severity: ipBlock.Severity,
+		})
+	}
+
+	return blocks, nil
+}
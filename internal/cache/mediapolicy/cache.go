@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mediapolicy
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching domain media policies
+// in memory to reduce load on an underlying storage mechanism,
+// as these are checked for every remote media load / uncache.
+type Cache struct {
+	// current cached policies by domain.
+	ptr atomic.Pointer[map[string]*gtsmodel.DomainMediaPolicy]
+}
+
+// Match returns the cached domain media policy for the given
+// domain, or for the nearest parent domain with a policy, loading
+// using callback if necessary. Returns nil if no policy matches.
+func (c *Cache) Match(domain string, load func() ([]*gtsmodel.DomainMediaPolicy, error)) (*gtsmodel.DomainMediaPolicy, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load policies from callback.
+		policies, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("error reloading cache: %w", err)
+		}
+
+		// Store the new
+		// policies by domain.
+		m := make(map[string]*gtsmodel.DomainMediaPolicy, len(policies))
+		for _, policy := range policies {
+			m[policy.Domain] = policy
+		}
+		ptr = &m
+		c.ptr.Store(ptr)
+	}
+
+	for domain != "" {
+		if policy, ok := (*ptr)[domain]; ok {
+			return policy, nil
+		}
+
+		// Move up to the parent domain,
+		// e.g. media.example.org => example.org.
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+
+	return nil, nil
+}
+
+// Clear will drop the currently loaded policies,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+// resizeFactor bounds runtime cache resizes to within this
+// factor of the capacity calculated from configuration, so
+// a typo can't exhaust memory or effectively disable a cache.
+const resizeFactor = 10
+
+// ErrUnknownCache is returned when resizing an unknown cache.
+var ErrUnknownCache = errors.New("unknown cache")
+
+// sizedCache is a database struct or slice cache.
+type sizedCache interface {
+	// Stats returns cache usage statistics.
+	Stats() Stats
+
+	// Resize replaces the cache with
+	// an empty one of given capacity.
+	Resize(cap int)
+
+	// InitCap returns the capacity the
+	// cache was initialized with.
+	InitCap() int
+}
+
+// sizedCaches returns the database struct and slice caches
+// keyed by name, as used in the cache-*-mem-ratio options.
+func (c *Caches) sizedCaches() map[string]sizedCache {
+	shared := c.sharedCaches()
+	sized := make(map[string]sizedCache, len(shared)+1)
+	for name, cache := range shared {
+		sized[name] = cache
+	}
+	sized["visibility"] = &c.Visibility
+	return sized
+}
+
+// ResizeBounds returns the minimum and maximum capacity that the
+// named cache (as keyed in Stats()) may be resized to at runtime.
+func (c *Caches) ResizeBounds(name string) (lower int, upper int, err error) {
+	cache, ok := c.sizedCaches()[name]
+	if !ok {
+		return 0, 0, ErrUnknownCache
+	}
+	lower, upper = resizeBounds(cache.InitCap())
+	return lower, upper, nil
+}
+
+// Resize replaces the named cache (as keyed in Stats()) with an empty
+// one of given capacity, which must be within ResizeBounds(). This is
+// not persisted, on restart sizes are calculated from configuration.
+//
+// Note that the cache's previous contents are dropped, and will be
+// reloaded from the database on demand as with a freshly started cache.
+func (c *Caches) Resize(name string, cap int) error {
+	cache, ok := c.sizedCaches()[name]
+	if !ok {
+		return ErrUnknownCache
+	}
+
+	lower, upper := resizeBounds(cache.InitCap())
+	if cap < lower || cap > upper {
+		return fmt.Errorf("capacity %d outside of bounds [%d, %d]", cap, lower, upper)
+	}
+
+	cache.Resize(cap)
+	return nil
+}
+
+// resizeBounds returns the resize bounds for initial capacity.
+func resizeBounds(initCap int) (lower int, upper int) {
+	// structr caches require
+	// a minimum size of 2.
+	lower = max(initCap/resizeFactor, 2)
+	upper = max(initCap*resizeFactor, lower)
+	return lower, upper
+}
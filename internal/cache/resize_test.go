@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestResize(t *testing.T) {
+	config.Config(func(cfg *config.Configuration) {
+		*cfg = config.Defaults
+	})
+
+	var c cache.Caches
+	c.Init()
+
+	account := &gtsmodel.Account{
+		ID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		URI:      "http://localhost:8080/users/the_mighty_zork",
+		Username: "the_mighty_zork",
+	}
+	c.GTS.Account.Put(account)
+	c.GTS.FollowIDs.Load(">"+account.ID, func() ([]string, error) { return []string{"a"}, nil })
+
+	for _, name := range []string{"account", "follow-ids"} {
+		lower, upper, err := c.ResizeBounds(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Out of bounds sizes should be refused.
+		for _, cap := range []int{lower - 1, upper + 1} {
+			if err := c.Resize(name, cap); err == nil {
+				t.Fatalf("expected error resizing %s to %d", name, cap)
+			}
+		}
+
+		// Resize to upper bound.
+		if err := c.Resize(name, upper); err != nil {
+			t.Fatal(err)
+		}
+
+		stats := c.Stats()[name]
+		if stats.Capacity != upper {
+			t.Fatalf("unexpected %s capacity: %d", name, stats.Capacity)
+		}
+
+		// Cache should have been emptied.
+		if stats.Size != 0 {
+			t.Fatalf("unexpected %s size: %d", name, stats.Size)
+		}
+	}
+
+	// Resized cache should still work as usual.
+	c.GTS.Account.Put(account)
+	if _, ok := c.GTS.Account.GetOne("Username,Domain", account.Username, ""); !ok {
+		t.Fatal("account not found in resized cache")
+	}
+
+	if err := c.Resize("not-a-cache", 100); !errors.Is(err, cache.ErrUnknownCache) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -210,6 +210,8 @@ func totalOfRatios() float64 {
 		config.GetCacheTokenMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
+		config.GetCacheUnretrievableMemRatio() +
+		config.GetCacheInboxActivityMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
@@ -269,6 +271,12 @@ func sizeofAccountSettings() uintptr {
 		CustomCSS:         exampleText,
 		EnableRSS:         util.Ptr(true),
 		HideCollections:   util.Ptr(false),
+		Boostable:         util.Ptr(true),
+		Replyable:         util.Ptr(true),
+		Likeable:          util.Ptr(true),
+		ExpiryDays:        30,
+		ExpiryKeepPinned:  util.Ptr(true),
+		ExpiryKeepFaved:   util.Ptr(true),
 	}))
 }
 
@@ -393,6 +401,7 @@ func sizeofFollow() uintptr {
 		ShowReblogs:     func() *bool { ok := true; return &ok }(),
 		URI:             exampleURI,
 		Notify:          func() *bool { ok := false; return &ok }(),
+		Languages:       []string{"en", "de"},
 	}))
 }
 
@@ -406,6 +415,7 @@ func sizeofFollowRequest() uintptr {
 		ShowReblogs:     func() *bool { ok := true; return &ok }(),
 		URI:             exampleURI,
 		Notify:          func() *bool { ok := false; return &ok }(),
+		Languages:       []string{"en", "de"},
 	}))
 }
 
@@ -560,6 +570,8 @@ func sizeofReport() uintptr {
 		ActionTaken:            exampleText,
 		ActionTakenAt:          exampleTime,
 		ActionTakenByAccountID: exampleID,
+		AssignedAccountID:      exampleID,
+		ResolutionCategory:     gtsmodel.ReportResolutionActioned,
 	}))
 }
 
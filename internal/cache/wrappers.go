@@ -18,40 +18,81 @@
 package cache
 
 import (
+	"encoding/json"
+	"reflect"
 	"slices"
+	"sync/atomic"
 
 	"codeberg.org/gruf/go-cache/v3/simple"
 	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
 // SliceCache wraps a simple.Cache to provide simple loader-callback
 // functions for fetching + caching slices of objects (e.g. IDs).
 type SliceCache[T any] struct {
-	cache simple.Cache[string, []T]
+	cache atomic.Pointer[simple.Cache[string, []T]]
+	stats *counters
+
+	// initCap is the capacity
+	// the cache was initialized
+	// with, see Resize() bounds.
+	initCap int
+
+	// bus, when set, is used to share
+	// invalidations under cache name.
+	bus  *invalidator
+	name string
 }
 
 // Init initializes the cache with given length + capacity.
 func (c *SliceCache[T]) Init(len, cap int) {
-	c.cache = simple.Cache[string, []T]{}
-	c.cache.Init(len, cap)
+	c.stats = new(counters)
+	c.initCap = cap
+	c.cache.Store(c.new(len, cap))
+}
+
+// Resize replaces the cache with an empty one of given capacity.
+func (c *SliceCache[T]) Resize(cap int) {
+	c.cache.Store(c.new(0, cap))
+}
+
+// InitCap returns the capacity the cache was initialized with.
+func (c *SliceCache[T]) InitCap() int {
+	return c.initCap
+}
+
+// new returns a new simple.Cache{} with given length
+// + capacity, with eviction hook updating cache stats.
+func (c *SliceCache[T]) new(len, cap int) *simple.Cache[string, []T] {
+	cache := simple.New[string, []T](len, cap)
+	cache.SetEvictionCallback(func(string, []T) {
+		c.stats.evictions.Add(1)
+	})
+	return cache
 }
 
 // Load will attempt to load an existing slice from cache for key, else calling load function and caching the result.
 func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error) {
+	cache := c.cache.Load()
+
 	// Look for cached values.
-	data, ok := c.cache.Get(key)
+	data, ok := cache.Get(key)
 
 	if !ok {
 		var err error
 
 		// Not cached, load!
+		c.stats.misses.Add(1)
 		data, err = load()
 		if err != nil {
 			return nil, err
 		}
 
 		// Store the data.
-		c.cache.Set(key, data)
+		cache.Set(key, data)
+	} else {
+		c.stats.hits.Add(1)
 	}
 
 	// Return data clone for safety.
@@ -60,27 +101,68 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 
 // Invalidate: see simple.Cache{}.InvalidateAll().
 func (c *SliceCache[T]) Invalidate(keys ...string) {
-	_ = c.cache.InvalidateAll(keys...)
+	_ = c.cache.Load().InvalidateAll(keys...)
+
+	if c.bus != nil && len(keys) > 0 {
+		raw := make([]json.RawMessage, 0, len(keys))
+		for _, key := range keys {
+			b, _ := json.Marshal(key)
+			raw = append(raw, b)
+		}
+		c.bus.publish(&invalidation{
+			Cache: c.name,
+			Keys:  map[string][]json.RawMessage{"": raw},
+		})
+	}
 }
 
 // Trim: see simple.Cache{}.Trim().
 func (c *SliceCache[T]) Trim(perc float64) {
-	c.cache.Trim(perc)
+	cache := c.cache.Load()
+	before := cache.Len()
+	cache.Trim(perc)
+	c.stats.trimmed(before, cache.Len())
 }
 
 // Clear: see simple.Cache{}.Clear().
 func (c *SliceCache[T]) Clear() {
-	c.cache.Clear()
+	c.cache.Load().Clear()
 }
 
 // Len: see simple.Cache{}.Len().
 func (c *SliceCache[T]) Len() int {
-	return c.cache.Len()
+	return c.cache.Load().Len()
 }
 
 // Cap: see simple.Cache{}.Cap().
 func (c *SliceCache[T]) Cap() int {
-	return c.cache.Cap()
+	return c.cache.Load().Cap()
+}
+
+// Stats returns the current usage statistics of the cache.
+func (c *SliceCache[T]) Stats() Stats {
+	return c.stats.snapshot(c.Len(), c.Cap())
+}
+
+func (c *SliceCache[T]) share(name string, bus *invalidator) {
+	c.name = name
+	c.bus = bus
+}
+
+func (c *SliceCache[T]) invalidateShared(keys map[string][]json.RawMessage) error {
+	for _, raw := range keys {
+		strs := make([]string, len(raw))
+		for x := range raw {
+			if err := json.Unmarshal(raw[x], &strs[x]); err != nil {
+				return err
+			}
+		}
+
+		// Skip wrapper func
+		// to avoid republishing.
+		_ = c.cache.Load().InvalidateAll(strs...)
+	}
+	return nil
 }
 
 // StructCache wraps a structr.Cache{} to simple index caching
@@ -89,52 +171,130 @@ func (c *SliceCache[T]) Cap() int {
 // name under the main database caches struct which would reduce
 // time required to access cached values).
 type StructCache[StructType any] struct {
-	cache structr.Cache[StructType]
-	index map[string]*structr.Index
+	cache atomic.Pointer[structCache[StructType]]
+	stats *counters
+
+	// config is the configuration
+	// the cache was initialized with,
+	// used to recreate it on Resize().
+	config structr.CacheConfig[StructType]
+
+	// fields contains the struct fields
+	// making up each index key, by name.
+	fields map[string][]indexField
+
+	// unique contains the names
+	// of all the unique indices.
+	unique []string
+
+	// bus, when set, is used to share
+	// invalidations under cache name.
+	bus  *invalidator
+	name string
 }
 
 // Init initializes the cache with given structr.CacheConfig{}.
 func (c *StructCache[T]) Init(config structr.CacheConfig[T]) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	c.stats = new(counters)
+	c.config = config
+	c.fields = make(map[string][]indexField, len(config.Indices))
+	c.unique = nil
+	for _, cfg := range config.Indices {
+		c.fields[cfg.Fields] = newIndexFields(t, cfg.Fields)
+		if !cfg.Multiple {
+			c.unique = append(c.unique, cfg.Fields)
+		}
+	}
+	c.cache.Store(newStructCache(config))
+}
+
+// Resize replaces the cache with an empty one of given capacity.
+func (c *StructCache[T]) Resize(cap int) {
+	config := c.config
+	config.MaxSize = cap
+	c.cache.Store(newStructCache(config))
+}
+
+// InitCap returns the capacity the cache was initialized with.
+func (c *StructCache[T]) InitCap() int {
+	return c.config.MaxSize
+}
+
+// structCache wraps a structr.Cache{}
+// with its structr.Index{}s by name.
+type structCache[T any] struct {
+	structr.Cache[T]
+	index map[string]*structr.Index
+}
+
+// newStructCache returns a new
+// structCache with given config.
+func newStructCache[T any](config structr.CacheConfig[T]) *structCache[T] {
+	c := new(structCache[T])
+	c.Init(config)
 	c.index = make(map[string]*structr.Index, len(config.Indices))
-	c.cache = structr.Cache[T]{}
-	c.cache.Init(config)
 	for _, cfg := range config.Indices {
-		c.index[cfg.Fields] = c.cache.Index(cfg.Fields)
+		c.index[cfg.Fields] = c.Index(cfg.Fields)
 	}
+	return c
 }
 
 // GetOne calls structr.Cache{}.GetOne(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) GetOne(index string, key ...any) (T, bool) {
-	i := c.index[index]
-	return c.cache.GetOne(i, i.Key(key...))
+	cache := c.cache.Load()
+	i := cache.index[index]
+	value, ok := cache.GetOne(i, i.Key(key...))
+	c.stats.lookup(ok)
+	return value, ok
 }
 
 // Get calls structr.Cache{}.Get(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) Get(index string, keys ...[]any) []T {
-	i := c.index[index]
-	return c.cache.Get(i, i.Keys(keys...)...)
+	cache := c.cache.Load()
+	i := cache.index[index]
+	values := cache.Get(i, i.Keys(keys...)...)
+	c.stats.lookup(len(values) > 0)
+	return values
 }
 
 // Put: see structr.Cache{}.Put().
 func (c *StructCache[T]) Put(values ...T) {
-	c.cache.Put(values...)
+	cache := c.cache.Load()
+	before := cache.Len()
+	cache.Put(values...)
+	c.stats.stored(before, len(values), cache.Cap())
+	c.publishValues(values...)
 }
 
 // LoadOne calls structr.Cache{}.LoadOne(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) LoadOne(index string, load func() (T, error), key ...any) (T, error) {
-	i := c.index[index]
-	return c.cache.LoadOne(i, i.Key(key...), load)
+	cache := c.cache.Load()
+	i := cache.index[index]
+	before := cache.Len()
+	loaded := false
+	value, err := cache.LoadOne(i, i.Key(key...), func() (T, error) {
+		loaded = true
+		return load()
+	})
+	c.stats.lookup(!loaded)
+	if loaded {
+		c.stats.stored(before, 1, cache.Cap())
+	}
+	return value, err
 }
 
 // LoadIDs calls structr.Cache{}.Load(), using a cached structr.Index{} by 'index' name. Note: this also handles
 // conversion of the ID strings to structr.Key{} via structr.Index{}. Strong typing is used for caller convenience.
+// The load callback is only called for IDs not found in the cache, and not at all if every ID was cached.
 //
 // If you need to load multiple cache keys other than by ID strings, please create another convenience wrapper.
 func (c *StructCache[T]) LoadIDs(index string, ids []string, load func([]string) ([]T, error)) ([]T, error) {
-	i := c.index[index]
+	cache := c.cache.Load()
+	i := cache.index[index]
 	if i == nil {
 		// we only perform this check here as
 		// we're going to use the index before
@@ -148,26 +308,65 @@ func (c *StructCache[T]) LoadIDs(index string, ids []string, load func([]string)
 		keys[x] = i.Key(id)
 	}
 
+	var (
+		before = cache.Len()
+		loaded int
+	)
+
 	// Pass loader callback with wrapper onto main cache load function.
-	return c.cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+	values, err := cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+		c.stats.hits.Add(uint64(len(ids) - len(uncached)))
+		c.stats.misses.Add(uint64(len(uncached)))
+
+		if len(uncached) == 0 {
+			// Everything was cached,
+			// skip the load entirely.
+			return nil, nil
+		}
+
 		uncachedIDs := make([]string, len(uncached))
 		for i := range uncached {
 			uncachedIDs[i] = uncached[i].Values()[0].(string)
 		}
-		return load(uncachedIDs)
+
+		values, err := load(uncachedIDs)
+		loaded = len(values)
+		return values, err
 	})
+
+	c.stats.stored(before, loaded, cache.Cap())
+	return values, err
 }
 
 // Store: see structr.Cache{}.Store().
 func (c *StructCache[T]) Store(value T, store func() error) error {
-	return c.cache.Store(value, store)
+	cache := c.cache.Load()
+	before := cache.Len()
+	if err := cache.Store(value, store); err != nil {
+		return err
+	}
+	c.stats.stored(before, 1, cache.Cap())
+	c.publishValues(value)
+	return nil
 }
 
 // Invalidate calls structr.Cache{}.Invalidate(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) Invalidate(index string, key ...any) {
-	i := c.index[index]
-	c.cache.Invalidate(i, i.Key(key...))
+	cache := c.cache.Load()
+	i := cache.index[index]
+	cache.Invalidate(i, i.Key(key...))
+
+	if c.bus != nil {
+		b, err := json.Marshal(key)
+		if err != nil {
+			return
+		}
+		c.bus.publish(&invalidation{
+			Cache: c.name,
+			Keys:  map[string][]json.RawMessage{index: {b}},
+		})
+	}
 }
 
 // InvalidateIDs calls structr.Cache{}.Invalidate(), using a cached structr.Index{} by 'index' name. Note: this also
@@ -175,7 +374,8 @@ func (c *StructCache[T]) Invalidate(index string, key ...any) {
 //
 // If you need to invalidate multiple cache keys other than by ID strings, please create another convenience wrapper.
 func (c *StructCache[T]) InvalidateIDs(index string, ids []string) {
-	i := c.index[index]
+	cache := c.cache.Load()
+	i := cache.index[index]
 	if i == nil {
 		// we only perform this check here as
 		// we're going to use the index before
@@ -190,25 +390,166 @@ func (c *StructCache[T]) InvalidateIDs(index string, ids []string) {
 	}
 
 	// Pass to main invalidate func.
-	c.cache.Invalidate(i, keys...)
+	cache.Invalidate(i, keys...)
+
+	if c.bus != nil && len(ids) > 0 {
+		raw := make([]json.RawMessage, 0, len(ids))
+		for _, id := range ids {
+			b, _ := json.Marshal([]string{id})
+			raw = append(raw, b)
+		}
+		c.bus.publish(&invalidation{
+			Cache: c.name,
+			Keys:  map[string][]json.RawMessage{index: raw},
+		})
+	}
 }
 
 // Trim: see structr.Cache{}.Trim().
 func (c *StructCache[T]) Trim(perc float64) {
-	c.cache.Trim(perc)
+	cache := c.cache.Load()
+	before := cache.Len()
+	cache.Trim(perc)
+	c.stats.trimmed(before, cache.Len())
 }
 
 // Clear: see structr.Cache{}.Clear().
 func (c *StructCache[T]) Clear() {
-	c.cache.Clear()
+	c.cache.Load().Clear()
 }
 
 // Len: see structr.Cache{}.Len().
 func (c *StructCache[T]) Len() int {
-	return c.cache.Len()
+	return c.cache.Load().Len()
 }
 
 // Cap: see structr.Cache{}.Cap().
 func (c *StructCache[T]) Cap() int {
-	return c.cache.Cap()
+	return c.cache.Load().Cap()
+}
+
+// Stats returns the current usage statistics of the cache.
+func (c *StructCache[T]) Stats() Stats {
+	return c.stats.snapshot(c.Len(), c.Cap())
+}
+
+func (c *StructCache[T]) share(name string, bus *invalidator) {
+	c.name = name
+	c.bus = bus
+}
+
+// publishValues publishes an invalidation of the unique index keys of
+// the given stored values, so other replicas drop any stale copies, or
+// cached "not found" errors. Non-unique indices are skipped as dropping
+// everything under those keys would needlessly evict other values.
+func (c *StructCache[T]) publishValues(values ...T) {
+	if c.bus == nil || len(values) == 0 {
+		return
+	}
+
+	keys := make(map[string][]json.RawMessage, len(c.unique))
+	for _, index := range c.unique {
+		for _, value := range values {
+			if key, ok := extract(value, c.fields[index]); ok {
+				keys[index] = append(keys[index], key)
+			}
+		}
+	}
+
+	c.bus.publish(&invalidation{
+		Cache: c.name,
+		Keys:  keys,
+	})
+}
+
+func (c *StructCache[T]) invalidateShared(keys map[string][]json.RawMessage) error {
+	cache := c.cache.Load()
+	for index, raw := range keys {
+		i := cache.index[index]
+		if i == nil {
+			return gtserror.Newf("unknown index: %s", index)
+		}
+
+		parts := make([][]any, 0, len(raw))
+		for _, key := range raw {
+			p, err := decode(key, c.fields[index])
+			if err != nil {
+				return err
+			}
+			parts = append(parts, p)
+		}
+
+		// Skip wrapper func
+		// to avoid republishing.
+		cache.Invalidate(i, i.Keys(parts...)...)
+	}
+	return nil
+}
+
+// Stats contains usage statistics of a cache,
+// such that cache sizing may be tuned using them.
+type Stats struct {
+	// Hits is the number of lookups
+	// served directly from the cache.
+	Hits uint64
+
+	// Misses is the number of lookups
+	// that required loading from source.
+	Misses uint64
+
+	// Evictions is the number of items dropped from the
+	// cache to make room for others, either on reaching
+	// capacity, or when trimmed during a cache sweep.
+	Evictions uint64
+
+	// Size is the current number of cached items.
+	Size int
+
+	// Capacity is the maximum number of cached items.
+	Capacity int
+}
+
+// counters wraps atomic cache usage counters.
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// lookup increments hits or misses depending on 'hit'.
+func (c *counters) lookup(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// stored increments evictions by the number of items the storing of 'n'
+// items must have evicted, given cache length 'before' and capacity 'cap'.
+// Note this is an estimate, as items may overwrite already cached items.
+func (c *counters) stored(before int, n int, cap int) {
+	if over := before + n - cap; over > 0 {
+		c.evictions.Add(uint64(min(over, n)))
+	}
+}
+
+// trimmed increments evictions by the number of items
+// dropped by a cache trim, given length before and after.
+func (c *counters) trimmed(before int, after int) {
+	if diff := before - after; diff > 0 {
+		c.evictions.Add(uint64(diff))
+	}
+}
+
+// snapshot returns current counter values
+// as Stats, with given cache length and capacity.
+func (c *counters) snapshot(len int, cap int) Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      len,
+		Capacity:  cap,
+	}
 }
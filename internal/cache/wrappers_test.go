@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache_test
+
+import (
+	"testing"
+
+	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+)
+
+type item struct{ ID string }
+
+func TestStructCacheStats(t *testing.T) {
+	var c cache.StructCache[*item]
+	c.Init(structr.CacheConfig[*item]{
+		Indices: []structr.IndexConfig{{Fields: "ID"}},
+		MaxSize: 2,
+		Copy:    func(i *item) *item { i2 := *i; return &i2 },
+	})
+
+	load := func(ids []string) ([]*item, error) {
+		items := make([]*item, len(ids))
+		for x, id := range ids {
+			items[x] = &item{ID: id}
+		}
+		return items, nil
+	}
+
+	// Initial load should miss for both.
+	if _, err := c.LoadIDs("ID", []string{"a", "b"}, load); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both now cached, so should hit.
+	if _, err := c.LoadIDs("ID", []string{"a", "b"}, load); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading a third should evict one.
+	if _, err := c.LoadIDs("ID", []string{"c"}, load); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 3 || stats.Evictions != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats.Size != 2 || stats.Capacity != 2 {
+		t.Fatalf("unexpected size: %+v", stats)
+	}
+
+	// Trimming should count as evictions.
+	c.Trim(0)
+	if stats := c.Stats(); stats.Evictions != 3 || stats.Size != 0 {
+		t.Fatalf("unexpected stats after trim: %+v", stats)
+	}
+}
+
+func TestSliceCacheStats(t *testing.T) {
+	var c cache.SliceCache[string]
+	c.Init(0, 1)
+
+	load := func() ([]string, error) { return []string{"a"}, nil }
+
+	for _, key := range []string{"1", "1", "2"} {
+		if _, err := c.Load(key, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Evictions != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
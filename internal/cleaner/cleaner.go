@@ -25,18 +25,30 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 const (
 	selectLimit = 50
+
+	// unusedGrace is the time after which
+	// unused media is considered prunable,
+	// since it was last updated.
+	unusedGrace = 24 * time.Hour
+
+	// expiredEvery is how often
+	// expired mutes and filters
+	// are removed from the database.
+	expiredEvery = time.Hour
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state   *state.State
+	emoji   Emoji
+	media   Media
+	expired Expired
 }
 
 func New(state *state.State) *Cleaner {
@@ -44,6 +56,7 @@ func New(state *state.State) *Cleaner {
 	c.state = state
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.expired.Cleaner = c
 	return c
 }
 
@@ -57,9 +70,20 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Expired returns the expired set of cleaner utilities.
+func (c *Cleaner) Expired() *Expired {
+	return &c.expired
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
+		if file == "" {
+			// Not expected, e.g.
+			// audio with no thumb.
+			continue
+		}
+
 		// Check whether each file exists in storage.
 		have, err := c.state.Storage.Has(ctx, file)
 		if err != nil {
@@ -72,6 +96,12 @@ func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error)
 	return true, nil
 }
 
+// webpVariant returns the storage path of any WebP
+// variant stored alongside derived image at path.
+func webpVariant(path string) string {
+	return media.WebPVariantPath(path)
+}
+
 // removeFiles removes the provided files, returning the number of them returned.
 func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error) {
 	if gtscontext.DryRun(ctx) {
@@ -85,6 +115,11 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	)
 
 	for _, path := range files {
+		if path == "" {
+			// Nothing stored.
+			continue
+		}
+
 		// Remove each provided storage path.
 		log.Debugf(ctx, "removing file: %s", path)
 		err := c.state.Storage.Delete(ctx, path)
@@ -106,7 +141,9 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 }
 
 // ScheduleJobs schedules cleaning
-// jobs using configured parameters.
+// jobs using configured parameters,
+// as well as the hourly clean of
+// expired mutes and filters.
 //
 // Returns an error if `MediaCleanupFrom`
 // is not a valid format (hh:mm:ss).
@@ -159,6 +196,14 @@ func (c *Cleaner) ScheduleJobs() error {
 		cleanupEvery, cleanupFromStr, firstCleanupAt,
 	)
 
+	// Ensure only one process sharing
+	// the database runs the cleaning.
+	fn = c.state.Workers.Scheduler.Exclusive(
+		"@mediacleanup",
+		cleanupEvery/2,
+		fn,
+	)
+
 	// Schedule the cleaning to execute according to schedule.
 	if !c.state.Workers.Scheduler.AddRecurring(
 		"@mediacleanup",
@@ -169,5 +214,30 @@ func (c *Cleaner) ScheduleJobs() error {
 		panic("failed to schedule @mediacleanup")
 	}
 
+	expiredFn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting expired clean")
+		c.Expired().All(ctx)
+		log.Infof(ctx, "finished expired clean after %s", time.Since(start))
+	}
+
+	// Ensure only one process sharing
+	// the database runs the cleaning.
+	expiredFn = c.state.Workers.Scheduler.Exclusive(
+		"@expiredcleanup",
+		expiredEvery/2,
+		expiredFn,
+	)
+
+	// Schedule expired mute / filter
+	// cleaning to run every hour.
+	if !c.state.Workers.Scheduler.AddRecurring(
+		"@expiredcleanup",
+		time.Time{},
+		expiredEvery,
+		expiredFn,
+	) {
+		panic("failed to schedule @expiredcleanup")
+	}
+
 	return nil
 }
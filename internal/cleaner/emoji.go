@@ -65,6 +65,16 @@ func (e *Emoji) LogFixBroken(ctx context.Context) {
 	}
 }
 
+// LogMissingFiles performs Emoji.MissingFiles(...), logging the start and outcome.
+func (e *Emoji) LogMissingFiles(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := e.MissingFiles(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "missing: %d", n)
+	}
+}
+
 // LogPruneUnused performs Emoji.PruneUnused(...), logging the start and outcome.
 func (e *Emoji) LogPruneUnused(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -183,6 +193,62 @@ func (e *Emoji) FixBroken(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// MissingFiles will check all cached emoji for files missing from storage (i.e. database
+// entries missing media), logging each and returning the count. This only reports, as local
+// emoji files can't be recovered, and remote emoji are uncached by Emoji.FixCacheStates().
+func (e *Emoji) MissingFiles(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of emoji to next max ID.
+		emojis, err := e.state.DB.GetEmojis(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting emojis: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no emoji or the same group is returned, we reached end.
+		if len(emojis) == 0 || maxID == emojis[len(emojis)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID'.
+		maxID = emojis[len(emojis)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, emoji := range emojis {
+			if !*emoji.Cached {
+				// Files not expected.
+				continue
+			}
+
+			// Check whether files exist.
+			exist, err := e.haveFiles(ctx,
+				emoji.ImageStaticPath,
+				emoji.ImagePath,
+			)
+			if err != nil {
+				return total, err
+			}
+
+			if !exist {
+				log.Infof(ctx, "emoji %s missing files in storage", emoji.ID)
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // PruneUnused will delete all unused emoji media from the database and storage driver.
 // Context will be checked for `gtscontext.DryRun()` to perform the action. NOTE: this function
 // should be updated to match media.FixCacheStat() if we ever support emoji uncaching.
@@ -340,6 +406,7 @@ func (e *Emoji) fixCacheState(ctx context.Context, emoji *gtsmodel.Emoji) (bool,
 		l.Debug("cached=false exists=true => removing files")
 		_, err := e.removeFiles(ctx,
 			emoji.ImageStaticPath,
+			webpVariant(emoji.ImageStaticPath),
 			emoji.ImagePath,
 		)
 		return true, err
@@ -477,6 +544,7 @@ func (e *Emoji) uncache(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	_, err := e.removeFiles(ctx,
 		emoji.ImagePath,
 		emoji.ImageStaticPath,
+		webpVariant(emoji.ImageStaticPath),
 	)
 	if err != nil {
 		return gtserror.Newf("error removing emoji files: %w", err)
@@ -501,6 +569,7 @@ func (e *Emoji) delete(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	// Remove emoji and static files.
 	_, err := e.removeFiles(ctx,
 		emoji.ImageStaticPath,
+		webpVariant(emoji.ImageStaticPath),
 		emoji.ImagePath,
 	)
 	if err != nil {
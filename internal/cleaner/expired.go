@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Expired encompasses a set of utilities
+// for cleaning up database entries that
+// have passed their expiry time, e.g. mutes.
+type Expired struct{ *Cleaner }
+
+// All will execute all cleaner.Expired utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (e *Expired) All(ctx context.Context) {
+	now := time.Now()
+	e.LogMutes(ctx, now)
+	e.LogFilters(ctx, now)
+}
+
+// LogMutes performs Expired.Mutes(...), logging the start and outcome.
+func (e *Expired) LogMutes(ctx context.Context, now time.Time) {
+	log.Info(ctx, "start")
+	if n, err := e.Mutes(ctx, now); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LogFilters performs Expired.Filters(...), logging the start and outcome.
+func (e *Expired) LogFilters(ctx context.Context, now time.Time) {
+	log.Info(ctx, "start")
+	if n, err := e.Filters(ctx, now); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// Mutes deletes all user mutes that expired at or before now, returning the number deleted.
+// Deletion goes through the database cache, so cached mutes are invalidated too.
+// Context will be checked for `gtscontext.DryRun()` to perform the action.
+func (e *Expired) Mutes(ctx context.Context, now time.Time) (int, error) {
+	muteIDs, err := e.state.DB.GetExpiredMuteIDs(ctx, now)
+	if err != nil {
+		return 0, gtserror.Newf("error getting expired mutes: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(muteIDs), nil
+	}
+
+	for i, id := range muteIDs {
+		if err := e.state.DB.DeleteMuteByID(ctx, id); err != nil {
+			return i, gtserror.Newf("error deleting mute %s: %w", id, err)
+		}
+	}
+
+	return len(muteIDs), nil
+}
+
+// Filters deletes all filters that expired at or before now, along with their keywords
+// and statuses, returning the number of filters deleted. Deletion goes through
+// the database cache, so cached filters are invalidated too.
+// Context will be checked for `gtscontext.DryRun()` to perform the action.
+func (e *Expired) Filters(ctx context.Context, now time.Time) (int, error) {
+	filterIDs, err := e.state.DB.GetExpiredFilterIDs(ctx, now)
+	if err != nil {
+		return 0, gtserror.Newf("error getting expired filters: %w", err)
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(filterIDs), nil
+	}
+
+	for i, id := range filterIDs {
+		if err := e.state.DB.DeleteFilterByID(ctx, id); err != nil {
+			return i, gtserror.Newf("error deleting filter %s: %w", id, err)
+		}
+	}
+
+	return len(filterIDs), nil
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) putTestMutes() (expired *gtsmodel.UserMute, unexpired *gtsmodel.UserMute) {
+	var (
+		ctx      = context.Background()
+		accounts = testrig.NewTestAccounts()
+		now      = time.Now()
+	)
+
+	expired = &gtsmodel.UserMute{
+		ID:              "01J1GQJ4X0WYBJ5D3DSJ5JN9ZT",
+		ExpiresAt:       now.Add(-time.Minute),
+		AccountID:       accounts["local_account_1"].ID,
+		TargetAccountID: accounts["local_account_2"].ID,
+		Notifications:   util.Ptr(false),
+	}
+	unexpired = &gtsmodel.UserMute{
+		ID:              "01J1GQJ9V5GRW03Y3J5QF0P2J6",
+		ExpiresAt:       now.Add(time.Hour),
+		AccountID:       accounts["local_account_1"].ID,
+		TargetAccountID: accounts["admin_account"].ID,
+		Notifications:   util.Ptr(false),
+	}
+
+	for _, mute := range []*gtsmodel.UserMute{expired, unexpired} {
+		if err := suite.state.DB.PutMute(ctx, mute); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return expired, unexpired
+}
+
+func (suite *CleanerTestSuite) TestExpiredMutes() {
+	ctx := context.Background()
+	expired, unexpired := suite.putTestMutes()
+
+	// Load the expired mute into the cache.
+	if _, err := suite.state.DB.GetMuteByID(ctx, expired.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	n, err := suite.cleaner.Expired().Mutes(ctx, time.Now())
+	suite.NoError(err)
+	suite.Equal(1, n)
+
+	// The expired mute should be gone, from the cache too.
+	_, err = suite.state.DB.GetMuteByID(ctx, expired.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// The unexpired mute should be left alone.
+	_, err = suite.state.DB.GetMuteByID(ctx, unexpired.ID)
+	suite.NoError(err)
+}
+
+func (suite *CleanerTestSuite) TestExpiredMutesDryRun() {
+	ctx := gtscontext.SetDryRun(context.Background())
+	expired, _ := suite.putTestMutes()
+
+	n, err := suite.cleaner.Expired().Mutes(ctx, time.Now())
+	suite.NoError(err)
+	suite.Equal(1, n)
+
+	// Nothing should have been deleted.
+	_, err = suite.state.DB.GetMuteByID(ctx, expired.ID)
+	suite.NoError(err)
+}
+
+func (suite *CleanerTestSuite) TestExpiredFilters() {
+	var (
+		ctx     = context.Background()
+		filters = testrig.NewTestFilters()
+		expired = filters["local_account_1_filter_1"]
+	)
+
+	// Expire one of the test filters and load it into the cache.
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.state.DB.UpdateFilter(ctx, expired, []string{"expires_at"}, nil, nil, nil); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.state.DB.GetFilterByID(ctx, expired.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	n, err := suite.cleaner.Expired().Filters(ctx, time.Now())
+	suite.NoError(err)
+	suite.Equal(1, n)
+
+	// The expired filter should be gone, along with its keywords.
+	_, err = suite.state.DB.GetFilterByID(ctx, expired.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	keywords, err := suite.state.DB.GetFilterKeywordsForFilterID(ctx, expired.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(keywords)
+
+	// The rest of the account's filters should be left alone.
+	remaining, err := suite.state.DB.GetFiltersForAccountID(ctx, expired.AccountID)
+	suite.NoError(err)
+	suite.Len(remaining, 3)
+}
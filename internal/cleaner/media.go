@@ -68,6 +68,16 @@ func (m *Media) LogPruneOrphaned(ctx context.Context) {
 	}
 }
 
+// LogMissingFiles performs Media.MissingFiles(...), logging the start and outcome.
+func (m *Media) LogMissingFiles(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.MissingFiles(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "missing: %d", n)
+	}
+}
+
 // LogPruneUnused performs Media.PruneUnused(...), logging the start and outcome.
 func (m *Media) LogPruneUnused(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -109,6 +119,7 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 
 		if orphaned {
 			// Add this orphaned entry.
+			log.Infof(ctx, "orphaned storage item: %s", path)
 			files = append(files, path)
 		}
 
@@ -121,6 +132,62 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 	return m.removeFiles(ctx, files...)
 }
 
+// MissingFiles will check all cached media attachments for files missing from storage (i.e. database
+// entries missing media), logging each and returning the count. This only reports, as media files
+// can't be recovered for local media, and remote media is uncached by Media.FixCacheStates().
+func (m *Media) MissingFiles(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments up to next max ID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			if !*media.Cached {
+				// Files not expected.
+				continue
+			}
+
+			// Check whether files exist.
+			exist, err := m.haveFiles(ctx,
+				media.Thumbnail.Path,
+				media.File.Path,
+			)
+			if err != nil {
+				return total, err
+			}
+
+			if !exist {
+				log.Infof(ctx, "media %s missing files in storage", media.ID)
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // PruneUnused will delete all unused media attachments from the database and storage driver.
 // Media is marked as unused if not attached to any status, account or account is suspended.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -170,7 +237,8 @@ func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 	return total, nil
 }
 
-// UncacheRemote will uncache all remote media attachments older than given input time.
+// UncacheRemote will uncache all remote media attachments older than given input time,
+// or older than the time given by any domain media policy covering the media's owner.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
 	var total int
@@ -182,6 +250,23 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	// Store recent time.
 	mostRecent := olderThan
 
+	// Fetch all domain media policies, which
+	// may require uncaching more recent media.
+	policies, err := m.state.DB.GetDomainMediaPolicies(ctx)
+	if err != nil {
+		return total, gtserror.Newf("error getting domain media policies: %w", err)
+	}
+
+	now := time.Now()
+	for _, policy := range policies {
+		// Start search from the most recent
+		// time any policy would uncache from.
+		after := policy.UncacheAfter(now, mostRecent)
+		if after.After(olderThan) {
+			olderThan = after
+		}
+	}
+
 	for {
 		// Fetch the next batch of cached attachments older than last-set time.
 		attachments, err := m.state.DB.GetCachedAttachmentsOlderThan(ctx, olderThan, selectLimit)
@@ -199,8 +284,28 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 		olderThan = attachments[len(attachments)-1].CreatedAt
 
 		for _, media := range attachments {
+			after := mostRecent
+
+			if len(policies) > 0 {
+				// Determine uncache time for media
+				// from any covering domain policy.
+				after, err = m.uncacheAfter(ctx,
+					now,
+					mostRecent,
+					media,
+				)
+				if err != nil {
+					return total, err
+				}
+
+				if media.CreatedAt.After(after) {
+					// Too recent under policy.
+					continue
+				}
+			}
+
 			// Check / uncache each remote media attachment.
-			uncached, err := m.uncacheRemote(ctx, mostRecent, media)
+			uncached, err := m.uncacheRemote(ctx, after, media)
 			if err != nil {
 				return total, err
 			}
@@ -374,6 +479,14 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	if time.Since(media.UpdatedAt) < unusedGrace {
+		// Media may have only just been uploaded, or
+		// unattached from a deleted status to be reused
+		// (e.g. delete + redraft), give it some time.
+		l.Debug("skipping as recently updated")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -422,6 +535,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 		l.Debug("cached=false exists=true => deleting")
 		_, err := m.removeFiles(ctx,
 			media.Thumbnail.Path,
+			webpVariant(media.Thumbnail.Path),
 			media.File.Path,
 		)
 		return true, err
@@ -431,6 +545,37 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	}
 }
 
+// uncacheAfter returns the time before which given remote media should be
+// uncached, according to any domain media policy covering the media's owner.
+func (m *Media) uncacheAfter(ctx context.Context, now time.Time, def time.Time, media *gtsmodel.MediaAttachment) (time.Time, error) {
+	// Fetch owning account, only the
+	// domain is needed so keep barebones.
+	account, err := m.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		media.AccountID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Use the default.
+			return def, nil
+		}
+		return def, gtserror.Newf("error getting media owner account: %w", err)
+	}
+
+	policy, err := m.state.DB.MatchDomainMediaPolicy(ctx, account.Domain)
+	if err != nil {
+		return def, gtserror.Newf("error matching domain media policy: %w", err)
+	}
+
+	if policy == nil {
+		// No policy,
+		// use default.
+		return def, nil
+	}
+
+	return policy.UncacheAfter(now, def), nil
+}
+
 func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmodel.MediaAttachment) (bool, error) {
 	if !*media.Cached {
 		// Already uncached.
@@ -552,6 +697,7 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	_, err := m.removeFiles(ctx,
 		media.File.Path,
 		media.Thumbnail.Path,
+		webpVariant(media.Thumbnail.Path),
 	)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
@@ -577,6 +723,7 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 	_, err := m.removeFiles(ctx,
 		media.File.Path,
 		media.Thumbnail.Path,
+		webpVariant(media.Thumbnail.Path),
 	)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
@@ -36,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -292,6 +293,29 @@ func (suite *MediaTestSuite) TearDownTest() {
 // 	suite.ErrorIs(err, db.ErrNoEntries)
 // }
 
+func (suite *MediaTestSuite) TestMissingFiles() {
+	ctx := context.Background()
+
+	// Get the no. testrig media already missing files.
+	missingBefore, err := suite.cleaner.Media().MissingFiles(ctx)
+	suite.NoError(err)
+
+	// Remove the file of a local attachment from storage.
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	if err := suite.storage.Delete(ctx, testAttachment.File.Path); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	missing, err := suite.cleaner.Media().MissingFiles(ctx)
+	suite.NoError(err)
+	suite.Equal(missingBefore+1, missing)
+
+	// The entry should only have been reported, not changed.
+	attachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.True(*attachment.Cached)
+}
+
 func (suite *MediaTestSuite) TestUncacheRemote() {
 	ctx := context.Background()
 
@@ -338,6 +362,41 @@ func (suite *MediaTestSuite) TestUncacheRemoteDry() {
 	suite.True(*uncachedAttachment.Cached)
 }
 
+func (suite *MediaTestSuite) TestUncacheRemoteDomainMediaPolicy() {
+	ctx := context.Background()
+
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.True(*testStatusAttachment.Cached)
+
+	testHeader := suite.testAttachments["remote_account_3_header"]
+	suite.True(*testHeader.Cached)
+
+	// Never cache media from remote_account_1's domain.
+	if err := suite.db.PutDomainMediaPolicy(ctx, &gtsmodel.DomainMediaPolicy{
+		ID:                 "01J1TP2W8AW4Y4ZNB6N3ZWE3ZQ",
+		Domain:             "fossbros-anonymous.io",
+		NeverCache:         util.Ptr(true),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Default time far enough back
+	// that nothing else is uncached.
+	after := time.Now().Add(-100 * 365 * 24 * time.Hour)
+	totalUncached, err := suite.cleaner.Media().UncacheRemote(ctx, after)
+	suite.NoError(err)
+	suite.Equal(1, totalUncached)
+
+	uncachedAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.False(*uncachedAttachment.Cached)
+
+	cachedAttachment, err := suite.db.GetAttachmentByID(ctx, testHeader.ID)
+	suite.NoError(err)
+	suite.True(*cachedAttachment.Cached)
+}
+
 func (suite *MediaTestSuite) TestUncacheRemoteTwice() {
 	ctx := context.Background()
 	after := time.Now().Add(-24 * time.Hour)
@@ -74,42 +74,54 @@ type Configuration struct {
 	DbSqliteCacheSize        bytesize.Size `name:"db-sqlite-cache-size" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_cache_size"`
 	DbSqliteBusyTimeout      time.Duration `name:"db-sqlite-busy-timeout" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_busy_timeout"`
 
+	RedisURL string `name:"redis-url" usage:"URL of a Redis server used to share cache invalidations and streaming events between multiple GoToSocial replicas, eg., redis://localhost:6379. Empty disables this."`
+
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
-
-	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
-	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
-	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
-	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
-	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
-	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
-	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
-	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
-
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	InstanceFederationMode                string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter          bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceFederationRepliesMaxDepth     int                `name:"instance-federation-replies-max-depth" usage:"Max depth of nested replies to follow when backfilling replies to a remote status from its replies collection."`
+	InstanceFederationRepliesMaxCount     int                `name:"instance-federation-replies-max-count" usage:"Max number of replies to dereference when backfilling replies to a remote status from its replies collection. 0 disables replies backfill."`
+	InstanceFederationOutboxBackfillCount int                `name:"instance-federation-outbox-backfill-count" usage:"Number of most recent statuses to fetch from the outbox of newly discovered remote accounts, so their profile isn't empty. 0 disables outbox backfill."`
+	InstanceExposePeers                   bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended               bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb            bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline          bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes        bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion         bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                     language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+
+	AccountsRegistrationOpen bool   `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsReasonRequired   bool   `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsAllowCustomCSS   bool   `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength  int    `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsAllowUserInvites bool   `name:"accounts-allow-user-invites" usage:"Allow all users to generate invite codes for new sign-ups, rather than only admins and moderators."`
+	AccountsExportPath       string `name:"accounts-export-path" usage:"Full path to a directory where account data export archives should be stored until downloaded. If empty, a directory within the system temporary directory is used."`
+
+	MediaImageMaxSize            bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
+	MediaVideoMaxSize            bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
+	MediaDescriptionMinChars     int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
+	MediaDescriptionMaxChars     int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
+	MediaRemoteCacheDays         int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	MediaEmojiLocalMaxSize       bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
+	MediaEmojiRemoteMaxSize      bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
+	MediaMetadataStripAll        bool          `name:"media-metadata-strip-all" usage:"Strip all metadata from images, including orientation, ICC color profiles, XMP, IPTC, comments and text chunks."`
+	MediaMetadataKeepOrientation bool          `name:"media-metadata-keep-orientation" usage:"Keep the EXIF orientation tag of images when stripping their metadata, so they still display the right way up."`
+	MediaMetadataKeepICC         bool          `name:"media-metadata-keep-icc" usage:"Keep embedded ICC color profiles of images when stripping their metadata."`
+	MediaDerivedFormat           string        `name:"media-derived-format" usage:"Additional modern image format to store thumbnails and static images in, served to clients that accept it. Options: ['', 'webp']."`
+	MediaCleanupFrom             string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
+	MediaCleanupEvery            time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+
+	StorageBackend             string        `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath       string        `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageS3Endpoint          string        `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey         string        `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey         string        `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL            bool          `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName        string        `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy             bool          `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3RedirectURLExpiry time.Duration `name:"storage-s3-redirect-url-expiry" usage:"Duration for which presigned S3 redirect URLs remain valid. Must be at least 10 minutes."`
 
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
@@ -156,14 +168,25 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
-	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
-	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedCookiesSamesite         string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests       int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitExceptions     []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
+	AdvancedThrottlingMultiplier    int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter    time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier        int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedDeliveryMaxAttempts     int           `name:"advanced-delivery-max-attempts" usage:"Max number of times to re-attempt (with exponential backoff) outgoing fedi messages that repeatedly fail to deliver. 0 disables persisting failed messages for retry."`
+	AdvancedDeliveryDeadHostAfter   time.Duration `name:"advanced-delivery-dead-host-after" usage:"Pause outgoing fedi messages to hosts that have been consistently failing to receive them for this long, resuming once a periodic probe delivery succeeds. 0 disables."`
+	AdvancedActivityConcurrency     []string      `name:"advanced-activity-concurrency" usage:"Limits on the no. client / federator worker messages of an ActivityPub activity type (optionally with object type) that may be processed concurrently, in the form 'Type=N' or 'Type/ObjectType=N', e.g. 'Create/Note=8'."`
+	AdvancedPersistWorkerQueues     bool          `name:"advanced-persist-worker-queues" usage:"Store client / federator messages still queued for processing in the database on shutdown, and re-queue them on next startup, so side effects of these messages aren't dropped on restart."`
+	AdvancedFederatorSpoolThreshold int           `name:"advanced-federator-spool-threshold" usage:"Length of the federator worker queue at which incoming activities are spooled to the database, to be queued again once the queue has drained, instead of held in memory. 0 disables spooling."`
+	AdvancedWorkerDrainTimeout      time.Duration `name:"advanced-worker-drain-timeout" usage:"Max time to wait on shutdown for client / federator / dereference workers to finish messages they're currently processing, before cancelling them. 0 cancels immediately."`
+	AdvancedStreamingMaxPerAccount  int           `name:"advanced-streaming-max-per-account" usage:"Max number of streaming connections that may be open at once per account. 0 or less means no limit."`
+	AdvancedStreamingMaxTotal       int           `name:"advanced-streaming-max-total" usage:"Max number of streaming connections that may be open at once across the whole instance. 0 or less means no limit."`
+	AdvancedStreamingBufferSize     int           `name:"advanced-streaming-buffer-size" usage:"Number of messages that may be buffered for sending on a streaming connection. Connections of clients too slow to keep this buffer from filling are closed."`
+	AdvancedStreamingPingInterval   time.Duration `name:"advanced-streaming-ping-interval" usage:"Interval at which to send heartbeat pings over otherwise quiet streaming connections."`
+	AdvancedStreamingIdleTimeout    time.Duration `name:"advanced-streaming-idle-timeout" usage:"Close streaming connections on which the client hasn't responded to pings, or on which writes have been blocked, for this long. 0 disables."`
+	AdvancedCSPExtraURIs            []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
+	AdvancedHeaderFilterMode        string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -172,13 +195,23 @@ type Configuration struct {
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername     string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
-	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
-	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminAccountUsername     string        `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail        string        `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword     string        `name:"password" usage:"the password to set for this account"`
+	AdminTransPath           string        `name:"path" usage:"the path of the file to import from/export to"`
+	AdminMediaPruneDryRun    bool          `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminMediaListLocalOnly  bool          `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
+	AdminMediaListRemoteOnly bool          `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminMediaMigrateTo      string        `name:"to" usage:"the storage backend to copy media to; one of 'local' or 's3'"`
+	AdminKeysRotateUsers     bool          `name:"users" usage:"also rotate the keys of all local user accounts, not just the instance account"`
+	AdminKeysRotateFederate  bool          `name:"federate" usage:"send Update activities for rotated user accounts so remote servers refresh their keys"`
+	AdminDeliveryDomain      string        `name:"domain" usage:"only include failed deliveries targeting this domain"`
+	AdminDeliveryType        string        `name:"activity-type" usage:"only include failed deliveries of this activity type, eg., Create"`
+	AdminInviteMaxUses       int           `name:"max-uses" usage:"number of sign-ups the invite may be used for; 0 means unlimited"`
+	AdminInviteExpiresIn     time.Duration `name:"expires-in" usage:"duration after which the invite expires, eg., 72h; 0 means never"`
+	AdminInviteCode          string        `name:"code" usage:"the invite code to revoke"`
+	AdminEmojiCategory       string        `name:"category" usage:"only export emojis in this category / place all imported emojis in this category"`
+	AdminEmojiOnConflict     string        `name:"on-conflict" usage:"how to handle imported emojis whose shortcode is already in use; one of 'skip', 'replace' or 'rename'"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -188,6 +221,22 @@ type HTTPClientConfiguration struct {
 	BlockIPs              []string      `name:"block-ips"`
 	Timeout               time.Duration `name:"timeout"`
 	TLSInsecureSkipVerify bool          `name:"tls-insecure-skip-verify"`
+	HostRequestBudget     int           `name:"host-request-budget"`
+	HostRequestWindow     time.Duration `name:"host-request-window"`
+
+	DeliveryTimeout        time.Duration `name:"delivery-timeout"`
+	DeliveryMaxBodySize    bytesize.Size `name:"delivery-max-body-size"`
+	DereferenceTimeout     time.Duration `name:"dereference-timeout"`
+	DereferenceMaxBodySize bytesize.Size `name:"dereference-max-body-size"`
+	MediaTimeout           time.Duration `name:"media-timeout"`
+	MediaMaxBodySize       bytesize.Size `name:"media-max-body-size"`
+
+	Proxy      string `name:"proxy"`
+	OnionProxy string `name:"onion-proxy"`
+	I2PProxy   string `name:"i2p-proxy"`
+
+	CircuitBreakerFailures int           `name:"circuit-breaker-failures"`
+	CircuitBreakerCooldown time.Duration `name:"circuit-breaker-cooldown"`
 }
 
 type CacheConfiguration struct {
@@ -235,6 +284,8 @@ type CacheConfiguration struct {
 	UserMemRatio              float64       `name:"user-mem-ratio"`
 	UserMuteMemRatio          float64       `name:"user-mute-mem-ratio"`
 	UserMuteIDsMemRatio       float64       `name:"user-mute-ids-mem-ratio"`
+	UnretrievableMemRatio     float64       `name:"unretrievable-mem-ratio"`
+	InboxActivityMemRatio     float64       `name:"inbox-activity-mem-ratio"`
 	WebfingerMemRatio         float64       `name:"webfinger-mem-ratio"`
 	VisibilityMemRatio        float64       `name:"visibility-mem-ratio"`
 }
@@ -29,4 +29,9 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Media derived format determines which additional
+	// format (if any) derived images are re-encoded to.
+	MediaDerivedFormatWebP     = "webp"
+	MediaDerivedFormatDisabled = ""
 )
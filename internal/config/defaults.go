@@ -58,33 +58,43 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:                InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:          false,
+	InstanceFederationRepliesMaxDepth:     16,
+	InstanceFederationRepliesMaxCount:     512,
+	InstanceFederationOutboxBackfillCount: 0,
+	InstanceExposePeers:                   false,
+	InstanceExposeSuspended:               false,
+	InstanceExposeSuspendedWeb:            false,
+	InstanceDeliverToSharedInboxes:        true,
+	InstanceLanguages:                     make(language.Languages, 0),
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
+	AccountsAllowUserInvites: false,
+	AccountsExportPath:       "",
 
-	MediaImageMaxSize:        10 * bytesize.MiB,
-	MediaVideoMaxSize:        40 * bytesize.MiB,
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 1500,
-	MediaRemoteCacheDays:     7,
-	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
-	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
-	MediaCleanupFrom:         "00:00",        // Midnight.
-	MediaCleanupEvery:        24 * time.Hour, // 1/day.
-
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	MediaImageMaxSize:            10 * bytesize.MiB,
+	MediaVideoMaxSize:            40 * bytesize.MiB,
+	MediaDescriptionMinChars:     0,
+	MediaDescriptionMaxChars:     1500,
+	MediaRemoteCacheDays:         7,
+	MediaEmojiLocalMaxSize:       50 * bytesize.KiB,
+	MediaEmojiRemoteMaxSize:      100 * bytesize.KiB,
+	MediaMetadataStripAll:        false,
+	MediaMetadataKeepOrientation: true,
+	MediaMetadataKeepICC:         true,
+	MediaDerivedFormat:           MediaDerivedFormatDisabled,
+	MediaCleanupFrom:             "00:00",        // Midnight.
+	MediaCleanupEvery:            24 * time.Hour, // 1/day.
+
+	StorageBackend:             "local",
+	StorageLocalBasePath:       "/gotosocial/storage",
+	StorageS3UseSSL:            true,
+	StorageS3Proxy:             false,
+	StorageS3RedirectURLExpiry: 24 * time.Hour,
 
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
@@ -127,14 +137,25 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
-	AdvancedThrottlingRetryAfter: time.Second * 30,
-	AdvancedSenderMultiplier:     2, // 2 senders per CPU
-	AdvancedCSPExtraURIs:         []string{},
-	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
+	AdvancedCookiesSamesite:         "lax",
+	AdvancedRateLimitRequests:       300, // 1 per second per 5 minutes
+	AdvancedRateLimitExceptions:     []string{},
+	AdvancedThrottlingMultiplier:    8, // 8 open requests per CPU
+	AdvancedThrottlingRetryAfter:    time.Second * 30,
+	AdvancedSenderMultiplier:        2,  // 2 senders per CPU
+	AdvancedDeliveryMaxAttempts:     12, // ~3 days of retries
+	AdvancedDeliveryDeadHostAfter:   24 * time.Hour,
+	AdvancedActivityConcurrency:     []string{},
+	AdvancedPersistWorkerQueues:     false,
+	AdvancedFederatorSpoolThreshold: 0,
+	AdvancedWorkerDrainTimeout:      30 * time.Second,
+	AdvancedStreamingMaxPerAccount:  20,
+	AdvancedStreamingMaxTotal:       0,
+	AdvancedStreamingBufferSize:     50,
+	AdvancedStreamingPingInterval:   30 * time.Second,
+	AdvancedStreamingIdleTimeout:    90 * time.Second,
+	AdvancedCSPExtraURIs:            []string{},
+	AdvancedHeaderFilterMode:        RequestHeaderFilterModeDisabled,
 
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
@@ -199,6 +220,8 @@ var Defaults = Configuration{
 		UserMemRatio:              0.25,
 		UserMuteMemRatio:          2,
 		UserMuteIDsMemRatio:       3,
+		UnretrievableMemRatio:     0.1,
+		InboxActivityMemRatio:     0.1,
 		WebfingerMemRatio:         0.1,
 		VisibilityMemRatio:        2,
 	},
@@ -208,6 +231,22 @@ var Defaults = Configuration{
 		BlockIPs:              make([]string, 0),
 		Timeout:               10 * time.Second,
 		TLSInsecureSkipVerify: false,
+		HostRequestBudget:     0,
+		HostRequestWindow:     5 * time.Minute,
+
+		DeliveryTimeout:        0,
+		DeliveryMaxBodySize:    0,
+		DereferenceTimeout:     0,
+		DereferenceMaxBodySize: 0,
+		MediaTimeout:           0,
+		MediaMaxBodySize:       0,
+
+		Proxy:      "",
+		OnionProxy: "",
+		I2PProxy:   "",
+
+		CircuitBreakerFailures: 10,
+		CircuitBreakerCooldown: time.Minute,
 	},
 
 	AdminMediaPruneDryRun: true,
@@ -62,6 +62,19 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().StringSlice(HTTPClientBlockIPsFlag(), cfg.HTTPClient.BlockIPs, "no usage string")
 		cmd.PersistentFlags().Duration(HTTPClientTimeoutFlag(), cfg.HTTPClient.Timeout, "no usage string")
 		cmd.PersistentFlags().Bool(HTTPClientTLSInsecureSkipVerifyFlag(), cfg.HTTPClient.TLSInsecureSkipVerify, "no usage string")
+		cmd.PersistentFlags().Int(HTTPClientHostRequestBudgetFlag(), cfg.HTTPClient.HostRequestBudget, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientHostRequestWindowFlag(), cfg.HTTPClient.HostRequestWindow, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientDeliveryTimeoutFlag(), cfg.HTTPClient.DeliveryTimeout, "no usage string")
+		cmd.PersistentFlags().Uint64(HTTPClientDeliveryMaxBodySizeFlag(), uint64(cfg.HTTPClient.DeliveryMaxBodySize), "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientDereferenceTimeoutFlag(), cfg.HTTPClient.DereferenceTimeout, "no usage string")
+		cmd.PersistentFlags().Uint64(HTTPClientDereferenceMaxBodySizeFlag(), uint64(cfg.HTTPClient.DereferenceMaxBodySize), "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientMediaTimeoutFlag(), cfg.HTTPClient.MediaTimeout, "no usage string")
+		cmd.PersistentFlags().Uint64(HTTPClientMediaMaxBodySizeFlag(), uint64(cfg.HTTPClient.MediaMaxBodySize), "no usage string")
+		cmd.PersistentFlags().String(HTTPClientProxyFlag(), cfg.HTTPClient.Proxy, "no usage string")
+		cmd.PersistentFlags().String(HTTPClientOnionProxyFlag(), cfg.HTTPClient.OnionProxy, "no usage string")
+		cmd.PersistentFlags().String(HTTPClientI2PProxyFlag(), cfg.HTTPClient.I2PProxy, "no usage string")
+		cmd.PersistentFlags().Int(HTTPClientCircuitBreakerFailuresFlag(), cfg.HTTPClient.CircuitBreakerFailures, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientCircuitBreakerCooldownFlag(), cfg.HTTPClient.CircuitBreakerCooldown, "no usage string")
 	})
 }
 
@@ -85,6 +98,9 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Int(InstanceFederationRepliesMaxDepthFlag(), cfg.InstanceFederationRepliesMaxDepth, fieldtag("InstanceFederationRepliesMaxDepth", "usage"))
+		cmd.Flags().Int(InstanceFederationRepliesMaxCountFlag(), cfg.InstanceFederationRepliesMaxCount, fieldtag("InstanceFederationRepliesMaxCount", "usage"))
+		cmd.Flags().Int(InstanceFederationOutboxBackfillCountFlag(), cfg.InstanceFederationOutboxBackfillCount, fieldtag("InstanceFederationOutboxBackfillCount", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
@@ -95,6 +111,8 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Bool(AccountsAllowUserInvitesFlag(), cfg.AccountsAllowUserInvites, fieldtag("AccountsAllowUserInvites", "usage"))
+		cmd.Flags().String(AccountsExportPathFlag(), cfg.AccountsExportPath, fieldtag("AccountsExportPath", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -104,6 +122,10 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
+		cmd.Flags().Bool(MediaMetadataStripAllFlag(), cfg.MediaMetadataStripAll, fieldtag("MediaMetadataStripAll", "usage"))
+		cmd.Flags().Bool(MediaMetadataKeepOrientationFlag(), cfg.MediaMetadataKeepOrientation, fieldtag("MediaMetadataKeepOrientation", "usage"))
+		cmd.Flags().Bool(MediaMetadataKeepICCFlag(), cfg.MediaMetadataKeepICC, fieldtag("MediaMetadataKeepICC", "usage"))
+		cmd.Flags().String(MediaDerivedFormatFlag(), cfg.MediaDerivedFormat, fieldtag("MediaDerivedFormat", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
 
@@ -156,6 +178,17 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
+		cmd.Flags().Int(AdvancedDeliveryMaxAttemptsFlag(), cfg.AdvancedDeliveryMaxAttempts, fieldtag("AdvancedDeliveryMaxAttempts", "usage"))
+		cmd.Flags().Duration(AdvancedDeliveryDeadHostAfterFlag(), cfg.AdvancedDeliveryDeadHostAfter, fieldtag("AdvancedDeliveryDeadHostAfter", "usage"))
+		cmd.Flags().StringSlice(AdvancedActivityConcurrencyFlag(), cfg.AdvancedActivityConcurrency, fieldtag("AdvancedActivityConcurrency", "usage"))
+		cmd.Flags().Bool(AdvancedPersistWorkerQueuesFlag(), cfg.AdvancedPersistWorkerQueues, fieldtag("AdvancedPersistWorkerQueues", "usage"))
+		cmd.Flags().Int(AdvancedFederatorSpoolThresholdFlag(), cfg.AdvancedFederatorSpoolThreshold, fieldtag("AdvancedFederatorSpoolThreshold", "usage"))
+		cmd.Flags().Duration(AdvancedWorkerDrainTimeoutFlag(), cfg.AdvancedWorkerDrainTimeout, fieldtag("AdvancedWorkerDrainTimeout", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxPerAccountFlag(), cfg.AdvancedStreamingMaxPerAccount, fieldtag("AdvancedStreamingMaxPerAccount", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxTotalFlag(), cfg.AdvancedStreamingMaxTotal, fieldtag("AdvancedStreamingMaxTotal", "usage"))
+		cmd.Flags().Int(AdvancedStreamingBufferSizeFlag(), cfg.AdvancedStreamingBufferSize, fieldtag("AdvancedStreamingBufferSize", "usage"))
+		cmd.Flags().Duration(AdvancedStreamingPingIntervalFlag(), cfg.AdvancedStreamingPingInterval, fieldtag("AdvancedStreamingPingInterval", "usage"))
+		cmd.Flags().Duration(AdvancedStreamingIdleTimeoutFlag(), cfg.AdvancedStreamingIdleTimeout, fieldtag("AdvancedStreamingIdleTimeout", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
 
@@ -173,6 +206,13 @@ func AddAdminAccount(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminAccountFixCounts attaches flags pertaining to admin account fix-counts.
+func AddAdminAccountFixCounts(cmd *cobra.Command) {
+	name := AdminAccountUsernameFlag()
+	usage := fieldtag("AdminAccountUsername", "usage")
+	cmd.Flags().String(name, "", usage) // OPTIONAL
+}
+
 // AddAdminAccountPassword attaches flags pertaining to admin account password reset.
 func AddAdminAccountPassword(cmd *cobra.Command) {
 	name := AdminAccountPasswordFlag()
@@ -207,6 +247,15 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminDomainImport attaches flags pertaining to domain block import commands.
+func AddAdminDomainImport(cmd *cobra.Command) {
+	AddAdminTrans(cmd)
+
+	name := AdminMediaPruneDryRunFlag()
+	usage := "perform a dry run and only print a summary of accounts/statuses that would be affected"
+	cmd.Flags().Bool(name, true, usage)
+}
+
 // AddAdminMediaList attaches flags pertaining to media list commands.
 func AddAdminMediaList(cmd *cobra.Command) {
 	localOnly := AdminMediaListLocalOnlyFlag()
@@ -218,6 +267,76 @@ func AddAdminMediaList(cmd *cobra.Command) {
 	cmd.Flags().Bool(remoteOnly, false, remoteOnlyUsage)
 }
 
+// AddAdminMediaMigrate attaches flags pertaining to media storage migrate commands.
+func AddAdminMediaMigrate(cmd *cobra.Command) {
+	name := AdminMediaMigrateToFlag()
+	usage := fieldtag("AdminMediaMigrateTo", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
+// AddAdminKeysRotate attaches flags pertaining to key rotation commands.
+func AddAdminKeysRotate(cmd *cobra.Command) {
+	users := AdminKeysRotateUsersFlag()
+	usersUsage := fieldtag("AdminKeysRotateUsers", "usage")
+	cmd.Flags().Bool(users, false, usersUsage)
+
+	federate := AdminKeysRotateFederateFlag()
+	federateUsage := fieldtag("AdminKeysRotateFederate", "usage")
+	cmd.Flags().Bool(federate, false, federateUsage)
+}
+
+// AddAdminDelivery attaches flags pertaining to failed delivery commands.
+func AddAdminDelivery(cmd *cobra.Command) {
+	domain := AdminDeliveryDomainFlag()
+	domainUsage := fieldtag("AdminDeliveryDomain", "usage")
+	cmd.Flags().String(domain, "", domainUsage)
+
+	activityType := AdminDeliveryTypeFlag()
+	activityTypeUsage := fieldtag("AdminDeliveryType", "usage")
+	cmd.Flags().String(activityType, "", activityTypeUsage)
+}
+
+// AddAdminInviteCreate attaches flags pertaining to invite creation.
+func AddAdminInviteCreate(cmd *cobra.Command) {
+	username := AdminAccountUsernameFlag()
+	usernameUsage := "the username of the local account to create the invite as; defaults to the instance account"
+	cmd.Flags().String(username, "", usernameUsage)
+
+	maxUses := AdminInviteMaxUsesFlag()
+	maxUsesUsage := fieldtag("AdminInviteMaxUses", "usage")
+	cmd.Flags().Int(maxUses, 0, maxUsesUsage)
+
+	expiresIn := AdminInviteExpiresInFlag()
+	expiresInUsage := fieldtag("AdminInviteExpiresIn", "usage")
+	cmd.Flags().Duration(expiresIn, 0, expiresInUsage)
+}
+
+// AddAdminInviteRevoke attaches flags pertaining to invite revocation.
+func AddAdminInviteRevoke(cmd *cobra.Command) {
+	name := AdminInviteCodeFlag()
+	usage := fieldtag("AdminInviteCode", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
+// AddAdminMediaEmojis attaches flags pertaining to emoji pack import/export commands.
+func AddAdminMediaEmojis(cmd *cobra.Command) {
+	AddAdminTrans(cmd)
+
+	category := AdminEmojiCategoryFlag()
+	categoryUsage := fieldtag("AdminEmojiCategory", "usage")
+	cmd.Flags().String(category, "", categoryUsage)
+
+	onConflict := AdminEmojiOnConflictFlag()
+	onConflictUsage := fieldtag("AdminEmojiOnConflict", "usage")
+	cmd.Flags().String(onConflict, "skip", onConflictUsage)
+}
+
 // AddAdminMediaPrune attaches flags pertaining to media storage prune commands.
 func AddAdminMediaPrune(cmd *cobra.Command) {
 	name := AdminMediaPruneDryRunFlag()
@@ -700,6 +700,31 @@ func GetDbSqliteBusyTimeout() time.Duration { return global.GetDbSqliteBusyTimeo
 // SetDbSqliteBusyTimeout safely sets the value for global configuration 'DbSqliteBusyTimeout' field
 func SetDbSqliteBusyTimeout(v time.Duration) { global.SetDbSqliteBusyTimeout(v) }
 
+// GetRedisURL safely fetches the Configuration value for state's 'RedisURL' field
+func (st *ConfigState) GetRedisURL() (v string) {
+	st.mutex.RLock()
+	v = st.config.RedisURL
+	st.mutex.RUnlock()
+	return
+}
+
+// SetRedisURL safely sets the Configuration value for state's 'RedisURL' field
+func (st *ConfigState) SetRedisURL(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.RedisURL = v
+	st.reloadToViper()
+}
+
+// RedisURLFlag returns the flag name for the 'RedisURL' field
+func RedisURLFlag() string { return "redis-url" }
+
+// GetRedisURL safely fetches the value for global configuration 'RedisURL' field
+func GetRedisURL() string { return global.GetRedisURL() }
+
+// SetRedisURL safely sets the value for global configuration 'RedisURL' field
+func SetRedisURL(v string) { global.SetRedisURL(v) }
+
 // GetWebTemplateBaseDir safely fetches the Configuration value for state's 'WebTemplateBaseDir' field
 func (st *ConfigState) GetWebTemplateBaseDir() (v string) {
 	st.mutex.RLock()
@@ -800,6 +825,87 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationRepliesMaxDepth safely fetches the Configuration value for state's 'InstanceFederationRepliesMaxDepth' field
+func (st *ConfigState) GetInstanceFederationRepliesMaxDepth() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationRepliesMaxDepth
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationRepliesMaxDepth safely sets the Configuration value for state's 'InstanceFederationRepliesMaxDepth' field
+func (st *ConfigState) SetInstanceFederationRepliesMaxDepth(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationRepliesMaxDepth = v
+	st.reloadToViper()
+}
+
+// InstanceFederationRepliesMaxDepthFlag returns the flag name for the 'InstanceFederationRepliesMaxDepth' field
+func InstanceFederationRepliesMaxDepthFlag() string { return "instance-federation-replies-max-depth" }
+
+// GetInstanceFederationRepliesMaxDepth safely fetches the value for global configuration 'InstanceFederationRepliesMaxDepth' field
+func GetInstanceFederationRepliesMaxDepth() int { return global.GetInstanceFederationRepliesMaxDepth() }
+
+// SetInstanceFederationRepliesMaxDepth safely sets the value for global configuration 'InstanceFederationRepliesMaxDepth' field
+func SetInstanceFederationRepliesMaxDepth(v int) { global.SetInstanceFederationRepliesMaxDepth(v) }
+
+// GetInstanceFederationRepliesMaxCount safely fetches the Configuration value for state's 'InstanceFederationRepliesMaxCount' field
+func (st *ConfigState) GetInstanceFederationRepliesMaxCount() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationRepliesMaxCount
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationRepliesMaxCount safely sets the Configuration value for state's 'InstanceFederationRepliesMaxCount' field
+func (st *ConfigState) SetInstanceFederationRepliesMaxCount(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationRepliesMaxCount = v
+	st.reloadToViper()
+}
+
+// InstanceFederationRepliesMaxCountFlag returns the flag name for the 'InstanceFederationRepliesMaxCount' field
+func InstanceFederationRepliesMaxCountFlag() string { return "instance-federation-replies-max-count" }
+
+// GetInstanceFederationRepliesMaxCount safely fetches the value for global configuration 'InstanceFederationRepliesMaxCount' field
+func GetInstanceFederationRepliesMaxCount() int { return global.GetInstanceFederationRepliesMaxCount() }
+
+// SetInstanceFederationRepliesMaxCount safely sets the value for global configuration 'InstanceFederationRepliesMaxCount' field
+func SetInstanceFederationRepliesMaxCount(v int) { global.SetInstanceFederationRepliesMaxCount(v) }
+
+// GetInstanceFederationOutboxBackfillCount safely fetches the Configuration value for state's 'InstanceFederationOutboxBackfillCount' field
+func (st *ConfigState) GetInstanceFederationOutboxBackfillCount() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationOutboxBackfillCount
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationOutboxBackfillCount safely sets the Configuration value for state's 'InstanceFederationOutboxBackfillCount' field
+func (st *ConfigState) SetInstanceFederationOutboxBackfillCount(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationOutboxBackfillCount = v
+	st.reloadToViper()
+}
+
+// InstanceFederationOutboxBackfillCountFlag returns the flag name for the 'InstanceFederationOutboxBackfillCount' field
+func InstanceFederationOutboxBackfillCountFlag() string {
+	return "instance-federation-outbox-backfill-count"
+}
+
+// GetInstanceFederationOutboxBackfillCount safely fetches the value for global configuration 'InstanceFederationOutboxBackfillCount' field
+func GetInstanceFederationOutboxBackfillCount() int {
+	return global.GetInstanceFederationOutboxBackfillCount()
+}
+
+// SetInstanceFederationOutboxBackfillCount safely sets the value for global configuration 'InstanceFederationOutboxBackfillCount' field
+func SetInstanceFederationOutboxBackfillCount(v int) {
+	global.SetInstanceFederationOutboxBackfillCount(v)
+}
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -1075,6 +1181,56 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsAllowUserInvites safely fetches the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) GetAccountsAllowUserInvites() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsAllowUserInvites
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsAllowUserInvites safely sets the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) SetAccountsAllowUserInvites(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAllowUserInvites = v
+	st.reloadToViper()
+}
+
+// AccountsAllowUserInvitesFlag returns the flag name for the 'AccountsAllowUserInvites' field
+func AccountsAllowUserInvitesFlag() string { return "accounts-allow-user-invites" }
+
+// GetAccountsAllowUserInvites safely fetches the value for global configuration 'AccountsAllowUserInvites' field
+func GetAccountsAllowUserInvites() bool { return global.GetAccountsAllowUserInvites() }
+
+// SetAccountsAllowUserInvites safely sets the value for global configuration 'AccountsAllowUserInvites' field
+func SetAccountsAllowUserInvites(v bool) { global.SetAccountsAllowUserInvites(v) }
+
+// GetAccountsExportPath safely fetches the Configuration value for state's 'AccountsExportPath' field
+func (st *ConfigState) GetAccountsExportPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsExportPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsExportPath safely sets the Configuration value for state's 'AccountsExportPath' field
+func (st *ConfigState) SetAccountsExportPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsExportPath = v
+	st.reloadToViper()
+}
+
+// AccountsExportPathFlag returns the flag name for the 'AccountsExportPath' field
+func AccountsExportPathFlag() string { return "accounts-export-path" }
+
+// GetAccountsExportPath safely fetches the value for global configuration 'AccountsExportPath' field
+func GetAccountsExportPath() string { return global.GetAccountsExportPath() }
+
+// SetAccountsExportPath safely sets the value for global configuration 'AccountsExportPath' field
+func SetAccountsExportPath(v string) { global.SetAccountsExportPath(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -1250,6 +1406,106 @@ func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRem
 // SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
 func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
 
+// GetMediaMetadataStripAll safely fetches the Configuration value for state's 'MediaMetadataStripAll' field
+func (st *ConfigState) GetMediaMetadataStripAll() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaMetadataStripAll
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaMetadataStripAll safely sets the Configuration value for state's 'MediaMetadataStripAll' field
+func (st *ConfigState) SetMediaMetadataStripAll(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaMetadataStripAll = v
+	st.reloadToViper()
+}
+
+// MediaMetadataStripAllFlag returns the flag name for the 'MediaMetadataStripAll' field
+func MediaMetadataStripAllFlag() string { return "media-metadata-strip-all" }
+
+// GetMediaMetadataStripAll safely fetches the value for global configuration 'MediaMetadataStripAll' field
+func GetMediaMetadataStripAll() bool { return global.GetMediaMetadataStripAll() }
+
+// SetMediaMetadataStripAll safely sets the value for global configuration 'MediaMetadataStripAll' field
+func SetMediaMetadataStripAll(v bool) { global.SetMediaMetadataStripAll(v) }
+
+// GetMediaMetadataKeepOrientation safely fetches the Configuration value for state's 'MediaMetadataKeepOrientation' field
+func (st *ConfigState) GetMediaMetadataKeepOrientation() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaMetadataKeepOrientation
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaMetadataKeepOrientation safely sets the Configuration value for state's 'MediaMetadataKeepOrientation' field
+func (st *ConfigState) SetMediaMetadataKeepOrientation(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaMetadataKeepOrientation = v
+	st.reloadToViper()
+}
+
+// MediaMetadataKeepOrientationFlag returns the flag name for the 'MediaMetadataKeepOrientation' field
+func MediaMetadataKeepOrientationFlag() string { return "media-metadata-keep-orientation" }
+
+// GetMediaMetadataKeepOrientation safely fetches the value for global configuration 'MediaMetadataKeepOrientation' field
+func GetMediaMetadataKeepOrientation() bool { return global.GetMediaMetadataKeepOrientation() }
+
+// SetMediaMetadataKeepOrientation safely sets the value for global configuration 'MediaMetadataKeepOrientation' field
+func SetMediaMetadataKeepOrientation(v bool) { global.SetMediaMetadataKeepOrientation(v) }
+
+// GetMediaMetadataKeepICC safely fetches the Configuration value for state's 'MediaMetadataKeepICC' field
+func (st *ConfigState) GetMediaMetadataKeepICC() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaMetadataKeepICC
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaMetadataKeepICC safely sets the Configuration value for state's 'MediaMetadataKeepICC' field
+func (st *ConfigState) SetMediaMetadataKeepICC(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaMetadataKeepICC = v
+	st.reloadToViper()
+}
+
+// MediaMetadataKeepICCFlag returns the flag name for the 'MediaMetadataKeepICC' field
+func MediaMetadataKeepICCFlag() string { return "media-metadata-keep-icc" }
+
+// GetMediaMetadataKeepICC safely fetches the value for global configuration 'MediaMetadataKeepICC' field
+func GetMediaMetadataKeepICC() bool { return global.GetMediaMetadataKeepICC() }
+
+// SetMediaMetadataKeepICC safely sets the value for global configuration 'MediaMetadataKeepICC' field
+func SetMediaMetadataKeepICC(v bool) { global.SetMediaMetadataKeepICC(v) }
+
+// GetMediaDerivedFormat safely fetches the Configuration value for state's 'MediaDerivedFormat' field
+func (st *ConfigState) GetMediaDerivedFormat() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaDerivedFormat
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDerivedFormat safely sets the Configuration value for state's 'MediaDerivedFormat' field
+func (st *ConfigState) SetMediaDerivedFormat(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDerivedFormat = v
+	st.reloadToViper()
+}
+
+// MediaDerivedFormatFlag returns the flag name for the 'MediaDerivedFormat' field
+func MediaDerivedFormatFlag() string { return "media-derived-format" }
+
+// GetMediaDerivedFormat safely fetches the value for global configuration 'MediaDerivedFormat' field
+func GetMediaDerivedFormat() string { return global.GetMediaDerivedFormat() }
+
+// SetMediaDerivedFormat safely sets the value for global configuration 'MediaDerivedFormat' field
+func SetMediaDerivedFormat(v string) { global.SetMediaDerivedFormat(v) }
+
 // GetMediaCleanupFrom safely fetches the Configuration value for state's 'MediaCleanupFrom' field
 func (st *ConfigState) GetMediaCleanupFrom() (v string) {
 	st.mutex.RLock()
@@ -1500,6 +1756,31 @@ func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
 // SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
 func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
 
+// GetStorageS3RedirectURLExpiry safely fetches the Configuration value for state's 'StorageS3RedirectURLExpiry' field
+func (st *ConfigState) GetStorageS3RedirectURLExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.StorageS3RedirectURLExpiry
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3RedirectURLExpiry safely sets the Configuration value for state's 'StorageS3RedirectURLExpiry' field
+func (st *ConfigState) SetStorageS3RedirectURLExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3RedirectURLExpiry = v
+	st.reloadToViper()
+}
+
+// StorageS3RedirectURLExpiryFlag returns the flag name for the 'StorageS3RedirectURLExpiry' field
+func StorageS3RedirectURLExpiryFlag() string { return "storage-s3-redirect-url-expiry" }
+
+// GetStorageS3RedirectURLExpiry safely fetches the value for global configuration 'StorageS3RedirectURLExpiry' field
+func GetStorageS3RedirectURLExpiry() time.Duration { return global.GetStorageS3RedirectURLExpiry() }
+
+// SetStorageS3RedirectURLExpiry safely sets the value for global configuration 'StorageS3RedirectURLExpiry' field
+func SetStorageS3RedirectURLExpiry(v time.Duration) { global.SetStorageS3RedirectURLExpiry(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.RLock()
@@ -2575,232 +2856,846 @@ func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultipli
 // SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
 func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
 
-// GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
-func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
+// GetAdvancedDeliveryMaxAttempts safely fetches the Configuration value for state's 'AdvancedDeliveryMaxAttempts' field
+func (st *ConfigState) GetAdvancedDeliveryMaxAttempts() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedCSPExtraURIs
+	v = st.config.AdvancedDeliveryMaxAttempts
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedCSPExtraURIs safely sets the Configuration value for state's 'AdvancedCSPExtraURIs' field
-func (st *ConfigState) SetAdvancedCSPExtraURIs(v []string) {
+// SetAdvancedDeliveryMaxAttempts safely sets the Configuration value for state's 'AdvancedDeliveryMaxAttempts' field
+func (st *ConfigState) SetAdvancedDeliveryMaxAttempts(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedCSPExtraURIs = v
+	st.config.AdvancedDeliveryMaxAttempts = v
 	st.reloadToViper()
 }
 
-// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
-func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+// AdvancedDeliveryMaxAttemptsFlag returns the flag name for the 'AdvancedDeliveryMaxAttempts' field
+func AdvancedDeliveryMaxAttemptsFlag() string { return "advanced-delivery-max-attempts" }
 
-// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
-func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+// GetAdvancedDeliveryMaxAttempts safely fetches the value for global configuration 'AdvancedDeliveryMaxAttempts' field
+func GetAdvancedDeliveryMaxAttempts() int { return global.GetAdvancedDeliveryMaxAttempts() }
 
-// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
-func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+// SetAdvancedDeliveryMaxAttempts safely sets the value for global configuration 'AdvancedDeliveryMaxAttempts' field
+func SetAdvancedDeliveryMaxAttempts(v int) { global.SetAdvancedDeliveryMaxAttempts(v) }
 
-// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+// GetAdvancedDeliveryDeadHostAfter safely fetches the Configuration value for state's 'AdvancedDeliveryDeadHostAfter' field
+func (st *ConfigState) GetAdvancedDeliveryDeadHostAfter() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.AdvancedHeaderFilterMode
+	v = st.config.AdvancedDeliveryDeadHostAfter
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+// SetAdvancedDeliveryDeadHostAfter safely sets the Configuration value for state's 'AdvancedDeliveryDeadHostAfter' field
+func (st *ConfigState) SetAdvancedDeliveryDeadHostAfter(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedHeaderFilterMode = v
+	st.config.AdvancedDeliveryDeadHostAfter = v
 	st.reloadToViper()
 }
 
-// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
-func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+// AdvancedDeliveryDeadHostAfterFlag returns the flag name for the 'AdvancedDeliveryDeadHostAfter' field
+func AdvancedDeliveryDeadHostAfterFlag() string { return "advanced-delivery-dead-host-after" }
 
-// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
-func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+// GetAdvancedDeliveryDeadHostAfter safely fetches the value for global configuration 'AdvancedDeliveryDeadHostAfter' field
+func GetAdvancedDeliveryDeadHostAfter() time.Duration {
+	return global.GetAdvancedDeliveryDeadHostAfter()
+}
 
-// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
-func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+// SetAdvancedDeliveryDeadHostAfter safely sets the value for global configuration 'AdvancedDeliveryDeadHostAfter' field
+func SetAdvancedDeliveryDeadHostAfter(v time.Duration) { global.SetAdvancedDeliveryDeadHostAfter(v) }
 
-// GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
-func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
+// GetAdvancedActivityConcurrency safely fetches the Configuration value for state's 'AdvancedActivityConcurrency' field
+func (st *ConfigState) GetAdvancedActivityConcurrency() (v []string) {
 	st.mutex.RLock()
-	v = st.config.HTTPClient.AllowIPs
+	v = st.config.AdvancedActivityConcurrency
 	st.mutex.RUnlock()
 	return
 }
 
-// SetHTTPClientAllowIPs safely sets the Configuration value for state's 'HTTPClient.AllowIPs' field
-func (st *ConfigState) SetHTTPClientAllowIPs(v []string) {
+// SetAdvancedActivityConcurrency safely sets the Configuration value for state's 'AdvancedActivityConcurrency' field
+func (st *ConfigState) SetAdvancedActivityConcurrency(v []string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.HTTPClient.AllowIPs = v
+	st.config.AdvancedActivityConcurrency = v
 	st.reloadToViper()
 }
 
-// HTTPClientAllowIPsFlag returns the flag name for the 'HTTPClient.AllowIPs' field
-func HTTPClientAllowIPsFlag() string { return "httpclient-allow-ips" }
+// AdvancedActivityConcurrencyFlag returns the flag name for the 'AdvancedActivityConcurrency' field
+func AdvancedActivityConcurrencyFlag() string { return "advanced-activity-concurrency" }
 
-// GetHTTPClientAllowIPs safely fetches the value for global configuration 'HTTPClient.AllowIPs' field
-func GetHTTPClientAllowIPs() []string { return global.GetHTTPClientAllowIPs() }
+// GetAdvancedActivityConcurrency safely fetches the value for global configuration 'AdvancedActivityConcurrency' field
+func GetAdvancedActivityConcurrency() []string { return global.GetAdvancedActivityConcurrency() }
 
-// SetHTTPClientAllowIPs safely sets the value for global configuration 'HTTPClient.AllowIPs' field
-func SetHTTPClientAllowIPs(v []string) { global.SetHTTPClientAllowIPs(v) }
+// SetAdvancedActivityConcurrency safely sets the value for global configuration 'AdvancedActivityConcurrency' field
+func SetAdvancedActivityConcurrency(v []string) { global.SetAdvancedActivityConcurrency(v) }
 
-// GetHTTPClientBlockIPs safely fetches the Configuration value for state's 'HTTPClient.BlockIPs' field
-func (st *ConfigState) GetHTTPClientBlockIPs() (v []string) {
+// GetAdvancedPersistWorkerQueues safely fetches the Configuration value for state's 'AdvancedPersistWorkerQueues' field
+func (st *ConfigState) GetAdvancedPersistWorkerQueues() (v bool) {
 	st.mutex.RLock()
-	v = st.config.HTTPClient.BlockIPs
+	v = st.config.AdvancedPersistWorkerQueues
 	st.mutex.RUnlock()
 	return
 }
 
-// SetHTTPClientBlockIPs safely sets the Configuration value for state's 'HTTPClient.BlockIPs' field
-func (st *ConfigState) SetHTTPClientBlockIPs(v []string) {
+// SetAdvancedPersistWorkerQueues safely sets the Configuration value for state's 'AdvancedPersistWorkerQueues' field
+func (st *ConfigState) SetAdvancedPersistWorkerQueues(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.HTTPClient.BlockIPs = v
+	st.config.AdvancedPersistWorkerQueues = v
 	st.reloadToViper()
 }
 
-// HTTPClientBlockIPsFlag returns the flag name for the 'HTTPClient.BlockIPs' field
-func HTTPClientBlockIPsFlag() string { return "httpclient-block-ips" }
+// AdvancedPersistWorkerQueuesFlag returns the flag name for the 'AdvancedPersistWorkerQueues' field
+func AdvancedPersistWorkerQueuesFlag() string { return "advanced-persist-worker-queues" }
 
-// GetHTTPClientBlockIPs safely fetches the value for global configuration 'HTTPClient.BlockIPs' field
-func GetHTTPClientBlockIPs() []string { return global.GetHTTPClientBlockIPs() }
+// GetAdvancedPersistWorkerQueues safely fetches the value for global configuration 'AdvancedPersistWorkerQueues' field
+func GetAdvancedPersistWorkerQueues() bool { return global.GetAdvancedPersistWorkerQueues() }
 
-// SetHTTPClientBlockIPs safely sets the value for global configuration 'HTTPClient.BlockIPs' field
-func SetHTTPClientBlockIPs(v []string) { global.SetHTTPClientBlockIPs(v) }
+// SetAdvancedPersistWorkerQueues safely sets the value for global configuration 'AdvancedPersistWorkerQueues' field
+func SetAdvancedPersistWorkerQueues(v bool) { global.SetAdvancedPersistWorkerQueues(v) }
 
-// GetHTTPClientTimeout safely fetches the Configuration value for state's 'HTTPClient.Timeout' field
-func (st *ConfigState) GetHTTPClientTimeout() (v time.Duration) {
+// GetAdvancedFederatorSpoolThreshold safely fetches the Configuration value for state's 'AdvancedFederatorSpoolThreshold' field
+func (st *ConfigState) GetAdvancedFederatorSpoolThreshold() (v int) {
 	st.mutex.RLock()
-	v = st.config.HTTPClient.Timeout
+	v = st.config.AdvancedFederatorSpoolThreshold
 	st.mutex.RUnlock()
 	return
 }
 
-// SetHTTPClientTimeout safely sets the Configuration value for state's 'HTTPClient.Timeout' field
-func (st *ConfigState) SetHTTPClientTimeout(v time.Duration) {
+// SetAdvancedFederatorSpoolThreshold safely sets the Configuration value for state's 'AdvancedFederatorSpoolThreshold' field
+func (st *ConfigState) SetAdvancedFederatorSpoolThreshold(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.HTTPClient.Timeout = v
+	st.config.AdvancedFederatorSpoolThreshold = v
 	st.reloadToViper()
 }
 
-// HTTPClientTimeoutFlag returns the flag name for the 'HTTPClient.Timeout' field
-func HTTPClientTimeoutFlag() string { return "httpclient-timeout" }
+// AdvancedFederatorSpoolThresholdFlag returns the flag name for the 'AdvancedFederatorSpoolThreshold' field
+func AdvancedFederatorSpoolThresholdFlag() string { return "advanced-federator-spool-threshold" }
 
-// GetHTTPClientTimeout safely fetches the value for global configuration 'HTTPClient.Timeout' field
-func GetHTTPClientTimeout() time.Duration { return global.GetHTTPClientTimeout() }
+// GetAdvancedFederatorSpoolThreshold safely fetches the value for global configuration 'AdvancedFederatorSpoolThreshold' field
+func GetAdvancedFederatorSpoolThreshold() int { return global.GetAdvancedFederatorSpoolThreshold() }
 
-// SetHTTPClientTimeout safely sets the value for global configuration 'HTTPClient.Timeout' field
-func SetHTTPClientTimeout(v time.Duration) { global.SetHTTPClientTimeout(v) }
+// SetAdvancedFederatorSpoolThreshold safely sets the value for global configuration 'AdvancedFederatorSpoolThreshold' field
+func SetAdvancedFederatorSpoolThreshold(v int) { global.SetAdvancedFederatorSpoolThreshold(v) }
 
-// GetHTTPClientTLSInsecureSkipVerify safely fetches the Configuration value for state's 'HTTPClient.TLSInsecureSkipVerify' field
-func (st *ConfigState) GetHTTPClientTLSInsecureSkipVerify() (v bool) {
+// GetAdvancedWorkerDrainTimeout safely fetches the Configuration value for state's 'AdvancedWorkerDrainTimeout' field
+func (st *ConfigState) GetAdvancedWorkerDrainTimeout() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.HTTPClient.TLSInsecureSkipVerify
+	v = st.config.AdvancedWorkerDrainTimeout
 	st.mutex.RUnlock()
 	return
 }
 
-// SetHTTPClientTLSInsecureSkipVerify safely sets the Configuration value for state's 'HTTPClient.TLSInsecureSkipVerify' field
-func (st *ConfigState) SetHTTPClientTLSInsecureSkipVerify(v bool) {
+// SetAdvancedWorkerDrainTimeout safely sets the Configuration value for state's 'AdvancedWorkerDrainTimeout' field
+func (st *ConfigState) SetAdvancedWorkerDrainTimeout(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.HTTPClient.TLSInsecureSkipVerify = v
+	st.config.AdvancedWorkerDrainTimeout = v
 	st.reloadToViper()
 }
 
-// HTTPClientTLSInsecureSkipVerifyFlag returns the flag name for the 'HTTPClient.TLSInsecureSkipVerify' field
-func HTTPClientTLSInsecureSkipVerifyFlag() string { return "httpclient-tls-insecure-skip-verify" }
+// AdvancedWorkerDrainTimeoutFlag returns the flag name for the 'AdvancedWorkerDrainTimeout' field
+func AdvancedWorkerDrainTimeoutFlag() string { return "advanced-worker-drain-timeout" }
 
-// GetHTTPClientTLSInsecureSkipVerify safely fetches the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
-func GetHTTPClientTLSInsecureSkipVerify() bool { return global.GetHTTPClientTLSInsecureSkipVerify() }
+// GetAdvancedWorkerDrainTimeout safely fetches the value for global configuration 'AdvancedWorkerDrainTimeout' field
+func GetAdvancedWorkerDrainTimeout() time.Duration { return global.GetAdvancedWorkerDrainTimeout() }
 
-// SetHTTPClientTLSInsecureSkipVerify safely sets the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
-func SetHTTPClientTLSInsecureSkipVerify(v bool) { global.SetHTTPClientTLSInsecureSkipVerify(v) }
+// SetAdvancedWorkerDrainTimeout safely sets the value for global configuration 'AdvancedWorkerDrainTimeout' field
+func SetAdvancedWorkerDrainTimeout(v time.Duration) { global.SetAdvancedWorkerDrainTimeout(v) }
 
-// GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
-func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
+// GetAdvancedStreamingMaxPerAccount safely fetches the Configuration value for state's 'AdvancedStreamingMaxPerAccount' field
+func (st *ConfigState) GetAdvancedStreamingMaxPerAccount() (v int) {
 	st.mutex.RLock()
-	v = st.config.Cache.MemoryTarget
+	v = st.config.AdvancedStreamingMaxPerAccount
 	st.mutex.RUnlock()
 	return
 }
 
-// SetCacheMemoryTarget safely sets the Configuration value for state's 'Cache.MemoryTarget' field
-func (st *ConfigState) SetCacheMemoryTarget(v bytesize.Size) {
+// SetAdvancedStreamingMaxPerAccount safely sets the Configuration value for state's 'AdvancedStreamingMaxPerAccount' field
+func (st *ConfigState) SetAdvancedStreamingMaxPerAccount(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.Cache.MemoryTarget = v
+	st.config.AdvancedStreamingMaxPerAccount = v
 	st.reloadToViper()
 }
 
-// CacheMemoryTargetFlag returns the flag name for the 'Cache.MemoryTarget' field
-func CacheMemoryTargetFlag() string { return "cache-memory-target" }
+// AdvancedStreamingMaxPerAccountFlag returns the flag name for the 'AdvancedStreamingMaxPerAccount' field
+func AdvancedStreamingMaxPerAccountFlag() string { return "advanced-streaming-max-per-account" }
 
-// GetCacheMemoryTarget safely fetches the value for global configuration 'Cache.MemoryTarget' field
-func GetCacheMemoryTarget() bytesize.Size { return global.GetCacheMemoryTarget() }
+// GetAdvancedStreamingMaxPerAccount safely fetches the value for global configuration 'AdvancedStreamingMaxPerAccount' field
+func GetAdvancedStreamingMaxPerAccount() int { return global.GetAdvancedStreamingMaxPerAccount() }
 
-// SetCacheMemoryTarget safely sets the value for global configuration 'Cache.MemoryTarget' field
-func SetCacheMemoryTarget(v bytesize.Size) { global.SetCacheMemoryTarget(v) }
+// SetAdvancedStreamingMaxPerAccount safely sets the value for global configuration 'AdvancedStreamingMaxPerAccount' field
+func SetAdvancedStreamingMaxPerAccount(v int) { global.SetAdvancedStreamingMaxPerAccount(v) }
 
-// GetCacheAccountMemRatio safely fetches the Configuration value for state's 'Cache.AccountMemRatio' field
-func (st *ConfigState) GetCacheAccountMemRatio() (v float64) {
+// GetAdvancedStreamingMaxTotal safely fetches the Configuration value for state's 'AdvancedStreamingMaxTotal' field
+func (st *ConfigState) GetAdvancedStreamingMaxTotal() (v int) {
 	st.mutex.RLock()
-	v = st.config.Cache.AccountMemRatio
+	v = st.config.AdvancedStreamingMaxTotal
 	st.mutex.RUnlock()
 	return
 }
 
-// SetCacheAccountMemRatio safely sets the Configuration value for state's 'Cache.AccountMemRatio' field
-func (st *ConfigState) SetCacheAccountMemRatio(v float64) {
+// SetAdvancedStreamingMaxTotal safely sets the Configuration value for state's 'AdvancedStreamingMaxTotal' field
+func (st *ConfigState) SetAdvancedStreamingMaxTotal(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.Cache.AccountMemRatio = v
+	st.config.AdvancedStreamingMaxTotal = v
 	st.reloadToViper()
 }
 
-// CacheAccountMemRatioFlag returns the flag name for the 'Cache.AccountMemRatio' field
-func CacheAccountMemRatioFlag() string { return "cache-account-mem-ratio" }
+// AdvancedStreamingMaxTotalFlag returns the flag name for the 'AdvancedStreamingMaxTotal' field
+func AdvancedStreamingMaxTotalFlag() string { return "advanced-streaming-max-total" }
 
-// GetCacheAccountMemRatio safely fetches the value for global configuration 'Cache.AccountMemRatio' field
-func GetCacheAccountMemRatio() float64 { return global.GetCacheAccountMemRatio() }
+// GetAdvancedStreamingMaxTotal safely fetches the value for global configuration 'AdvancedStreamingMaxTotal' field
+func GetAdvancedStreamingMaxTotal() int { return global.GetAdvancedStreamingMaxTotal() }
 
-// SetCacheAccountMemRatio safely sets the value for global configuration 'Cache.AccountMemRatio' field
-func SetCacheAccountMemRatio(v float64) { global.SetCacheAccountMemRatio(v) }
+// SetAdvancedStreamingMaxTotal safely sets the value for global configuration 'AdvancedStreamingMaxTotal' field
+func SetAdvancedStreamingMaxTotal(v int) { global.SetAdvancedStreamingMaxTotal(v) }
 
-// GetCacheAccountNoteMemRatio safely fetches the Configuration value for state's 'Cache.AccountNoteMemRatio' field
-func (st *ConfigState) GetCacheAccountNoteMemRatio() (v float64) {
+// GetAdvancedStreamingBufferSize safely fetches the Configuration value for state's 'AdvancedStreamingBufferSize' field
+func (st *ConfigState) GetAdvancedStreamingBufferSize() (v int) {
 	st.mutex.RLock()
-	v = st.config.Cache.AccountNoteMemRatio
+	v = st.config.AdvancedStreamingBufferSize
 	st.mutex.RUnlock()
 	return
 }
 
-// SetCacheAccountNoteMemRatio safely sets the Configuration value for state's 'Cache.AccountNoteMemRatio' field
-func (st *ConfigState) SetCacheAccountNoteMemRatio(v float64) {
+// SetAdvancedStreamingBufferSize safely sets the Configuration value for state's 'AdvancedStreamingBufferSize' field
+func (st *ConfigState) SetAdvancedStreamingBufferSize(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.Cache.AccountNoteMemRatio = v
+	st.config.AdvancedStreamingBufferSize = v
 	st.reloadToViper()
 }
 
-// CacheAccountNoteMemRatioFlag returns the flag name for the 'Cache.AccountNoteMemRatio' field
-func CacheAccountNoteMemRatioFlag() string { return "cache-account-note-mem-ratio" }
+// AdvancedStreamingBufferSizeFlag returns the flag name for the 'AdvancedStreamingBufferSize' field
+func AdvancedStreamingBufferSizeFlag() string { return "advanced-streaming-buffer-size" }
 
-// GetCacheAccountNoteMemRatio safely fetches the value for global configuration 'Cache.AccountNoteMemRatio' field
-func GetCacheAccountNoteMemRatio() float64 { return global.GetCacheAccountNoteMemRatio() }
+// GetAdvancedStreamingBufferSize safely fetches the value for global configuration 'AdvancedStreamingBufferSize' field
+func GetAdvancedStreamingBufferSize() int { return global.GetAdvancedStreamingBufferSize() }
 
-// SetCacheAccountNoteMemRatio safely sets the value for global configuration 'Cache.AccountNoteMemRatio' field
-func SetCacheAccountNoteMemRatio(v float64) { global.SetCacheAccountNoteMemRatio(v) }
+// SetAdvancedStreamingBufferSize safely sets the value for global configuration 'AdvancedStreamingBufferSize' field
+func SetAdvancedStreamingBufferSize(v int) { global.SetAdvancedStreamingBufferSize(v) }
 
-// GetCacheAccountSettingsMemRatio safely fetches the Configuration value for state's 'Cache.AccountSettingsMemRatio' field
+// GetAdvancedStreamingPingInterval safely fetches the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) GetAdvancedStreamingPingInterval() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingPingInterval
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingPingInterval safely sets the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) SetAdvancedStreamingPingInterval(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingPingInterval = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingPingIntervalFlag returns the flag name for the 'AdvancedStreamingPingInterval' field
+func AdvancedStreamingPingIntervalFlag() string { return "advanced-streaming-ping-interval" }
+
+// GetAdvancedStreamingPingInterval safely fetches the value for global configuration 'AdvancedStreamingPingInterval' field
+func GetAdvancedStreamingPingInterval() time.Duration {
+	return global.GetAdvancedStreamingPingInterval()
+}
+
+// SetAdvancedStreamingPingInterval safely sets the value for global configuration 'AdvancedStreamingPingInterval' field
+func SetAdvancedStreamingPingInterval(v time.Duration) { global.SetAdvancedStreamingPingInterval(v) }
+
+// GetAdvancedStreamingIdleTimeout safely fetches the Configuration value for state's 'AdvancedStreamingIdleTimeout' field
+func (st *ConfigState) GetAdvancedStreamingIdleTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingIdleTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingIdleTimeout safely sets the Configuration value for state's 'AdvancedStreamingIdleTimeout' field
+func (st *ConfigState) SetAdvancedStreamingIdleTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingIdleTimeout = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingIdleTimeoutFlag returns the flag name for the 'AdvancedStreamingIdleTimeout' field
+func AdvancedStreamingIdleTimeoutFlag() string { return "advanced-streaming-idle-timeout" }
+
+// GetAdvancedStreamingIdleTimeout safely fetches the value for global configuration 'AdvancedStreamingIdleTimeout' field
+func GetAdvancedStreamingIdleTimeout() time.Duration { return global.GetAdvancedStreamingIdleTimeout() }
+
+// SetAdvancedStreamingIdleTimeout safely sets the value for global configuration 'AdvancedStreamingIdleTimeout' field
+func SetAdvancedStreamingIdleTimeout(v time.Duration) { global.SetAdvancedStreamingIdleTimeout(v) }
+
+// GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
+func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedCSPExtraURIs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedCSPExtraURIs safely sets the Configuration value for state's 'AdvancedCSPExtraURIs' field
+func (st *ConfigState) SetAdvancedCSPExtraURIs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedCSPExtraURIs = v
+	st.reloadToViper()
+}
+
+// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
+func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+
+// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
+func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+
+// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
+func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+
+// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedHeaderFilterMode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedHeaderFilterMode = v
+	st.reloadToViper()
+}
+
+// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
+func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+
+// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
+func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+
+// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
+func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+
+// GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
+func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.AllowIPs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientAllowIPs safely sets the Configuration value for state's 'HTTPClient.AllowIPs' field
+func (st *ConfigState) SetHTTPClientAllowIPs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.AllowIPs = v
+	st.reloadToViper()
+}
+
+// HTTPClientAllowIPsFlag returns the flag name for the 'HTTPClient.AllowIPs' field
+func HTTPClientAllowIPsFlag() string { return "httpclient-allow-ips" }
+
+// GetHTTPClientAllowIPs safely fetches the value for global configuration 'HTTPClient.AllowIPs' field
+func GetHTTPClientAllowIPs() []string { return global.GetHTTPClientAllowIPs() }
+
+// SetHTTPClientAllowIPs safely sets the value for global configuration 'HTTPClient.AllowIPs' field
+func SetHTTPClientAllowIPs(v []string) { global.SetHTTPClientAllowIPs(v) }
+
+// GetHTTPClientBlockIPs safely fetches the Configuration value for state's 'HTTPClient.BlockIPs' field
+func (st *ConfigState) GetHTTPClientBlockIPs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.BlockIPs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientBlockIPs safely sets the Configuration value for state's 'HTTPClient.BlockIPs' field
+func (st *ConfigState) SetHTTPClientBlockIPs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.BlockIPs = v
+	st.reloadToViper()
+}
+
+// HTTPClientBlockIPsFlag returns the flag name for the 'HTTPClient.BlockIPs' field
+func HTTPClientBlockIPsFlag() string { return "httpclient-block-ips" }
+
+// GetHTTPClientBlockIPs safely fetches the value for global configuration 'HTTPClient.BlockIPs' field
+func GetHTTPClientBlockIPs() []string { return global.GetHTTPClientBlockIPs() }
+
+// SetHTTPClientBlockIPs safely sets the value for global configuration 'HTTPClient.BlockIPs' field
+func SetHTTPClientBlockIPs(v []string) { global.SetHTTPClientBlockIPs(v) }
+
+// GetHTTPClientTimeout safely fetches the Configuration value for state's 'HTTPClient.Timeout' field
+func (st *ConfigState) GetHTTPClientTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.Timeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientTimeout safely sets the Configuration value for state's 'HTTPClient.Timeout' field
+func (st *ConfigState) SetHTTPClientTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.Timeout = v
+	st.reloadToViper()
+}
+
+// HTTPClientTimeoutFlag returns the flag name for the 'HTTPClient.Timeout' field
+func HTTPClientTimeoutFlag() string { return "httpclient-timeout" }
+
+// GetHTTPClientTimeout safely fetches the value for global configuration 'HTTPClient.Timeout' field
+func GetHTTPClientTimeout() time.Duration { return global.GetHTTPClientTimeout() }
+
+// SetHTTPClientTimeout safely sets the value for global configuration 'HTTPClient.Timeout' field
+func SetHTTPClientTimeout(v time.Duration) { global.SetHTTPClientTimeout(v) }
+
+// GetHTTPClientTLSInsecureSkipVerify safely fetches the Configuration value for state's 'HTTPClient.TLSInsecureSkipVerify' field
+func (st *ConfigState) GetHTTPClientTLSInsecureSkipVerify() (v bool) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.TLSInsecureSkipVerify
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientTLSInsecureSkipVerify safely sets the Configuration value for state's 'HTTPClient.TLSInsecureSkipVerify' field
+func (st *ConfigState) SetHTTPClientTLSInsecureSkipVerify(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.TLSInsecureSkipVerify = v
+	st.reloadToViper()
+}
+
+// HTTPClientTLSInsecureSkipVerifyFlag returns the flag name for the 'HTTPClient.TLSInsecureSkipVerify' field
+func HTTPClientTLSInsecureSkipVerifyFlag() string { return "httpclient-tls-insecure-skip-verify" }
+
+// GetHTTPClientTLSInsecureSkipVerify safely fetches the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
+func GetHTTPClientTLSInsecureSkipVerify() bool { return global.GetHTTPClientTLSInsecureSkipVerify() }
+
+// SetHTTPClientTLSInsecureSkipVerify safely sets the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
+func SetHTTPClientTLSInsecureSkipVerify(v bool) { global.SetHTTPClientTLSInsecureSkipVerify(v) }
+
+// GetHTTPClientHostRequestBudget safely fetches the Configuration value for state's 'HTTPClient.HostRequestBudget' field
+func (st *ConfigState) GetHTTPClientHostRequestBudget() (v int) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.HostRequestBudget
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientHostRequestBudget safely sets the Configuration value for state's 'HTTPClient.HostRequestBudget' field
+func (st *ConfigState) SetHTTPClientHostRequestBudget(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.HostRequestBudget = v
+	st.reloadToViper()
+}
+
+// HTTPClientHostRequestBudgetFlag returns the flag name for the 'HTTPClient.HostRequestBudget' field
+func HTTPClientHostRequestBudgetFlag() string { return "httpclient-host-request-budget" }
+
+// GetHTTPClientHostRequestBudget safely fetches the value for global configuration 'HTTPClient.HostRequestBudget' field
+func GetHTTPClientHostRequestBudget() int { return global.GetHTTPClientHostRequestBudget() }
+
+// SetHTTPClientHostRequestBudget safely sets the value for global configuration 'HTTPClient.HostRequestBudget' field
+func SetHTTPClientHostRequestBudget(v int) { global.SetHTTPClientHostRequestBudget(v) }
+
+// GetHTTPClientHostRequestWindow safely fetches the Configuration value for state's 'HTTPClient.HostRequestWindow' field
+func (st *ConfigState) GetHTTPClientHostRequestWindow() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.HostRequestWindow
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientHostRequestWindow safely sets the Configuration value for state's 'HTTPClient.HostRequestWindow' field
+func (st *ConfigState) SetHTTPClientHostRequestWindow(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.HostRequestWindow = v
+	st.reloadToViper()
+}
+
+// HTTPClientHostRequestWindowFlag returns the flag name for the 'HTTPClient.HostRequestWindow' field
+func HTTPClientHostRequestWindowFlag() string { return "httpclient-host-request-window" }
+
+// GetHTTPClientHostRequestWindow safely fetches the value for global configuration 'HTTPClient.HostRequestWindow' field
+func GetHTTPClientHostRequestWindow() time.Duration { return global.GetHTTPClientHostRequestWindow() }
+
+// SetHTTPClientHostRequestWindow safely sets the value for global configuration 'HTTPClient.HostRequestWindow' field
+func SetHTTPClientHostRequestWindow(v time.Duration) { global.SetHTTPClientHostRequestWindow(v) }
+
+// GetHTTPClientDeliveryTimeout safely fetches the Configuration value for state's 'HTTPClient.DeliveryTimeout' field
+func (st *ConfigState) GetHTTPClientDeliveryTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.DeliveryTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientDeliveryTimeout safely sets the Configuration value for state's 'HTTPClient.DeliveryTimeout' field
+func (st *ConfigState) SetHTTPClientDeliveryTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.DeliveryTimeout = v
+	st.reloadToViper()
+}
+
+// HTTPClientDeliveryTimeoutFlag returns the flag name for the 'HTTPClient.DeliveryTimeout' field
+func HTTPClientDeliveryTimeoutFlag() string { return "httpclient-delivery-timeout" }
+
+// GetHTTPClientDeliveryTimeout safely fetches the value for global configuration 'HTTPClient.DeliveryTimeout' field
+func GetHTTPClientDeliveryTimeout() time.Duration { return global.GetHTTPClientDeliveryTimeout() }
+
+// SetHTTPClientDeliveryTimeout safely sets the value for global configuration 'HTTPClient.DeliveryTimeout' field
+func SetHTTPClientDeliveryTimeout(v time.Duration) { global.SetHTTPClientDeliveryTimeout(v) }
+
+// GetHTTPClientDeliveryMaxBodySize safely fetches the Configuration value for state's 'HTTPClient.DeliveryMaxBodySize' field
+func (st *ConfigState) GetHTTPClientDeliveryMaxBodySize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.DeliveryMaxBodySize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientDeliveryMaxBodySize safely sets the Configuration value for state's 'HTTPClient.DeliveryMaxBodySize' field
+func (st *ConfigState) SetHTTPClientDeliveryMaxBodySize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.DeliveryMaxBodySize = v
+	st.reloadToViper()
+}
+
+// HTTPClientDeliveryMaxBodySizeFlag returns the flag name for the 'HTTPClient.DeliveryMaxBodySize' field
+func HTTPClientDeliveryMaxBodySizeFlag() string { return "httpclient-delivery-max-body-size" }
+
+// GetHTTPClientDeliveryMaxBodySize safely fetches the value for global configuration 'HTTPClient.DeliveryMaxBodySize' field
+func GetHTTPClientDeliveryMaxBodySize() bytesize.Size {
+	return global.GetHTTPClientDeliveryMaxBodySize()
+}
+
+// SetHTTPClientDeliveryMaxBodySize safely sets the value for global configuration 'HTTPClient.DeliveryMaxBodySize' field
+func SetHTTPClientDeliveryMaxBodySize(v bytesize.Size) { global.SetHTTPClientDeliveryMaxBodySize(v) }
+
+// GetHTTPClientDereferenceTimeout safely fetches the Configuration value for state's 'HTTPClient.DereferenceTimeout' field
+func (st *ConfigState) GetHTTPClientDereferenceTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.DereferenceTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientDereferenceTimeout safely sets the Configuration value for state's 'HTTPClient.DereferenceTimeout' field
+func (st *ConfigState) SetHTTPClientDereferenceTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.DereferenceTimeout = v
+	st.reloadToViper()
+}
+
+// HTTPClientDereferenceTimeoutFlag returns the flag name for the 'HTTPClient.DereferenceTimeout' field
+func HTTPClientDereferenceTimeoutFlag() string { return "httpclient-dereference-timeout" }
+
+// GetHTTPClientDereferenceTimeout safely fetches the value for global configuration 'HTTPClient.DereferenceTimeout' field
+func GetHTTPClientDereferenceTimeout() time.Duration { return global.GetHTTPClientDereferenceTimeout() }
+
+// SetHTTPClientDereferenceTimeout safely sets the value for global configuration 'HTTPClient.DereferenceTimeout' field
+func SetHTTPClientDereferenceTimeout(v time.Duration) { global.SetHTTPClientDereferenceTimeout(v) }
+
+// GetHTTPClientDereferenceMaxBodySize safely fetches the Configuration value for state's 'HTTPClient.DereferenceMaxBodySize' field
+func (st *ConfigState) GetHTTPClientDereferenceMaxBodySize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.DereferenceMaxBodySize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientDereferenceMaxBodySize safely sets the Configuration value for state's 'HTTPClient.DereferenceMaxBodySize' field
+func (st *ConfigState) SetHTTPClientDereferenceMaxBodySize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.DereferenceMaxBodySize = v
+	st.reloadToViper()
+}
+
+// HTTPClientDereferenceMaxBodySizeFlag returns the flag name for the 'HTTPClient.DereferenceMaxBodySize' field
+func HTTPClientDereferenceMaxBodySizeFlag() string { return "httpclient-dereference-max-body-size" }
+
+// GetHTTPClientDereferenceMaxBodySize safely fetches the value for global configuration 'HTTPClient.DereferenceMaxBodySize' field
+func GetHTTPClientDereferenceMaxBodySize() bytesize.Size {
+	return global.GetHTTPClientDereferenceMaxBodySize()
+}
+
+// SetHTTPClientDereferenceMaxBodySize safely sets the value for global configuration 'HTTPClient.DereferenceMaxBodySize' field
+func SetHTTPClientDereferenceMaxBodySize(v bytesize.Size) {
+	global.SetHTTPClientDereferenceMaxBodySize(v)
+}
+
+// GetHTTPClientMediaTimeout safely fetches the Configuration value for state's 'HTTPClient.MediaTimeout' field
+func (st *ConfigState) GetHTTPClientMediaTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.MediaTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientMediaTimeout safely sets the Configuration value for state's 'HTTPClient.MediaTimeout' field
+func (st *ConfigState) SetHTTPClientMediaTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.MediaTimeout = v
+	st.reloadToViper()
+}
+
+// HTTPClientMediaTimeoutFlag returns the flag name for the 'HTTPClient.MediaTimeout' field
+func HTTPClientMediaTimeoutFlag() string { return "httpclient-media-timeout" }
+
+// GetHTTPClientMediaTimeout safely fetches the value for global configuration 'HTTPClient.MediaTimeout' field
+func GetHTTPClientMediaTimeout() time.Duration { return global.GetHTTPClientMediaTimeout() }
+
+// SetHTTPClientMediaTimeout safely sets the value for global configuration 'HTTPClient.MediaTimeout' field
+func SetHTTPClientMediaTimeout(v time.Duration) { global.SetHTTPClientMediaTimeout(v) }
+
+// GetHTTPClientMediaMaxBodySize safely fetches the Configuration value for state's 'HTTPClient.MediaMaxBodySize' field
+func (st *ConfigState) GetHTTPClientMediaMaxBodySize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.MediaMaxBodySize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientMediaMaxBodySize safely sets the Configuration value for state's 'HTTPClient.MediaMaxBodySize' field
+func (st *ConfigState) SetHTTPClientMediaMaxBodySize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.MediaMaxBodySize = v
+	st.reloadToViper()
+}
+
+// HTTPClientMediaMaxBodySizeFlag returns the flag name for the 'HTTPClient.MediaMaxBodySize' field
+func HTTPClientMediaMaxBodySizeFlag() string { return "httpclient-media-max-body-size" }
+
+// GetHTTPClientMediaMaxBodySize safely fetches the value for global configuration 'HTTPClient.MediaMaxBodySize' field
+func GetHTTPClientMediaMaxBodySize() bytesize.Size { return global.GetHTTPClientMediaMaxBodySize() }
+
+// SetHTTPClientMediaMaxBodySize safely sets the value for global configuration 'HTTPClient.MediaMaxBodySize' field
+func SetHTTPClientMediaMaxBodySize(v bytesize.Size) { global.SetHTTPClientMediaMaxBodySize(v) }
+
+// GetHTTPClientProxy safely fetches the Configuration value for state's 'HTTPClient.Proxy' field
+func (st *ConfigState) GetHTTPClientProxy() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.Proxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientProxy safely sets the Configuration value for state's 'HTTPClient.Proxy' field
+func (st *ConfigState) SetHTTPClientProxy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.Proxy = v
+	st.reloadToViper()
+}
+
+// HTTPClientProxyFlag returns the flag name for the 'HTTPClient.Proxy' field
+func HTTPClientProxyFlag() string { return "httpclient-proxy" }
+
+// GetHTTPClientProxy safely fetches the value for global configuration 'HTTPClient.Proxy' field
+func GetHTTPClientProxy() string { return global.GetHTTPClientProxy() }
+
+// SetHTTPClientProxy safely sets the value for global configuration 'HTTPClient.Proxy' field
+func SetHTTPClientProxy(v string) { global.SetHTTPClientProxy(v) }
+
+// GetHTTPClientOnionProxy safely fetches the Configuration value for state's 'HTTPClient.OnionProxy' field
+func (st *ConfigState) GetHTTPClientOnionProxy() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.OnionProxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientOnionProxy safely sets the Configuration value for state's 'HTTPClient.OnionProxy' field
+func (st *ConfigState) SetHTTPClientOnionProxy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.OnionProxy = v
+	st.reloadToViper()
+}
+
+// HTTPClientOnionProxyFlag returns the flag name for the 'HTTPClient.OnionProxy' field
+func HTTPClientOnionProxyFlag() string { return "httpclient-onion-proxy" }
+
+// GetHTTPClientOnionProxy safely fetches the value for global configuration 'HTTPClient.OnionProxy' field
+func GetHTTPClientOnionProxy() string { return global.GetHTTPClientOnionProxy() }
+
+// SetHTTPClientOnionProxy safely sets the value for global configuration 'HTTPClient.OnionProxy' field
+func SetHTTPClientOnionProxy(v string) { global.SetHTTPClientOnionProxy(v) }
+
+// GetHTTPClientI2PProxy safely fetches the Configuration value for state's 'HTTPClient.I2PProxy' field
+func (st *ConfigState) GetHTTPClientI2PProxy() (v string) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.I2PProxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientI2PProxy safely sets the Configuration value for state's 'HTTPClient.I2PProxy' field
+func (st *ConfigState) SetHTTPClientI2PProxy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.I2PProxy = v
+	st.reloadToViper()
+}
+
+// HTTPClientI2PProxyFlag returns the flag name for the 'HTTPClient.I2PProxy' field
+func HTTPClientI2PProxyFlag() string { return "httpclient-i2p-proxy" }
+
+// GetHTTPClientI2PProxy safely fetches the value for global configuration 'HTTPClient.I2PProxy' field
+func GetHTTPClientI2PProxy() string { return global.GetHTTPClientI2PProxy() }
+
+// SetHTTPClientI2PProxy safely sets the value for global configuration 'HTTPClient.I2PProxy' field
+func SetHTTPClientI2PProxy(v string) { global.SetHTTPClientI2PProxy(v) }
+
+// GetHTTPClientCircuitBreakerFailures safely fetches the Configuration value for state's 'HTTPClient.CircuitBreakerFailures' field
+func (st *ConfigState) GetHTTPClientCircuitBreakerFailures() (v int) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.CircuitBreakerFailures
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientCircuitBreakerFailures safely sets the Configuration value for state's 'HTTPClient.CircuitBreakerFailures' field
+func (st *ConfigState) SetHTTPClientCircuitBreakerFailures(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.CircuitBreakerFailures = v
+	st.reloadToViper()
+}
+
+// HTTPClientCircuitBreakerFailuresFlag returns the flag name for the 'HTTPClient.CircuitBreakerFailures' field
+func HTTPClientCircuitBreakerFailuresFlag() string { return "httpclient-circuit-breaker-failures" }
+
+// GetHTTPClientCircuitBreakerFailures safely fetches the value for global configuration 'HTTPClient.CircuitBreakerFailures' field
+func GetHTTPClientCircuitBreakerFailures() int { return global.GetHTTPClientCircuitBreakerFailures() }
+
+// SetHTTPClientCircuitBreakerFailures safely sets the value for global configuration 'HTTPClient.CircuitBreakerFailures' field
+func SetHTTPClientCircuitBreakerFailures(v int) { global.SetHTTPClientCircuitBreakerFailures(v) }
+
+// GetHTTPClientCircuitBreakerCooldown safely fetches the Configuration value for state's 'HTTPClient.CircuitBreakerCooldown' field
+func (st *ConfigState) GetHTTPClientCircuitBreakerCooldown() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.CircuitBreakerCooldown
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientCircuitBreakerCooldown safely sets the Configuration value for state's 'HTTPClient.CircuitBreakerCooldown' field
+func (st *ConfigState) SetHTTPClientCircuitBreakerCooldown(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.CircuitBreakerCooldown = v
+	st.reloadToViper()
+}
+
+// HTTPClientCircuitBreakerCooldownFlag returns the flag name for the 'HTTPClient.CircuitBreakerCooldown' field
+func HTTPClientCircuitBreakerCooldownFlag() string { return "httpclient-circuit-breaker-cooldown" }
+
+// GetHTTPClientCircuitBreakerCooldown safely fetches the value for global configuration 'HTTPClient.CircuitBreakerCooldown' field
+func GetHTTPClientCircuitBreakerCooldown() time.Duration {
+	return global.GetHTTPClientCircuitBreakerCooldown()
+}
+
+// SetHTTPClientCircuitBreakerCooldown safely sets the value for global configuration 'HTTPClient.CircuitBreakerCooldown' field
+func SetHTTPClientCircuitBreakerCooldown(v time.Duration) {
+	global.SetHTTPClientCircuitBreakerCooldown(v)
+}
+
+// GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
+func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.Cache.MemoryTarget
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheMemoryTarget safely sets the Configuration value for state's 'Cache.MemoryTarget' field
+func (st *ConfigState) SetCacheMemoryTarget(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.MemoryTarget = v
+	st.reloadToViper()
+}
+
+// CacheMemoryTargetFlag returns the flag name for the 'Cache.MemoryTarget' field
+func CacheMemoryTargetFlag() string { return "cache-memory-target" }
+
+// GetCacheMemoryTarget safely fetches the value for global configuration 'Cache.MemoryTarget' field
+func GetCacheMemoryTarget() bytesize.Size { return global.GetCacheMemoryTarget() }
+
+// SetCacheMemoryTarget safely sets the value for global configuration 'Cache.MemoryTarget' field
+func SetCacheMemoryTarget(v bytesize.Size) { global.SetCacheMemoryTarget(v) }
+
+// GetCacheAccountMemRatio safely fetches the Configuration value for state's 'Cache.AccountMemRatio' field
+func (st *ConfigState) GetCacheAccountMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.AccountMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheAccountMemRatio safely sets the Configuration value for state's 'Cache.AccountMemRatio' field
+func (st *ConfigState) SetCacheAccountMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.AccountMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheAccountMemRatioFlag returns the flag name for the 'Cache.AccountMemRatio' field
+func CacheAccountMemRatioFlag() string { return "cache-account-mem-ratio" }
+
+// GetCacheAccountMemRatio safely fetches the value for global configuration 'Cache.AccountMemRatio' field
+func GetCacheAccountMemRatio() float64 { return global.GetCacheAccountMemRatio() }
+
+// SetCacheAccountMemRatio safely sets the value for global configuration 'Cache.AccountMemRatio' field
+func SetCacheAccountMemRatio(v float64) { global.SetCacheAccountMemRatio(v) }
+
+// GetCacheAccountNoteMemRatio safely fetches the Configuration value for state's 'Cache.AccountNoteMemRatio' field
+func (st *ConfigState) GetCacheAccountNoteMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.AccountNoteMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheAccountNoteMemRatio safely sets the Configuration value for state's 'Cache.AccountNoteMemRatio' field
+func (st *ConfigState) SetCacheAccountNoteMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.AccountNoteMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheAccountNoteMemRatioFlag returns the flag name for the 'Cache.AccountNoteMemRatio' field
+func CacheAccountNoteMemRatioFlag() string { return "cache-account-note-mem-ratio" }
+
+// GetCacheAccountNoteMemRatio safely fetches the value for global configuration 'Cache.AccountNoteMemRatio' field
+func GetCacheAccountNoteMemRatio() float64 { return global.GetCacheAccountNoteMemRatio() }
+
+// SetCacheAccountNoteMemRatio safely sets the value for global configuration 'Cache.AccountNoteMemRatio' field
+func SetCacheAccountNoteMemRatio(v float64) { global.SetCacheAccountNoteMemRatio(v) }
+
+// GetCacheAccountSettingsMemRatio safely fetches the Configuration value for state's 'Cache.AccountSettingsMemRatio' field
 func (st *ConfigState) GetCacheAccountSettingsMemRatio() (v float64) {
 	st.mutex.RLock()
 	v = st.config.Cache.AccountSettingsMemRatio
@@ -3825,6 +4720,56 @@ func GetCacheUserMuteIDsMemRatio() float64 { return global.GetCacheUserMuteIDsMe
 // SetCacheUserMuteIDsMemRatio safely sets the value for global configuration 'Cache.UserMuteIDsMemRatio' field
 func SetCacheUserMuteIDsMemRatio(v float64) { global.SetCacheUserMuteIDsMemRatio(v) }
 
+// GetCacheUnretrievableMemRatio safely fetches the Configuration value for state's 'Cache.UnretrievableMemRatio' field
+func (st *ConfigState) GetCacheUnretrievableMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.UnretrievableMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheUnretrievableMemRatio safely sets the Configuration value for state's 'Cache.UnretrievableMemRatio' field
+func (st *ConfigState) SetCacheUnretrievableMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.UnretrievableMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheUnretrievableMemRatioFlag returns the flag name for the 'Cache.UnretrievableMemRatio' field
+func CacheUnretrievableMemRatioFlag() string { return "cache-unretrievable-mem-ratio" }
+
+// GetCacheUnretrievableMemRatio safely fetches the value for global configuration 'Cache.UnretrievableMemRatio' field
+func GetCacheUnretrievableMemRatio() float64 { return global.GetCacheUnretrievableMemRatio() }
+
+// SetCacheUnretrievableMemRatio safely sets the value for global configuration 'Cache.UnretrievableMemRatio' field
+func SetCacheUnretrievableMemRatio(v float64) { global.SetCacheUnretrievableMemRatio(v) }
+
+// GetCacheInboxActivityMemRatio safely fetches the Configuration value for state's 'Cache.InboxActivityMemRatio' field
+func (st *ConfigState) GetCacheInboxActivityMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.InboxActivityMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheInboxActivityMemRatio safely sets the Configuration value for state's 'Cache.InboxActivityMemRatio' field
+func (st *ConfigState) SetCacheInboxActivityMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.InboxActivityMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheInboxActivityMemRatioFlag returns the flag name for the 'Cache.InboxActivityMemRatio' field
+func CacheInboxActivityMemRatioFlag() string { return "cache-inbox-activity-mem-ratio" }
+
+// GetCacheInboxActivityMemRatio safely fetches the value for global configuration 'Cache.InboxActivityMemRatio' field
+func GetCacheInboxActivityMemRatio() float64 { return global.GetCacheInboxActivityMemRatio() }
+
+// SetCacheInboxActivityMemRatio safely sets the value for global configuration 'Cache.InboxActivityMemRatio' field
+func SetCacheInboxActivityMemRatio(v float64) { global.SetCacheInboxActivityMemRatio(v) }
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4050,6 +4995,256 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminMediaMigrateTo safely fetches the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) GetAdminMediaMigrateTo() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateTo
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateTo safely sets the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) SetAdminMediaMigrateTo(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateTo = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateToFlag returns the flag name for the 'AdminMediaMigrateTo' field
+func AdminMediaMigrateToFlag() string { return "to" }
+
+// GetAdminMediaMigrateTo safely fetches the value for global configuration 'AdminMediaMigrateTo' field
+func GetAdminMediaMigrateTo() string { return global.GetAdminMediaMigrateTo() }
+
+// SetAdminMediaMigrateTo safely sets the value for global configuration 'AdminMediaMigrateTo' field
+func SetAdminMediaMigrateTo(v string) { global.SetAdminMediaMigrateTo(v) }
+
+// GetAdminKeysRotateUsers safely fetches the Configuration value for state's 'AdminKeysRotateUsers' field
+func (st *ConfigState) GetAdminKeysRotateUsers() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminKeysRotateUsers
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminKeysRotateUsers safely sets the Configuration value for state's 'AdminKeysRotateUsers' field
+func (st *ConfigState) SetAdminKeysRotateUsers(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminKeysRotateUsers = v
+	st.reloadToViper()
+}
+
+// AdminKeysRotateUsersFlag returns the flag name for the 'AdminKeysRotateUsers' field
+func AdminKeysRotateUsersFlag() string { return "users" }
+
+// GetAdminKeysRotateUsers safely fetches the value for global configuration 'AdminKeysRotateUsers' field
+func GetAdminKeysRotateUsers() bool { return global.GetAdminKeysRotateUsers() }
+
+// SetAdminKeysRotateUsers safely sets the value for global configuration 'AdminKeysRotateUsers' field
+func SetAdminKeysRotateUsers(v bool) { global.SetAdminKeysRotateUsers(v) }
+
+// GetAdminKeysRotateFederate safely fetches the Configuration value for state's 'AdminKeysRotateFederate' field
+func (st *ConfigState) GetAdminKeysRotateFederate() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminKeysRotateFederate
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminKeysRotateFederate safely sets the Configuration value for state's 'AdminKeysRotateFederate' field
+func (st *ConfigState) SetAdminKeysRotateFederate(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminKeysRotateFederate = v
+	st.reloadToViper()
+}
+
+// AdminKeysRotateFederateFlag returns the flag name for the 'AdminKeysRotateFederate' field
+func AdminKeysRotateFederateFlag() string { return "federate" }
+
+// GetAdminKeysRotateFederate safely fetches the value for global configuration 'AdminKeysRotateFederate' field
+func GetAdminKeysRotateFederate() bool { return global.GetAdminKeysRotateFederate() }
+
+// SetAdminKeysRotateFederate safely sets the value for global configuration 'AdminKeysRotateFederate' field
+func SetAdminKeysRotateFederate(v bool) { global.SetAdminKeysRotateFederate(v) }
+
+// GetAdminDeliveryDomain safely fetches the Configuration value for state's 'AdminDeliveryDomain' field
+func (st *ConfigState) GetAdminDeliveryDomain() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDeliveryDomain
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDeliveryDomain safely sets the Configuration value for state's 'AdminDeliveryDomain' field
+func (st *ConfigState) SetAdminDeliveryDomain(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDeliveryDomain = v
+	st.reloadToViper()
+}
+
+// AdminDeliveryDomainFlag returns the flag name for the 'AdminDeliveryDomain' field
+func AdminDeliveryDomainFlag() string { return "domain" }
+
+// GetAdminDeliveryDomain safely fetches the value for global configuration 'AdminDeliveryDomain' field
+func GetAdminDeliveryDomain() string { return global.GetAdminDeliveryDomain() }
+
+// SetAdminDeliveryDomain safely sets the value for global configuration 'AdminDeliveryDomain' field
+func SetAdminDeliveryDomain(v string) { global.SetAdminDeliveryDomain(v) }
+
+// GetAdminDeliveryType safely fetches the Configuration value for state's 'AdminDeliveryType' field
+func (st *ConfigState) GetAdminDeliveryType() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDeliveryType
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDeliveryType safely sets the Configuration value for state's 'AdminDeliveryType' field
+func (st *ConfigState) SetAdminDeliveryType(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDeliveryType = v
+	st.reloadToViper()
+}
+
+// AdminDeliveryTypeFlag returns the flag name for the 'AdminDeliveryType' field
+func AdminDeliveryTypeFlag() string { return "activity-type" }
+
+// GetAdminDeliveryType safely fetches the value for global configuration 'AdminDeliveryType' field
+func GetAdminDeliveryType() string { return global.GetAdminDeliveryType() }
+
+// SetAdminDeliveryType safely sets the value for global configuration 'AdminDeliveryType' field
+func SetAdminDeliveryType(v string) { global.SetAdminDeliveryType(v) }
+
+// GetAdminInviteMaxUses safely fetches the Configuration value for state's 'AdminInviteMaxUses' field
+func (st *ConfigState) GetAdminInviteMaxUses() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdminInviteMaxUses
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminInviteMaxUses safely sets the Configuration value for state's 'AdminInviteMaxUses' field
+func (st *ConfigState) SetAdminInviteMaxUses(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminInviteMaxUses = v
+	st.reloadToViper()
+}
+
+// AdminInviteMaxUsesFlag returns the flag name for the 'AdminInviteMaxUses' field
+func AdminInviteMaxUsesFlag() string { return "max-uses" }
+
+// GetAdminInviteMaxUses safely fetches the value for global configuration 'AdminInviteMaxUses' field
+func GetAdminInviteMaxUses() int { return global.GetAdminInviteMaxUses() }
+
+// SetAdminInviteMaxUses safely sets the value for global configuration 'AdminInviteMaxUses' field
+func SetAdminInviteMaxUses(v int) { global.SetAdminInviteMaxUses(v) }
+
+// GetAdminInviteExpiresIn safely fetches the Configuration value for state's 'AdminInviteExpiresIn' field
+func (st *ConfigState) GetAdminInviteExpiresIn() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdminInviteExpiresIn
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminInviteExpiresIn safely sets the Configuration value for state's 'AdminInviteExpiresIn' field
+func (st *ConfigState) SetAdminInviteExpiresIn(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminInviteExpiresIn = v
+	st.reloadToViper()
+}
+
+// AdminInviteExpiresInFlag returns the flag name for the 'AdminInviteExpiresIn' field
+func AdminInviteExpiresInFlag() string { return "expires-in" }
+
+// GetAdminInviteExpiresIn safely fetches the value for global configuration 'AdminInviteExpiresIn' field
+func GetAdminInviteExpiresIn() time.Duration { return global.GetAdminInviteExpiresIn() }
+
+// SetAdminInviteExpiresIn safely sets the value for global configuration 'AdminInviteExpiresIn' field
+func SetAdminInviteExpiresIn(v time.Duration) { global.SetAdminInviteExpiresIn(v) }
+
+// GetAdminInviteCode safely fetches the Configuration value for state's 'AdminInviteCode' field
+func (st *ConfigState) GetAdminInviteCode() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminInviteCode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminInviteCode safely sets the Configuration value for state's 'AdminInviteCode' field
+func (st *ConfigState) SetAdminInviteCode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminInviteCode = v
+	st.reloadToViper()
+}
+
+// AdminInviteCodeFlag returns the flag name for the 'AdminInviteCode' field
+func AdminInviteCodeFlag() string { return "code" }
+
+// GetAdminInviteCode safely fetches the value for global configuration 'AdminInviteCode' field
+func GetAdminInviteCode() string { return global.GetAdminInviteCode() }
+
+// SetAdminInviteCode safely sets the value for global configuration 'AdminInviteCode' field
+func SetAdminInviteCode(v string) { global.SetAdminInviteCode(v) }
+
+// GetAdminEmojiCategory safely fetches the Configuration value for state's 'AdminEmojiCategory' field
+func (st *ConfigState) GetAdminEmojiCategory() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiCategory
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiCategory safely sets the Configuration value for state's 'AdminEmojiCategory' field
+func (st *ConfigState) SetAdminEmojiCategory(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiCategory = v
+	st.reloadToViper()
+}
+
+// AdminEmojiCategoryFlag returns the flag name for the 'AdminEmojiCategory' field
+func AdminEmojiCategoryFlag() string { return "category" }
+
+// GetAdminEmojiCategory safely fetches the value for global configuration 'AdminEmojiCategory' field
+func GetAdminEmojiCategory() string { return global.GetAdminEmojiCategory() }
+
+// SetAdminEmojiCategory safely sets the value for global configuration 'AdminEmojiCategory' field
+func SetAdminEmojiCategory(v string) { global.SetAdminEmojiCategory(v) }
+
+// GetAdminEmojiOnConflict safely fetches the Configuration value for state's 'AdminEmojiOnConflict' field
+func (st *ConfigState) GetAdminEmojiOnConflict() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiOnConflict
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiOnConflict safely sets the Configuration value for state's 'AdminEmojiOnConflict' field
+func (st *ConfigState) SetAdminEmojiOnConflict(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiOnConflict = v
+	st.reloadToViper()
+}
+
+// AdminEmojiOnConflictFlag returns the flag name for the 'AdminEmojiOnConflict' field
+func AdminEmojiOnConflictFlag() string { return "on-conflict" }
+
+// GetAdminEmojiOnConflict safely fetches the value for global configuration 'AdminEmojiOnConflict' field
+func GetAdminEmojiOnConflict() string { return global.GetAdminEmojiOnConflict() }
+
+// SetAdminEmojiOnConflict safely sets the value for global configuration 'AdminEmojiOnConflict' field
+func SetAdminEmojiOnConflict(v string) { global.SetAdminEmojiOnConflict(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
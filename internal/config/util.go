@@ -18,7 +18,11 @@
 package config
 
 import (
+	"fmt"
 	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -37,3 +41,43 @@ func MustParseIPPrefixes(in []string) []netip.Prefix {
 
 	return prefs
 }
+
+// MustParseURL parses in as a URL, returning
+// nil if empty, and panicking if invalid.
+func MustParseURL(in string) *url.URL {
+	if in == "" {
+		return nil
+	}
+
+	u, err := url.Parse(in)
+	if err != nil {
+		log.Panicf(nil, "error parsing url from %q: %v", in, err)
+	}
+
+	return u
+}
+
+// ParseActivityConcurrency parses activity concurrency limit entries
+// of the form 'Type=N' or 'Type/ObjectType=N', returning a map of
+// lowercased 'type' or 'type/objecttype' keys to their limits.
+func ParseActivityConcurrency(in []string) (map[string]int, error) {
+	limits := make(map[string]int, len(in))
+
+	for _, entry := range in {
+		key, val, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.Count(key, "/") > 1 ||
+			strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+			return nil, fmt.Errorf("entry %q must be of the form Type=N or Type/ObjectType=N", entry)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("entry %q limit must be a positive integer", entry)
+		}
+
+		limits[strings.ToLower(key)] = n
+	}
+
+	return limits, nil
+}
@@ -19,6 +19,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -98,6 +99,19 @@ func Validate() error {
 		)
 	}
 
+	// `media-derived-format` should
+	// be "webp" or unset (disabled).
+	switch format := GetMediaDerivedFormat(); format {
+	case MediaDerivedFormatWebP, MediaDerivedFormatDisabled:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to either webp or empty, provided value was %s",
+			MediaDerivedFormatFlag(), format,
+		)
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -118,6 +132,43 @@ func Validate() error {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// `http-client` proxies should
+	// be unset, or valid proxy URLs.
+	for _, p := range []struct{ flag, proxy string }{
+		{HTTPClientProxyFlag(), GetHTTPClientProxy()},
+		{HTTPClientOnionProxyFlag(), GetHTTPClientOnionProxy()},
+		{HTTPClientI2PProxyFlag(), GetHTTPClientI2PProxy()},
+	} {
+		flag, proxy := p.flag, p.proxy
+		if proxy == "" {
+			continue
+		}
+
+		u, err := url.Parse(proxy)
+		switch {
+		case err != nil:
+			errf("%s could not be parsed as a url: %v", flag, err)
+
+		case u.Scheme != "http" && u.Scheme != "https" &&
+			u.Scheme != "socks5" && u.Scheme != "socks5h":
+			errf(
+				"%s scheme must be one of http, https, socks5 or socks5h, provided value was %s",
+				flag, u.Scheme,
+			)
+
+		case u.Host == "":
+			errf("%s must include a host", flag)
+		}
+	}
+
+	// Ensure `advanced-activity-concurrency`
+	// entries are all in the expected form.
+	if _, err := ParseActivityConcurrency(
+		GetAdvancedActivityConcurrency(),
+	); err != nil {
+		errf("%s %v", AdvancedActivityConcurrencyFlag(), err)
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
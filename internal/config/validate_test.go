@@ -140,6 +140,38 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadMediaDerivedFormat() {
+	testrig.InitTestConfig()
+
+	config.SetMediaDerivedFormat("avif")
+
+	err := config.Validate()
+	suite.EqualError(err, "media-derived-format must be set to either webp or empty, provided value was avif")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadHTTPClientProxy() {
+	testrig.InitTestConfig()
+
+	config.SetHTTPClientProxy("ftp://proxy.example.org")
+
+	err := config.Validate()
+	suite.EqualError(err, "httpclient-proxy scheme must be one of http, https, socks5 or socks5h, provided value was ftp")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadActivityConcurrency() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedActivityConcurrency([]string{"Create/Note=8", "Delete"})
+
+	err := config.Validate()
+	suite.EqualError(err, "advanced-activity-concurrency entry \"Delete\" must be of the form Type=N or Type/ObjectType=N")
+
+	config.SetAdvancedActivityConcurrency([]string{"Create/Note=0"})
+
+	err = config.Validate()
+	suite.EqualError(err, "advanced-activity-concurrency entry \"Create/Note=0\" limit must be a positive integer")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
@@ -140,6 +140,10 @@ type Account interface {
 	// Update local account settings.
 	UpdateAccountSettings(ctx context.Context, settings *gtsmodel.AccountSettings, columns ...string) error
 
+	// GetAccountsWithStatusExpiry returns all local accounts
+	// whose settings have status expiry enabled (ExpiryDays > 0).
+	GetAccountsWithStatusExpiry(ctx context.Context) ([]*gtsmodel.Account, error)
+
 	// PopulateAccountStats either creates account stats for the given
 	// account by performing COUNT(*) database queries, or retrieves
 	// existing stats from the database, and attaches stats to account.
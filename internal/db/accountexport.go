@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountExport contains functionality for tracking
+// archives of local account data, see gtsmodel.AccountExport.
+type AccountExport interface {
+	// GetAccountExportByAccountID returns the account export
+	// of the account with given ID, if there is one.
+	GetAccountExportByAccountID(ctx context.Context, accountID string) (*gtsmodel.AccountExport, error)
+
+	// GetAccountExports returns all account exports currently in the database.
+	GetAccountExports(ctx context.Context) ([]*gtsmodel.AccountExport, error)
+
+	// PutAccountExport puts the given account export in the database. Returns
+	// ErrAlreadyExists if there is already an export for the same account.
+	PutAccountExport(ctx context.Context, export *gtsmodel.AccountExport) error
+
+	// UpdateAccountExport updates the given account export in the database,
+	// only updating given columns if provided. The updated_at column is
+	// always updated.
+	UpdateAccountExport(ctx context.Context, export *gtsmodel.AccountExport, columns ...string) error
+
+	// DeleteAccountExportByID deletes the account export with given ID from the database.
+	DeleteAccountExportByID(ctx context.Context, id string) error
+}
@@ -59,6 +59,12 @@ type Admin interface {
 	// (ie., the application owned by the instance account).
 	GetInstanceApplication(ctx context.Context) (*gtsmodel.Application, error)
 
+	// RotateAccountKeys generates a new RSA keypair for the given
+	// local account, replacing (and discarding) its existing keys.
+	// The public key URI is changed to a new key ID, so remote servers
+	// fetch the new public key the next time they see it in a signature.
+	RotateAccountKeys(ctx context.Context, account *gtsmodel.Account) error
+
 	// CountApprovedSignupsSince counts the number of new account
 	// sign-ups approved on this instance since the given time.
 	CountApprovedSignupsSince(ctx context.Context, since time.Time) (int, error)
@@ -463,7 +463,28 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts that signed up with
+		// an invite created by the given account.
+		invites, err := a.state.DB.GetInvites(ctx, invitedBy)
+		if err != nil {
+			return nil, err
+		}
+		inviteIDs := make(map[string]struct{}, len(invites))
+		for _, invite := range invites {
+			inviteIDs[invite.ID] = struct{}{}
+		}
+
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if _, ok := inviteIDs[user.InviteID]; ok {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -1159,6 +1180,24 @@ func (a *accountDB) UpdateAccountSettings(
 	})
 }
 
+func (a *accountDB) GetAccountsWithStatusExpiry(ctx context.Context) ([]*gtsmodel.Account, error) {
+	var accountIDs []string
+
+	// SELECT the IDs of all accounts
+	// with status expiry days set.
+	if _, err := a.db.NewSelect().
+		Table("account_settings").
+		Column("account_id").
+		Where("? > 0", bun.Ident("expiry_days")).
+		Order("account_id ASC").
+		Exec(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) PopulateAccountStats(ctx context.Context, account *gtsmodel.Account) error {
 	// Fetch stats from db cache with loader callback.
 	stats, err := a.state.Caches.GTS.AccountStats.LoadOne(
@@ -773,6 +773,29 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 	}
 }
 
+func (suite *AccountTestSuite) TestGetAccountsWithStatusExpiry() {
+	ctx := context.Background()
+
+	// No test accounts have status expiry set.
+	accounts, err := suite.db.GetAccountsWithStatusExpiry(ctx)
+	suite.NoError(err)
+	suite.Empty(accounts)
+
+	// Enable expiry for one account.
+	settings := new(gtsmodel.AccountSettings)
+	*settings = *suite.testAccounts["local_account_1"].Settings
+	settings.ExpiryDays = 7
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "expiry_days"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err = suite.db.GetAccountsWithStatusExpiry(ctx)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(settings.AccountID, accounts[0].ID)
+	suite.Equal(7, accounts[0].Settings.ExpiryDays)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -69,12 +69,7 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, err
 	domain := strings.Split(m.Address, "@")[1] // domain will always be the second part after @
 
 	// check if the email domain is blocked
-	emailDomainBlockedQ := a.db.
-		NewSelect().
-		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
-		Column("email_domain_block.id").
-		Where("? = ?", bun.Ident("email_domain_block.domain"), domain)
-	emailDomainBlocked, err := exists(ctx, emailDomainBlockedQ)
+	emailDomainBlocked, err := a.state.DB.IsEmailDomainBlocked(ctx, domain)
 	if err != nil {
 		return false, err
 	}
@@ -205,6 +200,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
 		ExternalID:             newSignup.ExternalID,
+		InviteID:               newSignup.InviteID,
 	}
 
 	if newSignup.EmailVerified {
@@ -423,6 +419,32 @@ func (a *adminDB) GetInstanceApplication(ctx context.Context) (*gtsmodel.Applica
 	return app, nil
 }
 
+func (a *adminDB) RotateAccountKeys(ctx context.Context, account *gtsmodel.Account) error {
+	if account.IsRemote() {
+		return gtserror.Newf("account %s is not local", account.ID)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return gtserror.Newf("error creating new rsa private key: %w", err)
+	}
+
+	// Give the new key a new ID, so remote servers
+	// that have the old key cached see a key they
+	// don't recognize, and go fetch the new one.
+	account.PrivateKey = key
+	account.PublicKey = &key.PublicKey
+	account.PublicKeyURI = fmt.Sprintf("%s/%s#%s",
+		account.URI, uris.PublicKeyPath, id.NewULID(),
+	)
+
+	return a.state.DB.UpdateAccount(ctx, account,
+		"private_key",
+		"public_key",
+		"public_key_uri",
+	)
+}
+
 func (a *adminDB) CountApprovedSignupsSince(ctx context.Context, since time.Time) (int, error) {
 	return a.db.
 		NewSelect().
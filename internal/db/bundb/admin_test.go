@@ -19,6 +19,7 @@ package bundb_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -68,6 +69,20 @@ func (suite *AdminTestSuite) TestIsEmailAvailableDomainBlocked() {
 	suite.False(available)
 }
 
+func (suite *AdminTestSuite) TestIsEmailAvailableSubdomainBlocked() {
+	if err := suite.db.Put(context.Background(), &gtsmodel.EmailDomainBlock{
+		ID:                 "01GEEV2R2YC5GRSN96761YJE47",
+		Domain:             "somewhere.com",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	available, err := suite.db.IsEmailAvailable(context.Background(), "someone@mail.somewhere.com")
+	suite.EqualError(err, "email domain mail.somewhere.com is blocked")
+	suite.False(available)
+}
+
 func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	// reinitialize db caches to clear
 	suite.state.Caches.Init()
@@ -92,6 +107,45 @@ func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	suite.NotNil(acct)
 }
 
+func (suite *AdminTestSuite) TestRotateAccountKeys() {
+	ctx := context.Background()
+
+	account, err := suite.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	oldPublicKey := account.PublicKey
+	oldPublicKeyURI := account.PublicKeyURI
+
+	err = suite.db.RotateAccountKeys(ctx, account)
+	suite.NoError(err)
+
+	// Reload account from the database.
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.False(oldPublicKey.Equal(dbAccount.PublicKey))
+	suite.True(dbAccount.PrivateKey.PublicKey.Equal(dbAccount.PublicKey))
+	suite.NotEqual(oldPublicKeyURI, dbAccount.PublicKeyURI)
+	suite.True(strings.HasPrefix(dbAccount.PublicKeyURI, dbAccount.URI+"/main-key#"))
+
+	// Account should be gettable by new key ID.
+	byKeyID, err := suite.db.GetAccountByPubkeyID(ctx, dbAccount.PublicKeyURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(account.ID, byKeyID.ID)
+}
+
+func (suite *AdminTestSuite) TestRotateAccountKeysRemote() {
+	account := suite.testAccounts["remote_account_1"]
+
+	err := suite.db.RotateAccountKeys(context.Background(), account)
+	suite.Error(err)
+}
+
 func TestAdminTestSuite(t *testing.T) {
 	suite.Run(t, new(AdminTestSuite))
 }
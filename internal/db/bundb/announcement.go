@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	var announcement gtsmodel.Announcement
+
+	q := a.db.
+		NewSelect().
+		Model(&announcement).
+		Where("? = ?", bun.Ident("announcement.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	announcements := []*gtsmodel.Announcement{}
+
+	q := a.db.
+		NewSelect().
+		Model(&announcements).
+		Order("announcement.id DESC")
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	announcements := []*gtsmodel.Announcement{}
+
+	q := a.db.
+		NewSelect().
+		Model(&announcements).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("announcement.starts_at")).
+				WhereOr("? <= ?", bun.Ident("announcement.starts_at"), now)
+		}).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("announcement.ends_at")).
+				WhereOr("? > ?", bun.Ident("announcement.ends_at"), now)
+		}).
+		Order("announcement.id DESC")
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	_, err := a.db.NewInsert().
+		Model(announcement).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.NewUpdate().
+		Model(announcement).
+		Column(columns...).
+		Where("? = ?", bun.Ident("announcement.id"), announcement.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete all reads of announcement.
+		if _, err := tx.NewDelete().
+			Table("announcement_reads").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete all reactions to announcement.
+		if _, err := tx.NewDelete().
+			Table("announcement_reactions").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete the announcement itself.
+		_, err := tx.NewDelete().
+			Table("announcements").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *announcementDB) IsAnnouncementRead(ctx context.Context, announcementID string, accountID string) (bool, error) {
+	return exists(ctx, a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("announcement_reads"), bun.Ident("announcement_read")).
+		Column("announcement_read.id").
+		Where("? = ?", bun.Ident("announcement_read.announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("announcement_read.account_id"), accountID),
+	)
+}
+
+func (a *announcementDB) PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error {
+	_, err := a.db.NewInsert().
+		Model(read).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	reactions := []*gtsmodel.AnnouncementReaction{}
+
+	q := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Order("announcement_reaction.id ASC")
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error {
+	_, err := a.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("announcement_reactions"), bun.Ident("announcement_reaction")).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("announcement_reaction.account_id"), accountID).
+		Where("? = ?", bun.Ident("announcement_reaction.name"), name).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AnnouncementTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) TestGetAnnouncements() {
+	ctx := context.Background()
+
+	announcements, err := suite.db.GetAnnouncements(ctx)
+	suite.NoError(err)
+	suite.Len(announcements, 2)
+
+	// Newest first.
+	suite.Equal("01J23QH3T0G0Q4V2BCRDT4X1MR", announcements[0].ID)
+}
+
+func (suite *AnnouncementTestSuite) TestGetActiveAnnouncements() {
+	ctx := context.Background()
+
+	announcements, err := suite.db.GetActiveAnnouncements(ctx, time.Now())
+	suite.NoError(err)
+	suite.Len(announcements, 1)
+	suite.Equal("01J23QDKXNW5Z9SB6A9BJ9T4YV", announcements[0].ID)
+
+	// At new year, both should be active.
+	announcements, err = suite.db.GetActiveAnnouncements(ctx, testrig.TimeMustParse("2024-01-01T12:00:00+00:00"))
+	suite.NoError(err)
+	suite.Len(announcements, 2)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementReads() {
+	ctx := context.Background()
+	announcement := testrig.NewTestAnnouncements()["admin_account_announcement_1"]
+
+	read, err := suite.db.IsAnnouncementRead(ctx, announcement.ID, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.True(read)
+
+	read, err = suite.db.IsAnnouncementRead(ctx, announcement.ID, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.False(read)
+
+	suite.NoError(suite.db.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      suite.testAccounts["local_account_1"].ID,
+	}))
+
+	read, err = suite.db.IsAnnouncementRead(ctx, announcement.ID, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.True(read)
+
+	// Reading again should conflict.
+	err = suite.db.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      suite.testAccounts["local_account_1"].ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementReactions() {
+	ctx := context.Background()
+	announcement := testrig.NewTestAnnouncements()["admin_account_announcement_1"]
+
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.Len(reactions, 3)
+
+	err = suite.db.DeleteAnnouncementReaction(ctx, announcement.ID, suite.testAccounts["local_account_1"].ID, "rainbow")
+	suite.NoError(err)
+
+	reactions, err = suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.Len(reactions, 2)
+}
+
+func (suite *AnnouncementTestSuite) TestDeleteAnnouncement() {
+	ctx := context.Background()
+	announcement := testrig.NewTestAnnouncements()["admin_account_announcement_1"]
+
+	err := suite.db.DeleteAnnouncementByID(ctx, announcement.ID)
+	suite.NoError(err)
+
+	_, err = suite.db.GetAnnouncementByID(ctx, announcement.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// Reads and reactions should be gone too.
+	read, err := suite.db.IsAnnouncementRead(ctx, announcement.ID, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.False(read)
+
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.Empty(reactions)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
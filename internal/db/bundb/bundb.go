@@ -53,13 +53,21 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountExport
 	db.Admin
+	db.Announcement
 	db.Application
 	db.Basic
+	db.CanonicalEmailBlock
+	db.DeliveryRetry
 	db.Domain
+	db.DomainMediaPolicy
+	db.EmailDomainBlock
 	db.Emoji
 	db.HeaderFilter
 	db.Instance
+	db.Invite
+	db.IPBlock
 	db.Filter
 	db.List
 	db.Marker
@@ -71,6 +79,7 @@ type DBService struct {
 	db.Relationship
 	db.Report
 	db.Rule
+	db.SchedulerLock
 	db.Search
 	db.Session
 	db.Status
@@ -81,6 +90,7 @@ type DBService struct {
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.WorkerTask
 	db *bun.DB
 }
 
@@ -125,43 +135,9 @@ func doMigration(ctx context.Context, db *bun.DB) error {
 // NewBunDBService returns a bunDB derived from the provided config, which implements the go-fed DB interface.
 // Under the hood, it uses https://github.com/uptrace/bun to create and maintain a database connection.
 func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
-	var db *bun.DB
-	var err error
-	t := strings.ToLower(config.GetDbType())
-
-	switch t {
-	case "postgres":
-		db, err = pgConn(ctx)
-		if err != nil {
-			return nil, err
-		}
-	case "sqlite":
-		db, err = sqliteConn(ctx)
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("database type %s not supported for bundb", t)
-	}
-
-	// Add database query hooks.
-	db.AddQueryHook(queryHook{})
-	if config.GetTracingEnabled() {
-		db.AddQueryHook(tracing.InstrumentBun())
-	}
-	if config.GetMetricsEnabled() {
-		db.AddQueryHook(metrics.InstrumentBun())
-	}
-
-	// table registration is needed for many-to-many, see:
-	// https://bun.uptrace.dev/orm/many-to-many-relation/
-	for _, t := range []interface{}{
-		&gtsmodel.AccountToEmoji{},
-		&gtsmodel.StatusToEmoji{},
-		&gtsmodel.StatusToTag{},
-		&gtsmodel.ThreadToStatus{},
-	} {
-		db.RegisterModel(t)
+	db, err := newBunDB(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// perform any pending database migrations: this includes
@@ -180,6 +156,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
@@ -187,10 +167,28 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		CanonicalEmailBlock: &canonicalEmailBlockDB{
+			db:    db,
+			state: state,
+		},
+		AccountExport: &accountExportDB{
+			db: db,
+		},
+		DeliveryRetry: &deliveryRetryDB{
+			db: db,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
 		},
+		DomainMediaPolicy: &domainMediaPolicyDB{
+			db:    db,
+			state: state,
+		},
+		EmailDomainBlock: &emailDomainBlockDB{
+			db:    db,
+			state: state,
+		},
 		Emoji: &emojiDB{
 			db:    db,
 			state: state,
@@ -203,6 +201,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
+		IPBlock: &ipBlockDB{
+			db:    db,
+			state: state,
+		},
 		Filter: &filterDB{
 			db:    db,
 			state: state,
@@ -247,6 +253,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		SchedulerLock: &schedulerLockDB{
+			db:       db,
+			holderID: uuid.NewString(),
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -286,6 +296,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WorkerTask: &workerTaskDB{
+			db: db,
+		},
 		db: db,
 	}
 
@@ -293,6 +306,57 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 	return ps, nil
 }
 
+// newBunDB returns a new bun database connection derived
+// from the provided config, with query hooks and models
+// registered, but without performing any migrations.
+func newBunDB(ctx context.Context) (*bun.DB, error) {
+	var db *bun.DB
+	var err error
+	t := strings.ToLower(config.GetDbType())
+
+	switch t {
+	case "postgres":
+		db, err = pgConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+	case "sqlite":
+		db, err = sqliteConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("database type %s not supported for bundb", t)
+	}
+
+	// Add database query hooks.
+	db.AddQueryHook(queryHook{})
+	if config.GetTracingEnabled() {
+		db.AddQueryHook(tracing.InstrumentBun())
+	}
+	if config.GetMetricsEnabled() {
+		db.AddQueryHook(metrics.InstrumentBun())
+	}
+
+	registerModels(db)
+
+	return db, nil
+}
+
+// registerModels registers models with the given
+// bun database, which is needed for many-to-many, see:
+// https://bun.uptrace.dev/orm/many-to-many-relation/
+func registerModels(db *bun.DB) {
+	for _, t := range []interface{}{
+		&gtsmodel.AccountToEmoji{},
+		&gtsmodel.StatusToEmoji{},
+		&gtsmodel.StatusToTag{},
+		&gtsmodel.ThreadToStatus{},
+	} {
+		db.RegisterModel(t)
+	}
+}
+
 func pgConn(ctx context.Context) (*bun.DB, error) {
 	opts, err := deriveBunDBPGOptions() //nolint:contextcheck
 	if err != nil {
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type deliveryRetryDB struct {
+	db *bun.DB
+}
+
+func (d *deliveryRetryDB) PutDeliveryRetry(ctx context.Context, retry *gtsmodel.DeliveryRetry) error {
+	_, err := d.db.NewInsert().
+		Model(retry).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryRetryDB) PopDueDeliveryRetries(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.DeliveryRetry, error) {
+	var retries []*gtsmodel.DeliveryRetry
+
+	// Select IDs of due retries.
+	subQ := d.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("delivery_retries"), bun.Ident("delivery_retry")).
+		Column("delivery_retry.id").
+		Where("? <= ?", bun.Ident("delivery_retry.next_attempt_at"), now).
+		OrderExpr("? ASC", bun.Ident("delivery_retry.next_attempt_at")).
+		Limit(limit)
+
+	// Delete + return all selected retries in a single
+	// statement, so each may only be claimed once.
+	if _, err := d.db.NewDelete().
+		Model(&retries).
+		Where("? IN (?)", bun.Ident("delivery_retry.id"), subQ).
+		Returning("*").
+		Exec(ctx, &retries); err != nil {
+		return nil, err
+	}
+
+	return retries, nil
+}
+
+func (d *deliveryRetryDB) GetDeliveryRetries(ctx context.Context, host string) ([]*gtsmodel.DeliveryRetry, error) {
+	var retries []*gtsmodel.DeliveryRetry
+
+	q := d.db.NewSelect().
+		Model(&retries).
+		OrderExpr("? ASC", bun.Ident("delivery_retry.next_attempt_at"))
+
+	if host != "" {
+		q = q.Where("? = ?", bun.Ident("delivery_retry.host"), host)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return retries, nil
+}
+
+func (d *deliveryRetryDB) UpdateDeliveryRetry(ctx context.Context, retry *gtsmodel.DeliveryRetry, columns ...string) error {
+	_, err := d.db.NewUpdate().
+		Model(retry).
+		Column(columns...).
+		Where("? = ?", bun.Ident("delivery_retry.id"), retry.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryRetryDB) DeleteDeliveryRetryByID(ctx context.Context, id string) error {
+	_, err := d.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("delivery_retries"), bun.Ident("delivery_retry")).
+		Where("? = ?", bun.Ident("delivery_retry.id"), id).
+		Exec(ctx)
+	return err
+}
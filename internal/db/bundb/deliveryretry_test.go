@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type DeliveryRetryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryRetryTestSuite) TestPopDueDeliveryRetries() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, next := range []time.Time{
+		now.Add(-time.Hour),
+		now.Add(-time.Minute),
+		now.Add(time.Hour),
+	} {
+		if err := suite.db.PutDeliveryRetry(ctx, &gtsmodel.DeliveryRetry{
+			ID:            id.NewULID(),
+			Host:          "example.org",
+			Attempts:      1,
+			NextAttemptAt: next,
+			Data:          []byte(`{}`),
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the two due retries should be popped, oldest first.
+	retries, err := suite.db.PopDueDeliveryRetries(ctx, now, 10)
+	suite.NoError(err)
+	suite.Len(retries, 2)
+	suite.True(retries[0].NextAttemptAt.Before(retries[1].NextAttemptAt))
+	suite.Equal("example.org", retries[0].Host)
+	suite.Equal([]byte(`{}`), retries[0].Data)
+
+	// Popped retries should now be gone.
+	retries, err = suite.db.PopDueDeliveryRetries(ctx, now, 10)
+	suite.NoError(err)
+	suite.Empty(retries)
+
+	// The remaining retry becomes due later.
+	retries, err = suite.db.PopDueDeliveryRetries(ctx, now.Add(2*time.Hour), 10)
+	suite.NoError(err)
+	suite.Len(retries, 1)
+}
+
+func (suite *DeliveryRetryTestSuite) TestGetUpdateDeleteDeliveryRetries() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, host := range []string{
+		"example.org",
+		"example.org",
+		"fossbros-anonymous.io",
+	} {
+		if err := suite.db.PutDeliveryRetry(ctx, &gtsmodel.DeliveryRetry{
+			ID:            id.NewULID(),
+			Host:          host,
+			Attempts:      1,
+			NextAttemptAt: now.Add(time.Hour),
+			Data:          []byte(`{}`),
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	retries, err := suite.db.GetDeliveryRetries(ctx, "")
+	suite.NoError(err)
+	suite.Len(retries, 3)
+
+	retries, err = suite.db.GetDeliveryRetries(ctx, "example.org")
+	suite.NoError(err)
+	suite.Len(retries, 2)
+
+	// Make one retry due now.
+	retries[0].NextAttemptAt = now
+	err = suite.db.UpdateDeliveryRetry(ctx, retries[0], "next_attempt_at")
+	suite.NoError(err)
+
+	due, err := suite.db.PopDueDeliveryRetries(ctx, now, 10)
+	suite.NoError(err)
+	suite.Len(due, 1)
+	suite.Equal(retries[0].ID, due[0].ID)
+
+	// Delete the other.
+	err = suite.db.DeleteDeliveryRetryByID(ctx, retries[1].ID)
+	suite.NoError(err)
+
+	retries, err = suite.db.GetDeliveryRetries(ctx, "")
+	suite.NoError(err)
+	suite.Len(retries, 1)
+	suite.Equal("fossbros-anonymous.io", retries[0].Host)
+}
+
+func TestDeliveryRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryRetryTestSuite))
+}
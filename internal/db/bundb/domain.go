@@ -51,7 +51,7 @@ func (d *domainDB) CreateDomainAllow(ctx context.Context, allow *gtsmodel.Domain
 	}
 
 	// Clear the domain allow cache (for later reload)
-	d.state.Caches.GTS.DomainAllow.Clear()
+	d.state.Caches.ClearShared(d.state.Caches.GTS.DomainAllow)
 
 	return nil
 }
@@ -126,7 +126,7 @@ func (d *domainDB) DeleteDomainAllow(ctx context.Context, domain string) error {
 	}
 
 	// Clear the domain allow cache (for later reload)
-	d.state.Caches.GTS.DomainAllow.Clear()
+	d.state.Caches.ClearShared(d.state.Caches.GTS.DomainAllow)
 
 	return nil
 }
@@ -146,8 +146,15 @@ func (d *domainDB) CreateDomainBlock(ctx context.Context, block *gtsmodel.Domain
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
-	d.state.Caches.GTS.DomainBlock.Clear()
+	// Clear the domain block caches (for later reload)
+	d.state.Caches.ClearShared(
+		d.state.Caches.GTS.DomainBlock,
+		d.state.Caches.GTS.DomainSilence,
+	)
+
+	// Clear visibility cache, as
+	// silences affect timelineability.
+	d.state.Caches.ClearShared(&d.state.Caches.Visibility)
 
 	return nil
 }
@@ -221,8 +228,15 @@ func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
-	d.state.Caches.GTS.DomainBlock.Clear()
+	// Clear the domain block caches (for later reload)
+	d.state.Caches.ClearShared(
+		d.state.Caches.GTS.DomainBlock,
+		d.state.Caches.GTS.DomainSilence,
+	)
+
+	// Clear visibility cache, as
+	// silences affect timelineability.
+	d.state.Caches.ClearShared(&d.state.Caches.Visibility)
 
 	return nil
 }
@@ -262,10 +276,12 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 	explicitBlock, err := d.state.Caches.GTS.DomainBlock.Matches(domain, func() ([]string, error) {
 		var domains []string
 
-		// Scan list of all blocked domains from DB
+		// Scan list of all blocked domains from DB,
+		// ignoring blocks which are only a silence.
 		q := d.db.NewSelect().
 			Table("domain_blocks").
-			Column("domain")
+			Column("domain").
+			Where("? != ?", bun.Ident("severity"), gtsmodel.DomainBlockSeveritySilence)
 		if err := q.Scan(ctx, &domains); err != nil {
 			return nil, err
 		}
@@ -303,6 +319,36 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 	}
 }
 
+func (d *domainDB) IsDomainSilenced(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* cannot be silenced.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	// Check the cache for a domain silence (hydrating the cache with callback if necessary)
+	return d.state.Caches.GTS.DomainSilence.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all silenced domains from DB
+		q := d.db.NewSelect().
+			Table("domain_blocks").
+			Column("domain").
+			Where("? = ?", bun.Ident("severity"), gtsmodel.DomainBlockSeveritySilence)
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
+
 func (d *domainDB) AreDomainsBlocked(ctx context.Context, domains []string) (bool, error) {
 	for _, domain := range domains {
 		if blocked, err := d.IsDomainBlocked(ctx, domain); err != nil {
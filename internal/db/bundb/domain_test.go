@@ -56,6 +56,49 @@ func (suite *DomainTestSuite) TestIsDomainBlocked() {
 	suite.WithinDuration(time.Now(), domainBlock.CreatedAt, 10*time.Second)
 }
 
+func (suite *DomainTestSuite) TestIsDomainSilenced() {
+	ctx := context.Background()
+
+	domainBlock := &gtsmodel.DomainBlock{
+		ID:                 "01G204214Y9TNJEBX39C7G88SW",
+		Domain:             "some.bad.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		CreatedByAccount:   suite.testAccounts["admin_account"],
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+	}
+
+	// no domain silence exists for the given domain yet
+	silenced, err := suite.db.IsDomainSilenced(ctx, domainBlock.Domain)
+	suite.NoError(err)
+	suite.False(silenced)
+
+	err = suite.db.CreateDomainBlock(ctx, domainBlock)
+	suite.NoError(err)
+
+	// domain is now silenced
+	silenced, err = suite.db.IsDomainSilenced(ctx, domainBlock.Domain)
+	suite.NoError(err)
+	suite.True(silenced)
+
+	// but a silence is not a block
+	blocked, err := suite.db.IsDomainBlocked(ctx, domainBlock.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// subdomains are silenced too
+	silenced, err = suite.db.IsDomainSilenced(ctx, "sub.some.bad.apples")
+	suite.NoError(err)
+	suite.True(silenced)
+
+	// removing the block removes the silence
+	err = suite.db.DeleteDomainBlock(ctx, domainBlock.Domain)
+	suite.NoError(err)
+
+	silenced, err = suite.db.IsDomainSilenced(ctx, domainBlock.Domain)
+	suite.NoError(err)
+	suite.False(silenced)
+}
+
 func (suite *DomainTestSuite) TestIsDomainBlockedWithAllow() {
 	ctx := context.Background()
 
@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type domainMediaPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicyByID(ctx context.Context, id string) (*gtsmodel.DomainMediaPolicy, error) {
+	var policy gtsmodel.DomainMediaPolicy
+
+	q := d.db.
+		NewSelect().
+		Model(&policy).
+		Where("? = ?", bun.Ident("domain_media_policy.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicy(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy gtsmodel.DomainMediaPolicy
+
+	q := d.db.
+		NewSelect().
+		Model(&policy).
+		Where("? = ?", bun.Ident("domain_media_policy.domain"), domain)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (d *domainMediaPolicyDB) GetDomainMediaPolicies(ctx context.Context) ([]*gtsmodel.DomainMediaPolicy, error) {
+	policies := []*gtsmodel.DomainMediaPolicy{}
+
+	if err := d.db.
+		NewSelect().
+		Model(&policies).
+		Order("domain_media_policy.domain ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (d *domainMediaPolicyDB) PutDomainMediaPolicy(ctx context.Context, policy *gtsmodel.DomainMediaPolicy) error {
+	// Normalize the domain as punycode
+	var err error
+	policy.Domain, err = util.Punify(policy.Domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to store domain media policy in DB
+	if _, err := d.db.NewInsert().
+		Model(policy).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain media policy cache (for later reload)
+	d.state.Caches.ClearShared(&d.state.Caches.DomainMediaPolicies)
+
+	return nil
+}
+
+func (d *domainMediaPolicyDB) DeleteDomainMediaPolicyByID(ctx context.Context, id string) error {
+	// Attempt to delete domain media policy
+	if _, err := d.db.NewDelete().
+		Model((*gtsmodel.DomainMediaPolicy)(nil)).
+		Where("? = ?", bun.Ident("domain_media_policy.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain media policy cache (for later reload)
+	d.state.Caches.ClearShared(&d.state.Caches.DomainMediaPolicies)
+
+	return nil
+}
+
+func (d *domainMediaPolicyDB) MatchDomainMediaPolicy(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain == "" {
+		// Local, nothing to match.
+		return nil, nil
+	}
+
+	// Check the cache for a matching policy (hydrating the cache with callback if necessary)
+	return d.state.Caches.DomainMediaPolicies.Match(domain, func() ([]*gtsmodel.DomainMediaPolicy, error) {
+		return d.GetDomainMediaPolicies(ctx)
+	})
+}
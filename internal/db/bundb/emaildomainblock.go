@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type emailDomainBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error) {
+	var block gtsmodel.EmailDomainBlock
+
+	q := e.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident("email_domain_block.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var block gtsmodel.EmailDomainBlock
+
+	q := e.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident("email_domain_block.domain"), domain)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error) {
+	blocks := []*gtsmodel.EmailDomainBlock{}
+
+	if err := e.db.
+		NewSelect().
+		Model(&blocks).
+		Order("email_domain_block.domain ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (e *emailDomainBlockDB) CreateEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	// Normalize the domain as punycode
+	var err error
+	block.Domain, err = util.Punify(block.Domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to store email domain block in DB
+	if _, err := e.db.NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the email domain block cache (for later reload)
+	e.state.Caches.ClearShared(e.state.Caches.GTS.EmailDomainBlock)
+
+	return nil
+}
+
+func (e *emailDomainBlockDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) error {
+	// Attempt to delete email domain block
+	if _, err := e.db.NewDelete().
+		Model((*gtsmodel.EmailDomainBlock)(nil)).
+		Where("? = ?", bun.Ident("email_domain_block.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the email domain block cache (for later reload)
+	e.state.Caches.ClearShared(e.state.Caches.GTS.EmailDomainBlock)
+
+	return nil
+}
+
+func (e *emailDomainBlockDB) IsEmailDomainBlocked(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	if domain == "" {
+		// Nothing to check.
+		return false, nil
+	}
+
+	// Check the cache for an email domain block (hydrating the cache with callback if necessary)
+	return e.state.Caches.GTS.EmailDomainBlock.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all blocked email domains from DB
+		q := e.db.NewSelect().
+			Table("email_domain_blocks").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
@@ -274,7 +274,7 @@ func (f *filterDB) UpdateFilter(
 			if _, err := tx.
 				NewDelete().
 				Model((*gtsmodel.FilterKeyword)(nil)).
-				Where("? = (?)", bun.Ident("id"), bun.In(deleteFilterKeywordIDs)).
+				Where("? IN (?)", bun.Ident("id"), bun.In(deleteFilterKeywordIDs)).
 				Exec(ctx); err != nil {
 				return err
 			}
@@ -284,7 +284,7 @@ func (f *filterDB) UpdateFilter(
 			if _, err := tx.
 				NewDelete().
 				Model((*gtsmodel.FilterStatus)(nil)).
-				Where("? = (?)", bun.Ident("id"), bun.In(deleteFilterStatusIDs)).
+				Where("? IN (?)", bun.Ident("id"), bun.In(deleteFilterStatusIDs)).
 				Exec(ctx); err != nil {
 				return err
 			}
@@ -350,3 +350,17 @@ func (f *filterDB) DeleteFilterByID(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (f *filterDB) GetExpiredFilterIDs(ctx context.Context, expiredBefore time.Time) ([]string, error) {
+	var filterIDs []string
+	if err := f.db.
+		NewSelect().
+		Model((*gtsmodel.Filter)(nil)).
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("expires_at")).
+		Where("? <= ?", bun.Ident("expires_at"), expiredBefore).
+		Scan(ctx, &filterIDs); err != nil {
+		return nil, err
+	}
+	return filterIDs, nil
+}
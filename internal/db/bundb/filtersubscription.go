@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func (f *filterDB) GetFilterSubscriptionByID(ctx context.Context, id string) (*gtsmodel.FilterSubscription, error) {
+	var subscription gtsmodel.FilterSubscription
+	if err := f.db.
+		NewSelect().
+		Model(&subscription).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (f *filterDB) GetFilterSubscriptionsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FilterSubscription, error) {
+	var subscriptions []*gtsmodel.FilterSubscription
+	if err := f.db.
+		NewSelect().
+		Model(&subscriptions).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Order("id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (f *filterDB) GetAllFilterSubscriptions(ctx context.Context) ([]*gtsmodel.FilterSubscription, error) {
+	var subscriptions []*gtsmodel.FilterSubscription
+	if err := f.db.
+		NewSelect().
+		Model(&subscriptions).
+		Order("id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (f *filterDB) PutFilterSubscription(ctx context.Context, subscription *gtsmodel.FilterSubscription) error {
+	_, err := f.db.
+		NewInsert().
+		Model(subscription).
+		Exec(ctx)
+	return err
+}
+
+func (f *filterDB) UpdateFilterSubscription(ctx context.Context, subscription *gtsmodel.FilterSubscription, columns ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := f.db.
+		NewUpdate().
+		Model(subscription).
+		Where("? = ?", bun.Ident("id"), subscription.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (f *filterDB) DeleteFilterSubscriptionByID(ctx context.Context, id string) error {
+	_, err := f.db.
+		NewDelete().
+		Model((*gtsmodel.FilterSubscription)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -83,6 +83,40 @@ func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (
 	return count, nil
 }
 
+func (i *instanceDB) CountInstanceActiveUsers(ctx context.Context, domain string, since time.Time) (int, error) {
+	// Statuses are keyed by ULID, so we can select
+	// only those created since the given time by
+	// comparing against a ULID generated from it.
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return 0, err
+	}
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("DISTINCT ?", bun.Ident("status.account_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("status.account_id")).
+		Where("? > ?", bun.Ident("status.id"), sinceID).
+		Where("? IS NULL", bun.Ident("account.suspended_at"))
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		// if the domain is *this* domain, just count where local is true
+		q = q.Where("? = ?", bun.Ident("status.local"), true)
+	} else {
+		q = q.Where("? = ?", bun.Ident("account.domain"), domain)
+	}
+
+	count, err := i.db.
+		NewSelect().
+		TableExpr("(?) AS ?", q, bun.Ident("active")).
+		Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (i *instanceDB) CountInstanceDomains(ctx context.Context, domain string) (int, error) {
 	q := i.db.
 		NewSelect().
@@ -20,6 +20,7 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -56,6 +57,24 @@ func (suite *InstanceTestSuite) TestCountInstanceStatusesRemote() {
 	suite.Equal(3, count)
 }
 
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsers() {
+	count, err := suite.db.CountInstanceActiveUsers(context.Background(), config.GetHost(), time.Unix(0, 0))
+	suite.NoError(err)
+	suite.Equal(3, count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsersRemote() {
+	count, err := suite.db.CountInstanceActiveUsers(context.Background(), "fossbros-anonymous.io", time.Unix(0, 0))
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsersSinceNow() {
+	count, err := suite.db.CountInstanceActiveUsers(context.Background(), config.GetHost(), time.Now())
+	suite.NoError(err)
+	suite.Equal(0, count)
+}
+
 func (suite *InstanceTestSuite) TestCountInstanceDomains() {
 	count, err := suite.db.CountInstanceDomains(context.Background(), config.GetHost())
 	suite.NoError(err)
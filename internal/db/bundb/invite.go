@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value string) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	q := i.db.
+		NewSelect().
+		Model(&invite).
+		Where("? = ?", bun.Ident("invite."+column), value)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, accountID string) ([]*gtsmodel.Invite, error) {
+	invites := []*gtsmodel.Invite{}
+
+	q := i.db.
+		NewSelect().
+		Model(&invites).
+		Order("invite.id DESC")
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("invite.created_by_account_id"), accountID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UseInvite(ctx context.Context, code string, now time.Time) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	// Increment uses and return the updated invite in a
+	// single statement, so that concurrent sign-ups can't
+	// push an invite beyond its max uses or past expiry.
+	if err := i.db.NewUpdate().
+		Model(&invite).
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("invite.code"), code).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("invite.max_uses")).
+				WhereOr("? < ?", bun.Ident("invite.uses"), bun.Ident("invite.max_uses"))
+		}).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("invite.expires_at")).
+				WhereOr("? > ?", bun.Ident("invite.expires_at"), now)
+		}).
+		Returning("*").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) ReleaseInvite(ctx context.Context, id string) error {
+	_, err := i.db.NewUpdate().
+		Model((*gtsmodel.Invite)(nil)).
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("invite.id"), id).
+		Where("? > 0", bun.Ident("invite.uses")).
+		Exec(ctx)
+	return err
+}
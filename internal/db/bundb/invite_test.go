@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestGetInvites() {
+	ctx := context.Background()
+
+	invites, err := suite.db.GetInvites(ctx, "")
+	suite.NoError(err)
+	suite.Len(invites, 3)
+
+	invites, err = suite.db.GetInvites(ctx, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.Len(invites, 2)
+
+	// Newest first.
+	suite.Equal("01J1XW3N8WZ0AE8EJ2H1Q7GZ5F", invites[0].ID)
+}
+
+func (suite *InviteTestSuite) TestUseInvite() {
+	ctx := context.Background()
+	testInvite := testrig.NewTestInvites()["admin_account_invite_1"]
+
+	for i := 1; i <= 2; i++ {
+		invite, err := suite.db.UseInvite(ctx, testInvite.Code, time.Now())
+		suite.NoError(err)
+		suite.Equal(testInvite.ID, invite.ID)
+		suite.Equal(i, invite.Uses)
+	}
+}
+
+func (suite *InviteTestSuite) TestReleaseInvite() {
+	ctx := context.Background()
+	testInvite := testrig.NewTestInvites()["admin_account_invite_1"]
+
+	invite, err := suite.db.UseInvite(ctx, testInvite.Code, time.Now())
+	suite.NoError(err)
+	suite.Equal(testInvite.Uses+1, invite.Uses)
+
+	// Releasing should give the use back,
+	// but never take uses below zero.
+	for i := 0; i < 2; i++ {
+		err = suite.db.ReleaseInvite(ctx, invite.ID)
+		suite.NoError(err)
+
+		invite, err = suite.db.GetInviteByID(ctx, invite.ID)
+		suite.NoError(err)
+		suite.Equal(testInvite.Uses, invite.Uses)
+	}
+}
+
+func (suite *InviteTestSuite) TestUseInviteUnusable() {
+	ctx := context.Background()
+
+	for _, invite := range []*gtsmodel.Invite{
+		testrig.NewTestInvites()["admin_account_invite_expired"],
+		testrig.NewTestInvites()["local_account_1_invite_used"],
+		{Code: "not a code"},
+	} {
+		_, err := suite.db.UseInvite(ctx, invite.Code, time.Now())
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+}
+
+func (suite *InviteTestSuite) TestUseInviteMaxUsesConcurrent() {
+	ctx := context.Background()
+
+	invite := &gtsmodel.Invite{
+		ID:                 id.NewULID(),
+		Code:               "conc0rrent",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		MaxUses:            3,
+	}
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		used int
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := suite.db.UseInvite(ctx, invite.Code, time.Now()); err == nil {
+				mu.Lock()
+				used++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Only max uses sign-ups should have succeeded.
+	suite.Equal(invite.MaxUses, used)
+
+	invite, err := suite.db.GetInviteByCode(ctx, invite.Code)
+	suite.NoError(err)
+	suite.Equal(3, invite.Uses)
+	suite.True(invite.UsedUp())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type ipBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *ipBlockDB) GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, error) {
+	var block gtsmodel.IPBlock
+
+	q := i.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident("ip_block.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (i *ipBlockDB) GetIPBlock(ctx context.Context, ip string) (*gtsmodel.IPBlock, error) {
+	var block gtsmodel.IPBlock
+
+	q := i.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident("ip_block.ip"), ip)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (i *ipBlockDB) GetIPBlocks(ctx context.Context) ([]*gtsmodel.IPBlock, error) {
+	blocks := []*gtsmodel.IPBlock{}
+
+	if err := i.db.
+		NewSelect().
+		Model(&blocks).
+		Order("ip_block.ip ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (i *ipBlockDB) PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error {
+	// Attempt to store IP block in DB
+	if _, err := i.db.NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the IP block cache (for later reload)
+	i.state.Caches.ClearShared(&i.state.Caches.IPBlocks)
+
+	return nil
+}
+
+func (i *ipBlockDB) DeleteIPBlockByID(ctx context.Context, id string) error {
+	// Attempt to delete IP block
+	if _, err := i.db.NewDelete().
+		Model((*gtsmodel.IPBlock)(nil)).
+		Where("? = ?", bun.Ident("ip_block.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the IP block cache (for later reload)
+	i.state.Caches.ClearShared(&i.state.Caches.IPBlocks)
+
+	return nil
+}
+
+func (i *ipBlockDB) IPBlockMatch(ctx context.Context, addr netip.Addr) (gtsmodel.IPBlockSeverity, error) {
+	return i.state.Caches.IPBlocks.Match(addr, func() ([]*gtsmodel.IPBlock, error) {
+		return i.GetIPBlocks(ctx)
+	})
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20240628150215_filter_title_unique_fix"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Filter titles were created unique across
+			// *all* accounts rather than per account. The
+			// constraint is inline on the column, so we
+			// need to recreate the table to replace it.
+			//
+			// Postgres would otherwise carry the old
+			// constraint names over, so drop them first.
+			if tx.Dialect().Name() == dialect.PG {
+				for _, constraint := range []string{
+					"filters_title_key",
+					"filters_account_id_title_uniq",
+				} {
+					if _, err := tx.ExecContext(
+						ctx,
+						"ALTER TABLE ? DROP CONSTRAINT IF EXISTS ?",
+						bun.Ident("filters"),
+						bun.Safe(constraint),
+					); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Create the new filters table.
+			if _, err := tx.
+				NewCreateTable().
+				ModelTableExpr("new_filters").
+				Model(&gtsmodel.Filter{}).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Specify columns explicitly to
+			// avoid any Postgres shenanigans.
+			columns := []string{
+				"id",
+				"created_at",
+				"updated_at",
+				"expires_at",
+				"account_id",
+				"title",
+				"action",
+				"context_home",
+				"context_notifications",
+				"context_public",
+				"context_thread",
+				"context_account",
+			}
+
+			// Copy existing filters to the new table.
+			if _, err := tx.
+				NewInsert().
+				Table("new_filters").
+				Table("filters").
+				Column(columns...).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Drop the old table.
+			if _, err := tx.
+				NewDropTable().
+				Table("filters").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Rename new table to old table.
+			if _, err := tx.
+				ExecContext(
+					ctx,
+					"ALTER TABLE ? RENAME TO ?",
+					bun.Ident("new_filters"),
+					bun.Ident("filters"),
+				); err != nil {
+				return err
+			}
+
+			// Add indexes to the new table.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("filters").
+				Index("filters_account_id_idx").
+				Column("account_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -107,11 +107,6 @@ func (n *notificationDB) GetNotificationsByIDs(ctx context.Context, ids []string
 	notifs, err := n.state.Caches.GTS.Notification.LoadIDs("ID",
 		ids,
 		func(uncached []string) ([]*gtsmodel.Notification, error) {
-			// Skip query if everything was cached.
-			if len(uncached) == 0 {
-				return nil, nil
-			}
-
 			// Preallocate expected length of uncached notifications.
 			notifs := make([]*gtsmodel.Notification, 0, len(uncached))
 
@@ -141,6 +136,17 @@ func (n *notificationDB) GetNotificationsByIDs(ctx context.Context, ids []string
 		return notifs, nil
 	}
 
+	// Preload all accounts and statuses involved in
+	// single queries, rather than queries per notif.
+	accountIDs := make([]string, 0, 2*len(notifs))
+	statusIDs := make([]string, 0, len(notifs))
+	for _, notif := range notifs {
+		accountIDs = append(accountIDs, notif.TargetAccountID, notif.OriginAccountID)
+		statusIDs = append(statusIDs, notif.StatusID)
+	}
+	preloadAccounts(ctx, n.state, accountIDs)
+	preloadStatuses(ctx, n.state, statusIDs)
+
 	// Populate all loaded notifs, removing those we fail to
 	// populate (removes needing so many nil checks everywhere).
 	notifs = slices.DeleteFunc(notifs, func(notif *gtsmodel.Notification) bool {
@@ -263,6 +263,37 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsPertainingToStatusID(
 	}
 }
 
+func (suite *NotificationTestSuite) TestGetNotificationsByIDsPopulated() {
+	ctx := context.Background()
+
+	all := []*gtsmodel.Notification{}
+	if err := suite.db.GetAll(ctx, &all); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids := make([]string, 0, len(all))
+	for _, notif := range all {
+		ids = append(ids, notif.ID)
+	}
+
+	// Load twice, the second time
+	// should be fully served from cache.
+	for i := 0; i < 2; i++ {
+		notifs, err := suite.db.GetNotificationsByIDs(ctx, ids)
+		suite.NoError(err)
+		suite.Len(notifs, len(ids))
+
+		for x, notif := range notifs {
+			suite.Equal(ids[x], notif.ID)
+			suite.NotNil(notif.TargetAccount)
+			suite.NotNil(notif.OriginAccount)
+			if notif.StatusID != "" {
+				suite.NotNil(notif.Status)
+			}
+		}
+	}
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, new(NotificationTestSuite))
 }
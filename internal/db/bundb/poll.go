@@ -176,6 +176,48 @@ func (p *pollDB) UpdatePoll(ctx context.Context, poll *gtsmodel.Poll, cols ...st
 	})
 }
 
+func (p *pollDB) ClosePoll(ctx context.Context, poll *gtsmodel.Poll) (bool, error) {
+	var closed bool
+
+	err := p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Close poll, only if still open.
+		res, err := tx.NewUpdate().
+			Table("polls").
+			Where("? = ?", bun.Ident("id"), poll.ID).
+			Where("? IS NULL", bun.Ident("closed_at")).
+			SetColumn("closed_at", "?", poll.ClosedAt).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if closed = (n > 0); !closed {
+			// Already closed,
+			// nothing to do.
+			return nil
+		}
+
+		// Update the status' "updated_at" field.
+		_, err = tx.NewUpdate().
+			Table("statuses").
+			Where("? = ?", bun.Ident("id"), poll.StatusID).
+			SetColumn("updated_at", "?", time.Now()).
+			Exec(ctx)
+		return err
+	})
+
+	// Invalidate poll either way, if already
+	// closed then our cached copy is stale.
+	p.state.Caches.GTS.Poll.Invalidate("ID", poll.ID)
+
+	return closed, err
+}
+
 func (p *pollDB) DeletePollByID(ctx context.Context, id string) error {
 	// Delete poll by ID from database.
 	if _, err := p.db.NewDelete().
@@ -384,6 +426,71 @@ func (p *pollDB) PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) error
 	})
 }
 
+func (p *pollDB) ReplacePollVote(ctx context.Context, vote *gtsmodel.PollVote) error {
+	// Invalidate any existing vote by this account in
+	// the cache first, as it is about to be replaced.
+	p.state.Caches.GTS.PollVote.Invalidate("PollID,AccountID",
+		vote.PollID,
+		vote.AccountID,
+	)
+
+	return p.state.Caches.GTS.PollVote.Store(vote, func() error {
+		return p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Slice should only ever be of length
+			// 0 or 1; it's a slice of slices only
+			// because we can't LIMIT deletes to 1.
+			var choicesSlice [][]int
+
+			// Delete any existing vote in poll by
+			// account, returning the choices of it.
+			if err := tx.NewDelete().
+				Table("poll_votes").
+				Where("? = ?", bun.Ident("poll_id"), vote.PollID).
+				Where("? = ?", bun.Ident("account_id"), vote.AccountID).
+				Returning("?", bun.Ident("choices")).
+				Scan(ctx, &choicesSlice); err != nil {
+				return err
+			}
+
+			// Insert the new vote into database.
+			if _, err := tx.NewInsert().
+				Model(vote).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			var poll gtsmodel.Poll
+
+			// Select current poll counts from DB,
+			// taking minimal columns needed to
+			// increment/decrement votes.
+			if err := tx.NewSelect().
+				Model(&poll).
+				Column("options", "votes", "voters").
+				Where("? = ?", bun.Ident("id"), vote.PollID).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			if len(choicesSlice) == 1 {
+				// Decrement votes for old choices.
+				poll.DecrementVotes(choicesSlice[0])
+			}
+
+			// Increment poll votes for new choices.
+			poll.IncrementVotes(vote.Choices)
+
+			// Finally, update the poll entry.
+			_, err := tx.NewUpdate().
+				Model(&poll).
+				Column("votes", "voters").
+				Where("? = ?", bun.Ident("id"), vote.PollID).
+				Exec(ctx)
+			return err
+		})
+	})
+}
+
 func (p *pollDB) DeletePollVotes(ctx context.Context, pollID string) error {
 	err := p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		// Delete all votes in poll.
@@ -199,6 +199,36 @@ func (suite *PollTestSuite) TestUpdatePoll() {
 	}
 }
 
+func (suite *PollTestSuite) TestClosePoll() {
+	ctx := context.Background()
+
+	// Take copy of an open poll.
+	poll := util.Ptr(*suite.testPolls["local_account_1_status_6_poll"])
+	poll.ClosedAt = time.Now()
+
+	// First close succeeds.
+	closed, err := suite.db.ClosePoll(ctx, poll)
+	suite.NoError(err)
+	suite.True(closed)
+
+	latest, err := suite.db.GetPollByID(ctx, poll.ID)
+	suite.NoError(err)
+	suite.WithinDuration(poll.ClosedAt, latest.ClosedAt, time.Millisecond)
+
+	// Closing again (e.g. by another
+	// process) leaves the poll as-is.
+	again := util.Ptr(*poll)
+	again.ClosedAt = time.Now().Add(time.Hour)
+
+	closed, err = suite.db.ClosePoll(ctx, again)
+	suite.NoError(err)
+	suite.False(closed)
+
+	latest, err = suite.db.GetPollByID(ctx, poll.ID)
+	suite.NoError(err)
+	suite.WithinDuration(poll.ClosedAt, latest.ClosedAt, time.Millisecond)
+}
+
 func (suite *PollTestSuite) TestPutPoll() {
 	// Create a new context for this test.
 	ctx, cncl := context.WithCancel(context.Background())
@@ -270,6 +300,55 @@ func (suite *PollTestSuite) TestPutPollVote() {
 	}
 }
 
+func (suite *PollTestSuite) TestReplacePollVote() {
+	ctx, cncl := context.WithCancel(context.Background())
+	defer cncl()
+
+	for _, poll := range suite.testPolls {
+		accountID := id.NewULID() // random account, doesn't matter
+
+		// Insert an initial vote for first option.
+		err := suite.db.PutPollVote(ctx, &gtsmodel.PollVote{
+			ID:        id.NewULID(),
+			Choices:   []int{0},
+			PollID:    poll.ID,
+			AccountID: accountID,
+		})
+		suite.NoError(err)
+
+		// Replace it with a vote for last option.
+		vote := &gtsmodel.PollVote{
+			ID:        id.NewULID(),
+			Choices:   []int{len(poll.Options) - 1},
+			PollID:    poll.ID,
+			AccountID: accountID,
+		}
+		err = suite.db.ReplacePollVote(ctx, vote)
+		suite.NoError(err)
+
+		// The account's vote should now be the replacement.
+		got, err := suite.db.GetPollVoteBy(
+			gtscontext.SetBarebones(ctx),
+			poll.ID,
+			accountID,
+		)
+		suite.NoError(err)
+		suite.Equal(vote.ID, got.ID)
+
+		// Fetch latest version of poll from database.
+		latest, err := suite.db.GetPollByID(ctx, poll.ID)
+		suite.NoError(err)
+
+		// Decr latest version choices by new vote's,
+		// only a single voter should have been added.
+		latest.Votes[len(poll.Options)-1]--
+		(*latest.Voters)--
+
+		suite.Equal(poll.Voters, latest.Voters)
+		suite.Equal(poll.Votes, latest.Votes)
+	}
+}
+
 func (suite *PollTestSuite) TestDeletePoll() {
 	// Create a new context for this test.
 	ctx, cncl := context.WithCancel(context.Background())
@@ -55,6 +55,7 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 		rel.Following = true
 		rel.ShowingReblogs = *follow.ShowReblogs
 		rel.Notifying = *follow.Notify
+		rel.Languages = follow.Languages
 	}
 
 	// check if the target follows the requesting
@@ -59,6 +59,36 @@ func (r *relationshipDB) IsEitherBlocked(ctx context.Context, accountID1 string,
 	return false, nil
 }
 
+func (r *relationshipDB) GetBlocksBetween(ctx context.Context, accountID string, targetAccountIDs []string) ([]*gtsmodel.Block, error) {
+	if len(targetAccountIDs) == 0 {
+		return nil, nil
+	}
+
+	var blockIDs []string
+
+	// Select IDs of all blocks from account to
+	// any target, or from any target to account.
+	if err := r.db.NewSelect().
+		TableExpr("?", bun.Ident("blocks")).
+		ColumnExpr("?", bun.Ident("id")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccountIDs))
+		}).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("target_account_id"), accountID).
+				Where("? IN (?)", bun.Ident("account_id"), bun.In(targetAccountIDs))
+		}).
+		Scan(ctx, &blockIDs); err != nil {
+		return nil, err
+	}
+
+	// Load blocks by their IDs, via cache.
+	return r.GetBlocksByIDs(ctx, blockIDs)
+}
+
 func (r *relationshipDB) GetBlockByID(ctx context.Context, id string) (*gtsmodel.Block, error) {
 	return r.getBlock(
 		ctx,
@@ -134,6 +164,14 @@ func (r *relationshipDB) GetBlocksByIDs(ctx context.Context, ids []string) ([]*g
 		return blocks, nil
 	}
 
+	// Preload all accounts involved in a single
+	// query, rather than one query per block.
+	accountIDs := make([]string, 0, 2*len(blocks))
+	for _, block := range blocks {
+		accountIDs = append(accountIDs, block.AccountID, block.TargetAccountID)
+	}
+	preloadAccounts(ctx, r.state, accountIDs)
+
 	// Populate all loaded blocks, removing those we fail to
 	// populate (removes needing so many nil checks everywhere).
 	blocks = slices.DeleteFunc(blocks, func(block *gtsmodel.Block) bool {
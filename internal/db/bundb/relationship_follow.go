@@ -111,6 +111,14 @@ func (r *relationshipDB) GetFollowsByIDs(ctx context.Context, ids []string) ([]*
 		return follows, nil
 	}
 
+	// Preload all accounts involved in a single
+	// query, rather than one query per follow.
+	accountIDs := make([]string, 0, 2*len(follows))
+	for _, follow := range follows {
+		accountIDs = append(accountIDs, follow.AccountID, follow.TargetAccountID)
+	}
+	preloadAccounts(ctx, r.state, accountIDs)
+
 	// Populate all loaded follows, removing those we fail to
 	// populate (removes needing so many nil checks everywhere).
 	follows = slices.DeleteFunc(follows, func(follow *gtsmodel.Follow) bool {
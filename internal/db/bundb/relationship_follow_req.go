@@ -110,6 +110,14 @@ func (r *relationshipDB) GetFollowRequestsByIDs(ctx context.Context, ids []strin
 		return follows, nil
 	}
 
+	// Preload all accounts involved in a single
+	// query, rather than one query per follow request.
+	accountIDs := make([]string, 0, 2*len(follows))
+	for _, follow := range follows {
+		accountIDs = append(accountIDs, follow.AccountID, follow.TargetAccountID)
+	}
+	preloadAccounts(ctx, r.state, accountIDs)
+
 	// Populate all loaded followreqs, removing those we fail to
 	// populate (removes needing so many nil checks everywhere).
 	follows = slices.DeleteFunc(follows, func(follow *gtsmodel.FollowRequest) bool {
@@ -240,6 +248,7 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, sourceAccountI
 		URI:             followReq.URI,
 		ShowReblogs:     followReq.ShowReblogs,
 		Notify:          followReq.Notify,
+		Languages:       followReq.Languages,
 	}
 
 	if err := r.state.Caches.GTS.Follow.Store(follow, func() error {
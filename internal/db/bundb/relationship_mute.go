@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -111,6 +112,14 @@ func (r *relationshipDB) getMutesByIDs(ctx context.Context, ids []string) ([]*gt
 		return mutes, nil
 	}
 
+	// Preload all accounts involved in a single
+	// query, rather than one query per mute.
+	accountIDs := make([]string, 0, 2*len(mutes))
+	for _, mute := range mutes {
+		accountIDs = append(accountIDs, mute.AccountID, mute.TargetAccountID)
+	}
+	preloadAccounts(ctx, r.state, accountIDs)
+
 	// Populate all loaded mutes, removing those we fail to
 	// populate (removes needing so many nil checks everywhere).
 	mutes = slices.DeleteFunc(mutes, func(mute *gtsmodel.UserMute) bool {
@@ -304,3 +313,36 @@ func (r *relationshipDB) getAccountMuteIDs(ctx context.Context, accountID string
 		return muteIDs, nil
 	})
 }
+
+func (r *relationshipDB) GetExpiredMuteIDs(ctx context.Context, expiredBefore time.Time) ([]string, error) {
+	var muteIDs []string
+	if err := r.db.
+		NewSelect().
+		Table("user_mutes").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("expires_at")).
+		Where("? <= ?", bun.Ident("expires_at"), expiredBefore).
+		Scan(ctx, &muteIDs); err != nil {
+		return nil, err
+	}
+	return muteIDs, nil
+}
+
+func (r *relationshipDB) GetExpiringMutes(ctx context.Context, expiringAfter time.Time) ([]*gtsmodel.UserMute, error) {
+	var muteIDs []string
+	if err := r.db.
+		NewSelect().
+		Table("user_mutes").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("expires_at")).
+		Where("? > ?", bun.Ident("expires_at"), expiringAfter).
+		Scan(ctx, &muteIDs); err != nil {
+		return nil, err
+	}
+
+	if len(muteIDs) == 0 {
+		return nil, nil
+	}
+
+	return r.getMutesByIDs(ctx, muteIDs)
+}
@@ -417,6 +417,34 @@ func (suite *RelationshipTestSuite) TestIsBlocked() {
 	suite.True(blocked)
 }
 
+func (suite *RelationshipTestSuite) TestGetBlocksBetween() {
+	ctx := context.Background()
+
+	var (
+		blockID  = "01FEXXET6XXMF7G2V3ASZP3YQW" // local_account_2_block_remote_account_1
+		account1 = suite.testAccounts["local_account_1"].ID
+		account2 = suite.testAccounts["local_account_2"].ID
+		remote1  = suite.testAccounts["remote_account_1"].ID
+	)
+
+	// Block is found looking from the blocking account...
+	blocks, err := suite.db.GetBlocksBetween(ctx, account2, []string{account1, remote1})
+	suite.NoError(err)
+	suite.Len(blocks, 1)
+	suite.Equal(blockID, blocks[0].ID)
+
+	// ...and from the blocked account.
+	blocks, err = suite.db.GetBlocksBetween(ctx, remote1, []string{account1, account2})
+	suite.NoError(err)
+	suite.Len(blocks, 1)
+	suite.Equal(blockID, blocks[0].ID)
+
+	// But not between uninvolved accounts.
+	blocks, err = suite.db.GetBlocksBetween(ctx, account1, []string{account2, remote1})
+	suite.NoError(err)
+	suite.Empty(blocks)
+}
+
 func (suite *RelationshipTestSuite) TestDeleteBlockByID() {
 	ctx := context.Background()
 
@@ -177,7 +177,7 @@ func (r *reportDB) getReport(ctx context.Context, lookup string, dbQuery func(*g
 func (r *reportDB) PopulateReport(ctx context.Context, report *gtsmodel.Report) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(4)
+		errs = gtserror.NewMultiError(6)
 	)
 
 	if report.Account == nil {
@@ -238,6 +238,18 @@ func (r *reportDB) PopulateReport(ctx context.Context, report *gtsmodel.Report)
 		}
 	}
 
+	if report.AssignedAccountID != "" &&
+		report.AssignedAccount == nil {
+		// Report assigned account is not set, fetch from the database.
+		report.AssignedAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			report.AssignedAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating report assigned account: %w", err)
+		}
+	}
+
 	return errs.Combine()
 }
 
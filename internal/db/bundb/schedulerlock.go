@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+type schedulerLockDB struct {
+	db *bun.DB
+
+	// holderID uniquely identifies
+	// this process as a lock holder.
+	holderID string
+
+	// conns contains dedicated postgres
+	// connections holding session-level
+	// advisory locks, keyed by lock name.
+	conns map[string]bun.Conn
+	mu    sync.Mutex
+}
+
+func (s *schedulerLockDB) TryLockScheduler(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	if s.db.Dialect().Name() == dialect.PG {
+		return s.tryAdvisoryLock(ctx, name)
+	}
+	return s.tryLeaseLock(ctx, name, ttl)
+}
+
+func (s *schedulerLockDB) UnlockScheduler(ctx context.Context, name string) error {
+	if s.db.Dialect().Name() == dialect.PG {
+		return s.advisoryUnlock(ctx, name)
+	}
+
+	// Drop the lock lease, only if held by us.
+	_, err := s.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduler_locks"), bun.Ident("scheduler_lock")).
+		Where("? = ?", bun.Ident("scheduler_lock.name"), name).
+		Where("? = ?", bun.Ident("scheduler_lock.holder_id"), s.holderID).
+		Exec(ctx)
+	return err
+}
+
+// tryLeaseLock attempts to insert a new lock lease under name, or otherwise take over an
+// existing lease if held by us or expired. This is performed in a single upsert query, so
+// whether the lock was acquired can be determined by whether a row was affected.
+func (s *schedulerLockDB) tryLeaseLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	lock := &gtsmodel.SchedulerLock{
+		Name:      name,
+		HolderID:  s.holderID,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	res, err := s.db.NewInsert().
+		Model(lock).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("name")).
+		Set("? = EXCLUDED.?", bun.Ident("holder_id"), bun.Ident("holder_id")).
+		Set("? = EXCLUDED.?", bun.Ident("expires_at"), bun.Ident("expires_at")).
+		Where("? = EXCLUDED.?", bun.Ident("scheduler_lock.holder_id"), bun.Ident("holder_id")).
+		WhereOr("? < ?", bun.Ident("scheduler_lock.expires_at"), now).
+		Exec(ctx)
+	if err != nil {
+		return false, gtserror.Newf("error upserting lock %s: %w", name, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, gtserror.Newf("error getting rows affected: %w", err)
+	}
+
+	return (n > 0), nil
+}
+
+// tryAdvisoryLock attempts to acquire a session-level postgres advisory lock for name on a
+// dedicated connection. Once held, the connection is kept open (and checked for liveness on
+// each subsequent call) until the lock is released, preventing other processes acquiring it.
+func (s *schedulerLockDB) tryAdvisoryLock(ctx context.Context, name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if conn, ok := s.conns[name]; ok {
+		// Already hold the lock, ensure connection still alive.
+		if err := conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+
+		// Connection was lost, along with the lock.
+		log.Warnf(ctx, "lost connection holding lock %s", name)
+		delete(s.conns, name)
+		_ = conn.Close()
+	}
+
+	// Acquire a dedicated connection from pool.
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, gtserror.Newf("error acquiring connection: %w", err)
+	}
+
+	var ok bool
+
+	if err := conn.NewRaw(
+		"SELECT pg_try_advisory_lock(?)",
+		advisoryLockKey(name),
+	).Scan(ctx, &ok); err != nil {
+		_ = conn.Close()
+		return false, gtserror.Newf("error acquiring advisory lock %s: %w", name, err)
+	}
+
+	if !ok {
+		// Held elsewhere, release conn.
+		_ = conn.Close()
+		return false, nil
+	}
+
+	if s.conns == nil {
+		s.conns = make(map[string]bun.Conn)
+	}
+
+	// Store conn holding lock.
+	s.conns[name] = conn
+	return true, nil
+}
+
+// advisoryUnlock releases session-level postgres
+// advisory lock for name, if held by this process.
+func (s *schedulerLockDB) advisoryUnlock(ctx context.Context, name string) error {
+	s.mu.Lock()
+	conn, ok := s.conns[name]
+	delete(s.conns, name)
+	s.mu.Unlock()
+
+	if !ok {
+		// Not held.
+		return nil
+	}
+
+	// Close conn on return, which in
+	// any case releases session locks.
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx,
+		"SELECT pg_advisory_unlock(?)",
+		advisoryLockKey(name),
+	); err != nil {
+		return gtserror.Newf("error releasing advisory lock %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// advisoryLockKey converts lock name
+// to a postgres advisory lock bigint key.
+func advisoryLockKey(name string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return int64(hash.Sum64()) // #nosec G115 -- overflow intended
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type SchedulerLockTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SchedulerLockTestSuite) TestTryLockScheduler() {
+	ctx := context.Background()
+
+	// Lock should be acquired fresh.
+	ok, err := suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.True(ok)
+
+	// Lock should be renewable by us.
+	ok, err = suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.True(ok)
+
+	// Release the lock.
+	err = suite.db.UnlockScheduler(ctx, "@test")
+	suite.NoError(err)
+
+	// Lock should be acquirable again.
+	ok, err = suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func (suite *SchedulerLockTestSuite) TestTryLockSchedulerHeldElsewhere() {
+	ctx := context.Background()
+
+	// Insert a lock held by some other process.
+	if err := suite.db.Put(ctx, &gtsmodel.SchedulerLock{
+		Name:      "@test",
+		HolderID:  "some-other-process",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Lock should not be acquirable.
+	ok, err := suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.False(ok)
+
+	// Unlocking should not release other's lock.
+	err = suite.db.UnlockScheduler(ctx, "@test")
+	suite.NoError(err)
+
+	ok, err = suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *SchedulerLockTestSuite) TestTryLockSchedulerExpired() {
+	ctx := context.Background()
+
+	// Insert an expired lock held by some other process.
+	if err := suite.db.Put(ctx, &gtsmodel.SchedulerLock{
+		Name:      "@test",
+		HolderID:  "some-other-process",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Lock should be taken over.
+	ok, err := suite.db.TryLockScheduler(ctx, "@test", time.Minute)
+	suite.NoError(err)
+	suite.True(ok)
+}
+
+func TestSchedulerLockTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerLockTestSuite))
+}
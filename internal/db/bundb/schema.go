@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/migrate"
+)
+
+// SchemaStatus describes the state of the database
+// schema, in terms of migrations and indexes.
+type SchemaStatus struct {
+	// Applied contains the names of
+	// applied migrations, oldest first.
+	Applied []string
+
+	// Pending contains the names of migrations
+	// not yet applied, oldest first.
+	Pending []string
+
+	// MissingIndexes contains the names of indexes
+	// expected to exist once all migrations have
+	// been applied, but which weren't found.
+	MissingIndexes []string
+}
+
+// GetSchemaStatus connects to the configured database and returns the
+// status of its schema, WITHOUT performing any pending migrations.
+func GetSchemaStatus(ctx context.Context) (*SchemaStatus, error) {
+	db, err := newBunDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	migrator := migrate.NewMigrator(db, migrations.Migrations)
+
+	// Ensure migration tables exist,
+	// as on a fresh database they won't.
+	if err := migrator.Init(ctx); err != nil {
+		return nil, fmt.Errorf("error initializing migrations: %w", err)
+	}
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting migrations: %w", err)
+	}
+
+	var status SchemaStatus
+
+	for _, m := range ms {
+		if m.IsApplied() {
+			status.Applied = append(status.Applied, m.Name)
+		} else {
+			status.Pending = append(status.Pending, m.Name)
+		}
+	}
+
+	// Get indexes expected after all migrations.
+	expected, err := expectedIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get indexes actually in database.
+	existing, err := listIndexes(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range expected {
+		if !slices.Contains(existing, index) {
+			status.MissingIndexes = append(status.MissingIndexes, index)
+		}
+	}
+
+	return &status, nil
+}
+
+// Migrate connects to the configured
+// database and performs any pending migrations.
+func Migrate(ctx context.Context) error {
+	db, err := newBunDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := doMigration(ctx, db); err != nil {
+		return fmt.Errorf("db migration error: %w", err)
+	}
+
+	return nil
+}
+
+// expectedIndexes returns the names of indexes that exist once all migrations
+// have been applied, by performing them against a scratch in-memory database.
+func expectedIndexes(ctx context.Context) ([]string, error) {
+	sqldb, err := sql.Open("sqlite-gts", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("error opening scratch database: %w", err)
+	}
+
+	// All connections to an in-memory
+	// database with this address are
+	// distinct, so only allow one.
+	sqldb.SetMaxOpenConns(1)
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	defer db.Close()
+
+	registerModels(db)
+
+	migrator := migrate.NewMigrator(db, migrations.Migrations)
+
+	if err := migrator.Init(ctx); err != nil {
+		return nil, fmt.Errorf("error initializing scratch migrations: %w", err)
+	}
+
+	if _, err := migrator.Migrate(ctx); err != nil {
+		return nil, fmt.Errorf("error migrating scratch database: %w", err)
+	}
+
+	return listIndexes(ctx, db)
+}
+
+// listIndexes returns the names of all indexes in the given database,
+// excluding those automatically created by the database engine itself.
+func listIndexes(ctx context.Context, db *bun.DB) ([]string, error) {
+	var (
+		indexes []string
+		q       string
+	)
+
+	switch db.Dialect().Name() {
+	case dialect.SQLite:
+		q = "SELECT name FROM sqlite_master WHERE type = 'index' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	case dialect.PG:
+		q = "SELECT indexname FROM pg_indexes WHERE schemaname = current_schema() ORDER BY indexname"
+	default:
+		return nil, fmt.Errorf("database dialect %s not supported", db.Dialect().Name())
+	}
+
+	if err := db.NewRaw(q).Scan(ctx, &indexes); err != nil {
+		return nil, fmt.Errorf("error listing indexes: %w", err)
+	}
+
+	for i := range indexes {
+		indexes[i] = strings.ToLower(indexes[i])
+	}
+
+	return indexes, nil
+}
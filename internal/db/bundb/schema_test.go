@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+)
+
+type SchemaTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SchemaTestSuite) TestGetSchemaStatusFresh() {
+	// test config uses in-memory sqlite,
+	// so each connection is a fresh database.
+	status, err := bundb.GetSchemaStatus(context.Background())
+	suite.NoError(err)
+	suite.Empty(status.Applied)
+	suite.NotEmpty(status.Pending)
+	suite.NotEmpty(status.MissingIndexes)
+}
+
+func (suite *SchemaTestSuite) TestGetSchemaStatusMigrated() {
+	// use on-disk sqlite so the
+	// migrations persist between calls.
+	config.SetDbAddress(filepath.Join(suite.T().TempDir(), "sqlite.db"))
+
+	err := bundb.Migrate(context.Background())
+	suite.NoError(err)
+
+	status, err := bundb.GetSchemaStatus(context.Background())
+	suite.NoError(err)
+	suite.NotEmpty(status.Applied)
+	suite.Empty(status.Pending)
+	suite.Empty(status.MissingIndexes)
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
@@ -20,13 +20,17 @@ package bundb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"slices"
 	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
 )
@@ -157,6 +161,40 @@ func loadPagedIDs(cache *cache.SliceCache[string], key string, page *paging.Page
 	return ids, nil
 }
 
+// preloadAccounts loads barebones accounts with given IDs into the cache, fetching
+// all those not yet cached in a single query. This is intended to be called before
+// populating a slice of models one at a time, so that each model's account lookups
+// are then served from the cache, rather than each performing their own query.
+func preloadAccounts(ctx context.Context, state *state.State, ids []string) {
+	ids = slices.DeleteFunc(util.Deduplicate(ids), isEmpty)
+	if len(ids) == 0 {
+		return
+	}
+
+	_, err := state.DB.GetAccountsByIDs(gtscontext.SetBarebones(ctx), ids)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Not fatal, population will fall back to single lookups.
+		log.Errorf(ctx, "error preloading accounts: %v", err)
+	}
+}
+
+// preloadStatuses is the status equivalent of preloadAccounts().
+func preloadStatuses(ctx context.Context, state *state.State, ids []string) {
+	ids = slices.DeleteFunc(util.Deduplicate(ids), isEmpty)
+	if len(ids) == 0 {
+		return
+	}
+
+	_, err := state.DB.GetStatusesByIDs(gtscontext.SetBarebones(ctx), ids)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Not fatal, population will fall back to single lookups.
+		log.Errorf(ctx, "error preloading statuses: %v", err)
+	}
+}
+
+// isEmpty returns whether given ID is empty.
+func isEmpty(id string) bool { return id == "" }
+
 // updateWhere parses []db.Where and adds it to the given update query.
 func updateWhere(q *bun.UpdateQuery, where []db.Where) {
 	for _, w := range where {
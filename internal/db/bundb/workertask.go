@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type workerTaskDB struct {
+	db *bun.DB
+}
+
+func (w *workerTaskDB) PutWorkerTasks(ctx context.Context, tasks []*gtsmodel.WorkerTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	_, err := w.db.NewInsert().
+		Model(&tasks).
+		Exec(ctx)
+	return err
+}
+
+func (w *workerTaskDB) PopWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType, limit int) ([]*gtsmodel.WorkerTask, error) {
+	var tasks []*gtsmodel.WorkerTask
+
+	// Select IDs of oldest tasks.
+	subQ := w.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Column("worker_task.id").
+		Where("? = ?", bun.Ident("worker_task.worker_type"), workerType).
+		OrderExpr("? ASC", bun.Ident("worker_task.id")).
+		Limit(limit)
+
+	// Delete + return all selected tasks in a single
+	// statement, so each may only be claimed once.
+	if _, err := w.db.NewDelete().
+		Model(&tasks).
+		Where("? IN (?)", bun.Ident("worker_task.id"), subQ).
+		Returning("*").
+		Exec(ctx, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (w *workerTaskDB) CountWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType) (int, error) {
+	return w.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Where("? = ?", bun.Ident("worker_task.worker_type"), workerType).
+		Count(ctx)
+}
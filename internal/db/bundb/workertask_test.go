@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type WorkerTaskTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WorkerTaskTestSuite) TestPutPopWorkerTasks() {
+	ctx := context.Background()
+
+	var tasks []*gtsmodel.WorkerTask
+	for _, typ := range []gtsmodel.WorkerType{
+		gtsmodel.ClientWorker,
+		gtsmodel.FederatorWorker,
+		gtsmodel.ClientWorker,
+	} {
+		tasks = append(tasks, &gtsmodel.WorkerTask{
+			WorkerType: typ,
+			TaskData:   []byte(`{}`),
+		})
+	}
+
+	err := suite.db.PutWorkerTasks(ctx, tasks)
+	suite.NoError(err)
+
+	// Pop with a limit smaller than total.
+	popped, err := suite.db.PopWorkerTasks(ctx, gtsmodel.ClientWorker, 1)
+	suite.NoError(err)
+	suite.Len(popped, 1)
+
+	// Pop the remainder of type.
+	rest, err := suite.db.PopWorkerTasks(ctx, gtsmodel.ClientWorker, 2)
+	suite.NoError(err)
+	suite.Len(rest, 1)
+	popped = append(popped, rest...)
+
+	// Pop tasks of other type.
+	rest, err = suite.db.PopWorkerTasks(ctx, gtsmodel.FederatorWorker, 2)
+	suite.NoError(err)
+	suite.Len(rest, 1)
+	popped = append(popped, rest...)
+
+	seen := make(map[uint]*gtsmodel.WorkerTask)
+	for _, task := range popped {
+		seen[task.ID] = task
+	}
+
+	for _, task := range tasks {
+		got, ok := seen[task.ID]
+		if suite.True(ok) {
+			suite.Equal(task.WorkerType, got.WorkerType)
+			suite.Equal(task.TaskData, got.TaskData)
+		}
+	}
+
+	// Popped tasks should now be gone.
+	for _, typ := range []gtsmodel.WorkerType{
+		gtsmodel.ClientWorker,
+		gtsmodel.FederatorWorker,
+	} {
+		popped, err = suite.db.PopWorkerTasks(ctx, typ, 2)
+		suite.NoError(err)
+		suite.Empty(popped)
+	}
+
+	// Putting no tasks is a no-op.
+	err = suite.db.PutWorkerTasks(ctx, nil)
+	suite.NoError(err)
+}
+
+func TestWorkerTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTaskTestSuite))
+}
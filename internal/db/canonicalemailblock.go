@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// CanonicalEmailBlock contains functions for managing blocks
+// of canonicalized email address hashes used for new sign-ups.
+type CanonicalEmailBlock interface {
+	// GetCanonicalEmailBlockByID fetches the canonical email block with ID from the database.
+	GetCanonicalEmailBlockByID(ctx context.Context, id string) (*gtsmodel.CanonicalEmailBlock, error)
+
+	// GetCanonicalEmailBlockByHash fetches the canonical email block with the given hash from the database.
+	GetCanonicalEmailBlockByHash(ctx context.Context, hash string) (*gtsmodel.CanonicalEmailBlock, error)
+
+	// GetCanonicalEmailBlocks fetches all canonical email blocks from the database.
+	GetCanonicalEmailBlocks(ctx context.Context) ([]*gtsmodel.CanonicalEmailBlock, error)
+
+	// PutCanonicalEmailBlock inserts the given canonical email block into the database.
+	PutCanonicalEmailBlock(ctx context.Context, block *gtsmodel.CanonicalEmailBlock) error
+
+	// DeleteCanonicalEmailBlockByID deletes the canonical email block with ID from the database.
+	DeleteCanonicalEmailBlockByID(ctx context.Context, id string) error
+}
@@ -25,13 +25,21 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountExport
 	Admin
+	Announcement
 	Application
 	Basic
+	CanonicalEmailBlock
+	DeliveryRetry
 	Domain
+	DomainMediaPolicy
+	EmailDomainBlock
 	Emoji
 	HeaderFilter
 	Instance
+	Invite
+	IPBlock
 	Filter
 	List
 	Marker
@@ -43,6 +51,7 @@ type DB interface {
 	Relationship
 	Report
 	Rule
+	SchedulerLock
 	Search
 	Session
 	Status
@@ -53,4 +62,5 @@ type DB interface {
 	Timeline
 	User
 	Tombstone
+	WorkerTask
 }
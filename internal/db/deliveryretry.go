@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DeliveryRetry contains functionality for persisting
+// outgoing ActivityPub deliveries which are due to be
+// re-attempted at a later time.
+type DeliveryRetry interface {
+	// PutDeliveryRetry puts the given delivery retry in the database.
+	PutDeliveryRetry(ctx context.Context, retry *gtsmodel.DeliveryRetry) error
+
+	// PopDueDeliveryRetries deletes and returns up to limit delivery retries due
+	// to be attempted at given time, oldest-due first. Each retry will only ever
+	// be returned to one caller, even between processes sharing the database.
+	PopDueDeliveryRetries(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.DeliveryRetry, error)
+
+	// GetDeliveryRetries returns all delivery retries currently in the database,
+	// soonest-due first. If host is set, only retries targeting host are returned.
+	GetDeliveryRetries(ctx context.Context, host string) ([]*gtsmodel.DeliveryRetry, error)
+
+	// UpdateDeliveryRetry updates the given delivery retry in the database,
+	// only updating given columns if provided.
+	UpdateDeliveryRetry(ctx context.Context, retry *gtsmodel.DeliveryRetry, columns ...string) error
+
+	// DeleteDeliveryRetryByID deletes the delivery retry with given ID from the database.
+	DeleteDeliveryRetryByID(ctx context.Context, id string) error
+}
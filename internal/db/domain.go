@@ -68,6 +68,10 @@ type Domain interface {
 	// Will check allows first, so an allowed domain will always return false, even if it's also blocked.
 	IsDomainBlocked(ctx context.Context, domain string) (bool, error)
 
+	// IsDomainSilenced checks if domain is silenced, ie., has a domain block with silence severity.
+	// Unlike IsDomainBlocked, this does not take account of explicit allows or federation mode.
+	IsDomainSilenced(ctx context.Context, domain string) (bool, error)
+
 	// AreDomainsBlocked calls IsDomainBlocked for each domain.
 	// Will return true if even one of the given domains is blocked.
 	AreDomainsBlocked(ctx context.Context, domains []string) (bool, error)
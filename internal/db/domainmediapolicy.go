@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DomainMediaPolicy contains functions for managing
+// per-domain overrides of remote media caching.
+type DomainMediaPolicy interface {
+	// GetDomainMediaPolicyByID fetches the domain media policy with ID from the database.
+	GetDomainMediaPolicyByID(ctx context.Context, id string) (*gtsmodel.DomainMediaPolicy, error)
+
+	// GetDomainMediaPolicy fetches the domain media policy for exactly the given domain from the database.
+	GetDomainMediaPolicy(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error)
+
+	// GetDomainMediaPolicies fetches all domain media policies from the database.
+	GetDomainMediaPolicies(ctx context.Context) ([]*gtsmodel.DomainMediaPolicy, error)
+
+	// PutDomainMediaPolicy inserts the given domain media policy into the database.
+	PutDomainMediaPolicy(ctx context.Context, policy *gtsmodel.DomainMediaPolicy) error
+
+	// DeleteDomainMediaPolicyByID deletes the domain media policy with ID from the database.
+	DeleteDomainMediaPolicyByID(ctx context.Context, id string) error
+
+	// MatchDomainMediaPolicy returns the domain media policy that applies to
+	// the given domain (or its nearest parent domain), or nil if none applies.
+	MatchDomainMediaPolicy(ctx context.Context, domain string) (*gtsmodel.DomainMediaPolicy, error)
+}
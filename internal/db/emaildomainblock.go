@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmailDomainBlock contains functions for managing
+// blocks of email domains used for new sign-ups.
+type EmailDomainBlock interface {
+	// GetEmailDomainBlockByID fetches the email domain block with ID from the database.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlock fetches the email domain block for exactly the given domain from the database.
+	GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlocks fetches all email domain blocks from the database.
+	GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error)
+
+	// CreateEmailDomainBlock inserts the given email domain block into the database.
+	CreateEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error
+
+	// DeleteEmailDomainBlockByID deletes the email domain block with ID from the database.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) error
+
+	// IsEmailDomainBlocked checks if the given email domain (or a parent domain of it) is blocked.
+	IsEmailDomainBlocked(ctx context.Context, domain string) (bool, error)
+}
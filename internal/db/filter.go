@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -57,6 +58,9 @@ type Filter interface {
 	// It uses a transaction to ensure no partial updates.
 	DeleteFilterByID(ctx context.Context, id string) error
 
+	// GetExpiredFilterIDs gets the IDs of all filters that expired at or before the given time.
+	GetExpiredFilterIDs(ctx context.Context, expiredBefore time.Time) ([]string, error)
+
 	//</editor-fold>
 
 	//<editor-fold desc="Filter keyword methods">
@@ -100,4 +104,28 @@ type Filter interface {
 	DeleteFilterStatusByID(ctx context.Context, id string) error
 
 	//</editor-fold>
+
+	//<editor-fold desc="Filter subscription methods">
+
+	// GetFilterSubscriptionByID gets one filter subscription with the given ID.
+	GetFilterSubscriptionByID(ctx context.Context, id string) (*gtsmodel.FilterSubscription, error)
+
+	// GetFilterSubscriptionsForAccountID gets all filter subscriptions owned by the given accountID.
+	GetFilterSubscriptionsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FilterSubscription, error)
+
+	// GetAllFilterSubscriptions gets all filter subscriptions of all accounts, for syncing.
+	GetAllFilterSubscriptions(ctx context.Context) ([]*gtsmodel.FilterSubscription, error)
+
+	// PutFilterSubscription inserts a single filter subscription into the database.
+	PutFilterSubscription(ctx context.Context, subscription *gtsmodel.FilterSubscription) error
+
+	// UpdateFilterSubscription updates the given filter subscription.
+	// Columns is optional, if not specified all will be updated.
+	UpdateFilterSubscription(ctx context.Context, subscription *gtsmodel.FilterSubscription, columns ...string) error
+
+	// DeleteFilterSubscriptionByID deletes one filter subscription with the given id.
+	// Filters managed by the subscription are not touched.
+	DeleteFilterSubscriptionByID(ctx context.Context, id string) error
+
+	//</editor-fold>
 }
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -31,6 +32,10 @@ type Instance interface {
 	// CountInstanceStatuses returns the number of known statuses posted from the given domain.
 	CountInstanceStatuses(ctx context.Context, domain string) (int, error)
 
+	// CountInstanceActiveUsers returns the number of known accounts registered with the given domain,
+	// which have posted a status since the given time. Used as an estimation of monthly active users.
+	CountInstanceActiveUsers(ctx context.Context, domain string, since time.Time) (int, error)
+
 	// CountInstanceDomains returns the number of known instances known that the given domain federates with.
 	CountInstanceDomains(ctx context.Context, domain string) (int, error)
 
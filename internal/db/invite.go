@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Invite contains functions for managing
+// sign-up invite codes on this instance.
+type Invite interface {
+	// GetInviteByID fetches the invite with ID from the database.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode fetches the invite with the given code from the database.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites fetches all invites from the database, newest first.
+	// If accountID is set, only invites created by that account are returned.
+	GetInvites(ctx context.Context, accountID string) ([]*gtsmodel.Invite, error)
+
+	// PutInvite inserts the given invite into the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates the given invite in the database,
+	// only updating given columns if provided.
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// UseInvite atomically increments the uses of the invite with the given code,
+	// provided it is still usable at the given time, and returns the updated invite.
+	// If no usable invite exists with the code, ErrNoEntries is returned.
+	UseInvite(ctx context.Context, code string, now time.Time) (*gtsmodel.Invite, error)
+
+	// ReleaseInvite gives back one use of the invite with the given ID,
+	// previously claimed with UseInvite, e.g. when the sign-up it was
+	// claimed for failed to complete.
+	ReleaseInvite(ctx context.Context, id string) error
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// IPBlock contains functions for managing
+// blocks of requests from IP addresses / ranges.
+type IPBlock interface {
+	// GetIPBlockByID fetches the IP block with ID from the database.
+	GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlock fetches the IP block for exactly the given CIDR prefix from the database.
+	GetIPBlock(ctx context.Context, ip string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlocks fetches all IP blocks from the database.
+	GetIPBlocks(ctx context.Context) ([]*gtsmodel.IPBlock, error)
+
+	// PutIPBlock inserts the given IP block into the database.
+	PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error
+
+	// DeleteIPBlockByID deletes the IP block with ID from the database.
+	DeleteIPBlockByID(ctx context.Context, id string) error
+
+	// IPBlockMatch returns the most severe severity of any IP blocks
+	// containing the given address, or an empty severity if none.
+	// (Note: the actual matching code can be found under ./internal/cache/ipblock/ ).
+	IPBlockMatch(ctx context.Context, addr netip.Addr) (gtsmodel.IPBlockSeverity, error)
+}
@@ -39,6 +39,11 @@ type Poll interface {
 	// UpdatePoll updates the Poll in the database, only on selected columns if provided (else, all).
 	UpdatePoll(ctx context.Context, poll *gtsmodel.Poll, cols ...string) error
 
+	// ClosePoll sets the closed_at column of the given Poll in the database to poll.ClosedAt, only
+	// if not already closed, returning whether it was closed by this call. As this is atomic, when
+	// multiple processes share a database only one will succeed in closing a particular poll.
+	ClosePoll(ctx context.Context, poll *gtsmodel.Poll) (bool, error)
+
 	// DeletePollByID deletes the Poll with given ID from the database.
 	DeletePollByID(ctx context.Context, id string) error
 
@@ -57,6 +62,10 @@ type Poll interface {
 	// PutPollVote puts the given PollVote in the database.
 	PutPollVote(ctx context.Context, vote *gtsmodel.PollVote) error
 
+	// ReplacePollVote replaces any existing PollVote by the vote's account in its poll with
+	// the given PollVote, updating the poll's vote counts to match, in a single transaction.
+	ReplacePollVote(ctx context.Context, vote *gtsmodel.PollVote) error
+
 	// DeletePollVotes deletes all PollVotes in Poll with given ID from the database.
 	DeletePollVotes(ctx context.Context, pollID string) error
 
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
@@ -32,6 +33,9 @@ type Relationship interface {
 	// IsEitherBlocked checks whether there is a block in place between either of account1 and account2.
 	IsEitherBlocked(ctx context.Context, accountID1 string, accountID2 string) (bool, error)
 
+	// GetBlocksBetween returns all blocks in place, in either direction, between accountID and any of the given target account IDs.
+	GetBlocksBetween(ctx context.Context, accountID string, targetAccountIDs []string) ([]*gtsmodel.Block, error)
+
 	// GetBlockByID fetches block with given ID from the database.
 	GetBlockByID(ctx context.Context, id string) (*gtsmodel.Block, error)
 
@@ -208,4 +212,10 @@ type Relationship interface {
 
 	// GetAccountMutes returns all mutes originating from the given account, with given optional paging parameters.
 	GetAccountMutes(ctx context.Context, accountID string, paging *paging.Page) ([]*gtsmodel.UserMute, error)
+
+	// GetExpiredMuteIDs returns the IDs of all mutes that expired at or before the given time.
+	GetExpiredMuteIDs(ctx context.Context, expiredBefore time.Time) ([]string, error)
+
+	// GetExpiringMutes returns all mutes that are yet to expire after the given time.
+	GetExpiringMutes(ctx context.Context, expiringAfter time.Time) ([]*gtsmodel.UserMute, error)
 }
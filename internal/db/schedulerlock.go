@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// SchedulerLock contains functionality for acquiring named locks shared between
+// all GoToSocial processes connected to the same database, so that scheduled
+// jobs may be elected to run on exactly one process at a time.
+type SchedulerLock interface {
+	// TryLockScheduler attempts to acquire, or renew if already held, the named lock for
+	// this process, returning whether it is held. The TTL determines how long the lease is
+	// held for without renewal, after which another process may acquire it. On Postgres,
+	// a session advisory lock is used and the lock is instead held until UnlockScheduler()
+	// is called, or until the connection holding the lock is closed.
+	TryLockScheduler(ctx context.Context, name string, ttl time.Duration) (bool, error)
+
+	// UnlockScheduler releases the named lock, if it is held by this process.
+	UnlockScheduler(ctx context.Context, name string) error
+}
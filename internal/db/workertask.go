@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WorkerTask contains functionality for persisting
+// queued worker messages between restarts.
+type WorkerTask interface {
+	// PutWorkerTasks puts the given worker tasks in the database.
+	PutWorkerTasks(ctx context.Context, tasks []*gtsmodel.WorkerTask) error
+
+	// PopWorkerTasks deletes and returns up to limit worker tasks of given worker
+	// type, oldest first. Each task will only ever be returned to one caller.
+	PopWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType, limit int) ([]*gtsmodel.WorkerTask, error)
+
+	// CountWorkerTasks returns the number of worker tasks of given worker type in the database.
+	CountWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType) (int, error)
+}
@@ -220,6 +220,24 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateReportClosedWithOutcome() {
+	reportClosedData := email.ReportClosedData{
+		InstanceURL:          "https://example.org",
+		InstanceName:         "Test Instance",
+		ReportTargetUsername: "foss_satan",
+		ReportTargetDomain:   "fossbros-anonymous.io",
+		ActionTakenComment:   "User was yeeted. Thank you for reporting!",
+		ResolutionOutcome:    "Action has been taken against the reported account.",
+	}
+
+	if err := suite.sender.SendReportClosedEmail("user@example.org", reportClosedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @foss_satan@fossbros-anonymous.io to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed. Action has been taken against the reported account.\r\n\r\nThe moderator who closed the report left the following comment: User was yeeted. Thank you for reporting!\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
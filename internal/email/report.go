@@ -57,6 +57,9 @@ type ReportClosedData struct {
 	ReportTargetDomain string
 	// Comment left by the admin who closed the report.
 	ActionTakenComment string
+	// Sentence describing the outcome of the report,
+	// derived from its resolution category, if set.
+	ResolutionOutcome string
 }
 
 func (s *sender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
@@ -0,0 +1,483 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package emojipack implements export and import of an instance's
+// custom emojis as an emoji pack: a zip archive of emoji images,
+// along with a manifest giving the shortcode and category of each.
+package emojipack
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ManifestFile is the name of the manifest
+// file contained in an emoji pack.
+const ManifestFile = "manifest.json"
+
+// emojiPageSize is the number of emojis to select
+// from the database at a time when writing a pack.
+const emojiPageSize = 100
+
+// Possible ways of handling an imported emoji whose
+// shortcode is already in use by a local emoji.
+const (
+	// ConflictSkip leaves the existing emoji as-is.
+	ConflictSkip = "skip"
+
+	// ConflictReplace replaces the image (and
+	// category) of the existing emoji.
+	ConflictReplace = "replace"
+
+	// ConflictRename imports the emoji under a
+	// new shortcode, suffixed with a number.
+	ConflictRename = "rename"
+)
+
+// Manifest describes the emojis contained in an emoji pack.
+type Manifest struct {
+	Emojis []ManifestEmoji `json:"emojis"`
+}
+
+// ManifestEmoji describes one emoji in an emoji pack.
+type ManifestEmoji struct {
+	// Shortcode of the emoji, without colons.
+	Shortcode string `json:"shortcode"`
+
+	// Category of the emoji, if any.
+	Category string `json:"category,omitempty"`
+
+	// Path of the emoji image within the pack.
+	File string `json:"file"`
+
+	// Whether the emoji is shown in the emoji picker.
+	// Defaults to true if not set.
+	VisibleInPicker *bool `json:"visible_in_picker,omitempty"`
+}
+
+// ImportOptions configures the import of an emoji pack.
+type ImportOptions struct {
+	// Category to assign all imported emojis to,
+	// overriding any categories in the manifest.
+	Category string
+
+	// OnConflict determines how to handle emojis whose shortcode
+	// is already in use. One of ConflictSkip (the default),
+	// ConflictReplace or ConflictRename.
+	OnConflict string
+}
+
+// ImportResult describes the outcome of importing an emoji pack,
+// in terms of the shortcodes of emojis in the pack. Renamed emojis
+// are included in Imported under their new shortcode.
+type ImportResult struct {
+	Imported []string
+	Replaced []string
+	Skipped  []string
+	Failed   []string
+}
+
+// Packer wraps functionality for
+// exporting and importing emoji packs.
+type Packer struct {
+	state   *state.State
+	manager *media.Manager
+}
+
+// NewPacker returns a new emoji Packer that will use the given state,
+// and media manager to process emojis. The manager may be nil if the
+// Packer will only be used to export packs.
+func NewPacker(state *state.State, manager *media.Manager) *Packer {
+	return &Packer{
+		state:   state,
+		manager: manager,
+	}
+}
+
+// Export writes an emoji pack of all local custom emojis to out,
+// or only those in the given category if category is set. Returns
+// the number of emojis written to the pack.
+func (p *Packer) Export(ctx context.Context, out io.Writer, category string) (int, error) {
+	var (
+		manifest Manifest
+		maxSCD   string
+	)
+
+	zw := zip.NewWriter(out)
+
+	for {
+		// Page through local emojis (both enabled + disabled).
+		emojis, err := p.state.DB.GetEmojisBy(ctx,
+			"",   // domain (local)
+			true, // includeDisabled
+			true, // includeEnabled
+			"",   // shortcode
+			maxSCD,
+			"", // minShortcodeDomain
+			emojiPageSize,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return 0, gtserror.Newf("db error getting emojis: %w", err)
+		}
+
+		if len(emojis) == 0 {
+			// Reached the end.
+			break
+		}
+
+		// Set next page max shortcode domain.
+		maxSCD = util.ShortcodeDomain(emojis[len(emojis)-1])
+
+		for _, emoji := range emojis {
+			var categoryName string
+
+			if emoji.CategoryID != "" {
+				c, err := p.state.DB.GetEmojiCategory(ctx, emoji.CategoryID)
+				if err != nil && !errors.Is(err, db.ErrNoEntries) {
+					return 0, gtserror.Newf("db error getting emoji category: %w", err)
+				}
+
+				if c != nil {
+					categoryName = c.Name
+				}
+			}
+
+			if category != "" && categoryName != category {
+				// Not wanted.
+				continue
+			}
+
+			file := "emojis/" + emoji.Shortcode + path.Ext(emoji.ImagePath)
+			if err := p.writeImage(ctx, zw, file, emoji); err != nil {
+				return 0, err
+			}
+
+			manifest.Emojis = append(manifest.Emojis, ManifestEmoji{
+				Shortcode:       emoji.Shortcode,
+				Category:        categoryName,
+				File:            file,
+				VisibleInPicker: emoji.VisibleInPicker,
+			})
+		}
+	}
+
+	fw, err := zw.Create(ManifestFile)
+	if err != nil {
+		return 0, gtserror.Newf("error creating manifest: %w", err)
+	}
+
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return 0, gtserror.Newf("error writing manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return 0, gtserror.Newf("error closing pack: %w", err)
+	}
+
+	return len(manifest.Emojis), nil
+}
+
+// writeImage copies the image of emoji from
+// storage into the zip archive at file.
+func (p *Packer) writeImage(ctx context.Context, zw *zip.Writer, file string, emoji *gtsmodel.Emoji) error {
+	rc, err := p.state.Storage.GetStream(ctx, emoji.ImagePath)
+	if err != nil {
+		return gtserror.Newf("error getting emoji %s image: %w", emoji.Shortcode, err)
+	}
+	defer rc.Close()
+
+	fw, err := zw.Create(file)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", file, err)
+	}
+
+	if _, err := io.Copy(fw, rc); err != nil {
+		return gtserror.Newf("error writing %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// Import imports the emojis of the emoji pack in r, of given size, as local
+// custom emojis, according to the given options. An error is only returned
+// if the pack itself is invalid, failures to import individual emojis are
+// logged, and included in the returned result.
+//
+// If the pack contains no manifest, then all png and gif images within it
+// are imported, using their file name (without extension) as shortcode.
+func (p *Packer) Import(ctx context.Context, r io.ReaderAt, size int64, opts ImportOptions) (*ImportResult, error) {
+	switch opts.OnConflict {
+	case "":
+		opts.OnConflict = ConflictSkip
+	case ConflictSkip, ConflictReplace, ConflictRename:
+	default:
+		return nil, fmt.Errorf("invalid conflict handling %q, must be one of %s, %s or %s",
+			opts.OnConflict, ConflictSkip, ConflictReplace, ConflictRename)
+	}
+
+	if err := validate.EmojiCategory(opts.Category); err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error opening emoji pack: %w", err)
+	}
+
+	manifest, err := readManifest(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Imported: []string{},
+		Replaced: []string{},
+		Skipped:  []string{},
+		Failed:   []string{},
+	}
+
+	for _, entry := range manifest.Emojis {
+		if opts.Category != "" {
+			entry.Category = opts.Category
+		}
+
+		shortcode, replaced, err := p.importEmoji(ctx, zr, entry, opts.OnConflict)
+		switch {
+		case err != nil:
+			log.Errorf(ctx, "error importing emoji %s: %v", entry.Shortcode, err)
+			result.Failed = append(result.Failed, entry.Shortcode)
+		case shortcode == "":
+			result.Skipped = append(result.Skipped, entry.Shortcode)
+		case replaced:
+			result.Replaced = append(result.Replaced, shortcode)
+		default:
+			result.Imported = append(result.Imported, shortcode)
+		}
+	}
+
+	return result, nil
+}
+
+// importEmoji imports a single emoji from the pack, returning the
+// shortcode it was imported under (empty if skipped), and whether
+// an existing emoji was replaced.
+func (p *Packer) importEmoji(
+	ctx context.Context,
+	zr *zip.Reader,
+	entry ManifestEmoji,
+	onConflict string,
+) (string, bool, error) {
+	if err := validate.EmojiShortcode(entry.Shortcode); err != nil {
+		return "", false, err
+	}
+
+	if err := validate.EmojiCategory(entry.Category); err != nil {
+		return "", false, err
+	}
+
+	file, err := zr.Open(entry.File)
+	if err != nil {
+		return "", false, fmt.Errorf("error opening %s: %w", entry.File, err)
+	}
+
+	info, err := file.Stat()
+	_ = file.Close()
+	if err != nil {
+		return "", false, fmt.Errorf("error getting %s info: %w", entry.File, err)
+	}
+
+	maxSize := config.GetMediaEmojiLocalMaxSize()
+	if info.Size() > int64(maxSize) {
+		return "", false, fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB", info.Size()/1024, maxSize/1024)
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		rc, err := zr.Open(entry.File)
+		return rc, info.Size(), err
+	}
+
+	var categoryID *string
+	if entry.Category != "" {
+		category, err := p.getOrCreateCategory(ctx, entry.Category)
+		if err != nil {
+			return "", false, err
+		}
+		categoryID = &category.ID
+	}
+
+	shortcode := entry.Shortcode
+
+	existing, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", false, gtserror.Newf("db error getting emoji: %w", err)
+	}
+
+	if existing != nil {
+		switch onConflict {
+		case ConflictSkip:
+			return "", false, nil
+
+		case ConflictReplace:
+			if categoryID != nil && *categoryID != existing.CategoryID {
+				existing.CategoryID = *categoryID
+				existing.Category = nil
+				if err := p.state.DB.UpdateEmoji(ctx, existing, "category_id"); err != nil {
+					return "", false, gtserror.Newf("db error updating emoji: %w", err)
+				}
+			}
+
+			processing := p.manager.RecacheEmoji(existing, data)
+			if _, err := processing.Load(ctx); err != nil {
+				return "", false, gtserror.Newf("error processing emoji: %w", err)
+			}
+
+			return shortcode, true, nil
+
+		case ConflictRename:
+			shortcode, err = p.freeShortcode(ctx, shortcode)
+			if err != nil {
+				return "", false, err
+			}
+		}
+	}
+
+	processing, err := p.manager.CreateEmoji(ctx,
+		shortcode,
+		"", // domain = "" -> local
+		data,
+		media.AdditionalEmojiInfo{
+			CategoryID:      categoryID,
+			VisibleInPicker: entry.VisibleInPicker,
+		},
+	)
+	if err != nil {
+		return "", false, gtserror.Newf("error creating emoji: %w", err)
+	}
+
+	if _, err := processing.Load(ctx); err != nil {
+		return "", false, gtserror.Newf("error processing emoji: %w", err)
+	}
+
+	return shortcode, false, nil
+}
+
+// freeShortcode returns the first shortcode not in use
+// by a local emoji, of the form shortcode_2, shortcode_3,
+// etc, truncating the shortcode to fit if necessary.
+func (p *Packer) freeShortcode(ctx context.Context, shortcode string) (string, error) {
+	for i := 2; ; i++ {
+		suffix := "_" + strconv.Itoa(i)
+
+		// Shortcodes may be at most 30 chars.
+		base := shortcode
+		if len(base)+len(suffix) > 30 {
+			base = base[:30-len(suffix)]
+		}
+
+		candidate := base + suffix
+
+		existing, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, candidate, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return "", gtserror.Newf("db error getting emoji: %w", err)
+		}
+
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+}
+
+// getOrCreateCategory either gets an existing category
+// with the given name from the database, or creates it.
+func (p *Packer) getOrCreateCategory(ctx context.Context, name string) (*gtsmodel.EmojiCategory, error) {
+	category, err := p.state.DB.GetEmojiCategoryByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting emoji category: %w", err)
+	}
+
+	if category != nil {
+		return category, nil
+	}
+
+	category = &gtsmodel.EmojiCategory{
+		ID:   id.NewULID(),
+		Name: name,
+	}
+
+	if err := p.state.DB.PutEmojiCategory(ctx, category); err != nil {
+		return nil, gtserror.Newf("db error inserting emoji category: %w", err)
+	}
+
+	return category, nil
+}
+
+// readManifest reads the manifest of the emoji pack in zr,
+// or if it has no manifest, builds one from the png and gif
+// images in the pack.
+func readManifest(zr *zip.Reader) (*Manifest, error) {
+	rc, err := zr.Open(ManifestFile)
+	if err == nil {
+		defer rc.Close()
+
+		var manifest Manifest
+		if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", ManifestFile, err)
+		}
+
+		return &manifest, nil
+	}
+
+	var manifest Manifest
+
+	for _, f := range zr.File {
+		ext := strings.ToLower(path.Ext(f.Name))
+		if ext != ".png" && ext != ".gif" {
+			continue
+		}
+
+		manifest.Emojis = append(manifest.Emojis, ManifestEmoji{
+			Shortcode: strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name)),
+			File:      f.Name,
+		})
+	}
+
+	if len(manifest.Emojis) == 0 {
+		return nil, errors.New("emoji pack contains no manifest or emoji images")
+	}
+
+	return &manifest, nil
+}
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiPackTestSuite struct {
+	suite.Suite
+	state  state.State
+	packer *emojipack.Packer
+}
+
+func (suite *EmojiPackTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *EmojiPackTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.state.Storage = testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(suite.state.Storage, "../../testrig/media")
+
+	suite.packer = emojipack.NewPacker(&suite.state, media.NewManager(&suite.state))
+}
+
+func (suite *EmojiPackTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StandardStorageTeardown(suite.state.Storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// export writes an emoji pack of the given
+// category, returning the pack and its manifest.
+func (suite *EmojiPackTestSuite) export(category string) ([]byte, emojipack.Manifest) {
+	var buf bytes.Buffer
+	if _, err := suite.packer.Export(context.Background(), &buf, category); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	r, err := zr.Open(emojipack.ManifestFile)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var manifest emojipack.Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return buf.Bytes(), manifest
+}
+
+func (suite *EmojiPackTestSuite) TestExport() {
+	_, manifest := suite.export("")
+
+	// Only the local emoji should be included.
+	suite.Len(manifest.Emojis, 1)
+	suite.Equal("rainbow", manifest.Emojis[0].Shortcode)
+	suite.Equal("reactions", manifest.Emojis[0].Category)
+	suite.Equal("emojis/rainbow.png", manifest.Emojis[0].File)
+
+	// Nothing in a category that doesn't exist.
+	_, manifest = suite.export("nope")
+	suite.Empty(manifest.Emojis)
+}
+
+func (suite *EmojiPackTestSuite) TestImportConflict() {
+	ctx := context.Background()
+	pack, _ := suite.export("")
+
+	// Importing our own pack should skip by default.
+	result, err := suite.packer.Import(ctx, bytes.NewReader(pack), int64(len(pack)), emojipack.ImportOptions{})
+	suite.NoError(err)
+	suite.Equal([]string{"rainbow"}, result.Skipped)
+	suite.Empty(result.Imported)
+
+	// Rename should create a new emoji in the requested category.
+	result, err = suite.packer.Import(ctx, bytes.NewReader(pack), int64(len(pack)), emojipack.ImportOptions{
+		Category:   "imported",
+		OnConflict: emojipack.ConflictRename,
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"rainbow_2"}, result.Imported)
+
+	emoji, err := suite.state.DB.GetEmojiByShortcodeDomain(ctx, "rainbow_2", "")
+	suite.NoError(err)
+	suite.NotEmpty(emoji.CategoryID)
+
+	category, err := suite.state.DB.GetEmojiCategory(ctx, emoji.CategoryID)
+	suite.NoError(err)
+	suite.Equal("imported", category.Name)
+
+	// Replace should leave us with the same shortcode.
+	result, err = suite.packer.Import(ctx, bytes.NewReader(pack), int64(len(pack)), emojipack.ImportOptions{
+		OnConflict: emojipack.ConflictReplace,
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"rainbow"}, result.Replaced)
+
+	// Unknown conflict handling should be refused.
+	_, err = suite.packer.Import(ctx, bytes.NewReader(pack), int64(len(pack)), emojipack.ImportOptions{
+		OnConflict: "explode",
+	})
+	suite.Error(err)
+}
+
+func TestEmojiPackTestSuite(t *testing.T) {
+	suite.Run(t, &EmojiPackTestSuite{})
+}
@@ -217,6 +217,19 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 
+		// The Actor may have rotated to a new key ID since we
+		// last fetched it, in which case the stored Actor will
+		// still have the old key. Refetch the Actor's key.
+		if pubKeyAuth.Owner.PublicKeyURI != pubKeyIDStr {
+			if errWithCode := f.refreshOwnerPubKey(ctx,
+				requestedUsername,
+				pubKeyAuth.Owner,
+				pubKeyAuth.OwnerURI,
+			); errWithCode != nil {
+				return nil, errWithCode
+			}
+		}
+
 		// Catch a possible (but very rare) race condition where
 		// we've fetched a key, then fetched the Actor who owns the
 		// key, but the Key of the Actor has changed in the meantime.
@@ -351,7 +364,7 @@ func (f *Federator) derefPubKey(
 	}
 
 	// Extract the key and the owner from the response.
-	pubKey, pubKeyOwner, err := parsePubKeyBytes(ctx, pubKeyBytes, pubKeyID)
+	pubKey, _, pubKeyOwner, err := parsePubKeyBytes(ctx, pubKeyBytes, pubKeyID)
 	if err != nil {
 		err := gtserror.Newf("error parsing public key (%s): %w", pubKeyID, err)
 		return nil, gtserror.NewErrorUnauthorized(err)
@@ -450,6 +463,48 @@ func (f *Federator) callForPubKey(
 	return nil, gtserror.NewErrorInternalError(err)
 }
 
+// refreshOwnerPubKey dereferences the Actor at ownerURI, and updates
+// the public key + public key ID of the stored owner account to match.
+func (f *Federator) refreshOwnerPubKey(
+	ctx context.Context,
+	requestedUsername string,
+	owner *gtsmodel.Account,
+	ownerURI *url.URL,
+) gtserror.WithCode {
+	ownerBytes, errWithCode := f.callForPubKey(ctx, requestedUsername, ownerURI)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	pubKey, pubKeyID, _, err := parsePubKeyBytes(ctx, ownerBytes, ownerURI)
+	if err != nil {
+		err := gtserror.Newf("error parsing public key of %s: %w", ownerURI, err)
+		return gtserror.NewErrorUnauthorized(err)
+	}
+
+	if pubKeyID.String() == owner.PublicKeyURI &&
+		pubKey.Equal(owner.PublicKey) {
+		// Nothing changed.
+		return nil
+	}
+
+	owner.PublicKey = pubKey
+	owner.PublicKeyURI = pubKeyID.String()
+	owner.PublicKeyExpiresAt = time.Time{}
+	if err := f.db.UpdateAccount(
+		ctx,
+		owner,
+		"public_key",
+		"public_key_uri",
+		"public_key_expires_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating account with rotated public key (%s): %w", pubKeyID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
 // fetchAccountInstance ensures that an instance model exists in
 // the database for the given account URI, deref'ing if necessary.
 func (f *Federator) fetchAccountInstance(
@@ -494,19 +549,20 @@ func (f *Federator) fetchAccountInstance(
 // parsePubKeyBytes extracts an rsa public key from the
 // given pubKeyBytes by trying to parse the pubKeyBytes
 // as an ActivityPub type. It will return the public key
-// itself, and the URI of the public key owner.
+// itself, its ID, and the URI of the public key owner.
 func parsePubKeyBytes(
 	ctx context.Context,
 	pubKeyBytes []byte,
 	pubKeyID *url.URL,
-) (*rsa.PublicKey, *url.URL, error) {
+) (*rsa.PublicKey, *url.URL, *url.URL, error) {
 	m := make(map[string]interface{})
 	if err := json.Unmarshal(pubKeyBytes, &m); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var (
 		pubKey   *rsa.PublicKey
+		keyID    *url.URL
 		ownerURI *url.URL
 	)
 
@@ -514,36 +570,36 @@ func parsePubKeyBytes(
 		// See if Actor with a PublicKey attached.
 		wpk, ok := t.(ap.WithPublicKey)
 		if !ok {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"resource at %s with type %T did not contain recognizable public key",
 				pubKeyID, t,
 			)
 		}
 
-		pubKey, _, ownerURI, err = ap.ExtractPubKeyFromActor(wpk)
+		pubKey, keyID, ownerURI, err = ap.ExtractPubKeyFromActor(wpk)
 		if err != nil {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"error extracting public key from %T at %s: %w",
 				t, pubKeyID, err,
 			)
 		}
 	} else if pk, err := typepublickey.DeserializePublicKey(m, nil); err == nil {
 		// Bare PublicKey.
-		pubKey, _, ownerURI, err = ap.ExtractPubKeyFromKey(pk)
+		pubKey, keyID, ownerURI, err = ap.ExtractPubKeyFromKey(pk)
 		if err != nil {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"error extracting public key at %s: %w",
 				pubKeyID, err,
 			)
 		}
 	} else {
-		return nil, nil, gtserror.Newf(
+		return nil, nil, nil, gtserror.Newf(
 			"resource at %s did not contain recognizable public key",
 			pubKeyID,
 		)
 	}
 
-	return pubKey, ownerURI, nil
+	return pubKey, keyID, ownerURI, nil
 }
 
 var signingAlgorithms = []httpsig.Algorithm{
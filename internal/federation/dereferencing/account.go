@@ -20,6 +20,7 @@ package dereferencing
 import (
 	"context"
 	"errors"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -103,7 +104,7 @@ func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string,
 	if accountable != nil {
 		// This account was updated, enqueue re-dereference featured posts + stats.
 		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
-			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
+			if err := d.dereferenceAccountFeatured(ctx, requestUser, account, accountable); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
@@ -215,7 +216,7 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 	if accountable != nil {
 		// This account was updated, enqueue re-dereference featured posts + stats.
 		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
-			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
+			if err := d.dereferenceAccountFeatured(ctx, requestUser, account, accountable); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
@@ -346,7 +347,7 @@ func (d *Dereferencer) RefreshAccount(
 	if accountable != nil {
 		// This account was updated, enqueue re-dereference featured posts + stats.
 		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
-			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
+			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest, accountable); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
@@ -398,7 +399,7 @@ func (d *Dereferencer) RefreshAccountAsync(
 
 		if accountable != nil {
 			// This account was updated, enqueue re-dereference featured posts + stats.
-			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
+			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest, accountable); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
@@ -489,6 +490,12 @@ func (d *Dereferencer) enrichAccountSafely(
 		}
 	}
 
+	if err == nil && apubAcc != nil && account.IsNew() {
+		// This is a newly discovered account,
+		// enqueue backfill of recent statuses.
+		d.enqueueOutboxBackfill(requestUser, latest)
+	}
+
 	return latest, apubAcc, err
 }
 
@@ -508,6 +515,10 @@ func (d *Dereferencer) enrichAccount(
 		return nil, nil, gtserror.Newf("couldn't create transport: %w", err)
 	}
 
+	// Note account location before webfinger,
+	// to check whether it changed at all later.
+	prevURI, prevDomain := account.URI, account.Domain
+
 	if account.Username != "" {
 		// A username was provided so we can attempt a webfinger, this ensures up-to-date accountdomain info.
 		accDomain, accURI, err := d.fingerRemoteAccount(ctx, tsport, account.Username, account.Domain)
@@ -590,16 +601,52 @@ func (d *Dereferencer) enrichAccount(
 	d.startHandshake(requestUser, uri)
 	defer d.stopHandshake(requestUser, uri)
 
+	// HTTP cache validators
+	// of dereferenced account.
+	var etag, lastModified string
+
+	if apubAcc != nil {
+		// Account was provided to us (e.g. via the inbox),
+		// so drop any cached gone response for the URI.
+		d.forgetUnretrievable(requestUser, uri.String())
+	}
+
 	if apubAcc == nil {
+		if !account.IsNew() &&
+			account.URI == prevURI &&
+			account.Domain == prevDomain &&
+			account.URI == uri.String() {
+			// For existing accounts, whose location
+			// webfinger didn't change, only fetch if
+			// account changed since our last fetch.
+			etag = account.FetchedETag
+			lastModified = account.FetchedLastModified
+		}
+
 		// We were not given any (partial) ActivityPub
 		// version of this account as a parameter.
 		// Dereference latest version of the account.
-		rsp, err := tsport.Dereference(ctx, uri)
+		rsp, err := d.dereferenceConditional(ctx, tsport, requestUser, uri, etag, lastModified)
+
+		if gtserror.StatusCode(err) == http.StatusNotModified {
+			// Unchanged since our last
+			// fetch, just mark as fetched.
+			account.FetchedAt = time.Now()
+			if err := d.state.DB.UpdateAccount(ctx, account, "fetched_at"); err != nil {
+				return nil, nil, gtserror.Newf("error updating database: %w", err)
+			}
+			return account, nil, nil
+		}
+
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
 		}
 
+		// Get validators for next fetch.
+		etag = rsp.Header.Get("ETag")
+		lastModified = rsp.Header.Get("Last-Modified")
+
 		// Attempt to resolve ActivityPub acc from response.
 		apubAcc, err = ap.ResolveAccountable(ctx, rsp.Body)
 
@@ -728,6 +775,8 @@ func (d *Dereferencer) enrichAccount(
 	// set and update fetch time.
 	latestAcc.ID = account.ID
 	latestAcc.FetchedAt = time.Now()
+	latestAcc.FetchedETag = etag
+	latestAcc.FetchedLastModified = lastModified
 
 	// Ensure the account's avatar media is populated, passing in existing to check for chages.
 	if err := d.fetchAccountAvatar(ctx, requestUser, account, latestAcc); err != nil {
@@ -1078,7 +1127,31 @@ func (d *Dereferencer) countCollection(
 
 // dereferenceAccountFeatured dereferences an account's featuredCollectionURI (if not empty). For each discovered status, this status will
 // be dereferenced (if necessary) and marked as pinned (if necessary). Then, old pins will be removed if they're not included in new pins.
-func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+// If the given (freshly dereferenced) accountable has no featured collection at all, then all old pins will be removed.
+func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUser string, account *gtsmodel.Account, accountable ap.Accountable) error {
+	// Get previous pinned statuses (we'll need these later).
+	wasPinned, err := d.state.DB.GetAccountPinnedStatuses(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting account pinned statuses: %w", err)
+	}
+
+	if account.FeaturedCollectionURI == "" {
+		if accountable == nil ||
+			accountable.GetTootFeatured() != nil {
+			// We can only be sure the featured collection
+			// was dropped if the actor we got from remote
+			// has no featured property. If it has one we
+			// didn't accept (e.g. different domain), or
+			// no actor was fetched, leave pins be.
+			return nil
+		}
+
+		// Account has no featured collection (anymore),
+		// so just unpin any previously pinned statuses.
+		d.unpinStatuses(ctx, wasPinned, nil)
+		return nil
+	}
+
 	uri, err := url.Parse(account.FeaturedCollectionURI)
 	if err != nil {
 		return err
@@ -1089,12 +1162,6 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 		return err
 	}
 
-	// Get previous pinned statuses (we'll need these later).
-	wasPinned, err := d.state.DB.GetAccountPinnedStatuses(ctx, account.ID)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return gtserror.Newf("error getting account pinned statuses: %w", err)
-	}
-
 	var statusURIs []*url.URL
 
 	for {
@@ -1166,9 +1233,17 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 
 	// Now that we know which statuses are pinned, we should
 	// *unpin* previous pinned statuses that aren't included.
+	d.unpinStatuses(ctx, wasPinned, statusURIs)
+
+	return nil
+}
+
+// unpinStatuses unpins each of the given previously pinned
+// statuses, unless it is included in the given pinned URIs.
+func (d *Dereferencer) unpinStatuses(ctx context.Context, wasPinned []*gtsmodel.Status, pinnedURIs []*url.URL) {
 outerLoop:
 	for _, status := range wasPinned {
-		for _, statusURI := range statusURIs {
+		for _, statusURI := range pinnedURIs {
 			if status.URI == statusURI.String() {
 				// This status is included in most recent
 				// pinned uris. No need to keep checking.
@@ -1184,6 +1259,4 @@ outerLoop:
 			continue
 		}
 	}
-
-	return nil
 }
@@ -20,6 +20,7 @@ package dereferencing_test
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
@@ -28,6 +29,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -230,6 +233,72 @@ func (suite *AccountTestSuite) TestDereferenceRemoteAccountWithNonMatchingURI()
 	suite.Nil(fetchedAccount)
 }
 
+// refreshPinned pins a status of remote_account_1, refreshes
+// the account using its AS representation with the featured
+// property set to given URI (or none if nil), runs queued
+// dereference jobs, then returns whether still pinned.
+func (suite *AccountTestSuite) refreshPinned(featured *url.URL) bool {
+	ctx := context.Background()
+
+	status := testrig.NewTestStatuses()["remote_account_1_status_1"]
+	status.PinnedAt = time.Now()
+	if err := suite.db.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["remote_account_1"]
+
+	accountable, err := typeutils.NewConverter(&suite.state).AccountToAS(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if featured == nil {
+		accountable.SetTootFeatured(nil)
+	} else {
+		ap.SetFeatured(accountable, featured)
+	}
+
+	_, _, err = suite.dereferencer.RefreshAccount(ctx,
+		suite.testAccounts["local_account_1"].Username,
+		account,
+		accountable,
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Run enqueued jobs, i.e. featured deref.
+	for {
+		fn, ok := suite.state.Workers.Dereference.Queue.Pop()
+		if !ok {
+			break
+		}
+		fn(ctx)
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return !dbStatus.PinnedAt.IsZero()
+}
+
+func (suite *AccountTestSuite) TestRefreshAccountFeaturedDropped() {
+	// Remote actor no longer has a featured
+	// collection, so pins should be removed.
+	suite.False(suite.refreshPinned(nil))
+}
+
+func (suite *AccountTestSuite) TestRefreshAccountFeaturedUntrusted() {
+	// Remote actor has a featured collection we
+	// don't accept (different domain), so we can't
+	// tell whether the status is still pinned.
+	suite.True(suite.refreshPinned(testrig.URLMustParse("http://example.org/users/foss_satan/collections/featured")))
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -18,11 +18,14 @@
 package dereferencing
 
 import (
+	"context"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -103,6 +106,17 @@ type Dereferencer struct {
 	// form of the data as we currently see it.
 	handshakes   map[string][]*url.URL
 	handshakesMu sync.Mutex
+
+	// media refetches currently queued, by
+	// media ID, and the number per remote host.
+	// this is used to throttle async recaching.
+	mediaRefetches    map[string]struct{}
+	mediaRefetchHosts map[string]int
+	mediaRefetchesMu  sync.Mutex
+
+	// outboxBackfills limits the no. account
+	// outbox backfills running at once.
+	outboxBackfills outboxBackfills
 }
 
 // NewDereferencer returns a Dereferencer initialized with the given parameters.
@@ -121,5 +135,79 @@ func NewDereferencer(
 		visibility:          visFilter,
 		derefEmojis:         make(map[string]*media.ProcessingEmoji),
 		handshakes:          make(map[string][]*url.URL),
+		mediaRefetches:      make(map[string]struct{}),
+		mediaRefetchHosts:   make(map[string]int),
+	}
+}
+
+// dereference dereferences the remote uri using given transport, returning
+// early with an error if uri was recently found to be gone (i.e. 404 or 410),
+// and caching that result for a while if the remote now says it is gone.
+// This prevents repeated timeline / thread loads hammering dead URIs.
+func (d *Dereferencer) dereference(
+	ctx context.Context,
+	tsport transport.Transport,
+	requestUser string,
+	uri *url.URL,
+) (*http.Response, error) {
+	return d.dereferenceConditional(ctx, tsport, requestUser, uri, "", "")
+}
+
+// dereferenceConditional is dereference(), but performing a
+// conditional request with given ETag and / or Last-Modified
+// values, where set. See transport.DereferenceConditional().
+func (d *Dereferencer) dereferenceConditional(
+	ctx context.Context,
+	tsport transport.Transport,
+	requestUser string,
+	uri *url.URL,
+	etag string,
+	lastModified string,
+) (*http.Response, error) {
+	uriStr := uri.String()
+
+	for _, key := range []string{
+		uriStr,
+		unretrievableKey(requestUser, uriStr),
+	} {
+		if code, ok := d.state.Caches.Unretrievable.Get(key); ok {
+			err := gtserror.Newf("%s recently responded %d, not retrying", uriStr, code)
+			return nil, gtserror.WithStatusCode(err, code)
+		}
 	}
+
+	rsp, err := tsport.DereferenceConditional(ctx, uri, etag, lastModified)
+	if err != nil {
+		switch code := gtserror.StatusCode(err); code {
+		case http.StatusGone:
+			// Gone is a definitive answer
+			// for everyone, cache by URI.
+			d.state.Caches.Unretrievable.Set(uriStr, code)
+
+		case http.StatusNotFound:
+			// Remotes may 404 objects that the signing
+			// user isn't permitted to see, so only
+			// cache this for the requesting user.
+			key := unretrievableKey(requestUser, uriStr)
+			d.state.Caches.Unretrievable.Set(key, code)
+		}
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// forgetUnretrievable drops any cached gone responses for uri,
+// e.g. when it has since been delivered to us via the inbox.
+func (d *Dereferencer) forgetUnretrievable(requestUser string, uriStr string) {
+	d.state.Caches.Unretrievable.InvalidateAll(
+		uriStr,
+		unretrievableKey(requestUser, uriStr),
+	)
+}
+
+// unretrievableKey returns the Unretrievable
+// cache key for uri as fetched by requestUser.
+func unretrievableKey(requestUser string, uriStr string) string {
+	return requestUser + " " + uriStr
 }
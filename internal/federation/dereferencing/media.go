@@ -24,6 +24,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
@@ -141,8 +142,30 @@ func (d *Dereferencer) RefreshMedia(
 		force = true
 	}
 
+	// Focal point changes don't need the media
+	// recaching, so just set them on the model
+	// (which gets stored on recache, otherwise
+	// is updated in the database here).
+	var focusChanged bool
+	if info.FocusX != nil && *info.FocusX != media.FileMeta.Focus.X {
+		media.FileMeta.Focus.X = *info.FocusX
+		focusChanged = true
+	}
+	if info.FocusY != nil && *info.FocusY != media.FileMeta.Focus.Y {
+		media.FileMeta.Focus.Y = *info.FocusY
+		focusChanged = true
+	}
+
 	// Check if needs updating.
 	if !force && *media.Cached {
+		if focusChanged {
+			if err := d.state.DB.UpdateAttachment(ctx, media,
+				"focus_x",
+				"focus_y",
+			); err != nil {
+				return nil, gtserror.Newf("error updating media focus: %w", err)
+			}
+		}
 		return media, nil
 	}
 
@@ -183,6 +206,80 @@ func (d *Dereferencer) RefreshMedia(
 	return media, err
 }
 
+// maxHostMediaRefetches is the maximum number of
+// async media refetches that may be queued (or in
+// progress) at any one time for a single remote host.
+const maxHostMediaRefetches = 4
+
+// RefreshMediaAsync enqueues the given media for an
+// asynchronous recache, if it's remote media that has
+// been uncached (e.g. pruned by the media cleaner).
+//
+// This is throttled per-host, and deduplicated per-media,
+// so the media may not be enqueued if too many refetches
+// are already queued for its host. In that case, it's
+// expected this will be called again when next needed.
+func (d *Dereferencer) RefreshMediaAsync(
+	ctx context.Context,
+	requestUser string,
+	attach *gtsmodel.MediaAttachment,
+) {
+	// Only refetch remote media which was uncached,
+	// not media of an unknown type (e.g. unsupported,
+	// which we never cached, or previously failed).
+	if attach.IsLocal() || *attach.Cached ||
+		attach.Type == gtsmodel.FileTypeUnknown {
+		return
+	}
+
+	// Parse the remote URL host.
+	url, err := url.Parse(attach.RemoteURL)
+	if err != nil {
+		log.Errorf(ctx, "invalid media remote url %q: %v", attach.RemoteURL, err)
+		return
+	}
+	host := url.Host
+
+	d.mediaRefetchesMu.Lock()
+	defer d.mediaRefetchesMu.Unlock()
+
+	if _, ok := d.mediaRefetches[attach.ID]; ok {
+		// Already queued.
+		return
+	}
+
+	if d.mediaRefetchHosts[host] >= maxHostMediaRefetches {
+		// Host is busy.
+		return
+	}
+
+	// Mark refetch as queued.
+	d.mediaRefetches[attach.ID] = struct{}{}
+	d.mediaRefetchHosts[host]++
+
+	// Enqueue a worker function to recache this media async.
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		defer func() {
+			d.mediaRefetchesMu.Lock()
+			delete(d.mediaRefetches, attach.ID)
+			d.mediaRefetchHosts[host]--
+			if d.mediaRefetchHosts[host] <= 0 {
+				delete(d.mediaRefetchHosts, host)
+			}
+			d.mediaRefetchesMu.Unlock()
+		}()
+
+		if _, err := d.RefreshMedia(ctx,
+			requestUser,
+			attach,
+			media.AdditionalMediaInfo{},
+			false,
+		); err != nil {
+			log.Errorf(ctx, "error refetching media: %v", err)
+		}
+	})
+}
+
 // updateAttachment handles the case of an existing media attachment
 // that *may* have changes or need recaching. it checks for changed
 // fields, updating in the database if so, and recaches uncached media.
@@ -203,6 +300,8 @@ func (d *Dereferencer) updateAttachment(
 		info.Description = &attach.Description
 		info.Blurhash = &attach.Blurhash
 		info.RemoteURL = &attach.RemoteURL
+		info.FocusX = &attach.FileMeta.Focus.X
+		info.FocusY = &attach.FileMeta.Focus.Y
 	}
 
 	// Ensure media is cached.
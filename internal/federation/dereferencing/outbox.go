@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxOutboxBackfills is the maximum number of account
+// outbox backfills permitted to run at any one time, to
+// prevent a burst of newly discovered accounts from
+// flooding remote instances with status requests.
+const maxOutboxBackfills = 2
+
+// outboxBackfills queues account outbox backfills
+// such that at most maxOutboxBackfills are pushed
+// to the worker queue (i.e. running) at once.
+type outboxBackfills struct {
+	running int
+	pending []func(context.Context)
+	mutex   sync.Mutex
+}
+
+// enqueue passes fn to push as soon as fewer than maxOutboxBackfills
+// previously enqueued funcs are running, else queues it until then.
+func (b *outboxBackfills) enqueue(push func(func(context.Context)), fn func(context.Context)) {
+	job := func(ctx context.Context) {
+		fn(ctx)
+		b.done(push)
+	}
+
+	b.mutex.Lock()
+	if b.running >= maxOutboxBackfills {
+		b.pending = append(b.pending, job)
+		b.mutex.Unlock()
+		return
+	}
+	b.running++
+	b.mutex.Unlock()
+
+	push(job)
+}
+
+// done marks a running func as finished,
+// passing next pending func (if any) to push.
+func (b *outboxBackfills) done(push func(func(context.Context))) {
+	b.mutex.Lock()
+	if len(b.pending) == 0 {
+		b.running--
+		b.mutex.Unlock()
+		return
+	}
+	next := b.pending[0]
+	b.pending[0] = nil
+	b.pending = b.pending[1:]
+	b.mutex.Unlock()
+
+	push(next)
+}
+
+// enqueueOutboxBackfill enqueues fetching of the most recent statuses from
+// the outbox of a newly discovered remote account, if enabled in config.
+func (d *Dereferencer) enqueueOutboxBackfill(requestUser string, account *gtsmodel.Account) {
+	limit := config.GetInstanceFederationOutboxBackfillCount()
+	if limit <= 0 || account.OutboxURI == "" {
+		return
+	}
+
+	push := d.state.Workers.Dereference.Queue.Push
+	d.outboxBackfills.enqueue(push, func(ctx context.Context) {
+		if err := d.dereferenceAccountOutbox(ctx, requestUser, account, limit); err != nil {
+			log.Errorf(ctx, "error backfilling account outbox: %v", err)
+		}
+	})
+}
+
+// dereferenceAccountOutbox dereferences up to 'limit' of the most
+// recent statuses created by account, from the first page of its outbox.
+func (d *Dereferencer) dereferenceAccountOutbox(ctx context.Context, requestUser string, account *gtsmodel.Account, limit int) error {
+	uri, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("invalid outbox uri %q: %w", account.OutboxURI, err)
+	}
+
+	collect, err := d.dereferenceCollection(ctx, requestUser, uri)
+	if err != nil {
+		return err
+	}
+
+	// Outbox items may be held directly in the
+	// collection, but are usually found in the
+	// first page of the collection, either
+	// embedded in the collection or by IRI.
+	var items interface{ NextItem() ap.TypeOrIRI } = collect
+	if page := getCollectionFirstPage(collect); page != nil {
+		items = page
+	} else if first := getCollectionFirst(collect); first != nil && first.IsIRI() {
+		page, err := d.dereferenceCollectionPage(ctx, requestUser, first.GetIRI())
+		if err != nil {
+			return err
+		}
+		items = page
+	}
+
+	for count := 0; count < limit; {
+		// Get next outbox item.
+		item := items.NextItem()
+		if item == nil {
+			break
+		}
+
+		// Get status IRI from outbox item.
+		statusIRI := outboxStatusIRI(item)
+		if statusIRI == nil {
+			continue
+		}
+
+		if statusIRI.Host != uri.Host {
+			// If this status doesn't share a host with
+			// the outbox URI, we shouldn't trust it.
+			continue
+		}
+
+		// Update no. statuses.
+		count++
+
+		// Dereference the remote status and store in the database.
+		if _, _, _, err := d.getStatusByURI(ctx, requestUser, statusIRI); err != nil {
+			log.Errorf(ctx, "error dereferencing outbox status %s: %v", statusIRI, err)
+		}
+	}
+
+	return nil
+}
+
+// outboxStatusIRI returns the status IRI of the given outbox
+// item, if a Create activity, else nil. Boosts (Announces), and
+// items only given by IRI (we can't tell their type), are skipped.
+func outboxStatusIRI(item ap.TypeOrIRI) *url.URL {
+	create, ok := item.GetType().(vocab.ActivityStreamsCreate)
+	if !ok {
+		return nil
+	}
+
+	statusIRI, err := ap.ExtractObjectURI(create)
+	if err != nil {
+		return nil
+	}
+
+	return statusIRI
+}
+
+// getCollectionFirst returns the "first" property
+// of the given (wrapped) collection, if any.
+func getCollectionFirst(collect ap.CollectionIterator) vocab.ActivityStreamsFirstProperty {
+	with, ok := collect.(interface {
+		GetActivityStreamsFirst() vocab.ActivityStreamsFirstProperty
+	})
+	if !ok {
+		return nil
+	}
+	return with.GetActivityStreamsFirst()
+}
+
+// getCollectionFirstPage returns the "first" page of the given
+// (wrapped) collection, if embedded in the collection, else nil.
+func getCollectionFirstPage(collect ap.CollectionIterator) ap.CollectionPageIterator {
+	first := getCollectionFirst(collect)
+	if first == nil {
+		return nil
+	}
+
+	// Look for an embedded collection page, wrap and return.
+	if page := first.GetActivityStreamsCollectionPage(); page != nil {
+		return ap.WrapCollectionPage(page)
+	}
+
+	// Look for an embedded ordered collection page, wrap and return.
+	if page := first.GetActivityStreamsOrderedCollectionPage(); page != nil {
+		return ap.WrapOrderedCollectionPage(page)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOutboxBackfillsQueued(t *testing.T) {
+	var (
+		b      outboxBackfills
+		pushed []func(context.Context)
+		ran    []int
+		peak   int
+	)
+
+	push := func(fn func(context.Context)) {
+		pushed = append(pushed, fn)
+		if b.running > peak {
+			peak = b.running
+		}
+	}
+
+	const total = 5
+	for i := 0; i < total; i++ {
+		i := i
+		b.enqueue(push, func(context.Context) {
+			ran = append(ran, i)
+		})
+	}
+
+	// Only the max may be pushed up front,
+	// the rest must wait rather than be dropped.
+	if len(pushed) != maxOutboxBackfills {
+		t.Fatalf("expected %d pushed, got %d", maxOutboxBackfills, len(pushed))
+	}
+
+	// Run pushed funcs in order, each one
+	// finishing should push the next pending.
+	for i := 0; i < len(pushed); i++ {
+		if i+maxOutboxBackfills < total && len(pushed) != i+maxOutboxBackfills {
+			t.Fatalf("expected %d pushed before running %d, got %d", i+maxOutboxBackfills, i, len(pushed))
+		}
+		pushed[i](context.Background())
+	}
+
+	if len(ran) != total {
+		t.Fatalf("expected %d backfills run, got %d", total, len(ran))
+	}
+	for i, n := range ran {
+		if n != i {
+			t.Fatalf("expected backfills run in order, got %v", ran)
+		}
+	}
+	if peak > maxOutboxBackfills {
+		t.Fatalf("expected at most %d running, got %d", maxOutboxBackfills, peak)
+	}
+	if b.running != 0 || len(b.pending) != 0 {
+		t.Fatalf("expected limiter drained, got running=%d pending=%d", b.running, len(b.pending))
+	}
+}
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package dereferencing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+// resolveCollection resolves the given json as a collection.
+func resolveCollection(t *testing.T, data string) ap.CollectionIterator {
+	collect, err := ap.ResolveCollection(context.Background(), io.NopCloser(strings.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return collect
+}
+
+func TestGetCollectionFirstPageEmbedded(t *testing.T) {
+	collect := resolveCollection(t, `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/outbox",
+  "type": "OrderedCollection",
+  "totalItems": 1,
+  "first": {
+    "id": "https://example.org/users/someone/outbox?page=true",
+    "type": "OrderedCollectionPage",
+    "partOf": "https://example.org/users/someone/outbox",
+    "orderedItems": [
+      {
+        "id": "https://example.org/users/someone/statuses/1/activity",
+        "type": "Create",
+        "actor": "https://example.org/users/someone",
+        "object": "https://example.org/users/someone/statuses/1"
+      }
+    ]
+  }
+}`)
+
+	page := getCollectionFirstPage(collect)
+	if page == nil {
+		t.Fatal("expected embedded first page")
+	}
+
+	item := page.NextItem()
+	if item == nil {
+		t.Fatal("expected item in embedded first page")
+	}
+
+	statusIRI := outboxStatusIRI(item)
+	if statusIRI == nil || statusIRI.String() != "https://example.org/users/someone/statuses/1" {
+		t.Fatalf("unexpected status iri %v", statusIRI)
+	}
+}
+
+func TestGetCollectionFirstPageIRI(t *testing.T) {
+	collect := resolveCollection(t, `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/outbox",
+  "type": "OrderedCollection",
+  "totalItems": 1,
+  "first": "https://example.org/users/someone/outbox?page=true"
+}`)
+
+	if page := getCollectionFirstPage(collect); page != nil {
+		t.Fatal("expected no embedded first page")
+	}
+
+	first := getCollectionFirst(collect)
+	if first == nil || !first.IsIRI() {
+		t.Fatal("expected first page iri")
+	}
+}
@@ -20,6 +20,7 @@ package dereferencing
 import (
 	"context"
 	"errors"
+	"net/http"
 	"net/url"
 	"slices"
 	"time"
@@ -389,14 +390,46 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetUnretrievable(err)
 	}
 
+	// HTTP cache validators
+	// of dereferenced status.
+	var etag, lastModified string
+
+	if apubStatus != nil {
+		// Status was provided to us (e.g. via the inbox),
+		// so drop any cached gone response for the URI.
+		d.forgetUnretrievable(requestUser, uri.String())
+	}
+
 	if apubStatus == nil {
+		if status.ID != "" {
+			// For existing statuses, only fetch
+			// if changed since our last fetch.
+			etag = status.FetchedETag
+			lastModified = status.FetchedLastModified
+		}
+
 		// Dereference latest version of the status.
-		rsp, err := tsport.Dereference(ctx, uri)
+		rsp, err := d.dereferenceConditional(ctx, tsport, requestUser, uri, etag, lastModified)
+
+		if gtserror.StatusCode(err) == http.StatusNotModified {
+			// Unchanged since our last
+			// fetch, just mark as fetched.
+			status.FetchedAt = time.Now()
+			if err := d.state.DB.UpdateStatus(ctx, status, "fetched_at"); err != nil {
+				return nil, nil, gtserror.Newf("error updating database: %w", err)
+			}
+			return status, nil, nil
+		}
+
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
 		}
 
+		// Get validators for next fetch.
+		etag = rsp.Header.Get("ETag")
+		lastModified = rsp.Header.Get("Last-Modified")
+
 		// Attempt to resolve ActivityPub status from response.
 		apubStatus, err = ap.ResolveStatusable(ctx, rsp.Body)
 
@@ -499,6 +532,8 @@ func (d *Dereferencer) enrichStatus(
 	// Carry-over values and set fetch time.
 	latestStatus.UpdatedAt = status.UpdatedAt
 	latestStatus.FetchedAt = time.Now()
+	latestStatus.FetchedETag = etag
+	latestStatus.FetchedLastModified = lastModified
 	latestStatus.Local = status.Local
 
 	// Check if this is a permitted status we should accept.
@@ -974,6 +1009,8 @@ func (d *Dereferencer) fetchStatusAttachments(
 				RemoteURL:   &placeholder.RemoteURL,
 				Description: &placeholder.Description,
 				Blurhash:    &placeholder.Blurhash,
+				FocusX:      &placeholder.FileMeta.Focus.X,
+				FocusY:      &placeholder.FileMeta.Focus.Y,
 			},
 		)
 		if err != nil {
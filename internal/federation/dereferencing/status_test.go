@@ -20,12 +20,16 @@ package dereferencing_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -241,6 +245,122 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithNonMatchingURI() {
 	suite.Nil(fetchedStatus)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusNotFoundCached() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	const remoteURI = "https://turnip.farm/users/turniplover6969/statuses/this-status-does-not-exist"
+
+	// First attempt should hit the remote and 404.
+	fetchedStatus, _, err := suite.dereferencer.GetStatusByURI(
+		context.Background(),
+		fetchingAccount.Username,
+		testrig.URLMustParse(remoteURI),
+	)
+	suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+	suite.Nil(fetchedStatus)
+
+	// This negative result should now be cached,
+	// but only for the account that fetched it.
+	code, ok := suite.state.Caches.Unretrievable.Get(fetchingAccount.Username + " " + remoteURI)
+	suite.True(ok)
+	suite.Equal(http.StatusNotFound, code)
+	suite.False(suite.state.Caches.Unretrievable.Has(remoteURI))
+
+	// Second attempt should not hit the remote.
+	fetchedStatus, _, err = suite.dereferencer.GetStatusByURI(
+		context.Background(),
+		fetchingAccount.Username,
+		testrig.URLMustParse(remoteURI),
+	)
+	suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+	suite.ErrorContains(err, "recently responded 404")
+	suite.Nil(fetchedStatus)
+}
+
+func (suite *StatusTestSuite) TestDereferenceStatusNotFoundOtherUser() {
+	fetchingAccount1 := suite.testAccounts["local_account_1"]
+	fetchingAccount2 := suite.testAccounts["local_account_2"]
+
+	const remoteURI = "https://turnip.farm/users/turniplover6969/statuses/this-status-does-not-exist"
+
+	// First account hits the remote and 404s.
+	_, _, err := suite.dereferencer.GetStatusByURI(
+		context.Background(),
+		fetchingAccount1.Username,
+		testrig.URLMustParse(remoteURI),
+	)
+	suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+
+	// The second account may be permitted to see
+	// it, so it should still hit the remote itself.
+	_, _, err = suite.dereferencer.GetStatusByURI(
+		context.Background(),
+		fetchingAccount2.Username,
+		testrig.URLMustParse(remoteURI),
+	)
+	suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+	suite.NotContains(err.Error(), "recently responded 404")
+}
+
+func (suite *StatusTestSuite) TestDereferenceStatusNotFoundDroppedOnArrival() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	const remoteURI = "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+
+	// Pretend the remote previously told us it was gone.
+	suite.state.Caches.Unretrievable.Set(remoteURI, http.StatusGone)
+	suite.state.Caches.Unretrievable.Set(fetchingAccount.Username+" "+remoteURI, http.StatusNotFound)
+
+	// The status now arrives via the inbox.
+	status, _, err := suite.dereferencer.RefreshStatus(
+		context.Background(),
+		fetchingAccount.Username,
+		&gtsmodel.Status{URI: remoteURI},
+		suite.client.TestRemoteStatuses[remoteURI],
+		nil,
+	)
+	suite.NoError(err)
+	suite.NotNil(status)
+
+	// Any cached gone responses should be dropped.
+	suite.False(suite.state.Caches.Unretrievable.Has(remoteURI))
+	suite.False(suite.state.Caches.Unretrievable.Has(fetchingAccount.Username + " " + remoteURI))
+}
+
+func (suite *StatusTestSuite) TestRefreshStatusNotModified() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+	status, _, err := suite.dereferencer.GetStatusByURI(context.Background(), fetchingAccount.Username, statusURL)
+	suite.NoError(err)
+
+	// The remote's ETag should be stored.
+	suite.NotEmpty(status.FetchedETag)
+	fetchedAt := status.FetchedAt
+
+	// Force a refresh of the status, which the
+	// remote should reply to as not modified.
+	refreshed, statusable, err := suite.dereferencer.RefreshStatus(
+		context.Background(),
+		fetchingAccount.Username,
+		status,
+		nil,
+		util.Ptr(dereferencing.FreshnessWindow(0)),
+	)
+	suite.NoError(err)
+
+	// No new AP model was dereferenced,
+	// but the status was marked fetched.
+	suite.Nil(statusable)
+	suite.Equal(status.ID, refreshed.ID)
+	suite.True(refreshed.FetchedAt.After(fetchedAt))
+
+	dbStatus, err := suite.db.GetStatusByURI(context.Background(), status.URI)
+	suite.NoError(err)
+	suite.Equal(refreshed.FetchedAt.Unix(), dbStatus.FetchedAt.Unix())
+	suite.Equal(status.FetchedETag, dbStatus.FetchedETag)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
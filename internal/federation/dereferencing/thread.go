@@ -219,6 +219,7 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 }
 
 // DereferenceStatusDescendents iterates downwards from the given status, using its replies, to ensure that as many children statuses as possible are dereferenced.
+// This is limited in depth and total no. replies dereferenced by the instance-federation-replies-max-{depth,count} configuration.
 func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, username string, statusIRI *url.URL, parent ap.Statusable) error {
 	statusIRIStr := statusIRI.String()
 
@@ -229,12 +230,24 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 			{"status", statusIRIStr},
 		}...)
 
+	// Get configured limits on replies backfill.
+	maxDepth := config.GetInstanceFederationRepliesMaxDepth()
+	maxCount := config.GetInstanceFederationRepliesMaxCount()
+	if maxCount <= 0 {
+		// Replies backfill disabled.
+		return nil
+	}
+
 	// Log function start
 	l.Trace("beginning")
 
 	// OUR instance hostname.
 	localhost := config.GetHost()
 
+	// Number of remote replies
+	// dereferenced so far.
+	var count int
+
 	// Keep track of already dereferenced collection
 	// pages for this thread to prevent recursion.
 	derefdPages := make(map[string]struct{}, 10)
@@ -321,6 +334,14 @@ stackLoop:
 					continue itemLoop
 				}
 
+				if count >= maxCount {
+					l.Debugf("reached max (%d) replies to dereference", maxCount)
+					return nil
+				}
+
+				// Update no. dereferenced.
+				count++
+
 				// Dereference the remote status and store in the database.
 				// getStatusByURI guards against the following conditions:
 				//   - refetching recently fetched statuses (recursion!)
@@ -341,6 +362,13 @@ stackLoop:
 					continue itemLoop
 				}
 
+				// Current frame depth in the thread is stack
+				// size + 1, as current frame has been popped.
+				if len(stack)+1 >= maxDepth {
+					l.Debugf("reached max (%d) replies depth at %s", maxDepth, itemIRI)
+					continue itemLoop
+				}
+
 				// Extract any attached collection + ID URI from status.
 				page, pageURI := getAttachedStatusCollectionPage(statusable)
 				if page == nil {
@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ThreadTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// threadStatusURI returns the URI of
+// the test thread status with given name.
+func threadStatusURI(name string) string {
+	return "https://unknown-instance.com/users/brand_new_person/statuses/" + name
+}
+
+// putThreadStatus adds a status with given name to
+// the mock remote, in reply to the status inReplyTo
+// (if set), with the given named statuses as replies.
+func (suite *ThreadTestSuite) putThreadStatus(name string, inReplyTo string, replies ...string) vocab.ActivityStreamsNote {
+	uri := threadStatusURI(name)
+	note := testrig.NewAPNote(
+		testrig.URLMustParse(uri),
+		testrig.URLMustParse("https://unknown-instance.com/@brand_new_person/"+name),
+		testrig.TimeMustParse("2022-07-13T12:13:12+02:00"),
+		"status "+name,
+		"",
+		testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+		[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+		nil,
+		false,
+		nil,
+		nil,
+		nil,
+	)
+
+	if inReplyTo != "" {
+		inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
+		inReplyToProp.AppendIRI(testrig.URLMustParse(threadStatusURI(inReplyTo)))
+		note.SetActivityStreamsInReplyTo(inReplyToProp)
+	}
+
+	// Attach replies collection with all
+	// reply IRIs in the first (only) page.
+	items := streams.NewActivityStreamsItemsProperty()
+	for _, reply := range replies {
+		items.AppendIRI(testrig.URLMustParse(threadStatusURI(reply)))
+	}
+	page := streams.NewActivityStreamsCollectionPage()
+	page.SetActivityStreamsItems(items)
+	first := streams.NewActivityStreamsFirstProperty()
+	first.SetActivityStreamsCollectionPage(page)
+	collection := streams.NewActivityStreamsCollection()
+	collection.SetActivityStreamsFirst(first)
+	repliesProp := streams.NewActivityStreamsRepliesProperty()
+	repliesProp.SetActivityStreamsCollection(collection)
+	note.SetActivityStreamsReplies(repliesProp)
+
+	suite.client.TestRemoteStatuses[uri] = note
+	return note
+}
+
+// statusStored returns whether the named
+// test thread status is in the database.
+func (suite *ThreadTestSuite) statusStored(name string) bool {
+	_, err := suite.db.GetStatusByURI(context.Background(), threadStatusURI(name))
+	if errors.Is(err, db.ErrNoEntries) {
+		return false
+	} else if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return true
+}
+
+func (suite *ThreadTestSuite) TestDereferenceStatusDescendantsMaxDepth() {
+	config.SetInstanceFederationRepliesMaxDepth(3)
+
+	// A single chain of replies, 5 deep.
+	root := suite.putThreadStatus("root", "", "reply1")
+	suite.putThreadStatus("reply1", "root", "reply2")
+	suite.putThreadStatus("reply2", "reply1", "reply3")
+	suite.putThreadStatus("reply3", "reply2", "reply4")
+	suite.putThreadStatus("reply4", "reply3", "reply5")
+	suite.putThreadStatus("reply5", "reply4")
+
+	err := suite.dereferencer.DereferenceStatusDescendants(
+		context.Background(),
+		suite.testAccounts["local_account_1"].Username,
+		testrig.URLMustParse(threadStatusURI("root")),
+		root,
+	)
+	suite.NoError(err)
+
+	// Replies up to max depth should have been
+	// dereferenced, but not their replies.
+	suite.True(suite.statusStored("reply1"))
+	suite.True(suite.statusStored("reply2"))
+	suite.True(suite.statusStored("reply3"))
+	suite.False(suite.statusStored("reply4"))
+	suite.False(suite.statusStored("reply5"))
+}
+
+func (suite *ThreadTestSuite) TestDereferenceStatusDescendantsMaxCount() {
+	config.SetInstanceFederationRepliesMaxCount(3)
+
+	// Root with 3 direct replies, the first of
+	// which has further replies of its own.
+	root := suite.putThreadStatus("root", "", "reply1", "reply2", "reply3")
+	suite.putThreadStatus("reply1", "root", "reply1_1", "reply1_2")
+	suite.putThreadStatus("reply1_1", "reply1")
+	suite.putThreadStatus("reply1_2", "reply1")
+	suite.putThreadStatus("reply2", "root")
+	suite.putThreadStatus("reply3", "root")
+
+	err := suite.dereferencer.DereferenceStatusDescendants(
+		context.Background(),
+		suite.testAccounts["local_account_1"].Username,
+		testrig.URLMustParse(threadStatusURI("root")),
+		root,
+	)
+	suite.NoError(err)
+
+	// Walk is depth first, so only the first
+	// 3 replies in that order are dereferenced.
+	var stored []string
+	for _, name := range []string{
+		"reply1",
+		"reply1_1",
+		"reply1_2",
+		"reply2",
+		"reply3",
+	} {
+		if suite.statusStored(name) {
+			stored = append(stored, name)
+		}
+	}
+	suite.Equal([]string{"reply1", "reply1_1", "reply1_2"}, stored)
+}
+
+func TestThreadTestSuite(t *testing.T) {
+	suite.Run(t, new(ThreadTestSuite))
+}
@@ -30,7 +30,9 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -40,16 +42,18 @@ import (
 type federatingActor struct {
 	sideEffectActor pub.DelegateActor
 	wrapped         pub.FederatingActor
+	caches          *cache.Caches
 }
 
 // newFederatingActor returns a federatingActor.
-func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock) pub.FederatingActor {
+func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock, caches *cache.Caches) pub.FederatingActor {
 	sideEffectActor := pub.NewSideEffectActor(c, s2s, nil, db, clock)
 	sideEffectActor.Serialize = ap.Serialize // hook in our own custom Serialize function
 
 	return &federatingActor{
 		sideEffectActor: sideEffectActor,
 		wrapped:         pub.NewCustomActor(sideEffectActor, false, true, clock),
+		caches:          caches,
 	}
 }
 
@@ -63,6 +67,8 @@ func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub
 //     provide more helpful messages to remote callers.
 //   - Return code 202 instead of 200 on successful POST, to reflect
 //     that we process most side effects asynchronously.
+//   - Drop activities already received at the same inbox, e.g.
+//     replayed, or duplicated by relays, without processing them.
 func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
 	l := log.WithContext(ctx).
 		WithFields([]kv.Field{
@@ -164,12 +170,26 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return u
 	}()
 
+	// Mark activity as received at this inbox from this signer.
+	// If it already has been recently, then it's a replay or a
+	// duplicate, so accept it with a 202, but don't bother
+	// processing the side effects again.
+	activityKey, first := f.markActivity(ctx, inboxID, activity)
+	if !first {
+		l.Debugf("dropping duplicate activity %s", activityKey)
+		return true, nil
+	}
+
 	// At this point we have everything we need, and have verified that
 	// the POST request is authentic (properly signed) and authorized
 	// (permitted to interact with the target inbox).
 	//
 	// Post the activity to the Actor's inbox and trigger side effects .
 	if err := f.sideEffectActor.PostInbox(ctx, inboxID, activity); err != nil {
+		// Activity wasn't processed, so unmark
+		// it, allowing the sender to try again.
+		f.unmarkActivity(activityKey)
+
 		// Special case: We know it is a bad request if the object or target
 		// props needed to be populated, or we failed parsing activity details.
 		// Send the rejection to the peer.
@@ -225,6 +245,36 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	return true, nil
 }
 
+// markActivity marks the activity as received at inbox from the
+// authenticated requester, returning its key in the inbox activities
+// cache, and whether this is the first time it's been received there
+// recently. Activities without an ID (i.e. transient activities) are
+// never deduplicated.
+//
+// The requester is part of the key so that one actor can't get
+// another's activity dropped by sending something with its
+// (often predictable) ID to the inbox first.
+func (f *federatingActor) markActivity(ctx context.Context, inboxID *url.URL, activity pub.Activity) (string, bool) {
+	activityID := ap.GetJSONLDId(activity)
+	if activityID == nil {
+		return "", true
+	}
+	var requester string
+	if acct := gtscontext.RequestingAccount(ctx); acct != nil {
+		requester = acct.URI
+	}
+	key := inboxID.String() + " " + requester + " " + activityID.String()
+	return key, f.caches.InboxActivities.Add(key, struct{}{})
+}
+
+// unmarkActivity drops the activity with key from
+// the inbox activities cache. See markActivity().
+func (f *federatingActor) unmarkActivity(key string) {
+	if key != "" {
+		f.caches.InboxActivities.Invalidate(key)
+	}
+}
+
 /*
 	Functions below are just lightly wrapped versions
 	of the original go-fed federatingActor functions.
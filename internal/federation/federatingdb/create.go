@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Create adds a new entry to the database which must be able to be
@@ -272,7 +273,7 @@ func (f *federatingDB) createPollOptionables(
 				return gtserror.Newf("error getting status %s poll votes from database: %w", statusURI, err)
 			}
 
-			if vote != nil {
+			if vote != nil && !util.PtrValueOr(inReplyTo.Poll.VoteChange, false) {
 				log.Warnf(ctx, "%s has already voted in poll %s", requester.URI, statusURI)
 				return nil // this is a useful warning for admins to report to us from logs
 			}
@@ -20,12 +20,15 @@ package federation_test
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"github.com/stretchr/testify/suite"
@@ -293,6 +296,53 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyExpired()
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyRotated() {
+	var (
+		ctx              = context.Background()
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Update remote account to have a different, old key
+	// stored under an old key ID, as though the account
+	// rotated its key since we last (recently) fetched it.
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	remoteAcct := &gtsmodel.Account{}
+	*remoteAcct = *suite.testAccounts["remote_account_1"]
+	remoteAcct.PublicKey = &oldKey.PublicKey
+	remoteAcct.PublicKeyURI = remoteAcct.URI + "#old-key"
+	remoteAcct.FetchedAt = time.Now()
+	if err := suite.state.DB.UpdateAccount(ctx, remoteAcct,
+		"public_key",
+		"public_key_uri",
+		"fetched_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, authed, resp, code := suite.authenticatePostInbox(
+		ctx,
+		receivingAccount,
+		activity,
+	)
+
+	suite.NotNil(gtscontext.RequestingAccount(ctx))
+	suite.True(authed)
+	suite.Equal([]byte{}, resp)
+	suite.Equal(http.StatusOK, code)
+
+	// Stored account should now have the new key.
+	dbAcct, err := suite.state.DB.GetAccountByID(ctx, remoteAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["remote_account_1"].PublicKeyURI, dbAcct.PublicKeyURI)
+	suite.True(suite.testAccounts["remote_account_1"].PublicKey.Equal(dbAcct.PublicKey))
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostGoneWithTombstone() {
 	var (
 		activity         = suite.testActivities["delete_https://somewhere.mysterious/users/rest_in_piss#main-key"]
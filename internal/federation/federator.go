@@ -64,7 +64,7 @@ func NewFederator(
 		mediaManager:        mediaManager,
 		Dereferencer:        dereferencing.NewDereferencer(state, converter, transportController, visFilter, mediaManager),
 	}
-	actor := newFederatingActor(f, f, federatingDB, clock)
+	actor := newFederatingActor(f, f, federatingDB, clock, &state.Caches)
 	f.actor = actor
 	return f
 }
@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Match is a single filter matched against a status.
+type Match struct {
+	// Filter that matched.
+	Filter *gtsmodel.Filter
+
+	// KeywordMatches contains the
+	// filter keywords that matched.
+	KeywordMatches []string
+
+	// StatusMatches contains the
+	// filter status IDs that matched.
+	StatusMatches []string
+}
+
+// Hide returns whether the matched
+// filter should hide the status entirely.
+func (m *Match) Hide() bool {
+	return m.Filter.Action == gtsmodel.FilterActionHide
+}
+
+// MatchStatus matches the given status against all of the provided filters valid
+// in this filter context at the given time, returning details of each that matched.
+//
+// Keyword filters use the prepared regular expression on each FilterKeyword, which
+// is compiled once on load from the database and kept with the account's cached filters.
+func MatchStatus(
+	status *gtsmodel.Status,
+	filters []*gtsmodel.Filter,
+	filterContext FilterContext,
+	now time.Time,
+) []Match {
+	if filterContext == FilterContextNone || len(filters) == 0 {
+		return nil
+	}
+
+	var (
+		matches []Match
+		fields  []string
+	)
+
+	for _, filter := range filters {
+		if !AppliesInContext(filter, filterContext) {
+			// Filter doesn't apply to this context.
+			continue
+		}
+
+		if filter.Expired(now) {
+			continue
+		}
+
+		if fields == nil && len(filter.Keywords) > 0 {
+			// Only gather text when we actually need it.
+			fields = TextFields(status)
+		}
+
+		// List all matching keywords.
+		keywordMatches := make([]string, 0, len(filter.Keywords))
+		for _, filterKeyword := range filter.Keywords {
+			if filterKeyword.Regexp == nil {
+				// Not prepared, can't match.
+				continue
+			}
+
+			for _, field := range fields {
+				if filterKeyword.Regexp.MatchString(field) {
+					keywordMatches = append(keywordMatches, filterKeyword.Keyword)
+					break
+				}
+			}
+		}
+
+		// A status has only one ID. Not clear why this is a list in the Mastodon API.
+		statusMatches := make([]string, 0, 1)
+		for _, filterStatus := range filter.Statuses {
+			if status.ID == filterStatus.StatusID {
+				statusMatches = append(statusMatches, filterStatus.StatusID)
+				break
+			}
+		}
+
+		if len(keywordMatches) > 0 || len(statusMatches) > 0 {
+			matches = append(matches, Match{
+				Filter:         filter,
+				KeywordMatches: keywordMatches,
+				StatusMatches:  statusMatches,
+			})
+		}
+	}
+
+	return matches
+}
+
+// TextFields returns all text from a status that we might want to filter on:
+// - content
+// - content warning
+// - media descriptions
+// - poll options
+func TextFields(status *gtsmodel.Status) []string {
+	fieldCount := 2 + len(status.Attachments)
+	if status.Poll != nil {
+		fieldCount += len(status.Poll.Options)
+	}
+	fields := make([]string, 0, fieldCount)
+
+	if status.Content != "" {
+		fields = append(fields, text.SanitizeToPlaintext(status.Content))
+	}
+	if status.ContentWarning != "" {
+		fields = append(fields, status.ContentWarning)
+	}
+	for _, attachment := range status.Attachments {
+		if attachment.Description != "" {
+			fields = append(fields, attachment.Description)
+		}
+	}
+	if status.Poll != nil {
+		for _, option := range status.Poll.Options {
+			if option != "" {
+				fields = append(fields, option)
+			}
+		}
+	}
+
+	return fields
+}
+
+// AppliesInContext returns whether a given filter applies in a given context.
+func AppliesInContext(filter *gtsmodel.Filter, filterContext FilterContext) bool {
+	switch filterContext {
+	case FilterContextHome:
+		return util.PtrValueOr(filter.ContextHome, false)
+	case FilterContextNotifications:
+		return util.PtrValueOr(filter.ContextNotifications, false)
+	case FilterContextPublic:
+		return util.PtrValueOr(filter.ContextPublic, false)
+	case FilterContextThread:
+		return util.PtrValueOr(filter.ContextThread, false)
+	case FilterContextAccount:
+		return util.PtrValueOr(filter.ContextAccount, false)
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type MatchTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MatchTestSuite) keywordFilter(keyword string, wholeWord bool, regex bool) *gtsmodel.Filter {
+	filterKeyword := &gtsmodel.FilterKeyword{
+		Keyword:   keyword,
+		WholeWord: &wholeWord,
+		Regex:     &regex,
+	}
+	if err := filterKeyword.Compile(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &gtsmodel.Filter{
+		Action:      gtsmodel.FilterActionWarn,
+		Keywords:    []*gtsmodel.FilterKeyword{filterKeyword},
+		ContextHome: util.Ptr(true),
+	}
+}
+
+func (suite *MatchTestSuite) matches(filter *gtsmodel.Filter, content string) bool {
+	status := &gtsmodel.Status{Content: "<p>" + content + "</p>"}
+	matches := statusfilter.MatchStatus(status, []*gtsmodel.Filter{filter}, statusfilter.FilterContextHome, time.Now())
+	return len(matches) > 0
+}
+
+func (suite *MatchTestSuite) TestMatchKeyword() {
+	filter := suite.keywordFilter("fnord", false, false)
+	suite.True(suite.matches(filter, "i saw a FNORD today"))
+	suite.True(suite.matches(filter, "fnords everywhere"))
+	suite.False(suite.matches(filter, "nothing to see here"))
+}
+
+func (suite *MatchTestSuite) TestMatchWholeWord() {
+	filter := suite.keywordFilter("fnord", true, false)
+	suite.True(suite.matches(filter, "i saw a fnord today"))
+	suite.False(suite.matches(filter, "fnords everywhere"))
+}
+
+func (suite *MatchTestSuite) TestMatchLiteralIsQuoted() {
+	filter := suite.keywordFilter("f.ord", false, false)
+	suite.True(suite.matches(filter, "what is an f.ord?"))
+	suite.False(suite.matches(filter, "fnord"))
+}
+
+func (suite *MatchTestSuite) TestMatchRegex() {
+	filter := suite.keywordFilter("colou?r", false, true)
+	suite.True(suite.matches(filter, "my favourite COLOR"))
+	suite.True(suite.matches(filter, "my favourite colour"))
+	suite.False(suite.matches(filter, "my favourite flavour"))
+}
+
+func (suite *MatchTestSuite) TestMatchRegexWholeWord() {
+	// Word breaks should apply to every alternative.
+	filter := suite.keywordFilter("cat|dog", true, true)
+	suite.True(suite.matches(filter, "i have a dog"))
+	suite.False(suite.matches(filter, "concatenate"))
+	suite.False(suite.matches(filter, "hotdogs"))
+}
+
+func (suite *MatchTestSuite) TestMatchContextAndExpiry() {
+	filter := suite.keywordFilter("fnord", false, false)
+	status := &gtsmodel.Status{Content: "fnord"}
+
+	// Filter isn't enabled in the public context.
+	matches := statusfilter.MatchStatus(status, []*gtsmodel.Filter{filter}, statusfilter.FilterContextPublic, time.Now())
+	suite.Empty(matches)
+
+	// Expired filters don't match.
+	filter.ExpiresAt = time.Now().Add(-time.Minute)
+	matches = statusfilter.MatchStatus(status, []*gtsmodel.Filter{filter}, statusfilter.FilterContextHome, time.Now())
+	suite.Empty(matches)
+}
+
+func (suite *MatchTestSuite) TestMatchStatus() {
+	filter := &gtsmodel.Filter{
+		Action:      gtsmodel.FilterActionHide,
+		Statuses:    []*gtsmodel.FilterStatus{{StatusID: "01HEWV37MHV8BAC8ANFGVRRM5D"}},
+		ContextHome: util.Ptr(true),
+	}
+	status := &gtsmodel.Status{ID: "01HEWV37MHV8BAC8ANFGVRRM5D"}
+
+	matches := statusfilter.MatchStatus(status, []*gtsmodel.Filter{filter}, statusfilter.FilterContextHome, time.Now())
+	if suite.Len(matches, 1) {
+		suite.True(matches[0].Hide())
+		suite.Empty(matches[0].KeywordMatches)
+		suite.Equal([]string{status.ID}, matches[0].StatusMatches)
+	}
+}
+
+func TestMatchTestSuite(t *testing.T) {
+	suite.Run(t, new(MatchTestSuite))
+}
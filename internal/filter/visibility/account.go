@@ -22,11 +22,34 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// AccountSilenced checks whether given account is silenced, either
+// by an account silence or by a silence of its domain. Statuses of
+// silenced accounts are kept out of public (and tag) timelines, their
+// follows always need approval, and they can only notify followers.
+func (f *Filter) AccountSilenced(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if account.IsSilenced() {
+		return true, nil
+	}
+
+	if account.IsLocal() {
+		// Can't silence our own domain.
+		return false, nil
+	}
+
+	silenced, err := f.state.DB.IsDomainSilenced(ctx, account.Domain)
+	if err != nil {
+		return false, gtserror.Newf("error checking domain silence: %w", err)
+	}
+
+	return silenced, nil
+}
+
 // AccountVisible will check if given account is visible to requester, accounting for requester with no auth (i.e is nil), suspensions, disabled local users and account blocks.
 func (f *Filter) AccountVisible(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
 	const vtype = cache.VisibilityTypeAccount
@@ -108,6 +131,101 @@ func (f *Filter) isAccountVisibleTo(ctx context.Context, requester *gtsmodel.Acc
 	return true, nil
 }
 
+// prefetchAccountsVisible resolves visibility of all given accounts to requester in a single batched pass, storing results in the visibility cache such that following calls to Filter{}.AccountVisible() for these accounts are cache hits.
+func (f *Filter) prefetchAccountsVisible(ctx context.Context, requester *gtsmodel.Account, accounts []*gtsmodel.Account) error {
+	const vtype = cache.VisibilityTypeAccount
+
+	if requester == nil {
+		// Without auth there are no
+		// relationships to resolve.
+		return nil
+	}
+
+	// Gather accounts whose visibility
+	// to requester is not yet cached.
+	uncached := make(map[string]*gtsmodel.Account, len(accounts))
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		if _, ok := f.state.Caches.Visibility.GetOne("Type,RequesterID,ItemID",
+			vtype,
+			requester.ID,
+			account.ID,
+		); ok {
+			continue
+		}
+
+		uncached[account.ID] = account
+	}
+
+	if len(uncached) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// If requester is not visible, they cannot *see* either.
+	requesterVisible, err := f.isAccountVisible(ctx, requester)
+	if err != nil {
+		return gtserror.Newf("error checking account %s visibility: %w", requester.ID, err)
+	}
+
+	accountIDs := make([]string, 0, len(uncached))
+	for id := range uncached {
+		accountIDs = append(accountIDs, id)
+	}
+
+	// Fetch all blocks between requester and
+	// these accounts in one go, rather than
+	// checking each account in turn.
+	blocks, err := f.state.DB.GetBlocksBetween(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		accountIDs,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting account blocks: %w", err)
+	}
+
+	// Mark the other party of each block.
+	blocked := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		if block.AccountID == requester.ID {
+			blocked[block.TargetAccountID] = struct{}{}
+		} else {
+			blocked[block.AccountID] = struct{}{}
+		}
+	}
+
+	for id, account := range uncached {
+		visible := requesterVisible
+
+		if visible {
+			// Check whether target account is visible to anyone.
+			visible, err = f.isAccountVisible(ctx, account)
+			if err != nil {
+				return gtserror.Newf("error checking account %s visibility: %w", id, err)
+			}
+		}
+
+		if _, ok := blocked[id]; ok {
+			log.Trace(ctx, "block exists between accounts")
+			visible = false
+		}
+
+		// Store the visibility value.
+		f.state.Caches.Visibility.Put(&cache.CachedVisibility{
+			ItemID:      id,
+			RequesterID: requester.ID,
+			Type:        vtype,
+			Value:       visible,
+		})
+	}
+
+	return nil
+}
+
 // isAccountVisible will check if given account should be visible at all, e.g. it may not be if suspended or disabled.
 func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account) (bool, error) {
 	if account.IsLocal() {
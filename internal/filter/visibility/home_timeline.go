@@ -20,6 +20,7 @@ package visibility
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
@@ -200,9 +201,38 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 		return false, nil
 	}
 
+	if status.BoostOfID == "" && !followLanguage(follow, status.Language) {
+		// Owner of this follow only wants to see
+		// posts in certain languages from this account.
+		log.Trace(ctx, "ignoring status in unfollowed language")
+		return false, nil
+	}
+
 	return true, nil
 }
 
+// followLanguage returns whether posts in the given language
+// should be shown according to the follow's languages (if set).
+// Follow languages match on language base, such that following
+// "zh" will match a status with language "zh-Hans".
+func followLanguage(follow *gtsmodel.Follow, lang string) bool {
+	if len(follow.Languages) == 0 || lang == "" {
+		// No languages filter set,
+		// or nothing to filter on.
+		return true
+	}
+
+	base, _, _ := strings.Cut(lang, "-")
+	for _, followLang := range follow.Languages {
+		followBase, _, _ := strings.Cut(followLang, "-")
+		if strings.EqualFold(base, followBase) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (f *Filter) isVisibleConversation(
 	ctx context.Context,
 	owner *gtsmodel.Account,
@@ -87,6 +87,36 @@ func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingBoostedStatusHo
 	suite.False(timelineable)
 }
 
+func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingStatusHomeTimelineableLanguages() {
+	ctx := context.Background()
+
+	testStatus := suite.testStatuses["local_account_2_status_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+
+	for _, test := range []struct {
+		languages    []string
+		timelineable bool
+	}{
+		{[]string{"de", "fr"}, false},
+		{[]string{"de", testStatus.Language}, true},
+		{nil, true},
+	} {
+		// Update follow to indicate that local_account_1
+		// only wants to see posts in the given languages.
+		follow := &gtsmodel.Follow{}
+		*follow = *suite.testFollows["local_account_1_local_account_2"]
+		follow.Languages = test.languages
+
+		if err := suite.db.UpdateFollow(ctx, follow, "languages"); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		timelineable, err := suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+		suite.NoError(err)
+		suite.Equal(test.timelineable, timelineable, "languages: %v", test.languages)
+	}
+}
+
 func (suite *StatusStatusHomeTimelineableTestSuite) TestNotFollowingStatusHomeTimelineable() {
 	testStatus := suite.testStatuses["remote_account_1_status_1"]
 	testAccount := suite.testAccounts["local_account_1"]
@@ -28,7 +28,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
-// StatusHomeTimelineable checks if given status should be included on requester's public timeline. Primarily relying on status visibility to requester and the AP visibility setting, and ignoring conversation threads.
+// StatusesPublicTimelineable calls StatusPublicTimelineable for each status in the statuses slice, resolving the requester's account relationships for the whole slice up front, and returns a slice of only statuses which should be included on requester's public timeline.
+func (f *Filter) StatusesPublicTimelineable(ctx context.Context, requester *gtsmodel.Account, statuses []*gtsmodel.Status) ([]*gtsmodel.Status, error) {
+	return f.filterStatuses(ctx, requester, statuses, f.StatusPublicTimelineable)
+}
+
+// StatusPublicTimelineable checks if given status should be included on requester's public timeline. Primarily relying on status visibility to requester and the AP visibility setting, and ignoring conversation threads.
 func (f *Filter) StatusPublicTimelineable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	const vtype = cache.VisibilityTypePublic
 
@@ -91,6 +96,17 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Check whether status author is silenced.
+	silenced, err := f.AccountSilenced(ctx, status.Account)
+	if err != nil {
+		return false, err
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author silenced")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
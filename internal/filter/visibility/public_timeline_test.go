@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusPublicTimelineableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestSilencedDomainStatusNotPublicTimelineable() {
+	var (
+		ctx         = context.Background()
+		testStatus  = suite.testStatuses["remote_account_1_status_1"]
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	// Silence the status author's domain.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01J1KYW0NX6HV4KET0C5TEMXYV",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Obfuscate:          util.Ptr(false),
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should still be visible...
+	visible, err := suite.filter.StatusVisible(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// ...but not on the public timeline.
+	timelineable, err = suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Nor on tag timelines.
+	timelineable, err = suite.filter.StatusTagTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestSilencedAccountStatusNotPublicTimelineable() {
+	var (
+		ctx         = context.Background()
+		testStatus  = suite.testStatuses["local_account_2_status_1"]
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	// Silence the status author.
+	author := new(gtsmodel.Account)
+	*author = *suite.testAccounts["local_account_2"]
+	author.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, author, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Clear cached timelineability,
+	// as the silence action does.
+	suite.state.Caches.Visibility.Clear()
+
+	// Refetch status with updated author.
+	testStatus, err = suite.db.GetStatusByID(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should still be visible...
+	visible, err := suite.filter.StatusVisible(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// ...but not on the public timeline.
+	timelineable, err = suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Nor on tag timelines.
+	timelineable, err = suite.filter.StatusTagTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestStatusesPublicTimelineableBlocked() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_2"]
+		statuses    = []*gtsmodel.Status{
+			suite.testStatuses["local_account_1_status_1"],
+			suite.testStatuses["remote_account_1_status_1"],
+		}
+	)
+
+	// Account 2 blocks remote account 1, so only
+	// the local account 1 status is timelineable.
+	statuses, err := suite.filter.StatusesPublicTimelineable(ctx, testAccount, statuses)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, statuses[0].ID)
+
+	// Author visibility was resolved for the whole page.
+	for _, accountID := range []string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["remote_account_1"].ID,
+	} {
+		_, ok := suite.state.Caches.Visibility.GetOne("Type,RequesterID,ItemID",
+			cache.VisibilityTypeAccount,
+			testAccount.ID,
+			accountID,
+		)
+		suite.True(ok)
+	}
+}
+
+func TestStatusPublicTimelineableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPublicTimelineableTestSuite))
+}
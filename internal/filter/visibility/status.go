@@ -29,14 +29,39 @@ import (
 
 // StatusesVisible calls StatusVisible for each status in the statuses slice, and returns a slice of only statuses which are visible to the requester.
 func (f *Filter) StatusesVisible(ctx context.Context, requester *gtsmodel.Account, statuses []*gtsmodel.Status) ([]*gtsmodel.Status, error) {
+	return f.filterStatuses(ctx, requester, statuses, f.StatusVisible)
+}
+
+// filterStatuses resolves the requester's relationships with all status authors in one batched pass, then calls check for each status in the statuses slice, returning a slice of only statuses for which check returned true.
+func (f *Filter) filterStatuses(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statuses []*gtsmodel.Status,
+	check func(context.Context, *gtsmodel.Account, *gtsmodel.Status) (bool, error),
+) ([]*gtsmodel.Status, error) {
+	// Gather all (populated) status authors.
+	accounts := make([]*gtsmodel.Account, 0, len(statuses))
+	for _, status := range statuses {
+		accounts = append(accounts, status.Account)
+		if status.BoostOfAccount != nil {
+			accounts = append(accounts, status.BoostOfAccount)
+		}
+	}
+
+	if err := f.prefetchAccountsVisible(ctx, requester, accounts); err != nil {
+		// Not fatal, each check below
+		// just falls back to a lookup.
+		log.Errorf(ctx, "error prefetching account visibility: %v", err)
+	}
+
 	var errs gtserror.MultiError
 	filtered := slices.DeleteFunc(statuses, func(status *gtsmodel.Status) bool {
-		visible, err := f.StatusVisible(ctx, requester, status)
+		ok, err := check(ctx, requester, status)
 		if err != nil {
 			errs.Append(err)
 			return true
 		}
-		return !visible
+		return !ok
 	})
 	return filtered, errs.Combine()
 }
@@ -25,7 +25,19 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
-// StatusHomeTimelineable checks if given status should be included
+// StatusesTagTimelineable calls StatusTagTimelineable for each
+// status in the statuses slice, resolving the requester's account
+// relationships for the whole slice up front, and returns a slice of
+// only statuses which should be included on requester's tag timeline.
+func (f *Filter) StatusesTagTimelineable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statuses []*gtsmodel.Status,
+) ([]*gtsmodel.Status, error) {
+	return f.filterStatuses(ctx, requester, statuses, f.StatusTagTimelineable)
+}
+
+// StatusTagTimelineable checks if given status should be included
 // on requester's tag timeline, primarily relying on status visibility
 // to requester and the AP visibility setting.
 func (f *Filter) StatusTagTimelineable(
@@ -55,6 +67,17 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Check whether status author is silenced.
+	silenced, err := f.AccountSilenced(ctx, status.Account)
+	if err != nil {
+		return false, err
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author silenced")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
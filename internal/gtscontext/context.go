@@ -34,6 +34,7 @@ const (
 	_ ctxkey = iota
 	barebonesKey
 	fastFailKey
+	idempotentKey
 	outgoingPubKeyIDKey
 	requestIDKey
 	receivingAccountKey
@@ -195,6 +196,21 @@ func SetFastFail(ctx context.Context) context.Context {
 	return context.WithValue(ctx, fastFailKey, struct{}{})
 }
 
+// IsIdempotent returns whether the "idempotent" context key has been set. This
+// can be used to indicate to an http client that an outgoing request with
+// an otherwise non-idempotent method (e.g. POST) is safe to retry, such as
+// an ActivityPub delivery, which recipients deduplicate by activity ID.
+func IsIdempotent(ctx context.Context) bool {
+	_, ok := ctx.Value(idempotentKey).(struct{})
+	return ok
+}
+
+// SetIdempotent sets the "idempotent" context flag and returns this wrapped context.
+// See IsIdempotent() for further information on the "idempotent" context flag.
+func SetIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey, struct{}{})
+}
+
 // Barebones returns whether the "barebones" context key has been set. This
 // can be used to indicate to the database, for example, that only a barebones
 // model need be returned, Allowing it to skip populating sub models.
@@ -37,6 +37,8 @@ type Account struct {
 	CreatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
 	UpdatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
 	FetchedAt               time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
+	FetchedETag             string           `bun:"fetched_etag,nullzero"`                                       // ETag of item (remote) when last fetched, if any, for conditional refetch.
+	FetchedLastModified     string           `bun:",nullzero"`                                                   // Last-Modified of item (remote) when last fetched, if any, for conditional refetch.
 	Username                string           `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
 	Domain                  string           `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
 	AvatarMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
@@ -181,6 +183,12 @@ func (a *Account) IsSuspended() bool {
 	return !a.SuspendedAt.IsZero()
 }
 
+// IsSilenced returns true if account
+// has been silenced on this instance.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
 // IsMoving returns true if
 // account is Moving or has Moved.
 func (a *Account) IsMoving() bool {
@@ -206,20 +214,21 @@ type Field struct {
 
 // Relationship describes a requester's relationship with another account.
 type Relationship struct {
-	ID                  string // The account id.
-	Following           bool   // Are you following this user?
-	ShowingReblogs      bool   // Are you receiving this user's boosts in your home timeline?
-	Notifying           bool   // Have you enabled notifications for this user?
-	FollowedBy          bool   // Are you followed by this user?
-	Blocking            bool   // Are you blocking this user?
-	BlockedBy           bool   // Is this user blocking you?
-	Muting              bool   // Are you muting this user?
-	MutingNotifications bool   // Are you muting notifications from this user?
-	Requested           bool   // Do you have a pending follow request targeting this user?
-	RequestedBy         bool   // Does the user have a pending follow request targeting you?
-	DomainBlocking      bool   // Are you blocking this user's domain?
-	Endorsed            bool   // Are you featuring this user on your profile?
-	Note                string // Your note on this account.
+	ID                  string   // The account id.
+	Following           bool     // Are you following this user?
+	ShowingReblogs      bool     // Are you receiving this user's boosts in your home timeline?
+	Notifying           bool     // Have you enabled notifications for this user?
+	Languages           []string // Languages you're seeing this user's posts in, if filtered.
+	FollowedBy          bool     // Are you followed by this user?
+	Blocking            bool     // Are you blocking this user?
+	BlockedBy           bool     // Is this user blocking you?
+	Muting              bool     // Are you muting this user?
+	MutingNotifications bool     // Are you muting notifications from this user?
+	Requested           bool     // Do you have a pending follow request targeting this user?
+	RequestedBy         bool     // Does the user have a pending follow request targeting you?
+	DomainBlocking      bool     // Are you blocking this user's domain?
+	Endorsed            bool     // Are you featuring this user on your profile?
+	Note                string   // Your note on this account.
 }
 
 // Theme represents a user-selected
@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// AccountExport represents an archive of a local account's data, either
+// being generated or available for download. The archive itself is kept
+// on disk as "{ID}.zip" in the configured export directory. There is at
+// most one export per account, as each replaces any previous export.
+type AccountExport struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated; while in progress, this is updated periodically
+	CompletedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when was archive finished (or failed), zero while in progress
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // id of the account whose data is archived
+	Done        int       `bun:",notnull,default:0"`                                          // no. files written to archive so far
+	Total       int       `bun:",notnull,default:0"`                                          // total no. files to write to archive, 0 if not yet known
+	Error       string    `bun:",nullzero"`                                                   // error generating the archive, if it failed
+}
@@ -32,4 +32,10 @@ type AccountSettings struct {
 	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
 	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
 	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	Boostable         *bool      `bun:",nullzero,notnull,default:true"`                              // Default boostable flag for new statuses, where not given per-status and the visibility allows setting it.
+	Replyable         *bool      `bun:",nullzero,notnull,default:true"`                              // Default replyable flag for new statuses, where not given per-status and the visibility allows setting it.
+	Likeable          *bool      `bun:",nullzero,notnull,default:true"`                              // Default likeable flag for new statuses, where not given per-status and the visibility allows setting it.
+	ExpiryDays        int        `bun:",notnull,default:0"`                                          // Delete public statuses authored by this account once older than this many days (0 = never).
+	ExpiryKeepPinned  *bool      `bun:",nullzero,notnull,default:true"`                              // Exempt pinned statuses from status expiry.
+	ExpiryKeepFaved   *bool      `bun:",nullzero,notnull,default:true"`                              // Exempt statuses faved by this account from status expiry.
 }
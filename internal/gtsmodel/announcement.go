@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// Announcement represents an instance announcement
+// authored by an admin, to be shown to local users.
+type Announcement struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who created this announcement
+	Content            string    `bun:",nullzero"`                                                   // HTML content of the announcement, parsed from Text
+	Text               string    `bun:",nullzero"`                                                   // Original markdown text of the announcement, as submitted by the admin
+	EmojiIDs           []string  `bun:"emojis,array"`                                                // Database IDs of any emojis used in the announcement content
+	Emojis             []*Emoji  `bun:"-"`                                                           // Emojis corresponding to EmojiIDs
+	StartsAt           time.Time `bun:"type:timestamptz,nullzero"`                                   // Time from which the announcement should be shown. Zero means immediately.
+	EndsAt             time.Time `bun:"type:timestamptz,nullzero"`                                   // Time after which the announcement should no longer be shown. Zero means never.
+	AllDay             *bool     `bun:",nullzero,notnull,default:false"`                             // StartsAt and EndsAt should be interpreted as days rather than exact times
+}
+
+// Active returns whether this announcement
+// should be shown to users at the given time.
+func (a *Announcement) Active(now time.Time) bool {
+	return (a.StartsAt.IsZero() || !now.Before(a.StartsAt)) &&
+		(a.EndsAt.IsZero() || now.Before(a.EndsAt))
+}
+
+// AnnouncementRead marks an announcement
+// as read (dismissed) by a local account.
+type AnnouncementRead struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reads_announcement_id_account_id_uniq,nullzero,notnull"` // ID of the announcement that was read
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reads_announcement_id_account_id_uniq,nullzero,notnull"` // ID of the account that read the announcement
+}
+
+// AnnouncementReaction represents an emoji
+// reaction to an announcement by a local account.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"` // ID of the announcement that was reacted to
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"` // ID of the account that reacted
+	Name           string    `bun:",unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"`              // Unicode emoji, or custom emoji shortcode, used to react
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // ID of the custom emoji used to react, if any
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // Custom emoji corresponding to EmojiID
+}
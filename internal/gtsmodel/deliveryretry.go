@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DeliveryRetry represents an outgoing ActivityPub delivery that could
+// not be delivered within its in-memory retry attempts (or was still
+// queued on shutdown), persisted to the database in order to be
+// re-attempted later with exponential backoff.
+type DeliveryRetry struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Host          string    `bun:",nullzero,notnull"`                                           // host that delivery is targeted at
+	Attempts      int       `bun:",notnull"`                                                    // number of (exhausted) delivery attempts so far
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // time at which delivery should next be attempted
+	LastError     string    `bun:",nullzero"`                                                   // error from the last failed delivery attempt, if any
+	Data          []byte    `bun:",nullzero,notnull"`                                           // serialized delivery data
+}
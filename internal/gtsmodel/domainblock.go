@@ -21,16 +21,37 @@ import "time"
 
 // DomainBlock represents a federation block against a particular domain
 type DomainBlock struct {
-	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain             string    `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
-	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
-	CreatedByAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
-	PrivateComment     string    `bun:""`                                                            // Private comment on this block, viewable to admins
-	PublicComment      string    `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
-	Obfuscate          *bool     `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
-	SubscriptionID     string    `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	ID                 string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string              `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
+	CreatedByAccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
+	CreatedByAccount   *Account            `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+	PrivateComment     string              `bun:""`                                                            // Private comment on this block, viewable to admins
+	PublicComment      string              `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
+	Obfuscate          *bool               `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
+	SubscriptionID     string              `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	Severity           DomainBlockSeverity `bun:",nullzero,notnull,default:'suspend'"`                         // severity of this block, ie., suspend (default) or silence
+}
+
+// DomainBlockSeverity denotes the severity of a domain block.
+type DomainBlockSeverity string
+
+const (
+	// DomainBlockSeveritySuspend blocks all federation with
+	// the domain, and removes its accounts from this instance.
+	DomainBlockSeveritySuspend DomainBlockSeverity = "suspend"
+
+	// DomainBlockSeveritySilence keeps the domain's posts out of
+	// public timelines, and requires follows from the domain to be
+	// manually approved, but otherwise allows federation with it.
+	DomainBlockSeveritySilence DomainBlockSeverity = "silence"
+)
+
+// IsSilence returns true if this
+// domain block is only a silence.
+func (d *DomainBlock) IsSilence() bool {
+	return d.Severity == DomainBlockSeveritySilence
 }
 
 func (d *DomainBlock) GetID() string {
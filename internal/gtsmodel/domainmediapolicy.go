@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainMediaPolicy represents an admin-created override of
+// the instance's remote media caching behaviour, for media
+// owned by accounts on the given domain (and its subdomains).
+type DomainMediaPolicy struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string    `bun:",nullzero,notnull,unique"`                                    // Domain this policy applies to, eg. 'example.org'.
+	NeverCache         *bool     `bun:",nullzero,notnull,default:false"`                             // Never cache media from this domain, always link to the remote instead.
+	RemoteCacheDays    int       `bun:",nullzero"`                                                   // Uncache media from this domain after this many days. 0 = use instance default.
+	PrivateComment     string    `bun:""`                                                            // Private comment on this policy, viewable to admins.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this policy
+	CreatedByAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+}
+
+// UncacheAfter returns the time before which
+// media covered by this policy should be
+// uncached, given the instance default time.
+func (p *DomainMediaPolicy) UncacheAfter(now time.Time, def time.Time) time.Time {
+	switch {
+	case *p.NeverCache:
+		// Uncache everything.
+		return now
+	case p.RemoteCacheDays > 0:
+		return now.Add(-24 * time.Hour * time.Duration(p.RemoteCacheDays))
+	default:
+		return def
+	}
+}
@@ -24,20 +24,21 @@ import (
 
 // Filter stores a filter created by a local account.
 type Filter struct {
-	ID                   string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	ExpiresAt            time.Time        `bun:"type:timestamptz,nullzero"`                                   // Time filter should expire. If null, should not expire.
-	AccountID            string           `bun:"type:CHAR(26),notnull,nullzero"`                              // ID of the local account that created the filter.
-	Title                string           `bun:",nullzero,notnull,unique"`                                    // The name of the filter.
-	Action               FilterAction     `bun:",nullzero,notnull"`                                           // The action to take.
-	Keywords             []*FilterKeyword `bun:"-"`                                                           // Keywords for this filter.
-	Statuses             []*FilterStatus  `bun:"-"`                                                           // Statuses for this filter.
-	ContextHome          *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to home timeline and lists.
-	ContextNotifications *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to notifications.
-	ContextPublic        *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter to home timeline and lists.
-	ContextThread        *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter when viewing a status's associated thread.
-	ContextAccount       *bool            `bun:",nullzero,notnull,default:false"`                             // Apply filter when viewing an account profile.
+	ID                   string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                            // id of this item in the database
+	CreatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`         // when was item created
+	UpdatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`         // when was item last updated
+	ExpiresAt            time.Time        `bun:"type:timestamptz,nullzero"`                                           // Time filter should expire. If null, should not expire.
+	AccountID            string           `bun:"type:CHAR(26),notnull,nullzero,unique:filters_account_id_title_uniq"` // ID of the local account that created the filter.
+	Title                string           `bun:",nullzero,notnull,unique:filters_account_id_title_uniq"`              // The name of the filter.
+	Action               FilterAction     `bun:",nullzero,notnull"`                                                   // The action to take.
+	SubscriptionID       string           `bun:"type:CHAR(26),nullzero"`                                              // ID of the filter subscription that manages this filter, if any.
+	Keywords             []*FilterKeyword `bun:"-"`                                                                   // Keywords for this filter.
+	Statuses             []*FilterStatus  `bun:"-"`                                                                   // Statuses for this filter.
+	ContextHome          *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to home timeline and lists.
+	ContextNotifications *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to notifications.
+	ContextPublic        *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to home timeline and lists.
+	ContextThread        *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter when viewing a status's associated thread.
+	ContextAccount       *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter when viewing an account profile.
 }
 
 // Expired returns whether the filter has expired at a given time.
@@ -56,6 +57,7 @@ type FilterKeyword struct {
 	Filter    *Filter        `bun:"-"`                                                                            // Filter corresponding to FilterID
 	Keyword   string         `bun:",nullzero,notnull,unique:filter_keywords_filter_id_keyword_uniq"`              // The keyword or phrase to filter against.
 	WholeWord *bool          `bun:",nullzero,notnull,default:false"`                                              // Should the filter consider word boundaries?
+	Regex     *bool          `bun:",nullzero,notnull,default:false"`                                              // Should the keyword be treated as a regular expression rather than literal text?
 	Regexp    *regexp.Regexp `bun:"-"`                                                                            // pre-prepared regular expression
 }
 
@@ -66,9 +68,20 @@ func (k *FilterKeyword) Compile() (err error) {
 		wordBreak = `\b`
 	}
 
+	// Literal keywords are quoted, regex
+	// keywords are used as-is. Either way
+	// the match is case-insensitive.
+	expr := k.Keyword
+	if k.Regex == nil || !*k.Regex {
+		expr = regexp.QuoteMeta(expr)
+	} else {
+		// Group the expression so that word
+		// breaks apply to all alternatives.
+		expr = `(?:` + expr + `)`
+	}
+
 	// Compile keyword filter regexp.
-	quoted := regexp.QuoteMeta(k.Keyword)
-	k.Regexp, err = regexp.Compile(`(?i)` + wordBreak + quoted + wordBreak)
+	k.Regexp, err = regexp.Compile(`(?i)` + wordBreak + expr + wordBreak)
 	return // caller is expected to wrap this error
 }
 
@@ -83,6 +96,20 @@ type FilterStatus struct {
 	StatusID  string    `bun:"type:CHAR(26),notnull,nullzero,unique:filter_statuses_filter_id_status_id_uniq"` // ID of the status to filter.
 }
 
+// FilterSubscription stores a local account's subscription to a
+// shared filter list, which is periodically re-fetched from its URL
+// so that the filters imported from it are kept up to date.
+type FilterSubscription struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                       // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item last updated
+	AccountID string    `bun:"type:CHAR(26),notnull,nullzero,unique:filter_subscriptions_account_id_url_uniq"` // ID of the local account that owns the subscription.
+	URL       string    `bun:",nullzero,notnull,unique:filter_subscriptions_account_id_url_uniq"`              // URL of the filter list document.
+	Title     string    `bun:",nullzero"`                                                                      // Title of the filter list, as of the last sync.
+	SyncedAt  time.Time `bun:"type:timestamptz,nullzero"`                                                      // When the filter list was last successfully synced.
+	Error     string    `bun:",nullzero"`                                                                      // Error encountered on the last sync attempt, if it failed.
+}
+
 // FilterAction represents the action to take on a filtered status.
 type FilterAction string
 
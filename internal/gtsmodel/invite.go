@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite code generated by an account
+// on this instance, allowing whoever holds it to sign up even
+// while registrations are closed, up to the given max uses.
+type Invite struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code               string    `bun:",nullzero,notnull,unique"`                                    // Code to be entered on the sign-up form.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this invite
+	CreatedByAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+	MaxUses            int       `bun:",nullzero"`                                                   // Max number of sign-ups using this invite. 0 means unlimited.
+	Uses               int       `bun:",notnull,default:0"`                                          // Number of sign-ups that have used this invite so far.
+	ExpiresAt          time.Time `bun:"type:timestamptz,nullzero"`                                   // Time after which this invite can no longer be used. Zero means never.
+}
+
+// Expired returns whether this invite has expired at the given time.
+func (i *Invite) Expired(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+// UsedUp returns whether this invite has reached its max uses.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Usable returns whether this invite may be
+// used to sign up at the given time.
+func (i *Invite) Usable(now time.Time) bool {
+	return !i.Expired(now) && !i.UsedUp()
+}
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// IPBlock represents an admin-created block on
+// requests coming from the given IP address or range.
+type IPBlock struct {
+	ID                 string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	IP                 string          `bun:",nullzero,notnull,unique"`                                    // Blocked IP range in CIDR notation, eg. '192.0.2.0/24' or '2001:db8::1/128'.
+	Severity           IPBlockSeverity `bun:",nullzero,notnull"`                                           // Severity of this block.
+	Comment            string          `bun:""`                                                            // Private comment on this block, viewable to admins.
+	CreatedByAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
+	CreatedByAccount   *Account        `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+}
+
+// IPBlockSeverity denotes what is
+// prevented for a blocked IP range.
+type IPBlockSeverity string
+
+const (
+	// IPBlockSeveritySignUpBlock prevents new sign-ups
+	// from the IP range, but allows all other requests.
+	IPBlockSeveritySignUpBlock IPBlockSeverity = "sign_up_block"
+
+	// IPBlockSeverityNoAccess prevents
+	// all requests from the IP range.
+	IPBlockSeverityNoAccess IPBlockSeverity = "no_access"
+)
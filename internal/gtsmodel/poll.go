@@ -27,6 +27,7 @@ type Poll struct {
 	ID         string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"` // Unique identity string.
 	Multiple   *bool     `bun:",nullzero,notnull,default:false"`          // Is this a multiple choice poll? i.e. can you vote on multiple options.
 	HideCounts *bool     `bun:",nullzero,notnull,default:false"`          // Hides vote counts until poll ends.
+	VoteChange *bool     `bun:",nullzero,notnull,default:false"`          // Allows voters to change their vote until poll ends (local polls only).
 	Options    []string  `bun:",nullzero,notnull"`                        // The available options for this poll.
 	Votes      []int     `bun:",nullzero,notnull"`                        // Vote counts per choice.
 	Voters     *int      `bun:",nullzero,notnull"`                        // Total no. voters count.
@@ -26,22 +26,36 @@ import "time"
 // or another instance, OR a report that was created remotely (on another instance)
 // about a user on this instance, and received via the federated (s2s) API.
 type Report struct {
-	ID                     string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	URI                    string    `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this report
-	AccountID              string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account created this report
-	Account                *Account  `bun:"-"`                                                           // account corresponding to AccountID
-	TargetAccountID        string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account is targeted by this report
-	TargetAccount          *Account  `bun:"-"`                                                           // account corresponding to TargetAccountID
-	Comment                string    `bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
-	StatusIDs              []string  `bun:"statuses,array"`                                              // database IDs of any statuses referenced by this report
-	Statuses               []*Status `bun:"-"`                                                           // statuses corresponding to StatusIDs
-	RuleIDs                []string  `bun:"rules,array"`                                                 // database IDs of any rules referenced by this report
-	Rules                  []*Rule   `bun:"-"`                                                           // rules corresponding to RuleIDs
-	Forwarded              *bool     `bun:",nullzero,notnull,default:false"`                             // flag to indicate report should be forwarded to remote instance
-	ActionTaken            string    `bun:",nullzero"`                                                   // string description of what action was taken in response to this report
-	ActionTakenAt          time.Time `bun:"type:timestamptz,nullzero"`                                   // time at which action was taken, if any
-	ActionTakenByAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // database ID of account which took action, if any
-	ActionTakenByAccount   *Account  `bun:"-"`                                                           // account corresponding to ActionTakenByID, if any
+	ID                     string                   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URI                    string                   `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this report
+	AccountID              string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // which account created this report
+	Account                *Account                 `bun:"-"`                                                           // account corresponding to AccountID
+	TargetAccountID        string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // which account is targeted by this report
+	TargetAccount          *Account                 `bun:"-"`                                                           // account corresponding to TargetAccountID
+	Comment                string                   `bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
+	StatusIDs              []string                 `bun:"statuses,array"`                                              // database IDs of any statuses referenced by this report
+	Statuses               []*Status                `bun:"-"`                                                           // statuses corresponding to StatusIDs
+	RuleIDs                []string                 `bun:"rules,array"`                                                 // database IDs of any rules referenced by this report
+	Rules                  []*Rule                  `bun:"-"`                                                           // rules corresponding to RuleIDs
+	Forwarded              *bool                    `bun:",nullzero,notnull,default:false"`                             // flag to indicate report should be forwarded to remote instance
+	ActionTaken            string                   `bun:",nullzero"`                                                   // string description of what action was taken in response to this report
+	ActionTakenAt          time.Time                `bun:"type:timestamptz,nullzero"`                                   // time at which action was taken, if any
+	ActionTakenByAccountID string                   `bun:"type:CHAR(26),nullzero"`                                      // database ID of account which took action, if any
+	ActionTakenByAccount   *Account                 `bun:"-"`                                                           // account corresponding to ActionTakenByID, if any
+	AssignedAccountID      string                   `bun:"type:CHAR(26),nullzero"`                                      // database ID of account assigned to handle this report, if any
+	AssignedAccount        *Account                 `bun:"-"`                                                           // account corresponding to AssignedAccountID, if any
+	ResolutionCategory     ReportResolutionCategory `bun:",nullzero"`                                                   // category of the outcome of this report, if resolved with one
 }
+
+// ReportResolutionCategory describes the outcome
+// of a report, as set by the admin who resolved it.
+type ReportResolutionCategory string
+
+const (
+	ReportResolutionNoAction  ReportResolutionCategory = "no_action" // report was reviewed, but no action was needed
+	ReportResolutionActioned  ReportResolutionCategory = "actioned"  // action was taken against the reported account
+	ReportResolutionDuplicate ReportResolutionCategory = "duplicate" // report duplicates another report
+	ReportResolutionOther     ReportResolutionCategory = "other"     // report was resolved some other way
+)
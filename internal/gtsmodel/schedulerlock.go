@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// SchedulerLock represents a lease on a named scheduler lock,
+// used to ensure that only one of potentially many GoToSocial
+// processes sharing a database executes a scheduled job. This
+// is only used by database types that lack native support for
+// advisory locks (i.e. SQLite), Postgres uses pg_advisory_lock.
+type SchedulerLock struct {
+	Name      string    `bun:",pk,nullzero,notnull,unique"`       // unique name of this lock
+	HolderID  string    `bun:",nullzero,notnull"`                 // unique ID of the process holding this lock
+	ExpiresAt time.Time `bun:"type:timestamptz,nullzero,notnull"` // time at which this lock lease expires if not renewed
+}
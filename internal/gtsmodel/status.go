@@ -28,6 +28,8 @@ type Status struct {
 	CreatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
+	FetchedETag              string             `bun:"fetched_etag,nullzero"`                                       // ETag of item (remote) when last fetched, if any, for conditional refetch.
+	FetchedLastModified      string             `bun:",nullzero"`                                                   // Last-Modified of item (remote) when last fetched, if any, for conditional refetch.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
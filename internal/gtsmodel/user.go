@@ -96,5 +96,6 @@ type NewSignup struct {
 	AppID         string // ID of the application used to create this account (optional).
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
 	ExternalID    string // ID of this user in external OIDC system (optional).
+	InviteID      string // ID of the invite used to sign up (optional).
 	Admin         bool   // Mark new user as an admin user (optional).
 }
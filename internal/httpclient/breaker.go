@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// hostBreakers provides a circuit breaker per host, which
+// opens after a number of consecutive failed requests to
+// that host, failing requests fast for a cool-down period.
+// After this a single trial request is permitted through
+// (i.e. half-open), closing the circuit again on success.
+type hostBreakers struct {
+	failures int           // consecutive failures to open, <= 0 = disabled
+	cooldown time.Duration // time to stay open before trial request
+	hosts    cache.TTLCache[string, *hostBreaker]
+	mu       sync.Mutex
+}
+
+// hostBreaker contains the
+// breaker state of one host.
+type hostBreaker struct {
+	// no. consecutive
+	// failed requests.
+	fails int
+
+	// time before which
+	// circuit is open.
+	until time.Time
+}
+
+func newHostBreakers(failures int, cooldown time.Duration) *hostBreakers {
+	if cooldown <= 0 {
+		// By default, a
+		// 1 minute cooldown.
+		cooldown = time.Minute
+	}
+
+	b := &hostBreakers{
+		failures: failures,
+		cooldown: cooldown,
+	}
+
+	// Keep host state long enough to be useful, but
+	// not so long that the occasional failure to a
+	// host eventually builds up into an open circuit.
+	b.hosts = cache.NewTTL[string, *hostBreaker](0, 8192, 0)
+	b.hosts.SetTTL(max(cooldown, 10*time.Minute), false)
+	if !b.hosts.Start(time.Minute) {
+		log.Panic(nil, "failed to start host breakers cache")
+	}
+
+	return b
+}
+
+// Wait returns zero if a request to host may be made, else
+// the time until the host's circuit will next permit one.
+// When cool-down has passed on an open circuit, this will
+// permit only the first caller through for a trial request,
+// re-arming the cool-down in case the trial never completes.
+func (b *hostBreakers) Wait(host string) time.Duration {
+	if b.failures <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, ok := b.hosts.Get(host)
+	if !ok || h.fails < b.failures {
+		// Circuit closed.
+		return 0
+	}
+
+	if now.Before(h.until) {
+		// Circuit open.
+		return h.until.Sub(now)
+	}
+
+	// Half-open, permit this
+	// trial request through.
+	h.until = now.Add(b.cooldown)
+	b.hosts.Set(host, h)
+	return 0
+}
+
+// Success marks a successful
+// request to host, closing circuit.
+func (b *hostBreakers) Success(host string) {
+	if b.failures <= 0 {
+		return
+	}
+	b.mu.Lock()
+	b.hosts.Invalidate(host)
+	b.mu.Unlock()
+}
+
+// Failure marks a failed request to host, opening
+// circuit on reaching max consecutive failures.
+func (b *hostBreakers) Failure(host string) {
+	if b.failures <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, ok := b.hosts.Get(host)
+	if !ok {
+		h = new(hostBreaker)
+	}
+
+	if h.fails++; h.fails >= b.failures {
+		// Circuit (re-)opened,
+		// start the cool-down.
+		h.until = now.Add(b.cooldown)
+
+		if h.fails == b.failures {
+			log.Warnf(nil, "circuit opened for %s after %d consecutive failures", host, h.fails)
+		}
+	}
+
+	b.hosts.Set(host, h)
+}
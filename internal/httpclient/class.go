@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"context"
+	"time"
+)
+
+// Class is the class of an outgoing request, by
+// which its timeout and max body size are selected.
+type Class uint8
+
+const (
+	// ClassDefault is any request not of another class,
+	// e.g. webfinger or nodeinfo lookups. This uses the
+	// top level Config{}.Timeout and .MaxBodySize.
+	ClassDefault Class = iota
+
+	// ClassDelivery is an ActivityPub activity delivery.
+	ClassDelivery
+
+	// ClassDereference is an ActivityPub object dereference.
+	ClassDereference
+
+	// ClassMedia is a remote media download.
+	ClassMedia
+)
+
+// ClassLimits provides the timeout and max
+// body size for requests of a particular class.
+// Zero values fall back to the defaults in Config{}.
+type ClassLimits struct {
+	// Timeout for the request, including reading the body.
+	Timeout time.Duration
+
+	// MaxBodySize is the maximum fetchable body size.
+	MaxBodySize int64
+}
+
+// classKey is the context
+// key for a request's Class.
+type classKey struct{}
+
+// SetClass sets the outgoing request class on
+// the context, for requests made with it. See Class.
+func SetClass(ctx context.Context, class Class) context.Context {
+	return context.WithValue(ctx, classKey{}, class)
+}
+
+// GetClass returns the outgoing request class set on
+// context, or ClassDefault if none set. See Class.
+func GetClass(ctx context.Context) Class {
+	class, _ := ctx.Value(classKey{}).(Class)
+	return class
+}
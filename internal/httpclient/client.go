@@ -26,9 +26,13 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
@@ -52,6 +56,18 @@ var (
 
 	// ErrBodyTooLarge is returned when a received response body is above predefined limit (default 40MB).
 	ErrBodyTooLarge = errors.New("body size too large")
+
+	// ErrHostRateLimited is returned when a request to a host is not permitted for now, either due to
+	// the host asking us to hold off via "Retry-After", or our own configured per-host request budget.
+	ErrHostRateLimited = errors.New("host rate limited")
+
+	// ErrCircuitOpen is returned when a request to a host is not permitted
+	// for now, as too many consecutive requests to it have recently failed.
+	ErrCircuitOpen = errors.New("host circuit open")
+
+	// ErrHiddenService is returned when a request is made to a
+	// .onion or .i2p host, without a proxy configured for it.
+	ErrHiddenService = errors.New("hidden service host not enabled")
 )
 
 // Config provides configuration details for setting up a new
@@ -60,8 +76,9 @@ var (
 // and http.Client{}, along with httpclient.Client{} specific.
 type Config struct {
 
-	// MaxOpenConnsPerHost limits the max
-	// number of open connections to a host.
+	// MaxOpenConnsPerHost limits the max number of
+	// open requests to a host, where a request is open
+	// until its response body is closed (or timeout).
 	MaxOpenConnsPerHost int
 
 	// AllowRanges allows outgoing
@@ -89,14 +106,63 @@ type Config struct {
 	// WriteBufferSize: see http.Transport{}.WriteBufferSize.
 	WriteBufferSize int
 
-	// MaxBodySize determines the maximum fetchable body size.
+	// MaxBodySize determines the maximum fetchable body
+	// size, for requests without a class specific limit.
 	MaxBodySize int64
 
-	// Timeout: see http.Client{}.Timeout.
+	// Timeout: see http.Client{}.Timeout. This is
+	// applied to each attempt at a request, for
+	// requests without a class specific timeout.
 	Timeout time.Duration
 
+	// Delivery, Dereference and Media provide the
+	// limits for requests of each class (see Class).
+	Delivery    ClassLimits
+	Dereference ClassLimits
+	Media       ClassLimits
+
 	// DisableCompression: see http.Transport{}.DisableCompression.
 	DisableCompression bool
+
+	// HostRequestBudget limits the number of requests
+	// made to any one host within HostRequestWindow,
+	// further requests being held until budget becomes
+	// available. Zero or less means unlimited.
+	HostRequestBudget int
+
+	// HostRequestWindow is the window of time over which
+	// HostRequestBudget applies. Defaults to 5 minutes.
+	HostRequestWindow time.Duration
+
+	// BreakerFailures is the number of consecutive failed
+	// requests to a host after which its circuit breaker
+	// opens, failing further requests to that host fast
+	// for BreakerCooldown. Zero or less means disabled.
+	BreakerFailures int
+
+	// BreakerCooldown is the time for which a host's circuit
+	// breaker stays open, before a single trial request is
+	// permitted through to it. Defaults to 1 minute.
+	BreakerCooldown time.Duration
+
+	// Proxy is an optional proxy through which to make all
+	// outgoing requests, with scheme http, https, socks5 or
+	// socks5h. When nil, any proxy set in the environment is
+	// used instead (see http.ProxyFromEnvironment()).
+	//
+	// The proxy itself is always dialable regardless of
+	// the IP ranges above. As the proxy dials the remote
+	// host, its hostname is instead resolved locally before
+	// each request, and its addresses checked against them.
+	Proxy *url.URL
+
+	// OnionProxy and I2PProxy are optional proxies through
+	// which to make requests to .onion and .i2p hosts, e.g.
+	// a Tor or I2P router's SOCKS proxy. When unset, requests
+	// to hosts of that network are refused. As with Proxy,
+	// these are dialable regardless of the IP ranges above.
+	OnionProxy *url.URL
+	I2PProxy   *url.URL
 }
 
 // Client wraps an underlying http.Client{} to provide the following:
@@ -105,25 +171,40 @@ type Config struct {
 //     cases to protect against forged / unknown content-lengths
 //   - protection from server side request forgery (SSRF) by only dialing
 //     out to known public IP prefixes, configurable with allows/blocks
-//   - retry-backoff logic for error temporary HTTP error responses
+//   - retry-backoff logic for temporary errors on idempotent requests
+//   - caching of DNS lookups made when dialing, respecting record TTLs
+//   - per-host request budgets, and holding off requests to hosts
+//     that responded asking us to do so with "Retry-After"
+//   - per-host circuit breakers, failing fast on requests to hosts
+//     that have recently been consistently failing
 //   - optional request signing
-//   - request logging
+//   - request logging, and per-host request statistics
 type Client struct {
-	client   http.Client
-	badHosts cache.TTLCache[string, struct{}]
-	bodyMax  int64
-	retries  uint
+	client     http.Client
+	sanitizer  atomic.Pointer[Sanitizer]
+	badHosts   cache.TTLCache[string, struct{}]
+	limits     *hostLimits
+	breakers   *hostBreakers
+	slots      *hostSlots
+	stats      stats
+	classes    [4]ClassLimits
+	proxy      func(*http.Request) (*url.URL, error)
+	resolver   *net.Resolver
+	onionProxy *url.URL
+	i2pProxy   *url.URL
+	retries    uint
 }
 
 // New returns a new instance of Client initialized using configuration.
 func New(cfg Config) *Client {
 	var c Client
 	c.retries = 5
+	c.resolver = newDNSCache().Resolver()
 
 	d := &net.Dialer{
 		Timeout:   15 * time.Second,
 		KeepAlive: 30 * time.Second,
-		Resolver:  &net.Resolver{},
+		Resolver:  c.resolver,
 	}
 
 	if cfg.MaxOpenConnsPerHost <= 0 {
@@ -142,16 +223,36 @@ func New(cfg Config) *Client {
 		cfg.MaxBodySize = int64(40 * bytesize.MiB)
 	}
 
-	// Protect the dialer
-	// with IP range sanitizer.
-	d.Control = (&Sanitizer{
-		Allow: cfg.AllowRanges,
-		Block: cfg.BlockRanges,
-	}).Sanitize
+	// Protect the dialer with IP range
+	// sanitizer, loaded on each dial so
+	// that ranges can be updated live.
+	c.SetRanges(cfg.AllowRanges, cfg.BlockRanges)
+	d.Control = func(ntwrk, addr string, conn syscall.RawConn) error {
+		return c.sanitizer.Load().Sanitize(ntwrk, addr, conn)
+	}
+
+	// Prepare per-class request limits,
+	// falling back to defaults where unset.
+	c.classes[ClassDefault] = ClassLimits{
+		Timeout:     cfg.Timeout,
+		MaxBodySize: cfg.MaxBodySize,
+	}
+	c.classes[ClassDelivery] = cfg.Delivery
+	c.classes[ClassDereference] = cfg.Dereference
+	c.classes[ClassMedia] = cfg.Media
+	for i := range c.classes {
+		if c.classes[i].Timeout <= 0 {
+			c.classes[i].Timeout = cfg.Timeout
+		}
+		if c.classes[i].MaxBodySize <= 0 {
+			c.classes[i].MaxBodySize = cfg.MaxBodySize
+		}
+	}
 
 	// Prepare client fields.
-	c.client.Timeout = cfg.Timeout
-	c.bodyMax = cfg.MaxBodySize
+	c.limits = newHostLimits(cfg.HostRequestBudget, cfg.HostRequestWindow)
+	c.breakers = newHostBreakers(cfg.BreakerFailures, cfg.BreakerCooldown)
+	c.slots = newHostSlots(cfg.MaxOpenConnsPerHost)
 
 	// Prepare transport TLS config.
 	tlsClientConfig := &tls.Config{
@@ -167,11 +268,61 @@ func New(cfg Config) *Client {
 		)
 	}
 
+	// By default use
+	// proxy from env.
+	proxy := http.ProxyFromEnvironment
+	if cfg.Proxy != nil {
+		// Use configured proxy for all requests.
+		proxy = http.ProxyURL(cfg.Proxy)
+	}
+
+	// Set any hidden service proxies, wrapping
+	// the proxy func to route their hosts to them.
+	c.onionProxy = cfg.OnionProxy
+	c.i2pProxy = cfg.I2PProxy
+	if c.onionProxy != nil || c.i2pProxy != nil {
+		next := proxy
+		proxy = func(r *http.Request) (*url.URL, error) {
+			if u, ok := c.hiddenServiceProxy(r.URL.Hostname()); ok {
+				return u, nil
+			}
+			return next(r)
+		}
+	}
+
+	// Keep the final proxy func
+	// to check requests against.
+	c.proxy = proxy
+
+	// Addresses the transport will
+	// dial for any configured proxies.
+	proxyAddrs := make(map[string]struct{})
+	for _, u := range []*url.URL{cfg.Proxy, cfg.OnionProxy, cfg.I2PProxy} {
+		if u != nil {
+			proxyAddrs[proxyAddr(u)] = struct{}{}
+		}
+	}
+
+	dial := d.DialContext
+	if len(proxyAddrs) > 0 {
+		// Proxy dials skip the IP range sanitizer,
+		// as the proxy is often on a private network.
+		pd := *d
+		pd.Control = nil
+
+		dial = func(ctx context.Context, ntwrk, addr string) (net.Conn, error) {
+			if _, ok := proxyAddrs[addr]; ok {
+				return pd.DialContext(ctx, ntwrk, addr)
+			}
+			return d.DialContext(ctx, ntwrk, addr)
+		}
+	}
+
 	// Set underlying HTTP client roundtripper.
 	c.client.Transport = &signingtransport{http.Transport{
-		Proxy:                 http.ProxyFromEnvironment,
+		Proxy:                 proxy,
 		ForceAttemptHTTP2:     true,
-		DialContext:           d.DialContext,
+		DialContext:           dial,
 		TLSClientConfig:       tlsClientConfig,
 		MaxIdleConns:          cfg.MaxIdleConns,
 		IdleConnTimeout:       90 * time.Second,
@@ -192,7 +343,109 @@ func New(cfg Config) *Client {
 	return &c
 }
 
+// Ranges returns the IP ranges currently
+// explicitly allowed / blocked for dialing.
+func (c *Client) Ranges() (allow []netip.Prefix, block []netip.Prefix) {
+	s := c.sanitizer.Load()
+	return slices.Clone(s.Allow), slices.Clone(s.Block)
+}
+
+// SetRanges updates the IP ranges explicitly allowed / blocked for dialing,
+// taking effect immediately for new connections. Idle connections are
+// closed, so they are not reused should their address now be blocked.
+func (c *Client) SetRanges(allow []netip.Prefix, block []netip.Prefix) {
+	c.sanitizer.Store(&Sanitizer{
+		Allow: slices.Clone(allow),
+		Block: slices.Clone(block),
+	})
+	c.client.CloseIdleConnections()
+}
+
+// DialCheck contains the result of checking whether
+// an IP address would be permitted to be dialed.
+type DialCheck struct {
+	// IP is the checked IP address.
+	IP netip.Addr
+
+	// Range is the configured allow / block
+	// range that IP matched, if any.
+	Range netip.Prefix
+
+	// Err is ErrReservedAddr when IP is not
+	// permitted to be dialed, else nil.
+	Err error
+}
+
+// CheckDialable checks whether the given host (a hostname or IP address) would be permitted
+// to be dialed under the current allow / block ranges, returning the result for each of the
+// IP addresses it resolves to. This is useful in debugging false positives of SSRF protection.
+func (c *Client) CheckDialable(ctx context.Context, host string) ([]DialCheck, error) {
+	var ips []netip.Addr
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		// Host is an IP already.
+		ips = []netip.Addr{ip}
+	} else {
+		// Resolve IPs of host.
+		ips, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, gtserror.Newf("error resolving %s: %w", host, err)
+		}
+	}
+
+	checks := make([]DialCheck, len(ips))
+	s := c.sanitizer.Load()
+
+	for i, ip := range ips {
+		// Unmap IPv4 addresses as
+		// the dialer would see them.
+		ip = ip.Unmap()
+
+		checks[i].IP = ip
+		checks[i].Range, checks[i].Err = s.Check(ip)
+	}
+
+	return checks, nil
+}
+
+// checkHost checks the IP addresses of host, resolving it if it's a
+// hostname, against the IP ranges permitted to be dialed. This is
+// used for proxied requests, where the dialer only sees the proxy.
+func (c *Client) checkHost(ctx context.Context, host string) error {
+	var ips []netip.Addr
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		// Host is an IP already.
+		ips = []netip.Addr{ip}
+	} else {
+		// Resolve IPs of host.
+		ips, err = c.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			if dnserr := errorsv2.AsV2[*net.DNSError](err); //
+			dnserr != nil && dnserr.IsNotFound {
+				// This domain does not exist.
+				err = gtserror.SetNotFound(err)
+			}
+			return err
+		}
+	}
+
+	s := c.sanitizer.Load()
+	for _, ip := range ips {
+		// Unmap IPv4 addresses as
+		// the dialer would see them.
+		if _, err := s.Check(ip.Unmap()); err != nil {
+			return fmt.Errorf("%w: %s resolves to %s", err, host, ip)
+		}
+	}
+
+	return nil
+}
+
 // Do will essentially perform http.Client{}.Do() with retry-backoff functionality.
+// Only idempotent requests (see Request{}.Idempotent()) are automatically retried,
+// on transient network errors and 5xx / 429 responses, with jittered exponential
+// backoff. No retry is attempted if it could not be made before context deadline.
 func (c *Client) Do(r *http.Request) (rsp *http.Response, err error) {
 
 	// First validate incoming request.
@@ -215,6 +468,17 @@ func (c *Client) Do(r *http.Request) (rsp *http.Response, err error) {
 		return rsp, nil
 	}
 
+	if !req.Idempotent() {
+		// Non-idempotent requests may have
+		// had side-effects on failure, so we
+		// can't safely retry them. One shot.
+		rsp, _, err = c.DoOnce(&req)
+		if err != nil {
+			return nil, fmt.Errorf("%w (not idempotent)", err)
+		}
+		return rsp, nil
+	}
+
 	for {
 		var retry bool
 
@@ -229,8 +493,30 @@ func (c *Client) Do(r *http.Request) (rsp *http.Response, err error) {
 			return nil, fmt.Errorf("%w (max retries)", err)
 		}
 
+		wait := req.BackOff()
+
+		if deadline, ok := r.Context().Deadline(); //
+		ok && time.Now().Add(wait).After(deadline) {
+			// No point waiting to retry
+			// after the request deadline.
+			return nil, fmt.Errorf("%w (deadline)", err)
+		}
+
+		if errors.Is(err, ErrCircuitOpen) {
+			// The point of an open circuit is
+			// to not hold up callers, fail now.
+			return nil, fmt.Errorf("%w (fast fail)", err)
+		}
+
+		if errors.Is(err, ErrHostRateLimited) &&
+			wait > baseBackoff*time.Duration(c.retries) {
+			// Only hold the request for host if we
+			// can do so within our usual max backoff.
+			return nil, fmt.Errorf("%w (fast fail)", err)
+		}
+
 		// Start new backoff sleep timer.
-		backoff := time.NewTimer(req.BackOff())
+		backoff := time.NewTimer(wait)
 
 		select {
 		// Request ctx cancelled.
@@ -257,6 +543,29 @@ func (c *Client) DoOnce(r *Request) (rsp *http.Response, retry bool, err error)
 		return
 	}
 
+	if wait := c.limits.Reserve(r.Host); wait > 0 {
+		// Request to host not currently permitted,
+		// have caller backoff until it will be. This
+		// is not counted as a delivery attempt.
+		r.backoff = wait
+		r.exhausted = false
+		err = fmt.Errorf("%w: %s", ErrHostRateLimited, r.Host)
+		r.Entry.Debug(err)
+		return nil, true, err
+	}
+
+	if wait := c.breakers.Wait(r.Host); wait > 0 {
+		// Host circuit is open after too many
+		// failures, have caller backoff until
+		// it's half-open. Again this is not
+		// counted as a delivery attempt.
+		r.backoff = wait
+		r.exhausted = false
+		err = fmt.Errorf("%w: %s", ErrCircuitOpen, r.Host)
+		r.Entry.Debug(err)
+		return nil, true, err
+	}
+
 	// Update no.
 	// attempts.
 	r.attempts++
@@ -264,6 +573,9 @@ func (c *Client) DoOnce(r *Request) (rsp *http.Response, retry bool, err error)
 	// Reset backoff.
 	r.backoff = 0
 
+	// Reset exhausted.
+	r.exhausted = false
+
 	// Perform main routine.
 	rsp, retry, err = c.do(r)
 
@@ -290,6 +602,7 @@ func (c *Client) DoOnce(r *Request) (rsp *http.Response, retry bool, err error)
 
 		// Ensure retry flag is unset
 		// when reached max attempts.
+		r.exhausted = true
 		retry = false
 
 	case c.badHosts.Has(r.Host):
@@ -297,6 +610,7 @@ func (c *Client) DoOnce(r *Request) (rsp *http.Response, retry bool, err error)
 		// check host hasn't been marked
 		// as a "badhost", i.e. erroring.
 		r.attempts = c.retries + 1
+		r.exhausted = true
 		retry = false
 	}
 
@@ -306,15 +620,121 @@ func (c *Client) DoOnce(r *Request) (rsp *http.Response, retry bool, err error)
 // do performs the "meat" of DoOnce(), but it's separated out to allow
 // easier wrapping of the response, retry, error returns with further logic.
 func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
+	switch host := r.URL.Hostname(); {
+	case isHiddenService(host):
+		// Hidden service hosts can only be reached
+		// via their proxy, and must never be looked
+		// up via DNS, so refuse if none configured.
+		if _, ok := c.hiddenServiceProxy(host); !ok {
+			return nil, false, fmt.Errorf("%w: %s", ErrHiddenService, host)
+		}
+
+	default:
+		if u, _ := c.proxy(r.Request); u == nil {
+			// Not proxied, the dialer
+			// will check the address.
+			break
+		}
+
+		// The dialer only sees the proxy address, so
+		// check the remote host's addresses here. This
+		// includes env proxies (ProxyFromEnvironment()).
+		if err := c.checkHost(r.Context(), host); err != nil {
+			if dnserr := errorsv2.AsV2[*net.DNSError](err); //
+			dnserr != nil && !dnserr.IsNotFound {
+				// Temporary lookup failure.
+				return nil, true, err
+			}
+			return nil, false, err
+		}
+	}
+
+	// Get the limits for this class of request.
+	limits := c.classes[ClassDefault]
+	if class := GetClass(r.Context()); int(class) < len(c.classes) {
+		limits = c.classes[class]
+	}
+
+	// Open request slots are released on body close, with a
+	// fallback after the request timeout (at which point body
+	// reads will fail anyway), or a minute if none was set.
+	hold := limits.Timeout
+	if hold <= 0 {
+		hold = time.Minute
+	}
+
+	// Wait on an open request slot for host.
+	release, err := c.slots.Acquire(r.Context(), r.Host, hold)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req := r.Request
+	cancel := context.CancelFunc(func() {})
+
+	if limits.Timeout > 0 {
+		// Apply timeout to this attempt, up to and including the body
+		// being read, just as http.Client{}.Timeout would have done.
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), limits.Timeout)
+		req = req.WithContext(ctx)
+	}
+
+	// done releases the request's
+	// slot and timeout resources.
+	done := func() {
+		cancel()
+		release()
+	}
+
+	defer func() {
+		if rsp == nil {
+			// No response body
+			// to close, done.
+			done()
+		}
+	}()
+
 	// Perform the HTTP request.
-	rsp, err = c.client.Do(r.Request)
+	start := time.Now()
+	rsp, err = c.client.Do(req)
+
+	var code int
+	if err == nil {
+		code = rsp.StatusCode
+	}
+
+	// Record request in host stats.
+	stats := c.stats.host(r.Host)
+	stats.record(code, time.Since(start), r.ContentLength)
+
+	switch {
+	case code >= 500 || (code == 0 && r.Context().Err() == nil):
+		// Host erroring or unreachable, (not
+		// just the caller giving up on us).
+		c.breakers.Failure(r.Host)
+
+	case code != 0:
+		// Host responding.
+		c.breakers.Success(r.Host)
+	}
+
 	if err != nil {
 
+		if r.Context().Err() == nil &&
+			errors.Is(err, context.DeadlineExceeded) {
+			// Only this attempt timed out,
+			// (not the caller's context),
+			// so this may still be retried.
+			return nil, true, err
+		}
+
 		if errorsv2.IsV2(err,
 			context.DeadlineExceeded,
 			context.Canceled,
 			ErrBodyTooLarge,
 			ErrReservedAddr,
+			ErrHiddenService,
 		) {
 			// Non-retryable errors.
 			return nil, false, err
@@ -346,6 +766,7 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 		// are generally temporary errors. For these
 		// we replace the response with a loggable error.
 		err = fmt.Errorf(`http response: %s`, rsp.Status)
+		err = gtserror.WithStatusCode(err, rsp.StatusCode)
 
 		// Search for a provided "Retry-After" header value.
 		if after := rsp.Header.Get("Retry-After"); after != "" {
@@ -361,6 +782,13 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 				r.backoff = at.Sub(now)
 			}
 
+			if r.backoff > 0 {
+				// Hold off all requests to this host
+				// for the time they asked, not just
+				// retries of this particular request.
+				c.limits.Pause(r.Host, r.backoff)
+			}
+
 			// Don't let their provided backoff exceed our max.
 			if max := baseBackoff * time.Duration(c.retries); //
 			r.backoff > max {
@@ -381,16 +809,22 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 
 	if limit = rsp.ContentLength; limit < 0 {
 		// If unknown, use max as reader limit.
-		limit = c.bodyMax
+		limit = limits.MaxBodySize
 	}
 
-	// Don't trust them, limit body reads.
-	rbody = io.LimitReader(rbody, limit)
+	// Don't trust them, limit body reads,
+	// counting bytes read into host stats.
+	rbody = &countingReader{
+		Reader: io.LimitReader(rbody, limit),
+		stats:  stats,
+	}
 
-	// Wrap closer to ensure entire body drained BEFORE close.
+	// Wrap closer to ensure entire body drained BEFORE close,
+	// and that the request is marked done AFTER close.
 	cbody = iotools.CloserAfterCallback(cbody, func() {
 		_, _ = discard.ReadFrom(rbody)
 	})
+	cbody = iotools.CloserCallback(cbody, done)
 
 	// Wrap body with limit.
 	rsp.Body = &struct {
@@ -399,7 +833,7 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 	}{rbody, cbody}
 
 	// Check response body not too large.
-	if rsp.ContentLength > c.bodyMax {
+	if rsp.ContentLength > limits.MaxBodySize {
 		_ = rsp.Body.Close()
 		return nil, false, ErrBodyTooLarge
 	}
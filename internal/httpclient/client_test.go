@@ -19,12 +19,15 @@ package httpclient_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/netip"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 )
@@ -143,3 +146,500 @@ func TestHTTPClientPrivateIP(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClientSetRanges(t *testing.T) {
+	client := httpclient.New(httpclient.Config{})
+
+	// Start a loopback test server.
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// Loopback should initially be blocked.
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrReservedAddr) {
+		t.Fatalf("dialing loopback address did not return expected error: %v", err)
+	}
+
+	// Live update to allow loopback.
+	client.SetRanges([]netip.Prefix{
+		netip.MustParsePrefix("127.0.0.1/8"),
+	}, nil)
+
+	// Loopback should now be dialable.
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	_ = rsp.Body.Close()
+
+	if allow, _ := client.Ranges(); len(allow) != 1 {
+		t.Fatalf("unexpected allow ranges: %v", allow)
+	}
+}
+
+func TestHTTPClientCheckDialable(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		BlockRanges: []netip.Prefix{
+			netip.MustParsePrefix("8.8.8.0/24"),
+		},
+	})
+
+	for _, test := range []struct {
+		host  string
+		rnge  string
+		valid bool
+	}{
+		{host: "127.0.0.1", rnge: "", valid: false},
+		{host: "8.8.8.8", rnge: "8.8.8.0/24", valid: false},
+		{host: "1.1.1.1", rnge: "", valid: true},
+	} {
+		checks, err := client.CheckDialable(context.Background(), test.host)
+		if err != nil {
+			t.Fatalf("error checking %s: %v", test.host, err)
+		}
+
+		if len(checks) != 1 {
+			t.Fatalf("expected 1 check result for %s, got %d", test.host, len(checks))
+		}
+
+		check := checks[0]
+
+		if valid := (check.Err == nil); valid != test.valid {
+			t.Errorf("expected %s dialable=%t, got %t", test.host, test.valid, valid)
+		}
+
+		if rnge := check.Range; rnge.IsValid() && rnge.String() != test.rnge {
+			t.Errorf("expected %s range=%q, got %q", test.host, test.rnge, rnge)
+		} else if !rnge.IsValid() && test.rnge != "" {
+			t.Errorf("expected %s range=%q, got none", test.host, test.rnge)
+		}
+	}
+}
+
+func TestHTTPClientRetryAfter(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	var requests int
+
+	// Start a test server that asks us to hold off for a minute.
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	// First request reaches the server, which rate limits us.
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	wreq := httpclient.WrapRequest(req)
+	if _, _, err := client.DoOnce(&wreq); err == nil {
+		t.Fatal("expected error response")
+	}
+
+	// Further requests to host are held back without
+	// reaching the server, asking the caller to retry
+	// once the held off time has passed.
+	req, _ = http.NewRequest("GET", srv.URL+"/other", nil)
+	wreq = httpclient.WrapRequest(req)
+	_, retry, err := client.DoOnce(&wreq)
+	if !errors.Is(err, httpclient.ErrHostRateLimited) {
+		t.Fatalf("expected host rate limited error, got: %v", err)
+	}
+	if !retry || wreq.BackOff() <= 50*time.Second {
+		t.Fatalf("unexpected retry=%t backoff=%s", retry, wreq.BackOff())
+	}
+
+	// The minute hold off is longer than
+	// we'd usually wait, so this fails fast.
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrHostRateLimited) {
+		t.Fatalf("expected host rate limited error, got: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request to server, got %d", requests)
+	}
+}
+
+func TestHTTPClientHostRequestBudget(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+		HostRequestBudget: 2,
+		HostRequestWindow: time.Hour,
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// Requests within budget are permitted.
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", srv.URL, nil)
+		rsp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("error performing client request: %v", err)
+		}
+		_ = rsp.Body.Close()
+	}
+
+	// Budget is now spent for the window.
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrHostRateLimited) {
+		t.Fatalf("expected host rate limited error, got: %v", err)
+	}
+}
+
+func TestHTTPClientRetryIdempotent(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	var requests int
+
+	// Start a test server that always errors.
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// A POST (not marked idempotent) is never retried.
+	req, _ := http.NewRequest("POST", srv.URL, bytes.NewReader([]byte("{}")))
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected error response")
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request to server, got %d", requests)
+	}
+
+	// A GET would be retried, but not when the
+	// backoff would exceed the request deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ = http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected error response")
+	} else if ctx.Err() != nil {
+		t.Fatalf("expected retry to give up before deadline: %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests to server, got %d", requests)
+	}
+}
+
+func TestHTTPClientMaxOpenConnsPerHost(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+		MaxOpenConnsPerHost: 1,
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("hello world!"))
+	}))
+	defer srv.Close()
+
+	// Open a request, leaving its body unclosed.
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+
+	// The host's only slot is held by the open
+	// body, so another request can't be made.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got: %v", err)
+	}
+
+	// Closing the body releases the slot.
+	_ = rsp.Body.Close()
+
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	rsp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	_ = rsp.Body.Close()
+}
+
+func TestHTTPClientStats(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write([]byte("hello world!"))
+	}))
+	defer srv.Close()
+
+	for _, path := range []string{"/", "/missing"} {
+		req, _ := http.NewRequest("POST", srv.URL+path, bytes.NewReader([]byte("{}")))
+		rsp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("error performing client request: %v", err)
+		}
+		_, _ = io.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+	}
+
+	stats := client.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("expected stats for 1 host, got %d", len(stats))
+	}
+
+	s := stats[0]
+	switch {
+	case s.Host != srv.Listener.Addr().String():
+		t.Errorf("unexpected host: %s", s.Host)
+	case s.Requests != 2 || s.Errors != 0:
+		t.Errorf("unexpected requests=%d errors=%d", s.Requests, s.Errors)
+	case s.Statuses[2] != 1 || s.Statuses[4] != 1:
+		t.Errorf("unexpected statuses: %v", s.Statuses)
+	case s.BytesSent != 4 || s.BytesReceived != 12:
+		t.Errorf("unexpected sent=%d received=%d", s.BytesSent, s.BytesReceived)
+	}
+}
+
+func TestHTTPClientClassLimits(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+		Timeout:            time.Second,
+		DisableCompression: true,
+		Delivery: httpclient.ClassLimits{
+			Timeout: 50 * time.Millisecond,
+		},
+		Dereference: httpclient.ClassLimits{
+			MaxBodySize: 4,
+		},
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = rw.Write([]byte("hello world!"))
+	}))
+	defer srv.Close()
+
+	// A slow delivery exceeds the delivery timeout.
+	ctx := httpclient.SetClass(context.Background(), httpclient.ClassDelivery)
+	req, _ := http.NewRequestWithContext(ctx, "POST", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got: %v", err)
+	}
+
+	// The same request with default class falls under the default timeout.
+	req, _ = http.NewRequest("POST", srv.URL, nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	_ = rsp.Body.Close()
+
+	// A dereference exceeds the dereference body size limit.
+	ctx = httpclient.SetClass(context.Background(), httpclient.ClassDereference)
+	req, _ = http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrBodyTooLarge) {
+		t.Fatalf("expected body too large error, got: %v", err)
+	}
+
+	// While media falls back to the default body size limit.
+	ctx = httpclient.SetClass(context.Background(), httpclient.ClassMedia)
+	req, _ = http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	rsp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	_ = rsp.Body.Close()
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	// Proxy server, (on loopback, which isn't in the
+	// client allowed ranges), answering on behalf of
+	// the remote host it was asked to fetch from.
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("proxied " + r.URL.Host))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := httpclient.New(httpclient.Config{
+		Proxy: proxyURL,
+	})
+
+	req, _ := http.NewRequest("GET", "http://93.184.215.14/", nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, _ := io.ReadAll(rsp.Body)
+	if string(body) != "proxied 93.184.215.14" {
+		t.Fatalf("unexpected response body: %q", body)
+	}
+
+	// Requests to reserved IPs are still refused.
+	for _, addr := range privateIPs {
+		req, _ := http.NewRequest("GET", addr, nil)
+		if _, err := client.Do(req); !errors.Is(err, httpclient.ErrReservedAddr) {
+			t.Errorf("expected reserved addr error for %s, got: %v", addr, err)
+		}
+	}
+
+	// As are hostnames resolving to reserved IPs,
+	// despite the proxy being the one to dial them.
+	req, _ = http.NewRequest("GET", "http://localhost/", nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrReservedAddr) {
+		t.Errorf("expected reserved addr error for localhost, got: %v", err)
+	}
+}
+
+func TestHTTPClientProxyAllowedHostname(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("proxied " + r.URL.Host))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			netip.MustParsePrefix("127.0.0.1/8"),
+			netip.MustParsePrefix("::1/128"),
+		},
+		Proxy: proxyURL,
+	})
+
+	// Hostnames resolving to allowed
+	// IPs are passed on to the proxy.
+	req, _ := http.NewRequest("GET", "http://localhost/", nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, _ := io.ReadAll(rsp.Body)
+	if string(body) != "proxied localhost" {
+		t.Fatalf("unexpected response body: %q", body)
+	}
+}
+
+func TestHTTPClientCircuitBreaker(t *testing.T) {
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+		BreakerFailures: 2,
+		BreakerCooldown: 100 * time.Millisecond,
+	})
+
+	var (
+		requests int
+		status   = http.StatusInternalServerError
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	do := func() error {
+		req, _ := http.NewRequest("POST", srv.URL, nil)
+		rsp, err := client.Do(req)
+		if err == nil {
+			_ = rsp.Body.Close()
+		}
+		return err
+	}
+
+	// Fail enough requests to open the circuit.
+	for i := 0; i < 2; i++ {
+		if err := do(); err == nil {
+			t.Fatal("expected error response")
+		}
+	}
+
+	// Requests now fail fast without reaching host.
+	if err := do(); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests to reach host, got %d", requests)
+	}
+
+	// After cool-down a trial request is
+	// permitted, closing circuit on success.
+	time.Sleep(150 * time.Millisecond)
+	status = http.StatusOK
+	for i := 0; i < 2; i++ {
+		if err := do(); err != nil {
+			t.Fatalf("error performing client request: %v", err)
+		}
+	}
+}
+
+func TestHTTPClientHiddenService(t *testing.T) {
+	// Onion proxy server, answering on
+	// behalf of the hidden service host.
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("proxied " + r.URL.Host))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := httpclient.New(httpclient.Config{
+		OnionProxy: proxyURL,
+	})
+
+	const onion = "gotosocialexampleexampleexampleexampleexampleexampleexam.onion"
+	req, _ := http.NewRequest("GET", "http://"+onion+"/", nil)
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, _ := io.ReadAll(rsp.Body)
+	if string(body) != "proxied "+onion {
+		t.Fatalf("unexpected response body: %q", body)
+	}
+
+	// No I2P proxy was configured, so these are refused.
+	req, _ = http.NewRequest("GET", "http://gotosocial.i2p/", nil)
+	if _, err := client.Do(req); !errors.Is(err, httpclient.ErrHiddenService) {
+		t.Fatalf("expected hidden service error, got: %v", err)
+	}
+}
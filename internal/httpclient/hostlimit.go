@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxRetryAfter is the longest we will hold
+// off all requests to a host on account of a
+// "Retry-After" header it sent us.
+const maxRetryAfter = time.Hour
+
+// hostLimits tracks per-host outbound request
+// budgets, along with any periods a host has
+// asked us to hold off for via "Retry-After".
+type hostLimits struct {
+	budget int           // requests per window, <= 0 = unlimited
+	window time.Duration // budget window duration
+	hosts  cache.TTLCache[string, *hostLimit]
+	mu     sync.Mutex
+}
+
+// hostLimit contains the
+// limit state of one host.
+type hostLimit struct {
+	// start of current budget
+	// window, and no. requests
+	// made to host within it.
+	start time.Time
+	count int
+
+	// time before which host asked
+	// us not to make any requests.
+	until time.Time
+}
+
+func newHostLimits(budget int, window time.Duration) *hostLimits {
+	if window <= 0 {
+		// By default, a
+		// 5 minute window.
+		window = 5 * time.Minute
+	}
+
+	l := &hostLimits{
+		budget: budget,
+		window: window,
+	}
+
+	// Keep host state at least as long
+	// as it can limit requests for.
+	l.hosts = cache.NewTTL[string, *hostLimit](0, 8192, 0)
+	l.hosts.SetTTL(max(window, maxRetryAfter), false)
+	if !l.hosts.Start(time.Minute) {
+		log.Panic(nil, "failed to start host limits cache")
+	}
+
+	return l
+}
+
+// Reserve attempts to reserve a request to host from its
+// budget, returning zero on success, else the time to
+// wait until a request to host will next be permitted.
+func (l *hostLimits) Reserve(host string) time.Duration {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hosts.Get(host)
+	if !ok {
+		if l.budget <= 0 {
+			// Nothing to
+			// track here.
+			return 0
+		}
+
+		h = &hostLimit{start: now}
+	}
+
+	if now.Before(h.until) {
+		// Host asked us to hold off.
+		return h.until.Sub(now)
+	}
+
+	if l.budget > 0 {
+		if now.Sub(h.start) >= l.window {
+			// Start a new budget window.
+			h.start, h.count = now, 0
+		}
+
+		if h.count >= l.budget {
+			// Budget spent, wait for next window.
+			return h.start.Add(l.window).Sub(now)
+		}
+
+		h.count++
+	}
+
+	l.hosts.Set(host, h)
+	return 0
+}
+
+// Pause marks host as having asked us to hold off
+// on further requests for given duration (capped
+// at maxRetryAfter), e.g. due to "Retry-After".
+func (l *hostLimits) Pause(host string, after time.Duration) {
+	now := time.Now()
+	until := now.Add(min(after, maxRetryAfter))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hosts.Get(host)
+	if !ok {
+		h = &hostLimit{start: now}
+	}
+
+	if until.After(h.until) {
+		h.until = until
+	}
+
+	l.hosts.Set(host, h)
+}
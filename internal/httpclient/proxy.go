@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// proxyPorts are the default ports
+// dialed for each proxy URL scheme.
+var proxyPorts = map[string]string{
+	"http":    "80",
+	"https":   "443",
+	"socks5":  "1080",
+	"socks5h": "1080",
+}
+
+// proxyAddr returns the address the transport will
+// dial for proxy u, i.e. host with port always included.
+func proxyAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = proxyPorts[u.Scheme]
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// isOnion returns whether host is a Tor hidden service.
+func isOnion(host string) bool {
+	return hasSuffixFold(strings.TrimSuffix(host, "."), ".onion")
+}
+
+// isI2P returns whether host is an I2P eepsite.
+func isI2P(host string) bool {
+	return hasSuffixFold(strings.TrimSuffix(host, "."), ".i2p")
+}
+
+// isHiddenService returns whether host is an onion or I2P host,
+// both special-use domains that mustn't be resolved via DNS.
+func isHiddenService(host string) bool {
+	return isOnion(host) || isI2P(host)
+}
+
+// hiddenServiceProxy returns the configured
+// proxy for hidden service host, if any.
+func (c *Client) hiddenServiceProxy(host string) (*url.URL, bool) {
+	switch {
+	case c.onionProxy != nil && isOnion(host):
+		return c.onionProxy, true
+	case c.i2pProxy != nil && isI2P(host):
+		return c.i2pProxy, true
+	default:
+		return nil, false
+	}
+}
+
+// hasSuffixFold is strings.HasSuffix() but case insensitive.
+func hasSuffixFold(s, suffix string) bool {
+	return len(s) >= len(suffix) &&
+		strings.EqualFold(s[len(s)-len(suffix):], suffix)
+}
@@ -18,9 +18,11 @@
 package httpclient
 
 import (
+	"math/rand/v2"
 	"net/http"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -38,6 +40,11 @@ type Request struct {
 	// Delivery attempts.
 	attempts uint
 
+	// Set when request failed with
+	// a temporary error on its final
+	// permitted attempt (see Exhausted).
+	exhausted bool
+
 	// log fields.
 	log.Entry
 
@@ -68,8 +75,31 @@ func WrapRequest(r *http.Request) Request {
 func (r *Request) BackOff() time.Duration {
 	if r.backoff <= 0 {
 		// No backoff dur found, set our predefined
-		// backoff according to a multiplier of 2^n.
-		r.backoff = baseBackoff * 1 << (r.attempts + 1)
+		// backoff according to a multiplier of 2^n,
+		// with up to half of it jittered so retries
+		// of many requests don't all land at once.
+		backoff := baseBackoff * 1 << (r.attempts + 1)
+		r.backoff = backoff/2 + rand.N(backoff/2)
 	}
 	return r.backoff
 }
+
+// Idempotent returns whether the request is safe to
+// automatically retry, i.e. it is GET / HEAD, or has
+// been marked idempotent via gtscontext.SetIdempotent().
+func (r *Request) Idempotent() bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	default:
+		return gtscontext.IsIdempotent(r.Context())
+	}
+}
+
+// Exhausted returns whether the last attempt at request failed with a temporary
+// error, i.e. one that would usually be retried, but no further attempts were
+// permitted, either by reaching max retries or the host being marked as "bad".
+// Such requests may be worth attempting again at a much later time.
+func (r *Request) Exhausted() bool {
+	return r.exhausted
+}
@@ -0,0 +1,278 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/miekg/dns"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// dnsMinTTL is the minimum time for which DNS
+	// responses are cached, so that even records with
+	// very low TTLs are only looked up once during a
+	// large fan-out of deliveries.
+	dnsMinTTL = 10 * time.Second
+
+	// dnsMaxTTL is the maximum time
+	// positive DNS responses are cached.
+	dnsMaxTTL = time.Hour
+
+	// dnsMaxNegTTL is the maximum time negative
+	// (NXDOMAIN / no data) DNS responses are cached.
+	dnsMaxNegTTL = 5 * time.Minute
+)
+
+// dnsCache is an in-process cache of DNS responses, sat
+// beneath the Go resolver by way of net.Resolver{}.Dial.
+// The resolver itself continues to handle /etc/hosts, search
+// domains, address sorting etc, while responses from upstream
+// nameservers are cached by question for their record TTLs.
+// Negative responses are cached according to RFC 2308.
+type dnsCache struct {
+	cache cache.TTLCache[dnsKey, *dnsEntry]
+}
+
+// dnsKey is the cache key for
+// a DNS question's response.
+type dnsKey struct {
+	name  string
+	qtype uint16
+}
+
+// dnsEntry is a
+// cached DNS response.
+type dnsEntry struct {
+	msg    *dns.Msg
+	expiry time.Time
+}
+
+func newDNSCache() *dnsCache {
+	c := new(dnsCache)
+	c.cache = cache.NewTTL[dnsKey, *dnsEntry](0, 8192, 0)
+	c.cache.SetTTL(dnsMaxTTL, false)
+	if !c.cache.Start(time.Minute) {
+		log.Panic(nil, "failed to start dns cache")
+	}
+	return c
+}
+
+// Resolver returns a new net.Resolver{}
+// that makes lookups via this cache.
+func (c *dnsCache) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial:     c.dial,
+	}
+}
+
+// dial implements net.Resolver{}.Dial, returning a conn to
+// DNS server at address, via which queries are answered
+// from cache where possible. The Go resolver talks to this
+// in the (length-prefixed) stream format, whatever network.
+func (c *dnsCache) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	return &dnsConn{
+		ctx:     ctx,
+		cache:   c,
+		network: network,
+		address: address,
+	}, nil
+}
+
+// exchange answers DNS query q from cache, or
+// by querying DNS server at address on network.
+func (c *dnsCache) exchange(ctx context.Context, network, address string, q *dns.Msg) (*dns.Msg, error) {
+	if len(q.Question) != 1 {
+		// Only cache standard
+		// single question queries.
+		return c.query(ctx, network, address, q)
+	}
+
+	key := dnsKey{
+		name:  strings.ToLower(q.Question[0].Name),
+		qtype: q.Question[0].Qtype,
+	}
+
+	if e, ok := c.cache.Get(key); ok {
+		if time.Now().Before(e.expiry) {
+			// Still valid, respond with
+			// cached message under query ID.
+			rsp := e.msg.Copy()
+			rsp.Id = q.Id
+			return rsp, nil
+		}
+	}
+
+	rsp, err := c.query(ctx, network, address, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl, ok := cacheTTL(rsp); ok {
+		c.cache.Set(key, &dnsEntry{
+			msg:    rsp.Copy(),
+			expiry: time.Now().Add(ttl),
+		})
+	}
+
+	return rsp, nil
+}
+
+// query sends DNS query q to server at address on network.
+func (c *dnsCache) query(ctx context.Context, network, address string, q *dns.Msg) (*dns.Msg, error) {
+	client := dns.Client{Net: network}
+	rsp, _, err := client.ExchangeContext(ctx, q, address)
+	return rsp, err
+}
+
+// cacheTTL returns the duration for which DNS response
+// may be cached, or false if it shouldn't be cached.
+func cacheTTL(rsp *dns.Msg) (time.Duration, bool) {
+	if rsp.Truncated {
+		// Resolver will retry this over TCP.
+		return 0, false
+	}
+
+	switch rsp.Rcode {
+	case dns.RcodeSuccess:
+		if len(rsp.Answer) > 0 {
+			// Positive response, cache for
+			// the lowest TTL of the answers.
+			ttl := rsp.Answer[0].Header().Ttl
+			for _, rr := range rsp.Answer[1:] {
+				ttl = min(ttl, rr.Header().Ttl)
+			}
+			return clampTTL(ttl, dnsMaxTTL), true
+		}
+
+		// No data, negative
+		// response (see below).
+		fallthrough
+
+	case dns.RcodeNameError:
+		// Negative responses are cached for the
+		// lesser of the authority SOA record's TTL
+		// and its minimum field (RFC 2308 section 5).
+		for _, rr := range rsp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				ttl := min(soa.Hdr.Ttl, soa.Minttl)
+				return clampTTL(ttl, dnsMaxNegTTL), true
+			}
+		}
+
+		// Without an SOA record
+		// negative responses must
+		// not be cached.
+		return 0, false
+
+	default:
+		// i.e. SERVFAIL, REFUSED
+		// etc, don't cache these.
+		return 0, false
+	}
+}
+
+// clampTTL converts TTL in seconds to duration
+// within the range of dnsMinTTL to given max.
+func clampTTL(ttl uint32, maxTTL time.Duration) time.Duration {
+	d := time.Duration(ttl) * time.Second
+	return min(maxTTL, max(d, dnsMinTTL))
+}
+
+// dnsConn implements net.Conn{} for the Go resolver,
+// answering written DNS queries via its dnsCache{}.
+type dnsConn struct {
+	ctx     context.Context
+	cache   *dnsCache
+	network string
+	address string
+	wbuf    bytes.Buffer
+	rbuf    bytes.Buffer
+}
+
+func (c *dnsConn) Write(b []byte) (int, error) {
+	c.wbuf.Write(b)
+
+	// Queries are prefixed
+	// with 2 byte length.
+	buf := c.wbuf.Bytes()
+	if len(buf) < 2 {
+		return len(b), nil
+	}
+	l := int(buf[0])<<8 | int(buf[1])
+	if len(buf) < 2+l {
+		return len(b), nil
+	}
+
+	// Unpack the full query message.
+	q := new(dns.Msg)
+	if err := q.Unpack(buf[2 : 2+l]); err != nil {
+		return 0, err
+	}
+	c.wbuf.Next(2 + l)
+
+	// Get response to query, from cache or upstream.
+	rsp, err := c.cache.exchange(c.ctx, c.network, c.address, q)
+	if err != nil {
+		return 0, err
+	}
+
+	p, err := rsp.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	// Buffer length-prefixed
+	// response for reading.
+	c.rbuf.WriteByte(byte(len(p) >> 8))
+	c.rbuf.WriteByte(byte(len(p)))
+	c.rbuf.Write(p)
+
+	return len(b), nil
+}
+
+func (c *dnsConn) Read(b []byte) (int, error) {
+	if c.rbuf.Len() == 0 {
+		return 0, errors.New("no dns response")
+	}
+	return c.rbuf.Read(b)
+}
+
+func (c *dnsConn) Close() error                       { return nil }
+func (c *dnsConn) LocalAddr() net.Addr                { return dnsAddr{network: c.network} }
+func (c *dnsConn) RemoteAddr() net.Addr               { return dnsAddr{c.network, c.address} }
+func (c *dnsConn) SetDeadline(t time.Time) error      { return nil }
+func (c *dnsConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *dnsConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// dnsAddr implements net.Addr{}.
+type dnsAddr struct {
+	network string
+	address string
+}
+
+func (a dnsAddr) Network() string { return a.network }
+func (a dnsAddr) String() string  { return a.address }
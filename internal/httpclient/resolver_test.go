@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestDNSCache(t *testing.T) {
+	var queries atomic.Int32
+
+	// Start test DNS server, with one known
+	// name and NXDOMAIN for everything else.
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, q *dns.Msg) {
+		queries.Add(1)
+		rsp := new(dns.Msg)
+		rsp.SetReply(q)
+		if q.Question[0].Name == "example.org." {
+			rr, _ := dns.NewRR("example.org. 60 IN A 93.184.215.14")
+			rsp.Answer = append(rsp.Answer, rr)
+		} else {
+			rsp.Rcode = dns.RcodeNameError
+			rr, _ := dns.NewRR("org. 3600 IN SOA a0.org.afilias-nst.info. hostmaster.donuts.email. 1 7200 900 1209600 30")
+			rsp.Ns = append(rsp.Ns, rr)
+		}
+		_ = w.WriteMsg(rsp)
+	})}
+	go func() { _ = srv.ActivateAndServe() }()
+	defer func() { _ = srv.Shutdown() }()
+
+	c := newDNSCache()
+	addr := pc.LocalAddr().String()
+
+	for _, test := range []struct {
+		name  string
+		rcode int
+	}{
+		{name: "example.org.", rcode: dns.RcodeSuccess},
+		{name: "missing.example.org.", rcode: dns.RcodeNameError},
+	} {
+		before := queries.Load()
+
+		// Perform the same query a few times,
+		// only the first should hit the server.
+		for i := 0; i < 3; i++ {
+			q := new(dns.Msg)
+			q.SetQuestion(test.name, dns.TypeA)
+
+			rsp, err := c.exchange(context.Background(), "udp", addr, q)
+			if err != nil {
+				t.Fatalf("error querying %s: %v", test.name, err)
+			}
+
+			if rsp.Id != q.Id {
+				t.Errorf("response id %d did not match query id %d", rsp.Id, q.Id)
+			}
+
+			if rsp.Rcode != test.rcode {
+				t.Errorf("unexpected rcode for %s: %d", test.name, rsp.Rcode)
+			}
+		}
+
+		if n := queries.Load() - before; n != 1 {
+			t.Errorf("expected 1 upstream query for %s, got %d", test.name, n)
+		}
+	}
+}
@@ -83,29 +83,36 @@ func (s *Sanitizer) Sanitize(ntwrk, addr string, _ syscall.RawConn) error {
 		return ErrInvalidNetwork
 	}
 
-	// Separate the IP.
+	// Separate the IP
+	// and check it.
 	ip := ipport.Addr()
+	_, err = s.Check(ip)
+	return err
+}
 
+// Check checks whether ip is permitted to be dialed, returning ErrReservedAddr if not.
+// The returned prefix is the configured Allow / Block range that the IP matched, if any.
+func (s *Sanitizer) Check(ip netip.Addr) (netip.Prefix, error) {
 	// Check if this IP is explicitly allowed.
 	for i := 0; i < len(s.Allow); i++ {
 		if s.Allow[i].Contains(ip) {
-			return nil
+			return s.Allow[i], nil
 		}
 	}
 
 	// Check if this IP is explicitly blocked.
 	for i := 0; i < len(s.Block); i++ {
 		if s.Block[i].Contains(ip) {
-			return ErrReservedAddr
+			return s.Block[i], ErrReservedAddr
 		}
 	}
 
 	// Validate this is a safe IP.
 	if !SafeIP(ip) {
-		return ErrReservedAddr
+		return netip.Prefix{}, ErrReservedAddr
 	}
 
-	return nil
+	return netip.Prefix{}, nil
 }
 
 // SafeIP returns whether ip is an IPv4/6
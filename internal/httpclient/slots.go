@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostSlots limits the number of concurrently
+// open requests to each host, where a request is
+// considered open until its response body closed.
+type hostSlots struct {
+	max   int // max open per host
+	hosts map[string]*hostSlot
+	mu    sync.Mutex
+}
+
+// hostSlot is the slot
+// semaphore for one host.
+type hostSlot struct {
+	ch   chan struct{}
+	refs int
+}
+
+func newHostSlots(max int) *hostSlots {
+	return &hostSlots{
+		max:   max,
+		hosts: make(map[string]*hostSlot),
+	}
+}
+
+// Acquire blocks until a request slot is available for host, or context
+// is cancelled, returning a func to release the slot. The release func is
+// safe to call multiple times. As a fallback against callers that never
+// release (e.g. forgetting to close a response body), the slot will be
+// automatically released once held for longer than given hold duration.
+func (s *hostSlots) Acquire(ctx context.Context, host string, hold time.Duration) (func(), error) {
+	s.mu.Lock()
+	slot, ok := s.hosts[host]
+	if !ok {
+		slot = &hostSlot{ch: make(chan struct{}, s.max)}
+		s.hosts[host] = slot
+	}
+	slot.refs++
+	s.mu.Unlock()
+
+	select {
+	case slot.ch <- struct{}{}:
+	case <-ctx.Done():
+		s.unref(host, slot)
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			<-slot.ch
+			s.unref(host, slot)
+		})
+	}
+
+	// Set fallback release timer, so a leaked
+	// slot can never deadlock requests to host.
+	timer := time.AfterFunc(hold, release)
+
+	return func() {
+		timer.Stop()
+		release()
+	}, nil
+}
+
+// unref drops a reference to host's slot,
+// removing it from the map if now unused.
+func (s *hostSlots) unref(host string, slot *hostSlot) {
+	s.mu.Lock()
+	if slot.refs--; slot.refs == 0 {
+		delete(s.hosts, host)
+	}
+	s.mu.Unlock()
+}
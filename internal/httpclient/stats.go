@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HostStats contains statistics
+// of outgoing requests to a host.
+type HostStats struct {
+	// Host requests were made to.
+	Host string
+
+	// Requests is the number of requests made.
+	Requests uint64
+
+	// Errors is the number of requests
+	// that failed without any response.
+	Errors uint64
+
+	// Statuses is the number of responses by
+	// status class, i.e. Statuses[2] is 2xx.
+	Statuses [6]uint64
+
+	// Latency is the total time spent
+	// waiting on response headers.
+	Latency time.Duration
+
+	// BytesSent is the total no.
+	// request body bytes sent.
+	BytesSent uint64
+
+	// BytesReceived is the total no.
+	// response body bytes received.
+	BytesReceived uint64
+}
+
+// AvgLatency returns the average time
+// spent waiting on response headers.
+func (s *HostStats) AvgLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Requests) // #nosec G115 -- won't overflow
+}
+
+// hostStats is the live, atomically
+// updated form of HostStats{}.
+type hostStats struct {
+	requests  atomic.Uint64
+	errors    atomic.Uint64
+	statuses  [6]atomic.Uint64
+	latency   atomic.Int64
+	bytesSent atomic.Uint64
+	bytesRecv atomic.Uint64
+}
+
+// stats tracks outgoing
+// request stats per host.
+type stats struct {
+	hosts map[string]*hostStats
+	mu    sync.RWMutex
+}
+
+// host returns stats for host, allocating if needed.
+func (s *stats) host(host string) *hostStats {
+	s.mu.RLock()
+	hs := s.hosts[host]
+	s.mu.RUnlock()
+
+	if hs != nil {
+		return hs
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.hosts == nil {
+		s.hosts = make(map[string]*hostStats)
+	}
+
+	if hs = s.hosts[host]; hs == nil {
+		hs = new(hostStats)
+		s.hosts[host] = hs
+	}
+
+	return hs
+}
+
+// record records the result of a request to host, which
+// either errored (code = 0), or responded with status code.
+func (s *hostStats) record(code int, latency time.Duration, sent int64) {
+	s.requests.Add(1)
+	s.latency.Add(int64(latency))
+
+	if sent > 0 {
+		s.bytesSent.Add(uint64(sent)) // #nosec G115 -- checked positive
+	}
+
+	if class := code / 100; class > 0 && class < len(s.statuses) {
+		s.statuses[class].Add(1)
+	} else {
+		s.errors.Add(1)
+	}
+}
+
+// Stats returns outgoing request statistics for each host
+// requests have been made to since the client was created.
+func (c *Client) Stats() []HostStats {
+	c.stats.mu.RLock()
+	defer c.stats.mu.RUnlock()
+
+	out := make([]HostStats, 0, len(c.stats.hosts))
+	for host, hs := range c.stats.hosts {
+		s := HostStats{
+			Host:          host,
+			Requests:      hs.requests.Load(),
+			Errors:        hs.errors.Load(),
+			Latency:       time.Duration(hs.latency.Load()),
+			BytesSent:     hs.bytesSent.Load(),
+			BytesReceived: hs.bytesRecv.Load(),
+		}
+		for i := range hs.statuses {
+			s.Statuses[i] = hs.statuses[i].Load()
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
+
+// countingReader wraps a reader
+// to count bytes read into stats.
+type countingReader struct {
+	io.Reader
+	stats *hostStats
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	r.stats.bytesRecv.Add(uint64(n)) // #nosec G115 -- n >= 0
+	return n, err
+}
@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// scriptLangs maps unicode scripts used
+// (near enough) exclusively by a single
+// language, to that language's ISO 639-1 code.
+//
+// Scripts shared by many languages (Latin,
+// Cyrillic, Arabic, Devanagari, etc) are
+// deliberately absent, since those can't
+// be told apart by script alone.
+var scriptLangs = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Thai, "th"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Armenian, "hy"},
+	{unicode.Georgian, "ka"},
+	{unicode.Khmer, "km"},
+	{unicode.Lao, "lo"},
+	{unicode.Myanmar, "my"},
+	{unicode.Sinhala, "si"},
+	{unicode.Tamil, "ta"},
+	{unicode.Telugu, "te"},
+	{unicode.Kannada, "kn"},
+	{unicode.Malayalam, "ml"},
+	{unicode.Gujarati, "gu"},
+	{unicode.Gurmukhi, "pa"},
+	{unicode.Ethiopic, "am"},
+	{unicode.Tibetan, "bo"},
+}
+
+// Detect makes a best-effort guess at the language the given
+// text is written in, returning an ISO 639-1 language code, or
+// an empty string if the language could not be reliably detected.
+//
+// This is NOT a statistical language detector: it only looks at
+// the unicode script(s) the text is written in, so only languages
+// with an (almost) unique script can be detected, e.g. Korean from
+// Hangul or Japanese from kana. Text in shared scripts (Latin, Han,
+// Cyrillic, Arabic etc) is never guessed at, and returns "".
+// Mentions, hashtags and URLs are ignored.
+func Detect(text string) string {
+	var (
+		letters int
+		kana    int
+		han     int
+		counts  = make([]int, len(scriptLangs))
+	)
+
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(word, "@") ||
+			strings.HasPrefix(word, "#") ||
+			strings.Contains(word, "://") {
+			// Skip mentions, hashtags, links.
+			continue
+		}
+
+		for _, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+
+			letters++
+
+			switch {
+			case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+				kana++
+				continue
+			case unicode.Is(unicode.Han, r):
+				han++
+				continue
+			}
+
+			for i, sl := range scriptLangs {
+				if unicode.Is(sl.script, r) {
+					counts[i]++
+					break
+				}
+			}
+		}
+	}
+
+	if letters == 0 {
+		// Nothing to go on.
+		return ""
+	}
+
+	// majority returns whether given
+	// count is over half of all letters.
+	majority := func(n int) bool {
+		return n*2 > letters
+	}
+
+	// Japanese mixes kana with Han characters,
+	// so any kana at all alongside a majority of
+	// Han + kana indicates Japanese. Han alone is
+	// shared by Chinese, Japanese and others, so
+	// isn't enough to go on.
+	if kana > 0 && majority(kana+han) {
+		return "ja"
+	}
+
+	for i, n := range counts {
+		if majority(n) {
+			return scriptLangs[i].lang
+		}
+	}
+
+	return ""
+}
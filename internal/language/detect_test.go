@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package language_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+)
+
+func TestDetect(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{"hello world!", ""},
+		{"안녕하세요 세계", "ko"},
+		{"こんにちは世界", "ja"},
+		{"你好世界", ""},
+		{"Γειά σου Κόσμε", "el"},
+		{"שלום עולם", "he"},
+		{"สวัสดีชาวโลก", "th"},
+		{"@someone@example.org https://example.org/some/path #hashtag 안녕하세요", "ko"},
+		{"hello everyone, 你好", ""},
+		{"🎉🎉🎉 :party:", ""},
+	} {
+		if lang := language.Detect(test.text); lang != test.expected {
+			t.Errorf("expected %q for text %q, got %q", test.expected, test.text, lang)
+		}
+	}
+}
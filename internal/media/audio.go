@@ -0,0 +1,742 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxAudioMetaSize is the maximum size of a single piece of
+// audio metadata (tag, metadata block, or ogg packet) that
+// we're willing to load into memory when probing audio.
+const maxAudioMetaSize = 16 * 1024 * 1024 // 16MiB
+
+type gtsAudio struct {
+	artwork  *gtsImage // embedded cover art, may be nil
+	duration float32   // in seconds
+	bitrate  uint64
+}
+
+// decodeAudio probes the given audio stream of contentType for
+// its duration and bitrate, and decodes any embedded cover art.
+func decodeAudio(r io.Reader, contentType string) (*gtsAudio, error) {
+	// Check if audio stream supports
+	// seeking, usually when *os.File.
+	rsc, ok := r.(io.ReadSeekCloser)
+	if !ok {
+		var err error
+
+		// Store stream to temporary location
+		// in order that we can get seek-reads.
+		rsc, err = iotools.TempFileSeeker(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+		}
+
+		defer func() {
+			// Ensure temp. read seeker closed.
+			if err := rsc.Close(); err != nil {
+				log.Errorf(nil, "error closing temp file seeker: %s", err)
+			}
+		}()
+	}
+
+	// Determine total stream size.
+	size, err := rsc.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking audio end: %w", err)
+	}
+
+	if _, err := rsc.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error seeking audio start: %w", err)
+	}
+
+	info, err := probeAudio(rsc, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	audio := &gtsAudio{
+		duration: float32(info.duration),
+
+		// Average bitrate of the audio data, excluding
+		// any tags / headers (which may contain artwork).
+		bitrate: uint64(math.Round(float64((size-info.metaSize)*8) / info.duration)),
+	}
+
+	if artwork := info.artwork; len(artwork) > 0 {
+		// Artwork is optional, so failing to
+		// decode it shouldn't fail the audio.
+		audio.artwork, err = decodeImage(
+			bytes.NewReader(artwork),
+			imaging.AutoOrientation(true),
+		)
+		if err != nil {
+			log.Warnf(nil, "error decoding embedded audio artwork: %v", err)
+		}
+	}
+
+	return audio, nil
+}
+
+// audioInfo contains the details
+// probed from an audio container.
+type audioInfo struct {
+	duration float64 // in seconds
+	metaSize int64   // bytes of non-audio data
+	artwork  []byte  // encoded cover art
+}
+
+// probeAudio probes the audio stream of given size and contentType
+// for its details, checking these are sane for the stream as a whole,
+// as the container may describe more than is actually present.
+func probeAudio(rs io.ReadSeeker, size int64, contentType string) (audioInfo, error) {
+	var (
+		info audioInfo
+		err  error
+	)
+
+	switch contentType {
+	case mimeAudioMpeg:
+		info, err = probeMP3(rs, size)
+	case mimeAudioOgg:
+		info, err = probeOgg(rs)
+	case mimeAudioFlac:
+		info, err = probeFLAC(rs)
+	default:
+		err = fmt.Errorf("unsupported audio type %s", contentType)
+	}
+
+	if err != nil {
+		return info, err
+	}
+
+	if info.duration <= 0 ||
+		math.IsInf(info.duration, 0) ||
+		math.IsNaN(info.duration) {
+		return info, errors.New("error determining audio duration")
+	}
+
+	if info.metaSize < 0 || info.metaSize > size {
+		return info, errors.New("audio metadata exceeds stream size")
+	}
+
+	return info, nil
+}
+
+// readN reads exactly n bytes from r, refusing
+// to allocate for anything over maxAudioMetaSize.
+func readN(r io.Reader, n int64) ([]byte, error) {
+	if n < 0 || n > maxAudioMetaSize {
+		return nil, fmt.Errorf("invalid metadata size %d", n)
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+// mpeg audio frame bitrates in kbps, indexed
+// by [version is mpeg1][layer-1][bitrate index].
+var mpegBitrates = [2][3][16]int{
+	{ // MPEG 2 / 2.5
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+	{ // MPEG 1
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+}
+
+// mpeg audio sample rates in Hz, indexed
+// by [version bits][sample rate index].
+var mpegSampleRates = [4][3]int{
+	{11025, 12000, 8000},  // MPEG 2.5
+	{},                    // reserved
+	{22050, 24000, 16000}, // MPEG 2
+	{44100, 48000, 32000}, // MPEG 1
+}
+
+// probeMP3 returns the duration of the MP3 stream of
+// given size, and any cover art found in its ID3v2 tag.
+func probeMP3(rs io.ReadSeeker, size int64) (audioInfo, error) {
+	var (
+		info  audioInfo
+		start int64
+		hdr   [10]byte
+	)
+
+	if _, err := io.ReadFull(rs, hdr[:]); err != nil {
+		return info, fmt.Errorf("error reading mp3 header: %w", err)
+	}
+
+	if string(hdr[:3]) == "ID3" {
+		// Audio frames begin after the ID3v2 tag.
+		tagSize := int64(syncsafe(hdr[6:10]))
+		start = 10 + tagSize
+		if hdr[5]&0x10 != 0 {
+			// Tag is followed by a footer.
+			start += 10
+		}
+
+		tag, err := readN(rs, tagSize)
+		if err != nil {
+			return info, fmt.Errorf("error reading id3 tag: %w", err)
+		}
+
+		info.artwork = id3Artwork(hdr[3], hdr[5], tag)
+	}
+
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		return info, fmt.Errorf("error seeking mp3 frames: %w", err)
+	}
+
+	// Read a chunk that should contain the first
+	// frame, allowing for some junk / padding.
+	buf := make([]byte, 64*1024)
+	n, err := io.ReadFull(rs, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return info, fmt.Errorf("error reading mp3 frames: %w", err)
+	}
+	buf = buf[:n]
+
+	if size >= 128 {
+		var tag [3]byte
+		if _, err := rs.Seek(size-128, io.SeekStart); err == nil {
+			if _, err := io.ReadFull(rs, tag[:]); err == nil && string(tag[:]) == "TAG" {
+				// Trailing ID3v1 tag.
+				info.metaSize += 128
+			}
+		}
+	}
+
+	for i := 0; i+4 <= len(buf); i++ {
+		if buf[i] != 0xFF || buf[i+1]&0xE0 != 0xE0 {
+			continue
+		}
+
+		var (
+			version = (buf[i+1] >> 3) & 0x3
+			layer   = (buf[i+1] >> 1) & 0x3
+			brIdx   = buf[i+2] >> 4
+			srIdx   = (buf[i+2] >> 2) & 0x3
+			mono    = buf[i+3]>>6 == 0x3
+		)
+
+		if version == 1 || layer == 0 || brIdx == 0 || brIdx == 0xF || srIdx == 0x3 {
+			// Not a valid frame header.
+			continue
+		}
+
+		mpeg1 := version == 3
+		bitrate := mpegBitrates[b2i(mpeg1)][layer^0x3][brIdx] * 1000
+		sampleRate := mpegSampleRates[version][srIdx]
+
+		// Samples per frame from layer and version.
+		samples := 1152
+		switch {
+		case layer == 0x3: // Layer I
+			samples = 384
+		case layer == 0x1 && !mpeg1: // Layer III
+			samples = 576
+		}
+
+		// Check for a VBR header listing total frames,
+		// location depending on mpeg version / channels.
+		sideInfo := 32
+		switch {
+		case mpeg1 && mono, !mpeg1 && !mono:
+			sideInfo = 17
+		case !mpeg1 && mono:
+			sideInfo = 9
+		}
+
+		// Everything before the first frame.
+		info.metaSize += start + int64(i)
+
+		frame := buf[i:]
+		if off := 4 + sideInfo; len(frame) >= off+12 {
+			if id := string(frame[off : off+4]); (id == "Xing" || id == "Info") &&
+				frame[off+7]&0x1 != 0 {
+				frames := binary.BigEndian.Uint32(frame[off+8:])
+				info.duration = float64(frames) * float64(samples) / float64(sampleRate)
+				return info, nil
+			}
+		}
+
+		if len(frame) >= 36+18 && string(frame[36:40]) == "VBRI" {
+			frames := binary.BigEndian.Uint32(frame[50:])
+			info.duration = float64(frames) * float64(samples) / float64(sampleRate)
+			return info, nil
+		}
+
+		// Assume constant bitrate over rest of the stream.
+		info.duration = float64((size-info.metaSize)*8) / float64(bitrate)
+		return info, nil
+	}
+
+	return info, errors.New("no mp3 frames found")
+}
+
+// id3Artwork returns the picture data found in the given
+// ID3v2 tag body, preferring the front cover if present.
+func id3Artwork(version byte, flags byte, tag []byte) []byte {
+	if version < 2 || version > 4 {
+		return nil
+	}
+
+	if version < 4 && flags&0x80 != 0 {
+		// Reverse whole-tag unsynchronisation.
+		tag = bytes.ReplaceAll(tag, []byte{0xFF, 0x00}, []byte{0xFF})
+	}
+
+	if version > 2 && flags&0x40 != 0 && len(tag) >= 4 {
+		// Skip the extended header.
+		var extSize int
+		if version == 3 {
+			extSize = 4 + int(binary.BigEndian.Uint32(tag))
+		} else {
+			extSize = int(syncsafe(tag[:4]))
+		}
+		if extSize > len(tag) {
+			return nil
+		}
+		tag = tag[extSize:]
+	}
+
+	var (
+		artwork []byte
+		idSize  = 4
+		hdrSize = 10
+	)
+
+	if version == 2 {
+		idSize = 3
+		hdrSize = 6
+	}
+
+	for len(tag) >= hdrSize && tag[0] != 0 {
+		id := string(tag[:idSize])
+
+		var size int
+		switch version {
+		case 2:
+			size = int(tag[3])<<16 | int(tag[4])<<8 | int(tag[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(tag[4:]))
+		case 4:
+			size = int(syncsafe(tag[4:8]))
+		}
+
+		if size < 0 || size > len(tag)-hdrSize {
+			break
+		}
+
+		body := tag[hdrSize : hdrSize+size]
+		tag = tag[hdrSize+size:]
+
+		if id != "APIC" && id != "PIC" {
+			continue
+		}
+
+		picType, data := id3Picture(version, body)
+		if data == nil {
+			continue
+		}
+
+		if picType == 3 {
+			// Front cover.
+			return data
+		}
+
+		if artwork == nil {
+			artwork = data
+		}
+	}
+
+	return artwork
+}
+
+// id3Picture parses an APIC (or v2.2 PIC)
+// frame body, returning picture type and data.
+func id3Picture(version byte, body []byte) (byte, []byte) {
+	if len(body) < 2 {
+		return 0, nil
+	}
+
+	enc := body[0]
+	body = body[1:]
+
+	if version == 2 {
+		// 3 byte image format.
+		if len(body) < 3 {
+			return 0, nil
+		}
+		body = body[3:]
+	} else {
+		// Null-terminated mime type.
+		i := bytes.IndexByte(body, 0)
+		if i < 0 {
+			return 0, nil
+		}
+		body = body[i+1:]
+	}
+
+	if len(body) < 1 {
+		return 0, nil
+	}
+
+	picType := body[0]
+	body = body[1:]
+
+	// Skip description, terminated
+	// according to text encoding.
+	if enc == 1 || enc == 2 {
+		// UTF-16, 2 byte aligned terminator.
+		for i := 0; i+1 < len(body); i += 2 {
+			if body[i] == 0 && body[i+1] == 0 {
+				return picType, body[i+2:]
+			}
+		}
+		return 0, nil
+	}
+
+	i := bytes.IndexByte(body, 0)
+	if i < 0 {
+		return 0, nil
+	}
+
+	return picType, body[i+1:]
+}
+
+// probeFLAC returns the duration of the FLAC stream,
+// and any cover art found in its metadata blocks.
+func probeFLAC(rs io.ReadSeeker) (audioInfo, error) {
+	var (
+		info audioInfo
+		hdr  [4]byte
+	)
+
+	if _, err := io.ReadFull(rs, hdr[:]); err != nil {
+		return info, fmt.Errorf("error reading flac header: %w", err)
+	}
+
+	if string(hdr[:]) != "fLaC" {
+		return info, errors.New("invalid flac header")
+	}
+
+	info.metaSize = 4
+
+	for last := false; !last; {
+		if _, err := io.ReadFull(rs, hdr[:]); err != nil {
+			return info, fmt.Errorf("error reading flac metadata block: %w", err)
+		}
+
+		last = hdr[0]&0x80 != 0
+		blockType := hdr[0] & 0x7F
+		size := int64(hdr[1])<<16 | int64(hdr[2])<<8 | int64(hdr[3])
+		info.metaSize += 4 + size
+
+		switch blockType {
+		case 0: // STREAMINFO
+			streamInfo, err := readN(rs, size)
+			if err != nil {
+				return info, fmt.Errorf("error reading flac streaminfo: %w", err)
+			}
+
+			if len(streamInfo) < 18 {
+				return info, errors.New("invalid flac streaminfo")
+			}
+
+			// 20 bits of sample rate, 3 bits
+			// channels, 5 bits bits-per-sample
+			// and 36 bits of total samples.
+			bits := binary.BigEndian.Uint64(streamInfo[10:18])
+			sampleRate := bits >> 44
+			totalSamples := bits & 0xFFFFFFFFF
+
+			if sampleRate > 0 {
+				info.duration = float64(totalSamples) / float64(sampleRate)
+			}
+
+		case 6: // PICTURE
+			block, err := readN(rs, size)
+			if err != nil {
+				return info, fmt.Errorf("error reading flac picture: %w", err)
+			}
+
+			picType, data := flacPicture(block)
+			if data != nil && (info.artwork == nil || picType == 3) {
+				info.artwork = data
+			}
+
+		default:
+			if _, err := rs.Seek(size, io.SeekCurrent); err != nil {
+				return info, fmt.Errorf("error skipping flac metadata block: %w", err)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// flacPicture parses a FLAC picture metadata
+// block, returning picture type and data.
+func flacPicture(block []byte) (uint32, []byte) {
+	// readField reads a length prefixed field.
+	readField := func() []byte {
+		if len(block) < 4 {
+			return nil
+		}
+		n := binary.BigEndian.Uint32(block)
+		if uint64(n) > uint64(len(block)-4) {
+			return nil
+		}
+		field := block[4 : 4+n]
+		block = block[4+n:]
+		return field
+	}
+
+	if len(block) < 4 {
+		return 0, nil
+	}
+
+	picType := binary.BigEndian.Uint32(block)
+	block = block[4:]
+
+	// Skip mime and description.
+	if readField() == nil || readField() == nil {
+		return 0, nil
+	}
+
+	// Skip width, height, depth and colors.
+	if len(block) < 16 {
+		return 0, nil
+	}
+	block = block[16:]
+
+	return picType, readField()
+}
+
+// probeOgg returns the duration of the first Vorbis or Opus
+// stream in the Ogg container, and any cover art found in
+// its comment header.
+func probeOgg(rs io.ReadSeeker) (audioInfo, error) {
+	var (
+		info       audioInfo
+		serial     uint32
+		granule    int64
+		sampleRate float64
+		preSkip    int64
+
+		// Packets of the first logical stream. We're only after
+		// the identification and comment header packets.
+		packets [][]byte
+		packet  []byte
+
+		hdr [27]byte
+		seg [255]byte
+	)
+
+	for first := true; ; first = false {
+		if _, err := io.ReadFull(rs, hdr[:]); err != nil {
+			if err == io.EOF && !first {
+				break
+			}
+			return info, fmt.Errorf("error reading ogg page: %w", err)
+		}
+
+		if string(hdr[:4]) != "OggS" {
+			return info, errors.New("invalid ogg page")
+		}
+
+		pageGranule := int64(binary.LittleEndian.Uint64(hdr[6:14]))
+		pageSerial := binary.LittleEndian.Uint32(hdr[14:18])
+		segments := seg[:hdr[26]]
+
+		if _, err := io.ReadFull(rs, segments); err != nil {
+			return info, fmt.Errorf("error reading ogg segment table: %w", err)
+		}
+
+		var bodySize int64
+		for _, s := range segments {
+			bodySize += int64(s)
+		}
+
+		if first {
+			serial = pageSerial
+		}
+
+		if pageSerial != serial || len(packets) >= 2 {
+			// Only page granules are needed now.
+			if _, err := rs.Seek(bodySize, io.SeekCurrent); err != nil {
+				return info, fmt.Errorf("error skipping ogg page: %w", err)
+			}
+
+			if pageSerial == serial && pageGranule > granule {
+				granule = pageGranule
+			}
+
+			continue
+		}
+
+		body, err := readN(rs, bodySize)
+		if err != nil {
+			return info, fmt.Errorf("error reading ogg page: %w", err)
+		}
+
+		// Header page, not audio data.
+		info.metaSize += int64(len(hdr)+len(segments)) + bodySize
+
+		if pageGranule > granule {
+			granule = pageGranule
+		}
+
+		// Reassemble packets from segments, any
+		// segment < 255 bytes terminates a packet.
+		for _, s := range segments {
+			packet = append(packet, body[:s]...)
+			body = body[s:]
+
+			if len(packet) > maxAudioMetaSize {
+				return info, errors.New("ogg header packet too large")
+			}
+
+			if s < 255 {
+				packets = append(packets, packet)
+				packet = nil
+			}
+		}
+	}
+
+	if len(packets) < 1 {
+		return info, errors.New("no ogg header packets found")
+	}
+
+	var comments []byte
+	switch id := packets[0]; {
+	case len(id) >= 16 && string(id[:7]) == "\x01vorbis":
+		sampleRate = float64(binary.LittleEndian.Uint32(id[12:16]))
+		if len(packets) > 1 && bytes.HasPrefix(packets[1], []byte("\x03vorbis")) {
+			comments = packets[1][7:]
+		}
+
+	case len(id) >= 12 && string(id[:8]) == "OpusHead":
+		// Opus granule is always at 48kHz.
+		sampleRate = 48000
+		preSkip = int64(binary.LittleEndian.Uint16(id[10:12]))
+		if len(packets) > 1 && bytes.HasPrefix(packets[1], []byte("OpusTags")) {
+			comments = packets[1][8:]
+		}
+
+	default:
+		return info, errors.New("unsupported ogg codec")
+	}
+
+	if comments != nil {
+		info.artwork = vorbisCommentArtwork(comments)
+	}
+
+	if sampleRate <= 0 {
+		return info, errors.New("invalid ogg sample rate")
+	}
+
+	info.duration = float64(granule-preSkip) / sampleRate
+	return info, nil
+}
+
+// vorbisCommentArtwork returns picture data from any
+// METADATA_BLOCK_PICTURE fields in a vorbis comment.
+func vorbisCommentArtwork(b []byte) []byte {
+	// readField reads a length prefixed field.
+	readField := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := binary.LittleEndian.Uint32(b)
+		if uint64(n) > uint64(len(b)-4) {
+			return nil, false
+		}
+		field := b[4 : 4+n]
+		b = b[4+n:]
+		return field, true
+	}
+
+	// Skip vendor string.
+	if _, ok := readField(); !ok || len(b) < 4 {
+		return nil
+	}
+
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+
+	var artwork []byte
+	for i := uint32(0); i < count; i++ {
+		field, ok := readField()
+		if !ok {
+			break
+		}
+
+		const key = "METADATA_BLOCK_PICTURE="
+		if len(field) < len(key) || !strings.EqualFold(string(field[:len(key)]), key) {
+			continue
+		}
+
+		block, err := base64.StdEncoding.DecodeString(string(field[len(key):]))
+		if err != nil {
+			continue
+		}
+
+		picType, data := flacPicture(block)
+		if data != nil && (artwork == nil || picType == 3) {
+			artwork = data
+		}
+	}
+
+	return artwork
+}
+
+// syncsafe decodes an ID3v2 syncsafe integer,
+// where only the lower 7 bits of each byte count.
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 |
+		uint32(b[1]&0x7F)<<14 |
+		uint32(b[2]&0x7F)<<7 |
+		uint32(b[3]&0x7F)
+}
+
+// b2i converts bool to int for table indexing.
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+)
+
+// seedAudio adds the test audio file at path to the fuzz corpus,
+// along with truncated and corrupted copies of it, so the fuzzer
+// starts out exercising partial and malformed headers / frames.
+func seedAudio(f *testing.F, path string) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(b)
+
+	// Truncated within and just
+	// after the leading headers.
+	for _, n := range []int{
+		0, 3, 4, 9, 10, 26, 27, 40,
+		64, 128, 512, 4096, len(b) / 2,
+	} {
+		if n < len(b) {
+			f.Add(b[:n])
+		}
+	}
+
+	// Flip every bit of the first
+	// bytes in turn, one per seed.
+	for i := 0; i < 64 && i < len(b); i++ {
+		c := bytes.Clone(b)
+		c[i] ^= 0xFF
+		f.Add(c)
+	}
+}
+
+// fuzzProbeAudio checks that probing the given data as
+// contentType never panics, and that any details probed
+// successfully are sane for the size of the data.
+func fuzzProbeAudio(t *testing.T, data []byte, contentType string) {
+	size := int64(len(data))
+
+	info, err := probeAudio(bytes.NewReader(data), size, contentType)
+	if err != nil {
+		return
+	}
+
+	if info.duration <= 0 || math.IsInf(info.duration, 0) || math.IsNaN(info.duration) {
+		t.Fatalf("invalid duration %v", info.duration)
+	}
+
+	if info.metaSize < 0 || info.metaSize > size {
+		t.Fatalf("invalid metadata size %d for stream of %d bytes", info.metaSize, size)
+	}
+
+	if int64(len(info.artwork)) > size {
+		t.Fatalf("artwork of %d bytes for stream of %d bytes", len(info.artwork), size)
+	}
+}
+
+func FuzzProbeMP3(f *testing.F) {
+	seedAudio(f, "./test/test-mp3-original.mp3")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzProbeAudio(t, data, mimeAudioMpeg)
+	})
+}
+
+func FuzzProbeFLAC(f *testing.F) {
+	seedAudio(f, "./test/test-flac-original.flac")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzProbeAudio(t, data, mimeAudioFlac)
+	})
+}
+
+func FuzzProbeOgg(f *testing.F) {
+	seedAudio(f, "./test/test-opus-original.opus")
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzProbeAudio(t, data, mimeAudioOgg)
+	})
+}
+
+func TestProbeFLACTruncatedMetadata(t *testing.T) {
+	// STREAMINFO of 44.1kHz with 44100 samples,
+	// followed by a final padding block that
+	// claims far more data than is present.
+	streamInfo := make([]byte, 34)
+	streamInfo[10] = 0x0A
+	streamInfo[11] = 0xC4
+	streamInfo[12] = 0x40
+	streamInfo[16] = 0xAC
+	streamInfo[17] = 0x44
+
+	var b bytes.Buffer
+	b.WriteString("fLaC")
+	b.Write([]byte{0x00, 0x00, 0x00, 34})
+	b.Write(streamInfo)
+	b.Write([]byte{0x81, 0xFF, 0xFF, 0xFF})
+
+	if _, err := probeAudio(bytes.NewReader(b.Bytes()), int64(b.Len()), mimeAudioFlac); err == nil {
+		t.Fatal("expected error probing truncated flac")
+	}
+}
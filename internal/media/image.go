@@ -27,6 +27,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/HugoSmits86/nativewebp"
 	"github.com/buckket/go-blurhash"
 	"github.com/disintegration/imaging"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
@@ -159,6 +160,13 @@ func (m *gtsImage) ToPNG() io.Reader {
 	})
 }
 
+// ToWebP creates a new streaming (lossless) WebP encoder from receiving image.
+func (m *gtsImage) ToWebP() io.Reader {
+	return iotools.StreamWriteFunc(func(w io.Writer) error {
+		return nativewebp.Encode(w, m.image, nil)
+	})
+}
+
 // getJPEGBuffer fetches a reset JPEG encoding buffer from global JPEG buffer pool.
 func getJPEGBuffer(w io.Writer) *bufio.Writer {
 	v := jpegBufferPool.Get()
@@ -19,10 +19,14 @@ package media
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -39,6 +43,9 @@ var SupportedMIMETypes = []string{
 	mimeImagePng,
 	mimeImageWebp,
 	mimeVideoMp4,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
 }
 
 var SupportedEmojiMIMETypes = []string{
@@ -202,6 +209,39 @@ func (m *Manager) RecacheMedia(
 	}
 }
 
+// neverCache returns whether caching the given remote
+// media is prevented by a domain media policy covering
+// the domain of the account that owns the media.
+func (m *Manager) neverCache(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.IsLocal() {
+		// Local media
+		// always cached.
+		return false, nil
+	}
+
+	// Fetch the owning account, only need
+	// the domain so a barebones model will do.
+	account, err := m.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		media.AccountID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Nothing to go on,
+			// use the default.
+			return false, nil
+		}
+		return false, gtserror.Newf("error getting media owner account: %w", err)
+	}
+
+	policy, err := m.state.DB.MatchDomainMediaPolicy(ctx, account.Domain)
+	if err != nil {
+		return false, gtserror.Newf("error matching domain media policy: %w", err)
+	}
+
+	return policy != nil && *policy.NeverCache, nil
+}
+
 // CreateEmoji creates a new emoji entry in the
 // database for given shortcode, domain and extra
 // information, and prepares a new processing emoji
@@ -342,6 +382,9 @@ func (m *Manager) RefreshEmoji(
 				log.Errorf(ctx, "error deleting old static emoji %s from storage: %v", shortcodeDomain, err)
 			}
 
+			// Remove any *old* emoji static image variant.
+			m.deleteWebPVariant(ctx, oldStaticPath)
+
 			return nil
 		})
 
@@ -466,3 +509,58 @@ func (m *Manager) RecacheEmoji(
 		mgr:    m,
 	}
 }
+
+// storeWebPVariant encodes a WebP variant of the given derived image (i.e. a
+// thumbnail or static emoji), storing it alongside the original at path, if
+// configured to do so. The variant is only stored when it is smaller than
+// the original derived image of given size, else clients get the original.
+func (m *Manager) storeWebPVariant(ctx context.Context, img *gtsImage, path string, size int64) error {
+	if config.GetMediaDerivedFormat() != config.MediaDerivedFormatWebP {
+		// Not enabled.
+		return nil
+	}
+
+	webpPath := WebPVariantPath(path)
+	if webpPath == "" {
+		// Original is webp.
+		return nil
+	}
+
+	// Remove any existing variant (might be broken / out-of-date).
+	if err := m.state.Storage.Delete(ctx, webpPath); err != nil &&
+		!storage.IsNotFound(err) {
+		return gtserror.Newf("error removing webp variant %s from storage: %w", webpPath, err)
+	}
+
+	// Encode WebP variant into memory, as we
+	// need the final size before deciding to store.
+	b, err := io.ReadAll(img.ToWebP())
+	if err != nil {
+		return gtserror.Newf("error encoding webp variant: %w", err)
+	}
+
+	if int64(len(b)) >= size {
+		// No saving to be had.
+		return nil
+	}
+
+	if _, err := m.state.Storage.Put(ctx, webpPath, b); err != nil {
+		return gtserror.Newf("error writing webp variant to storage: %w", err)
+	}
+
+	return nil
+}
+
+// deleteWebPVariant removes any WebP variant
+// stored alongside derived image at path.
+func (m *Manager) deleteWebPVariant(ctx context.Context, path string) {
+	webpPath := WebPVariantPath(path)
+	if webpPath == "" {
+		return
+	}
+
+	err := m.state.Storage.Delete(ctx, webpPath)
+	if err != nil && !storage.IsNotFound(err) {
+		log.Errorf(ctx, "error deleting %s: %v", webpPath, err)
+	}
+}
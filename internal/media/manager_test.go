@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"image/png"
 	"io"
 	"os"
 	"testing"
@@ -28,12 +29,14 @@ import (
 
 	"codeberg.org/gruf/go-storage/disk"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/testrig"
+	"golang.org/x/image/webp"
 )
 
 type ManagerTestSuite struct {
@@ -441,6 +444,114 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcess() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessWebPVariant() {
+	ctx := context.Background()
+
+	// Enable storing webp variants.
+	config.SetMediaDerivedFormat(config.MediaDerivedFormatWebP)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// the jpeg thumbnail should still be stored as usual
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+
+	// but a lossless webp variant of a photo is
+	// larger than the jpeg, so none should be stored
+	webpPath := media.WebPVariantPath(attachment.Thumbnail.Path)
+	_, err = suite.storage.Get(ctx, webpPath)
+	suite.True(storage.IsNotFound(err))
+}
+
+func (suite *ManagerTestSuite) TestEmojiProcessWebPVariant() {
+	ctx := context.Background()
+
+	// Enable storing webp variants.
+	config.SetMediaDerivedFormat(config.MediaDerivedFormatWebP)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/rainbow-original.png")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	processing, err := suite.manager.CreateEmoji(ctx,
+		"rainbow_test",
+		"",
+		data,
+		media.AdditionalEmojiInfo{},
+	)
+	suite.NoError(err)
+
+	// do a blocking call to fetch the emoji
+	emoji, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(emoji)
+
+	// the png static image should still be stored as usual
+	suite.Equal("image/png", emoji.ImageStaticContentType)
+	processedStaticBytes, err := suite.storage.Get(ctx, emoji.ImageStaticPath)
+	suite.NoError(err)
+	suite.NotEmpty(processedStaticBytes)
+
+	// with a smaller webp variant stored alongside it
+	webpPath := media.WebPVariantPath(emoji.ImageStaticPath)
+	webpBytes, err := suite.storage.Get(ctx, webpPath)
+	suite.NoError(err)
+	suite.Less(len(webpBytes), len(processedStaticBytes))
+
+	// which should decode to the same (lossless) image
+	img, err := webp.Decode(bytes.NewReader(webpBytes))
+	suite.NoError(err)
+	staticImg, err := png.Decode(bytes.NewReader(processedStaticBytes))
+	suite.NoError(err)
+	suite.Equal(staticImg.Bounds(), img.Bounds())
+
+	var mismatched int
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			r1, g1, b1, a1 := staticImg.At(x, y).RGBA()
+			r2, g2, b2, a2 := img.At(x, y).RGBA()
+			if a1 == 0 && a2 == 0 {
+				// Fully transparent, color is irrelevant.
+				continue
+			}
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				mismatched++
+			}
+		}
+	}
+	suite.Zero(mismatched)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -843,6 +954,153 @@ func (suite *ManagerTestSuite) TestNotAnMp4Process() {
 	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
 }
 
+func (suite *ManagerTestSuite) TestMp3Process() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test mp3 with embedded cover art
+		b, err := os.ReadFile("./test/test-mp3-original.mp3")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// make sure it's got the stuff set on it that we expect
+	// the attachment ID and accountID we expect
+	suite.Equal(processing.ID(), attachment.ID)
+	suite.Equal(accountID, attachment.AccountID)
+
+	// file meta should be correctly derived from the audio,
+	// with the embedded cover art used for the thumbnail
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.EqualValues(float32(2.60625), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(128000, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/mpeg", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(311499, attachment.File.FileSize)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// now make sure the attachment is in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.NotNil(dbAttachment)
+
+	// make sure the original file is in storage untouched
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	processedFullBytesExpected, err := os.ReadFile("./test/test-mp3-original.mp3")
+	suite.NoError(err)
+	suite.Equal(processedFullBytesExpected, processedFullBytes)
+
+	// and that a thumbnail was generated
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestFlacProcessNoArtwork() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test flac without cover art
+		b, err := os.ReadFile("./test/test-flac-original.flac")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the audio
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, attachment.Processing)
+	suite.True(*attachment.Cached)
+	suite.EqualValues(float32(10), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(1602, *attachment.FileMeta.Original.Bitrate)
+	suite.Equal("audio/flac", attachment.File.ContentType)
+	suite.Equal(2044, attachment.File.FileSize)
+
+	// with no artwork there should be no thumbnail
+	suite.Empty(attachment.Thumbnail)
+	suite.Empty(attachment.Blurhash)
+	suite.Zero(attachment.FileMeta.Small)
+}
+
+func (suite *ManagerTestSuite) TestOpusProcess() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test opus audio file
+		b, err := os.ReadFile("./test/test-opus-original.opus")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// duration should be derived from the final granule
+	// position, minus the opus pre-skip samples
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(5), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(1824, *attachment.FileMeta.Original.Bitrate)
+	suite.Equal("audio/ogg", attachment.File.ContentType)
+	suite.Empty(attachment.Thumbnail)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessNoContentLengthGiven() {
 	ctx := context.Background()
 
@@ -0,0 +1,377 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	terminator "codeberg.org/superseriousbusiness/exif-terminator"
+	jpegstructure "github.com/superseriousbusiness/go-jpeg-image-structure/v2"
+	pngstructure "github.com/superseriousbusiness/go-png-image-structure/v2"
+)
+
+// metadataOpts determines what image metadata gets stripped
+// on top of what exif-terminator already does, which is to
+// zero all EXIF data (except the orientation tag in jpegs),
+// leaving other metadata like ICC color profiles alone. For
+// webp images, EXIF and XMP chunks are always dropped.
+type metadataOpts struct {
+	// stripAll strips all metadata, overriding the
+	// below: orientation, ICC color profiles, XMP,
+	// IPTC, jpeg comments and png text chunks.
+	stripAll bool
+
+	// keepOrientation keeps the jpeg
+	// EXIF orientation tag, if present.
+	keepOrientation bool
+
+	// keepICC keeps any
+	// embedded color profile.
+	keepICC bool
+}
+
+// terminate wraps the image stream r of the given file extension
+// and (non-zero) size, cleaning EXIF data from it using exif-terminator
+// as it's read, and stripping further metadata according to opts.
+func terminate(r io.Reader, fileSize int, ext string, opts metadataOpts) (io.Reader, error) {
+	if opts.stripAll {
+		opts.keepOrientation = false
+		opts.keepICC = false
+	}
+
+	if ext == "webp" {
+		// exif-terminator's webp splitter only handles
+		// a single chunk per call, so truncates images
+		// whose final read contains more than one chunk.
+		// WebP metadata all lives in its own chunks, so
+		// we can just drop those here instead.
+		v := &webpStripper{opts: opts}
+		return scanPipe(r, fileSize, nil, func(w io.Writer) bufio.SplitFunc {
+			v.w = w
+			return v.split
+		}), nil
+	}
+
+	r, err := terminator.Terminate(r, fileSize, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.keepOrientation && opts.keepICC {
+		// exif-terminator
+		// did all we need.
+		return r, nil
+	}
+
+	switch ext {
+	case "jpg", "jpeg":
+		v := &jpegStripper{opts: opts}
+		return scanPipe(r, fileSize, nil, func(w io.Writer) bufio.SplitFunc {
+			v.w = w
+			v.js = jpegstructure.NewJpegSplitter(v)
+			return v.js.Split
+		}), nil
+
+	case "png":
+		v := &pngStripper{ps: pngstructure.NewPngSplitter(), opts: opts}
+		v.ps.DoCheckCrc(false) // fixed by terminator
+
+		// The png splitter doesn't handle the header
+		// (already checked by terminator), so this is
+		// passed straight through first.
+		hdr := pngstructure.PngSignature[:]
+		return scanPipe(r, fileSize, hdr, func(w io.Writer) bufio.SplitFunc {
+			v.w = w
+			return v.split
+		}), nil
+
+	default:
+		return r, nil
+	}
+}
+
+// scanPipe scans through r in the same way as exif-terminator,
+// with a split function (from given constructor) writing to the
+// returned reader as it goes, after first passing through len(hdr)
+// bytes of r unchanged. Scanning happens in a separate goroutine
+// that exits on error or once the reader has been read to EOF.
+func scanPipe(
+	r io.Reader,
+	fileSize int,
+	hdr []byte,
+	newSplit func(io.Writer) bufio.SplitFunc,
+) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		var err error
+
+		defer func() {
+			// Close with the result, on
+			// nil this will just be io.EOF.
+			_ = pw.CloseWithError(err)
+		}()
+
+		if len(hdr) > 0 {
+			if _, err = io.CopyN(pw, r, int64(len(hdr))); err != nil {
+				return
+			}
+		}
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer([]byte{}, fileSize)
+		scanner.Split(newSplit(pw))
+
+		for scanner.Scan() {
+		}
+
+		err = scanner.Err()
+	}()
+
+	return pr
+}
+
+// jpegStripper is a jpegstructure segment visitor,
+// writing out each segment as it's scanned, except
+// for metadata segments dropped according to opts.
+type jpegStripper struct {
+	js   *jpegstructure.JpegSplitter
+	w    io.Writer
+	opts metadataOpts
+}
+
+// HandleSegment implements jpegstructure.SegmentVisitor{}.
+func (v *jpegStripper) HandleSegment(byte, string, int, bool) error {
+	// Get the most recently scanned segment.
+	segments := v.js.Segments().Segments()
+	s := segments[len(segments)-1]
+
+	// The splitter keeps all segments,
+	// so evict data once we're done.
+	defer func() { s.Data = nil }()
+
+	if v.drop(s) {
+		return nil
+	}
+
+	switch {
+	case s.MarkerId == 0:
+		// Scan data, which
+		// has no marker.
+		_, err := v.w.Write(s.Data)
+		return err
+
+	case s.MarkerId <= 0x01 ||
+		(s.MarkerId >= 0xd0 && s.MarkerId <= jpegstructure.MARKER_SOS):
+		// TEM, RSTn, SOI, EOI and SOS are split by
+		// jpegstructure without length, the SOS
+		// header is included in the scan data.
+		_, err := v.w.Write([]byte{0xff, s.MarkerId})
+		return err
+
+	default:
+		hdr := []byte{0xff, s.MarkerId, 0, 0}
+		binary.BigEndian.PutUint16(hdr[2:], uint16(len(s.Data)+2))
+		if _, err := v.w.Write(hdr); err != nil {
+			return err
+		}
+		_, err := v.w.Write(s.Data)
+		return err
+	}
+}
+
+// drop returns whether to drop given segment.
+func (v *jpegStripper) drop(s *jpegstructure.Segment) bool {
+	switch id := s.MarkerId; {
+	case s.IsExif():
+		return !v.opts.keepOrientation
+
+	case id == jpegstructure.MARKER_APP2 &&
+		bytes.HasPrefix(s.Data, []byte("ICC_PROFILE\x00")):
+		return !v.opts.keepICC
+
+	case id == jpegstructure.MARKER_COM:
+		return v.opts.stripAll
+
+	case id > jpegstructure.MARKER_APP0 &&
+		id <= jpegstructure.MARKER_APP15 &&
+		id != jpegstructure.MARKER_APP14:
+		// Any other application data, e.g. XMP
+		// (APP1) or IPTC (APP13). JFIF (APP0)
+		// and Adobe (APP14) affect decoding.
+		return v.opts.stripAll
+	}
+
+	return false
+}
+
+// pngStripper wraps a pngstructure splitter, writing
+// out each chunk as it's scanned, except for metadata
+// chunks dropped according to opts.
+type pngStripper struct {
+	ps      *pngstructure.PngSplitter
+	w       io.Writer
+	opts    metadataOpts
+	written int
+}
+
+func (v *pngStripper) split(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := v.ps.Split(data, atEOF)
+	if err != nil || advance == 0 {
+		// Errored, or no
+		// new chunks yet.
+		return advance, token, err
+	}
+
+	chunkSlice, err := v.ps.Chunks()
+	if err != nil {
+		return advance, token, err
+	}
+
+	chunks := chunkSlice.Chunks()
+	for _, chunk := range chunks[v.written:] {
+		if !v.drop(chunk.Type) {
+			if _, err := chunk.WriteTo(v.w); err != nil {
+				return advance, token, err
+			}
+		}
+
+		// Zero data; here you
+		// go garbage collector.
+		chunk.Data = nil
+		v.written++
+	}
+
+	return advance, token, nil
+}
+
+// drop returns whether to drop given chunk type.
+func (v *pngStripper) drop(typ string) bool {
+	switch typ {
+	case pngstructure.EXifChunkType:
+		return !v.opts.keepOrientation
+	case "iCCP":
+		return !v.opts.keepICC
+	case "tEXt", "zTXt", "iTXt", "tIME":
+		return v.opts.stripAll
+	}
+	return false
+}
+
+// VP8X feature flags for metadata, see:
+// https://developers.google.com/speed/webp/docs/riff_container#extended_file_format
+const (
+	webpFlagICC  = 0x20
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// webpStripper provides a bufio.SplitFunc{} writing out
+// each webp chunk as it's scanned, replacing metadata
+// chunks dropped according to opts with zeroed "JUNK"
+// chunks of the same size, so the RIFF size stays valid.
+type webpStripper struct {
+	w          io.Writer
+	opts       metadataOpts
+	doneHeader bool
+	left       int // in RIFF container
+}
+
+func (v *webpStripper) split(data []byte, atEOF bool) (int, []byte, error) {
+	var advance int
+
+	if !v.doneHeader {
+		const riffHeaderSize = 12
+		if len(data) < riffHeaderSize {
+			return 0, nil, nil
+		}
+
+		if string(data[:4]) != "RIFF" ||
+			string(data[8:12]) != "WEBP" {
+			return 0, nil, errors.New("invalid webp header")
+		}
+
+		if _, err := v.w.Write(data[:riffHeaderSize]); err != nil {
+			return 0, nil, err
+		}
+
+		v.doneHeader = true
+		v.left = int(binary.LittleEndian.Uint32(data[4:8])) - 4
+		advance += riffHeaderSize
+		data = data[riffHeaderSize:]
+	}
+
+	// Handle as many chunks as we can, as
+	// the scanner won't call us again with
+	// remaining data once at EOF.
+	for len(data) >= 8 {
+		if v.left <= 0 {
+			// Drop anything trailing
+			// the RIFF container.
+			return advance, nil, bufio.ErrFinalToken
+		}
+
+		// Chunk data is padded to even size.
+		size := int(binary.LittleEndian.Uint32(data[4:]))
+		size += size & 1
+
+		if len(data)-8 < size {
+			// Wait until
+			// there's enough.
+			break
+		}
+
+		hdr := data[:8]
+		chunk := data[8 : 8+size]
+
+		switch fourcc := string(hdr[:4]); {
+		case fourcc == "VP8X" && size > 0:
+			// Unset the feature
+			// flags of what we drop.
+			chunk = bytes.Clone(chunk)
+			chunk[0] &^= webpFlagEXIF | webpFlagXMP
+			if !v.opts.keepICC {
+				chunk[0] &^= webpFlagICC
+			}
+
+		case fourcc == "EXIF",
+			fourcc == "XMP ",
+			fourcc == "ICCP" && !v.opts.keepICC:
+			hdr = append([]byte("JUNK"), hdr[4:]...)
+			chunk = make([]byte, size)
+		}
+
+		if _, err := v.w.Write(hdr); err != nil {
+			return 0, nil, err
+		}
+
+		if _, err := v.w.Write(chunk); err != nil {
+			return 0, nil, err
+		}
+
+		advance += 8 + size
+		data = data[8+size:]
+		v.left -= 8 + size
+	}
+
+	return advance, nil, nil
+}
@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// gpsSecret is contained in every piece of metadata (EXIF GPS IFD,
+// XMP, IPTC, comments, text chunks, trailing data) of the test/*-gps.*
+// images, which all also have an EXIF orientation of 6 and an ICC profile.
+var gpsSecret = []byte("GTS-SECRET-LOCATION")
+
+type MetadataTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// process processes the given test image as if it were
+// a local upload, or a remote media with unknown size,
+// returning the bytes of the stored original media.
+func (suite *MetadataTestSuite) process(path string, remote bool) []byte {
+	ctx := context.Background()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	size := int64(len(b))
+	info := media.AdditionalMediaInfo{}
+	if remote {
+		// Remote media doesn't
+		// always provide a size.
+		size = 0
+		info.RemoteURL = util.Ptr("http://example.org/media/" + path)
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), size, nil
+	}
+
+	processing, err := suite.manager.CreateMedia(ctx,
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		data,
+		info,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment, err := processing.Load(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Stripped image should still be usable.
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.NotZero(attachment.FileMeta.Original.Width)
+
+	stored, err := suite.storage.Get(ctx, attachment.File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// EXIF data (incl. GPS IFD) should never survive.
+	suite.False(bytes.Contains(stored, []byte("GTSCAM")), "exif tags survived")
+
+	return stored
+}
+
+func (suite *MetadataTestSuite) TestStripJPEG() {
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-jpeg-gps.jpg", remote)
+		suite.True(bytes.Contains(stored, []byte("Exif\x00\x00")))
+		suite.True(bytes.Contains(stored, []byte("ICC_PROFILE\x00")))
+		suite.True(bytes.HasSuffix(stored, []byte{0xff, 0xd9}))
+	}
+}
+
+func (suite *MetadataTestSuite) TestStripJPEGNoICC() {
+	config.SetMediaMetadataKeepICC(false)
+
+	stored := suite.process("./test/test-jpeg-gps.jpg", false)
+	suite.True(bytes.Contains(stored, []byte("Exif\x00\x00")))
+	suite.False(bytes.Contains(stored, []byte("ICC_PROFILE")))
+}
+
+func (suite *MetadataTestSuite) TestStripJPEGAll() {
+	config.SetMediaMetadataStripAll(true)
+
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-jpeg-gps.jpg", remote)
+		suite.False(bytes.Contains(stored, gpsSecret), "metadata survived")
+		suite.False(bytes.Contains(stored, []byte("Exif")))
+		suite.False(bytes.Contains(stored, []byte("ICC_PROFILE")))
+		suite.False(bytes.Contains(stored, []byte("http://ns.adobe.com/xap/1.0/")))
+		suite.True(bytes.HasSuffix(stored, []byte{0xff, 0xd9}))
+	}
+}
+
+func (suite *MetadataTestSuite) TestStripPNG() {
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-png-gps.png", remote)
+		suite.True(bytes.Contains(stored, []byte("eXIf")))
+	}
+}
+
+func (suite *MetadataTestSuite) TestStripPNGAll() {
+	config.SetMediaMetadataStripAll(true)
+
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-png-gps.png", remote)
+		suite.False(bytes.Contains(stored, gpsSecret), "metadata survived")
+		suite.False(bytes.Contains(stored, []byte("eXIf")))
+		suite.False(bytes.Contains(stored, []byte("tEXt")))
+		suite.False(bytes.Contains(stored, []byte("iTXt")))
+		suite.False(bytes.Contains(stored, []byte("tIME")))
+	}
+}
+
+func (suite *MetadataTestSuite) TestStripWebP() {
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-webp-gps.webp", remote)
+		suite.False(bytes.Contains(stored, gpsSecret), "metadata survived")
+		suite.True(bytes.Contains(stored, []byte("ICCP")))
+	}
+}
+
+func (suite *MetadataTestSuite) TestStripWebPAll() {
+	config.SetMediaMetadataStripAll(true)
+
+	for _, remote := range []bool{false, true} {
+		stored := suite.process("./test/test-webp-gps.webp", remote)
+		suite.False(bytes.Contains(stored, gpsSecret), "metadata survived")
+		suite.False(bytes.Contains(stored, []byte("EXIF")))
+		suite.False(bytes.Contains(stored, []byte("ICCP")))
+	}
+}
+
+func TestMetadataTestSuite(t *testing.T) {
+	suite.Run(t, &MetadataTestSuite{})
+}
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+// webpChunk returns a WebP (RIFF) chunk
+// of given fourcc, length and data.
+func webpChunk(fourcc string, n uint32, data []byte) []byte {
+	b := append([]byte(fourcc), binary.LittleEndian.AppendUint32(nil, n)...)
+	return append(b, data...)
+}
+
+// webpFile returns a WebP (RIFF) file of
+// given chunks, with the given RIFF size.
+func webpFile(size uint32, chunks ...[]byte) []byte {
+	b := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, size)...)
+	b = append(b, "WEBP"...)
+	for _, chunk := range chunks {
+		b = append(b, chunk...)
+	}
+	return b
+}
+
+// terminateWebP returns the WebP
+// file b terminated with given opts.
+func terminateWebP(b []byte, opts metadataOpts) ([]byte, error) {
+	r, err := terminate(bytes.NewReader(b), len(b), "webp", opts)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestTerminateWebP(t *testing.T) {
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpFlagICC | webpFlagEXIF | webpFlagXMP
+
+	b := webpFile(4+18+8+8+8+8+8+8+8+8,
+		webpChunk("VP8X", 10, vp8x),
+		webpChunk("ICCP", 8, []byte("ICCDATA!")),
+		webpChunk("EXIF", 8, []byte("SECRET!!")),
+		webpChunk("XMP ", 8, []byte("SECRET!!")),
+		webpChunk("VP8L", 8, []byte("IMGDATA!")),
+	)
+
+	// Trailing data should be dropped.
+	b = append(b, "SECRET-TRAILING-DATA"...)
+
+	for _, test := range []struct {
+		opts    metadataOpts
+		keepICC bool
+	}{
+		{opts: metadataOpts{keepOrientation: true, keepICC: true}, keepICC: true},
+		{opts: metadataOpts{keepOrientation: true}, keepICC: false},
+		{opts: metadataOpts{stripAll: true, keepICC: true}, keepICC: false},
+	} {
+		stripped, err := terminateWebP(b, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// EXIF and XMP are always replaced by junk of
+		// the same size with their VP8X flags unset,
+		// the ICC profile only if not keeping it.
+		vp8x := make([]byte, 10)
+		iccp := webpChunk("JUNK", 8, make([]byte, 8))
+		if test.keepICC {
+			vp8x[0] = webpFlagICC
+			iccp = webpChunk("ICCP", 8, []byte("ICCDATA!"))
+		}
+
+		expect := webpFile(4+18+8+8+8+8+8+8+8+8,
+			webpChunk("VP8X", 10, vp8x),
+			iccp,
+			webpChunk("JUNK", 8, make([]byte, 8)),
+			webpChunk("JUNK", 8, make([]byte, 8)),
+			webpChunk("VP8L", 8, []byte("IMGDATA!")),
+		)
+
+		if !bytes.Equal(expect, stripped) {
+			t.Fatalf("unexpected stripped webp for %+v: %x", test.opts, stripped)
+		}
+	}
+}
@@ -330,6 +330,12 @@ func (p *ProcessingEmoji) finish(ctx context.Context) error {
 	// Set final written thumb size.
 	p.emoji.ImageStaticFileSize = int(sz)
 
+	// Store WebP variant of static emoji if configured. This is
+	// only an optimization, so errors are logged, not returned.
+	if err := p.mgr.storeWebPVariant(ctx, staticImg, p.emoji.ImageStaticPath, sz); err != nil {
+		log.Errorf(ctx, "error storing webp static emoji variant: %v", err)
+	}
+
 	return nil
 }
 
@@ -352,6 +358,9 @@ func (p *ProcessingEmoji) cleanup(ctx context.Context) {
 		if err != nil && !storage.IsNotFound(err) {
 			log.Errorf(ctx, "error deleting %s: %v", p.emoji.ImageStaticPath, err)
 		}
+
+		// Ensure any emoji static file variant is also deleted.
+		p.mgr.deleteWebPVariant(ctx, p.emoji.ImageStaticPath)
 	}
 
 	// Ensure marked as not cached.
@@ -27,9 +27,9 @@ import (
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-runners"
-	terminator "codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -141,6 +141,22 @@ func (p *ProcessingMedia) load(ctx context.Context) (
 			return nil
 		}
 
+		// Check whether a domain media
+		// policy prevents caching this.
+		var neverCache bool
+		neverCache, err = p.mgr.neverCache(ctx, p.media)
+		if err != nil {
+			return err
+		}
+
+		if neverCache {
+			// Leave as an unknown type placeholder,
+			// which will be served by redirecting
+			// to the media's remote URL instead.
+			p.media.Type = gtsmodel.FileTypeUnknown
+			return nil
+		}
+
 		// Attempt to store media and calculate
 		// full-size media attachment details.
 		//
@@ -236,14 +252,37 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case "gif":
 		// No problem
 
+	case "mp3", "ogg", "flac":
+		// No problem.
+
 	case "jpg", "jpeg", "png", "webp":
-		if fileSize > 0 {
-			// A file size was provided so we can clean
-			// exif data from image as we're streaming it.
-			r, err = terminator.Terminate(r, fileSize, info.Extension)
+		if fileSize <= 0 {
+			// Size unknown (e.g. remote media without
+			// a content-length), which exif-terminator
+			// needs, so read the image into memory.
+			maxSize := int64(config.GetMediaImageMaxSize())
+			b, err := io.ReadAll(io.LimitReader(r, maxSize+1))
 			if err != nil {
-				return gtserror.Newf("error cleaning exif data: %w", err)
+				return gtserror.Newf("error reading image: %w", err)
+			}
+
+			if int64(len(b)) > maxSize {
+				return gtserror.Newf("image exceeds max size %d", maxSize)
 			}
+
+			fileSize = len(b)
+			r = bytes.NewReader(b)
+		}
+
+		// Clean exif data (and any other metadata
+		// per config) from image as we're streaming it.
+		r, err = terminate(r, fileSize, info.Extension, metadataOpts{
+			stripAll:        config.GetMediaMetadataStripAll(),
+			keepOrientation: config.GetMediaMetadataKeepOrientation(),
+			keepICC:         config.GetMediaMetadataKeepICC(),
+		})
+		if err != nil {
+			return gtserror.Newf("error cleaning exif data: %w", err)
 		}
 
 	default:
@@ -264,6 +303,11 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 
 	// Prefer discovered MIME, fallback to generic data stream.
 	mime := cmp.Or(info.MIME.Value, "application/octet-stream")
+	if info.Extension == "flac" {
+		// Use the registered FLAC MIME
+		// type over the "audio/x-flac".
+		mime = mimeAudioFlac
+	}
 	p.media.File.ContentType = mime
 
 	// Calculate final media attachment file path.
@@ -377,6 +421,24 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeVideo
+
+	// .mp3, .ogg, .flac audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac:
+		audio, err := decodeAudio(rc, p.media.File.ContentType)
+		if err != nil {
+			return gtserror.Newf("error decoding audio: %w", err)
+		}
+
+		// Use embedded artwork (if any) as image.
+		fullImg = audio.artwork
+
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &audio.duration
+		p.media.FileMeta.Original.Bitrate = &audio.bitrate
+
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeAudio
 	}
 
 	// fullImg should be in-memory by
@@ -385,11 +447,21 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return gtserror.Newf("error closing file: %w", err)
 	}
 
-	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = fullImg.Width()
-	p.media.FileMeta.Original.Height = fullImg.Height()
-	p.media.FileMeta.Original.Size = fullImg.Size()
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	if fullImg == nil {
+		// Audio without embedded artwork, there's
+		// nothing to generate a thumbnail from.
+		p.media.Thumbnail = gtsmodel.Thumbnail{}
+		p.media.Processing = gtsmodel.ProcessingStatusProcessed
+		return nil
+	}
+
+	if p.media.Type != gtsmodel.FileTypeAudio {
+		// Set full-size dimensions in attachment info.
+		p.media.FileMeta.Original.Width = fullImg.Width()
+		p.media.FileMeta.Original.Height = fullImg.Height()
+		p.media.FileMeta.Original.Size = fullImg.Size()
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	}
 
 	// Get smaller thumbnail image
 	thumbImg := fullImg.Thumbnail()
@@ -438,6 +510,12 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	// Set final written thumb size.
 	p.media.Thumbnail.FileSize = int(sz)
 
+	// Store WebP variant of thumbnail if configured. This is
+	// only an optimization, so errors are logged, not returned.
+	if err := p.mgr.storeWebPVariant(ctx, thumbImg, p.media.Thumbnail.Path, sz); err != nil {
+		log.Errorf(ctx, "error storing webp thumbnail variant: %v", err)
+	}
+
 	// Set thumbnail dimensions in attachment info.
 	p.media.FileMeta.Small = gtsmodel.Small{
 		Width:  thumbImg.Width(),
@@ -471,6 +549,9 @@ func (p *ProcessingMedia) cleanup(ctx context.Context) {
 		if err != nil && !storage.IsNotFound(err) {
 			log.Errorf(ctx, "error deleting %s: %v", p.media.Thumbnail.Path, err)
 		}
+
+		// Ensure any media thumbnail variant is also deleted.
+		p.mgr.deleteWebPVariant(ctx, p.media.Thumbnail.Path)
 	}
 
 	// Also ensure marked as unknown and finished
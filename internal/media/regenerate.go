@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"path"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RegenerateThumbnail checks whether the thumbnail or blurhash of the given
+// media attachment are missing, or were produced by an older processing
+// pipeline, and if so re-derives them from the original file in storage.
+//
+// Returns whether the attachment needed (and received) regeneration.
+func (m *Manager) RegenerateThumbnail(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if !*media.Cached {
+		// Nothing in storage
+		// to regenerate from.
+		return false, nil
+	}
+
+	switch media.File.ContentType {
+	case mimeImageJpeg, mimeImageGif, mimeImageWebp, mimeImagePng, mimeVideoMp4:
+		// Types we can decode.
+	default:
+		return false, nil
+	}
+
+	outdated, err := m.thumbnailOutdated(ctx, media)
+	if err != nil || !outdated {
+		return false, err
+	}
+
+	if path.Ext(media.Thumbnail.Path) != ".jpg" {
+		if media.Thumbnail.Path != "" {
+			// Older pipeline thumbnails may have been stored in
+			// other formats, remove at the old path to avoid orphans.
+			if err := m.state.Storage.Delete(ctx, media.Thumbnail.Path); err != nil &&
+				!storage.IsNotFound(err) {
+				return false, gtserror.Newf("error removing thumbnail %s from storage: %w", media.Thumbnail.Path, err)
+			}
+
+			// Along with any variant stored beside it.
+			m.deleteWebPVariant(ctx, media.Thumbnail.Path)
+		}
+
+		// Set thumbnail path and URL
+		// as in the current pipeline.
+		media.Thumbnail.Path = uris.StoragePathForAttachment(
+			media.AccountID,
+			string(TypeAttachment),
+			string(SizeSmall),
+			media.ID,
+			"jpg",
+		)
+		media.Thumbnail.URL = uris.URIForAttachment(
+			media.AccountID,
+			string(TypeAttachment),
+			string(SizeSmall),
+			media.ID,
+			"jpg",
+		)
+	}
+
+	// Thumbs always jpg.
+	media.Thumbnail.ContentType = mimeImageJpeg
+
+	// Reuse the finishing step of processing, which
+	// regenerates thumbnail (and blurhash if unset)
+	// from the original file already in storage.
+	p := &ProcessingMedia{media: media, mgr: m}
+	if err := p.finish(ctx); err != nil {
+		return false, err
+	}
+
+	if err := m.state.DB.UpdateAttachment(ctx, media); err != nil {
+		return false, gtserror.Newf("error updating media in db: %w", err)
+	}
+
+	return true, nil
+}
+
+// thumbnailOutdated returns whether the thumbnail or
+// blurhash of given cached media need regenerating.
+func (m *Manager) thumbnailOutdated(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	switch {
+	case media.Blurhash == "":
+		return true, nil
+	case media.Thumbnail.ContentType != mimeImageJpeg,
+		path.Ext(media.Thumbnail.Path) != ".jpg":
+		return true, nil
+	case media.FileMeta.Small.Width == 0,
+		media.FileMeta.Small.Height == 0:
+		return true, nil
+	}
+
+	have, err := m.state.Storage.Has(ctx, media.Thumbnail.Path)
+	if err != nil {
+		return false, gtserror.Newf("error checking storage for %s: %w", media.Thumbnail.Path, err)
+	}
+
+	if !have {
+		log.Debugf(ctx, "media %s missing thumbnail in storage", media.ID)
+		return true, nil
+	}
+
+	return false, nil
+}
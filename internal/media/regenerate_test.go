@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RegenerateTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *RegenerateTestSuite) TestRegenerateThumbnailNothingToDo() {
+	ctx := context.Background()
+
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	regenerated, err := suite.manager.RegenerateThumbnail(ctx, attachment)
+	suite.NoError(err)
+	suite.False(regenerated)
+}
+
+func (suite *RegenerateTestSuite) TestRegenerateThumbnailMissing() {
+	ctx := context.Background()
+
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// Delete the thumbnail from storage.
+	if err := suite.storage.Delete(ctx, attachment.Thumbnail.Path); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	regenerated, err := suite.manager.RegenerateThumbnail(ctx, attachment)
+	suite.NoError(err)
+	suite.True(regenerated)
+
+	// Thumbnail should be back in storage.
+	have, err := suite.storage.Has(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.True(have)
+}
+
+func (suite *RegenerateTestSuite) TestRegenerateThumbnailBlurhash() {
+	ctx := context.Background()
+
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	attachment.Blurhash = ""
+
+	regenerated, err := suite.manager.RegenerateThumbnail(ctx, attachment)
+	suite.NoError(err)
+	suite.True(regenerated)
+
+	// Blurhash should be set in the database.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.NotEmpty(dbAttachment.Blurhash)
+	suite.Equal("image/jpeg", dbAttachment.Thumbnail.ContentType)
+}
+
+func TestRegenerateTestSuite(t *testing.T) {
+	suite.Run(t, &RegenerateTestSuite{})
+}
@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -42,6 +43,15 @@ const (
 
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
 )
 
 type Size string
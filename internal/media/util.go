@@ -17,6 +17,11 @@
 
 package media
 
+import (
+	"path"
+	"strings"
+)
+
 // newHdrBuf returns a buffer of suitable size to
 // read bytes from a file header or magic number.
 //
@@ -39,3 +44,14 @@ func newHdrBuf(fileSize int) []byte {
 	}
 	return make([]byte, bufSize)
 }
+
+// WebPVariantPath returns the storage path of the WebP variant
+// of a derived image (thumbnail / static image) stored at p,
+// or empty if the image is itself already WebP (or p empty).
+func WebPVariantPath(p string) string {
+	ext := path.Ext(p)
+	if p == "" || ext == ".webp" {
+		return ""
+	}
+	return strings.TrimSuffix(p, ext) + ".webp"
+}
@@ -230,7 +230,9 @@ func (msg *FromFediAPI) Serialize() ([]byte, error) {
 
 	// Set serialized AP object data if set.
 	if t, ok := msg.APObject.(vocab.Type); ok {
-		obj, err := t.Serialize()
+		// Serialize including JSON-LD
+		// context, needed to resolve type.
+		obj, err := streams.Serialize(t)
 		if err != nil {
 			return nil, err
 		}
@@ -289,6 +291,14 @@ func (msg *FromFediAPI) Deserialize(data []byte) error {
 	msg.APActivityType = imsg.APActivityType
 	msg.TargetURI = imsg.TargetURI
 
+	if imsg.APIRI != "" {
+		// Parse AP IRI string.
+		msg.APIRI, err = url.Parse(imsg.APIRI)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Resolve AP object from JSON data.
 	msg.APObject, err = resolveAPObject(
 		imsg.APObject,
@@ -352,7 +362,7 @@ func resolveAPObject(data map[string]interface{}) (interface{}, error) {
 // we then need to wrangle back into the original type. So we also store the type name
 // and use this to determine the appropriate Go structure type to unmarshal into to.
 func resolveGTSModel(typ string, data []byte) (interface{}, error) {
-	if typ == "" && data == nil {
+	if typ == "" && (data == nil || string(data) == "null") {
 		// No data given.
 		return nil, nil
 	}
@@ -121,6 +121,23 @@ var fromFediAPICases = []struct {
 			"receiving_id":     "654321",
 		}),
 	},
+	{
+		msg: messages.FromFediAPI{
+			APObjectType:   ap.ActivityLike,
+			APActivityType: ap.ActivityUndo,
+			APIRI:          testrig.URLMustParse("https://fossbros-anonymous.io/users/foss_satan/undo/1"),
+			Requesting:     &gtsmodel.Account{ID: "123456"},
+			Receiving:      &gtsmodel.Account{ID: "654321"},
+		},
+		data: toJSON(map[string]any{
+			"ap_object_type":   ap.ActivityLike,
+			"ap_activity_type": ap.ActivityUndo,
+			"ap_iri":           "https://fossbros-anonymous.io/users/foss_satan/undo/1",
+			"gts_model":        nil,
+			"requesting_id":    "123456",
+			"receiving_id":     "654321",
+		}),
+	},
 }
 
 func TestSerializeFromClientAPI(t *testing.T) {
@@ -22,14 +22,21 @@ package metrics
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/queue"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,7 +48,7 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -74,6 +81,7 @@ func Initialize(db db.DB) error {
 
 	meter := meterProvider.Meter(serviceName)
 
+	db := state.DB
 	thisInstance := config.GetHost()
 
 	_, err = meter.Int64ObservableGauge(
@@ -124,7 +132,219 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
-	return nil
+	if err := initializeCaches(meter, &state.Caches); err != nil {
+		return err
+	}
+
+	if err := initializeWorkers(meter, &state.Workers); err != nil {
+		return err
+	}
+
+	return initializeHTTPClient(meter, state)
+}
+
+// initializeWorkers registers instruments exposing the queue
+// depths, enqueue counts and processing latencies of the client,
+// federator and delivery worker pools, e.g. for autoscaling.
+func initializeWorkers(meter metric.Meter, w *workers.Workers) error {
+	depth, err := meter.Int64ObservableGauge(
+		"gotosocial.workers.queue_depth",
+		metric.WithDescription("Number of messages currently queued for workers, by queue (and lane)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	enqueued, err := meter.Int64ObservableCounter(
+		"gotosocial.workers.enqueued",
+		metric.WithDescription("Number of messages pushed to worker queues"),
+	)
+	if err != nil {
+		return err
+	}
+
+	duration, err := meter.Float64Histogram(
+		"gotosocial.workers.process_duration",
+		metric.WithDescription("Time spent by workers processing each queued message (or delivery attempt)"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60),
+	)
+	if err != nil {
+		return err
+	}
+
+	queues := []struct {
+		name  string
+		stats *queue.Stats
+	}{
+		{"client", &w.Client.Queue.Stats},
+		{"federator", &w.Federator.Queue.Stats},
+		{"delivery", &w.Delivery.Queue.Stats},
+	}
+
+	for _, q := range queues {
+		// Record processing durations into
+		// histogram as they happen, as these
+		// can't be observed after the fact.
+		attrs := metric.WithAttributes(attribute.String("queue", q.name))
+		q.stats.Observe(func(d time.Duration) {
+			duration.Record(context.Background(), d.Seconds(), attrs)
+		})
+	}
+
+	_, err = meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			for _, lane := range workers.Lanes() {
+				laneAttr := attribute.String("lane", lane.String())
+				o.ObserveInt64(depth, int64(w.Client.Queue.LaneLen(lane)), metric.WithAttributes(attribute.String("queue", "client"), laneAttr))
+				o.ObserveInt64(depth, int64(w.Federator.Queue.LaneLen(lane)), metric.WithAttributes(attribute.String("queue", "federator"), laneAttr))
+			}
+			o.ObserveInt64(depth, int64(w.Delivery.Queue.Len()), metric.WithAttributes(attribute.String("queue", "delivery")))
+
+			for _, q := range queues {
+				attrs := metric.WithAttributes(attribute.String("queue", q.name))
+				o.ObserveInt64(enqueued, int64(q.stats.Enqueued()), attrs) // #nosec G115 -- counter won't overflow
+			}
+			return nil
+		},
+		depth, enqueued,
+	)
+	return err
+}
+
+// initializeCaches registers instruments
+// exposing the usage statistics of caches.
+func initializeCaches(meter metric.Meter, caches *cache.Caches) error {
+	hits, err := meter.Int64ObservableCounter(
+		"gotosocial.cache.hits",
+		metric.WithDescription("Number of cache lookups served from the cache"),
+	)
+	if err != nil {
+		return err
+	}
+
+	misses, err := meter.Int64ObservableCounter(
+		"gotosocial.cache.misses",
+		metric.WithDescription("Number of cache lookups that had to be loaded from the database"),
+	)
+	if err != nil {
+		return err
+	}
+
+	evictions, err := meter.Int64ObservableCounter(
+		"gotosocial.cache.evictions",
+		metric.WithDescription("Number of items evicted from the cache, on reaching capacity or by cache sweeps"),
+	)
+	if err != nil {
+		return err
+	}
+
+	size, err := meter.Int64ObservableGauge(
+		"gotosocial.cache.size",
+		metric.WithDescription("Number of items currently in the cache"),
+	)
+	if err != nil {
+		return err
+	}
+
+	capacity, err := meter.Int64ObservableGauge(
+		"gotosocial.cache.capacity",
+		metric.WithDescription("Maximum number of items in the cache, as calculated from memory target and ratio"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			for name, stats := range caches.Stats() {
+				attrs := metric.WithAttributes(attribute.String("cache", name))
+				o.ObserveInt64(hits, int64(stats.Hits), attrs)
+				o.ObserveInt64(misses, int64(stats.Misses), attrs)
+				o.ObserveInt64(evictions, int64(stats.Evictions), attrs) // #nosec G115 -- counter won't overflow
+				o.ObserveInt64(size, int64(stats.Size), attrs)
+				o.ObserveInt64(capacity, int64(stats.Capacity), attrs)
+			}
+			return nil
+		},
+		hits, misses, evictions, size, capacity,
+	)
+	return err
+}
+
+// initializeHTTPClient registers instruments exposing
+// statistics of outgoing HTTP client requests. These are
+// aggregated over hosts, as per-host labels would be of
+// far too high cardinality. See the admin API for those.
+func initializeHTTPClient(meter metric.Meter, state *state.State) error {
+	requests, err := meter.Int64ObservableCounter(
+		"gotosocial.http_client.requests",
+		metric.WithDescription("Number of outgoing HTTP requests, by response status class (or error)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	duration, err := meter.Float64ObservableCounter(
+		"gotosocial.http_client.request_duration",
+		metric.WithDescription("Total time spent waiting on outgoing HTTP request response headers"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	sent, err := meter.Int64ObservableCounter(
+		"gotosocial.http_client.sent_bytes",
+		metric.WithDescription("Number of outgoing HTTP request body bytes sent"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	received, err := meter.Int64ObservableCounter(
+		"gotosocial.http_client.received_bytes",
+		metric.WithDescription("Number of outgoing HTTP response body bytes received"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			client := state.Workers.Delivery.Client
+			if client == nil {
+				return nil
+			}
+
+			// Sum stats over all hosts.
+			var total httpclient.HostStats
+			for _, stats := range client.Stats() {
+				total.Requests += stats.Requests
+				total.Errors += stats.Errors
+				total.Latency += stats.Latency
+				total.BytesSent += stats.BytesSent
+				total.BytesReceived += stats.BytesReceived
+				for i := range stats.Statuses {
+					total.Statuses[i] += stats.Statuses[i]
+				}
+			}
+
+			for i := 1; i < len(total.Statuses); i++ {
+				attrs := metric.WithAttributes(attribute.String("status_class", strconv.Itoa(i)+"xx"))
+				o.ObserveInt64(requests, int64(total.Statuses[i]), attrs) // #nosec G115 -- counter won't overflow
+			}
+			o.ObserveInt64(requests, int64(total.Errors), metric.WithAttributes(attribute.String("status_class", "error"))) // #nosec G115 -- counter won't overflow
+			o.ObserveFloat64(duration, total.Latency.Seconds())
+			o.ObserveInt64(sent, int64(total.BytesSent))         // #nosec G115 -- counter won't overflow
+			o.ObserveInt64(received, int64(total.BytesReceived)) // #nosec G115 -- counter won't overflow
+			return nil
+		},
+		requests, duration, sent, received,
+	)
+	return err
 }
 
 func InstrumentGin() gin.HandlerFunc {
@@ -67,7 +67,10 @@ func HeaderFilter(state *state.State) gin.HandlerFunc {
 }
 
 func headerFilterAllowMode(state *state.State) func(c *gin.Context) {
-	_ = *state //nolint
+	if state == nil {
+		panic("nil state")
+	}
+
 	// Allowlist mode: explicit block takes
 	// precedence over explicit allow.
 	//
@@ -105,7 +108,10 @@ func headerFilterAllowMode(state *state.State) func(c *gin.Context) {
 }
 
 func headerFilterBlockMode(state *state.State) func(c *gin.Context) {
-	_ = *state //nolint
+	if state == nil {
+		panic("nil state")
+	}
+
 	// Blocklist/default mode: explicit allow
 	// takes precedence over explicit block.
 	//
@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func TestIPBlock(t *testing.T) {
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+
+	for _, test := range []struct {
+		blocks map[string]gtsmodel.IPBlockSeverity
+		method string
+		path   string
+		ip     string
+		expect bool
+	}{
+		{
+			// No blocks with expected 200 OK.
+			blocks: map[string]gtsmodel.IPBlockSeverity{},
+			method: http.MethodGet,
+			path:   "/",
+			ip:     "192.0.2.1",
+			expect: true,
+		},
+		{
+			// No access block on range with expected 403 Forbidden.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"192.0.2.0/24": gtsmodel.IPBlockSeverityNoAccess,
+			},
+			method: http.MethodGet,
+			path:   "/",
+			ip:     "192.0.2.1",
+			expect: false,
+		},
+		{
+			// No access block on other range with expected 200 OK.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"198.51.100.0/24": gtsmodel.IPBlockSeverityNoAccess,
+			},
+			method: http.MethodGet,
+			path:   "/",
+			ip:     "192.0.2.1",
+			expect: true,
+		},
+		{
+			// No access block on IPv6 range with expected 403 Forbidden.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"2001:db8::/32": gtsmodel.IPBlockSeverityNoAccess,
+			},
+			method: http.MethodGet,
+			path:   "/",
+			ip:     "2001:db8::1",
+			expect: false,
+		},
+		{
+			// Sign-up block on non sign-up request with expected 200 OK.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"192.0.2.1/32": gtsmodel.IPBlockSeveritySignUpBlock,
+			},
+			method: http.MethodGet,
+			path:   "/api/v1/accounts",
+			ip:     "192.0.2.1",
+			expect: true,
+		},
+		{
+			// Sign-up block on sign-up request with expected 403 Forbidden.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"192.0.2.1/32": gtsmodel.IPBlockSeveritySignUpBlock,
+			},
+			method: http.MethodPost,
+			path:   "/api/v1/accounts",
+			ip:     "192.0.2.1",
+			expect: false,
+		},
+		{
+			// Overlapping blocks where most severe takes precedence with expected 403 Forbidden.
+			blocks: map[string]gtsmodel.IPBlockSeverity{
+				"192.0.2.0/24": gtsmodel.IPBlockSeveritySignUpBlock,
+				"192.0.2.1/32": gtsmodel.IPBlockSeverityNoAccess,
+			},
+			method: http.MethodGet,
+			path:   "/",
+			ip:     "192.0.2.1",
+			expect: false,
+		},
+	} {
+		// Generate a unique name for this test case.
+		name := fmt.Sprintf("blocks=%v %s %s from %s => expect=%v",
+			test.blocks,
+			test.method,
+			test.path,
+			test.ip,
+			test.expect,
+		)
+
+		// Run this particular test case.
+		ok := t.Run(name, func(t *testing.T) {
+			testIPBlock(t,
+				test.blocks,
+				test.method,
+				test.path,
+				test.ip,
+				test.expect,
+			)
+		})
+
+		if !ok {
+			return
+		}
+	}
+}
+
+func testIPBlock(t *testing.T, blocks map[string]gtsmodel.IPBlockSeverity, method, path, ip string, expect bool) {
+	var err error
+
+	// Create test context with cancel.
+	ctx := context.Background()
+	ctx, cncl := context.WithCancel(ctx)
+	defer cncl()
+
+	// Initialize caches.
+	var state state.State
+	state.Caches.Init()
+
+	// Create new database instance with test config.
+	state.DB, err = bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+
+	// Insert all IP blocks into DB.
+	for prefix, severity := range blocks {
+		block := &gtsmodel.IPBlock{
+			ID:                 id.NewULID(),
+			IP:                 prefix,
+			Severity:           severity,
+			CreatedByAccountID: "admin-id",
+		}
+
+		if err := state.DB.PutIPBlock(ctx, block); err != nil {
+			t.Fatalf("error inserting ip block into database: %v", err)
+		}
+	}
+
+	// Gin test http engine
+	// (used for ctx init).
+	e := gin.New()
+
+	// Create new ip block middleware to test against.
+	middleware := middleware.IPBlock(&state)
+	e.Use(middleware)
+
+	// Set the empty gin handler (always returns okay).
+	e.Handle(method, path, func(ctx *gin.Context) { ctx.Status(200) })
+
+	// Prepare a gin test context.
+	r := httptest.NewRequest(method, path, nil)
+	rw := httptest.NewRecorder()
+
+	// Set input remote address.
+	r.RemoteAddr = "[" + ip + "]:1234"
+
+	// Pass req through
+	// engine handler.
+	e.ServeHTTP(rw, r)
+
+	// Get http result.
+	res := rw.Result()
+
+	switch {
+	case expect && res.StatusCode != http.StatusOK:
+		t.Errorf("unexpected response (should allow): %s", res.Status)
+
+	case !expect && res.StatusCode != http.StatusForbidden:
+		t.Errorf("unexpected response (should block): %s", res.Status)
+	}
+}
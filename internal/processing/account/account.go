@@ -18,6 +18,8 @@
 package account
 
 import (
+	"sync/atomic"
+
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -43,6 +45,10 @@ type Processor struct {
 	federator    *federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
 	themes       *Themes
+
+	// set while status
+	// expiry is running.
+	expiring *atomic.Bool
 }
 
 // New returns a new account processor.
@@ -65,5 +71,6 @@ func New(
 		federator:    federator,
 		parseMention: parseMention,
 		themes:       PopulateThemes(),
+		expiring:     new(atomic.Bool),
 	}
 }
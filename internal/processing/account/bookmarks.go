@@ -18,15 +18,21 @@
 package account
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"errors"
+	"mime/multipart"
+	"net/url"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -91,3 +97,162 @@ func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmode
 		Limit:          limit,
 	})
 }
+
+// BookmarksExport returns all statuses bookmarked by requestingAccount
+// as CSV data, with one status URI per line, suitable for later import.
+func (p *Processor) BookmarksExport(ctx context.Context, requestingAccount *gtsmodel.Account) ([]byte, gtserror.WithCode) {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting bookmarks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	for _, bookmark := range bookmarks {
+		if bookmark.Status == nil {
+			// Status no longer
+			// available, skip.
+			continue
+		}
+
+		if err := w.Write([]string{bookmark.Status.URI}); err != nil {
+			err = gtserror.Newf("error writing csv: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		err = gtserror.Newf("error writing csv: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// BookmarksImport handles the import of bookmarks for requester
+// from the provided CSV file of status URIs, as generated by BookmarksExport.
+//
+// The file is parsed straight away, but the statuses within are resolved
+// (dereferencing them from their origin if necessary) and bookmarked in
+// the background, as this may take a long time for large imports.
+func (p *Processor) BookmarksImport(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	dataF *multipart.FileHeader,
+) gtserror.WithCode {
+	// Open the provided file.
+	file, err := dataF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse file as slice of status URIs.
+	uris, err := typeutils.CSVToBookmarks(file)
+	if err != nil {
+		err = gtserror.Newf("error parsing attachment as bookmarks csv: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(uris) == 0 {
+		err = gtserror.New("error importing bookmarks: 0 entries provided")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	requesterID := requester.ID
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := p.importBookmarks(ctx, requesterID, uris); err != nil {
+			log.Errorf(ctx, "error importing bookmarks for account %s: %v", requesterID, err)
+		}
+	})
+
+	return nil
+}
+
+// importBookmarks bookmarks the status at each of the given
+// uris on behalf of the account with requesterID. Statuses
+// that can't be bookmarked are logged and skipped.
+func (p *Processor) importBookmarks(
+	ctx context.Context,
+	requesterID string,
+	uris []string,
+) error {
+	requester, err := p.state.DB.GetAccountByID(ctx, requesterID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	for _, uri := range uris {
+		if errWithCode := p.bookmarkImport(ctx, requester, uri); errWithCode != nil {
+			log.Warnf(ctx, "error importing bookmark %s: %v", uri, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// bookmarkImport bookmarks the status at uri for requestingAccount,
+// dereferencing it if necessary. No-op if already bookmarked.
+func (p *Processor) bookmarkImport(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	uriStr string,
+) gtserror.WithCode {
+	uri, err := url.Parse(uriStr)
+	if err != nil || uri.Scheme == "" || uri.Host == "" {
+		const text = "invalid status uri"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Fetch the status, dereferencing it if we don't have it already.
+	status, _, err := p.federator.GetStatusByURI(ctx, requestingAccount.Username, uri)
+	if err != nil {
+		err = gtserror.Newf("error getting status %s: %w", uriStr, err)
+		return gtserror.NewErrorNotFound(err, "status could not be retrieved")
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+	if err != nil {
+		err = gtserror.Newf("error checking status visibility: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !visible {
+		const text = "status not found"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	bookmarked, err := p.state.DB.IsStatusBookmarkedBy(ctx, requestingAccount.ID, status.ID)
+	if err != nil {
+		err = gtserror.Newf("error checking existing bookmark: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if bookmarked {
+		// Nothing to do.
+		return nil
+	}
+
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+	}); err != nil {
+		err = gtserror.Newf("error putting bookmark in database: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, requestingAccount.ID, status.ID); err != nil {
+		log.Errorf(ctx, "error invalidating timelined status: %v", err)
+	}
+
+	return nil
+}
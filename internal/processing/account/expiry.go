@@ -0,0 +1,242 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+const (
+	// how often the status expiry job runs.
+	statusExpiryEvery = time.Hour
+
+	// max no. statuses to queue for
+	// deletion before pausing, so that
+	// deletes get federated in batches.
+	statusExpiryBatch = 20
+
+	// time to wait between batches.
+	statusExpiryPause = 30 * time.Second
+)
+
+// ScheduleStatusExpiry schedules a recurring job to delete
+// statuses of local accounts that have status expiry set.
+func (p *Processor) ScheduleStatusExpiry() error {
+	fn := func(ctx context.Context, start time.Time) {
+		if !p.expiring.CompareAndSwap(false, true) {
+			// A slow previous run is still
+			// going, don't queue duplicates.
+			log.Warn(ctx, "previous status expiry still running, skipping")
+			return
+		}
+		defer p.expiring.Store(false)
+
+		log.Info(ctx, "starting status expiry")
+		n := p.ExpireStatuses(ctx)
+		log.Infof(ctx, "finished status expiry after %s; %d statuses queued for deletion", time.Since(start), n)
+	}
+
+	// Ensure only one process sharing
+	// the database runs status expiry.
+	fn = p.state.Workers.Scheduler.Exclusive(
+		"@statusexpiry",
+		statusExpiryEvery/2,
+		fn,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@statusexpiry",
+		time.Time{},
+		statusExpiryEvery,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @statusexpiry")
+	}
+
+	return nil
+}
+
+// ExpireStatuses queues deletion of all public statuses authored by local accounts
+// that are older than their account's configured expiry, skipping those exempt by
+// the account's settings. Deletions are queued on the client API worker, so they go
+// through the usual status wipe + Delete federation path, in batches of
+// statusExpiryBatch with a pause in between. Returns no. statuses queued.
+func (p *Processor) ExpireStatuses(ctx context.Context) int {
+	accounts, err := p.state.DB.GetAccountsWithStatusExpiry(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting accounts with status expiry: %v", err)
+		return 0
+	}
+
+	var total, batch int
+
+	for _, account := range accounts {
+		var (
+			statuses []*gtsmodel.Status
+			maxID    string
+			now      = time.Now()
+		)
+
+		// Page through the account's expired statuses,
+		// queuing each page before fetching the next.
+		for {
+			statuses, maxID, err = p.expiredStatuses(ctx, account, maxID, now)
+			if err != nil {
+				log.Errorf(ctx, "error getting expired statuses for account %s: %v", account.ID, err)
+				break
+			}
+
+			for _, status := range statuses {
+				if batch == statusExpiryBatch {
+					// Batch is full, pause before
+					// queuing any further deletes.
+					select {
+					case <-ctx.Done():
+						return total
+					case <-time.After(statusExpiryPause):
+					}
+					batch = 0
+				}
+
+				// Process delete side effects, as bulk
+				// work so as not to hold up anything
+				// a user is actively waiting on.
+				p.state.Workers.Client.Queue.PushLane(workers.LaneBulk, &messages.FromClientAPI{
+					APObjectType:   ap.ObjectNote,
+					APActivityType: ap.ActivityDelete,
+					GTSModel:       status,
+					Origin:         account,
+					Target:         account,
+				})
+
+				batch++
+				total++
+			}
+
+			if maxID == "" {
+				// Reached the end.
+				break
+			}
+		}
+	}
+
+	return total
+}
+
+// expiredStatuses returns a page of public statuses older than maxID authored
+// by the given account, that have expired as of now according to the account's
+// settings, along with the maxID for the next page. An empty maxID fetches the
+// first page, and an empty next maxID indicates there are no more pages.
+func (p *Processor) expiredStatuses(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	maxID string,
+	now time.Time,
+) ([]*gtsmodel.Status, string, error) {
+	if account.Settings == nil || account.Settings.ExpiryDays <= 0 {
+		// Nothing to expire.
+		return nil, "", nil
+	}
+
+	var (
+		settings   = account.Settings
+		keepPinned = util.PtrValueOr(settings.ExpiryKeepPinned, true)
+		keepFaved  = util.PtrValueOr(settings.ExpiryKeepFaved, true)
+	)
+
+	// Statuses created before this cutoff have expired.
+	cutoff := now.Add(-time.Duration(settings.ExpiryDays) * 24 * time.Hour)
+
+	if maxID == "" {
+		// ULIDs are sortable by time, so use a
+		// ULID generated at cutoff as maxID to
+		// only select statuses older than it.
+		var err error
+		maxID, err = id.NewULIDFromTime(cutoff)
+		if err != nil {
+			return nil, "", gtserror.Newf("error generating cutoff id: %w", err)
+		}
+	}
+
+	// Get a page of the account's public
+	// statuses, excluding boosts, from maxID.
+	statuses, err := p.state.DB.GetAccountStatuses(ctx,
+		account.ID,
+		statusExpiryBatch,
+		false, // excludeReplies
+		true,  // excludeReblogs
+		maxID,
+		"",    // minID
+		false, // mediaOnly
+		true,  // publicOnly
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, "", gtserror.Newf("db error getting statuses: %w", err)
+	}
+
+	if len(statuses) == 0 {
+		// Reached the end.
+		return nil, "", nil
+	}
+
+	// Set next page maxID.
+	maxID = statuses[len(statuses)-1].ID
+
+	// Filter the page in-place.
+	expired := statuses[:0]
+
+	for _, status := range statuses {
+		if status.CreatedAt.After(cutoff) {
+			// Not yet expired.
+			continue
+		}
+
+		if keepPinned && !status.PinnedAt.IsZero() {
+			// Exempt as pinned.
+			continue
+		}
+
+		if keepFaved {
+			faved, err := p.state.DB.IsStatusFavedBy(ctx, status.ID, account.ID)
+			if err != nil {
+				return nil, "", gtserror.Newf("db error checking fave: %w", err)
+			}
+
+			if faved {
+				// Exempt as self-faved.
+				continue
+			}
+		}
+
+		expired = append(expired, status)
+	}
+
+	return expired, maxID, nil
+}
@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusExpiryTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// enableExpiry enables status expiry after 1 day
+// for account, keeping pinned + self-faved statuses.
+func (suite *StatusExpiryTestSuite) enableExpiry(account *gtsmodel.Account) {
+	settings := new(gtsmodel.AccountSettings)
+	*settings = *account.Settings
+	settings.ExpiryDays = 1
+	settings.ExpiryKeepPinned = util.Ptr(true)
+	settings.ExpiryKeepFaved = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(context.Background(), settings,
+		"expiry_days",
+		"expiry_keep_pinned",
+		"expiry_keep_faved",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// pin pins the given status.
+func (suite *StatusExpiryTestSuite) pin(status *gtsmodel.Status) {
+	pin := new(gtsmodel.Status)
+	*pin = *status
+	pin.PinnedAt = time.Now()
+	if err := suite.db.UpdateStatus(context.Background(), pin, "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *StatusExpiryTestSuite) TestExpireStatuses() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		pinned  = suite.testStatuses["local_account_1_status_1"]
+		public  = suite.testStatuses["local_account_1_status_7"]
+	)
+
+	suite.enableExpiry(account)
+	suite.pin(pinned)
+
+	// All the account's statuses are older than 1 day,
+	// but only public ones expire, and one is pinned.
+	// Unlisted, followers-only etc must all be kept.
+	n := suite.accountProcessor.ExpireStatuses(ctx)
+	suite.Equal(1, n)
+
+	msg, ok := suite.getClientMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("timed out waiting for delete message")
+	}
+
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(account.ID, msg.Origin.ID)
+
+	status, ok := msg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		suite.FailNow("", "unexpected model type %T", msg.GTSModel)
+	}
+
+	suite.Equal(public.ID, status.ID)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+}
+
+func (suite *StatusExpiryTestSuite) TestExpireStatusesKeepFaved() {
+	var (
+		ctx       = context.Background()
+		account   = suite.testAccounts["local_account_1"]
+		pinned    = suite.testStatuses["local_account_1_status_1"]
+		selfFaved = suite.testStatuses["local_account_1_status_7"]
+	)
+
+	suite.enableExpiry(account)
+	suite.pin(pinned)
+
+	// Fave the remaining public status.
+	if err := suite.db.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              "01J1B6ZAQV48X3SSRP0FQVWWM6",
+		AccountID:       account.ID,
+		TargetAccountID: account.ID,
+		StatusID:        selfFaved.ID,
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01J1B6ZAQV48X3SSRP0FQVWWM6",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Every public status is exempt.
+	n := suite.accountProcessor.ExpireStatuses(ctx)
+	suite.Zero(n)
+}
+
+func TestStatusExpiryTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusExpiryTestSuite))
+}
@@ -0,0 +1,491 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package account
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/archive"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// exportRetention is how long a
+	// completed account data archive is
+	// kept available for download.
+	exportRetention = 24 * time.Hour
+
+	// exportHeartbeat is how often the
+	// progress of an in-progress export
+	// is written to the database.
+	exportHeartbeat = 30 * time.Second
+
+	// exportStale is how long after its last
+	// heartbeat an in-progress export is
+	// considered abandoned, e.g. because the
+	// process generating it was restarted.
+	exportStale = 10 * exportHeartbeat
+
+	// exportSweepEvery is how often
+	// expired and abandoned exports
+	// are removed from the export dir.
+	exportSweepEvery = time.Hour
+
+	// exportExt is the extension
+	// of archive files in export dir.
+	exportExt = ".zip"
+)
+
+// Possible states of an account export.
+const (
+	exportStateInProgress = "in_progress"
+	exportStateComplete   = "complete"
+	exportStateFailed     = "failed"
+)
+
+// exportDir returns the directory account data
+// archives are written to, creating it if needed.
+func exportDir() (string, error) {
+	dir := config.GetAccountsExportPath()
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "gotosocial-exports")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", gtserror.Newf("error creating export dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+// exportPath returns the path in dir of the archive file for export.
+func exportPath(dir string, export *gtsmodel.AccountExport) string {
+	return filepath.Join(dir, export.ID+exportExt)
+}
+
+// exportInProgress returns whether export is still being
+// generated, ie., it's not completed and not abandoned.
+func exportInProgress(export *gtsmodel.AccountExport, now time.Time) bool {
+	return export.CompletedAt.IsZero() &&
+		now.Sub(export.UpdatedAt) < exportStale
+}
+
+// exportExpired returns whether export is either completed and
+// past the retention period, or abandoned while in progress.
+func exportExpired(export *gtsmodel.AccountExport, now time.Time) bool {
+	if export.CompletedAt.IsZero() {
+		return now.Sub(export.UpdatedAt) >= exportStale
+	}
+	return now.Sub(export.CompletedAt) >= exportRetention
+}
+
+// removeExport removes the archive file in dir and the database entry of export.
+func (p *Processor) removeExport(ctx context.Context, dir string, export *gtsmodel.AccountExport) error {
+	path := exportPath(dir, export)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return gtserror.Newf("error removing archive file %s: %w", path, err)
+	}
+
+	if err := p.state.DB.DeleteAccountExportByID(ctx, export.ID); err != nil {
+		return gtserror.Newf("db error deleting export %s: %w", export.ID, err)
+	}
+
+	return nil
+}
+
+// getExport returns the current export of requester, if any. Expired
+// exports are removed and treated as though they don't exist.
+func (p *Processor) getExport(ctx context.Context, requester *gtsmodel.Account) (*gtsmodel.AccountExport, gtserror.WithCode) {
+	export, err := p.state.DB.GetAccountExportByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting export: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if export == nil ||
+		export.CompletedAt.IsZero() ||
+		time.Since(export.CompletedAt) < exportRetention {
+		// Abandoned exports are left
+		// for the sweep, so that the
+		// user can still see they failed.
+		return export, nil
+	}
+
+	// Completed longer ago than retention
+	// period, remove it now rather than
+	// waiting for the next sweep.
+	dir, err := exportDir()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.removeExport(ctx, dir, export); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return nil, nil
+}
+
+// ExportCreate starts generating an archive of the data of
+// requester in the background, on the processing worker
+// pool. Any previous archive for requester is removed first.
+// Only one export per account may be in progress at a time.
+func (p *Processor) ExportCreate(ctx context.Context, requester *gtsmodel.Account) (*apimodel.AccountExport, gtserror.WithCode) {
+	const text = "an export is already in progress"
+
+	dir, err := exportDir()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	previous, errWithCode := p.getExport(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if previous != nil {
+		if exportInProgress(previous, time.Now()) {
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		// Clear up the previous archive.
+		if err := p.removeExport(ctx, dir, previous); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	now := time.Now()
+	export := &gtsmodel.AccountExport{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: requester.ID,
+	}
+
+	// The unique account ID constraint ensures
+	// that a concurrent create, possibly on another
+	// process, doesn't result in two exports.
+	if err := p.state.DB.PutAccountExport(ctx, export); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error putting export: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.runExport(ctx, dir, export)
+	})
+
+	return apiExport(export), nil
+}
+
+// runExport writes the archive for export to the
+// export dir, keeping its database entry updated
+// with progress, and marks it completed when done.
+func (p *Processor) runExport(ctx context.Context, dir string, export *gtsmodel.AccountExport) {
+	var (
+		mu       sync.Mutex
+		progress archive.Progress
+		done     = make(chan struct{})
+		stopped  = make(chan struct{})
+	)
+
+	// Periodically write progress to the
+	// db, which also shows other processes
+	// this export hasn't been abandoned.
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(exportHeartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			mu.Lock()
+			export.Done = progress.Done
+			export.Total = progress.Total
+			mu.Unlock()
+
+			if err := p.state.DB.UpdateAccountExport(ctx, export,
+				"done",
+				"total",
+			); err != nil {
+				log.Errorf(ctx, "db error updating export %s: %v", export.ID, err)
+			}
+		}
+	}()
+
+	err := p.writeExport(ctx, exportPath(dir, export), export.AccountID, func(p archive.Progress) {
+		mu.Lock()
+		progress = p
+		mu.Unlock()
+	})
+
+	close(done)
+	<-stopped
+
+	export.Done = progress.Done
+	export.Total = progress.Total
+	export.CompletedAt = time.Now()
+	if err != nil {
+		log.Errorf(ctx, "error exporting account %s: %v", export.AccountID, err)
+		export.Error = err.Error()
+	}
+
+	if err := p.state.DB.UpdateAccountExport(ctx, export,
+		"done",
+		"total",
+		"completed_at",
+		"error",
+	); err != nil {
+		log.Errorf(ctx, "db error updating export %s: %v", export.ID, err)
+	}
+}
+
+// writeExport writes an archive of the data of the account with
+// accountID to a file at path, calling progress as it goes.
+func (p *Processor) writeExport(ctx context.Context, path string, accountID string, progress func(archive.Progress)) error {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return gtserror.Newf("error creating archive file: %w", err)
+	}
+
+	w := archive.NewWriter(p.state, p.converter)
+	err = w.Write(ctx, account, file, progress)
+
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = gtserror.Newf("error closing archive file: %w", closeErr)
+	}
+
+	if err != nil {
+		// Don't leave a partial archive lying around.
+		if err := os.Remove(path); err != nil {
+			log.Errorf(ctx, "error removing partial account export: %v", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ExportGet returns the state of requester's
+// most recent export, if there is one.
+func (p *Processor) ExportGet(ctx context.Context, requester *gtsmodel.Account) (*apimodel.AccountExport, gtserror.WithCode) {
+	export, errWithCode := p.getExport(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if export == nil {
+		const text = "no export found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return apiExport(export), nil
+}
+
+// ExportArchive opens requester's most recently completed
+// archive for reading, returning it along with its size.
+// It is up to the caller to close the returned reader.
+func (p *Processor) ExportArchive(ctx context.Context, requester *gtsmodel.Account) (io.ReadCloser, int64, gtserror.WithCode) {
+	const text = "no completed export found"
+
+	export, errWithCode := p.getExport(ctx, requester)
+	if errWithCode != nil {
+		return nil, 0, errWithCode
+	}
+
+	if export == nil || export.CompletedAt.IsZero() || export.Error != "" {
+		return nil, 0, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	dir, err := exportDir()
+	if err != nil {
+		return nil, 0, gtserror.NewErrorInternalError(err)
+	}
+
+	file, err := os.Open(exportPath(dir, export))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+		err = gtserror.Newf("error opening archive file: %w", err)
+		return nil, 0, gtserror.NewErrorInternalError(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		err = gtserror.Newf("error getting archive file info: %w", err)
+		return nil, 0, gtserror.NewErrorInternalError(err)
+	}
+
+	return file, info.Size(), nil
+}
+
+// ScheduleExportSweep sweeps the export dir once now, to clear
+// up anything left behind by a previous run, then schedules a
+// recurring job to keep sweeping it, see SweepExports.
+func (p *Processor) ScheduleExportSweep(ctx context.Context) error {
+	if err := p.SweepExports(ctx); err != nil {
+		return err
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		if err := p.SweepExports(ctx); err != nil {
+			log.Errorf(ctx, "error sweeping account exports: %v", err)
+		}
+	}
+
+	// Ensure only one process sharing
+	// the database sweeps at a time.
+	fn = p.state.Workers.Scheduler.Exclusive(
+		"@exportsweep",
+		exportSweepEvery/2,
+		fn,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@exportsweep",
+		time.Time{},
+		exportSweepEvery,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @exportsweep")
+	}
+
+	return nil
+}
+
+// SweepExports removes account exports that completed longer ago than
+// the retention period or were abandoned while in progress, along with
+// any files in the export dir that don't belong to a known export.
+func (p *Processor) SweepExports(ctx context.Context) error {
+	dir, err := exportDir()
+	if err != nil {
+		return err
+	}
+
+	// List files *before* fetching exports: entries
+	// are put before their file is created, so any
+	// file listed here for an export that's still
+	// in progress will have an entry in the db.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return gtserror.Newf("error reading export dir: %w", err)
+	}
+
+	exports, err := p.state.DB.GetAccountExports(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting exports: %w", err)
+	}
+
+	var (
+		now   = time.Now()
+		known = make(map[string]struct{}, len(exports))
+	)
+
+	for _, export := range exports {
+		if !exportExpired(export, now) {
+			known[export.ID+exportExt] = struct{}{}
+			continue
+		}
+
+		log.Debugf(ctx, "removing expired export %s", export.ID)
+		if err := p.removeExport(ctx, dir, export); err != nil {
+			log.Errorf(ctx, "error removing expired export: %v", err)
+		}
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() ||
+			!strings.HasSuffix(name, exportExt) {
+			// Only ever touch export archives,
+			// the dir may be shared with others.
+			continue
+		}
+
+		if _, ok := known[name]; ok {
+			continue
+		}
+
+		log.Debugf(ctx, "removing orphaned export file %s", name)
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			log.Errorf(ctx, "error removing orphaned export file: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// apiExport converts export to its API model.
+func apiExport(export *gtsmodel.AccountExport) *apimodel.AccountExport {
+	apiExport := &apimodel.AccountExport{
+		State:     exportStateInProgress,
+		CreatedAt: util.FormatISO8601(export.CreatedAt),
+	}
+
+	if export.Total > 0 {
+		apiExport.Progress = 100 * export.Done / export.Total
+	}
+
+	if export.CompletedAt.IsZero() {
+		if !exportInProgress(export, time.Now()) {
+			// Abandoned before completion.
+			apiExport.State = exportStateFailed
+		}
+		return apiExport
+	}
+
+	apiExport.CompletedAt = util.FormatISO8601(export.CompletedAt)
+
+	if export.Error != "" {
+		apiExport.State = exportStateFailed
+		return apiExport
+	}
+
+	apiExport.State = exportStateComplete
+	apiExport.Progress = 100
+	apiExport.ExpiresAt = util.FormatISO8601(export.CompletedAt.Add(exportRetention))
+	return apiExport
+}
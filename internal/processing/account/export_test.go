@@ -0,0 +1,231 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/archive"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ExportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ExportTestSuite) TestExport() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetAccountsExportPath(suite.T().TempDir())
+
+	// There's no export yet.
+	_, errWithCode := suite.accountProcessor.ExportGet(ctx, testAccount)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	export, errWithCode := suite.accountProcessor.ExportCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("in_progress", export.State)
+	suite.Zero(export.Progress)
+
+	// Can't start another while this one's in progress.
+	_, errWithCode = suite.accountProcessor.ExportCreate(ctx, testAccount)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Archive isn't available yet.
+	_, _, errWithCode = suite.accountProcessor.ExportArchive(ctx, testAccount)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Run the queued export job.
+	job, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected export job to be queued")
+	}
+	job(ctx)
+
+	export, errWithCode = suite.accountProcessor.ExportGet(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("complete", export.State)
+	suite.Equal(100, export.Progress)
+	suite.NotEmpty(export.CompletedAt)
+	suite.NotEmpty(export.ExpiresAt)
+
+	rc, size, errWithCode := suite.accountProcessor.ExportArchive(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer rc.Close()
+
+	r, ok := rc.(io.ReaderAt)
+	if !ok {
+		suite.FailNow("expected archive to be io.ReaderAt")
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	suite.Contains(names, archive.FollowingFile)
+	suite.Contains(names, archive.OutboxFile)
+
+	// Another export can now be started.
+	export, errWithCode = suite.accountProcessor.ExportCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("in_progress", export.State)
+
+	job, ok = suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected export job to be queued")
+	}
+	job(ctx)
+}
+
+func (suite *ExportTestSuite) TestSweepExports() {
+	var (
+		ctx         = context.Background()
+		dir         = suite.T().TempDir()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetAccountsExportPath(dir)
+
+	// Generate a completed export.
+	if _, errWithCode := suite.accountProcessor.ExportCreate(ctx, testAccount); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	job, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected export job to be queued")
+	}
+	job(ctx)
+
+	export, err := suite.state.DB.GetAccountExportByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	path := filepath.Join(dir, export.ID+".zip")
+
+	// Leave a file not belonging to any export.
+	orphan := filepath.Join(dir, "01J1Z2X3Y4Z5A6B7C8D9E0F1G2.zip")
+	if err := os.WriteFile(orphan, []byte("orphan"), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Leave an unrelated file and directory.
+	unrelated := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(unrelated, []byte("unrelated"), 0o600); err != nil {
+		suite.FailNow(err.Error())
+	}
+	subdir := filepath.Join(dir, "backups.zip")
+	if err := os.Mkdir(subdir, 0o700); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fresh export is kept, orphan is removed,
+	// anything that isn't an archive is kept.
+	if err := suite.accountProcessor.SweepExports(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.FileExists(path)
+	suite.NoFileExists(orphan)
+	suite.FileExists(unrelated)
+	suite.DirExists(subdir)
+
+	// Move completion back past the retention period.
+	export.CompletedAt = time.Now().Add(-25 * time.Hour)
+	if err := suite.state.DB.UpdateAccountExport(ctx, export, "completed_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Expired export is now removed.
+	if err := suite.accountProcessor.SweepExports(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NoFileExists(path)
+
+	_, err = suite.state.DB.GetAccountExportByAccountID(ctx, testAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ExportTestSuite) TestExportAbandoned() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetAccountsExportPath(suite.T().TempDir())
+
+	// An export left in progress by a process
+	// that since stopped, and hasn't been
+	// updated for a while.
+	stale := time.Now().Add(-time.Hour)
+	if err := suite.state.DB.PutAccountExport(ctx, &gtsmodel.AccountExport{
+		ID:        "01J1Z2X3Y4Z5A6B7C8D9E0F1G3",
+		CreatedAt: stale,
+		UpdatedAt: stale,
+		AccountID: testAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	export, errWithCode := suite.accountProcessor.ExportGet(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("failed", export.State)
+
+	// A new export can be started in its place.
+	export, errWithCode = suite.accountProcessor.ExportCreate(ctx, testAccount)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("in_progress", export.State)
+
+	job, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected export job to be queued")
+	}
+	job(ctx)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// max no. bytes of a profile field
+// link page to read when looking for
+// rel="me" links back to the account.
+const fieldVerifyMaxSize = 1 << 20
+
+// QueueVerifyFields queues verification
+// of the given account's profile fields
+// on the dereference worker pool.
+func (p *Processor) QueueVerifyFields(account *gtsmodel.Account) {
+	accountID := account.ID
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := p.VerifyFields(ctx, accountID); err != nil {
+			log.Errorf(ctx, "error verifying fields of account %s: %v", accountID, err)
+		}
+	})
+}
+
+// VerifyFields fetches the webpage of each of the local account's profile
+// fields with an http(s) URL value, and checks whether it contains a
+// rel="me" link back to the account. Each field's VerifiedAt is set
+// (or unset) according to the result, and stored in the database.
+func (p *Processor) VerifyFields(ctx context.Context, accountID string) error {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	if !account.IsLocal() || len(account.Fields) != len(account.FieldsRaw) {
+		// Only local accounts are verified,
+		// and we need raw values to do so.
+		return nil
+	}
+
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting transport: %w", err)
+	}
+
+	// Verify each field's raw value.
+	verified := make([]bool, len(account.FieldsRaw))
+	rawValues := make([]string, len(account.FieldsRaw))
+	for i, fieldRaw := range account.FieldsRaw {
+		rawValues[i] = fieldRaw.Value
+		verified[i], err = p.verifyField(ctx, tsport, account, fieldRaw.Value)
+		if err != nil {
+			log.Debugf(ctx, "field %q of account %s not verified: %v", fieldRaw.Name, account.ID, err)
+		}
+	}
+
+	// Fetching pages may have taken some time,
+	// so reload the account before updating to
+	// ensure we don't clobber any newer fields.
+	account, err = p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	if len(account.Fields) != len(account.FieldsRaw) {
+		return nil
+	}
+
+	now := time.Now()
+	changed := false
+	for i, field := range account.Fields {
+		if i >= len(rawValues) || account.FieldsRaw[i].Value != rawValues[i] {
+			// Field changed since
+			// we verified it, skip.
+			continue
+		}
+
+		switch {
+		case verified[i] && field.VerifiedAt.IsZero():
+			field.VerifiedAt = now
+			changed = true
+		case !verified[i] && !field.VerifiedAt.IsZero():
+			field.VerifiedAt = time.Time{}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, account, "fields"); err != nil {
+		return gtserror.Newf("db error updating account fields: %w", err)
+	}
+
+	return nil
+}
+
+// verifyField checks whether the given raw profile field
+// value is an http(s) URL to a webpage that contains a
+// rel="me" link back to the given account.
+func (p *Processor) verifyField(
+	ctx context.Context,
+	tsport transport.Transport,
+	account *gtsmodel.Account,
+	value string,
+) (bool, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "https://") &&
+		!strings.HasPrefix(value, "http://") {
+		// Not a link.
+		return false, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		// Not a valid link.
+		return false, nil
+	}
+
+	// Don't fetch pages from blocked domains.
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, u.Hostname())
+	if err != nil {
+		return false, gtserror.Newf("db error checking for domain block: %w", err)
+	}
+
+	if blocked {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Add("Accept", "text/html")
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return false, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return false, gtserror.NewFromResponse(rsp)
+	}
+
+	// Resolve links relative to the final
+	// page URL, in case of redirects.
+	base := u
+	if rsp.Request != nil && rsp.Request.URL != nil {
+		base = rsp.Request.URL
+	}
+
+	links := relMeLinks(io.LimitReader(rsp.Body, fieldVerifyMaxSize), base)
+	for _, link := range links {
+		if link == account.URL || link == account.URI {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// relMeLinks returns the absolute hrefs of all <a> and
+// <link> elements with rel="me" in the given HTML document.
+func relMeLinks(r io.Reader, base *url.URL) []string {
+	var links []string
+
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// Either EOF or
+			// malformed HTML.
+			return links
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if t.DataAtom != atom.A && t.DataAtom != atom.Link {
+				continue
+			}
+
+			var href string
+			var me bool
+			for _, attr := range t.Attr {
+				switch attr.Key {
+				case "href":
+					href = attr.Val
+				case "rel":
+					me = slices.Contains(strings.Fields(strings.ToLower(attr.Val)), "me")
+				}
+			}
+
+			if !me || href == "" {
+				continue
+			}
+
+			link, err := base.Parse(href)
+			if err != nil {
+				continue
+			}
+
+			links = append(links, strings.TrimSuffix(link.String(), "/"))
+		}
+	}
+}
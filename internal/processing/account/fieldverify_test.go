@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FieldVerifyTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// fieldVerifyProcessor returns an account processor
+// whose HTTP client serves the given pages by URL.
+func (suite *FieldVerifyTestSuite) fieldVerifyProcessor(pages map[string]string) *account.Processor {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader("not found")),
+				Request:    req,
+			}, nil
+		}
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": []string{"text/html"}},
+			Body:          io.NopCloser(strings.NewReader(page)),
+			ContentLength: int64(len(page)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	var (
+		transportController = testrig.NewTestTransportController(&suite.state, httpClient)
+		federator           = testrig.NewTestFederator(&suite.state, transportController, suite.mediaManager)
+		filter              = visibility.NewFilter(&suite.state)
+		common              = common.New(&suite.state, suite.mediaManager, suite.tc, federator, filter)
+		processor           = account.New(&common, &suite.state, suite.tc, suite.mediaManager, federator, filter, processing.GetParseMentionFunc(&suite.state, federator))
+	)
+
+	return &processor
+}
+
+func (suite *FieldVerifyTestSuite) TestVerifyFields() {
+	var (
+		ctx            = context.Background()
+		testAccount    = new(gtsmodel.Account)
+		originalFields = suite.testAccounts["local_account_1"].Fields
+	)
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	processor := suite.fieldVerifyProcessor(map[string]string{
+		"https://example.org/me": `<html><head>` +
+			`<link rel="me" href="http://localhost:8080/@the_mighty_zork">` +
+			`</head><body>hello</body></html>`,
+		"https://example.org/not-me": `<html><body>` +
+			`<a href="http://localhost:8080/@the_mighty_zork">zork</a>` +
+			`<a rel="me" href="https://someone.else.example.org/@fnord">fnord</a>` +
+			`</body></html>`,
+		"https://example.org/zork": `<html><body>` +
+			`<a rel="me" href="http://localhost:8080/users/the_mighty_zork/">me</a>` +
+			`</body></html>`,
+	})
+
+	// Give the account one field per page,
+	// plus one field that's not a link at all.
+	testAccount.FieldsRaw = []*gtsmodel.Field{
+		{Name: "me", Value: "https://example.org/me"},
+		{Name: "not me", Value: "https://example.org/not-me"},
+		{Name: "plain", Value: "example.org/me"},
+		{Name: "missing", Value: "https://example.org/missing"},
+		{Name: "via uri", Value: "https://example.org/zork"},
+	}
+	testAccount.Fields = make([]*gtsmodel.Field, len(testAccount.FieldsRaw))
+	for i, fieldRaw := range testAccount.FieldsRaw {
+		field := *fieldRaw
+		testAccount.Fields[i] = &field
+	}
+
+	if err := suite.state.DB.UpdateAccount(ctx, testAccount, "fields", "fields_raw"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := processor.VerifyFields(ctx, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAccount, err := suite.state.DB.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	verified := make(map[string]bool, len(dbAccount.Fields))
+	for _, field := range dbAccount.Fields {
+		verified[field.Name] = !field.VerifiedAt.IsZero()
+	}
+	suite.Equal(map[string]bool{
+		"me":      true,
+		"not me":  false,
+		"plain":   false,
+		"missing": false,
+		"via uri": true,
+	}, verified)
+
+	// Make sure original test model wasn't changed.
+	suite.Equal(originalFields, suite.testAccounts["local_account_1"].Fields)
+}
+
+func TestFieldVerifyTestSuite(t *testing.T) {
+	suite.Run(t, new(FieldVerifyTestSuite))
+}
@@ -20,6 +20,7 @@ package account
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -31,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // FollowCreate handles a follow request to an account, either remote or local.
@@ -40,6 +42,12 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, errWithCode
 	}
 
+	// Validate + normalize any given languages.
+	languages, errWithCode := followLanguages(form.Languages)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Check if a follow exists already.
 	if follow, err := p.state.DB.GetFollow(
 		gtscontext.SetBarebones(ctx),
@@ -54,8 +62,10 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 			ctx,
 			requestingAccount,
 			form,
+			languages,
 			follow.ShowReblogs,
 			follow.Notify,
+			&follow.Languages,
 			func(columns ...string) error { return p.state.DB.UpdateFollow(ctx, follow, columns...) },
 		)
 	}
@@ -74,8 +84,10 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 			ctx,
 			requestingAccount,
 			form,
+			languages,
 			followRequest.ShowReblogs,
 			followRequest.Notify,
+			&followRequest.Languages,
 			func(columns ...string) error { return p.state.DB.UpdateFollowRequest(ctx, followRequest, columns...) },
 		)
 	}
@@ -97,6 +109,7 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		TargetAccount:   targetAccount,
 		ShowReblogs:     form.Reblogs,
 		Notify:          form.Notify,
+		Languages:       languages,
 	}
 
 	// Insert the new follow request.
@@ -113,12 +126,15 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 
 	// For unlocked accounts on the same instance,
 	// we can already optimistically show the follow
-	// request as accepted in the returned relationship.
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
+	// request as accepted in the returned relationship,
+	// unless requester is silenced and so needs approval.
+	if targetAccount.IsLocal() && !*targetAccount.Locked &&
+		!requestingAccount.IsSilenced() {
 		rel.Requested = false
 		rel.Following = true
 		rel.ShowingReblogs = util.PtrValueOr(fr.ShowReblogs, true)
 		rel.Notifying = util.PtrValueOr(fr.Notify, false)
+		rel.Languages = fr.Languages
 	}
 
 	// Handle side effects async.
@@ -165,17 +181,19 @@ func (p *Processor) updateFollow(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
 	form *apimodel.AccountFollowRequest,
+	newLanguages []string,
 	currentShowReblogs *bool,
 	currentNotify *bool,
+	currentLanguages *[]string,
 	update func(...string) error,
 ) (*apimodel.Relationship, gtserror.WithCode) {
-	if form.Reblogs == nil && form.Notify == nil {
+	if form.Reblogs == nil && form.Notify == nil && form.Languages == nil {
 		// There's nothing to update.
 		return p.RelationshipGet(ctx, requestingAccount, form.ID)
 	}
 
-	// Including "updated_at", max 3 columns may change.
-	columns := make([]string, 0, 3)
+	// Including "updated_at", max 4 columns may change.
+	columns := make([]string, 0, 4)
 
 	// Check what we need to update (if anything).
 	if newReblogs := form.Reblogs; newReblogs != nil && *newReblogs != *currentShowReblogs {
@@ -188,6 +206,11 @@ func (p *Processor) updateFollow(
 		columns = append(columns, "notify")
 	}
 
+	if form.Languages != nil && !slices.Equal(newLanguages, *currentLanguages) {
+		*currentLanguages = newLanguages
+		columns = append(columns, "languages")
+	}
+
 	if len(columns) == 0 {
 		// Nothing actually changed.
 		return p.RelationshipGet(ctx, requestingAccount, form.ID)
@@ -306,3 +329,28 @@ func (p *Processor) unfollow(ctx context.Context, requestingAccount *gtsmodel.Ac
 
 	return msgs, nil
 }
+
+// followLanguages validates and normalizes the given
+// follow languages, dropping empty values, so that an
+// empty value can be given to unset follow languages.
+func followLanguages(languages []string) ([]string, gtserror.WithCode) {
+	var normalized []string
+
+	for _, lang := range languages {
+		if lang == "" {
+			continue
+		}
+
+		lang, err := validate.Language(lang)
+		if err != nil {
+			text := "invalid follow language: " + err.Error()
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if !slices.Contains(normalized, lang) {
+			normalized = append(normalized, lang)
+		}
+	}
+
+	return normalized, nil
+}
@@ -132,6 +132,42 @@ func (suite *FollowTestSuite) TestUpdateExistingFollowSetNothing() {
 	suite.False(relationship.Notifying)
 }
 
+func (suite *FollowTestSuite) TestUpdateExistingFollowLanguages() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	// Set Languages, with some duplicates
+	// and values needing normalization.
+	relationship, err := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+		ID:        targetAccount.ID,
+		Languages: []string{"en", "DE", "en"},
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal([]string{"en", "de"}, relationship.Languages)
+
+	// Unset Languages with an empty value.
+	relationship, err = suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+		ID:        targetAccount.ID,
+		Languages: []string{""},
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(relationship.Languages)
+
+	// Invalid language should be rejected.
+	_, err = suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+		ID:        targetAccount.ID,
+		Languages: []string{"not a language"},
+	})
+	suite.Error(err)
+}
+
 func (suite *FollowTestSuite) TestFollowRequestLocal() {
 	ctx := context.Background()
 	requestingAccount := suite.testAccounts["admin_account"]
@@ -0,0 +1,342 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Possible types and modes of a relationships import.
+const (
+	importTypeFollowing = "following"
+	importTypeBlocks    = "blocks"
+	importTypeMutes     = "mutes"
+	importModeMerge     = "merge"
+	importModeOverwrite = "overwrite"
+)
+
+// importRecord is one account to follow,
+// block, or mute, parsed from a CSV row.
+type importRecord struct {
+	namestring string
+
+	// Following only.
+	showReblogs *bool
+	notify      *bool
+	languages   []string
+
+	// Mutes only.
+	notifications *bool
+}
+
+// Import handles the import of follows, blocks, or mutes for
+// requester from the provided Mastodon-format CSV file.
+//
+// The file is parsed straight away, but the accounts within are
+// resolved (dereferencing them if necessary) and acted on in the
+// background, as this may take a long time for large imports.
+// Side effects of each follow, block, or mute, such as federating
+// it, are handled by the client API worker as usual.
+//
+// In "overwrite" mode, any existing follows, blocks, or mutes of
+// accounts not contained in the file are removed after the import.
+// If any account in the file could not be resolved, nothing is
+// removed, as it can't be known which existing account it was.
+func (p *Processor) Import(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	dataF *multipart.FileHeader,
+	importType string,
+	importMode string,
+) gtserror.WithCode {
+	switch importType {
+	case importTypeFollowing, importTypeBlocks, importTypeMutes:
+	default:
+		const text = "import type must be one of following, blocks, or mutes"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	switch importMode {
+	case "":
+		importMode = importModeMerge
+	case importModeMerge, importModeOverwrite:
+	default:
+		const text = "import mode must be one of merge or overwrite"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Open the provided file.
+	file, err := dataF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	records, err := parseImportCSV(file, importType)
+	if err != nil {
+		err = gtserror.Newf("error parsing attachment as %s csv: %w", importType, err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(records) == 0 {
+		err = gtserror.Newf("error importing %s: 0 entries provided", importType)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	requesterID := requester.ID
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := p.importRecords(ctx,
+			requesterID,
+			importType,
+			importMode == importModeOverwrite,
+			records,
+		); err != nil {
+			log.Errorf(ctx, "error importing %s for account %s: %v", importType, requesterID, err)
+		}
+	})
+
+	return nil
+}
+
+// importRecords follows, blocks, or mutes the account of each
+// record on behalf of the account with requesterID. Records that
+// can't be imported are logged and skipped. If overwrite is set,
+// existing follows, blocks, or mutes of other accounts are removed,
+// so long as every record could be resolved to an account.
+func (p *Processor) importRecords(
+	ctx context.Context,
+	requesterID string,
+	importType string,
+	overwrite bool,
+	records []importRecord,
+) error {
+	requester, err := p.state.DB.GetAccountByID(ctx, requesterID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	var (
+		// IDs of target accounts in
+		// the file, imported or not.
+		keep = make(map[string]struct{}, len(records))
+
+		// No. records that couldn't
+		// be resolved to an account.
+		unresolved int
+	)
+
+	for _, record := range records {
+		target, err := p.importTarget(ctx, requester, record.namestring)
+		if err != nil {
+			log.Warnf(ctx, "error resolving %s to import: %v", record.namestring, err)
+			unresolved++
+			continue
+		}
+
+		// Never remove an account listed in the
+		// file, even if (re)importing it failed.
+		keep[target.ID] = struct{}{}
+
+		var errWithCode gtserror.WithCode
+		switch importType {
+		case importTypeFollowing:
+			_, errWithCode = p.FollowCreate(ctx, requester, &apimodel.AccountFollowRequest{
+				ID:        target.ID,
+				Reblogs:   record.showReblogs,
+				Notify:    record.notify,
+				Languages: record.languages,
+			})
+		case importTypeBlocks:
+			_, errWithCode = p.BlockCreate(ctx, requester, target.ID)
+		case importTypeMutes:
+			_, errWithCode = p.MuteCreate(ctx, requester, target.ID, &apimodel.UserMuteCreateUpdateRequest{
+				Notifications: util.Ptr(util.PtrValueOr(record.notifications, false)),
+			})
+		}
+
+		if errWithCode != nil {
+			log.Warnf(ctx, "error importing %s: %v", record.namestring, errWithCode)
+		}
+	}
+
+	if !overwrite {
+		return nil
+	}
+
+	if unresolved > 0 {
+		// Any of the existing targets could be one of those that
+		// failed to resolve (e.g. a temporary network error), so
+		// removing any would risk losing relationships in the file.
+		log.Warnf(ctx, "not removing %s of account %s missing from file: %d entries could not be resolved", importType, requester.ID, unresolved)
+		return nil
+	}
+
+	// Gather current targets of this type of relationship.
+	var (
+		targetIDs []string
+		remove    func(context.Context, *gtsmodel.Account, string) (*apimodel.Relationship, gtserror.WithCode)
+	)
+
+	switch importType {
+	case importTypeFollowing:
+		follows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting follows: %w", err)
+		}
+		for _, follow := range follows {
+			targetIDs = append(targetIDs, follow.TargetAccountID)
+		}
+		remove = p.FollowRemove
+	case importTypeBlocks:
+		blocks, err := p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting blocks: %w", err)
+		}
+		for _, block := range blocks {
+			targetIDs = append(targetIDs, block.TargetAccountID)
+		}
+		remove = p.BlockRemove
+	case importTypeMutes:
+		mutes, err := p.state.DB.GetAccountMutes(ctx, requester.ID, nil)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting mutes: %w", err)
+		}
+		for _, mute := range mutes {
+			targetIDs = append(targetIDs, mute.TargetAccountID)
+		}
+		remove = p.MuteRemove
+	}
+
+	// Remove those that weren't in the file.
+	for _, targetID := range targetIDs {
+		if _, ok := keep[targetID]; ok {
+			continue
+		}
+
+		if _, errWithCode := remove(ctx, requester, targetID); errWithCode != nil {
+			log.Warnf(ctx, "error removing %s of %s: %v", importType, targetID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// importTarget resolves the given "user@domain" account
+// address to an account, dereferencing it if necessary.
+func (p *Processor) importTarget(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	namestring string,
+) (*gtsmodel.Account, error) {
+	// Allow addresses with or without a leading "@".
+	namestring = "@" + strings.TrimPrefix(namestring, "@")
+
+	username, domain, err := util.ExtractNamestringParts(namestring)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain == "" {
+		return nil, gtserror.Newf("no domain in account address %s", namestring)
+	}
+
+	account, _, err := p.federator.GetAccountByUsernameDomain(ctx,
+		requester.Username,
+		username,
+		domain,
+	)
+	return account, err
+}
+
+// parseImportCSV parses import records from the given csv
+// of the given import type. Files with or without a header
+// row, and with or without the optional columns, as written
+// by Mastodon over the years, are all accepted.
+func parseImportCSV(r io.Reader, importType string) ([]importRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > 0 && len(rows[0]) > 0 &&
+		rows[0][0] == "Account address" {
+		// Skip header row.
+		rows = rows[1:]
+	}
+
+	records := make([]importRecord, 0, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+
+		record := importRecord{namestring: strings.TrimSpace(row[0])}
+
+		switch importType {
+		case importTypeFollowing:
+			record.showReblogs = csvBool(row, 1)
+			record.notify = csvBool(row, 2)
+			if len(row) > 3 {
+				for _, lang := range strings.Split(row[3], ",") {
+					if lang = strings.TrimSpace(lang); lang != "" {
+						record.languages = append(record.languages, lang)
+					}
+				}
+			}
+		case importTypeMutes:
+			record.notifications = csvBool(row, 1)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// csvBool returns the boolean in column i of
+// the given csv row, or nil if it's not set.
+func csvBool(row []string, i int) *bool {
+	if i >= len(row) {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(strings.TrimSpace(row[i]))
+	if err != nil {
+		return nil
+	}
+
+	return &b
+}
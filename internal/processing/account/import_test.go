@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// fileHeader returns a multipart file
+// header for a file with the given data.
+func (suite *ImportTestSuite) fileHeader(data string) *multipart.FileHeader {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	fw, err := w.CreateFormFile("data", "import.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(&b, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["data"][0]
+}
+
+// runImport runs the queued import job.
+func (suite *ImportTestSuite) runImport(ctx context.Context) {
+	job, ok := suite.state.Workers.Dereference.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected import job to be queued")
+	}
+	job(ctx)
+}
+
+func (suite *ImportTestSuite) TestImportFollowingOverwrite() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_1"]
+		adminAccount  = suite.testAccounts["admin_account"]
+		turtleAccount = suite.testAccounts["local_account_2"]
+		followingCSV  = "Account address,Show boosts,Notify on new posts,Languages\n" +
+			"@admin@localhost:8080,false,true,\"en, fr\"\n"
+	)
+
+	errWithCode := suite.accountProcessor.Import(ctx, requester, suite.fileHeader(followingCSV), "following", "overwrite")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.runImport(ctx)
+
+	// Follow of admin should be updated.
+	follow, err := suite.state.DB.GetFollow(ctx, requester.ID, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*follow.ShowReblogs)
+	suite.True(*follow.Notify)
+	suite.Equal([]string{"en", "fr"}, follow.Languages)
+
+	// Follow of turtle wasn't in
+	// the file so should be gone.
+	_, err = suite.state.DB.GetFollow(ctx, requester.ID, turtleAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ImportTestSuite) TestImportFollowingOverwriteUnresolved() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_1"]
+		adminAccount  = suite.testAccounts["admin_account"]
+		turtleAccount = suite.testAccounts["local_account_2"]
+		followingCSV  = "Account address,Show boosts,Notify on new posts,Languages\n" +
+			"@admin@localhost:8080,false,true,\n" +
+			"someone@not.a.real.domain.example.org,true,false,\n"
+	)
+
+	errWithCode := suite.accountProcessor.Import(ctx, requester, suite.fileHeader(followingCSV), "following", "overwrite")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.runImport(ctx)
+
+	// Follow of admin should still be updated.
+	follow, err := suite.state.DB.GetFollow(ctx, requester.ID, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*follow.ShowReblogs)
+
+	// One entry couldn't be resolved, and may have
+	// been turtle for all we know, so turtle's follow
+	// mustn't have been removed by the overwrite.
+	follows, err := suite.state.DB.IsFollowing(ctx, requester.ID, turtleAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(follows)
+}
+
+func (suite *ImportTestSuite) TestImportBlocksMerge() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_1"]
+		turtleAccount = suite.testAccounts["local_account_2"]
+		adminAccount  = suite.testAccounts["admin_account"]
+	)
+
+	// Blocks files have no header.
+	errWithCode := suite.accountProcessor.Import(ctx, requester, suite.fileHeader("1happyturtle@localhost:8080\n"), "blocks", "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.runImport(ctx)
+
+	blocked, err := suite.state.DB.IsBlocked(ctx, requester.ID, turtleAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
+
+	// Merging leaves other relationships alone.
+	follows, err := suite.state.DB.IsFollowing(ctx, requester.ID, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(follows)
+}
+
+func (suite *ImportTestSuite) TestImportMutes() {
+	var (
+		ctx          = context.Background()
+		requester    = suite.testAccounts["local_account_1"]
+		adminAccount = suite.testAccounts["admin_account"]
+	)
+
+	errWithCode := suite.accountProcessor.Import(ctx, requester, suite.fileHeader("Account address,Hide notifications\nadmin@localhost:8080,true\n"), "mutes", "merge")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.runImport(ctx)
+
+	mute, err := suite.state.DB.GetMute(ctx, requester.ID, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*mute.Notifications)
+}
+
+func (suite *ImportTestSuite) TestImportInvalid() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	for _, test := range []struct {
+		data       string
+		importType string
+		importMode string
+		expect     string
+	}{
+		{"admin@localhost:8080\n", "bookmarks", "", "Bad Request: import type must be one of following, blocks, or mutes"},
+		{"admin@localhost:8080\n", "blocks", "replace", "Bad Request: import mode must be one of merge or overwrite"},
+		{"Account address,Hide notifications\n", "mutes", "", "error importing mutes: 0 entries provided"},
+	} {
+		errWithCode := suite.accountProcessor.Import(ctx, requester, suite.fileHeader(test.data), test.importType, test.importMode)
+		if suite.NotNil(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+			suite.Contains(errWithCode.Safe(), test.expect)
+		}
+	}
+
+	// Nothing should have been queued.
+	suite.Zero(suite.state.Workers.Dereference.Queue.Len())
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// MigrationGet returns the current alsoKnownAs
+// aliases and Move target of the given account.
+func (p *Processor) MigrationGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.AccountMigration, gtserror.WithCode) {
+	return p.apiMigration(account), nil
+}
+
+// MigrationCheck checks whether the given account would be able
+// to Move to the account at form.MovedToURI, ie., that the target
+// exists, and is aliased back to the account via alsoKnownAs. This
+// lets users prepare a Move before actually triggering it.
+//
+// Nothing is changed by this function.
+func (p *Processor) MigrationCheck(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.AccountMigrationRequest,
+) (*apimodel.AccountMigration, gtserror.WithCode) {
+	targetAcctURI, errWithCode := parseMovedToURI(form.MovedToURI)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	targetAcct, errWithCode := p.getMoveTarget(ctx, account, targetAcctURI)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiTarget, errWithCode := p.c.GetAPIAccount(ctx, account, targetAcct)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiMigration := p.apiMigration(account)
+	apiMigration.Target = apiTarget
+	return apiMigration, nil
+}
+
+func (p *Processor) apiMigration(account *gtsmodel.Account) *apimodel.AccountMigration {
+	akaURIs := account.AlsoKnownAsURIs
+	if akaURIs == nil {
+		// Serialize as empty array.
+		akaURIs = []string{}
+	}
+
+	return &apimodel.AccountMigration{
+		AlsoKnownAsURIs: akaURIs,
+		MovedToURI:      account.MovedToURI,
+	}
+}
@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type MigrationTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *MigrationTestSuite) TestMigrationCheckOK() {
+	ctx := context.Background()
+
+	// Copy zork.
+	requestingAcct := new(gtsmodel.Account)
+	*requestingAcct = *suite.testAccounts["local_account_1"]
+
+	// Copy admin.
+	targetAcct := new(gtsmodel.Account)
+	*targetAcct = *suite.testAccounts["admin_account"]
+
+	// Update admin to alias back to zork.
+	targetAcct.AlsoKnownAsURIs = []string{requestingAcct.URI}
+	if err := suite.state.DB.UpdateAccount(
+		ctx,
+		targetAcct,
+		"also_known_as_uris",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	migration, errWithCode := suite.accountProcessor.MigrationCheck(
+		ctx,
+		requestingAcct,
+		&apimodel.AccountMigrationRequest{
+			MovedToURI: targetAcct.URI,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Empty(migration.AlsoKnownAsURIs)
+	suite.Empty(migration.MovedToURI)
+	if suite.NotNil(migration.Target) {
+		suite.Equal(targetAcct.ID, migration.Target.ID)
+	}
+
+	// Nothing should have changed for zork.
+	dbAcct, err := suite.state.DB.GetAccountByID(ctx, requestingAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAcct.MovedToURI)
+	suite.Empty(dbAcct.MoveID)
+}
+
+func (suite *MigrationTestSuite) TestMigrationCheckNotAliased() {
+	ctx := context.Background()
+
+	// Check move from zork to admin.
+	//
+	// Check should fail since admin
+	// is not aliased back to zork.
+	_, errWithCode := suite.accountProcessor.MigrationCheck(
+		ctx,
+		suite.testAccounts["local_account_1"],
+		&apimodel.AccountMigrationRequest{
+			MovedToURI: suite.testAccounts["admin_account"].URI,
+		},
+	)
+	if errWithCode == nil {
+		suite.FailNow("expected error")
+	}
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal(
+		"Unprocessable Entity: target account http://localhost:8080/users/admin is not aliased to this account via alsoKnownAs; "+
+			"if you just changed it, please wait a few minutes and try the Move again",
+		errWithCode.Safe(),
+	)
+}
+
+func (suite *MigrationTestSuite) TestMigrationGet() {
+	ctx := context.Background()
+
+	// Copy zork, and alias to turtle.
+	requestingAcct := new(gtsmodel.Account)
+	*requestingAcct = *suite.testAccounts["local_account_1"]
+	requestingAcct.AlsoKnownAsURIs = []string{suite.testAccounts["local_account_2"].URI}
+
+	migration, errWithCode := suite.accountProcessor.MigrationGet(ctx, requestingAcct)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([]string{suite.testAccounts["local_account_2"].URI}, migration.AlsoKnownAsURIs)
+	suite.Empty(migration.MovedToURI)
+	suite.Nil(migration.Target)
+}
+
+func TestMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationTestSuite))
+}
@@ -45,21 +45,9 @@ func (p *Processor) MoveSelf(
 	form *apimodel.AccountMoveRequest,
 ) gtserror.WithCode {
 	// Ensure valid MovedToURI.
-	if form.MovedToURI == "" {
-		const text = "no moved_to_uri provided in Move request"
-		return gtserror.NewErrorBadRequest(errors.New(text), text)
-	}
-
-	targetAcctURIStr := form.MovedToURI
-	targetAcctURI, err := url.Parse(form.MovedToURI)
-	if err != nil {
-		err := fmt.Errorf("invalid moved_to_uri provided in account Move request: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
-	}
-
-	if targetAcctURI.Scheme != "https" && targetAcctURI.Scheme != "http" {
-		const text = "invalid move_to_uri in Move request: scheme must be http(s)"
-		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	targetAcctURI, errWithCode := parseMovedToURI(form.MovedToURI)
+	if errWithCode != nil {
+		return errWithCode
 	}
 
 	// Self account Move requires
@@ -77,38 +65,9 @@ func (p *Processor) MoveSelf(
 		return gtserror.NewErrorBadRequest(errors.New(text), text)
 	}
 
-	// We can't/won't validate Move activities
-	// to domains we have blocked, so check this.
-	targetDomainBlocked, err := p.state.DB.IsDomainBlocked(ctx, targetAcctURI.Host)
-	if err != nil {
-		err := gtserror.Newf(
-			"db error checking if target domain %s blocked: %w",
-			targetAcctURI.Host, err,
-		)
-		return gtserror.NewErrorInternalError(err)
-	}
-
-	if targetDomainBlocked {
-		text := fmt.Sprintf(
-			"domain of %s is blocked from this instance; "+
-				"you will not be able to Move to that account",
-			targetAcctURIStr,
-		)
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
-	}
-
-	var (
-		// Current account from which
-		// the move is taking place.
-		originAcct = authed.Account
-
-		// Target account to which
-		// the move is taking place.
-		targetAcct *gtsmodel.Account
-
-		// AP representation of target.
-		targetAcctable ap.Accountable
-	)
+	// Current account from which
+	// the move is taking place.
+	originAcct := authed.Account
 
 	// Next steps involve checking + setting
 	// state that might get messed up if a
@@ -119,82 +78,10 @@ func (p *Processor) MoveSelf(
 	unlock := p.state.ProcessingLocks.Lock(lockKey)
 	defer unlock()
 
-	// Ensure we have a valid, up-to-date representation of the target account.
-	targetAcct, targetAcctable, err = p.federator.GetAccountByURI(
-		ctx,
-		originAcct.Username,
-		targetAcctURI,
-	)
-	if err != nil {
-		const text = "error dereferencing moved_to_uri"
-		err := gtserror.Newf("error dereferencing move_to_uri: %w", err)
-		return gtserror.NewErrorUnprocessableEntity(err, text)
-	}
-
-	if !targetAcct.SuspendedAt.IsZero() {
-		text := fmt.Sprintf(
-			"target account %s is suspended from this instance; "+
-				"you will not be able to Move to that account",
-			targetAcct.URI,
-		)
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
-	}
-
-	if targetAcctable == nil {
-		// Target account was not dereferenced, now
-		// force refresh Move target account to ensure we
-		// have most up-to-date version (non remote = no-op).
-		targetAcct, _, err = p.federator.RefreshAccount(ctx,
-			originAcct.Username,
-			targetAcct,
-			targetAcctable,
-			dereferencing.Freshest,
-		)
-		if err != nil {
-			const text = "error dereferencing moved_to_uri"
-			err := gtserror.Newf("error dereferencing move_to_uri: %w", err)
-			return gtserror.NewErrorUnprocessableEntity(err, text)
-		}
-	}
-
-	// If originAcct has already moved, ensure
-	// this move reattempt is to the same account.
-	if originAcct.IsMoving() &&
-		originAcct.MovedToURI != targetAcct.URI {
-		text := fmt.Sprintf(
-			"your account is already Moving or has Moved to %s; you cannot also Move to %s",
-			originAcct.MovedToURI, targetAcct.URI,
-		)
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
-	}
-
-	// Target account MUST be aliased to this
-	// account for this to be a valid Move.
-	if !slices.Contains(targetAcct.AlsoKnownAsURIs, originAcct.URI) {
-		text := fmt.Sprintf(
-			"target account %s is not aliased to this account via alsoKnownAs; "+
-				"if you just changed it, please wait a few minutes and try the Move again",
-			targetAcct.URI,
-		)
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
-	}
-
-	// Target account cannot itself have
-	// already Moved somewhere else.
-	if targetAcct.MovedToURI != "" {
-		text := fmt.Sprintf(
-			"target account %s has already Moved somewhere else (%s); "+
-				"you will not be able to Move to that account",
-			targetAcct.URI, targetAcct.MovedToURI,
-		)
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
-	}
-
-	// Check this isn't a recursive loop of moves.
-	if errWithCode := p.checkMoveRecursion(ctx,
-		originAcct,
-		targetAcct,
-	); errWithCode != nil {
+	// Ensure target account is valid
+	// and aliased back to this account.
+	targetAcct, errWithCode := p.getMoveTarget(ctx, originAcct, targetAcctURI)
+	if errWithCode != nil {
 		return errWithCode
 	}
 
@@ -281,9 +168,9 @@ func (p *Processor) MoveSelf(
 		// We might have selected the target
 		// using the URL and not the URI.
 		// Ensure we continue with the URI!
-		if targetAcctURIStr != targetAcct.URI {
-			targetAcctURIStr = targetAcct.URI
-			targetAcctURI, err = url.Parse(targetAcctURIStr)
+		if targetAcctURI.String() != targetAcct.URI {
+			var err error
+			targetAcctURI, err = url.Parse(targetAcct.URI)
 			if err != nil {
 				return gtserror.NewErrorInternalError(err)
 			}
@@ -301,7 +188,7 @@ func (p *Processor) MoveSelf(
 			AttemptedAt: time.Now(),
 			OriginURI:   originAcct.URI,
 			Origin:      originAcctURI,
-			TargetURI:   targetAcctURIStr,
+			TargetURI:   targetAcct.URI,
 			Target:      targetAcctURI,
 			URI:         moveURIStr,
 		}
@@ -339,6 +226,138 @@ func (p *Processor) MoveSelf(
 	return nil
 }
 
+// parseMovedToURI parses and validates the given moved_to_uri.
+func parseMovedToURI(movedToURI string) (*url.URL, gtserror.WithCode) {
+	if movedToURI == "" {
+		const text = "no moved_to_uri provided in Move request"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	targetAcctURI, err := url.Parse(movedToURI)
+	if err != nil {
+		err := fmt.Errorf("invalid moved_to_uri provided in account Move request: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if targetAcctURI.Scheme != "https" && targetAcctURI.Scheme != "http" {
+		const text = "invalid move_to_uri in Move request: scheme must be http(s)"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return targetAcctURI, nil
+}
+
+// getMoveTarget dereferences the account at targetAcctURI, and checks
+// that originAcct would be able to Move to it, ie., that the target is
+// not blocked or suspended, is aliased back to originAcct via alsoKnownAs,
+// hasn't Moved elsewhere itself, and wouldn't cause a loop of Moves.
+func (p *Processor) getMoveTarget(
+	ctx context.Context,
+	originAcct *gtsmodel.Account,
+	targetAcctURI *url.URL,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	// We can't/won't validate Move activities
+	// to domains we have blocked, so check this.
+	targetDomainBlocked, err := p.state.DB.IsDomainBlocked(ctx, targetAcctURI.Host)
+	if err != nil {
+		err := gtserror.Newf(
+			"db error checking if target domain %s blocked: %w",
+			targetAcctURI.Host, err,
+		)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if targetDomainBlocked {
+		text := fmt.Sprintf(
+			"domain of %s is blocked from this instance; "+
+				"you will not be able to Move to that account",
+			targetAcctURI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Ensure we have a valid, up-to-date representation of the target account.
+	targetAcct, targetAcctable, err := p.federator.GetAccountByURI(
+		ctx,
+		originAcct.Username,
+		targetAcctURI,
+	)
+	if err != nil {
+		const text = "error dereferencing moved_to_uri"
+		err := gtserror.Newf("error dereferencing move_to_uri: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	if !targetAcct.SuspendedAt.IsZero() {
+		text := fmt.Sprintf(
+			"target account %s is suspended from this instance; "+
+				"you will not be able to Move to that account",
+			targetAcct.URI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if targetAcctable == nil {
+		// Target account was not dereferenced, now
+		// force refresh Move target account to ensure we
+		// have most up-to-date version (non remote = no-op).
+		targetAcct, _, err = p.federator.RefreshAccount(ctx,
+			originAcct.Username,
+			targetAcct,
+			targetAcctable,
+			dereferencing.Freshest,
+		)
+		if err != nil {
+			const text = "error dereferencing moved_to_uri"
+			err := gtserror.Newf("error dereferencing move_to_uri: %w", err)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+		}
+	}
+
+	// If originAcct has already moved, ensure
+	// this move reattempt is to the same account.
+	if originAcct.IsMoving() &&
+		originAcct.MovedToURI != targetAcct.URI {
+		text := fmt.Sprintf(
+			"your account is already Moving or has Moved to %s; you cannot also Move to %s",
+			originAcct.MovedToURI, targetAcct.URI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Target account MUST be aliased to this
+	// account for this to be a valid Move.
+	if !slices.Contains(targetAcct.AlsoKnownAsURIs, originAcct.URI) {
+		text := fmt.Sprintf(
+			"target account %s is not aliased to this account via alsoKnownAs; "+
+				"if you just changed it, please wait a few minutes and try the Move again",
+			targetAcct.URI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Target account cannot itself have
+	// already Moved somewhere else.
+	if targetAcct.MovedToURI != "" {
+		text := fmt.Sprintf(
+			"target account %s has already Moved somewhere else (%s); "+
+				"you will not be able to Move to that account",
+			targetAcct.URI, targetAcct.MovedToURI,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Check this isn't a recursive loop of moves.
+	if errWithCode := p.checkMoveRecursion(ctx,
+		originAcct,
+		targetAcct,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return targetAcct, nil
+}
+
 // checkMoveRecursion checks that a move from origin to target would
 // not cause a loop of account moved_from_uris pointing in a loop.
 func (p *Processor) checkMoveRecursion(
@@ -24,6 +24,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -74,6 +75,15 @@ func (p *Processor) MuteCreate(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Muted statuses are filtered out as timelines
+	// are prepared, so drop any already prepared.
+	if err := p.c.InvalidateTimelines(ctx, requestingAccount.ID); err != nil {
+		log.Errorf(ctx, "error invalidating timelines: %v", err)
+	}
+
+	// (Re)schedule same on expiry.
+	p.scheduleMuteExpiry(ctx, mute)
+
 	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
 }
 
@@ -99,6 +109,15 @@ func (p *Processor) MuteRemove(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Previously muted statuses were filtered out of
+	// timelines entirely, so they must be re-indexed.
+	if err := p.c.InvalidateTimelines(ctx, requestingAccount.ID); err != nil {
+		log.Errorf(ctx, "error invalidating timelines: %v", err)
+	}
+
+	// Nothing left to expire.
+	p.state.Workers.Scheduler.Cancel(muteExpiryID(existingMute.ID))
+
 	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
 }
 
@@ -163,6 +182,57 @@ func (p *Processor) MutesGet(
 	}), nil
 }
 
+// ScheduleMuteExpiries schedules timeline invalidation on
+// expiry of all existing mutes that are yet to expire, as
+// scheduled tasks do not survive a restart.
+func (p *Processor) ScheduleMuteExpiries(ctx context.Context) error {
+	mutes, err := p.state.DB.GetExpiringMutes(
+		gtscontext.SetBarebones(ctx),
+		time.Now(),
+	)
+	if err != nil {
+		return gtserror.Newf("error getting expiring mutes from db: %w", err)
+	}
+
+	for _, mute := range mutes {
+		p.scheduleMuteExpiry(ctx, mute)
+	}
+
+	return nil
+}
+
+// scheduleMuteExpiry schedules invalidation of the muting account's
+// timelines when the given mute expires, so that muted statuses show
+// up again straight away. Any previously scheduled expiry is replaced.
+func (p *Processor) scheduleMuteExpiry(ctx context.Context, mute *gtsmodel.UserMute) {
+	taskID := muteExpiryID(mute.ID)
+
+	// Drop any existing expiry
+	// of this mute, as it may
+	// have been updated.
+	p.state.Workers.Scheduler.Cancel(taskID)
+
+	if mute.ExpiresAt.IsZero() {
+		// Never expires.
+		return
+	}
+
+	accountID := mute.AccountID
+	if !p.state.Workers.Scheduler.AddOnce(taskID, mute.ExpiresAt, func(ctx context.Context, _ time.Time) {
+		if err := p.c.InvalidateTimelines(ctx, accountID); err != nil {
+			log.Errorf(ctx, "error invalidating timelines on expiry of mute %s: %v", mute.ID, err)
+		}
+	}) {
+		log.Warnf(ctx, "failed scheduling expiry of mute %s", mute.ID)
+	}
+}
+
+// muteExpiryID returns the scheduler
+// task ID for expiry of given mute.
+func muteExpiryID(muteID string) string {
+	return "@muteexpiry:" + muteID
+}
+
 func (p *Processor) getMuteTarget(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
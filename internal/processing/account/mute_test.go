@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MuteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// homeAccountIDs returns the IDs of accounts
+// with statuses in the given account's home timeline.
+func (suite *MuteTestSuite) homeAccountIDs(accountID string) map[string]bool {
+	items, err := suite.state.Timelines.Home.GetTimeline(context.Background(), accountID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids := make(map[string]bool)
+	for _, item := range items {
+		ids[item.(*apimodel.Status).Account.ID] = true
+	}
+	return ids
+}
+
+func (suite *MuteTestSuite) TestMuteUnmuteTimeline() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["local_account_1"]
+		targetAccount     = suite.testAccounts["admin_account"]
+	)
+
+	// Target should be in timeline to begin with.
+	suite.True(suite.homeAccountIDs(requestingAccount.ID)[targetAccount.ID])
+
+	// Mute target, they should disappear straight away.
+	if _, errWithCode := suite.accountProcessor.MuteCreate(ctx, requestingAccount, targetAccount.ID, &apimodel.UserMuteCreateUpdateRequest{
+		Notifications: util.Ptr(false),
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(suite.homeAccountIDs(requestingAccount.ID)[targetAccount.ID])
+
+	// Unmute target, they should reappear straight away.
+	if _, errWithCode := suite.accountProcessor.MuteRemove(ctx, requestingAccount, targetAccount.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(suite.homeAccountIDs(requestingAccount.ID)[targetAccount.ID])
+}
+
+func (suite *MuteTestSuite) TestMuteExpiryTimeline() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["local_account_1"]
+		targetAccount     = suite.testAccounts["admin_account"]
+	)
+
+	// Mute target for just a second.
+	if _, errWithCode := suite.accountProcessor.MuteCreate(ctx, requestingAccount, targetAccount.ID, &apimodel.UserMuteCreateUpdateRequest{
+		Notifications: util.Ptr(false),
+		Duration:      util.Ptr(1),
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(suite.homeAccountIDs(requestingAccount.ID)[targetAccount.ID])
+
+	// Target should reappear once the mute expires.
+	if !testrig.WaitFor(func() bool {
+		return suite.homeAccountIDs(requestingAccount.ID)[targetAccount.ID]
+	}) {
+		suite.FailNow("timed out waiting for mute expiry")
+	}
+}
+
+func (suite *MuteTestSuite) TestScheduleMuteExpiries() {
+	var (
+		ctx               = context.Background()
+		requestingAccount = suite.testAccounts["local_account_1"]
+		targetAccount     = suite.testAccounts["admin_account"]
+	)
+
+	// Mute target for a minute.
+	if _, errWithCode := suite.accountProcessor.MuteCreate(ctx, requestingAccount, targetAccount.ID, &apimodel.UserMuteCreateUpdateRequest{
+		Notifications: util.Ptr(false),
+		Duration:      util.Ptr(60),
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	mute, err := suite.db.GetMute(ctx, requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	taskID := "@muteexpiry:" + mute.ID
+
+	// Drop the scheduled expiry, as a restart would.
+	suite.True(suite.state.Workers.Scheduler.Cancel(taskID))
+
+	// Reschedule expiries as done at startup.
+	if err := suite.accountProcessor.ScheduleMuteExpiries(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Expiry should be scheduled again.
+	suite.True(suite.state.Workers.Scheduler.Cancel(taskID))
+}
+
+func TestMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(MuteTestSuite))
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"time"
 
 	"codeberg.org/gruf/go-bytesize"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -95,6 +96,18 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		emojisChanged = true
 	}
 
+	// Note when any current fields were verified,
+	// so that reprocessed fields with unchanged
+	// values keep their rel="me" verification.
+	fieldsVerifiedAt := make(map[string]time.Time, len(account.FieldsRaw))
+	if len(account.Fields) == len(account.FieldsRaw) {
+		for i, field := range account.Fields {
+			if !field.VerifiedAt.IsZero() {
+				fieldsVerifiedAt[account.FieldsRaw[i].Value] = field.VerifiedAt
+			}
+		}
+	}
+
 	if form.FieldsAttributes != nil {
 		var (
 			fieldsAttributes = *form.FieldsAttributes
@@ -190,6 +203,9 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 				emojis[emoji.ID] = emoji
 			}
 
+			// Keep verification if value unchanged.
+			field.VerifiedAt = fieldsVerifiedAt[fieldRaw.Value]
+
 			// We're done, append the shiny new field.
 			account.Fields = append(account.Fields, field)
 		}
@@ -264,6 +280,35 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 			account.Settings.StatusContentType = *form.Source.StatusContentType
 		}
+
+		if form.Source.Boostable != nil {
+			account.Settings.Boostable = form.Source.Boostable
+		}
+
+		if form.Source.Replyable != nil {
+			account.Settings.Replyable = form.Source.Replyable
+		}
+
+		if form.Source.Likeable != nil {
+			account.Settings.Likeable = form.Source.Likeable
+		}
+
+		if form.Source.StatusExpiryDays != nil {
+			days := *form.Source.StatusExpiryDays
+			if days < 0 {
+				const text = "status_expiry_days must not be negative"
+				return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+			}
+			account.Settings.ExpiryDays = days
+		}
+
+		if form.Source.StatusExpiryKeepPinned != nil {
+			account.Settings.ExpiryKeepPinned = form.Source.StatusExpiryKeepPinned
+		}
+
+		if form.Source.StatusExpiryKeepFaved != nil {
+			account.Settings.ExpiryKeepFaved = form.Source.StatusExpiryKeepFaved
+		}
 	}
 
 	if form.Theme != nil {
@@ -313,6 +358,12 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		Origin:         account,
 	})
 
+	if form.FieldsAttributes != nil {
+		// Fields changed, (re)verify
+		// any rel="me" links in them.
+		p.QueueVerifyFields(account)
+	}
+
 	acctSensitive, err := p.converter.AccountToAPIAccountSensitive(ctx, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not convert account into apisensitive account: %s", err))
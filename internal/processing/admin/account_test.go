@@ -77,6 +77,160 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionDisable() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			Text:     "stinky",
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	// Ensure target user disabled.
+	targetUser, err := suite.db.GetUserByAccountID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(*targetUser.Disabled)
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "only local accounts can be disabled")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionSilence() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			Text:     "too loud",
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	// Ensure target account silenced.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(targetAcct.SilencedAt)
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsilence() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["remote_account_1"].ID
+	)
+
+	for _, actionType := range []gtsmodel.AdminActionType{
+		gtsmodel.AdminActionSilence,
+		gtsmodel.AdminActionUnsilence,
+	} {
+		actionID, errWithCode := suite.adminProcessor.AccountAction(
+			ctx,
+			adminAcct,
+			&apimodel.AdminActionRequest{
+				Category: gtsmodel.AdminActionCategoryAccount.String(),
+				Type:     actionType.String(),
+				TargetID: targetID,
+			},
+		)
+		suite.NoError(errWithCode)
+		suite.NotEmpty(actionID)
+
+		// Wait for action to finish.
+		if !testrig.WaitFor(func() bool {
+			return suite.adminProcessor.Actions().TotalRunning() == 0
+		}) {
+			suite.FailNow("timed out waiting for admin action(s) to finish")
+		}
+
+		adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Empty(adminAction.Errors)
+	}
+
+	// Ensure target account no longer silenced.
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Zero(targetAcct.SilencedAt)
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +248,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"disable\" \"silence\" \"unsilence\" \"suspend\"]")
 	suite.Empty(actionID)
 }
 
@@ -19,7 +19,9 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -27,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) AccountAction(
@@ -41,6 +44,15 @@ func (p *Processor) AccountAction(
 	}
 
 	switch gtsmodel.NewAdminActionType(request.Type) {
+	case gtsmodel.AdminActionDisable:
+		return p.accountActionDisable(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionSilence:
+		return p.accountActionSilence(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionUnsilence:
+		return p.accountActionUnsilence(ctx, adminAcct, targetAcct, request.Text)
+
 	case gtsmodel.AdminActionSuspend:
 		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
 
@@ -48,6 +60,9 @@ func (p *Processor) AccountAction(
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
+			gtsmodel.AdminActionDisable.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
 			gtsmodel.AdminActionSuspend.String(),
 		}
 
@@ -60,6 +75,139 @@ func (p *Processor) AccountAction(
 	}
 }
 
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsLocal() {
+		err := errors.New("only local accounts can be disabled")
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting target user: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionDisable,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			// Disabled users can no longer
+			// log in or use their tokens.
+			user.Disabled = util.Ptr(true)
+			if err := p.state.DB.UpdateUser(ctx, user, "disabled"); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Append(err)
+				return errs
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionSilence,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			if !targetAcct.SilencedAt.IsZero() {
+				// Already silenced.
+				return nil
+			}
+
+			targetAcct.SilencedAt = time.Now()
+			if err := p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at"); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Append(err)
+				return errs
+			}
+
+			// Clear visibility cache, as
+			// silences affect timelineability.
+			p.state.Caches.ClearShared(&p.state.Caches.Visibility)
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+func (p *Processor) accountActionUnsilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionUnsilence,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			if targetAcct.SilencedAt.IsZero() {
+				// Not silenced.
+				return nil
+			}
+
+			targetAcct.SilencedAt = time.Time{}
+			if err := p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at"); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Append(err)
+				return errs
+			}
+
+			// Clear visibility cache, as
+			// silences affect timelineability.
+			p.state.Caches.ClearShared(&p.state.Caches.Visibility)
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
 func (p *Processor) accountActionSuspend(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// CachesGet returns usage statistics and
+// capacities of all database caches, by name.
+func (p *Processor) CachesGet(ctx context.Context) ([]*apimodel.AdminCache, gtserror.WithCode) {
+	stats := p.state.Caches.Stats()
+
+	apiCaches := make([]*apimodel.AdminCache, 0, len(stats))
+	for name, s := range stats {
+		apiCache, err := p.apiCache(name, s)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiCaches = append(apiCaches, apiCache)
+	}
+
+	slices.SortFunc(apiCaches, func(a, b *apimodel.AdminCache) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return apiCaches, nil
+}
+
+// CacheUpdate resizes the named database cache, taking effect
+// immediately. The cache is emptied in the process. This is not
+// persisted, so on restart size is calculated from configuration.
+func (p *Processor) CacheUpdate(
+	ctx context.Context,
+	name string,
+	form *apimodel.AdminCacheUpdateRequest,
+) (*apimodel.AdminCache, gtserror.WithCode) {
+	if err := p.state.Caches.Resize(name, form.Capacity); err != nil {
+		if errors.Is(err, cache.ErrUnknownCache) {
+			err := gtserror.Newf("cache %s not found", name)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	apiCache, err := p.apiCache(name, p.state.Caches.Stats()[name])
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCache, nil
+}
+
+// apiCache converts named cache stats to API model.
+func (p *Processor) apiCache(name string, stats cache.Stats) (*apimodel.AdminCache, error) {
+	lower, upper, err := p.state.Caches.ResizeBounds(name)
+	if err != nil {
+		return nil, gtserror.Newf("error getting bounds of cache %s: %w", name, err)
+	}
+
+	var ratio float64
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		ratio = float64(stats.Hits) / float64(lookups)
+	}
+
+	return &apimodel.AdminCache{
+		Name:        name,
+		Hits:        stats.Hits,
+		Misses:      stats.Misses,
+		HitRatio:    ratio,
+		Evictions:   stats.Evictions,
+		Size:        stats.Size,
+		Capacity:    stats.Capacity,
+		MinCapacity: lower,
+		MaxCapacity: upper,
+	}, nil
+}
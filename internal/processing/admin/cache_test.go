@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type CacheTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *CacheTestSuite) TestCachesGetUpdate() {
+	ctx := context.Background()
+
+	caches, errWithCode := suite.adminProcessor.CachesGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(caches)
+
+	var account *apimodel.AdminCache
+	for _, cache := range caches {
+		if cache.Name == "account" {
+			account = cache
+		}
+	}
+	if account == nil {
+		suite.FailNow("account cache not found")
+	}
+	suite.LessOrEqual(account.MinCapacity, account.Capacity)
+	suite.GreaterOrEqual(account.MaxCapacity, account.Capacity)
+
+	// Resize within bounds.
+	updated, errWithCode := suite.adminProcessor.CacheUpdate(ctx, "account", &apimodel.AdminCacheUpdateRequest{
+		Capacity: account.MaxCapacity,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(account.MaxCapacity, updated.Capacity)
+
+	// Resize out of bounds.
+	_, errWithCode = suite.adminProcessor.CacheUpdate(ctx, "account", &apimodel.AdminCacheUpdateRequest{
+		Capacity: account.MaxCapacity + 1,
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Resize unknown cache.
+	_, errWithCode = suite.adminProcessor.CacheUpdate(ctx, "not-a-cache", &apimodel.AdminCacheUpdateRequest{
+		Capacity: 100,
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// CanonicalEmailBlocksGet returns all canonical email blocks
+// stored on this instance. If export is true, the format will
+// be suitable for writing out to an export.
+func (p *Processor) CanonicalEmailBlocksGet(ctx context.Context, export bool) ([]*apimodel.AdminCanonicalEmailBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetCanonicalEmailBlocks(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting canonical email blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminCanonicalEmailBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlock := p.converter.CanonicalEmailBlockToAdminAPICanonicalEmailBlock(block, export)
+		apiBlocks = append(apiBlocks, apiBlock)
+	}
+
+	return apiBlocks, nil
+}
+
+// CanonicalEmailBlockGet returns one canonical email block, with the given ID.
+func (p *Processor) CanonicalEmailBlockGet(ctx context.Context, id string) (*apimodel.AdminCanonicalEmailBlock, gtserror.WithCode) {
+	block, errWithCode := p.getCanonicalEmailBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.CanonicalEmailBlockToAdminAPICanonicalEmailBlock(block, false), nil
+}
+
+// CanonicalEmailBlockCreate blocks new sign-ups using the given email address,
+// or any address that canonicalizes to the same hash. Either email or hash
+// should be set; if email is set, its canonical hash will be derived from it.
+func (p *Processor) CanonicalEmailBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	email string,
+	hash string,
+) (*apimodel.AdminCanonicalEmailBlock, gtserror.WithCode) {
+	switch {
+	case email != "" && hash != "":
+		const text = "only one of email or canonical_email_hash should be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+
+	case email != "":
+		if err := validate.Email(email); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		hash = util.CanonicalEmailHash(email)
+
+	default:
+		var errWithCode gtserror.WithCode
+		hash, errWithCode = parseCanonicalEmailHash(hash)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Check if a block already exists for this hash.
+	existing, err := p.state.DB.GetCanonicalEmailBlockByHash(ctx, hash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting canonical email block %s: %w", hash, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("a canonical email block already exists for %s", hash)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	block := &gtsmodel.CanonicalEmailBlock{
+		ID:                 id.NewULID(),
+		CanonicalEmailHash: hash,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutCanonicalEmailBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting canonical email block %s: %w", hash, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.CanonicalEmailBlockToAdminAPICanonicalEmailBlock(block, false), nil
+}
+
+// CanonicalEmailBlocksImport handles the import of multiple canonical
+// email blocks, by decoding the given file as a JSON list of blocks
+// (as written out by an export), and creating a block for each hash.
+func (p *Processor) CanonicalEmailBlocksImport(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	hashesF *multipart.FileHeader,
+) (*apimodel.MultiStatus, gtserror.WithCode) {
+	// Open the provided file.
+	file, err := hashesF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse file as slice of canonical email blocks.
+	blocks := make([]*apimodel.AdminCanonicalEmailBlock, 0)
+	if err := json.NewDecoder(file).Decode(&blocks); err != nil {
+		err = gtserror.Newf("error parsing attachment as canonical email blocks: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		err = gtserror.New("error importing canonical email blocks: 0 entries provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Try to process each block, differentiating
+	// between successes and errors so that the caller
+	// can try failed imports again if desired.
+	multiStatusEntries := make([]apimodel.MultiStatusEntry, 0, count)
+
+	for _, block := range blocks {
+		hash := block.CanonicalEmailHash
+
+		block, errWithCode := p.CanonicalEmailBlockCreate(ctx, adminAcct, "", hash)
+
+		var entry *apimodel.MultiStatusEntry
+
+		if errWithCode != nil {
+			entry = &apimodel.MultiStatusEntry{
+				// Use the failed hash as the resource value.
+				Resource: hash,
+				Message:  errWithCode.Safe(),
+				Status:   errWithCode.Code(),
+			}
+		} else {
+			entry = &apimodel.MultiStatusEntry{
+				// Use successfully created API model block as the resource value.
+				Resource: block,
+				Message:  http.StatusText(http.StatusOK),
+				Status:   http.StatusOK,
+			}
+		}
+
+		multiStatusEntries = append(multiStatusEntries, *entry)
+	}
+
+	return apimodel.NewMultiStatus(multiStatusEntries), nil
+}
+
+// CanonicalEmailBlockDelete removes the canonical email block with the given ID.
+func (p *Processor) CanonicalEmailBlockDelete(ctx context.Context, id string) (*apimodel.AdminCanonicalEmailBlock, gtserror.WithCode) {
+	block, errWithCode := p.getCanonicalEmailBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteCanonicalEmailBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting canonical email block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.CanonicalEmailBlockToAdminAPICanonicalEmailBlock(block, false), nil
+}
+
+// CanonicalEmailBlocksTest returns the canonical email
+// blocks (if any) that match the given email address.
+func (p *Processor) CanonicalEmailBlocksTest(ctx context.Context, email string) ([]*apimodel.AdminCanonicalEmailBlock, gtserror.WithCode) {
+	if err := validate.Email(email); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	apiBlocks := make([]*apimodel.AdminCanonicalEmailBlock, 0, 1)
+
+	block, err := p.state.DB.GetCanonicalEmailBlockByHash(ctx, util.CanonicalEmailHash(email))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting canonical email block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block != nil {
+		apiBlock := p.converter.CanonicalEmailBlockToAdminAPICanonicalEmailBlock(block, false)
+		apiBlocks = append(apiBlocks, apiBlock)
+	}
+
+	return apiBlocks, nil
+}
+
+func (p *Processor) getCanonicalEmailBlock(ctx context.Context, id string) (*gtsmodel.CanonicalEmailBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetCanonicalEmailBlockByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting canonical email block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		err := fmt.Errorf("no canonical email block exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return block, nil
+}
+
+// parseCanonicalEmailHash ensures the given hash
+// is a hex-encoded SHA256 hash, returning it in
+// normalized (lowercase) form.
+func parseCanonicalEmailHash(hash string) (string, gtserror.WithCode) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != 32 {
+		text := fmt.Sprintf("canonical_email_hash %q is not a hex-encoded SHA256 hash", hash)
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return hash, nil
+}
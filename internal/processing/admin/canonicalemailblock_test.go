@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type CanonicalEmailBlockTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *CanonicalEmailBlockTestSuite) TestCanonicalEmailBlockCreateTestDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	block, errWithCode := suite.adminProcessor.CanonicalEmailBlockCreate(ctx, adminAcct, "spammer@example.org", "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(util.CanonicalEmailHash("spammer@example.org"), block.CanonicalEmailHash)
+
+	// Blocking by the same hash again should conflict.
+	_, errWithCode = suite.adminProcessor.CanonicalEmailBlockCreate(ctx, adminAcct, "", block.CanonicalEmailHash)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Aliases of the blocked address should match.
+	matches, errWithCode := suite.adminProcessor.CanonicalEmailBlocksTest(ctx, "Spam.Mer+lol@example.org")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(matches, 1)
+	suite.Equal(block.ID, matches[0].ID)
+
+	// Export should include only the hash.
+	exported, errWithCode := suite.adminProcessor.CanonicalEmailBlocksGet(ctx, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(exported, 1)
+	suite.Empty(exported[0].ID)
+	suite.Empty(exported[0].CreatedBy)
+	suite.Equal(block.CanonicalEmailHash, exported[0].CanonicalEmailHash)
+
+	_, errWithCode = suite.adminProcessor.CanonicalEmailBlockDelete(ctx, block.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	matches, errWithCode = suite.adminProcessor.CanonicalEmailBlocksTest(ctx, "spammer@example.org")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(matches)
+}
+
+func (suite *CanonicalEmailBlockTestSuite) TestCanonicalEmailBlockCreateInvalidHash() {
+	_, errWithCode := suite.adminProcessor.CanonicalEmailBlockCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		"",
+		"not a hash",
+	)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestCanonicalEmailBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(CanonicalEmailBlockTestSuite))
+}
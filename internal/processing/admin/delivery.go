@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DeliveryPausedHostsGet returns the destination hosts to which
+// outgoing deliveries are currently paused, due to consistently
+// failing to receive them.
+func (p *Processor) DeliveryPausedHostsGet(ctx context.Context) ([]*apimodel.DeliveryPausedHost, gtserror.WithCode) {
+	paused := p.state.Workers.Delivery.DeadHosts.Paused()
+
+	apiHosts := make([]*apimodel.DeliveryPausedHost, 0, len(paused))
+	for _, host := range paused {
+		apiHosts = append(apiHosts, &apimodel.DeliveryPausedHost{
+			Host:         host.Host,
+			Failures:     host.Failures,
+			FailingSince: util.FormatISO8601(host.FailingSince),
+			NextProbeAt:  util.FormatISO8601(host.NextProbeAt),
+		})
+	}
+
+	return apiHosts, nil
+}
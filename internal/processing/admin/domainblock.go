@@ -43,8 +43,14 @@ func (p *Processor) createDomainBlock(
 	obfuscate bool,
 	publicComment string,
 	privateComment string,
+	severityStr string,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	severity, errWithCode := parseDomainBlockSeverity(severityStr)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
 	// Check if a block already exists for this domain.
 	domainBlock, err := p.state.DB.GetDomainBlock(ctx, domain)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -53,6 +59,14 @@ func (p *Processor) createDomainBlock(
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
+	if domainBlock != nil && domainBlock.IsSilence() != (severity == gtsmodel.DomainBlockSeveritySilence) {
+		// Changing the severity of an existing block would mean
+		// undoing or redoing side effects; make the caller delete
+		// the existing block first so this is done explicitly.
+		err := fmt.Errorf("a domain block with a different severity already exists for %s", domain)
+		return nil, "", gtserror.NewErrorConflict(err, err.Error())
+	}
+
 	if domainBlock == nil {
 		// No block exists yet, create it.
 		domainBlock = &gtsmodel.DomainBlock{
@@ -63,6 +77,7 @@ func (p *Processor) createDomainBlock(
 			PublicComment:      text.SanitizeToPlaintext(publicComment),
 			Obfuscate:          &obfuscate,
 			SubscriptionID:     subscriptionID,
+			Severity:           severity,
 		}
 
 		// Insert the new block into the database.
@@ -72,6 +87,18 @@ func (p *Processor) createDomainBlock(
 		}
 	}
 
+	if domainBlock.IsSilence() {
+		// Silences are enforced when
+		// filtering statuses + follows,
+		// there are no side effects.
+		apiDomainBlock, errWithCode := p.apiDomainPerm(ctx, domainBlock, false)
+		if errWithCode != nil {
+			return nil, "", errWithCode
+		}
+
+		return apiDomainBlock, "", nil
+	}
+
 	actionID := id.NewULID()
 
 	// Process domain block side
@@ -247,6 +274,12 @@ func (p *Processor) deleteDomainBlock(
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
+	if domainBlock.IsSilence() {
+		// Nothing was suspended by
+		// a silence, so no side effects.
+		return apiDomainBlock, "", nil
+	}
+
 	actionID := id.NewULID()
 
 	// Process domain unblock side
@@ -348,3 +381,17 @@ func (p *Processor) domainUnblockSideEffects(
 
 	return errs
 }
+
+// parseDomainBlockSeverity parses the given string as a
+// domain block severity, defaulting to suspend if empty.
+func parseDomainBlockSeverity(severity string) (gtsmodel.DomainBlockSeverity, gtserror.WithCode) {
+	switch s := gtsmodel.DomainBlockSeverity(severity); s {
+	case "", gtsmodel.DomainBlockSeveritySuspend:
+		return gtsmodel.DomainBlockSeveritySuspend, nil
+	case gtsmodel.DomainBlockSeveritySilence:
+		return s, nil
+	default:
+		const text = "severity must be one of suspend, silence"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainMediaPoliciesGet returns all domain media policies stored on this instance.
+func (p *Processor) DomainMediaPoliciesGet(ctx context.Context) ([]*apimodel.AdminDomainMediaPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetDomainMediaPolicies(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain media policies: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.AdminDomainMediaPolicy, 0, len(policies))
+	for _, policy := range policies {
+		apiPolicies = append(apiPolicies, p.converter.DomainMediaPolicyToAdminAPIDomainMediaPolicy(policy))
+	}
+
+	return apiPolicies, nil
+}
+
+// DomainMediaPolicyGet returns one domain media policy, with the given ID.
+func (p *Processor) DomainMediaPolicyGet(ctx context.Context, id string) (*apimodel.AdminDomainMediaPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getDomainMediaPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.DomainMediaPolicyToAdminAPIDomainMediaPolicy(policy), nil
+}
+
+// DomainMediaPolicyCreate overrides remote media caching for media
+// owned by accounts on the given domain (and its subdomains), either
+// never caching it, or uncaching it after the given number of days.
+func (p *Processor) DomainMediaPolicyCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+	neverCache bool,
+	remoteCacheDays int,
+	privateComment string,
+) (*apimodel.AdminDomainMediaPolicy, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		const text = "domain must not be empty"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Normalize the domain as punycode.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		text := fmt.Sprintf("invalid domain %s", domain)
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	switch {
+	case remoteCacheDays < 0:
+		const text = "remote_cache_days must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	case !neverCache && remoteCacheDays == 0:
+		const text = "one of never_cache or remote_cache_days must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check if a policy already exists for this domain.
+	existing, err := p.state.DB.GetDomainMediaPolicy(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain media policy %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("a domain media policy already exists for %s", domain)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	policy := &gtsmodel.DomainMediaPolicy{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		NeverCache:         &neverCache,
+		RemoteCacheDays:    remoteCacheDays,
+		PrivateComment:     strings.TrimSpace(privateComment),
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutDomainMediaPolicy(ctx, policy); err != nil {
+		err := gtserror.Newf("db error putting domain media policy %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.DomainMediaPolicyToAdminAPIDomainMediaPolicy(policy), nil
+}
+
+// DomainMediaPolicyDelete removes the domain media policy with the given ID.
+func (p *Processor) DomainMediaPolicyDelete(ctx context.Context, id string) (*apimodel.AdminDomainMediaPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getDomainMediaPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainMediaPolicyByID(ctx, policy.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain media policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.DomainMediaPolicyToAdminAPIDomainMediaPolicy(policy), nil
+}
+
+func (p *Processor) getDomainMediaPolicy(ctx context.Context, id string) (*gtsmodel.DomainMediaPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetDomainMediaPolicyByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting domain media policy: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		err := fmt.Errorf("no domain media policy exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DomainMediaPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainMediaPolicyTestSuite) TestDomainMediaPolicyCreateGetDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	policy, errWithCode := suite.adminProcessor.DomainMediaPolicyCreate(ctx, adminAcct, "Example.org", false, 3, "huge files")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("example.org", policy.Domain)
+	suite.False(policy.NeverCache)
+	suite.Equal(3, policy.RemoteCacheDays)
+	suite.Equal("huge files", policy.PrivateComment)
+
+	// Creating a policy for the same domain again should conflict.
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyCreate(ctx, adminAcct, "example.org", true, 0, "")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	policies, errWithCode := suite.adminProcessor.DomainMediaPoliciesGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(policies, 1)
+
+	// Policy should apply to subdomains too.
+	match, err := suite.db.MatchDomainMediaPolicy(ctx, "media.example.org")
+	suite.NoError(err)
+	suite.NotNil(match)
+	suite.Equal(policy.ID, match.ID)
+
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyDelete(ctx, policy.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyGet(ctx, policy.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	match, err = suite.db.MatchDomainMediaPolicy(ctx, "media.example.org")
+	suite.NoError(err)
+	suite.Nil(match)
+}
+
+func (suite *DomainMediaPolicyTestSuite) TestDomainMediaPolicyCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	_, errWithCode := suite.adminProcessor.DomainMediaPolicyCreate(ctx, adminAcct, "example.org", false, 0, "")
+	suite.NotNil(errWithCode)
+	suite.Equal("Bad Request: one of never_cache or remote_cache_days must be set", errWithCode.Safe())
+
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyCreate(ctx, adminAcct, "example.org", false, -1, "")
+	suite.NotNil(errWithCode)
+	suite.Equal("Bad Request: remote_cache_days must not be negative", errWithCode.Safe())
+
+	_, errWithCode = suite.adminProcessor.DomainMediaPolicyCreate(ctx, adminAcct, "", true, 0, "")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestDomainMediaPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainMediaPolicyTestSuite))
+}
@@ -56,6 +56,8 @@ func (p *Processor) apiDomainPerm(
 // If the same permission type already exists for the domain,
 // side effects will be retried.
 //
+// Severity is only used for blocks, and may be empty (suspend).
+//
 // Return values for this function are the new or existing
 // domain permission, the ID of the admin action resulting
 // from this call, and/or an error if something goes wrong.
@@ -67,6 +69,7 @@ func (p *Processor) DomainPermissionCreate(
 	obfuscate bool,
 	publicComment string,
 	privateComment string,
+	severity string,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
 	switch permissionType {
@@ -80,6 +83,7 @@ func (p *Processor) DomainPermissionCreate(
 			obfuscate,
 			publicComment,
 			privateComment,
+			severity,
 			subscriptionID,
 		)
 
@@ -195,6 +199,7 @@ func (p *Processor) DomainPermissionsImport(
 			obfuscate      = domainPerm.Obfuscate
 			publicComment  = domainPerm.PublicComment
 			privateComment = domainPerm.PrivateComment
+			severity       = domainPerm.Severity
 			subscriptionID = "" // No sub ID for imports.
 			errWithCode    gtserror.WithCode
 		)
@@ -207,6 +212,7 @@ func (p *Processor) DomainPermissionsImport(
 			obfuscate,
 			publicComment,
 			privateComment,
+			severity,
 			subscriptionID,
 		)
 
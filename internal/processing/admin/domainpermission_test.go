@@ -134,6 +134,7 @@ func (suite *DomainBlockTestSuite) createDomainPerm(
 		"",
 		"",
 		"",
+		"",
 	)
 	suite.NoError(errWithCode)
 	suite.NotNil(apiPerm)
@@ -526,6 +527,87 @@ func (suite *DomainBlockTestSuite) TestAllowAndBlockDomain() {
 	})
 }
 
+func (suite *DomainBlockTestSuite) TestDomainBlockSilence() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		domain    = "fossbros-anonymous.io"
+	)
+
+	apiPerm, actionID, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		domain,
+		false,
+		"",
+		"",
+		"silence",
+		"",
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("silence", apiPerm.Severity)
+
+	// Silence has no side effects to run.
+	suite.Empty(actionID)
+
+	// Domain should be silenced but not blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	silenced, err := suite.db.IsDomainSilenced(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(silenced)
+
+	// Accounts on the domain should not be suspended.
+	accounts, err := suite.db.GetInstanceAccounts(ctx, domain, "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	for _, account := range accounts {
+		suite.Zero(account.SuspendedAt)
+	}
+
+	// Trying to suspend the domain without
+	// removing the silence first should fail.
+	_, _, errWithCode = suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		domain,
+		false,
+		"",
+		"",
+		"suspend",
+		"",
+	)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *DomainBlockTestSuite) TestDomainBlockInvalidSeverity() {
+	_, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		context.Background(),
+		gtsmodel.DomainPermissionBlock,
+		suite.testAccounts["admin_account"],
+		"fossbros-anonymous.io",
+		false,
+		"",
+		"",
+		"yeet",
+		"",
+	)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
 func TestDomainBlockTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainBlockTestSuite))
 }
@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// EmailDomainBlocksGet returns all email domain blocks stored on this instance.
+func (p *Processor) EmailDomainBlocksGet(ctx context.Context) ([]*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminEmailDomainBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlock, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(block)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiBlocks = append(apiBlocks, apiBlock)
+	}
+
+	return apiBlocks, nil
+}
+
+// EmailDomainBlockGet returns one email domain block, with the given ID.
+func (p *Processor) EmailDomainBlockGet(ctx context.Context, id string) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiBlock, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlockCreate blocks new sign-ups using
+// email addresses from the given domain, or its
+// subdomains, or that are served by its mail servers.
+func (p *Processor) EmailDomainBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "@")
+	if domain == "" {
+		const text = "email domain must not be empty"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check if a block already exists for this domain.
+	existing, err := p.state.DB.GetEmailDomainBlock(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("an email domain block already exists for %s", domain)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	block := &gtsmodel.EmailDomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.CreateEmailDomainBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlockDelete removes the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockDelete(ctx context.Context, id string) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the block to return, *before* the deletion goes through.
+	apiBlock, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(block)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+func (p *Processor) getEmailDomainBlock(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting email domain block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		err := fmt.Errorf("no email domain block exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return block, nil
+}
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailDomainBlockTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *EmailDomainBlockTestSuite) TestEmailDomainBlockCreateGetDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	// Leading @ and casing should be normalized away.
+	block, errWithCode := suite.adminProcessor.EmailDomainBlockCreate(ctx, adminAcct, "@Spam.Example.org")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("spam.example.org", block.Domain)
+
+	// Creating the same block again should conflict.
+	_, errWithCode = suite.adminProcessor.EmailDomainBlockCreate(ctx, adminAcct, "spam.example.org")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	blocks, errWithCode := suite.adminProcessor.EmailDomainBlocksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(blocks, 1)
+
+	blocked, err := suite.db.IsEmailDomainBlocked(ctx, "mail.spam.example.org")
+	suite.NoError(err)
+	suite.True(blocked)
+
+	_, errWithCode = suite.adminProcessor.EmailDomainBlockDelete(ctx, block.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.EmailDomainBlockGet(ctx, block.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	blocked, err = suite.db.IsEmailDomainBlocked(ctx, "spam.example.org")
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *EmailDomainBlockTestSuite) TestEmailDomainBlockCreateEmpty() {
+	_, errWithCode := suite.adminProcessor.EmailDomainBlockCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		" @ ",
+	)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestEmailDomainBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailDomainBlockTestSuite))
+}
@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"io"
+	"os"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmojiPackExport writes an emoji pack of this instance's custom emojis
+// (or only those in category, if set) to a temporary file, and opens it for
+// reading, returning it along with its size. The file is removed on close.
+func (p *Processor) EmojiPackExport(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	category string,
+) (io.ReadCloser, int64, gtserror.WithCode) {
+	file, err := os.CreateTemp("", "gotosocial-emojis-*.zip")
+	if err != nil {
+		err := gtserror.Newf("error creating emoji pack file: %w", err)
+		return nil, 0, gtserror.NewErrorInternalError(err)
+	}
+
+	// Ensure file gets
+	// removed on failure.
+	pack := &tempFile{file}
+	fail := func(err error) (io.ReadCloser, int64, gtserror.WithCode) {
+		_ = pack.Close()
+		return nil, 0, gtserror.NewErrorInternalError(err)
+	}
+
+	packer := emojipack.NewPacker(p.state, p.media)
+	if _, err := packer.Export(ctx, file, category); err != nil {
+		return fail(gtserror.Newf("error writing emoji pack: %w", err))
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fail(gtserror.Newf("error getting emoji pack size: %w", err))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fail(gtserror.Newf("error rewinding emoji pack: %w", err))
+	}
+
+	return pack, size, nil
+}
+
+// EmojiPackImport imports the emojis of the uploaded emoji pack as custom
+// emojis of this instance, handling shortcode conflicts as requested.
+func (p *Processor) EmojiPackImport(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.EmojiPackImportRequest,
+) (*apimodel.EmojiPackImportResult, gtserror.WithCode) {
+	file, err := form.Pack.Open()
+	if err != nil {
+		err := gtserror.Newf("error opening emoji pack: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer file.Close()
+
+	packer := emojipack.NewPacker(p.state, p.media)
+	result, err := packer.Import(ctx, file, form.Pack.Size, emojipack.ImportOptions{
+		Category:   form.CategoryName,
+		OnConflict: form.OnConflict,
+	})
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return &apimodel.EmojiPackImportResult{
+		Imported: result.Imported,
+		Replaced: result.Replaced,
+		Skipped:  result.Skipped,
+		Failed:   result.Failed,
+	}, nil
+}
+
+// tempFile wraps a temporary
+// file to remove it on close.
+type tempFile struct{ *os.File }
+
+func (f *tempFile) Close() error {
+	_ = f.File.Close()
+	return os.Remove(f.File.Name())
+}
@@ -0,0 +1,251 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+)
+
+// HTTPClientRangesGet returns the IP ranges currently
+// explicitly allowed / blocked for outgoing requests.
+func (p *Processor) HTTPClientRangesGet(ctx context.Context) (*apimodel.HTTPClientRanges, gtserror.WithCode) {
+	client, errWithCode := p.httpClient()
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	allow, block := client.Ranges()
+	return &apimodel.HTTPClientRanges{
+		AllowIPs: prefixStrings(allow),
+		BlockIPs: prefixStrings(block),
+	}, nil
+}
+
+// HTTPClientRangesUpdate replaces the IP ranges explicitly allowed / blocked
+// for outgoing requests, taking effect immediately. This is not persisted,
+// so on restart ranges will be loaded from configuration again.
+func (p *Processor) HTTPClientRangesUpdate(
+	ctx context.Context,
+	form *apimodel.HTTPClientRangesRequest,
+) (*apimodel.HTTPClientRanges, gtserror.WithCode) {
+	client, errWithCode := p.httpClient()
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	allow, err := parsePrefixes(form.AllowIPs)
+	if err != nil {
+		err := fmt.Errorf("invalid allow_ips: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	block, err := parsePrefixes(form.BlockIPs)
+	if err != nil {
+		err := fmt.Errorf("invalid block_ips: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Update live client ranges.
+	client.SetRanges(allow, block)
+
+	// Keep config in sync with live values.
+	config.SetHTTPClientAllowIPs(prefixStrings(allow))
+	config.SetHTTPClientBlockIPs(prefixStrings(block))
+
+	return &apimodel.HTTPClientRanges{
+		AllowIPs: prefixStrings(allow),
+		BlockIPs: prefixStrings(block),
+	}, nil
+}
+
+// HTTPClientDialCheck checks whether the given target (a URL,
+// hostname or IP address) would be dialable for outgoing
+// requests under the current allowed / blocked IP ranges.
+func (p *Processor) HTTPClientDialCheck(
+	ctx context.Context,
+	target string,
+) (*apimodel.HTTPClientDialCheck, gtserror.WithCode) {
+	client, errWithCode := p.httpClient()
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	host, err := dialCheckHost(target)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	checks, err := client.CheckDialable(ctx, host)
+	if err != nil {
+		err := fmt.Errorf("error checking %s: %w", host, err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	apiCheck := &apimodel.HTTPClientDialCheck{
+		Host:      host,
+		Dialable:  true,
+		Addresses: make([]apimodel.HTTPClientDialCheckAddress, 0, len(checks)),
+	}
+
+	for _, check := range checks {
+		addr := apimodel.HTTPClientDialCheckAddress{
+			IP:       check.IP.String(),
+			Dialable: (check.Err == nil),
+		}
+
+		switch matched := check.Range.IsValid(); {
+		case addr.Dialable && matched:
+			addr.Reason = "allowed"
+		case addr.Dialable:
+			addr.Reason = "public"
+		case matched:
+			addr.Reason = "blocked"
+		default:
+			addr.Reason = "reserved"
+		}
+
+		if check.Range.IsValid() {
+			addr.Range = check.Range.String()
+		}
+
+		// Host is only dialable if all addresses are.
+		apiCheck.Dialable = apiCheck.Dialable && addr.Dialable
+		apiCheck.Addresses = append(apiCheck.Addresses, addr)
+	}
+
+	return apiCheck, nil
+}
+
+// HTTPClientHostsGet returns statistics of outgoing requests
+// made to each host since startup, busiest hosts first.
+func (p *Processor) HTTPClientHostsGet(ctx context.Context) ([]*apimodel.HTTPClientHostStats, gtserror.WithCode) {
+	client, errWithCode := p.httpClient()
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	stats := client.Stats()
+	apiStats := make([]*apimodel.HTTPClientHostStats, 0, len(stats))
+
+	for _, s := range stats {
+		statuses := make(map[string]uint64, len(s.Statuses))
+		for i, count := range s.Statuses {
+			if count > 0 {
+				statuses[strconv.Itoa(i)+"xx"] = count
+			}
+		}
+
+		apiStats = append(apiStats, &apimodel.HTTPClientHostStats{
+			Host:          s.Host,
+			Requests:      s.Requests,
+			Errors:        s.Errors,
+			Statuses:      statuses,
+			AvgLatencyMS:  s.AvgLatency().Milliseconds(),
+			BytesSent:     s.BytesSent,
+			BytesReceived: s.BytesReceived,
+		})
+	}
+
+	slices.SortFunc(apiStats, func(a, b *apimodel.HTTPClientHostStats) int {
+		if c := cmp.Compare(b.Requests, a.Requests); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Host, b.Host)
+	})
+
+	return apiStats, nil
+}
+
+// httpClient returns the instance outgoing http client.
+func (p *Processor) httpClient() (*httpclient.Client, gtserror.WithCode) {
+	client := p.state.Workers.Delivery.Client
+	if client == nil {
+		const text = "http client not initialized"
+		return nil, gtserror.NewErrorInternalError(errors.New(text))
+	}
+	return client, nil
+}
+
+// dialCheckHost extracts the host to
+// check from a URL, host:port, or host.
+func dialCheckHost(target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", errors.New("no target given")
+	}
+
+	if strings.Contains(target, "://") {
+		// Parse target as URL.
+		u, err := url.Parse(target)
+		if err != nil {
+			return "", fmt.Errorf("invalid url: %w", err)
+		}
+		target = u.Hostname()
+	} else if host, _, err := net.SplitHostPort(target); err == nil {
+		// Strip port from target.
+		target = host
+	}
+
+	// Strip any IPv6 brackets.
+	target = strings.TrimPrefix(target, "[")
+	target = strings.TrimSuffix(target, "]")
+
+	if target == "" {
+		return "", errors.New("no host in target")
+	}
+
+	return target, nil
+}
+
+// parsePrefixes parses the given
+// strings as IP address prefixes.
+func parsePrefixes(in []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(in))
+	for _, str := range in {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// prefixStrings converts IP
+// address prefixes to strings.
+func prefixStrings(prefixes []netip.Prefix) []string {
+	strs := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		strs[i] = prefix.String()
+	}
+	return strs
+}
@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// IPBlocksGet returns all IP blocks stored on this instance.
+func (p *Processor) IPBlocksGet(ctx context.Context) ([]*apimodel.AdminIPBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetIPBlocks(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting ip blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminIPBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlocks = append(apiBlocks, p.converter.IPBlockToAdminAPIIPBlock(block))
+	}
+
+	return apiBlocks, nil
+}
+
+// IPBlockGet returns one IP block, with the given ID.
+func (p *Processor) IPBlockGet(ctx context.Context, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.IPBlockToAdminAPIIPBlock(block), nil
+}
+
+// IPBlockCreate blocks requests from the given IP
+// address or CIDR range, with the given severity.
+func (p *Processor) IPBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	ip string,
+	severity string,
+	comment string,
+) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	prefix, errWithCode := parseIPBlockPrefix(ip)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	sev, errWithCode := parseIPBlockSeverity(severity)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check if a block already exists for this range.
+	existing, err := p.state.DB.GetIPBlock(ctx, prefix)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting ip block %s: %w", prefix, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("an ip block already exists for %s", prefix)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	block := &gtsmodel.IPBlock{
+		ID:                 id.NewULID(),
+		IP:                 prefix,
+		Severity:           sev,
+		Comment:            strings.TrimSpace(comment),
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutIPBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting ip block %s: %w", prefix, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.IPBlockToAdminAPIIPBlock(block), nil
+}
+
+// IPBlockDelete removes the IP block with the given ID.
+func (p *Processor) IPBlockDelete(ctx context.Context, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteIPBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.IPBlockToAdminAPIIPBlock(block), nil
+}
+
+func (p *Processor) getIPBlock(ctx context.Context, id string) (*gtsmodel.IPBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetIPBlockByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting ip block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		err := fmt.Errorf("no ip block exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return block, nil
+}
+
+// parseIPBlockPrefix parses the given IP address or CIDR
+// range, returning it as a normalized CIDR prefix string.
+// Single addresses are treated as a /32 or /128 range.
+func parseIPBlockPrefix(ip string) (string, gtserror.WithCode) {
+	ip = strings.TrimSpace(ip)
+
+	if !strings.Contains(ip, "/") {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			text := fmt.Sprintf("invalid ip address %q", ip)
+			return "", gtserror.NewErrorBadRequest(err, text)
+		}
+
+		// Single address, e.g. 192.0.2.1/32.
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(ip)
+	if err != nil {
+		text := fmt.Sprintf("invalid ip range %q", ip)
+		return "", gtserror.NewErrorBadRequest(err, text)
+	}
+
+	// Ensure host bits are zeroed,
+	// e.g. 192.0.2.1/24 => 192.0.2.0/24.
+	return prefix.Masked().String(), nil
+}
+
+func parseIPBlockSeverity(severity string) (gtsmodel.IPBlockSeverity, gtserror.WithCode) {
+	switch s := gtsmodel.IPBlockSeverity(severity); s {
+	case "", gtsmodel.IPBlockSeverityNoAccess:
+		return gtsmodel.IPBlockSeverityNoAccess, nil
+	case gtsmodel.IPBlockSeveritySignUpBlock:
+		return s, nil
+	default:
+		const text = "severity must be one of sign_up_block, no_access"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type IPBlockTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *IPBlockTestSuite) TestIPBlockCreateGetDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	// Host bits should be masked out of the range.
+	block, errWithCode := suite.adminProcessor.IPBlockCreate(ctx, adminAcct, "192.0.2.69/24", "sign_up_block", "spam wave")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("192.0.2.0/24", block.IP)
+	suite.Equal("sign_up_block", block.Severity)
+	suite.Equal("spam wave", block.Comment)
+
+	// Creating the same block again should conflict.
+	_, errWithCode = suite.adminProcessor.IPBlockCreate(ctx, adminAcct, "192.0.2.0/24", "", "")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	blocks, errWithCode := suite.adminProcessor.IPBlocksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(blocks, 1)
+
+	severity, err := suite.db.IPBlockMatch(ctx, netip.MustParseAddr("192.0.2.1"))
+	suite.NoError(err)
+	suite.Equal(gtsmodel.IPBlockSeveritySignUpBlock, severity)
+
+	_, errWithCode = suite.adminProcessor.IPBlockDelete(ctx, block.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.IPBlockGet(ctx, block.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	severity, err = suite.db.IPBlockMatch(ctx, netip.MustParseAddr("192.0.2.1"))
+	suite.NoError(err)
+	suite.Empty(severity)
+}
+
+func (suite *IPBlockTestSuite) TestIPBlockCreateSingleAddress() {
+	block, errWithCode := suite.adminProcessor.IPBlockCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		"2001:db8::1",
+		"",
+		"",
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("2001:db8::1/128", block.IP)
+	suite.Equal("no_access", block.Severity)
+}
+
+func (suite *IPBlockTestSuite) TestIPBlockCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	_, errWithCode := suite.adminProcessor.IPBlockCreate(ctx, adminAcct, "not an ip", "", "")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.adminProcessor.IPBlockCreate(ctx, adminAcct, "192.0.2.1", "sign_up_requires_approval", "")
+	suite.NotNil(errWithCode)
+	suite.Equal("Bad Request: severity must be one of sign_up_block, no_access", errWithCode.Safe())
+}
+
+func TestIPBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(IPBlockTestSuite))
+}
@@ -117,10 +117,18 @@ func (p *Processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id
 }
 
 // ReportResolve marks a report with the given id as resolved,
-// and stores the provided actionTakenComment (if not null).
-// If the report creator is from this instance, an email will
-// be sent to them to let them know that the report is resolved.
-func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string, actionTakenComment *string) (*apimodel.AdminReport, gtserror.WithCode) {
+// and stores the provided actionTakenComment (if not null), and
+// resolutionCategory (if not null). If the report was not yet
+// assigned, it will be assigned to the resolving account. If the
+// report creator is from this instance, an email will be sent to
+// them to let them know that the report is resolved.
+func (p *Processor) ReportResolve(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	actionTakenComment *string,
+	resolutionCategory *string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
 	report, err := p.state.DB.GetReportByID(ctx, id)
 	if err != nil {
 		if err == db.ErrNoEntries {
@@ -137,11 +145,28 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 	report.ActionTakenAt = time.Now()
 	report.ActionTakenByAccountID = account.ID
 
+	if report.AssignedAccountID == "" {
+		// Nobody had picked up this report
+		// yet, so assign it to the resolver.
+		report.AssignedAccountID = account.ID
+		columns = append(columns, "assigned_account_id")
+	}
+
 	if actionTakenComment != nil {
 		report.ActionTaken = *actionTakenComment
 		columns = append(columns, "action_taken")
 	}
 
+	if resolutionCategory != nil {
+		category, errWithCode := parseResolutionCategory(*resolutionCategory)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		report.ResolutionCategory = category
+		columns = append(columns, "resolution_category")
+	}
+
 	updatedReport, err := p.state.DB.UpdateReport(ctx, report, columns...)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -163,3 +188,64 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 
 	return apimodelReport, nil
 }
+
+// ReportAssign assigns the report with the given id
+// to the given account, so that other admins know
+// someone is already handling it.
+func (p *Processor) ReportAssign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.reportSetAssigned(ctx, account, id, account.ID)
+}
+
+// ReportUnassign removes any assigned
+// account from the report with the given id.
+func (p *Processor) ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.reportSetAssigned(ctx, account, id, "")
+}
+
+func (p *Processor) reportSetAssigned(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	assignedAccountID string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, err := p.state.DB.GetReportByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if report.AssignedAccountID != assignedAccountID {
+		report.AssignedAccountID = assignedAccountID
+		report.AssignedAccount = nil
+
+		report, err = p.state.DB.UpdateReport(ctx, report, "assigned_account_id")
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apimodelReport, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apimodelReport, nil
+}
+
+// parseResolutionCategory parses the given string
+// as a report resolution category, returning a bad
+// request error if it's not a recognized category.
+func parseResolutionCategory(category string) (gtsmodel.ReportResolutionCategory, gtserror.WithCode) {
+	switch c := gtsmodel.ReportResolutionCategory(category); c {
+	case gtsmodel.ReportResolutionNoAction,
+		gtsmodel.ReportResolutionActioned,
+		gtsmodel.ReportResolutionDuplicate,
+		gtsmodel.ReportResolutionOther:
+		return c, nil
+	default:
+		const text = "resolution_category must be one of no_action, actioned, duplicate, other"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ReportTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ReportTestSuite) TestReportAssignUnassign() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+	)
+
+	apiReport, errWithCode := suite.adminProcessor.ReportAssign(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotNil(apiReport.AssignedAccount)
+	suite.Equal(adminAcct.ID, apiReport.AssignedAccount.ID)
+
+	dbReport, err := suite.db.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(adminAcct.ID, dbReport.AssignedAccountID)
+
+	apiReport, errWithCode = suite.adminProcessor.ReportUnassign(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Nil(apiReport.AssignedAccount)
+
+	dbReport, err = suite.db.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbReport.AssignedAccountID)
+}
+
+func (suite *ReportTestSuite) TestReportResolveWithCategory() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+	)
+
+	apiReport, errWithCode := suite.adminProcessor.ReportResolve(
+		ctx,
+		adminAcct,
+		report.ID,
+		util.Ptr("that's a duplicate, mate"),
+		util.Ptr("duplicate"),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(apiReport.ActionTaken)
+	suite.Equal("duplicate", *apiReport.ResolutionCategory)
+
+	dbReport, err := suite.db.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ReportResolutionDuplicate, dbReport.ResolutionCategory)
+}
+
+func (suite *ReportTestSuite) TestReportResolveInvalidCategory() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+	)
+
+	_, errWithCode := suite.adminProcessor.ReportResolve(
+		ctx,
+		adminAcct,
+		report.ID,
+		nil,
+		util.Ptr("yeeted"),
+	)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: resolution_category must be one of no_action, actioned, duplicate, other", errWithCode.Safe())
+
+	// Report should not be resolved.
+	dbReport, err := suite.db.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbReport.ActionTakenAt)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
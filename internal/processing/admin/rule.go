@@ -77,6 +77,9 @@ func (p *Processor) RuleCreate(ctx context.Context, form *apimodel.InstanceRuleC
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Rules are included in instance responses.
+	p.state.Caches.Anonymous.ClearInstance()
+
 	return p.converter.InstanceRuleToAdminAPIRule(rule), nil
 }
 
@@ -99,6 +102,9 @@ func (p *Processor) RuleUpdate(ctx context.Context, id string, form *apimodel.In
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Rules are included in instance responses.
+	p.state.Caches.Anonymous.ClearInstance()
+
 	return p.converter.InstanceRuleToAdminAPIRule(updatedRule), nil
 }
 
@@ -120,5 +126,8 @@ func (p *Processor) RuleDelete(ctx context.Context, id string) (*apimodel.AdminI
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Rules are included in instance responses.
+	p.state.Caches.Anonymous.ClearInstance()
+
 	return p.converter.InstanceRuleToAdminAPIRule(deletedRule), nil
 }
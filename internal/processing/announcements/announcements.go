@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state            *state.State
+	converter        *typeutils.Converter
+	formatter        *text.Formatter
+	parseMentionFunc gtsmodel.ParseMentionFunc
+	stream           *stream.Processor
+}
+
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	formatter *text.Formatter,
+	parseMentionFunc gtsmodel.ParseMentionFunc,
+	stream *stream.Processor,
+) Processor {
+	return Processor{
+		state:            state,
+		converter:        converter,
+		formatter:        formatter,
+		parseMentionFunc: parseMentionFunc,
+		stream:           stream,
+	}
+}
+
+// getAnnouncement fetches the announcement with the given ID,
+// returning 404 if it doesn't exist, or if active is set and
+// the announcement is not currently being shown to users.
+func (p *Processor) getAnnouncement(
+	ctx context.Context,
+	id string,
+	active bool,
+) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil || (active && !announcement.Active(time.Now())) {
+		err := gtserror.Newf("announcement %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return announcement, nil
+}
+
+// streamAnnouncement streams the given announcement to all
+// users if it's currently active, else if remove is set, streams
+// its deletion so clients stop showing it, eg., after it's ended.
+func (p *Processor) streamAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	remove bool,
+) {
+	if !announcement.Active(time.Now()) {
+		if remove {
+			p.stream.AnnouncementDelete(ctx, announcement.ID)
+		}
+		return
+	}
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, nil)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement %s to api: %v", announcement.ID, err)
+		return
+	}
+
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Create creates a new announcement authored by the requesting admin account,
+// streaming it to all users if it's active immediately. Announcements scheduled
+// to start in the future are not streamed, but will be returned to users from
+// the announcements API once they've started.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	now := time.Now()
+	announcement := &gtsmodel.Announcement{
+		ID:                 id.NewULID(),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		CreatedByAccountID: requester.ID,
+	}
+
+	if errWithCode := p.applyForm(ctx, requester, announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamAnnouncement(ctx, announcement, false)
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// applyForm parses the given create / update form
+// onto the given announcement, formatting its text
+// as markdown on behalf of the requesting account.
+func (p *Processor) applyForm(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcement *gtsmodel.Announcement,
+	form *apimodel.AnnouncementCreateRequest,
+) gtserror.WithCode {
+	var (
+		startsAt time.Time
+		endsAt   time.Time
+		err      error
+	)
+
+	if form.StartsAt != "" {
+		startsAt, err = time.Parse(time.RFC3339, form.StartsAt)
+		if err != nil {
+			const text = "starts_at must be an ISO 8601 datetime"
+			return gtserror.NewErrorBadRequest(err, text)
+		}
+	}
+
+	if form.EndsAt != "" {
+		endsAt, err = time.Parse(time.RFC3339, form.EndsAt)
+		if err != nil {
+			const text = "ends_at must be an ISO 8601 datetime"
+			return gtserror.NewErrorBadRequest(err, text)
+		}
+	}
+
+	if !startsAt.IsZero() && !endsAt.IsZero() && !endsAt.After(startsAt) {
+		const text = "ends_at must be after starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	formatted := p.formatter.FromMarkdown(
+		ctx,
+		p.parseMentionFunc,
+		requester.ID,
+		"",
+		form.Text,
+	)
+
+	emojiIDs := make([]string, 0, len(formatted.Emojis))
+	for _, emoji := range formatted.Emojis {
+		emojiIDs = append(emojiIDs, emoji.ID)
+	}
+
+	announcement.Text = form.Text
+	announcement.Content = formatted.HTML
+	announcement.EmojiIDs = emojiIDs
+	announcement.Emojis = formatted.Emojis
+	announcement.StartsAt = startsAt
+	announcement.EndsAt = endsAt
+	announcement.AllDay = &form.AllDay
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete deletes the announcement with the given ID, along with all reads
+// of and reactions to it, and streams its deletion to all users. The deleted
+// announcement is returned as it was just prior to deletion.
+func (p *Processor) Delete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, so that
+	// reactions are still included.
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, announcementID); err != nil {
+		err := gtserror.Newf("db error deleting announcement %s: %w", announcementID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.stream.AnnouncementDelete(ctx, announcementID)
+
+	return apiAnnouncement, nil
+}
@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// GetActive returns the announcements currently being shown to users,
+// newest first, from the perspective of the given requesting account.
+// Announcements already dismissed by the account are only included
+// if withDismissed is set.
+func (p *Processor) GetActive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	withDismissed bool,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetActiveAnnouncements(ctx, time.Now())
+	if err != nil {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+		if err != nil {
+			err := gtserror.Newf("error converting announcement %s to api: %w", announcement.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if apiAnnouncement.Read && !withDismissed {
+			continue
+		}
+
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// Dismiss marks the active announcement with
+// the given ID as read by the requesting account.
+func (p *Processor) Dismiss(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+) gtserror.WithCode {
+	if _, errWithCode := p.getAnnouncement(ctx, announcementID, true); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+		ID:             id.NewULID(),
+		AnnouncementID: announcementID,
+		AccountID:      requester.ID,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error marking announcement %s read: %w", announcementID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// GetAll returns all announcements, newest first, including
+// those not currently being shown to users, for the admin API.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+		if err != nil {
+			err := gtserror.Newf("error converting announcement %s to api: %w", announcement.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// Get returns the announcement with the given ID, even
+// if not currently being shown to users, for the admin API.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement %s to api: %w", announcement.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
@@ -0,0 +1,223 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+	"errors"
+	"unicode"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+const (
+	// maxReactionNames is the max no.
+	// distinct reactions (by name) that
+	// an announcement may have, as Mastodon.
+	maxReactionNames = 8
+
+	// maxUnicodeEmojiRunes is the max no. runes
+	// allowed in a unicode emoji reaction, enough
+	// for skin tone modifier and ZWJ sequences.
+	maxUnicodeEmojiRunes = 10
+)
+
+// ReactionAdd adds a reaction with the given name, either a unicode
+// emoji or a local custom emoji shortcode, by the requesting account
+// to the active announcement with the given ID. Adding a reaction
+// the account has already made is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, true)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	emoji, errWithCode := p.reactionEmoji(ctx, name)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	names := make(map[string]struct{}, len(reactions))
+	for _, r := range reactions {
+		if r.Name == name && r.AccountID == requester.ID {
+			// Already reacted.
+			return nil
+		}
+		names[r.Name] = struct{}{}
+	}
+
+	if _, ok := names[name]; !ok && len(names) >= maxReactionNames {
+		const text = "announcement already has the maximum number of different reactions"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      requester.ID,
+		Name:           name,
+	}
+
+	if emoji != nil {
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Raced with another
+			// request, that's fine.
+			return nil
+		}
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name, append(reactions, reaction))
+	return nil
+}
+
+// ReactionRemove removes the reaction with the given name by the requesting
+// account from the active announcement with the given ID, if it exists.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, true)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReaction(ctx, announcement.ID, requester.ID, name); err != nil {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name, reactions)
+	return nil
+}
+
+// reactionEmoji checks the given reaction name is valid, returning the
+// enabled local custom emoji it refers to if it's a shortcode, else nil
+// if it's a unicode emoji.
+func (p *Processor) reactionEmoji(ctx context.Context, name string) (*gtsmodel.Emoji, gtserror.WithCode) {
+	if !regexes.EmojiValidator.MatchString(name) {
+		if !isUnicodeEmoji(name) {
+			const text = "reaction must be a unicode emoji or custom emoji shortcode"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		return nil, nil
+	}
+
+	emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if emoji == nil || *emoji.Disabled {
+		const text = "no custom emoji with that shortcode"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return emoji, nil
+}
+
+// streamReaction streams the current count of reactions
+// with the given name to announcement with given ID, out
+// of the given reactions, to all users. Zero count means
+// the last reaction with the given name was removed.
+func (p *Processor) streamReaction(
+	ctx context.Context,
+	announcementID string,
+	name string,
+	reactions []*gtsmodel.AnnouncementReaction,
+) {
+	apiReactions, err := p.converter.AnnouncementReactionsToAPIAnnouncementReactions(ctx, reactions, nil)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement reactions to api: %v", err)
+		return
+	}
+
+	apiReaction := &apimodel.AnnouncementReaction{Name: name}
+	for i := range apiReactions {
+		if apiReactions[i].Name == name {
+			apiReaction = &apiReactions[i]
+			break
+		}
+	}
+	apiReaction.AnnouncementID = announcementID
+
+	p.stream.AnnouncementReaction(ctx, apiReaction)
+}
+
+// isUnicodeEmoji returns whether given string looks like a single
+// unicode emoji, including keycap, flag, skin tone modifier and ZWJ
+// sequences. This is a loose check, intended only to prevent using
+// arbitrary text as a reaction, not to validate against the standard.
+func isUnicodeEmoji(s string) bool {
+	if s == "" || utf8.RuneCountInString(s) > maxUnicodeEmojiRunes {
+		return false
+	}
+
+	var symbol bool
+	for _, r := range s {
+		switch {
+		// Emoji proper, keycap enclosure.
+		case unicode.Is(unicode.So, r), r == '\u20e3':
+			symbol = true
+
+		// Skin tone modifiers, variation selectors,
+		// zero-width joiners, tag sequences (flags).
+		case unicode.In(r, unicode.Sk, unicode.Mn, unicode.Cf):
+
+		// Keycap bases.
+		case r == '#', r == '*', r >= '0' && r <= '9':
+
+		default:
+			return false
+		}
+	}
+
+	return symbol
+}
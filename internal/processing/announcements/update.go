@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package announcements
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Update updates the announcement with the given ID from the given form,
+// streaming the updated announcement to all users if it's active, else
+// streaming its deletion so that clients stop showing it.
+func (p *Processor) Update(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, announcementID, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.applyForm(ctx, requester, announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement,
+		"text",
+		"content",
+		"emojis",
+		"starts_at",
+		"ends_at",
+		"all_day",
+	); err != nil {
+		err := gtserror.Newf("db error updating announcement %s: %w", announcementID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamAnnouncement(ctx, announcement, true)
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
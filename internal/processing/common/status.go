@@ -228,3 +228,39 @@ func (p *Processor) InvalidateTimelinedStatus(ctx context.Context, accountID str
 
 	return nil
 }
+
+// InvalidateTimelines is a shortcut function for dropping the home timeline
+// and all list timelines of the given accountID, so that they're indexed again
+// from the database next time they're fetched. Unlike InvalidateTimelinedStatus,
+// this is for changes affecting which statuses belong in the timelines at all,
+// eg., muting or unmuting an account, as filtered-out items are not retained.
+func (p *Processor) InvalidateTimelines(ctx context.Context, accountID string) error {
+	// Get lists first + bail if this fails.
+	lists, err := p.state.DB.GetListsForAccountID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting lists for account %s: %w", accountID, err)
+	}
+
+	// Start new log entry with
+	// the above calling func's name.
+	l := log.
+		WithContext(ctx).
+		WithField("caller", log.Caller(3)).
+		WithField("accountID", accountID)
+
+	// Remove home + list timelines, just log
+	// if something goes wrong since this is
+	// not a showstopper.
+
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, accountID); err != nil {
+		l.Errorf("error removing home timeline: %v", err)
+	}
+
+	for _, list := range lists {
+		if err := p.state.Timelines.List.RemoveTimeline(ctx, list.ID); err != nil {
+			l.Errorf("error removing list timeline %s: %v", list.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -71,6 +71,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 			Filter:    filter,
 			Keyword:   formKeyword.Keyword,
 			WholeWord: formKeyword.WholeWord,
+			Regex:     formKeyword.Regex,
 		}
 		filter.Keywords = append(filter.Keywords, filterKeyword)
 	}
@@ -0,0 +1,450 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+const (
+	// max size of a fetched filter list document.
+	filterListMaxSize = 1 << 20 // 1MiB
+
+	// max no. filters in a filter list document.
+	filterListMaxFilters = 100
+)
+
+// Export returns all filters of the given account as a portable filter list document.
+func (p *Processor) Export(ctx context.Context, account *gtsmodel.Account) (*apimodel.FilterList, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.FiltersToAPIFilterList(ctx, "", filters), nil
+}
+
+// Import creates filters for the given account from a filter list document, either
+// provided directly or fetched from a URL. If subscribe is set, a subscription to
+// the URL is also created, so that the imported filters are periodically re-synced.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Import(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.FilterListImportRequest,
+) (*apimodel.FilterListImportResult, gtserror.WithCode) {
+	list := form.List
+	switch {
+	case form.URL != "":
+		var err error
+		list, err = p.fetchFilterList(ctx, form.URL)
+		if err != nil {
+			err := fmt.Errorf("error fetching filter list: %w", err)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+	case form.ListFile != nil:
+		file, err := form.ListFile.Open()
+		if err != nil {
+			err = gtserror.Newf("error opening attachment: %w", err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		defer file.Close()
+
+		list, err = readFilterList(file)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if err := validateFilterList(list); err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Titles are unique per account, so check for
+	// conflicts up front rather than partially importing.
+	for _, filter := range filters {
+		for _, listFilter := range list.Filters {
+			if filter.Title == listFilter.Title {
+				err := fmt.Errorf("duplicate title: %s", filter.Title)
+				return nil, gtserror.NewErrorConflict(err, err.Error())
+			}
+		}
+	}
+
+	var subscription *gtsmodel.FilterSubscription
+	if form.Subscribe {
+		subscription = &gtsmodel.FilterSubscription{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			URL:       form.URL,
+			Title:     list.Title,
+			SyncedAt:  time.Now(),
+		}
+
+		if err := p.state.DB.PutFilterSubscription(ctx, subscription); err != nil {
+			if errors.Is(err, db.ErrAlreadyExists) {
+				err = errors.New("already subscribed to this filter list")
+				return nil, gtserror.NewErrorConflict(err, err.Error())
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	created, err := p.applyFilterList(ctx, account, subscription, list, filters)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	result := &apimodel.FilterListImportResult{
+		Filters: make([]apimodel.FilterV2, 0, len(created)),
+	}
+
+	for _, filter := range created {
+		apiFilter, errWithCode := p.apiFilter(ctx, filter)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		result.Filters = append(result.Filters, *apiFilter)
+	}
+
+	if subscription != nil {
+		result.Subscription = p.converter.FilterSubscriptionToAPIFilterSubscription(ctx, subscription, created)
+	}
+
+	// Send a filters changed event.
+	p.stream.FiltersChanged(ctx, account)
+
+	return result, nil
+}
+
+// applyFilterList brings the given account's filters in line with the given
+// filter list, returning any newly created filters. When a subscription is given,
+// filters already managed by it are updated in place (and removed if no longer
+// in the list), and any filter in the list with a title taken by one of the
+// account's other filters is skipped. Filters are created as managed by the
+// subscription, if given. Existing filters must be passed in fully populated.
+func (p *Processor) applyFilterList(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	subscription *gtsmodel.FilterSubscription,
+	list *apimodel.FilterList,
+	filters []*gtsmodel.Filter,
+) ([]*gtsmodel.Filter, error) {
+	var (
+		subscriptionID string
+		managed        = make(map[string]*gtsmodel.Filter)
+		taken          = make(map[string]struct{})
+		created        []*gtsmodel.Filter
+	)
+
+	if subscription != nil {
+		subscriptionID = subscription.ID
+	}
+
+	for _, filter := range filters {
+		if subscriptionID != "" && filter.SubscriptionID == subscriptionID {
+			managed[filter.Title] = filter
+		} else {
+			taken[filter.Title] = struct{}{}
+		}
+	}
+
+	for _, listFilter := range list.Filters {
+		if filter, ok := managed[listFilter.Title]; ok {
+			// Already managed by this
+			// subscription, update it.
+			delete(managed, listFilter.Title)
+			if err := p.updateFilterFromList(ctx, filter, listFilter); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if _, ok := taken[listFilter.Title]; ok {
+			// Don't touch filters that
+			// weren't created by this list.
+			continue
+		}
+
+		filter := &gtsmodel.Filter{
+			ID:             id.NewULID(),
+			AccountID:      account.ID,
+			SubscriptionID: subscriptionID,
+			Title:          listFilter.Title,
+		}
+		setFilterFromList(filter, listFilter)
+
+		for _, listKeyword := range listFilter.Keywords {
+			filter.Keywords = append(filter.Keywords, &gtsmodel.FilterKeyword{
+				ID:        id.NewULID(),
+				AccountID: account.ID,
+				FilterID:  filter.ID,
+				Filter:    filter,
+				Keyword:   listKeyword.Keyword,
+				WholeWord: util.Ptr(listKeyword.WholeWord),
+				Regex:     util.Ptr(listKeyword.Regex),
+			})
+		}
+
+		if err := p.state.DB.PutFilter(ctx, filter); err != nil {
+			return nil, gtserror.Newf("error putting filter %s: %w", filter.Title, err)
+		}
+
+		created = append(created, filter)
+	}
+
+	// Anything left over was removed from the list.
+	for _, filter := range managed {
+		if err := p.state.DB.DeleteFilterByID(ctx, filter.ID); err != nil {
+			return nil, gtserror.Newf("error deleting filter %s: %w", filter.Title, err)
+		}
+	}
+
+	return created, nil
+}
+
+// updateFilterFromList updates an existing filter to match the given
+// filter list entry, keeping existing keywords where they still match.
+func (p *Processor) updateFilterFromList(ctx context.Context, filter *gtsmodel.Filter, listFilter apimodel.FilterListFilter) error {
+	setFilterFromList(filter, listFilter)
+
+	existing := make(map[string]*gtsmodel.FilterKeyword, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		existing[filterKeyword.Keyword] = filterKeyword
+	}
+
+	var (
+		keywords       = make([]*gtsmodel.FilterKeyword, 0, len(listFilter.Keywords))
+		keywordColumns = make([][]string, 0, len(listFilter.Keywords))
+		deleteIDs      []string
+	)
+
+	for _, listKeyword := range listFilter.Keywords {
+		if filterKeyword, ok := existing[listKeyword.Keyword]; ok {
+			delete(existing, listKeyword.Keyword)
+			filterKeyword.WholeWord = util.Ptr(listKeyword.WholeWord)
+			filterKeyword.Regex = util.Ptr(listKeyword.Regex)
+			keywords = append(keywords, filterKeyword)
+			keywordColumns = append(keywordColumns, []string{"whole_word", "regex"})
+			continue
+		}
+
+		keywords = append(keywords, &gtsmodel.FilterKeyword{
+			ID:        id.NewULID(),
+			AccountID: filter.AccountID,
+			FilterID:  filter.ID,
+			Filter:    filter,
+			Keyword:   listKeyword.Keyword,
+			WholeWord: util.Ptr(listKeyword.WholeWord),
+			Regex:     util.Ptr(listKeyword.Regex),
+		})
+		keywordColumns = append(keywordColumns, nil)
+	}
+
+	for _, filterKeyword := range existing {
+		deleteIDs = append(deleteIDs, filterKeyword.ID)
+	}
+
+	// Statuses aren't part of filter
+	// lists, leave any existing alone.
+	filter.Keywords = keywords
+	filter.Statuses = nil
+
+	if err := p.state.DB.UpdateFilter(ctx, filter,
+		[]string{
+			"action",
+			"context_home",
+			"context_notifications",
+			"context_public",
+			"context_thread",
+			"context_account",
+		},
+		keywordColumns,
+		deleteIDs,
+		nil,
+	); err != nil {
+		return gtserror.Newf("error updating filter %s: %w", filter.Title, err)
+	}
+
+	return nil
+}
+
+// setFilterFromList sets the action and contexts
+// of filter to those of the given filter list entry.
+func setFilterFromList(filter *gtsmodel.Filter, listFilter apimodel.FilterListFilter) {
+	filter.Action = typeutils.APIFilterActionToFilterAction(listFilter.FilterAction)
+	filter.ContextHome = util.Ptr(false)
+	filter.ContextNotifications = util.Ptr(false)
+	filter.ContextPublic = util.Ptr(false)
+	filter.ContextThread = util.Ptr(false)
+	filter.ContextAccount = util.Ptr(false)
+	for _, context := range listFilter.Context {
+		switch context {
+		case apimodel.FilterContextHome:
+			filter.ContextHome = util.Ptr(true)
+		case apimodel.FilterContextNotifications:
+			filter.ContextNotifications = util.Ptr(true)
+		case apimodel.FilterContextPublic:
+			filter.ContextPublic = util.Ptr(true)
+		case apimodel.FilterContextThread:
+			filter.ContextThread = util.Ptr(true)
+		case apimodel.FilterContextAccount:
+			filter.ContextAccount = util.Ptr(true)
+		}
+	}
+}
+
+// fetchFilterList fetches and parses the filter list document at the given URL,
+// using the instance account transport, so the requester is not revealed.
+func (p *Processor) fetchFilterList(ctx context.Context, rawURL string) (*apimodel.FilterList, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure URL not blocked.
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, u.Hostname())
+	if err != nil {
+		return nil, gtserror.Newf("db error checking for domain block: %w", err)
+	}
+
+	if blocked {
+		return nil, fmt.Errorf("domain %s is blocked", u.Hostname())
+	}
+
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting transport: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	return readFilterList(rsp.Body)
+}
+
+// readFilterList reads and parses a filter list
+// document, up to a maximum of filterListMaxSize.
+func readFilterList(r io.Reader) (*apimodel.FilterList, error) {
+	b, err := io.ReadAll(io.LimitReader(r, filterListMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > filterListMaxSize {
+		return nil, fmt.Errorf("filter list larger than %d bytes", filterListMaxSize)
+	}
+
+	var list apimodel.FilterList
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, fmt.Errorf("error parsing filter list: %w", err)
+	}
+
+	return &list, nil
+}
+
+// validateFilterList checks that the given
+// filter list document can be imported.
+func validateFilterList(list *apimodel.FilterList) error {
+	if list == nil {
+		return errors.New("no filter list provided")
+	}
+
+	if list.Version != apimodel.FilterListVersion {
+		return fmt.Errorf("unsupported filter list version %d", list.Version)
+	}
+
+	if len(list.Filters) > filterListMaxFilters {
+		return fmt.Errorf("filter list contains more than %d filters", filterListMaxFilters)
+	}
+
+	titles := make(map[string]struct{}, len(list.Filters))
+	for _, listFilter := range list.Filters {
+		if err := validate.FilterTitle(listFilter.Title); err != nil {
+			return err
+		}
+
+		if _, ok := titles[listFilter.Title]; ok {
+			return fmt.Errorf("filter list contains duplicate title: %s", listFilter.Title)
+		}
+		titles[listFilter.Title] = struct{}{}
+
+		if err := validate.FilterAction(listFilter.FilterAction); err != nil {
+			return err
+		}
+
+		if err := validate.FilterContexts(listFilter.Context); err != nil {
+			return err
+		}
+
+		keywords := make(map[string]struct{}, len(listFilter.Keywords))
+		for _, listKeyword := range listFilter.Keywords {
+			if err := validate.FilterKeyword(listKeyword.Keyword); err != nil {
+				return err
+			}
+
+			if _, ok := keywords[listKeyword.Keyword]; ok {
+				return fmt.Errorf("filter %s contains duplicate keyword: %s", listFilter.Title, listKeyword.Keyword)
+			}
+			keywords[listKeyword.Keyword] = struct{}{}
+
+			if listKeyword.Regex {
+				if err := validate.FilterKeywordRegex(listKeyword.Keyword); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
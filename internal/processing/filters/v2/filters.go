@@ -20,6 +20,7 @@ package v2
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
@@ -27,12 +28,19 @@ type Processor struct {
 	state     *state.State
 	converter *typeutils.Converter
 	stream    *stream.Processor
+	transport transport.Controller
 }
 
-func New(state *state.State, converter *typeutils.Converter, stream *stream.Processor) Processor {
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	stream *stream.Processor,
+	transportController transport.Controller,
+) Processor {
 	return Processor{
 		state:     state,
 		converter: converter,
 		stream:    stream,
+		transport: transportController,
 	}
 }
@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// how often subscribed filter lists are re-synced.
+const filterSubscriptionSyncEvery = 6 * time.Hour
+
+// Subscriptions returns all filter subscriptions of the given account.
+func (p *Processor) Subscriptions(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.FilterSubscription, gtserror.WithCode) {
+	subscriptions, err := p.state.DB.GetFilterSubscriptionsForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(subscriptions) == 0 {
+		return []*apimodel.FilterSubscription{}, nil
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubscriptions := make([]*apimodel.FilterSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		apiSubscription := p.converter.FilterSubscriptionToAPIFilterSubscription(ctx, subscription, filters)
+		apiSubscriptions = append(apiSubscriptions, apiSubscription)
+	}
+
+	return apiSubscriptions, nil
+}
+
+// SubscriptionDelete removes a filter subscription of the given account. The filters
+// managed by it are deleted too, unless keepFilters is set, in which case they are
+// kept as regular filters that are no longer synced.
+func (p *Processor) SubscriptionDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	subscriptionID string,
+	keepFilters bool,
+) gtserror.WithCode {
+	subscription, err := p.state.DB.GetFilterSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+	if subscription.AccountID != account.ID {
+		return gtserror.NewErrorNotFound(
+			fmt.Errorf("filter subscription %s doesn't belong to account %s", subscription.ID, account.ID),
+		)
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, filter := range filters {
+		if filter.SubscriptionID != subscription.ID {
+			continue
+		}
+
+		if !keepFilters {
+			err = p.state.DB.DeleteFilterByID(ctx, filter.ID)
+		} else {
+			filter.SubscriptionID = ""
+			err = p.state.DB.UpdateFilter(ctx, filter, []string{"subscription_id"}, nil, nil, nil)
+		}
+
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteFilterSubscriptionByID(ctx, subscription.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send a filters changed event.
+	p.stream.FiltersChanged(ctx, account)
+
+	return nil
+}
+
+// ScheduleSubscriptionSync schedules a recurring job
+// to re-sync all subscribed filter lists from their URLs.
+func (p *Processor) ScheduleSubscriptionSync() error {
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting filter subscription sync")
+		n := p.SyncSubscriptions(ctx)
+		log.Infof(ctx, "finished filter subscription sync after %s; %d subscriptions synced", time.Since(start), n)
+	}
+
+	// Ensure only one process sharing the
+	// database runs filter subscription sync.
+	fn = p.state.Workers.Scheduler.Exclusive(
+		"@filtersubscriptions",
+		filterSubscriptionSyncEvery/2,
+		fn,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@filtersubscriptions",
+		time.Time{},
+		filterSubscriptionSyncEvery,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @filtersubscriptions")
+	}
+
+	return nil
+}
+
+// SyncSubscriptions re-fetches the filter list of every filter subscription and
+// updates the filters it manages to match. Failures are recorded on the subscription,
+// leaving its filters as they were. Returns no. subscriptions successfully synced.
+func (p *Processor) SyncSubscriptions(ctx context.Context) int {
+	subscriptions, err := p.state.DB.GetAllFilterSubscriptions(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting filter subscriptions: %v", err)
+		return 0
+	}
+
+	var n int
+
+	for _, subscription := range subscriptions {
+		if err := p.syncSubscription(ctx, subscription); err != nil {
+			log.Warnf(ctx, "error syncing filter subscription %s: %v", subscription.ID, err)
+
+			// Record the failure for the user.
+			subscription.Error = err.Error()
+			if err := p.state.DB.UpdateFilterSubscription(ctx, subscription, "error"); err != nil {
+				log.Errorf(ctx, "db error updating filter subscription %s: %v", subscription.ID, err)
+			}
+			continue
+		}
+		n++
+	}
+
+	return n
+}
+
+// syncSubscription re-fetches the filter list of the
+// given subscription and applies it to the account.
+func (p *Processor) syncSubscription(ctx context.Context, subscription *gtsmodel.FilterSubscription) error {
+	account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), subscription.AccountID)
+	if err != nil {
+		return gtserror.Newf("error getting account: %w", err)
+	}
+
+	if account.IsSuspended() {
+		// Nothing to do.
+		return nil
+	}
+
+	list, err := p.fetchFilterList(ctx, subscription.URL)
+	if err != nil {
+		return err
+	}
+
+	if err := validateFilterList(list); err != nil {
+		return err
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	if _, err := p.applyFilterList(ctx, account, subscription, list, filters); err != nil {
+		return err
+	}
+
+	subscription.Title = list.Title
+	subscription.SyncedAt = time.Now()
+	subscription.Error = ""
+	if err := p.state.DB.UpdateFilterSubscription(ctx, subscription, "title", "synced_at", "error"); err != nil {
+		return gtserror.Newf("db error updating filter subscription: %w", err)
+	}
+
+	// Send a filters changed event.
+	p.stream.FiltersChanged(ctx, account)
+
+	return nil
+}
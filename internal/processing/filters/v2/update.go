@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Update an existing filter for the given account, using the provided parameters.
@@ -169,7 +170,7 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 			}
 
 			// Process updates.
-			columns := make([]string, 0, 2)
+			columns := make([]string, 0, 3)
 			if formKeyword.Keyword != nil {
 				columns = append(columns, "keyword")
 				filterKeyword.Keyword = *formKeyword.Keyword
@@ -178,6 +179,17 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 				columns = append(columns, "whole_word")
 				filterKeyword.WholeWord = formKeyword.WholeWord
 			}
+			if formKeyword.Regex != nil {
+				columns = append(columns, "regex")
+				filterKeyword.Regex = formKeyword.Regex
+			}
+			if util.PtrValueOr(filterKeyword.Regex, false) {
+				// Keyword or regex flag may have changed
+				// independently, so check the combination.
+				if err := validate.FilterKeywordRegex(filterKeyword.Keyword); err != nil {
+					return nil, nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+				}
+			}
 			filterKeywordColumnsByID[id] = columns
 			continue
 		}
@@ -190,6 +202,7 @@ func applyKeywordChanges(filter *gtsmodel.Filter, formKeywords []apimodel.Filter
 			Filter:    filter,
 			Keyword:   *formKeyword.Keyword,
 			WholeWord: util.Ptr(util.PtrValueOr(formKeyword.WholeWord, false)),
+			Regex:     util.Ptr(util.PtrValueOr(formKeyword.Regex, false)),
 		}
 		filterKeywordsByID[filterKeyword.ID] = filterKeyword
 		// Don't need to set columns, as we're using all of them.
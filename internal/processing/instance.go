@@ -34,6 +34,10 @@ import (
 )
 
 func (p *Processor) InstanceGetV1(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+	if ai, ok := p.state.Caches.Anonymous.InstanceV1.Get(""); ok {
+		return ai, nil
+	}
+
 	i, err := p.getThisInstance(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance: %s", err))
@@ -44,10 +48,15 @@ func (p *Processor) InstanceGetV1(ctx context.Context) (*apimodel.InstanceV1, gt
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
 	}
 
+	p.state.Caches.Anonymous.InstanceV1.Set("", ai)
 	return ai, nil
 }
 
 func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gtserror.WithCode) {
+	if ai, ok := p.state.Caches.Anonymous.InstanceV2.Get(""); ok {
+		return ai, nil
+	}
+
 	i, err := p.getThisInstance(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance: %s", err))
@@ -58,6 +67,7 @@ func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gt
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
 	}
 
+	p.state.Caches.Anonymous.InstanceV2.Set("", ai)
 	return ai, nil
 }
 
@@ -104,11 +114,18 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 				d = obfuscate(d)
 			}
 
-			domains = append(domains, &apimodel.Domain{
+			domain := &apimodel.Domain{
 				Domain:        d,
-				SuspendedAt:   util.FormatISO8601(domainBlock.CreatedAt),
 				PublicComment: domainBlock.PublicComment,
-			})
+			}
+
+			if domainBlock.IsSilence() {
+				domain.SilencedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			} else {
+				domain.SuspendedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			}
+
+			domains = append(domains, domain)
 		}
 	}
 
@@ -297,6 +314,10 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		}
 	}
 
+	// Drop any cached responses
+	// showing the old settings.
+	p.state.Caches.Anonymous.ClearInstance()
+
 	return p.InstanceGetV1(ctx)
 }
 
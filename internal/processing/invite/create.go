@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Create creates a new sign-up invite code for the given user,
+// with the max uses and expiry specified in the given form.
+func (p *Processor) Create(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !canInvite(user) {
+		const text = "only admins and moderators may create invites on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.MaxUses < 0 {
+		const text = "max_uses must be 0 (unlimited) or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must be 0 (never) or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:                 id.NewULID(),
+		Code:               id.NewInviteCode(),
+		CreatedByAccountID: user.AccountID,
+		MaxUses:            form.MaxUses,
+	}
+
+	if form.ExpiresIn > 0 {
+		expiresIn := time.Duration(form.ExpiresIn) * time.Second
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InviteToAPIInvite(invite), nil
+}
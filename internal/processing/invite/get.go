@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// GetAll returns all invites created by the given user,
+// or all invites on the instance if the user is an admin
+// or moderator.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]*apimodel.Invite, gtserror.WithCode) {
+	var accountID string
+	if !isModerator(user) {
+		// Regular users only
+		// see their own invites.
+		accountID = user.AccountID
+	}
+
+	invites, err := p.state.DB.GetInvites(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvites := make([]*apimodel.Invite, 0, len(invites))
+	for _, invite := range invites {
+		apiInvites = append(apiInvites, p.converter.InviteToAPIInvite(invite))
+	}
+
+	return apiInvites, nil
+}
+
+// getInvite gets the invite with the given ID, checking that
+// the given user is allowed to see (and therefore manage) it.
+func (p *Processor) getInvite(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*gtsmodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil ||
+		(invite.CreatedByAccountID != user.AccountID && !isModerator(user)) {
+		err := gtserror.Newf("invite %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return invite, nil
+}
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Revoke expires the invite with the given ID, so it can no longer
+// be used to sign up. The invite itself is kept, so that admins can
+// still see who invited users that signed up with it.
+func (p *Processor) Revoke(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, errWithCode := p.getInvite(ctx, user, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if now := time.Now(); !invite.Expired(now) {
+		invite.ExpiresAt = now
+		if err := p.state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+			err := gtserror.Newf("db error updating invite %s: %w", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.converter.InviteToAPIInvite(invite), nil
+}
@@ -25,6 +25,8 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
@@ -63,3 +65,104 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 
 	return &apiAttachment, nil
 }
+
+// CreateAsync creates a new media attachment belonging to the given account, using the
+// request form, but unlike Create() the media is processed asynchronously by a worker.
+//
+// The returned attachment will still be processing, and so will have no URLs set. Callers
+// should use Get() to check on the attachment until processing has finished.
+func (p *Processor) CreateAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	f, err := form.File.Open()
+	if err != nil {
+		err := gtserror.Newf("error opening uploaded file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The uploaded file is cleaned up once the request
+	// is finished, so copy it to a temporary file that
+	// will instead be removed once processing is done.
+	tmp, err := iotools.TempFileSeeker(f)
+	_ = f.Close()
+	if err != nil {
+		err := gtserror.Newf("error copying uploaded file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.createAsync(ctx, account, tmp, form.File.Size, media.AdditionalMediaInfo{
+		Description: &form.Description,
+		FocusX:      &focusX,
+		FocusY:      &focusY,
+	})
+}
+
+// createAsync creates a new media attachment belonging to the given account
+// from the given temporary file of size, queueing it to be processed by a worker.
+// The file is closed (and so should remove itself) once processing is done.
+func (p *Processor) createAsync(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tmp io.ReadSeekCloser,
+	size int64,
+	info media.AdditionalMediaInfo,
+) (*apimodel.Attachment, gtserror.WithCode) {
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, 0, err
+		}
+		return tmp, size, nil
+	}
+
+	// Create a new processing media attachment,
+	// this inserts a placeholder in the database.
+	processing, err := p.mediaManager.CreateMedia(ctx,
+		account.ID,
+		data,
+		info,
+	)
+	if err != nil {
+		_ = tmp.Close()
+		err := gtserror.Newf("error creating media: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Fetch the placeholder to return, before
+	// queueing, so it isn't read while changing.
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, processing.ID())
+	if err != nil {
+		err := gtserror.Newf("error getting media %s: %w", processing.ID(), err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The received status is the zero value, which gets
+	// stored as the column default (processed), so mark
+	// the placeholder as processing until the job is done.
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := p.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		err := gtserror.Newf("error updating media %s: %w", processing.ID(), err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process (store + decode) media in the background.
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if _, err := processing.Load(ctx); err != nil {
+			log.Errorf(ctx, "error processing media %s: %v", processing.ID(), err)
+		}
+	})
+
+	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
+	if err != nil {
+		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// No files available yet.
+	unprocessedURLs(&apiAttachment)
+
+	return &apiAttachment, nil
+}
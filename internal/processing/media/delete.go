@@ -25,6 +25,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
@@ -49,6 +50,13 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		}
 	}
 
+	// delete any thumbnail variant from storage
+	if webpPath := media.WebPVariantPath(attachment.Thumbnail.Path); webpPath != "" {
+		if err := p.state.Storage.Delete(ctx, webpPath); err != nil && !storage.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("remove thumbnail variant at path %s: %s", webpPath, err))
+		}
+	}
+
 	// delete the file from storage
 	if attachment.File.Path != "" {
 		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
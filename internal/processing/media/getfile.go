@@ -18,9 +18,11 @@
 package media
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -87,6 +90,7 @@ func (p *Processor) GetFile(
 			owningAccountID,
 			wantedMediaID,
 			mediaSize,
+			form.AcceptWebP,
 		)
 	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
 		return p.getAttachmentContent(ctx,
@@ -94,6 +98,7 @@ func (p *Processor) GetFile(
 			owningAccountID,
 			wantedMediaID,
 			mediaSize,
+			form.AcceptWebP,
 		)
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
@@ -106,6 +111,7 @@ func (p *Processor) getAttachmentContent(
 	ownerID string,
 	mediaID string,
 	sizeStr media.Size,
+	acceptWebP bool,
 ) (
 	*apimodel.Content,
 	gtserror.WithCode,
@@ -152,15 +158,7 @@ func (p *Processor) getAttachmentContent(
 		// to process because it wasn't supported, then we
 		// can skip a lot of steps here by simply forwarding
 		// the request to the remote URL.
-		url := &storage.PresignedURL{
-			URL: remoteURL,
-
-			// We might manage to cache the media
-			// at some point, so set a low-ish expiry.
-			Expiry: time.Now().Add(2 * time.Hour),
-		}
-
-		return &apimodel.Content{URL: url}, nil
+		return remoteContent(remoteURL), nil
 	}
 
 	var requestUser string
@@ -184,6 +182,13 @@ func (p *Processor) getAttachmentContent(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	if attach.Type == gtsmodel.FileTypeUnknown && remoteURL != nil {
+		// Media was left uncached on recache, e.g.
+		// due to a domain media policy preventing
+		// caching, so forward to the remote URL.
+		return remoteContent(remoteURL), nil
+	}
+
 	// Start preparing API content model.
 	apiContent := &apimodel.Content{
 		ContentUpdated: attach.UpdatedAt,
@@ -202,6 +207,12 @@ func (p *Processor) getAttachmentContent(
 		)
 
 	case media.SizeSmall:
+		if acceptWebP {
+			// Serve WebP thumbnail variant if stored.
+			if p.getWebPVariant(ctx, attach.Thumbnail.Path, apiContent) {
+				return apiContent, nil
+			}
+		}
 		apiContent.ContentType = attach.Thumbnail.ContentType
 		apiContent.ContentLength = int64(attach.Thumbnail.FileSize)
 		return p.getContent(ctx,
@@ -221,6 +232,7 @@ func (p *Processor) getEmojiContent(
 	ownerID string,
 	emojiID string,
 	sizeStr media.Size,
+	acceptWebP bool,
 ) (
 	*apimodel.Content,
 	gtserror.WithCode,
@@ -282,6 +294,12 @@ func (p *Processor) getEmojiContent(
 		)
 
 	case media.SizeStatic:
+		if acceptWebP {
+			// Serve WebP static variant if stored.
+			if p.getWebPVariant(ctx, emoji.ImageStaticPath, apiContent) {
+				return apiContent, nil
+			}
+		}
 		apiContent.ContentType = emoji.ImageStaticContentType
 		apiContent.ContentLength = int64(emoji.ImageStaticFileSize)
 		return p.getContent(ctx,
@@ -332,6 +350,67 @@ func (p *Processor) getContent(
 	return content, nil
 }
 
+// getWebPVariant attempts to populate content with any WebP
+// variant stored alongside the derived image at path, returning
+// whether it was found. Any errors are logged and treated as not
+// found, in which case the caller should serve the original.
+func (p *Processor) getWebPVariant(
+	ctx context.Context,
+	path string,
+	content *apimodel.Content,
+) bool {
+	webpPath := media.WebPVariantPath(path)
+	if webpPath == "" {
+		return false
+	}
+
+	// If running on S3 storage with proxying disabled then
+	// just fetch pre-signed URL instead of the content, so
+	// long as the variant was actually stored.
+	if url := p.state.Storage.URL(ctx, webpPath); url != nil {
+		have, err := p.state.Storage.Has(ctx, webpPath)
+		if err != nil {
+			log.Errorf(ctx, "error checking storage for %s: %v", webpPath, err)
+			return false
+		}
+
+		if have {
+			content.URL = url
+		}
+
+		return have
+	}
+
+	// Variants are small, so just fetch into
+	// memory, which gives us the content length.
+	b, err := p.state.Storage.Get(ctx, webpPath)
+	if err != nil {
+		if !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error getting file %s from storage: %v", webpPath, err)
+		}
+		return false
+	}
+
+	content.ContentType = "image/webp"
+	content.ContentLength = int64(len(b))
+	content.Content = io.NopCloser(bytes.NewReader(b))
+	return true
+}
+
+// remoteContent returns API content that
+// forwards the request to the given remote URL.
+func remoteContent(remoteURL *url.URL) *apimodel.Content {
+	url := &storage.PresignedURL{
+		URL: remoteURL,
+
+		// We might manage to cache the media
+		// at some point, so set a low-ish expiry.
+		Expiry: time.Now().Add(2 * time.Hour),
+	}
+
+	return &apimodel.Content{URL: url}
+}
+
 func parseType(s string) (media.Type, error) {
 	switch s {
 	case string(media.TypeAttachment):
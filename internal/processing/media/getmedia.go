@@ -42,10 +42,31 @@ func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAtt
 		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
 	}
 
+	if attachment.Processing == gtsmodel.ProcessingStatusProcessed &&
+		attachment.Type == gtsmodel.FileTypeUnknown {
+		// Asynchronous processing finished, but failed.
+		const text = "media could not be processed"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	if attachment.Processing != gtsmodel.ProcessingStatusProcessed {
+		// Still processing,
+		// no files available yet.
+		unprocessedURLs(&a)
+	}
+
 	return &a, nil
 }
+
+// unprocessedURLs unsets the URLs of an API attachment
+// that is still processing, as its files don't exist yet.
+func unprocessedURLs(a *apimodel.Attachment) {
+	a.URL = nil
+	a.TextURL = nil
+	a.PreviewURL = nil
+}
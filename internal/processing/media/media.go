@@ -35,6 +35,9 @@ type Processor struct {
 	federator           *federation.Federator
 	mediaManager        *media.Manager
 	transportController transport.Controller
+
+	// in-progress chunked uploads
+	uploads *uploads
 }
 
 // New returns a new media processor.
@@ -53,5 +56,6 @@ func New(
 		federator:           federator,
 		mediaManager:        mediaManager,
 		transportController: transportController,
+		uploads:             &uploads{m: make(map[string]*upload)},
 	}
 }
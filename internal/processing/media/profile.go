@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 // DeleteAvatar deletes the account's avatar, if one exists, and returns the updated account.
@@ -34,6 +36,7 @@ func (p *Processor) DeleteAvatar(
 ) (*apimodel.Account, gtserror.WithCode) {
 	attachmentID := account.AvatarMediaAttachmentID
 	account.AvatarMediaAttachmentID = ""
+	account.AvatarMediaAttachment = nil
 	return p.deleteProfileAttachment(ctx, account, "avatar_media_attachment_id", attachmentID)
 }
 
@@ -45,11 +48,13 @@ func (p *Processor) DeleteHeader(
 ) (*apimodel.Account, gtserror.WithCode) {
 	attachmentID := account.HeaderMediaAttachmentID
 	account.HeaderMediaAttachmentID = ""
+	account.HeaderMediaAttachment = nil
 	return p.deleteProfileAttachment(ctx, account, "header_media_attachment_id", attachmentID)
 }
 
-// deleteProfileAttachment updates an attachment ID column and then deletes the attachment.
-// Precondition: the relevant attachment ID field of the account model has already been set to the empty string.
+// deleteProfileAttachment updates an attachment ID column, deletes the attachment,
+// and federates the account update to followers of the account.
+// Precondition: the relevant attachment fields of the account model have already been cleared.
 func (p *Processor) deleteProfileAttachment(
 	ctx context.Context,
 	account *gtsmodel.Account,
@@ -66,6 +71,14 @@ func (p *Processor) deleteProfileAttachment(
 		if err := p.Delete(ctx, attachmentID); err != nil {
 			return nil, err
 		}
+
+		// Federate the updated account.
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       account,
+			Origin:         account,
+		})
 	}
 
 	acctSensitive, err := p.converter.AccountToAPIAccountSensitive(ctx, account)
@@ -0,0 +1,382 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// how long an upload session may go without
+	// receiving data before it expires, and its
+	// partially uploaded data is removed.
+	uploadExpiry = 24 * time.Hour
+
+	// how often the upload expiry job runs.
+	uploadExpiryEvery = time.Hour
+
+	// max no. upload sessions an
+	// account may have in progress.
+	uploadMaxPerAccount = 5
+)
+
+// upload is an in-progress chunked media upload
+// session, storing received data in a temporary file.
+type upload struct {
+	id        string
+	accountID string
+	size      int64
+	info      media.AdditionalMediaInfo
+
+	// mu protects below fields, and is held
+	// for the duration of each chunk write.
+	mu      sync.Mutex
+	file    *os.File
+	offset  int64
+	expires time.Time
+}
+
+// remove closes and removes the upload's temporary
+// file, marking the upload as done. Expects mu held.
+func (u *upload) remove() {
+	if u.file == nil {
+		return
+	}
+	_ = u.file.Close()
+	_ = os.Remove(u.file.Name())
+	u.file = nil
+}
+
+// toAPI converts upload to its API model. Expects mu held.
+func (u *upload) toAPI() *apimodel.MediaUpload {
+	return &apimodel.MediaUpload{
+		ID:        u.id,
+		Size:      u.size,
+		Offset:    u.offset,
+		ExpiresAt: util.FormatISO8601(u.expires),
+	}
+}
+
+// uploads stores in-progress chunked
+// upload sessions, keyed by their ID.
+type uploads struct {
+	mu sync.Mutex
+	m  map[string]*upload
+}
+
+// tempFile wraps an upload's temporary
+// file so that it's removed on close.
+type tempFile struct{ *os.File }
+
+func (f tempFile) Close() error {
+	_ = f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+// UploadCreate starts a new chunked upload session for a media file of the given
+// size belonging to account. Data for the file should then be written in chunks
+// with UploadWrite(), and the upload completed with UploadFinish().
+func (p *Processor) UploadCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.MediaUploadRequest,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	if form.Size <= 0 {
+		const text = "size must be provided and greater than 0"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// A superficial check against the largest
+	// media size, as we don't yet know the type.
+	maxSize := max(
+		int64(config.GetMediaVideoMaxSize()),
+		int64(config.GetMediaImageMaxSize()),
+	)
+	if form.Size > maxSize {
+		err := fmt.Errorf("file size limit exceeded: limit is %d bytes but upload was %d bytes", maxSize, form.Size)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	p.uploads.mu.Lock()
+	defer p.uploads.mu.Unlock()
+
+	var count int
+	for _, u := range p.uploads.m {
+		if u.accountID == account.ID {
+			count++
+		}
+	}
+
+	if count >= uploadMaxPerAccount {
+		err := fmt.Errorf("too many uploads in progress: limit is %d", uploadMaxPerAccount)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	file, err := os.CreateTemp(os.TempDir(), "gotosocial-upload-")
+	if err != nil {
+		err := gtserror.Newf("error creating upload file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	u := &upload{
+		id:        id.NewULID(),
+		accountID: account.ID,
+		size:      form.Size,
+		info: media.AdditionalMediaInfo{
+			Description: &form.Description,
+			FocusX:      &focusX,
+			FocusY:      &focusY,
+		},
+		file:    file,
+		expires: time.Now().Add(uploadExpiry),
+	}
+	p.uploads.m[u.id] = u
+
+	return u.toAPI(), nil
+}
+
+// UploadGet returns the current state of the given chunked upload session
+// belonging to account, allowing a client to resume an interrupted upload.
+func (p *Processor) UploadGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	u, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.file == nil {
+		return nil, uploadNotFound(uploadID)
+	}
+
+	return u.toAPI(), nil
+}
+
+// UploadWrite writes a chunk of data read from r to the given chunked upload session
+// belonging to account, at offset. The offset must match that of data received so far.
+// If reading from r fails part way, the data received so far is kept so the client can
+// resume from the returned offset.
+func (p *Processor) UploadWrite(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+	offset int64,
+	r io.Reader,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	u, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.file == nil {
+		return nil, uploadNotFound(uploadID)
+	}
+
+	if offset != u.offset {
+		err := fmt.Errorf("offset %d does not match upload offset %d", offset, u.offset)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// Write no more than remaining size of file.
+	remaining := u.size - u.offset
+	w := io.NewOffsetWriter(u.file, u.offset)
+	n, err := io.Copy(w, io.LimitReader(r, remaining))
+
+	if err == nil && n == remaining {
+		// Check for any data beyond the expected size, in which
+		// case discard the chunk, as it's not what was intended.
+		if m, _ := r.Read(make([]byte, 1)); m > 0 {
+			_ = u.file.Truncate(offset)
+			err := fmt.Errorf("upload chunk exceeds remaining size of %d bytes", remaining)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	// Keep whatever made it, and
+	// push back expiry on activity.
+	u.offset += n
+	u.expires = time.Now().Add(uploadExpiry)
+
+	if err != nil {
+		err := gtserror.Newf("error writing upload chunk: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, "error reading upload chunk")
+	}
+
+	return u.toAPI(), nil
+}
+
+// UploadFinish completes the given chunked upload session belonging to account,
+// once all data has been received, creating a new media attachment from the
+// uploaded file. As with CreateAsync(), the media is processed asynchronously.
+func (p *Processor) UploadFinish(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) (*apimodel.Attachment, gtserror.WithCode) {
+	u, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.file == nil {
+		return nil, uploadNotFound(uploadID)
+	}
+
+	if u.offset != u.size {
+		err := fmt.Errorf("upload incomplete: received %d of %d bytes", u.offset, u.size)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Hand over the file to media processing,
+	// which will remove it once it's finished.
+	tmp := tempFile{u.file}
+	u.file = nil
+	p.deleteUpload(u.id)
+
+	return p.createAsync(ctx, account, tmp, u.size, u.info)
+}
+
+// UploadDelete cancels the given chunked upload session
+// belonging to account, removing any data received so far.
+func (p *Processor) UploadDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) gtserror.WithCode {
+	u, errWithCode := p.getUpload(account, uploadID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	u.mu.Lock()
+	u.remove()
+	u.mu.Unlock()
+
+	p.deleteUpload(u.id)
+	return nil
+}
+
+// ScheduleUploadExpiry schedules a recurring job
+// to remove chunked upload sessions that expired.
+func (p *Processor) ScheduleUploadExpiry() error {
+	fn := func(ctx context.Context, now time.Time) {
+		if n := p.ExpireUploads(now); n > 0 {
+			log.Infof(ctx, "expired %d media uploads", n)
+		}
+	}
+
+	// Upload sessions are kept in memory, so
+	// this runs on every instance process.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@mediauploadexpiry",
+		time.Time{},
+		uploadExpiryEvery,
+		fn,
+	) {
+		return gtserror.New("failed to schedule @mediauploadexpiry")
+	}
+
+	return nil
+}
+
+// ExpireUploads removes all chunked upload sessions
+// that expired as of now, returning no. removed.
+func (p *Processor) ExpireUploads(now time.Time) int {
+	p.uploads.mu.Lock()
+	defer p.uploads.mu.Unlock()
+
+	var n int
+	for uploadID, u := range p.uploads.m {
+		// Don't block on uploads mid-write,
+		// they're evidently not expired.
+		if !u.mu.TryLock() {
+			continue
+		}
+
+		if now.After(u.expires) {
+			u.remove()
+			delete(p.uploads.m, uploadID)
+			n++
+		}
+
+		u.mu.Unlock()
+	}
+
+	return n
+}
+
+// getUpload fetches the upload with
+// ID if it belongs to given account.
+func (p *Processor) getUpload(
+	account *gtsmodel.Account,
+	uploadID string,
+) (*upload, gtserror.WithCode) {
+	p.uploads.mu.Lock()
+	u, ok := p.uploads.m[uploadID]
+	p.uploads.mu.Unlock()
+
+	if !ok || u.accountID != account.ID {
+		return nil, uploadNotFound(uploadID)
+	}
+
+	return u, nil
+}
+
+// deleteUpload drops the upload with ID from the map.
+func (p *Processor) deleteUpload(uploadID string) {
+	p.uploads.mu.Lock()
+	delete(p.uploads.m, uploadID)
+	p.uploads.mu.Unlock()
+}
+
+func uploadNotFound(uploadID string) gtserror.WithCode {
+	err := fmt.Errorf("upload %s not found", uploadID)
+	return gtserror.NewErrorNotFound(err)
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type UploadTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *UploadTestSuite) TestChunkedUpload() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	b, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, account, &apimodel.MediaUploadRequest{
+		Size:        int64(len(b)),
+		Description: "a chunked upload",
+	})
+	suite.NoError(errWithCode)
+	suite.EqualValues(len(b), upload.Size)
+	suite.Zero(upload.Offset)
+
+	// Write the first half of the file.
+	half := len(b) / 2
+	upload, errWithCode = suite.mediaProcessor.UploadWrite(ctx, account, upload.ID, 0, bytes.NewReader(b[:half]))
+	suite.NoError(errWithCode)
+	suite.EqualValues(half, upload.Offset)
+
+	// Finishing now should fail, as upload is incomplete.
+	_, errWithCode = suite.mediaProcessor.UploadFinish(ctx, account, upload.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Writing at the wrong offset should conflict.
+	_, errWithCode = suite.mediaProcessor.UploadWrite(ctx, account, upload.ID, 0, bytes.NewReader(b[half:]))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Another account should not see the upload.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, suite.testAccounts["local_account_2"], upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Resume from where we left off.
+	upload, errWithCode = suite.mediaProcessor.UploadGet(ctx, account, upload.ID)
+	suite.NoError(errWithCode)
+	suite.EqualValues(half, upload.Offset)
+
+	upload, errWithCode = suite.mediaProcessor.UploadWrite(ctx, account, upload.ID, upload.Offset, bytes.NewReader(b[half:]))
+	suite.NoError(errWithCode)
+	suite.EqualValues(len(b), upload.Offset)
+
+	attachment, errWithCode := suite.mediaProcessor.UploadFinish(ctx, account, upload.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("a chunked upload", *attachment.Description)
+	suite.Nil(attachment.URL)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(account.ID, dbAttachment.AccountID)
+	suite.Equal(gtsmodel.ProcessingStatusProcessing, dbAttachment.Processing)
+
+	// Upload session should now be gone.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, account, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *UploadTestSuite) TestChunkedUploadTooLarge() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, account, &apimodel.MediaUploadRequest{
+		Size: 4,
+	})
+	suite.NoError(errWithCode)
+
+	// Writing more than the declared size should be refused.
+	_, errWithCode = suite.mediaProcessor.UploadWrite(ctx, account, upload.ID, 0, bytes.NewReader([]byte("hello")))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	upload, errWithCode = suite.mediaProcessor.UploadGet(ctx, account, upload.ID)
+	suite.NoError(errWithCode)
+	suite.Zero(upload.Offset)
+
+	suite.NoError(suite.mediaProcessor.UploadDelete(ctx, account, upload.ID))
+}
+
+func (suite *UploadTestSuite) TestChunkedUploadExpiry() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, account, &apimodel.MediaUploadRequest{
+		Size: 1024,
+	})
+	suite.NoError(errWithCode)
+
+	// Nothing expired yet.
+	suite.Zero(suite.mediaProcessor.ExpireUploads(time.Now()))
+
+	// Now a couple of days later.
+	suite.Equal(1, suite.mediaProcessor.ExpireUploads(time.Now().Add(48*time.Hour)))
+
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, account, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, &UploadTestSuite{})
+}
@@ -54,7 +54,10 @@ func (p *Processor) ScheduleExpiry(ctx context.Context, poll *gtsmodel.Poll) err
 		return gtserror.Newf("poll %s already expired", poll.ID)
 	}
 
-	// Add the given poll to the scheduler.
+	// Add the given poll to the scheduler. This is not
+	// an exclusive task: every process sharing the db
+	// schedules it, and closing it is atomic, so only
+	// the first to close the poll will federate this.
 	ok := p.state.Workers.Scheduler.AddOnce(
 		poll.ID,
 		poll.ExpiresAt,
@@ -84,7 +87,7 @@ func (p *Processor) onExpiry(pollID string) func(context.Context, time.Time) {
 
 		if !poll.ClosedAt.IsZero() {
 			// Expiry handler has already been run for this poll.
-			log.Errorf(ctx, "poll %s already closed", pollID)
+			log.Debugf(ctx, "poll %s already closed", pollID)
 			return
 		}
 
@@ -108,9 +111,17 @@ func (p *Processor) onExpiry(pollID string) func(context.Context, time.Time) {
 		poll.ClosedAt = now
 		poll.Closing = true
 
-		// Update the Poll to mark it as closed in the database.
-		if err := p.state.DB.UpdatePoll(ctx, poll, "closed_at"); err != nil {
-			log.Errorf(ctx, "error updating poll %s in db: %v", pollID, err)
+		// Mark the Poll as closed in the database.
+		closed, err := p.state.DB.ClosePoll(ctx, poll)
+		if err != nil {
+			log.Errorf(ctx, "error closing poll %s in db: %v", pollID, err)
+			return
+		}
+
+		if !closed {
+			// Another process sharing the
+			// db got here first, leave it.
+			log.Debugf(ctx, "poll %s already closed", pollID)
 			return
 		}
 
@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -208,6 +209,41 @@ func (suite *PollTestSuite) testPollVote(ctx context.Context, requester *gtsmode
 	}
 }
 
+func (suite *PollTestSuite) TestPollVoteChange() {
+	ctx, cncl := context.WithCancel(context.Background())
+	defer cncl()
+
+	// Populate the database with test models.
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	requester := testrig.NewTestAccounts()["local_account_2"]
+	poll := testrig.NewTestPolls()["local_account_1_status_6_poll"]
+
+	// Without vote changes allowed, the
+	// requester's existing vote should
+	// prevent them voting again.
+	_, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{1})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Allow vote changes in this poll.
+	poll.VoteChange = util.Ptr(true)
+	if err := suite.state.DB.UpdatePoll(ctx, poll, "vote_change"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Change the requester's vote from 0 to 1.
+	apiPoll, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{1})
+	suite.NoError(errWithCode)
+	suite.Equal([]int{1}, *apiPoll.OwnVotes)
+
+	// The stored poll counts should reflect
+	// the changed vote, with the same voters.
+	latest, err := suite.state.DB.GetPollByID(ctx, poll.ID)
+	suite.NoError(err)
+	suite.Equal([]int{1, 1, 0}, latest.Votes)
+	suite.Equal(2, *latest.Voters)
+}
+
 // voteChoicesAreValid is a utility function to check whether choices are valid for poll.
 func voteChoicesAreValid(poll *gtsmodel.Poll, choices []int) bool {
 	if len(choices) == 0 || !*poll.Multiple && len(choices) > 1 {
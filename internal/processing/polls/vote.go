@@ -24,10 +24,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
@@ -72,8 +74,36 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 		Poll:      poll,
 	}
 
-	// Insert the new poll votes into the database.
-	err := p.state.DB.PutPollVote(ctx, vote)
+	// Previous vote choices
+	// (if any) being replaced.
+	var prevChoices []int
+
+	var err error
+
+	if util.PtrValueOr(poll.VoteChange, false) {
+		// This poll allows changing votes, look
+		// for any existing vote by the requester.
+		prev, perr := p.state.DB.GetPollVoteBy(
+			gtscontext.SetBarebones(ctx),
+			pollID,
+			requester.ID,
+		)
+		if perr != nil && !errors.Is(perr, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting existing poll vote: %w", perr)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if prev != nil {
+			prevChoices = prev.Choices
+		}
+
+		// Replace any existing vote with this new one.
+		err = p.state.DB.ReplacePollVote(ctx, vote)
+	} else {
+		// Insert the new poll votes into the database.
+		err = p.state.DB.PutPollVote(ctx, vote)
+	}
+
 	switch {
 
 	case err == nil:
@@ -90,9 +120,11 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// Before enqueuing it, increment the poll
+	// Before enqueuing it, update the poll
 	// vote counts on the copy attached to the
-	// PollVote (that we also later return).
+	// PollVote (that we also later return),
+	// dropping any replaced vote's choices.
+	poll.DecrementVotes(prevChoices)
 	poll.IncrementVotes(choices)
 
 	// Enqueue worker task to handle side-effects of user poll vote(s).
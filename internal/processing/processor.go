@@ -27,10 +27,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/announcements"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
 	filtersv1 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v1"
 	filtersv2 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/invite"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
@@ -70,22 +72,24 @@ type Processor struct {
 		SUB-PROCESSORS
 	*/
 
-	account   account.Processor
-	admin     admin.Processor
-	fedi      fedi.Processor
-	filtersv1 filtersv1.Processor
-	filtersv2 filtersv2.Processor
-	list      list.Processor
-	markers   markers.Processor
-	media     media.Processor
-	polls     polls.Processor
-	report    report.Processor
-	search    search.Processor
-	status    status.Processor
-	stream    stream.Processor
-	timeline  timeline.Processor
-	user      user.Processor
-	workers   workers.Processor
+	account       account.Processor
+	admin         admin.Processor
+	announcements announcements.Processor
+	fedi          fedi.Processor
+	filtersv1     filtersv1.Processor
+	filtersv2     filtersv2.Processor
+	invite        invite.Processor
+	list          list.Processor
+	markers       markers.Processor
+	media         media.Processor
+	polls         polls.Processor
+	report        report.Processor
+	search        search.Processor
+	status        status.Processor
+	stream        stream.Processor
+	timeline      timeline.Processor
+	user          user.Processor
+	workers       workers.Processor
 }
 
 func (p *Processor) Account() *account.Processor {
@@ -96,6 +100,10 @@ func (p *Processor) Admin() *admin.Processor {
 	return &p.admin
 }
 
+func (p *Processor) Announcements() *announcements.Processor {
+	return &p.announcements
+}
+
 func (p *Processor) Fedi() *fedi.Processor {
 	return &p.fedi
 }
@@ -108,6 +116,10 @@ func (p *Processor) FiltersV2() *filtersv2.Processor {
 	return &p.filtersv2
 }
 
+func (p *Processor) Invite() *invite.Processor {
+	return &p.invite
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -188,14 +200,16 @@ func NewProcessor(
 	// processors + pin them to this struct.
 	processor.account = account.New(&common, state, converter, mediaManager, federator, filter, parseMentionFunc)
 	processor.admin = admin.New(&common, state, cleaner, federator, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.announcements = announcements.New(state, converter, processor.formatter, parseMentionFunc, &processor.stream)
 	processor.fedi = fedi.New(state, &common, converter, federator, filter)
 	processor.filtersv1 = filtersv1.New(state, converter, &processor.stream)
-	processor.filtersv2 = filtersv2.New(state, converter, &processor.stream)
+	processor.filtersv2 = filtersv2.New(state, converter, &processor.stream, federator.TransportController())
+	processor.invite = invite.New(state, converter)
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
 	processor.report = report.New(state, converter)
-	processor.timeline = timeline.New(state, converter, filter)
+	processor.timeline = timeline.New(state, converter, federator, filter)
 	processor.search = search.New(state, federator, converter, filter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, filter, parseMentionFunc)
 	processor.user = user.New(state, converter, oauthServer, emailSender)
@@ -85,19 +85,6 @@ func (p *Processor) Accounts(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// if caller supplied an offset greater than 0, return
-	// nothing as though there were no additional results.
-	if offset > 0 {
-		return p.packageAccounts(
-			ctx,
-			requestingAccount,
-			foundAccounts,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	// See if we have something that looks like a namestring.
 	username, domain, err := util.ExtractNamestringParts(query)
 	if err == nil {
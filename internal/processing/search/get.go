@@ -119,21 +119,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,6 +206,20 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		if offset > 0 {
+			// A URI search only ever has one
+			// result at most, which was on
+			// the first page, so return none.
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -375,6 +374,12 @@ func (p *Processor) accountsByUsernameDomain(
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
+	if offset > 0 {
+		// Only ever one exact match at most,
+		// which was on the first page.
+		return nil
+	}
+
 	foundAccount, err := p.accountByUsernameDomain(
 		ctx,
 		requestingAccount,
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -90,6 +91,7 @@ func (p *Processor) Create(
 			ID:         id.NewULID(),
 			Multiple:   &form.Poll.Multiple,
 			HideCounts: &form.Poll.HideTotals,
+			VoteChange: &form.Poll.VoteChange,
 			Options:    form.Poll.Options,
 			StatusID:   statusID,
 			Status:     status,
@@ -117,7 +119,7 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
-	if err := processVisibility(form, requester.Settings.Privacy, status); err != nil {
+	if err := processVisibility(form, requester.Settings, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -272,6 +274,11 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
+		if attachment.Processing != gtsmodel.ProcessingStatusProcessed {
+			text := fmt.Sprintf("media %s has not finished processing", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
 		if length := len([]rune(attachment.Description)); length < minChars {
 			text := fmt.Sprintf("media %s description too short, at least %d required", mediaID, minChars)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -286,12 +293,15 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
-func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error {
-	// by default all flags are set to true
+func processVisibility(form *apimodel.AdvancedStatusCreateForm, settings *gtsmodel.AccountSettings, status *gtsmodel.Status) error {
+	// by default all flags are set to true,
+	// other than where the account has set
+	// its own defaults for these flags.
+	accountDefaultVis := settings.Privacy
 	federated := true
-	boostable := true
-	replyable := true
-	likeable := true
+	boostable := util.PtrValueOr(settings.Boostable, true)
+	replyable := util.PtrValueOr(settings.Replyable, true)
+	likeable := util.PtrValueOr(settings.Likeable, true)
 
 	// If visibility isn't set on the form, then just take the account default.
 	// If that's also not set, take the default for the whole instance.
@@ -308,7 +318,9 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	switch vis {
 	case gtsmodel.VisibilityPublic:
 		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
-		break
+		boostable = true
+		replyable = true
+		likeable = true
 	case gtsmodel.VisibilityUnlocked:
 		// for unlocked the user can set any combination of flags they like so look at them all to see if they're set and then apply them
 		if form.Federated != nil {
@@ -360,10 +372,15 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 }
 
 func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
+	switch {
+	case form.Language != "":
 		status.Language = form.Language
-	} else {
+	case accountDefaultLanguage != "":
 		status.Language = accountDefaultLanguage
+	default:
+		// Neither given, last resort is
+		// to detect it from status text.
+		status.Language = language.Detect(form.Status)
 	}
 	if status.Language == "" {
 		return errors.New("no language given either in status create form or account default")
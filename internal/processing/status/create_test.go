@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusCreateTestSuite struct {
@@ -242,6 +243,60 @@ func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	suite.Equal("zh-Hans", *apiStatus.Language)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessLanguageAccountDefault() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// No language given, so the account default
+	// ("en") should win over anything detected.
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "안녕하세요 세계", // hello world
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Equal("en", *apiStatus.Language)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessLanguageDetected() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Unset the account default language.
+	defaultLang := creatingAccount.Settings.Language
+	creatingAccount.Settings.Language = ""
+	defer func() {
+		creatingAccount.Settings.Language = defaultLang
+	}()
+
+	// No language given and no account
+	// default, so it should be detected
+	// from the status text.
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "안녕하세요 세계", // hello world
+			Visibility:  apimodel.VisibilityPublic,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Equal("ko", *apiStatus.Language)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	ctx := context.Background()
 
@@ -286,6 +341,62 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessAccountDefaultInteractionFlags() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Set account-level defaults for interactions.
+	creatingAccount.Settings.Replyable = util.Ptr(false)
+	creatingAccount.Settings.Likeable = util.Ptr(false)
+	defer func() {
+		creatingAccount.Settings.Replyable = util.Ptr(true)
+		creatingAccount.Settings.Likeable = util.Ptr(true)
+	}()
+
+	statusCreateForm := func(vis apimodel.Visibility, likeable *bool) *apimodel.AdvancedStatusCreateForm {
+		return &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "hello world",
+				Visibility:  vis,
+				Language:    "en",
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+			AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+				Likeable: likeable,
+			},
+		}
+	}
+
+	// Unlisted status with no flags set
+	// should take the account defaults,
+	// except where set in the form.
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm(apimodel.VisibilityUnlisted, util.Ptr(true)))
+	suite.NoError(err)
+
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.True(*dbStatus.Boostable)
+	suite.False(*dbStatus.Replyable)
+	suite.True(*dbStatus.Likeable)
+
+	// Public status should always
+	// be boostable, replyable, likeable.
+	apiStatus, err = suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm(apimodel.VisibilityPublic, nil))
+	suite.NoError(err)
+
+	dbStatus, dbErr = suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.True(*dbStatus.Boostable)
+	suite.True(*dbStatus.Replyable)
+	suite.True(*dbStatus.Likeable)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -30,7 +30,10 @@ import (
 )
 
 // Delete processes the delete of a given status, returning the deleted status if the delete goes through.
-func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+//
+// If deleteMedia is false, attachments of the status are unattached rather than deleted, so that they
+// can be immediately reused by the requesting account in a new status, e.g. for delete + redraft.
+func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, deleteMedia bool) (*apimodel.Status, gtserror.WithCode) {
 	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
@@ -50,6 +53,27 @@ func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 		return nil, errWithCode
 	}
 
+	if !deleteMedia && len(targetStatus.AttachmentIDs) > 0 {
+		// Unattach media from the status now, rather than
+		// in the worker, so it's immediately available for
+		// reuse and doesn't get deleted along with status.
+		for _, attachment := range targetStatus.Attachments {
+			attachment.StatusID = ""
+			if err := p.state.DB.UpdateAttachment(ctx, attachment, "status_id"); err != nil {
+				err := gtserror.Newf("db error unattaching media %s: %w", attachment.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		// Pass on a copy of the status
+		// with no attachments to worker.
+		status := new(gtsmodel.Status)
+		*status = *targetStatus
+		status.AttachmentIDs = nil
+		status.Attachments = nil
+		targetStatus = status
+	}
+
 	// Process delete side effects.
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
 		APObjectType:   ap.ObjectNote,
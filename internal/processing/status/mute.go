@@ -35,7 +35,6 @@ import (
 //   - Status exists and is visible to requester.
 //   - Status belongs to or mentions requesting account.
 //   - Status is not a boost.
-//   - Status has a thread ID.
 func (p *Processor) getMuteableStatus(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -61,12 +60,30 @@ func (p *Processor) getMuteableStatus(
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	if targetStatus.ThreadID == "" {
-		err := gtserror.New("cannot mute or unmute status with no threadID")
-		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	return targetStatus, nil
+}
+
+// threadStatus creates a new thread starting
+// from the given status, so that it can be
+// muted, and any later replies that inherit
+// the thread ID get muted along with it.
+func (p *Processor) threadStatus(ctx context.Context, status *gtsmodel.Status) error {
+	threadID := id.NewULID()
+	if err := p.state.DB.PutThread(
+		ctx,
+		&gtsmodel.Thread{
+			ID: threadID,
+		},
+	); err != nil {
+		return gtserror.Newf("error inserting new thread in db: %w", err)
 	}
 
-	return targetStatus, nil
+	status.ThreadID = threadID
+	if err := p.state.DB.UpdateStatus(ctx, status, "thread_id"); err != nil {
+		return gtserror.Newf("error updating status thread id in db: %w", err)
+	}
+
+	return nil
 }
 
 func (p *Processor) MuteCreate(
@@ -79,6 +96,15 @@ func (p *Processor) MuteCreate(
 		return nil, errWithCode
 	}
 
+	if targetStatus.ThreadID == "" {
+		// Status isn't threaded yet (eg., a
+		// remote status that was deref'd before
+		// threading), so create a thread for it.
+		if err := p.threadStatus(ctx, targetStatus); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	var (
 		threadID  = targetStatus.ThreadID
 		accountID = requestingAccount.ID
@@ -108,6 +134,13 @@ func (p *Processor) MuteCreate(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Update the muted flag of
+	// any timelined status.
+	if err := p.c.InvalidateTimelinedStatus(ctx, accountID, targetStatus.ID); err != nil {
+		err = gtserror.Newf("error invalidating status from timelines: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
@@ -121,6 +154,12 @@ func (p *Processor) MuteRemove(
 		return nil, errWithCode
 	}
 
+	if targetStatus.ThreadID == "" {
+		// Status isn't threaded,
+		// so it can't be muted.
+		return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
+	}
+
 	var (
 		threadID  = targetStatus.ThreadID
 		accountID = requestingAccount.ID
@@ -146,5 +185,12 @@ func (p *Processor) MuteRemove(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Update the muted flag of
+	// any timelined status.
+	if err := p.c.InvalidateTimelinedStatus(ctx, accountID, targetStatus.ID); err != nil {
+		err = gtserror.Newf("error invalidating status from timelines: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
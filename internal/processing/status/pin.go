@@ -23,9 +23,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 const allowedPinnedCount = 10
@@ -111,6 +113,14 @@ func (p *Processor) PinCreate(ctx context.Context, requestingAccount *gtsmodel.A
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Process pin side effects in the client API worker.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APActivityType: ap.ActivityAdd,
+		APObjectType:   ap.ObjectNote,
+		GTSModel:       targetStatus,
+		Origin:         requestingAccount,
+	})
+
 	// Update account stats.
 	*requestingAccount.Stats.StatusesPinnedCount++
 	if err := p.state.DB.UpdateAccountStats(
@@ -170,6 +180,14 @@ func (p *Processor) PinRemove(ctx context.Context, requestingAccount *gtsmodel.A
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Process unpin side effects in the client API worker.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APActivityType: ap.ActivityRemove,
+		APObjectType:   ap.ObjectNote,
+		GTSModel:       targetStatus,
+		Origin:         requestingAccount,
+	})
+
 	// Update account stats.
 	//
 	// Clamp to 0 to avoid funny business.
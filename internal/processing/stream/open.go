@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"errors"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -34,5 +35,39 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 		{"streamType", streamType},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	if streamType == "" {
+		// No initial stream type was given,
+		// client may instead subscribe to
+		// types over the open connection.
+		return p.open(account, "")
+	}
+
+	streamType, errWithCode := p.checkStreamType(ctx, account, streamType)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.open(account, streamType)
+}
+
+// open opens a new stream for account, subscribed
+// to streamType if set, returning 429 if the stream
+// limits for the account or instance have been hit.
+func (p *Processor) open(account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+	var streamTypes []string
+	if streamType != "" {
+		streamTypes = []string{streamType}
+	}
+
+	str, err := p.streams.Open(account.ID, streamTypes...)
+	if errors.Is(err, stream.ErrTooManyStreams) {
+		const text = "too many open streaming connections, close some and try again"
+		return nil, gtserror.NewErrorTooManyRequests(err, text)
+	} else if err != nil {
+		err := gtserror.Newf("error opening stream: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return str, nil
 }
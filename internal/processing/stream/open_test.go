@@ -19,9 +19,15 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	gtsstream "github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 type OpenStreamTestSuite struct {
@@ -35,6 +41,80 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenStreamTooMany() {
+	config.SetAdvancedStreamingMaxPerAccount(2)
+	config.SetAdvancedStreamingMaxTotal(3)
+	processor := stream.New(&suite.state, suite.oauthServer)
+
+	var (
+		ctx      = context.Background()
+		account1 = suite.testAccounts["local_account_1"]
+		account2 = suite.testAccounts["local_account_2"]
+	)
+
+	// Open up to the per-account limit.
+	str1, errWithCode := processor.Open(ctx, account1, "user")
+	suite.NoError(errWithCode)
+	_, errWithCode = processor.Open(ctx, account1, "user")
+	suite.NoError(errWithCode)
+
+	// Account is now at its limit.
+	_, errWithCode = processor.Open(ctx, account1, "user")
+	if suite.Error(errWithCode) {
+		suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+	}
+
+	// Another account may open one
+	// more, bringing instance to limit.
+	_, errWithCode = processor.Open(ctx, account2, "user")
+	suite.NoError(errWithCode)
+	_, errWithCode = processor.Open(ctx, account2, "user")
+	if suite.Error(errWithCode) {
+		suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+	}
+
+	// Closing a stream frees up a slot.
+	str1.Close()
+	_, errWithCode = processor.Open(ctx, account2, "user")
+	suite.NoError(errWithCode)
+}
+
+func (suite *OpenStreamTestSuite) TestOpenStreamSlowClient() {
+	config.SetAdvancedStreamingBufferSize(5)
+	processor := stream.New(&suite.state, suite.oauthServer)
+
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	str, errWithCode := processor.Open(ctx, account, gtsstream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Send more messages than the buffer
+	// holds, without ever reading them.
+	for i := 0; i < 10; i++ {
+		processor.Update(ctx, account, &apimodel.Status{ID: "01J1SE5MC8D4SJPZAS5TKQQGNZ"}, gtsstream.TimelineHome)
+	}
+
+	ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+
+	// Stream should have been closed, so at most
+	// the buffered messages can still be received.
+	var recvd int
+	for {
+		if _, ok := str.Recv(ctx); !ok {
+			break
+		}
+		recvd++
+	}
+	suite.LessOrEqual(recvd, 5)
+	suite.NoError(ctx.Err())
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
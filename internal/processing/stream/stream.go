@@ -18,6 +18,9 @@
 package stream
 
 import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -27,12 +30,50 @@ type Processor struct {
 	state       *state.State
 	oauthServer oauth.Server
 	streams     stream.Streams
+	stop        func()
 }
 
 func New(state *state.State, oauthServer oauth.Server) Processor {
 	return Processor{
 		state:       state,
 		oauthServer: oauthServer,
-		streams:     stream.Streams{},
+		streams: stream.Streams{
+			MaxPerAccount: config.GetAdvancedStreamingMaxPerAccount(),
+			MaxTotal:      config.GetAdvancedStreamingMaxTotal(),
+			BufferSize:    config.GetAdvancedStreamingBufferSize(),
+		},
+	}
+}
+
+// Start starts fanning out stream messages between instance
+// replicas via given bus, e.g. a Redis pub/sub channel, until
+// Stop() is called. The bus handles any reconnecting itself.
+//
+// NOTE: must be called before any streams are in use.
+func (p *Processor) Start(bus stream.Bus) {
+	// Set bus before listening,
+	// such that all messages now
+	// are published to the bus.
+	p.streams.Bus = bus
+
+	ctx, cncl := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		p.streams.Listen(ctx)
+	}()
+
+	p.stop = func() {
+		cncl()
+		<-done
+	}
+}
+
+// Stop stops fanning out stream
+// messages, if started by Start().
+func (p *Processor) Stop() {
+	if p.stop != nil {
+		p.stop()
 	}
 }
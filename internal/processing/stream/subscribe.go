@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// streamTypes contains stream types
+// that don't take any parameter, eg.,
+// a list ID, and that may be streamed
+// by any account.
+var streamTypes = []string{
+	stream.TimelineHome,
+	stream.TimelineNotifications,
+	stream.TimelinePublic,
+	stream.TimelineLocal,
+	stream.TimelineDirect,
+}
+
+// Subscribe adds given stream type to the types that given open
+// stream belonging to account receives messages for. This allows
+// a client to multiplex many streams over one connection. An error
+// is returned if stream type is unknown, or not permitted for account.
+func (p *Processor) Subscribe(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	str *stream.Stream,
+	streamType string,
+) gtserror.WithCode {
+	streamType, errWithCode := p.checkStreamType(ctx, account, streamType)
+	if errWithCode != nil {
+		return errWithCode
+	}
+	str.Subscribe(streamType)
+	return nil
+}
+
+// Unsubscribe removes given stream type from the types
+// that given open stream receives messages for, if found.
+func (p *Processor) Unsubscribe(str *stream.Stream, streamType string) {
+	if tagType, tagName, ok := cutHashtagStreamType(streamType); ok {
+		// Normalize hashtag name, to match
+		// the normalized subscribed type.
+		tagName, _ = normalizeHashtag(tagName)
+		streamType = tagType + ":" + tagName
+	}
+	str.Unsubscribe(streamType)
+}
+
+// AccountIDs returns the IDs of all accounts with open
+// streams subscribed to any of the given stream types.
+func (p *Processor) AccountIDs(streamTypes ...string) []string {
+	return p.streams.AccountIDs(streamTypes...)
+}
+
+// checkStreamType checks that given stream type is known, and that
+// account is permitted to stream it, returning the stream type in
+// the form it should be subscribed with (i.e. normalized hashtags).
+func (p *Processor) checkStreamType(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	streamType string,
+) (string, gtserror.WithCode) {
+	if slices.Contains(streamTypes, streamType) {
+		return streamType, nil
+	}
+
+	// Hashtag streams are in the form 'hashtag:{tagName}'
+	// or 'hashtag:local:{tagName}', and are public.
+	if tagType, tagName, ok := cutHashtagStreamType(streamType); ok {
+		tagName, ok := normalizeHashtag(tagName)
+		if !ok {
+			const text = "invalid hashtag name"
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		return tagType + ":" + tagName, nil
+	}
+
+	// List streams are in the form 'list:{listID}',
+	// and may only be streamed by the list owner.
+	if listID, ok := strings.CutPrefix(streamType, stream.TimelineList+":"); ok {
+		list, err := p.state.DB.GetListByID(gtscontext.SetBarebones(ctx), listID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting list %s: %w", listID, err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if list == nil || list.AccountID != account.ID {
+			const text = "list not found"
+			return "", gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+
+		return streamType, nil
+	}
+
+	text := fmt.Sprintf("unknown stream type: %s", streamType)
+	return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+}
+
+// cutHashtagStreamType splits given stream type into its hashtag
+// stream type and hashtag name, returning false if not a hashtag
+// stream type. Note that 'hashtag:local' on its own is taken to
+// be a (non-local) hashtag stream for the hashtag #local.
+func cutHashtagStreamType(streamType string) (string, string, bool) {
+	if tagName, ok := strings.CutPrefix(streamType, stream.TimelineHashtagLocal+":"); ok {
+		return stream.TimelineHashtagLocal, tagName, true
+	}
+	if tagName, ok := strings.CutPrefix(streamType, stream.TimelineHashtag+":"); ok {
+		return stream.TimelineHashtag, tagName, true
+	}
+	return "", "", false
+}
+
+// normalizeHashtag normalizes and validates given hashtag
+// name, lowercasing it to match the stored tag name.
+func normalizeHashtag(tagName string) (string, bool) {
+	tagName, ok := text.NormalizeHashtag(tagName)
+	return strings.ToLower(tagName), ok
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SubscribeTestSuite struct {
+	StreamTestSuite
+}
+
+func (suite *SubscribeTestSuite) TestSubscribe() {
+	var (
+		ctx      = context.Background()
+		account  = suite.testAccounts["local_account_1"]
+		list     = testrig.NewTestLists()["local_account_1_list_1"]
+		listType = stream.TimelineList + ":" + list.ID
+	)
+
+	// Open a stream without any initial stream type.
+	str, errWithCode := suite.streamProcessor.Open(ctx, account, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer str.Close()
+
+	// Subscribe to home and list streams.
+	suite.NoError(suite.streamProcessor.Subscribe(ctx, account, str, stream.TimelineHome))
+	suite.NoError(suite.streamProcessor.Subscribe(ctx, account, str, listType))
+
+	// Subscribing to unknown stream
+	// type should return bad request.
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, str, "public:nonsense")
+	if suite.Error(errWithCode) {
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+
+	// Stream an update to each of the
+	// multiplexed subscriptions in turn.
+	for _, streamType := range []string{
+		stream.TimelineHome,
+		listType,
+	} {
+		suite.streamProcessor.Update(ctx, account, &apimodel.Status{ID: "01J1SE5MC8D4SJPZAS5TKQQGNZ"}, streamType)
+
+		ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+		msg, ok := str.Recv(ctx)
+		cncl()
+
+		if !ok {
+			suite.FailNow("expected a message but message was not received")
+		}
+		suite.Equal([]string{streamType}, msg.Stream)
+		suite.Equal(stream.EventTypeUpdate, msg.Event)
+	}
+
+	// Unsubscribe from list, updates
+	// to it should no longer arrive.
+	suite.streamProcessor.Unsubscribe(str, listType)
+	suite.streamProcessor.Update(ctx, account, &apimodel.Status{ID: "01J1SE5MC8D4SJPZAS5TKQQGNZ"}, listType)
+
+	ctx, cncl := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cncl()
+
+	_, ok := str.Recv(ctx)
+	suite.False(ok)
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeOtherAccountList() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+		list    = testrig.NewTestLists()["local_account_1_list_1"]
+	)
+
+	// Local account 2 should not be
+	// able to stream account 1's list.
+	_, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineList+":"+list.ID)
+	if suite.Error(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeHashtag() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	str, errWithCode := suite.streamProcessor.Open(ctx, account, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer str.Close()
+
+	// Hashtag names should be normalized on subscribe.
+	suite.NoError(suite.streamProcessor.Subscribe(ctx, account, str, "hashtag:local:SomeTag"))
+	suite.Equal([]string{account.ID}, suite.streamProcessor.AccountIDs("hashtag:local:sometag"))
+	suite.Empty(suite.streamProcessor.AccountIDs("hashtag:sometag"))
+
+	// And on unsubscribe.
+	suite.streamProcessor.Unsubscribe(str, "hashtag:local:SOMETAG")
+	suite.Empty(suite.streamProcessor.AccountIDs("hashtag:local:sometag"))
+
+	// Invalid hashtag names should return bad request.
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, str, "hashtag:not a tag")
+	if suite.Error(errWithCode) {
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func TestSubscribeTestSuite(t *testing.T) {
+	suite.Run(t, &SubscribeTestSuite{})
+}
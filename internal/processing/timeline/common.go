@@ -20,6 +20,10 @@ package timeline
 import (
 	"context"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 )
 
@@ -69,3 +73,53 @@ func SkipInsert() timeline.SkipInsertFunction {
 		return false, nil
 	}
 }
+
+// refetchMedia enqueues an async recache of any remote media
+// attached to the given timeline items (*apimodel.Status), and
+// the statuses boosted by them, which has since been uncached.
+// This ensures pruned media is available again locally soon
+// after being served, instead of remaining uncached.
+func (p *Processor) refetchMedia(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	items []interface{},
+) {
+	var requestUser string
+	if requester != nil {
+		requestUser = requester.Username
+	}
+
+	for _, item := range items {
+		status, ok := item.(*apimodel.Status)
+		if !ok {
+			continue
+		}
+
+		// Gather media of status and any boosted status,
+		// (without touching the original status slices).
+		var attachments []*apimodel.Attachment
+		attachments = append(attachments, status.MediaAttachments...)
+		if status.Reblog != nil && status.Reblog.Status != nil {
+			attachments = append(attachments, status.Reblog.MediaAttachments...)
+		}
+
+		for _, a := range attachments {
+			if a == nil || a.RemoteURL == nil {
+				// Local media
+				// is always cached.
+				continue
+			}
+
+			attach, err := p.state.DB.GetAttachmentByID(
+				gtscontext.SetBarebones(ctx),
+				a.ID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "error getting attachment %s: %v", a.ID, err)
+				continue
+			}
+
+			p.federator.RefreshMediaAsync(ctx, requestUser, attach)
+		}
+	}
+}
@@ -64,6 +64,10 @@ func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 		items = append(items, apiStatus)
 	}
 
+	// Ensure any pruned remote
+	// media gets recached.
+	p.refetchMedia(ctx, authed.Account, items)
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
 		Path:           "/api/v1/favourites",
@@ -140,6 +140,10 @@ func (p *Processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, max
 		items[i] = statuses[i]
 	}
 
+	// Ensure any pruned remote
+	// media gets recached.
+	p.refetchMedia(ctx, authed.Account, items)
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
 		Path:           "/api/v1/timelines/home",
@@ -166,6 +166,10 @@ func (p *Processor) ListTimelineGet(ctx context.Context, authed *oauth.Auth, lis
 		items[i] = statuses[i]
 	}
 
+	// Ensure any pruned remote
+	// media gets recached.
+	p.refetchMedia(ctx, authed.Account, items)
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
 		Path:           "/api/v1/timelines/list/" + listID,
@@ -23,6 +23,7 @@ import (
 	"strconv"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
@@ -41,6 +42,36 @@ func (p *Processor) PublicTimelineGet(
 	minID string,
 	limit int,
 	local bool,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	if requester != nil {
+		return p.publicTimelineGet(ctx, requester, maxID, sinceID, minID, limit, local)
+	}
+
+	// Unauthenticated requests all get the same
+	// page for the same params, so check whether
+	// this one was served very recently already.
+	key := cache.PublicTimelineKey(maxID, sinceID, minID, limit, local)
+	if resp, ok := p.state.Caches.Anonymous.PublicTimeline.Get(key); ok {
+		return resp, nil
+	}
+
+	resp, errWithCode := p.publicTimelineGet(ctx, nil, maxID, sinceID, minID, limit, local)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.state.Caches.Anonymous.PublicTimeline.Set(key, resp)
+	return resp, nil
+}
+
+func (p *Processor) publicTimelineGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+	local bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	const maxAttempts = 3
 	var (
@@ -91,23 +122,20 @@ outer:
 		// (ie., one with the highest ID).
 		prevMinIDValue = statuses[0].ID
 
-	inner:
-		for _, s := range statuses {
-			// Push back the next page down ID to
-			// this status, regardless of whether
-			// we end up filtering it out or not.
-			nextMaxIDValue = s.ID
-
-			timelineable, err := p.filter.StatusPublicTimelineable(ctx, requester, s)
-			if err != nil {
-				log.Errorf(ctx, "error checking status visibility: %v", err)
-				continue inner
-			}
+		// Push back the next page down ID to the
+		// last status, regardless of whether we
+		// end up filtering it out or not.
+		nextMaxIDValue = statuses[count-1].ID
 
-			if !timelineable {
-				continue inner
-			}
+		// Filter statuses for the whole page at once, so that
+		// relationships with their authors are resolved in bulk.
+		statuses, err = p.filter.StatusesPublicTimelineable(ctx, requester, statuses)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+		}
 
+	inner:
+		for _, s := range statuses {
 			apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requester, statusfilter.FilterContextPublic, filters, compiledMutes)
 			if errors.Is(err, statusfilter.ErrHideStatus) {
 				continue
@@ -124,8 +152,10 @@ outer:
 			// more than the desired limit.
 			//
 			// Ensure we don't return more
-			// than the caller asked for.
+			// than the caller asked for, paging
+			// down from this last returned status.
 			if len(items) == limit {
+				nextMaxIDValue = s.ID
 				break outer
 			}
 		}
@@ -157,6 +187,10 @@ outer:
 		}
 	}
 
+	// Ensure any pruned remote
+	// media gets recached.
+	p.refetchMedia(ctx, requester, items)
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
 		Path:           "/api/v1/timelines/public",
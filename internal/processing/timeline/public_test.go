@@ -22,6 +22,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type PublicTestSuite struct {
@@ -91,6 +95,86 @@ func (suite *PublicTestSuite) TestPublicTimelineGetNotEmpty() {
 	suite.Equal(`http://localhost:8080/api/v1/timelines/public?limit=1&min_id=01HE7XJ1CG84TBKH5V9XKBVGF5&local=false`, resp.PrevLink)
 }
 
+func (suite *PublicTestSuite) TestPublicTimelineGetRefetchUncachedMedia() {
+	var (
+		ctx        = context.Background()
+		requester  = suite.testAccounts["local_account_1"]
+		status     = testrig.NewTestStatuses()["remote_account_1_status_1"]
+		attachment = testrig.NewTestAttachments()["remote_account_1_status_1_attachment_1"]
+		// Select 1 *just above* the status.
+		maxID = "01FVW7JHQFSFK166WWKR8CBA6N"
+	)
+
+	// Make the status public so
+	// it's in the public timeline.
+	status.Visibility = gtsmodel.VisibilityPublic
+	if err := suite.db.UpdateStatus(ctx, status, "visibility"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Uncache the remote attachment,
+	// as if it were pruned by cleaner.
+	attachment.Cached = util.Ptr(false)
+	if err := suite.db.UpdateAttachment(ctx, attachment, "cached"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Serve the timeline a couple of times,
+	// the refetch should only be queued once.
+	for i := 0; i < 2; i++ {
+		resp, errWithCode := suite.timeline.PublicTimelineGet(ctx, requester, maxID, "", "", 1, false)
+		suite.NoError(errWithCode)
+		suite.Len(resp.Items, 1)
+	}
+
+	refetch, ok := suite.state.Workers.Dereference.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected media refetch to be queued")
+	}
+
+	_, ok = suite.state.Workers.Dereference.Queue.Pop()
+	suite.False(ok)
+
+	// Run the refetch, the
+	// media should be cached.
+	refetch(ctx)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbAttachment.Cached)
+}
+
+func (suite *PublicTestSuite) TestPublicTimelineGetAnonymousCached() {
+	ctx := context.Background()
+
+	get := func() *apimodel.Status {
+		resp, errWithCode := suite.timeline.PublicTimelineGet(ctx, nil, "", "", "", 1, false)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		suite.Len(resp.Items, 1)
+		return resp.Items[0].(*apimodel.Status)
+	}
+
+	// Get the top status of the public timeline.
+	top := get()
+
+	// Delete it straight from the db,
+	// bypassing any timeline events.
+	if err := suite.db.DeleteStatusByID(ctx, top.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The page should still be served from cache.
+	suite.Equal(top.ID, get().ID)
+
+	// Until a timeline event clears it.
+	suite.state.Caches.Anonymous.ClearTimelines()
+	suite.NotEqual(top.ID, get().ID)
+}
+
 func TestPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(PublicTestSuite))
 }
@@ -127,17 +127,14 @@ func (p *Processor) packageTagResponse(
 	}
 	compiledMutes := usermute.NewCompiledUserMuteList(mutes)
 
-	for _, s := range statuses {
-		timelineable, err := p.filter.StatusTagTimelineable(ctx, requestingAcct, s)
-		if err != nil {
-			log.Errorf(ctx, "error checking status visibility: %v", err)
-			continue
-		}
-
-		if !timelineable {
-			continue
-		}
+	// Filter statuses for the whole page at once, so that
+	// relationships with their authors are resolved in bulk.
+	statuses, err = p.filter.StatusesTagTimelineable(ctx, requestingAcct, statuses)
+	if err != nil {
+		log.Errorf(ctx, "error checking status visibility: %v", err)
+	}
 
+	for _, s := range statuses {
 		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requestingAcct, statusfilter.FilterContextPublic, filters, compiledMutes)
 		if errors.Is(err, statusfilter.ErrHideStatus) {
 			continue
@@ -150,6 +147,10 @@ func (p *Processor) packageTagResponse(
 		items = append(items, apiStatus)
 	}
 
+	// Ensure any pruned remote
+	// media gets recached.
+	p.refetchMedia(ctx, requestingAcct, items)
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
 		Path:           requestPath,
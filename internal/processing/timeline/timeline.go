@@ -18,6 +18,7 @@
 package timeline
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -26,13 +27,15 @@ import (
 type Processor struct {
 	state     *state.State
 	converter *typeutils.Converter
+	federator *federation.Federator
 	filter    *visibility.Filter
 }
 
-func New(state *state.State, converter *typeutils.Converter, filter *visibility.Filter) Processor {
+func New(state *state.State, converter *typeutils.Converter, federator *federation.Federator, filter *visibility.Filter) Processor {
 	return Processor{
 		state:     state,
 		converter: converter,
+		federator: federator,
 		filter:    filter,
 	}
 }
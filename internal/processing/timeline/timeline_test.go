@@ -24,14 +24,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type TimelineStandardTestSuite struct {
 	suite.Suite
-	db    db.DB
-	state state.State
+	db      db.DB
+	storage *storage.Driver
+	state   state.State
 
 	// standard suite models
 	testAccounts map[string]*gtsmodel.Account
@@ -53,17 +55,26 @@ func (suite *TimelineStandardTestSuite) SetupTest() {
 
 	suite.db = testrig.NewTestDB(&suite.state)
 	suite.state.DB = suite.db
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	mediaManager := testrig.NewTestMediaManager(&suite.state)
+	transportController := testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../testrig/media"))
+	federator := testrig.NewTestFederator(&suite.state, transportController, mediaManager)
 
 	suite.timeline = timeline.New(
 		&suite.state,
 		typeutils.NewConverter(&suite.state),
+		federator,
 		visibility.NewFilter(&suite.state),
 	)
 
 	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+	testrig.StandardStorageSetup(suite.storage, "../../../testrig/media")
 }
 
 func (suite *TimelineStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
 	testrig.StopWorkers(&suite.state)
 }
@@ -19,16 +19,20 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -74,6 +78,29 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	emailBlocked, err := p.emailDomainBlocked(ctx, form.Email)
+	if err != nil {
+		err := fmt.Errorf("error checking email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if emailBlocked {
+		err := fmt.Errorf("email address %s is not allowed to sign up", form.Email)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Check whether the canonicalized email address is blocked,
+	// to catch aliases like "some.one+spam@example.org".
+	hash := util.CanonicalEmailHash(form.Email)
+	emailBlock, err := p.state.DB.GetCanonicalEmailBlockByHash(ctx, hash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error checking canonical email block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if emailBlock != nil {
+		err := fmt.Errorf("email address %s is not allowed to sign up", form.Email)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
 	if err != nil {
 		err := fmt.Errorf("db error checking email availability: %w", err)
@@ -109,6 +136,22 @@ func (p *Processor) Create(
 		}
 	}
 
+	// If an invite code was provided, claim one use
+	// of it, failing if it's invalid or no longer usable.
+	var inviteID string
+	if form.InviteCode != "" {
+		invite, err := p.state.DB.UseInvite(ctx, form.InviteCode, time.Now())
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if invite == nil {
+			err := fmt.Errorf("invite code %s is invalid, expired, or has already been used", form.InviteCode)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		inviteID = invite.ID
+	}
+
 	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
 		Username: form.Username,
 		Email:    form.Email,
@@ -117,8 +160,17 @@ func (p *Processor) Create(
 		SignUpIP: form.IP,
 		Locale:   form.Locale,
 		AppID:    app.ID,
+		InviteID: inviteID,
 	})
 	if err != nil {
+		if inviteID != "" {
+			// Sign-up didn't happen, so give
+			// back the invite use we claimed.
+			if err := p.state.DB.ReleaseInvite(ctx, inviteID); err != nil {
+				log.Errorf(ctx, "db error releasing invite %s: %v", inviteID, err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
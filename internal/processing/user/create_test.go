@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type CreateTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *CreateTestSuite) TestCreateEmailDomainBlocked() {
+	ctx := context.Background()
+
+	if err := suite.db.CreateEmailDomainBlock(ctx, &gtsmodel.EmailDomainBlock{
+		ID:                 "01J1M1WBHZ4KFQ5YQ0V6YJ3Z8N",
+		Domain:             "spammers.example.org",
+		CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Reason:    "i'm definitely not a spammer",
+		Username:  "not_a_spammer",
+		Email:     "me@mail.spammers.example.org",
+		Password:  "this is a very long and secure password",
+		Agreement: true,
+		Locale:    "en",
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: email address me@mail.spammers.example.org is not allowed to sign up", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateCanonicalEmailBlocked() {
+	ctx := context.Background()
+
+	if err := suite.db.PutCanonicalEmailBlock(ctx, &gtsmodel.CanonicalEmailBlock{
+		ID:                 "01J1P3QG5ZB9S8RZ4J0W6K7N2M",
+		CanonicalEmailHash: util.CanonicalEmailHash("spammer@example.org"),
+		CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Reason:    "i'm definitely not a spammer",
+		Username:  "not_a_spammer",
+		Email:     "spam.mer+new@example.org",
+		Password:  "this is a very long and secure password",
+		Agreement: true,
+		Locale:    "en",
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: email address spam.mer+new@example.org is not allowed to sign up", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	invite := suite.testInvites["admin_account_invite_1"]
+
+	user, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Reason:     "a friend invited me",
+		Username:   "invited_user",
+		Email:      "invited@example.org",
+		Password:   "this is a very long and secure password",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: invite.Code,
+	})
+	suite.Nil(errWithCode)
+	suite.Equal(invite.ID, user.InviteID)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	suite.NoError(err)
+	suite.Equal(invite.Uses+1, dbInvite.Uses)
+}
+
+func (suite *CreateTestSuite) TestCreateWithUsedInvite() {
+	ctx := context.Background()
+	invite := suite.testInvites["local_account_1_invite_used"]
+
+	_, errWithCode := suite.user.Create(ctx, nil, &apimodel.AccountCreateRequest{
+		Reason:     "a friend invited me",
+		Username:   "invited_user",
+		Email:      "invited@example.org",
+		Password:   "this is a very long and secure password",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: invite.Code,
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: invite code mN4ha9Ue is invalid, expired, or has already been used", errWithCode.Safe())
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// emailDomainBlocked returns whether the domain of the
+// given email address is blocked from signing up, either
+// directly, or because one of its mail exchange servers
+// is on a blocked domain (which catches domain aliases).
+func (p *Processor) emailDomainBlocked(ctx context.Context, email string) (bool, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return false, gtserror.Newf("error parsing email address %s: %w", email, err)
+	}
+
+	// Domain will always be the part after the last @.
+	domain := addr.Address[strings.LastIndexByte(addr.Address, '@')+1:]
+	domain = strings.ToLower(domain)
+
+	blocked, err := p.state.DB.IsEmailDomainBlocked(ctx, domain)
+	if err != nil {
+		return false, gtserror.Newf("db error checking email domain %s: %w", domain, err)
+	}
+
+	if blocked {
+		return true, nil
+	}
+
+	// Check whether there are any blocks before
+	// looking up MX records, so we don't make
+	// needless DNS queries for every sign-up.
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx)
+	if err != nil {
+		return false, gtserror.Newf("db error getting email domain blocks: %w", err)
+	}
+
+	if len(blocks) == 0 {
+		return false, nil
+	}
+
+	// Don't let a slow DNS server hold up sign-up.
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	mxs, err := p.lookupMX(ctx, domain)
+	if err != nil {
+		// MX lookup failing is not fatal, the domain
+		// may simply have no MX records, so just log.
+		log.Debugf(ctx, "error looking up mx records for %s: %v", domain, err)
+		return false, nil
+	}
+
+	for _, mx := range mxs {
+		host := strings.TrimSuffix(strings.ToLower(mx.Host), ".")
+
+		blocked, err := p.state.DB.IsEmailDomainBlocked(ctx, host)
+		if err != nil {
+			return false, gtserror.Newf("db error checking mx domain %s: %w", host, err)
+		}
+
+		if blocked {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -18,6 +18,9 @@
 package user
 
 import (
+	"context"
+	"net"
+
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -29,6 +32,10 @@ type Processor struct {
 	converter   *typeutils.Converter
 	oauthServer oauth.Server
 	emailSender email.Sender
+
+	// lookupMX is used to resolve MX
+	// records of sign-up email domains.
+	lookupMX func(ctx context.Context, name string) ([]*net.MX, error)
 }
 
 // New returns a new user processor.
@@ -42,5 +49,6 @@ func New(
 		state:       state,
 		converter:   converter,
 		emailSender: emailSender,
+		lookupMX:    net.DefaultResolver.LookupMX,
 	}
 }
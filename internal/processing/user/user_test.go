@@ -34,7 +34,8 @@ type UserStandardTestSuite struct {
 	db          db.DB
 	state       state.State
 
-	testUsers map[string]*gtsmodel.User
+	testUsers   map[string]*gtsmodel.User
+	testInvites map[string]*gtsmodel.Invite
 
 	sentEmails map[string]string
 
@@ -53,6 +54,7 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
+	suite.testInvites = testrig.NewTestInvites()
 
 	suite.user = user.New(&suite.state, typeutils.NewConverter(&suite.state), testrig.NewTestOauthServer(suite.db), suite.emailSender)
 
@@ -1015,3 +1015,112 @@ func (f *federate) MoveAccount(ctx context.Context, account *gtsmodel.Account) e
 
 	return nil
 }
+
+func (f *federate) AddStatusToFeatured(ctx context.Context, status *gtsmodel.Status) error {
+	return f.featuredCollectionActivity(ctx,
+		streams.NewActivityStreamsAdd(),
+		status,
+	)
+}
+
+func (f *federate) RemoveStatusFromFeatured(ctx context.Context, status *gtsmodel.Status) error {
+	return f.featuredCollectionActivity(ctx,
+		streams.NewActivityStreamsRemove(),
+		status,
+	)
+}
+
+// featuredCollectionActivity sends the given Add or Remove
+// activity for status, targeting its author's featured
+// collection, to let remote servers know of (un)pins.
+func (f *federate) featuredCollectionActivity(
+	ctx context.Context,
+	activity interface {
+		ap.WithActor
+		ap.WithObject
+		ap.WithTarget
+		ap.WithTo
+		ap.WithCc
+		pub.Activity
+	},
+	status *gtsmodel.Status,
+) error {
+	// Do nothing if the status
+	// shouldn't be federated.
+	if !*status.Federated {
+		return nil
+	}
+
+	// Do nothing if this
+	// isn't our status.
+	if !*status.Local {
+		return nil
+	}
+
+	// Ensure the status model is fully populated.
+	if err := f.state.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status: %w", err)
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(status.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	actorIRI, err := parseURI(status.Account.URI)
+	if err != nil {
+		return err
+	}
+
+	statusIRI, err := parseURI(status.URI)
+	if err != nil {
+		return err
+	}
+
+	featuredIRI, err := parseURI(status.Account.FeaturedCollectionURI)
+	if err != nil {
+		return err
+	}
+
+	followersIRI, err := parseURI(status.Account.FollowersURI)
+	if err != nil {
+		return err
+	}
+
+	// Set the author as Actor.
+	ap.AppendActorIRIs(activity, actorIRI)
+
+	// Set the status IRI as the 'object' property.
+	ap.AppendObjectIRIs(activity, statusIRI)
+
+	// Set the featured collection as the 'target' property.
+	ap.AppendTargetIRIs(activity, featuredIRI)
+
+	// Address the activity To followers.
+	ap.AppendTo(activity, followersIRI)
+
+	if status.Visibility == gtsmodel.VisibilityPublic ||
+		status.Visibility == gtsmodel.VisibilityUnlocked {
+		publicIRI, err := parseURI(pub.PublicActivityPubIRI)
+		if err != nil {
+			return err
+		}
+
+		// Address the activity CC public,
+		// as the status itself is public.
+		ap.AppendCc(activity, publicIRI)
+	}
+
+	// Send the activity via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, activity,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			activity, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
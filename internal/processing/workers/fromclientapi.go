@@ -65,6 +65,17 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 	l := log.WithContext(ctx).WithFields(fields...)
 	l.Info("processing from client API")
 
+	// Wait on any concurrency limit
+	// configured for this activity type.
+	release, err := p.clientLimits.Acquire(ctx,
+		cMsg.APActivityType,
+		cMsg.APObjectType,
+	)
+	if err != nil {
+		return gtserror.Newf("error waiting on activity limit: %w", err)
+	}
+	defer release()
+
 	switch cMsg.APActivityType {
 
 	// CREATE SOMETHING
@@ -201,6 +212,24 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActorPerson:
 			return p.clientAPI.MoveAccount(ctx, cMsg)
 		}
+
+	// ADD SOMETHING
+	case ap.ActivityAdd:
+		switch cMsg.APObjectType { //nolint:gocritic
+
+		// ADD NOTE/STATUS (pin)
+		case ap.ObjectNote:
+			return p.clientAPI.PinStatus(ctx, cMsg)
+		}
+
+	// REMOVE SOMETHING
+	case ap.ActivityRemove:
+		switch cMsg.APObjectType { //nolint:gocritic
+
+		// REMOVE NOTE/STATUS (unpin)
+		case ap.ObjectNote:
+			return p.clientAPI.UnpinStatus(ctx, cMsg)
+		}
 	}
 
 	return gtserror.Newf("unhandled: %s %s", cMsg.APActivityType, cMsg.APObjectType)
@@ -305,10 +334,18 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg *messages.FromClie
 		return gtserror.Newf("%T not parseable as *gtsmodel.FollowRequest", cMsg.GTSModel)
 	}
 
+	// Follows from silenced accounts always need
+	// to be manually approved by the target account.
+	silenced, err := p.surface.Filter.AccountSilenced(ctx, cMsg.Origin)
+	if err != nil {
+		return err
+	}
+
 	// If target is a local, unlocked account,
-	// we can skip side effects for the follow
-	// request and accept the follow immediately.
-	if cMsg.Target.IsLocal() && !*cMsg.Target.Locked {
+	// and origin isn't silenced, we can skip
+	// side effects for the follow request
+	// and accept the follow immediately.
+	if cMsg.Target.IsLocal() && !*cMsg.Target.Locked && !silenced {
 		// Accept the FR first to get the Follow.
 		follow, err := p.state.DB.AcceptFollowRequest(
 			ctx,
@@ -456,7 +493,7 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 	}
 
 	// Status representation has changed, invalidate from timelines.
-	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+	p.surface.unprepareStatusFromTimelines(ctx, status.ID)
 
 	if status.Poll != nil && status.Poll.Closing {
 
@@ -611,6 +648,32 @@ func (p *clientAPI) UndoBlock(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) PinStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", cMsg.GTSModel)
+	}
+
+	if err := p.federate.AddStatusToFeatured(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating status pin: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) UnpinStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", cMsg.GTSModel)
+	}
+
+	if err := p.federate.RemoveStatusFromFeatured(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating status unpin: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	statusFave, ok := cMsg.GTSModel.(*gtsmodel.StatusFave)
 	if !ok {
@@ -659,10 +722,11 @@ func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientA
 }
 
 func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
-	// Don't delete attachments, just unattach them:
-	// this request comes from the client API and the
-	// poster may want to use attachments again later.
-	const deleteAttachments = false
+	// Delete any attachments still attached to status.
+	// Attachments that the poster wants to keep for
+	// reuse (e.g. delete + redraft) will have already
+	// been unattached from the status by the processor.
+	const deleteAttachments = true
 
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -21,11 +21,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
@@ -281,6 +283,484 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotification() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotificationWarnFiltered() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		testList         = suite.testLists["local_account_1_list_1"]
+		streams          = suite.openStreams(ctx,
+			testStructs.Processor,
+			receivingAccount,
+			[]string{testList.ID},
+		)
+		homeStream  = streams[stream.TimelineHome]
+		listStream  = streams[stream.TimelineList+":"+testList.ID]
+		notifStream = streams[stream.TimelineNotifications]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Update the status content to match receiving account's
+	// "fnord" warn filter, which applies in home context.
+	status.Content = "fnord"
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Update the follow from receiving account -> posting account so
+	// that receiving account wants notifs when posting account posts.
+	follow := new(gtsmodel.Follow)
+	*follow = *suite.testFollows["local_account_1_admin_account"]
+
+	follow.Notify = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait for a notification to appear for the status.
+	if !testrig.WaitFor(func() bool {
+		_, err := testStructs.State.DB.GetNotification(
+			ctx,
+			gtsmodel.NotificationStatus,
+			receivingAccount.ID,
+			postingAccount.ID,
+			status.ID,
+		)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for new status notification")
+	}
+
+	// Status is warn filtered in home context, so should
+	// still be streamed as an update, but annotated with
+	// the matching filter, for clients to show a warning.
+	var updates int
+	for i := 0; i < 2; i++ {
+		msg, ok := homeStream.Recv(ctx)
+		if !ok {
+			suite.FailNow("expected a message but message was not received")
+		}
+
+		if msg.Event != stream.EventTypeUpdate {
+			suite.Equal(stream.EventTypeNotification, msg.Event)
+			continue
+		}
+
+		apiStatus := new(apimodel.Status)
+		if err := json.Unmarshal([]byte(msg.Payload), apiStatus); err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(status.ID, apiStatus.ID)
+		if suite.Len(apiStatus.Filtered, 1) {
+			suite.Equal("fnord", apiStatus.Filtered[0].Filter.Title)
+			suite.Equal(apimodel.FilterActionWarn, apiStatus.Filtered[0].Filter.FilterAction)
+		}
+		updates++
+	}
+	suite.Equal(1, updates)
+
+	suite.checkStreamed(listStream, true, "", stream.EventTypeUpdate)
+	suite.checkStreamed(notifStream, true, "", stream.EventTypeNotification)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDirectConversation() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Open direct streams for both participants.
+	postingStream, errWithCode := testStructs.Processor.Stream().Open(ctx, postingAccount, stream.TimelineDirect)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	receivingStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, stream.TimelineDirect)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Admin account posts a direct reply to zork.
+	status := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityDirect,
+		suite.testStatuses["local_account_1_status_1"],
+		nil,
+	)
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		str         *stream.Stream
+		unread      bool
+		participant *gtsmodel.Account
+	}{
+		{receivingStream, true, postingAccount},
+		{postingStream, false, receivingAccount},
+	} {
+		ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+		msg, ok := test.str.Recv(ctx)
+		cncl()
+
+		if !ok {
+			suite.FailNow("expected a message but message was not received")
+		}
+		suite.Equal(stream.EventTypeConversation, msg.Event)
+
+		var conversation apimodel.Conversation
+		if err := json.Unmarshal([]byte(msg.Payload), &conversation); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Conversation should be keyed by
+		// thread, contain the other participant,
+		// and only be unread for the recipient.
+		suite.Equal(status.ThreadID, conversation.ID)
+		suite.Equal(test.unread, conversation.Unread)
+		if suite.Len(conversation.Accounts, 1) {
+			suite.Equal(test.participant.ID, conversation.Accounts[0].ID)
+		}
+		if suite.NotNil(conversation.LastStatus) {
+			suite.Equal(status.ID, conversation.LastStatus.ID)
+		}
+	}
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusHashtagStreams() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		otherAccount     = suite.testAccounts["local_account_2"]
+		tag              = suite.testTags["welcome"]
+	)
+
+	// Open a stream for both hashtag
+	// stream types of the #welcome tag.
+	tagStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, "hashtag:Welcome")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if errWithCode := testStructs.Processor.Stream().Subscribe(ctx,
+		receivingAccount,
+		tagStream,
+		"hashtag:local:welcome",
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Open a stream for a different tag.
+	otherStream, errWithCode := testStructs.Processor.Stream().Open(ctx, otherAccount, "hashtag:hashtag")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Admin account posts a new top-level status using #welcome.
+	status := suite.newStatus(
+		ctx,
+		testStructs.State,
+		postingAccount,
+		gtsmodel.VisibilityPublic,
+		nil,
+		nil,
+	)
+	status.TagIDs = []string{tag.ID}
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "tags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should be streamed once
+	// for each subscribed tag stream.
+	for _, streamType := range []string{
+		"hashtag:welcome",
+		"hashtag:local:welcome",
+	} {
+		ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+		msg, ok := tagStream.Recv(ctx)
+		cncl()
+
+		if !ok {
+			suite.FailNow("expected a message but message was not received")
+		}
+		suite.Equal([]string{streamType}, msg.Stream)
+		suite.Equal(stream.EventTypeUpdate, msg.Event)
+	}
+
+	// Other tag stream should receive nothing.
+	suite.checkStreamed(otherStream, false, "", "")
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusDirectConversation() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+
+		// Admin account has sent a direct reply to zork.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityDirect,
+			suite.testStatuses["local_account_1_status_1"],
+			nil,
+		)
+	)
+
+	directStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, stream.TimelineDirect)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Admin account edits the status.
+	status.Content = "pee pee poo poo, edited"
+	status.UpdatedAt = time.Now()
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "content", "updated_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status edit.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The direct stream should receive the
+	// conversation with the edited status.
+	ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+
+	msg, ok := directStream.Recv(ctx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+	suite.Equal(stream.EventTypeConversation, msg.Event)
+
+	var conversation apimodel.Conversation
+	if err := json.Unmarshal([]byte(msg.Payload), &conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.NotNil(conversation.LastStatus) {
+		suite.Equal(status.ID, conversation.LastStatus.ID)
+		suite.Equal(status.Content, conversation.LastStatus.Content)
+	}
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusListAndHashtagStreams() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		testList         = suite.testLists["local_account_1_list_1"]
+		tag              = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, []string{testList.ID})
+		homeStream       = streams[stream.TimelineHome]
+		listStream       = streams[stream.TimelineList+":"+testList.ID]
+
+		// Admin account has posted a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	tagStream, errWithCode := testStructs.Processor.Stream().Open(ctx, receivingAccount, "hashtag:welcome")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Admin account edits the status to add #welcome.
+	status.Content = "pee pee poo poo, edited #welcome"
+	status.TagIDs = []string{tag.ID}
+	status.UpdatedAt = time.Now()
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "content", "tags", "updated_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status edit.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The edit should be streamed to the home,
+	// list and hashtag streams alike.
+	for _, str := range []*stream.Stream{
+		homeStream,
+		listStream,
+		tagStream,
+	} {
+		suite.checkStreamed(str, true, "", stream.EventTypeStatusUpdate)
+	}
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotificationHideFiltered() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		testList         = suite.testLists["local_account_1_list_1"]
+		streams          = suite.openStreams(ctx,
+			testStructs.Processor,
+			receivingAccount,
+			[]string{testList.ID},
+		)
+		homeStream  = streams[stream.TimelineHome]
+		listStream  = streams[stream.TimelineList+":"+testList.ID]
+		notifStream = streams[stream.TimelineNotifications]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Make receiving account's "fnord" filter,
+	// which applies in home context, a hide filter.
+	filter := testrig.NewTestFilters()["local_account_1_filter_1"]
+	filter.Action = gtsmodel.FilterActionHide
+	if err := testStructs.State.DB.UpdateFilter(ctx, filter, []string{"action"}, nil, nil, nil); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Update the status content to match it.
+	status.Content = "fnord"
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Update the follow from receiving account -> posting account so
+	// that receiving account wants notifs when posting account posts.
+	follow := new(gtsmodel.Follow)
+	*follow = *suite.testFollows["local_account_1_admin_account"]
+
+	follow.Notify = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status is hidden from receiving account, so
+	// nothing should have been streamed at all, nor
+	// a notification created.
+	suite.checkStreamed(homeStream, false, "", "")
+	suite.checkStreamed(listStream, false, "", "")
+	suite.checkStreamed(notifStream, false, "", "")
+
+	_, err := testStructs.State.DB.GetNotification(
+		ctx,
+		gtsmodel.NotificationStatus,
+		receivingAccount.ID,
+		postingAccount.ID,
+		status.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -810,6 +1290,67 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostNoReblogs() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessStatusPin() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx           = context.Background()
+		pinningAcct   = suite.testAccounts["local_account_1"]
+		remoteAccount = suite.testAccounts["remote_account_1"]
+		pinnedStatus  = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	// Add a remote follower so the pin gets delivered.
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		AccountID:       remoteAccount.ID,
+		TargetAccountID: pinningAcct.ID,
+		URI:             remoteAccount.URI + "/follows/" + id.NewULID(),
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status pin.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityAdd,
+			GTSModel:       pinnedStatus,
+			Origin:         pinningAcct,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// An Add of the status to the pinning
+	// account's featured collection should
+	// have been queued for delivery.
+	var sent []byte
+	if !testrig.WaitFor(func() bool {
+		delivery, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		if !ok {
+			return false
+		}
+		sent, _ = io.ReadAll(delivery.Request.Body)
+		return true
+	}) {
+		suite.FailNow("timed out waiting for delivery")
+	}
+
+	add := make(map[string]any)
+	if err := json.Unmarshal(sent, &add); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(ap.ActivityAdd, add["type"])
+	suite.Equal(pinnedStatus.URI, add["object"])
+	suite.Equal(pinningAcct.FeaturedCollectionURI, add["target"])
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -72,6 +72,17 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 	l := log.WithContext(ctx).WithFields(fields...)
 	l.Info("processing from fedi API")
 
+	// Wait on any concurrency limit
+	// configured for this activity type.
+	release, err := p.fediLimits.Acquire(ctx,
+		fMsg.APActivityType,
+		fMsg.APObjectType,
+	)
+	if err != nil {
+		return gtserror.Newf("error waiting on activity limit: %w", err)
+	}
+	defer release()
+
 	switch fMsg.APActivityType {
 
 	// CREATE SOMETHING
@@ -242,9 +253,26 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI
 		return gtserror.Newf("cannot cast %T -> *gtsmodel.PollVote", fMsg.GTSModel)
 	}
 
-	// Insert the new poll vote in the database.
-	if err := p.state.DB.PutPollVote(ctx, vote); err != nil {
-		return gtserror.Newf("error inserting poll vote in db: %w", err)
+	// Check whether this vote may
+	// replace an existing one, i.e.
+	// the voter is changing their vote.
+	replace := vote.Poll != nil &&
+		util.PtrValueOr(vote.Poll.VoteChange, false)
+
+	if replace {
+		// Replace any existing poll vote in the database.
+		if err := p.state.DB.ReplacePollVote(ctx, vote); err != nil {
+			return gtserror.Newf("error replacing poll vote in db: %w", err)
+		}
+
+		// Drop the attached poll so it gets
+		// reloaded with latest vote counts.
+		vote.Poll = nil
+	} else {
+		// Insert the new poll vote in the database.
+		if err := p.state.DB.PutPollVote(ctx, vote); err != nil {
+			return gtserror.Newf("error inserting poll vote in db: %w", err)
+		}
 	}
 
 	// Ensure the poll vote is fully populated at this point.
@@ -266,9 +294,11 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI
 	p.surface.invalidateStatusFromTimelines(ctx, vote.Poll.StatusID)
 
 	if *status.Local {
-		// Before federating it, increment the
-		// poll vote counts on our local copy.
-		status.Poll.IncrementVotes(vote.Choices)
+		if !replace {
+			// Before federating it, increment the
+			// poll vote counts on our local copy.
+			status.Poll.IncrementVotes(vote.Choices)
+		}
 
 		// These were poll votes in a local status, we need to
 		// federate the updated status model with latest vote counts.
@@ -290,8 +320,16 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
-	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
+	// Follows from silenced accounts always need
+	// to be manually approved by the local account.
+	silenced, err := p.surface.Filter.AccountSilenced(ctx, followRequest.Account)
+	if err != nil {
+		return err
+	}
+
+	if *followRequest.TargetAccount.Locked || silenced {
+		// Local account is locked, or requester
+		// is silenced: just notify the follow request.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
 			log.Errorf(ctx, "error notifying follow request: %v", err)
 		}
@@ -573,7 +611,7 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	}
 
 	// Status representation was refetched, uncache from timelines.
-	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
+	p.surface.unprepareStatusFromTimelines(ctx, status.ID)
 
 	if status.Poll != nil && status.Poll.Closing {
 
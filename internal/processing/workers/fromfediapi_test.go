@@ -446,6 +446,209 @@ func (suite *FromFediAPITestSuite) TestProcessFollowRequestLocked() {
 	suite.Empty(testStructs.HTTPClient.SentMessages)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestSilencedDomain() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is an unlocked account
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// silence the origin account's domain
+	err := testStructs.State.DB.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01J1KZ3JXTK4MYKV3EWGXGF0VN",
+		Domain:             originAccount.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Obfuscate:          util.Ptr(false),
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+	})
+	suite.NoError(err)
+
+	wssStream, errWithCode := testStructs.Processor.Stream().Open(context.Background(), targetAccount, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	// put the follow request in the database as though it had passed through the federating db already
+	satanFollowRequestTurtle := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	err = testStructs.State.DB.Put(ctx, satanFollowRequestTurtle)
+	suite.NoError(err)
+
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       satanFollowRequestTurtle,
+		Receiving:      targetAccount,
+		Requesting:     originAccount,
+	})
+	suite.NoError(err)
+
+	msg, ok := wssStream.Recv(context.Background())
+	suite.True(ok)
+
+	// target should be notified of a
+	// follow request, not a new follow
+	notif := &apimodel.Notification{}
+	err = json.Unmarshal([]byte(msg.Payload), notif)
+	suite.NoError(err)
+	suite.Equal("follow_request", notif.Type)
+	suite.Equal(originAccount.ID, notif.Account.ID)
+
+	// no accept should have been queued for delivery
+	_, ok = testStructs.State.Workers.Delivery.Queue.Pop()
+	suite.False(ok)
+
+	// follow request should still be pending
+	followRequested, err := testStructs.State.DB.IsFollowRequested(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(followRequested)
+}
+
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestSilencedAccount() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	// target is an unlocked account
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// silence the origin account
+	originAccount := new(gtsmodel.Account)
+	*originAccount = *suite.testAccounts["remote_account_1"]
+	originAccount.SilencedAt = time.Now()
+	err := testStructs.State.DB.UpdateAccount(ctx, originAccount, "silenced_at")
+	suite.NoError(err)
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	err = testStructs.State.DB.Put(ctx, followRequest)
+	suite.NoError(err)
+
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       followRequest,
+		Receiving:      targetAccount,
+		Requesting:     originAccount,
+	})
+	suite.NoError(err)
+
+	// no accept should have been queued for delivery
+	_, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+	suite.False(ok)
+
+	// follow request should still be pending
+	followRequested, err := testStructs.State.DB.IsFollowRequested(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(followRequested)
+
+	// and target should have been notified of it
+	_, err = testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationFollowRequest,
+		targetAccount.ID,
+		originAccount.ID,
+		"",
+	)
+	suite.NoError(err)
+}
+
+func (suite *FromFediAPITestSuite) TestProcessFaveSilencedAccount() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	favedAccount := suite.testAccounts["local_account_1"]
+	favedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// silence the faving account
+	favingAccount := new(gtsmodel.Account)
+	*favingAccount = *suite.testAccounts["remote_account_1"]
+	favingAccount.SilencedAt = time.Now()
+	err := testStructs.State.DB.UpdateAccount(ctx, favingAccount, "silenced_at")
+	suite.NoError(err)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01FGKJPXFTVQPG9YSSZ95ADS7Q",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedAccount.ID,
+		TargetAccount:   favedAccount,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/aaaaaaaaaaaa",
+	}
+
+	err = testStructs.State.DB.Put(ctx, fave)
+	suite.NoError(err)
+
+	processFave := func() {
+		err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+			APObjectType:   ap.ActivityLike,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       fave,
+			Receiving:      favedAccount,
+			Requesting:     favingAccount,
+		})
+		suite.NoError(err)
+	}
+
+	getNotif := func() error {
+		_, err := testStructs.State.DB.GetNotification(ctx,
+			gtsmodel.NotificationFave,
+			favedAccount.ID,
+			favingAccount.ID,
+			favedStatus.ID,
+		)
+		return err
+	}
+
+	// faved account doesn't follow the silenced
+	// account, so shouldn't have been notified
+	processFave()
+	suite.ErrorIs(getNotif(), db.ErrNoEntries)
+
+	// once followed, the silenced
+	// account can notify again
+	err = testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01J2A8Y3DBSXNPNZKM5EW2Y09K",
+		URI:             favedAccount.URI + "/follow/01J2A8Y3DBSXNPNZKM5EW2Y09K",
+		AccountID:       favedAccount.ID,
+		TargetAccountID: favingAccount.ID,
+	})
+	suite.NoError(err)
+
+	processFave()
+	suite.NoError(getNotif())
+}
+
 func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlocked() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"sync"
+	"time"
+)
+
+// invalidateInterval is the interval over which
+// repeated timeline invalidations of a status are
+// coalesced into at most one further invalidation.
+var invalidateInterval = 5 * time.Second
+
+// invalidations coalesces timeline invalidations by status
+// ID, such that a burst of interactions with a status (e.g.
+// many likes / boosts of a viral post) results in at most one
+// invalidation per interval, rather than one per interaction.
+type invalidations struct {
+	// pending contains statuses invalidated
+	// within the current interval, mapped to
+	// whether a further invalidation has since
+	// been requested for the end of interval.
+	pending map[string]bool
+	mu      sync.Mutex
+}
+
+// Coalesce returns whether the status with ID should be invalidated
+// now, i.e. it hasn't been within the current interval. If it has,
+// then fn will be called once at the end of the interval instead.
+func (i *invalidations) Coalesce(statusID string, fn func()) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.pending == nil {
+		i.pending = make(map[string]bool)
+	}
+
+	if _, ok := i.pending[statusID]; ok {
+		// Already invalidated this
+		// interval, defer until end.
+		i.pending[statusID] = true
+		return false
+	}
+
+	// Start new interval for status.
+	i.pending[statusID] = false
+	i.schedule(statusID, fn)
+	return true
+}
+
+// schedule schedules the end of the current
+// interval for status with ID. Must hold lock.
+func (i *invalidations) schedule(statusID string, fn func()) {
+	time.AfterFunc(invalidateInterval, func() {
+		i.mu.Lock()
+		again := i.pending[statusID]
+		if again {
+			// Invalidating again now,
+			// so start a new interval.
+			i.pending[statusID] = false
+			i.schedule(statusID, fn)
+		} else {
+			delete(i.pending, statusID)
+		}
+		i.mu.Unlock()
+
+		if again {
+			fn()
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInvalidationsCoalesce(t *testing.T) {
+	interval := invalidateInterval
+	invalidateInterval = 50 * time.Millisecond
+	defer func() { invalidateInterval = interval }()
+
+	var (
+		i        invalidations
+		deferred atomic.Int32
+		fn       = func() { deferred.Add(1) }
+	)
+
+	// First invalidation should happen immediately.
+	if !i.Coalesce("status", fn) {
+		t.Fatal("expected first invalidation to happen immediately")
+	}
+
+	// Burst of further invalidations should be deferred.
+	for n := 0; n < 100; n++ {
+		if i.Coalesce("status", fn) {
+			t.Fatal("expected repeat invalidation to be coalesced")
+		}
+	}
+
+	// Other statuses are unaffected.
+	if !i.Coalesce("other", fn) {
+		t.Fatal("expected invalidation of other status to happen immediately")
+	}
+
+	// Coalesced burst should result in a single
+	// invalidation at the end of the interval.
+	time.Sleep(100 * time.Millisecond)
+	if n := deferred.Load(); n != 1 {
+		t.Fatalf("expected 1 deferred invalidation, got %d", n)
+	}
+
+	// Once the intervals pass without
+	// further requests, invalidations
+	// should happen immediately again.
+	time.Sleep(150 * time.Millisecond)
+	if !i.Coalesce("status", fn) {
+		t.Fatal("expected invalidation after quiet interval to happen immediately")
+	}
+	if n := deferred.Load(); n != 1 {
+		t.Fatalf("expected 1 deferred invalidation, got %d", n)
+	}
+}
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// activityLimits limits the no. worker messages of each
+// configured activity type (optionally with object type)
+// that may be processed concurrently, in order to protect
+// the database and network under spikes in load of one type.
+type activityLimits struct {
+	// semaphores keyed by lowercase
+	// 'type' or 'type/objecttype'.
+	sems map[string]chan struct{}
+}
+
+// newActivityLimits returns activityLimits{}
+// configured by advanced-activity-concurrency.
+func newActivityLimits() activityLimits {
+	// Errors are already caught
+	// during config validation.
+	limits, err := config.ParseActivityConcurrency(
+		config.GetAdvancedActivityConcurrency(),
+	)
+	if err != nil {
+		log.Errorf(nil, "error parsing activity concurrency: %v", err)
+	}
+
+	sems := make(map[string]chan struct{}, len(limits))
+	for key, n := range limits {
+		sems[key] = make(chan struct{}, n)
+	}
+
+	return activityLimits{sems: sems}
+}
+
+// Acquire blocks until a message of given activity and object type may
+// be processed, or context is cancelled, returning a func to release it.
+// Limits for 'Type/ObjectType' take precedence over those for 'Type'.
+func (l *activityLimits) Acquire(ctx context.Context, activityType, objectType string) (func(), error) {
+	if len(l.sems) == 0 {
+		// No limits set.
+		return func() {}, nil
+	}
+
+	activityType = strings.ToLower(activityType)
+	objectType = strings.ToLower(objectType)
+
+	// Look for most specific limit.
+	sem, ok := l.sems[activityType+"/"+objectType]
+	if !ok {
+		sem, ok = l.sems[activityType]
+		if !ok {
+			// No limit.
+			return func() {}, nil
+		}
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
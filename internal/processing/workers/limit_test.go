@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+func TestActivityLimits(t *testing.T) {
+	config.SetAdvancedActivityConcurrency([]string{
+		"Create=2",
+		"Create/Note=1",
+	})
+	defer config.SetAdvancedActivityConcurrency(nil)
+
+	limits := newActivityLimits()
+
+	ctx, cncl := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cncl()
+
+	// Take the single Create/Note slot.
+	release, err := limits.Acquire(ctx, ap.ActivityCreate, ap.ObjectNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A Create of other object types uses
+	// the separate (less specific) limit.
+	releaseLike, err := limits.Acquire(ctx, ap.ActivityCreate, ap.ActivityLike)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releaseLike()
+
+	// Unlimited activity types never block.
+	releaseDelete, err := limits.Acquire(ctx, ap.ActivityDelete, ap.ObjectNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releaseDelete()
+
+	// Create/Note is now at its limit.
+	if _, err := limits.Acquire(ctx, ap.ActivityCreate, ap.ObjectNote); err == nil {
+		t.Fatal("expected create note to block until context timeout")
+	}
+
+	// Releasing frees the slot.
+	release()
+	release, err = limits.Acquire(context.Background(), ap.ActivityCreate, ap.ObjectNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// how often to check whether spooled
+// federator messages can be re-queued.
+const unspoolEvery = 10 * time.Second
+
+// ScheduleSpooling enables spooling of incoming federator messages to the
+// database once the federator queue reaches the configured threshold length,
+// and schedules a recurring job to re-queue them as the queue drains. This
+// is a no-op if federator spooling is disabled.
+func (p *Processor) ScheduleSpooling(ctx context.Context) error {
+	threshold := config.GetAdvancedFederatorSpoolThreshold()
+	if threshold <= 0 {
+		return nil
+	}
+
+	// Check for messages left spooled from before a restart
+	// (FillWorkerQueues() leaves these to be unspooled), which
+	// newly pushed messages mustn't overtake by being queued.
+	spooled, err := p.state.DB.CountWorkerTasks(ctx, gtsmodel.FederatorWorker)
+	if err != nil {
+		return gtserror.Newf("error counting spooled federator messages: %w", err)
+	}
+
+	// Spool federator messages
+	// pushed beyond threshold.
+	p.workers.Federator.Queue.SetOverflow(
+		threshold,
+		spooled > 0,
+		p.spoolFederatorMsgs,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@federatorunspool",
+		time.Time{},
+		unspoolEvery,
+		func(ctx context.Context, _ time.Time) {
+			_ = p.UnspoolFederatorMsgs(ctx)
+		},
+	) {
+		return gtserror.New("failed to schedule @federatorunspool")
+	}
+
+	return nil
+}
+
+// spoolFederatorMsgs stores given federator messages in the database
+// as worker tasks, to be re-queued later by UnspoolFederatorMsgs().
+// Returns false if they couldn't be stored, i.e. should be queued.
+func (p *Processor) spoolFederatorMsgs(msgs []*messages.FromFediAPI) bool {
+	ctx := context.Background()
+
+	tasks := make([]*gtsmodel.WorkerTask, 0, len(msgs))
+	for _, msg := range msgs {
+		data, err := msg.Serialize()
+		if err != nil {
+			log.Errorf(ctx, "error serializing federator message: %v", err)
+			return false
+		}
+
+		tasks = append(tasks, &gtsmodel.WorkerTask{
+			WorkerType: gtsmodel.FederatorWorker,
+			TaskData:   data,
+		})
+	}
+
+	if err := p.state.DB.PutWorkerTasks(ctx, tasks); err != nil {
+		log.Errorf(ctx, "error spooling %d federator messages: %v", len(tasks), err)
+		return false
+	}
+
+	return true
+}
+
+// UnspoolFederatorMsgs re-queues federator messages spooled to the
+// database, oldest first, until the federator queue reaches half the
+// spool threshold length, returning the no. messages re-queued. Once
+// all spooled messages are re-queued, the federator queue stops
+// spooling all newly pushed messages, see MsgQueue{}.SetOverflow().
+func (p *Processor) UnspoolFederatorMsgs(ctx context.Context) int {
+	target := max(1, config.GetAdvancedFederatorSpoolThreshold()/2)
+
+	var n int
+
+	for {
+		// Check for room in queue.
+		room := target - p.workers.Federator.Queue.Len()
+		if room <= 0 {
+			break
+		}
+
+		// Pop next batch of spooled messages from the database.
+		limit := min(room, workerTaskPopLimit)
+		count, more, err := p.unspoolFederatorMsgs(ctx, limit)
+		n += count
+
+		if err != nil {
+			log.Errorf(ctx, "error popping spooled federator messages: %v", err)
+			break
+		}
+
+		if !more {
+			// Reached end, though more may
+			// have been spooled meanwhile.
+			p.workers.Federator.Queue.EndSpill(func() bool {
+				count, more, err := p.unspoolFederatorMsgs(ctx, workerTaskPopLimit)
+				n += count
+				return err == nil && !more
+			})
+			break
+		}
+	}
+
+	if n > 0 {
+		log.Infof(ctx, "re-queued %d spooled federator messages", n)
+	}
+
+	return n
+}
+
+// unspoolFederatorMsgs re-queues up to limit spooled federator messages,
+// returning the no. messages re-queued and whether there may be more.
+func (p *Processor) unspoolFederatorMsgs(ctx context.Context, limit int) (int, bool, error) {
+	tasks, err := p.state.DB.PopWorkerTasks(ctx, gtsmodel.FederatorWorker, limit)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var n int
+
+	for _, task := range tasks {
+		if err := p.fillWorkerTask(ctx, task); err != nil {
+			log.Errorf(ctx, "error re-queueing spooled worker task %d: %v", task.ID, err)
+			continue
+		}
+		n++
+	}
+
+	return n, len(tasks) == limit, nil
+}
@@ -0,0 +1,272 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SpoolTestSuite struct {
+	WorkersTestSuite
+}
+
+func (suite *SpoolTestSuite) TestSpoolUnspoolFederatorMsgs() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx        = context.Background()
+		workers    = &testStructs.State.Workers
+		processor  = testStructs.Processor.Workers()
+		requesting = suite.testAccounts["remote_account_1"]
+		receiving  = suite.testAccounts["local_account_1"]
+	)
+
+	// Stop workers so messages stay queued.
+	testrig.StopWorkers(testStructs.State)
+	config.SetAdvancedFederatorSpoolThreshold(4)
+
+	// Scheduler must be running
+	// to schedule unspooling.
+	workers.StartScheduler()
+	defer workers.Scheduler.Stop()
+
+	err := processor.ScheduleSpooling(ctx)
+	suite.NoError(err)
+
+	newMsg := func() *messages.FromFediAPI {
+		return &messages.FromFediAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			Requesting:     requesting,
+			Receiving:      receiving,
+		}
+	}
+
+	// Push beyond the spool threshold.
+	for i := 0; i < 10; i++ {
+		workers.Federator.Queue.Push(newMsg())
+	}
+
+	// Only up to threshold should be queued.
+	suite.Equal(4, workers.Federator.Queue.Len())
+
+	// Nothing should be unspooled
+	// while queue is above target.
+	n := processor.UnspoolFederatorMsgs(ctx)
+	suite.Zero(n)
+
+	// Drain queue to empty.
+	for workers.Federator.Queue.Len() > 0 {
+		workers.Federator.Queue.Pop()
+	}
+
+	// Should unspool up to half threshold.
+	n = processor.UnspoolFederatorMsgs(ctx)
+	suite.Equal(2, n)
+	suite.Equal(2, workers.Federator.Queue.Len())
+
+	msg, ok := workers.Federator.Queue.Pop()
+	suite.True(ok)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(requesting.ID, msg.Requesting.ID)
+	suite.Equal(receiving.Username, msg.Receiving.Username)
+
+	// Remaining spooled messages should
+	// be unspooled as the queue drains.
+	for workers.Federator.Queue.Len() > 0 {
+		workers.Federator.Queue.Pop()
+	}
+	var total int
+	for i := 0; i < 10; i++ {
+		n := processor.UnspoolFederatorMsgs(ctx)
+		for workers.Federator.Queue.Len() > 0 {
+			workers.Federator.Queue.Pop()
+		}
+		total += n
+	}
+	suite.Equal(4, total)
+}
+
+func (suite *SpoolTestSuite) TestSpoolOrdering() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx        = context.Background()
+		workers    = &testStructs.State.Workers
+		processor  = testStructs.Processor.Workers()
+		requesting = suite.testAccounts["remote_account_1"]
+		receiving  = suite.testAccounts["local_account_1"]
+	)
+
+	// Stop workers so messages stay queued.
+	testrig.StopWorkers(testStructs.State)
+	config.SetAdvancedFederatorSpoolThreshold(4)
+
+	workers.StartScheduler()
+	defer workers.Scheduler.Stop()
+
+	err := processor.ScheduleSpooling(ctx)
+	suite.NoError(err)
+
+	var pushed int
+	push := func(n int) {
+		for i := 0; i < n; i++ {
+			workers.Federator.Queue.Push(&messages.FromFediAPI{
+				APObjectType:   ap.ActorPerson,
+				APActivityType: ap.ActivityUpdate,
+				APIRI:          testrig.URLMustParse(fmt.Sprintf("http://fossbros-anonymous.io/updates/%d", pushed)),
+				Requesting:     requesting,
+				Receiving:      receiving,
+			})
+			pushed++
+		}
+	}
+
+	var popped []string
+	drain := func() {
+		for {
+			msg, ok := workers.Federator.Queue.Pop()
+			if !ok {
+				return
+			}
+			popped = append(popped, msg.APIRI.String())
+		}
+	}
+
+	// Push beyond the spool threshold,
+	// then drain the in-memory queue.
+	push(10)
+	drain()
+	suite.Len(popped, 4)
+
+	// Queue is below threshold, but messages are
+	// still spooled, so new ones must be spooled
+	// too rather than overtaking those.
+	push(2)
+	suite.Zero(workers.Federator.Queue.Len())
+
+	// Unspool, pushing more in between.
+	for i := 0; i < 10; i++ {
+		processor.UnspoolFederatorMsgs(ctx)
+		push(1)
+		drain()
+	}
+
+	// Once everything is unspooled, pushed
+	// messages are queued in memory again.
+	suite.Len(popped, pushed)
+	push(1)
+	suite.Equal(1, workers.Federator.Queue.Len())
+	drain()
+
+	// Everything should have been popped in push order.
+	suite.Len(popped, pushed)
+	for i, iri := range popped {
+		suite.Equal(fmt.Sprintf("http://fossbros-anonymous.io/updates/%d", i), iri)
+	}
+}
+
+func (suite *SpoolTestSuite) TestSpoolOrderingAfterRestart() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx        = context.Background()
+		workers    = &testStructs.State.Workers
+		processor  = testStructs.Processor.Workers()
+		requesting = suite.testAccounts["remote_account_1"]
+		receiving  = suite.testAccounts["local_account_1"]
+	)
+
+	// Stop workers so messages stay queued.
+	testrig.StopWorkers(testStructs.State)
+	config.SetAdvancedFederatorSpoolThreshold(4)
+
+	workers.StartScheduler()
+	defer workers.Scheduler.Stop()
+
+	var pushed int
+	newMsg := func() *messages.FromFediAPI {
+		msg := &messages.FromFediAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			APIRI:          testrig.URLMustParse(fmt.Sprintf("http://fossbros-anonymous.io/updates/%d", pushed)),
+			Requesting:     requesting,
+			Receiving:      receiving,
+		}
+		pushed++
+		return msg
+	}
+
+	// Messages left spooled from before a restart.
+	var tasks []*gtsmodel.WorkerTask
+	for i := 0; i < 3; i++ {
+		data, err := newMsg().Serialize()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		tasks = append(tasks, &gtsmodel.WorkerTask{
+			WorkerType: gtsmodel.FederatorWorker,
+			TaskData:   data,
+		})
+	}
+	if err := testStructs.State.DB.PutWorkerTasks(ctx, tasks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err := processor.ScheduleSpooling(ctx)
+	suite.NoError(err)
+
+	// Queue is empty, but messages are still
+	// spooled, so new ones must be spooled too
+	// rather than overtaking those.
+	workers.Federator.Queue.Push(newMsg(), newMsg())
+	suite.Zero(workers.Federator.Queue.Len())
+
+	var popped []string
+	for i := 0; i < 10; i++ {
+		processor.UnspoolFederatorMsgs(ctx)
+		for {
+			msg, ok := workers.Federator.Queue.Pop()
+			if !ok {
+				break
+			}
+			popped = append(popped, msg.APIRI.String())
+		}
+	}
+
+	// Everything should have been popped in push order.
+	suite.Len(popped, pushed)
+	for i, iri := range popped {
+		suite.Equal(fmt.Sprintf("http://fossbros-anonymous.io/updates/%d", i), iri)
+	}
+}
+
+func TestSpoolTestSuite(t *testing.T) {
+	suite.Run(t, new(SpoolTestSuite))
+}
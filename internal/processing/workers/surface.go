@@ -37,4 +37,8 @@ type Surface struct {
 	Stream      *stream.Processor
 	Filter      *visibility.Filter
 	EmailSender email.Sender
+
+	// coalesces timeline
+	// status invalidations.
+	invalidations invalidations
 }
@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// streamConversation streams the given status, if direct,
+// as a conversation event to the direct streams of each local
+// participant in the conversation, i.e. the status author and
+// any accounts mentioned by the status. The status is expected
+// to already be populated, with mentioned accounts.
+//
+// Conversations aren't stored, so the conversation is keyed by
+// the status' thread ID, with the status as its last status.
+func (s *Surface) streamConversation(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect {
+		// Not a conversation.
+		return nil
+	}
+
+	// Gather all participants in the conversation.
+	participants := make([]*gtsmodel.Account, 0, 1+len(status.Mentions))
+	participants = append(participants, status.Account)
+	for _, mention := range status.Mentions {
+		if mention.TargetAccount == nil ||
+			slices.ContainsFunc(participants, func(a *gtsmodel.Account) bool {
+				return a.ID == mention.TargetAccountID
+			}) {
+			continue
+		}
+		participants = append(participants, mention.TargetAccount)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, account := range participants {
+		if account.IsRemote() {
+			// no need to stream
+			// to remote accounts.
+			continue
+		}
+
+		if err := s.streamConversationTo(ctx,
+			account,
+			status,
+			participants,
+		); err != nil {
+			errs.Appendf("error streaming conversation to account %s: %w", account.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// streamConversationTo streams the given direct status and
+// conversation participants as a conversation event to given
+// account, if visible to and not muted / filtered by them.
+func (s *Surface) streamConversationTo(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	participants []*gtsmodel.Account,
+) error {
+	visible, err := s.Filter.StatusVisible(ctx, account, status)
+	if err != nil {
+		return gtserror.Newf("error checking status %s visibility: %w", status.ID, err)
+	}
+
+	if !visible {
+		// Nothing to do.
+		return nil
+	}
+
+	// Ensure thread not muted by account.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		status.ThreadID,
+		account.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", status.ThreadID, err)
+	}
+
+	if muted {
+		// Don't pester them.
+		return nil
+	}
+
+	filters, err := s.State.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+	}
+
+	mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), account.ID, nil)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve mutes for account %s: %w", account.ID, err)
+	}
+	compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+	apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+		status,
+		account,
+		statusfilter.FilterContextThread,
+		filters,
+		compiledMutes,
+	)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Don't put this status in the stream.
+		return nil
+	}
+	if err != nil {
+		return gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
+	}
+
+	apiConversation := &apimodel.Conversation{
+		ID:         cmp.Or(status.ThreadID, status.ID),
+		Unread:     account.ID != status.AccountID,
+		Accounts:   make([]apimodel.Account, 0, len(participants)-1),
+		LastStatus: apiStatus,
+	}
+
+	// Add every participant
+	// except the account itself.
+	for _, participant := range participants {
+		if participant.ID == account.ID {
+			continue
+		}
+
+		apiAccount, err := s.Converter.AccountToAPIAccountPublic(ctx, participant)
+		if err != nil {
+			return gtserror.Newf("error converting account %s to frontend representation: %w", participant.ID, err)
+		}
+
+		apiConversation.Accounts = append(apiConversation.Accounts, *apiAccount)
+	}
+
+	s.Stream.Conversation(ctx, account, apiConversation)
+	return nil
+}
@@ -67,11 +67,28 @@ func (s *Surface) emailUserReportClosed(ctx context.Context, report *gtsmodel.Re
 		ReportTargetUsername: report.TargetAccount.Username,
 		ReportTargetDomain:   report.TargetAccount.Domain,
 		ActionTakenComment:   report.ActionTaken,
+		ResolutionOutcome:    reportResolutionOutcome(report.ResolutionCategory),
 	}
 
 	return s.EmailSender.SendReportClosedEmail(user.Email, reportClosedData)
 }
 
+// reportResolutionOutcome returns a sentence describing
+// the given report resolution category, suitable for
+// showing to the user who created the report.
+func reportResolutionOutcome(category gtsmodel.ReportResolutionCategory) string {
+	switch category {
+	case gtsmodel.ReportResolutionNoAction:
+		return "After review, no action was deemed necessary."
+	case gtsmodel.ReportResolutionActioned:
+		return "Action has been taken against the reported account."
+	case gtsmodel.ReportResolutionDuplicate:
+		return "It was a duplicate of a report that has already been handled."
+	default:
+		return ""
+	}
+}
+
 // emailUserPleaseConfirm emails the given user
 // to ask them to confirm their email address.
 //
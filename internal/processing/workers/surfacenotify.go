@@ -21,9 +21,10 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
-	"github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -448,6 +449,42 @@ func (s *Surface) Notify(
 		return gtserror.Newf("error checking existence of notification: %w", err)
 	}
 
+	// Silenced accounts can only notify their
+	// followers; follow requests are exempt, as
+	// follows from silenced accounts always need
+	// approval, which can't happen unnoticed.
+	if notificationType != gtsmodel.NotificationFollowRequest {
+		hidden, err := s.notifOriginSilenced(ctx, targetAccount, originAccount)
+		if err != nil {
+			return err
+		}
+
+		if hidden {
+			return nil
+		}
+	}
+
+	// Get the target account's filters, so we can
+	// check whether the notification's subject status
+	// is hidden from notifications entirely.
+	filters, err := s.State.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", targetAccount.ID, err)
+	}
+
+	if statusID != "" {
+		hidden, err := s.notifStatusHidden(ctx, targetAccount, statusID, filters)
+		if err != nil {
+			return err
+		}
+
+		if hidden {
+			// Status is hidden by one of the target
+			// account's filters, don't notify at all.
+			return nil
+		}
+	}
+
 	// Notification doesn't yet exist, so
 	// we need to create + store one.
 	notif := &gtsmodel.Notification{
@@ -469,11 +506,6 @@ func (s *Surface) Notify(
 	unlock()
 
 	// Stream notification to the user.
-	filters, err := s.State.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
-	if err != nil {
-		return gtserror.Newf("couldn't retrieve filters for account %s: %w", targetAccount.ID, err)
-	}
-
 	mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), targetAccount.ID, nil)
 	if err != nil {
 		return gtserror.Newf("couldn't retrieve mutes for account %s: %w", targetAccount.ID, err)
@@ -482,12 +514,78 @@ func (s *Surface) Notify(
 
 	apiNotif, err := s.Converter.NotificationToAPINotification(ctx, notif, filters, compiledMutes)
 	if err != nil {
-		if errors.Is(err, status.ErrHideStatus) {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
 			return nil
 		}
 		return gtserror.Newf("error converting notification to api representation: %w", err)
 	}
+
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
 	return nil
 }
+
+// notifOriginSilenced returns whether origin account
+// is silenced and not followed by the target account,
+// in which case it should not notify the target.
+func (s *Surface) notifOriginSilenced(
+	ctx context.Context,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+) (bool, error) {
+	silenced, err := s.Filter.AccountSilenced(ctx, originAccount)
+	if err != nil || !silenced {
+		return false, err
+	}
+
+	following, err := s.State.DB.IsFollowing(ctx, targetAccount.ID, originAccount.ID)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	return !following, nil
+}
+
+// notifStatusHidden returns whether the status with
+// given ID matches any of the target account's filters
+// with action "hide" in the notifications context.
+// Statuses authored by the target, or boosts of them,
+// are never hidden.
+func (s *Surface) notifStatusHidden(
+	ctx context.Context,
+	targetAccount *gtsmodel.Account,
+	statusID string,
+	filters []*gtsmodel.Filter,
+) (bool, error) {
+	if len(filters) == 0 {
+		// Nothing to match.
+		return false, nil
+	}
+
+	status, err := s.State.DB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		return false, gtserror.Newf("error getting notification status %s: %w", statusID, err)
+	}
+
+	if status.AccountID == targetAccount.ID ||
+		status.BoostOfAccountID == targetAccount.ID {
+		// Don't hide the target's own
+		// statuses, eg., when faved or
+		// boosted (status is the boost).
+		return false, nil
+	}
+
+	matches := statusfilter.MatchStatus(
+		status,
+		filters,
+		statusfilter.FilterContextNotifications,
+		time.Now(),
+	)
+	for _, match := range matches {
+		if match.Hide() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -24,10 +24,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type SurfaceNotifyTestSuite struct {
@@ -110,6 +112,135 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestNotifHiddenByFilter() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:       testStructs.State,
+		Converter:   testStructs.TypeConverter,
+		Stream:      testStructs.Processor.Stream(),
+		Filter:      visibility.NewFilter(testStructs.State),
+		EmailSender: testStructs.EmailSender,
+	}
+
+	var (
+		ctx            = context.Background()
+		targetAccount  = suite.testAccounts["local_account_1"]
+		originAccount  = suite.testAccounts["local_account_2"]
+		filteredStatus = suite.testStatuses["local_account_2_status_1"]
+		otherStatus    = suite.testStatuses["local_account_2_status_2"]
+	)
+
+	// Hide statuses mentioning "everyone" from notifications.
+	filter := &gtsmodel.Filter{
+		ID:                   "01J1H1XNEBJ2AE4QBB1JNTPYN8",
+		AccountID:            targetAccount.ID,
+		Title:                "no everyone",
+		Action:               gtsmodel.FilterActionHide,
+		ContextNotifications: util.Ptr(true),
+		Keywords: []*gtsmodel.FilterKeyword{
+			{
+				ID:        "01J1H1Y0PQJ3WQ8KDR2D4FCEWD",
+				AccountID: targetAccount.ID,
+				FilterID:  "01J1H1XNEBJ2AE4QBB1JNTPYN8",
+				Keyword:   "everyone",
+			},
+		},
+	}
+	if err := testStructs.State.DB.PutFilter(ctx, filter); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, status := range []*gtsmodel.Status{filteredStatus, otherStatus} {
+		if err := surface.Notify(ctx,
+			gtsmodel.NotificationStatus,
+			targetAccount,
+			originAccount,
+			status.ID,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the unfiltered status should have a notif.
+	_, err := testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationStatus,
+		targetAccount.ID,
+		originAccount.ID,
+		filteredStatus.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationStatus,
+		targetAccount.ID,
+		originAccount.ID,
+		otherStatus.ID,
+	)
+	suite.NoError(err)
+}
+
+func (suite *SurfaceNotifyTestSuite) TestNotifReblogNotHiddenByFilter() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:       testStructs.State,
+		Converter:   testStructs.TypeConverter,
+		Stream:      testStructs.Processor.Stream(),
+		Filter:      visibility.NewFilter(testStructs.State),
+		EmailSender: testStructs.EmailSender,
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["admin_account"]
+		boost         = suite.testStatuses["admin_account_status_4"]
+	)
+
+	// Hide statuses mentioning "everyone" from
+	// notifications, which the boost matches.
+	filter := &gtsmodel.Filter{
+		ID:                   "01J1H1XNEBJ2AE4QBB1JNTPYN8",
+		AccountID:            targetAccount.ID,
+		Title:                "no everyone",
+		Action:               gtsmodel.FilterActionHide,
+		ContextNotifications: util.Ptr(true),
+		Keywords: []*gtsmodel.FilterKeyword{
+			{
+				ID:        "01J1H1Y0PQJ3WQ8KDR2D4FCEWD",
+				AccountID: targetAccount.ID,
+				FilterID:  "01J1H1XNEBJ2AE4QBB1JNTPYN8",
+				Keyword:   "everyone",
+			},
+		},
+	}
+	if err := testStructs.State.DB.PutFilter(ctx, filter); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationReblog,
+		targetAccount,
+		originAccount,
+		boost.ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Boost is of target's own status,
+	// so should still have been notified.
+	_, err := testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationReblog,
+		targetAccount.ID,
+		originAccount.ID,
+		boost.ID,
+	)
+	suite.NoError(err)
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
@@ -44,6 +44,13 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error populating status with id %s: %w", status.ID, err)
 	}
 
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		// New status may show up in
+		// the public timeline, drop
+		// cached anonymous pages.
+		s.State.Caches.Anonymous.ClearTimelines()
+	}
+
 	// Get all local followers of the account that posted the status.
 	follows, err := s.State.DB.GetAccountLocalFollowers(ctx, status.AccountID)
 	if err != nil {
@@ -73,9 +80,108 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Stream direct statuses as conversation
+	// updates to each local participant.
+	if err := s.streamConversation(ctx, status); err != nil {
+		return gtserror.Newf("error streaming conversation for status %s: %w", status.ID, err)
+	}
+
+	// Stream public statuses to any
+	// open streams of their hashtags.
+	if err := s.streamStatusToTags(ctx, status, false); err != nil {
+		return gtserror.Newf("error streaming status %s to tags: %w", status.ID, err)
+	}
+
 	return nil
 }
 
+// streamStatusToTags streams the given status to the hashtag
+// streams of each of its tags (and local hashtag streams, if
+// the status is local), for each account with such a stream
+// open, provided it is tag timelineable for that account and
+// not hidden by their filters or mutes. If edited is set, the
+// status is streamed as a status.update rather than an update.
+func (s *Surface) streamStatusToTags(ctx context.Context, status *gtsmodel.Status, edited bool) error {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" ||
+		len(status.Tags) == 0 {
+		// Not for tag streams.
+		return nil
+	}
+
+	// Gather the stream types of each tag.
+	streamTypes := make([]string, 0, 2*len(status.Tags))
+	for _, tag := range status.Tags {
+		streamTypes = append(streamTypes, stream.TimelineHashtag+":"+tag.Name)
+		if status.IsLocal() {
+			streamTypes = append(streamTypes, stream.TimelineHashtagLocal+":"+tag.Name)
+		}
+	}
+
+	var errs gtserror.MultiError
+
+	for _, accountID := range s.Stream.AccountIDs(streamTypes...) {
+		account, err := s.State.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("error getting account %s: %w", accountID, err)
+			continue
+		}
+
+		timelineable, err := s.Filter.StatusTagTimelineable(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking status %s tagtimelineability: %w", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			// Nothing to do.
+			continue
+		}
+
+		filters, err := s.State.DB.GetFiltersForAccountID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("couldn't retrieve filters for account %s: %w", accountID, err)
+			continue
+		}
+
+		mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), accountID, nil)
+		if err != nil {
+			errs.Appendf("couldn't retrieve mutes for account %s: %w", accountID, err)
+			continue
+		}
+		compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+		apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+			status,
+			account,
+			statusfilter.FilterContextPublic,
+			filters,
+			compiledMutes,
+		)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't put this status in the stream.
+			continue
+		}
+		if err != nil {
+			errs.Appendf("error converting status %s to frontend representation: %w", status.ID, err)
+			continue
+		}
+
+		// Stream to each tag stream type; only
+		// streams of this account subscribed
+		// to each type will receive the update.
+		for _, streamType := range streamTypes {
+			if edited {
+				s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
+			} else {
+				s.Stream.Update(ctx, account, apiStatus, streamType)
+			}
+		}
+	}
+
+	return errs.Combine()
+}
+
 // timelineAndNotifyStatusForFollowers iterates through the given
 // slice of followers of the account that posted the given status,
 // adding the status to list timelines + home timelines of each
@@ -373,10 +479,15 @@ func (s *Surface) timelineStatus(
 		filters,
 		mutes,
 	)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Don't put this status in the stream.
+		return true, nil
+	}
 	if err != nil {
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return true, err
 	}
+
 	s.Stream.Update(ctx, account, apiStatus, streamType)
 
 	return true, nil
@@ -391,6 +502,7 @@ func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string
 	if err := s.State.Timelines.List.WipeItemFromAllTimelines(ctx, statusID); err != nil {
 		return err
 	}
+	s.State.Caches.Anonymous.ClearTimelines()
 	s.Stream.Delete(ctx, statusID)
 	return nil
 }
@@ -399,7 +511,25 @@ func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string
 // unpreparing it from all timelines, forcing it to be prepared again (with updated
 // stats, boost counts, etc) next time it's fetched by the timeline owner. This goes
 // both for the status itself, and for any boosts of the status.
+//
+// Invalidations of the same status are coalesced, so that a burst of interactions
+// only unprepares it once per interval. Use unprepareStatusFromTimelines() for changes
+// to the status itself (e.g. edits) that should always be reflected immediately.
 func (s *Surface) invalidateStatusFromTimelines(ctx context.Context, statusID string) {
+	if !s.invalidations.Coalesce(statusID, func() {
+		// Invalidation deferred to end of interval,
+		// at which point original ctx may be done.
+		s.unprepareStatusFromTimelines(context.Background(), statusID)
+	}) {
+		return
+	}
+	s.unprepareStatusFromTimelines(ctx, statusID)
+}
+
+// unprepareStatusFromTimelines unprepares the given status (and any
+// boosts of it) from all timelines, without any coalescing. See
+// invalidateStatusFromTimelines().
+func (s *Surface) unprepareStatusFromTimelines(ctx context.Context, statusID string) {
 	if err := s.State.Timelines.Home.UnprepareItemFromAllTimelines(ctx, statusID); err != nil {
 		log.
 			WithContext(ctx).
@@ -427,6 +557,13 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error populating status with id %s: %w", status.ID, err)
 	}
 
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		// Drop cached anonymous public
+		// timeline pages showing the
+		// previous version of the status.
+		s.State.Caches.Anonymous.ClearTimelines()
+	}
+
 	// Get all local followers of the account that posted the status.
 	follows, err := s.State.DB.GetAccountLocalFollowers(ctx, status.AccountID)
 	if err != nil {
@@ -449,6 +586,19 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Push edited direct statuses as conversation
+	// updates to each local participant, so their
+	// conversation shows the latest status version.
+	if err := s.streamConversation(ctx, status); err != nil {
+		return gtserror.Newf("error streaming conversation for status %s: %w", status.ID, err)
+	}
+
+	// Push edits of public statuses to any
+	// open streams of their (current) hashtags.
+	if err := s.streamStatusToTags(ctx, status, true); err != nil {
+		return gtserror.Newf("error streaming status %s update to tags: %w", status.ID, err)
+	}
+
 	return nil
 }
 
@@ -604,6 +754,7 @@ func (s *Surface) timelineStreamStatusUpdate(
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return err
 	}
+
 	s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
 	return nil
 }
@@ -192,7 +192,7 @@ func (u *utils) redirectFollowers(
 
 		// Use the account processor FollowCreate
 		// function to send off the new follow,
-		// carrying over the Reblogs and Notify
+		// carrying over the Reblogs, Notify and Languages
 		// values from the old follow to the new.
 		//
 		// This will also handle cases where our
@@ -207,9 +207,10 @@ func (u *utils) redirectFollowers(
 				ctx,
 				follow.Account,
 				&apimodel.AccountFollowRequest{
-					ID:      targetAcct.ID,
-					Reblogs: follow.ShowReblogs,
-					Notify:  follow.Notify,
+					ID:        targetAcct.ID,
+					Reblogs:   follow.ShowReblogs,
+					Notify:    follow.Notify,
+					Languages: follow.Languages,
 				},
 			); err != nil {
 				log.Errorf(ctx,
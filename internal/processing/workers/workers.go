@@ -30,9 +30,12 @@ import (
 )
 
 type Processor struct {
-	clientAPI clientAPI
-	fediAPI   fediAPI
-	workers   *workers.Workers
+	clientAPI    clientAPI
+	fediAPI      fediAPI
+	clientLimits activityLimits
+	fediLimits   activityLimits
+	state        *state.State
+	workers      *workers.Workers
 }
 
 func New(
@@ -72,7 +75,10 @@ func New(
 	}
 
 	return Processor{
-		workers: &state.Workers,
+		clientLimits: newActivityLimits(),
+		fediLimits:   newActivityLimits(),
+		state:        state,
+		workers:      &state.Workers,
 		clientAPI: clientAPI{
 			state:     state,
 			converter: converter,
@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// max worker tasks to pop
+// from the database in one go.
+const workerTaskPopLimit = 100
+
+// PersistWorkerQueues stores all client / federator messages still
+// queued for processing in the database, to be re-queued on next
+// startup by FillWorkerQueues(). This is a no-op if persisting worker
+// queues is disabled, and should only be called after workers stop.
+func (p *Processor) PersistWorkerQueues(ctx context.Context) error {
+	if !config.GetAdvancedPersistWorkerQueues() {
+		return nil
+	}
+
+	var tasks []*gtsmodel.WorkerTask
+
+	for {
+		// Pop remaining client messages.
+		msg, ok := p.workers.Client.Queue.Pop()
+		if !ok {
+			break
+		}
+
+		data, err := msg.Serialize()
+		if err != nil {
+			log.Errorf(ctx, "error serializing client message: %v", err)
+			continue
+		}
+
+		tasks = append(tasks, &gtsmodel.WorkerTask{
+			WorkerType: gtsmodel.ClientWorker,
+			TaskData:   data,
+		})
+	}
+
+	for {
+		// Pop remaining federator messages.
+		msg, ok := p.workers.Federator.Queue.Pop()
+		if !ok {
+			break
+		}
+
+		data, err := msg.Serialize()
+		if err != nil {
+			log.Errorf(ctx, "error serializing federator message: %v", err)
+			continue
+		}
+
+		tasks = append(tasks, &gtsmodel.WorkerTask{
+			WorkerType: gtsmodel.FederatorWorker,
+			TaskData:   data,
+		})
+	}
+
+	if len(tasks) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Insert all worker tasks into the database.
+	if err := p.state.DB.PutWorkerTasks(ctx, tasks); err != nil {
+		return gtserror.Newf("error persisting %d worker tasks: %w", len(tasks), err)
+	}
+
+	log.Infof(ctx, "persisted %d queued worker tasks", len(tasks))
+	return nil
+}
+
+// FillWorkerQueues pops all client / federator messages stored
+// in the database by PersistWorkerQueues(), and pushes them back
+// into their respective worker queues for processing. Messages
+// which can no longer be processed (e.g. their accounts have
+// since been deleted) are logged and dropped. When federator
+// spooling is enabled, federator messages are instead left to
+// be gradually re-queued by UnspoolFederatorMsgs().
+func (p *Processor) FillWorkerQueues(ctx context.Context) error {
+	var n int
+
+	workerTypes := []gtsmodel.WorkerType{gtsmodel.ClientWorker}
+	if config.GetAdvancedFederatorSpoolThreshold() <= 0 {
+		workerTypes = append(workerTypes, gtsmodel.FederatorWorker)
+	}
+
+	for _, workerType := range workerTypes {
+		for {
+			// Pop next batch of worker tasks from the database.
+			tasks, err := p.state.DB.PopWorkerTasks(ctx, workerType, workerTaskPopLimit)
+			if err != nil {
+				return gtserror.Newf("error popping worker tasks: %w", err)
+			}
+
+			for _, task := range tasks {
+				if err := p.fillWorkerTask(ctx, task); err != nil {
+					log.Errorf(ctx, "error re-queueing worker task %d: %v", task.ID, err)
+					continue
+				}
+				n++
+			}
+
+			if len(tasks) < workerTaskPopLimit {
+				// Reached end.
+				break
+			}
+		}
+	}
+
+	if n > 0 {
+		log.Infof(ctx, "re-queued %d persisted worker tasks", n)
+	}
+
+	return nil
+}
+
+// fillWorkerTask deserializes the message in given
+// worker task, and pushes it to the relevant queue.
+func (p *Processor) fillWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	switch task.WorkerType {
+	case gtsmodel.ClientWorker:
+		msg := new(messages.FromClientAPI)
+		if err := msg.Deserialize(task.TaskData); err != nil {
+			return gtserror.Newf("error deserializing client message: %w", err)
+		}
+
+		// Fill in the origin + target
+		// account placeholder models.
+		if err := p.fillAccount(ctx, &msg.Origin); err != nil {
+			return err
+		}
+		if err := p.fillAccount(ctx, &msg.Target); err != nil {
+			return err
+		}
+
+		p.workers.Client.Queue.Requeue(msg)
+		return nil
+
+	case gtsmodel.FederatorWorker:
+		msg := new(messages.FromFediAPI)
+		if err := msg.Deserialize(task.TaskData); err != nil {
+			return gtserror.Newf("error deserializing federator message: %w", err)
+		}
+
+		// Fill in the requesting + receiving
+		// account placeholder models.
+		if err := p.fillAccount(ctx, &msg.Requesting); err != nil {
+			return err
+		}
+		if err := p.fillAccount(ctx, &msg.Receiving); err != nil {
+			return err
+		}
+
+		p.workers.Federator.Queue.Requeue(msg)
+		return nil
+
+	default:
+		return gtserror.Newf("unknown worker type: %d", task.WorkerType)
+	}
+}
+
+// fillAccount replaces a deserialized placeholder
+// account model (containing only an ID) with the
+// full model fetched from the database, if set.
+func (p *Processor) fillAccount(ctx context.Context, account **gtsmodel.Account) error {
+	if *account == nil {
+		return nil
+	}
+
+	acc, err := p.state.DB.GetAccountByID(ctx, (*account).ID)
+	if err != nil {
+		return gtserror.Newf("error getting account %s: %w", (*account).ID, err)
+	}
+
+	*account = acc
+	return nil
+}
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WorkerTaskTestSuite struct {
+	WorkersTestSuite
+}
+
+func (suite *WorkerTaskTestSuite) TestPersistFillWorkerQueues() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx        = context.Background()
+		workers    = &testStructs.State.Workers
+		processor  = testStructs.Processor.Workers()
+		status     = suite.testStatuses["local_account_1_status_1"]
+		origin     = suite.testAccounts["local_account_1"]
+		requesting = suite.testAccounts["remote_account_1"]
+		receiving  = suite.testAccounts["local_account_1"]
+		activity   = suite.testActivities["dm_for_zork"]
+		create     = activity.Activity.(vocab.ActivityStreamsCreate)
+		createIRI  = ap.GetJSONLDId(create)
+	)
+
+	// Stop workers so messages stay queued.
+	testrig.StopWorkers(testStructs.State)
+	config.SetAdvancedPersistWorkerQueues(true)
+
+	workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       status,
+		Origin:         origin,
+	})
+
+	workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		APIRI:          createIRI,
+		APObject:       create,
+		Requesting:     requesting,
+		Receiving:      receiving,
+	})
+
+	// Persist queued messages to the database.
+	err := processor.PersistWorkerQueues(ctx)
+	suite.NoError(err)
+	suite.Zero(workers.Client.Queue.Len())
+	suite.Zero(workers.Federator.Queue.Len())
+
+	// Re-queue the persisted messages.
+	err = processor.FillWorkerQueues(ctx)
+	suite.NoError(err)
+
+	cMsg, ok := workers.Client.Queue.Pop()
+	suite.True(ok)
+	suite.Equal(ap.ActivityCreate, cMsg.APActivityType)
+	suite.Equal(status.ID, cMsg.GTSModel.(*gtsmodel.Status).ID)
+	suite.Equal(origin.ID, cMsg.Origin.ID)
+	suite.Equal(origin.Username, cMsg.Origin.Username)
+	suite.Nil(cMsg.Target)
+
+	fMsg, ok := workers.Federator.Queue.Pop()
+	suite.True(ok)
+	suite.Equal(createIRI.String(), fMsg.APIRI.String())
+	suite.IsType(create, fMsg.APObject)
+	suite.Equal(requesting.URI, fMsg.Requesting.URI)
+	suite.Equal(receiving.Username, fMsg.Receiving.Username)
+
+	// Tasks should only be re-queued once.
+	err = processor.FillWorkerQueues(ctx)
+	suite.NoError(err)
+	suite.Zero(workers.Client.Queue.Len())
+	suite.Zero(workers.Federator.Queue.Len())
+}
+
+func (suite *WorkerTaskTestSuite) TestPersistWorkerQueuesDisabled() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx       = context.Background()
+		workers   = &testStructs.State.Workers
+		processor = testStructs.Processor.Workers()
+	)
+
+	// Stop workers so messages stay queued.
+	testrig.StopWorkers(testStructs.State)
+	config.SetAdvancedPersistWorkerQueues(false)
+
+	workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		Origin:         suite.testAccounts["local_account_1"],
+	})
+
+	err := processor.PersistWorkerQueues(ctx)
+	suite.NoError(err)
+
+	// Nothing should have been stored.
+	err = processor.FillWorkerQueues(ctx)
+	suite.NoError(err)
+	suite.Equal(1, workers.Client.Queue.Len())
+}
+
+func TestWorkerTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTaskTestSuite))
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package queue
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats tracks statistics of a worker queue, i.e. the
+// no. messages enqueued, and those processed by workers
+// along with the total time spent processing them.
+type Stats struct {
+	enqueued  atomic.Uint64
+	processed atomic.Uint64
+	latency   atomic.Int64
+	observe   atomic.Pointer[func(time.Duration)]
+}
+
+// Enqueue records n messages pushed to the queue.
+func (s *Stats) Enqueue(n int) {
+	if n > 0 {
+		s.enqueued.Add(uint64(n)) // #nosec G115 -- checked positive
+	}
+}
+
+// Process records a message processed
+// by a worker, taking given duration.
+func (s *Stats) Process(d time.Duration) {
+	s.processed.Add(1)
+	s.latency.Add(int64(d))
+	if fn := s.observe.Load(); fn != nil {
+		(*fn)(d)
+	}
+}
+
+// Observe sets a func to be passed the processing
+// duration of each processed message, e.g. for
+// recording into a histogram. Nil unsets it.
+func (s *Stats) Observe(fn func(time.Duration)) {
+	if fn == nil {
+		s.observe.Store(nil)
+		return
+	}
+	s.observe.Store(&fn)
+}
+
+// Enqueued returns the total no.
+// messages pushed to the queue.
+func (s *Stats) Enqueued() uint64 {
+	return s.enqueued.Load()
+}
+
+// Processed returns the total no.
+// messages processed by workers.
+func (s *Stats) Processed() uint64 {
+	return s.processed.Load()
+}
+
+// Latency returns the total time
+// spent processing messages.
+func (s *Stats) Latency() time.Duration {
+	return time.Duration(s.latency.Load())
+}
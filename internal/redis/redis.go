@@ -0,0 +1,389 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package redis provides a minimal Redis client, supporting
+// only the publish / subscribe commands that GoToSocial needs
+// to share state between multiple replicas of itself.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// dialTimeout is the maximum time
+	// allowed to connect + authenticate.
+	dialTimeout = 10 * time.Second
+
+	// writeTimeout is the maximum time
+	// allowed to write a command + read reply.
+	writeTimeout = 10 * time.Second
+
+	// maxBackoff is the maximum time to wait
+	// between attempts at resubscribing.
+	maxBackoff = 30 * time.Second
+)
+
+// Client is a minimal Redis client, supporting PUBLISH + SUBSCRIBE.
+type Client struct {
+	addr     string
+	username string
+	password string
+	tls      *tls.Config
+
+	// conn is the connection used
+	// for publishing, protected by mu.
+	conn *conn
+	mu   sync.Mutex
+}
+
+// New returns a new Client for the Redis server at given URL, of the
+// form redis://[[username]:password@]host[:port], or rediss:// for TLS.
+// No connection is made until the client is first used.
+func New(rawURL string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	c := new(Client)
+
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		c.tls = &tls.Config{
+			ServerName: u.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		}
+	default:
+		return nil, fmt.Errorf("invalid redis url scheme %q, must be redis or rediss", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, errors.New("invalid redis url: no host")
+	}
+
+	c.addr = u.Host
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+
+	return c, nil
+}
+
+// Publish publishes message to the given channel.
+func (c *Client) Publish(ctx context.Context, channel string, message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		conn, err := c.dial(ctx)
+		if err != nil {
+			return err
+		}
+		c.conn = conn
+	}
+
+	if _, err := c.conn.do(ctx, "PUBLISH", []byte(channel), message); err != nil {
+		// Drop the connection on
+		// error, next call redials.
+		_ = c.conn.Close()
+		c.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+// Subscribe subscribes to the given channel, passing each message received to
+// recv, until context is cancelled. Connection errors are logged, and the
+// subscription re-established with backoff, so this only returns on cancel.
+func (c *Client) Subscribe(ctx context.Context, channel string, recv func([]byte)) {
+	var backoff time.Duration
+
+	for {
+		err := c.subscribe(ctx, channel, func(msg []byte) {
+			// Connection is evidently
+			// healthy, reset backoff.
+			backoff = 0
+			recv(msg)
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Increase backoff up to max.
+		backoff = min(2*backoff+time.Second, maxBackoff)
+		log.Errorf(ctx, "redis subscription to %s failed, retrying in %s: %v", channel, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// Channel returns a Channel for publishing
+// to / subscribing to the named channel.
+func (c *Client) Channel(name string) *Channel {
+	return &Channel{client: c, name: name}
+}
+
+// Channel wraps a Client to publish to
+// and subscribe to a single channel.
+type Channel struct {
+	client *Client
+	name   string
+}
+
+// Publish: see Client{}.Publish().
+func (ch *Channel) Publish(ctx context.Context, message []byte) error {
+	return ch.client.Publish(ctx, ch.name, message)
+}
+
+// Subscribe: see Client{}.Subscribe().
+func (ch *Channel) Subscribe(ctx context.Context, recv func([]byte)) {
+	ch.client.Subscribe(ctx, ch.name, recv)
+}
+
+// subscribe performs a single subscription attempt.
+func (c *Client) subscribe(ctx context.Context, channel string, recv func([]byte)) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Close connection on context cancel,
+	// as this unblocks the read loop below.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	if _, err := conn.do(ctx, "SUBSCRIBE", []byte(channel)); err != nil {
+		return err
+	}
+
+	// Subscribed connections
+	// have no read deadline.
+	_ = conn.SetDeadline(time.Time{})
+
+	for {
+		reply, err := conn.read()
+		if err != nil {
+			return err
+		}
+
+		// Pushed messages are of the
+		// form ["message", channel, data].
+		push, ok := reply.([]any)
+		if !ok || len(push) != 3 {
+			continue
+		}
+
+		if kind, _ := push[0].([]byte); string(kind) != "message" {
+			continue
+		}
+
+		if data, ok := push[2].([]byte); ok {
+			recv(data)
+		}
+	}
+}
+
+// dial opens a new connection to the
+// Redis server, authenticating if needed.
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var (
+		nc  net.Conn
+		err error
+	)
+
+	if c.tls != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: c.tls}
+		nc, err = tlsDialer.DialContext(ctx, "tcp", c.addr)
+	} else {
+		nc, err = dialer.DialContext(ctx, "tcp", c.addr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to redis: %w", err)
+	}
+
+	conn := &conn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if c.password != "" {
+		args := [][]byte{[]byte(c.password)}
+		if c.username != "" {
+			args = append([][]byte{[]byte(c.username)}, args...)
+		}
+
+		if _, err := conn.do(ctx, "AUTH", args...); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("error authenticating with redis: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// conn wraps a net.Conn to speak RESP.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// do writes the given command and
+// arguments, and reads a single reply.
+func (c *conn) do(ctx context.Context, cmd string, args ...[]byte) (any, error) {
+	deadline := time.Now().Add(writeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = c.SetDeadline(deadline)
+
+	if _, err := c.Write(encode(cmd, args)); err != nil {
+		return nil, fmt.Errorf("error writing redis command: %w", err)
+	}
+
+	reply, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err, ok := reply.(Error); ok {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// read reads a single RESP reply, returning one of
+// string, Error, int64, []byte, []any, or nil.
+func (c *conn) read() (any, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("error reading redis reply: %w", err)
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("malformed redis reply")
+	}
+
+	kind, body := line[0], string(line[1:len(line)-2])
+
+	switch kind {
+	case '+':
+		return body, nil
+
+	case '-':
+		return Error(body), nil
+
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis bulk length: %w", err)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		// Read bulk data + trailing CRLF.
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, fmt.Errorf("error reading redis reply: %w", err)
+		}
+
+		return data[:n], nil
+
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis array length: %w", err)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		array := make([]any, n)
+		for i := range array {
+			if array[i], err = c.read(); err != nil {
+				return nil, err
+			}
+		}
+
+		return array, nil
+
+	default:
+		return nil, fmt.Errorf("unknown redis reply type %q", kind)
+	}
+}
+
+// encode encodes the given command
+// and arguments as a RESP array.
+func encode(cmd string, args [][]byte) []byte {
+	b := make([]byte, 0, 64)
+	b = append(b, '*')
+	b = strconv.AppendInt(b, int64(len(args)+1), 10)
+	b = append(b, '\r', '\n')
+	b = appendBulk(b, []byte(cmd))
+	for _, arg := range args {
+		b = appendBulk(b, arg)
+	}
+	return b
+}
+
+// appendBulk appends data as a RESP bulk string.
+func appendBulk(b []byte, data []byte) []byte {
+	b = append(b, '$')
+	b = strconv.AppendInt(b, int64(len(data)), 10)
+	b = append(b, '\r', '\n')
+	b = append(b, data...)
+	return append(b, '\r', '\n')
+}
+
+// Error is an error reply from the Redis server.
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
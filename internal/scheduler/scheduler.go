@@ -24,8 +24,16 @@ import (
 
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-sched"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// Locker provides named locks shared between all
+// processes connected to the same database, e.g.
+// the database's db.SchedulerLock implementation.
+type Locker interface {
+	TryLockScheduler(ctx context.Context, name string, ttl time.Duration) (bool, error)
+}
+
 // Scheduler wraps an underlying scheduler to provide
 // task tracking by unique string identifiers, so jobs
 // may be cancelled with only an identifier.
@@ -33,6 +41,12 @@ type Scheduler struct {
 	sch sched.Scheduler
 	ts  map[string]*task
 	mu  sync.Mutex
+
+	// Locker is used by Exclusive() to elect the single
+	// process that runs a task, when many GoToSocial
+	// processes share a database (e.g. blue/green
+	// deployments). If nil, tasks always run.
+	Locker Locker
 }
 
 // Start attempts to start the scheduler. Returns false if already running.
@@ -84,6 +98,34 @@ func (sch *Scheduler) Cancel(id string) bool {
 	return true
 }
 
+// Exclusive wraps the given task function such that it only executes on the process that
+// holds the scheduler lock with given name, acquiring (or renewing) the lock for ttl on
+// each run. This ensures that of all processes sharing a database, only one will run
+// the task. TTL should exceed any schedule skew between processes, while being less
+// than the period between runs, so the lease is free to be acquired on the next run.
+func (sch *Scheduler) Exclusive(name string, ttl time.Duration, fn func(context.Context, time.Time)) func(context.Context, time.Time) {
+	if fn == nil {
+		panic("nil function")
+	}
+	return func(ctx context.Context, now time.Time) {
+		if sch.Locker != nil {
+			ok, err := sch.Locker.TryLockScheduler(ctx, name, ttl)
+			if err != nil {
+				log.Errorf(ctx, "error acquiring scheduler lock %s: %v", name, err)
+				return
+			}
+
+			if !ok {
+				// Lock is held by another process.
+				log.Debugf(ctx, "skipping task, scheduler lock %s held elsewhere", name)
+				return
+			}
+		}
+
+		fn(ctx, now)
+	}
+}
+
 func (sch *Scheduler) schedule(id string, fn func(context.Context, time.Time), t sched.Timing) bool {
 	if fn == nil {
 		panic("nil function")
@@ -93,6 +135,12 @@ func (sch *Scheduler) schedule(id string, fn func(context.Context, time.Time), t
 	sch.mu.Lock()
 	defer sch.mu.Unlock()
 
+	if !sch.sch.Running() {
+		// can't schedule
+		// when stopped.
+		return false
+	}
+
 	if _, ok := sch.ts[id]; ok {
 		// existing task already
 		// exists under this ID.
@@ -41,7 +41,6 @@ import (
 )
 
 const (
-	urlCacheTTL             = time.Hour * 24
 	urlCacheExpiryFrequency = time.Minute * 5
 )
 
@@ -72,6 +71,7 @@ type Driver struct {
 	// S3-only parameters
 	Proxy          bool
 	Bucket         string
+	PresignExpiry  time.Duration
 	PresignedCache *ttl.Cache[string, PresignedURL]
 }
 
@@ -133,7 +133,7 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 		return &e.Value
 	}
 
-	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, urlCacheTTL, url.Values{
+	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, d.PresignExpiry, url.Values{
 		"response-content-type": []string{mime.TypeByExtension(path.Ext(key))},
 	})
 	if err != nil {
@@ -143,7 +143,7 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 
 	psu := PresignedURL{
 		URL:    u,
-		Expiry: time.Now().Add(urlCacheTTL), // link expires in 24h time
+		Expiry: time.Now().Add(d.PresignExpiry),
 	}
 
 	d.PresignedCache.Set(key, psu)
@@ -247,6 +247,13 @@ func NewS3Storage() (*Driver, error) {
 	secret := config.GetStorageS3SecretKey()
 	secure := config.GetStorageS3UseSSL()
 	bucket := config.GetStorageS3BucketName()
+	expiry := config.GetStorageS3RedirectURLExpiry()
+
+	// The cache TTL is derived from the expiry, so ensure
+	// it leaves enough room for cached URLs to stay valid.
+	if expiry < 2*urlCacheExpiryFrequency {
+		return nil, fmt.Errorf("storage-s3-redirect-url-expiry must be at least %s", 2*urlCacheExpiryFrequency)
+	}
 
 	// Open the s3 storage implementation
 	s3, err := s3.Open(endpoint, bucket, &s3.Config{
@@ -266,12 +273,13 @@ func NewS3Storage() (*Driver, error) {
 	}
 
 	// ttl should be lower than the expiry used by S3 to avoid serving invalid URLs
-	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
+	presignedCache := ttl.New[string, PresignedURL](0, 1000, expiry-urlCacheExpiryFrequency)
 	presignedCache.Start(urlCacheExpiryFrequency)
 
 	return &Driver{
 		Proxy:          config.GetStorageS3Proxy(),
 		Bucket:         config.GetStorageS3BucketName(),
+		PresignExpiry:  expiry,
 		Storage:        s3,
 		PresignedCache: presignedCache,
 	}, nil
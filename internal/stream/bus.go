@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package stream
+
+import "context"
+
+// Bus is a message bus via which messages posted to
+// Streams{} are fanned out between multiple replicas of
+// an instance, each delivering them to the streams it holds,
+// e.g. a Redis pub/sub channel (see redis.Channel{}).
+type Bus interface {
+	// Publish publishes message to all subscribers of the bus.
+	Publish(ctx context.Context, message []byte) error
+
+	// Subscribe passes each message published on the bus
+	// (including by this replica) to recv, blocking until
+	// the context is cancelled. Any dropped connection
+	// must be re-established by the implementation.
+	Subscribe(ctx context.Context, recv func(message []byte))
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type BusTestSuite struct {
+	suite.Suite
+}
+
+// memBus is a simple in-memory stream.Bus{},
+// on which each subscriber receives all messages.
+type memBus struct {
+	subs []chan []byte
+	err  error
+	mu   sync.Mutex
+}
+
+func (b *memBus) Publish(ctx context.Context, message []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	for _, ch := range b.subs {
+		ch <- message
+	}
+	return nil
+}
+
+func (b *memBus) Subscribe(ctx context.Context, recv func([]byte)) {
+	ch := make(chan []byte, 10)
+	b.mu.Lock()
+	b.subs = append(b.subs, ch)
+	b.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			recv(msg)
+		}
+	}
+}
+
+func (suite *BusTestSuite) TestFanOut() {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	// Two "replicas" sharing one bus.
+	bus := new(memBus)
+	replica1 := &stream.Streams{Bus: bus}
+	replica2 := &stream.Streams{Bus: bus}
+	go replica1.Listen(ctx)
+	go replica2.Listen(ctx)
+
+	// Wait for both to subscribe.
+	suite.Eventually(func() bool {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+		return len(bus.subs) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Client connected to second replica.
+	str, err := replica2.Open("01F8MH1H7YV1Z7D2C8K2730QBF", stream.TimelineHome)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer str.Close()
+
+	// Account message posted by first replica.
+	replica1.Post(ctx, "01F8MH1H7YV1Z7D2C8K2730QBF", stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: "account",
+	})
+
+	msg, ok := str.Recv(ctx)
+	suite.True(ok)
+	suite.Equal("account", msg.Payload)
+
+	// Message to all accounts posted by first replica.
+	replica1.PostAll(ctx, stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeDelete,
+		Payload: "all",
+	})
+
+	msg, ok = str.Recv(ctx)
+	suite.True(ok)
+	suite.Equal("all", msg.Payload)
+}
+
+func (suite *BusTestSuite) TestPublishErrorPostsLocally() {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	// Bus that fails to publish.
+	bus := &memBus{err: errors.New("connection refused")}
+	replica := &stream.Streams{Bus: bus}
+
+	str, err := replica.Open("01F8MH1H7YV1Z7D2C8K2730QBF", stream.TimelineHome)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer str.Close()
+
+	// Message should still reach
+	// the streams held locally.
+	replica.Post(ctx, "01F8MH1H7YV1Z7D2C8K2730QBF", stream.Message{
+		Stream:  []string{stream.TimelineHome},
+		Event:   stream.EventTypeUpdate,
+		Payload: "account",
+	})
+
+	msg, ok := str.Recv(ctx)
+	suite.True(ok)
+	suite.Equal("account", msg.Payload)
+}
+
+func TestBusTestSuite(t *testing.T) {
+	suite.Run(t, new(BusTestSuite))
+}
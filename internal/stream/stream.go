@@ -19,10 +19,14 @@ package stream
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"maps"
 	"slices"
 	"sync"
 	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 const (
@@ -46,6 +50,24 @@ const (
 	// EventTypeFiltersChanged -- the user's filters
 	// (including keywords and statuses) have changed.
 	EventTypeFiltersChanged = "filters_changed"
+
+	// EventTypeConversation -- a direct
+	// conversation the user is participating
+	// in has been updated with a new status.
+	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- an instance
+	// announcement has been published or updated.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- the
+	// reactions to an instance announcement
+	// with a given name have changed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an
+	// instance announcement has been deleted.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -75,6 +97,16 @@ const (
 	// TimelineList:
 	// Updates to a specific list.
 	TimelineList = "list"
+
+	// TimelineHashtag:
+	// All public posts known to the
+	// server using a specific hashtag.
+	TimelineHashtag = "hashtag"
+
+	// TimelineHashtagLocal:
+	// All public posts originating from
+	// this server using a specific hashtag.
+	TimelineHashtagLocal = "hashtag:local"
 )
 
 // AllStatusTimelines contains all Timelines
@@ -88,30 +120,67 @@ var AllStatusTimelines = []string{
 	TimelineList,
 }
 
+// ErrTooManyStreams is returned by Streams{}.Open() when
+// opening a new stream would exceed the configured limits.
+var ErrTooManyStreams = errors.New("too many open streams")
+
 type Streams struct {
+	// MaxPerAccount is the maximum no. streams
+	// that may be open at once for one account.
+	// Values <= 0 mean no limit.
+	MaxPerAccount int
+
+	// MaxTotal is the maximum no. streams that
+	// may be open at once across all accounts.
+	// Values <= 0 mean no limit.
+	MaxTotal int
+
+	// BufferSize is the no. messages that may
+	// be buffered for each stream, after which
+	// the stream is closed as its client is not
+	// keeping up. Values <= 0 use a default.
+	BufferSize int
+
+	// Bus, if set, is the message bus via which
+	// posted messages are fanned out between instance
+	// replicas. Each replica must then be receiving
+	// from the bus via Streams{}.Listen() for messages
+	// to be delivered to the streams it holds.
+	Bus Bus
+
 	streams map[string][]*Stream
+	total   int
 	mutex   sync.Mutex
 }
 
-// Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
-func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
-	if len(streamTypes) == 0 {
-		panic("no stream types given")
+// Open will open open a new Stream for given account ID and stream types. If
+// no stream types are given, they may be added later with Stream{}.Subscribe().
+// Returns ErrTooManyStreams if this would exceed the configured stream limits.
+func (s *Streams) Open(accountID string, streamTypes ...string) (*Stream, error) {
+	bufSize := s.BufferSize
+	if bufSize <= 0 {
+		bufSize = 50
 	}
 
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
-	str.msgCh = make(chan Message, 50) // TODO: make configurable
+	str.msgCh = make(chan Message, bufSize)
 	for _, streamType := range streamTypes {
 		str.Subscribe(streamType)
 	}
 
-	// TODO: add configurable
-	// max streams per account.
-
 	// Acquire lock.
 	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.MaxTotal > 0 && s.total >= s.MaxTotal {
+		return nil, ErrTooManyStreams
+	}
+
+	if s.MaxPerAccount > 0 && len(s.streams[accountID]) >= s.MaxPerAccount {
+		return nil, ErrTooManyStreams
+	}
 
 	if s.streams == nil {
 		// Main stream-map needs allocating.
@@ -122,6 +191,7 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	strs := s.streams[accountID]
 	strs = append(strs, str)
 	s.streams[accountID] = strs
+	s.total++
 
 	// Register close callback
 	// to remove stream from our
@@ -132,18 +202,107 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		strs = slices.DeleteFunc(strs, func(s *Stream) bool {
 			return s == str // remove 'str' ptr
 		})
-		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			delete(s.streams, accountID)
+		} else {
+			s.streams[accountID] = strs
+		}
+		s.total--
 		s.mutex.Unlock()
 	}
 
+	return str, nil
+}
+
+// AccountIDs returns the IDs of all accounts with open streams
+// supporting any of given stream types. Note this only includes
+// the streams held here, and not those of any other replicas.
+func (s *Streams) AccountIDs(streamTypes ...string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Iterate all streams stored for each account.
+	for accountID, strs := range s.streams {
+		for _, str := range strs {
+			if str.getStreamType(streamTypes...) != "" {
+				accountIDs = append(accountIDs, accountID)
+				break
+			}
+		}
+	}
+
 	// Done with lock.
 	s.mutex.Unlock()
 
-	return str
+	return accountIDs
+}
+
+// busMessage is the JSON form of
+// a message published on Streams{}.Bus.
+type busMessage struct {
+	AccountID string  `json:"account_id,omitempty"`
+	Message   Message `json:"message"`
+}
+
+// Listen receives messages published on Streams{}.Bus, delivering
+// them to matching streams held here, until context is cancelled.
+func (s *Streams) Listen(ctx context.Context) {
+	s.Bus.Subscribe(ctx, func(data []byte) {
+		var msg busMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			log.Errorf(ctx, "error decoding stream message: %v", err)
+			return
+		}
+
+		if msg.AccountID == "" {
+			s.postAll(ctx, msg.Message)
+		} else {
+			s.post(ctx, msg.AccountID, msg.Message)
+		}
+	})
 }
 
 // Post will post the given message to all streams of given account ID matching type.
 func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool {
+	if s.Bus != nil && s.publish(ctx, accountID, msg) {
+		return true
+	}
+	return s.post(ctx, accountID, msg)
+}
+
+// PostAll will post the given message to all streams with matching types.
+func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
+	if s.Bus != nil && s.publish(ctx, "", msg) {
+		return true
+	}
+	return s.postAll(ctx, msg)
+}
+
+// publish publishes message for account ID to Streams{}.Bus, from which
+// it will be received and posted by each replica. On failure this returns
+// false, and the caller falls back to posting to the streams held here.
+func (s *Streams) publish(ctx context.Context, accountID string, msg Message) bool {
+	data, err := json.Marshal(busMessage{
+		AccountID: accountID,
+		Message:   msg,
+	})
+	if err != nil {
+		log.Errorf(ctx, "error encoding stream message: %v", err)
+		return false
+	}
+
+	if err := s.Bus.Publish(ctx, data); err != nil {
+		log.Errorf(ctx, "error publishing stream message: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// post will post the given message to all local streams of given account ID matching type.
+func (s *Streams) post(ctx context.Context, accountID string, msg Message) bool {
 	var deferred []func() bool
 
 	// Acquire lock.
@@ -192,8 +351,8 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	return ok
 }
 
-// PostAll will post the given message to all streams with matching types.
-func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
+// postAll will post the given message to all local streams with matching types.
+func (s *Streams) postAll(ctx context.Context, msg Message) bool {
 	var deferred []func() bool
 
 	// Acquire lock.
@@ -255,6 +414,7 @@ type Stream struct {
 
 	// protects stream close.
 	done chan struct{}
+	once sync.Once
 
 	// inbound msg ch.
 	msgCh chan Message
@@ -298,16 +458,20 @@ func (s *Stream) getStreamType(streamTypes ...string) string {
 	return ""
 }
 
-// send will block on posting a new Message{}, returning early with
-// a false value if provided context is canceled, or stream closed.
+// send will post a new Message{} without blocking, returning
+// a false value if stream is closed. If the stream's message
+// buffer is full, i.e. its client isn't keeping up, the stream
+// is closed rather than blocking the sender or buffering more.
 func (s *Stream) send(ctx context.Context, msg Message) bool {
 	select {
 	case <-s.done:
 		return false
-	case <-ctx.Done():
-		return false
 	case s.msgCh <- msg:
 		return true
+	default:
+		log.Warnf(ctx, "closing stream of slow client with %d buffered messages", len(s.msgCh))
+		s.Close()
+		return false
 	}
 }
 
@@ -327,12 +491,10 @@ func (s *Stream) Recv(ctx context.Context) (Message, bool) {
 // Close will close the underlying context, finally
 // removing it from the parent Streams per-account-map.
 func (s *Stream) Close() {
-	select {
-	case <-s.done:
-	default:
+	s.once.Do(func() {
 		close(s.done)
 		s.close()
-	}
+	})
 }
 
 // cas will perform a Compare And Swap operation on s.types using modifier func.
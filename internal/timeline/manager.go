@@ -29,6 +29,19 @@ import (
 const (
 	pruneLengthIndexed  = 400
 	pruneLengthPrepared = 50
+
+	// warmLength is the number of newest items to keep
+	// prepared in recently active timelines, which is
+	// the largest page size served by the client API.
+	warmLength = 40
+
+	// warmInterval is how often cold
+	// timelines are checked for warming.
+	warmInterval = 30 * time.Second
+
+	// warmActiveWithin is how recently a timeline must have
+	// been fetched for its owner to be considered active.
+	warmActiveWithin = 24 * time.Hour
 )
 
 // Manager abstracts functions for creating multiple timelines, and adding, removing, and fetching entries from those timelines.
@@ -83,6 +96,12 @@ type Manager interface {
 	// Use this for cache invalidation when the prepared representation of an item has changed.
 	UnprepareItemFromAllTimelines(ctx context.Context, itemID string) error
 
+	// WarmTimelines prepares the newest items of each recently fetched timeline that
+	// has had items unprepared since it was last warmed, so that its owner's next first
+	// page load is fast. It returns the number of timelines warmed. Once the manager is
+	// started this is called periodically in the background, so needn't be called directly.
+	WarmTimelines(ctx context.Context) int
+
 	// Prune manually triggers a prune operation for the given timelineID.
 	Prune(ctx context.Context, timelineID string, desiredPreparedItemsLength int, desiredIndexedItemsLength int) (int, error)
 
@@ -101,6 +120,7 @@ func NewManager(grabFunction GrabFunction, filterFunction FilterFunction, prepar
 		filterFunction:     filterFunction,
 		prepareFunction:    prepareFunction,
 		skipInsertFunction: skipInsertFunction,
+		stop:               make(chan struct{}),
 	}
 }
 
@@ -110,6 +130,15 @@ type manager struct {
 	filterFunction     FilterFunction
 	prepareFunction    PrepareFunction
 	skipInsertFunction SkipInsertFunction
+
+	// cold contains IDs of timelines
+	// with items unprepared since they
+	// were last warmed by WarmTimelines.
+	cold sync.Map
+
+	// stop is closed on Stop,
+	// ending warming routine.
+	stop chan struct{}
 }
 
 func (m *manager) Start() error {
@@ -152,10 +181,28 @@ func (m *manager) Start() error {
 		}
 	}()
 
+	// Start a background goroutine which keeps the
+	// newest items of recently active timelines
+	// prepared, so that first page loads are fast.
+	go func() {
+		ticker := time.NewTicker(warmInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.WarmTimelines(context.Background())
+			}
+		}
+	}()
+
 	return nil
 }
 
 func (m *manager) Stop() error {
+	close(m.stop)
 	return nil
 }
 
@@ -174,6 +221,7 @@ func (m *manager) Remove(ctx context.Context, timelineID string, itemID string)
 }
 
 func (m *manager) RemoveTimeline(ctx context.Context, timelineID string) error {
+	m.cold.Delete(timelineID)
 	m.timelines.Delete(timelineID)
 	return nil
 }
@@ -218,11 +266,13 @@ func (m *manager) UnprepareItemFromAllTimelines(ctx context.Context, itemID stri
 
 	// Work through all timelines held by this
 	// manager, and call Unprepare for each.
-	m.timelines.Range(func(_ any, v any) bool {
+	m.timelines.Range(func(k any, v any) bool {
 		if err := v.(Timeline).Unprepare(ctx, itemID); err != nil {
 			errs.Append(err)
 		}
 
+		m.cold.Store(k, struct{}{})
+
 		return true // always continue range
 	})
 
@@ -234,9 +284,43 @@ func (m *manager) UnprepareItemFromAllTimelines(ctx context.Context, itemID stri
 }
 
 func (m *manager) UnprepareItem(ctx context.Context, timelineID string, itemID string) error {
+	m.cold.Store(timelineID, struct{}{})
 	return m.getOrCreateTimeline(ctx, timelineID).Unprepare(ctx, itemID)
 }
 
+func (m *manager) WarmTimelines(ctx context.Context) int {
+	var warmed int
+
+	m.cold.Range(func(k any, _ any) bool {
+		// Mark as warm before preparing, so
+		// concurrent unprepares aren't lost.
+		m.cold.Delete(k)
+
+		v, ok := m.timelines.Load(k)
+		if !ok {
+			// Timeline since removed.
+			return true
+		}
+
+		timeline := v.(Timeline)
+		if time.Since(timeline.LastGot()) > warmActiveWithin {
+			// Owner not recently active, they can
+			// wait for preparing on next fetch.
+			return true
+		}
+
+		if err := timeline.Warm(ctx, warmLength); err != nil {
+			log.Errorf(ctx, "error warming timeline %s: %v", timeline.TimelineID(), err)
+			return true
+		}
+
+		warmed++
+		return true
+	})
+
+	return warmed
+}
+
 func (m *manager) Prune(ctx context.Context, timelineID string, desiredPreparedItemsLength int, desiredIndexedItemsLength int) (int, error) {
 	return m.getOrCreateTimeline(ctx, timelineID).Prune(desiredPreparedItemsLength, desiredIndexedItemsLength), nil
 }
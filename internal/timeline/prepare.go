@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -144,3 +145,7 @@ func (t *timeline) prepareXBetweenIDs(ctx context.Context, amount int, behindID
 
 	return nil
 }
+
+func (t *timeline) Warm(ctx context.Context, amount int) error {
+	return t.prepareXBetweenIDs(ctx, amount, id.Highest, id.Lowest, true)
+}
@@ -94,6 +94,11 @@ type Timeline interface {
 	// not need to be removed: it will be prepared again next time Get is called.
 	Unprepare(ctx context.Context, itemID string) error
 
+	// Warm prepares the newest amount of items in the timeline,
+	// indexing more first if needed. This is used to ensure the
+	// first page of an active timeline is quick to serve.
+	Warm(ctx context.Context, amount int) error
+
 	/*
 		INFO FUNCTIONS
 	*/
@@ -137,6 +137,39 @@ func (suite *UnprepareTestSuite) TestUnprepareFromFave() {
 	suite.True(targetStatus.Favourited)
 }
 
+func (suite *UnprepareTestSuite) TestWarmTimelines() {
+	var (
+		ctx            = context.Background()
+		activeAccount  = suite.testAccounts["local_account_1"]
+		idleAccount    = suite.testAccounts["local_account_2"]
+		targetStatusID = suite.highestStatusID
+	)
+
+	suite.fillTimeline(activeAccount.ID)
+	suite.fillTimeline(idleAccount.ID)
+
+	// Only the active account fetches their timeline.
+	if _, err := suite.state.Timelines.Home.GetTimeline(
+		ctx, activeAccount.ID, "", "", "", 20, false,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing is cold yet, so nothing to warm.
+	suite.Zero(suite.state.Timelines.Home.WarmTimelines(ctx))
+
+	// Unprepare the top status everywhere, e.g. after a fave.
+	if err := suite.state.Timelines.Home.UnprepareItemFromAllTimelines(ctx, targetStatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the active account's timeline should be warmed.
+	suite.Equal(1, suite.state.Timelines.Home.WarmTimelines(ctx))
+
+	// And now everything is warm again.
+	suite.Zero(suite.state.Timelines.Home.WarmTimelines(ctx))
+}
+
 func TestUnprepareTestSuite(t *testing.T) {
 	suite.Run(t, new(UnprepareTestSuite))
 }
@@ -114,6 +114,15 @@ func (i *importer) instanceDecode(e transmodel.Entry) (*transmodel.Instance, err
 	return inst, nil
 }
 
+func (i *importer) statusDecode(e transmodel.Entry) (*transmodel.Status, error) {
+	s := &transmodel.Status{}
+	if err := i.simpleDecode(e, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
 func (i *importer) userDecode(e transmodel.Entry) (*transmodel.User, error) {
 	u := &transmodel.User{}
 	if err := i.simpleDecode(e, u); err != nil {
@@ -205,6 +205,67 @@ func (e *exporter) exportInstances(ctx context.Context, file *os.File) ([]*trans
 	return instances, nil
 }
 
+func (e *exporter) exportStatuses(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.Status, error) {
+	statuses := []*transmodel.Status{}
+
+	// for each account we want to export the statuses it authored, excluding boosts
+	for _, a := range accounts {
+		whereAuthored := []db.Where{
+			{Key: "account_id", Value: a.ID},
+			{Key: "boost_of_id", Value: nil},
+		}
+		authored := []*transmodel.Status{}
+		if err := e.db.GetWhere(ctx, whereAuthored, &authored); err != nil {
+			return nil, fmt.Errorf("exportStatuses: error selecting statuses owned by account %s: %s", a.ID, err)
+		}
+		statuses = append(statuses, authored...)
+	}
+
+	// note which statuses are being exported
+	exported := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		exported[s.ID] = true
+	}
+
+	for _, s := range statuses {
+		if s.InReplyToID != "" && !exported[s.InReplyToID] {
+			// replied-to status won't be in the export, so only
+			// keep the URI, which can be dereferenced on import
+			s.InReplyToID = ""
+			s.InReplyToAccountID = ""
+		}
+
+		s.Type = transmodel.TransStatus
+		if err := e.simpleEncode(ctx, file, s, s.ID); err != nil {
+			return nil, fmt.Errorf("exportStatuses: error encoding status %s: %s", s.ID, err)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (e *exporter) exportAccountUsers(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.User, error) {
+	users := []*transmodel.User{}
+
+	// for each account we want to export the user it belongs to
+	for _, a := range accounts {
+		whereUser := []db.Where{{Key: "account_id", Value: a.ID}}
+		accountUsers := []*transmodel.User{}
+		if err := e.db.GetWhere(ctx, whereUser, &accountUsers); err != nil {
+			return nil, fmt.Errorf("exportAccountUsers: error selecting user of account %s: %s", a.ID, err)
+		}
+		for _, u := range accountUsers {
+			u.Type = transmodel.TransUser
+			if err := e.simpleEncode(ctx, file, u, u.ID); err != nil {
+				return nil, fmt.Errorf("exportAccountUsers: error encoding user of account %s: %s", a.ID, err)
+			}
+		}
+		users = append(users, accountUsers...)
+	}
+
+	return users, nil
+}
+
 func (e *exporter) exportUsers(ctx context.Context, file *os.File) ([]*transmodel.User, error) {
 	users := []*transmodel.User{}
 
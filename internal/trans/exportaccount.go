@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+func (e *exporter) ExportAccount(ctx context.Context, path string, username string) error {
+	if path == "" {
+		return errors.New("ExportAccount: path empty")
+	}
+
+	if username == "" {
+		return errors.New("ExportAccount: username empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: couldn't export to %s: %s", path, err)
+	}
+
+	// export the local account with the given username
+	accounts, err := e.exportAccounts(ctx, []db.Where{
+		{Key: "username", Value: username},
+		{Key: "domain", Value: nil},
+	}, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting account: %s", err)
+	}
+
+	if len(accounts) == 0 {
+		return fmt.Errorf("ExportAccount: no local account found with username %s", username)
+	}
+
+	// export the user belonging to the account
+	if _, err := e.exportAccountUsers(ctx, accounts, file); err != nil {
+		return fmt.Errorf("ExportAccount: error exporting user: %s", err)
+	}
+
+	// export all blocks that relate to the account
+	blocks, err := e.exportBlocks(ctx, accounts, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting blocks: %s", err)
+	}
+
+	// for each block, make sure we've written out the other account involved in it
+	for _, b := range blocks {
+		if err := e.exportAccountIDs(ctx, file, b.AccountID, b.TargetAccountID); err != nil {
+			return fmt.Errorf("ExportAccount: error exporting block account: %s", err)
+		}
+	}
+
+	// export all follows that relate to the account
+	follows, err := e.exportFollows(ctx, accounts, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting follows: %s", err)
+	}
+
+	// for each follow, make sure we've written out the other account involved in it
+	for _, follow := range follows {
+		if err := e.exportAccountIDs(ctx, file, follow.AccountID, follow.TargetAccountID); err != nil {
+			return fmt.Errorf("ExportAccount: error exporting follow account: %s", err)
+		}
+	}
+
+	// export all follow requests that relate to the account
+	followRequests, err := e.exportFollowRequests(ctx, accounts, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting follow requests: %s", err)
+	}
+
+	// for each follow request, make sure we've written out the other account involved in it
+	for _, fr := range followRequests {
+		if err := e.exportAccountIDs(ctx, file, fr.AccountID, fr.TargetAccountID); err != nil {
+			return fmt.Errorf("ExportAccount: error exporting follow request account: %s", err)
+		}
+	}
+
+	// export all statuses authored by the account
+	if _, err := e.exportStatuses(ctx, accounts, file); err != nil {
+		return fmt.Errorf("ExportAccount: error exporting statuses: %s", err)
+	}
+
+	return neatClose(file)
+}
+
+// exportAccountIDs exports each of the accounts with
+// the given IDs, where they haven't been written yet.
+func (e *exporter) exportAccountIDs(ctx context.Context, file *os.File, ids ...string) error {
+	for _, id := range ids {
+		if e.writtenIDs[id] {
+			continue
+		}
+
+		if _, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: id}}, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
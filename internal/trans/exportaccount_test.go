@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ExportAccountTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *ExportAccountTestSuite) TestExportImportAccount() {
+	var (
+		ctx          = context.Background()
+		zork         = suite.testAccounts["local_account_1"]
+		localAcct2   = suite.testAccounts["local_account_2"]
+		adminAcct    = suite.testAccounts["admin_account"]
+		whereAuthor  = []db.Where{{Key: "account_id", Value: zork.ID}, {Key: "boost_of_id", Value: nil}}
+		tempFilePath = fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+	)
+
+	statusesBefore := []*gtsmodel.Status{}
+	if err := suite.db.GetWhere(ctx, whereAuthor, &statusesBefore); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(statusesBefore)
+
+	// export zork to the tempFilePath
+	exporter := trans.NewExporter(suite.db)
+	err := exporter.ExportAccount(ctx, tempFilePath, zork.Username)
+	suite.NoError(err)
+
+	var state state.State
+	state.Caches.Init()
+
+	// create a new database with just the tables created,
+	// and only one of the accounts zork has follows with
+	newDB := testrig.NewTestDB(&state)
+	if err := newDB.PutAccount(ctx, localAcct2); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	importer := trans.NewImporter(newDB)
+	err = importer.ImportAccount(ctx, tempFilePath)
+	suite.NoError(err)
+
+	// zork and their user should now be in the database
+	zorkAfter, err := newDB.GetAccountByID(ctx, zork.ID)
+	suite.NoError(err)
+	suite.Equal(zork.URI, zorkAfter.URI)
+	suite.True(zork.PrivateKey.Equal(zorkAfter.PrivateKey))
+	suite.True(zork.PublicKey.Equal(zorkAfter.PublicKey))
+
+	_, err = newDB.GetUserByAccountID(ctx, zork.ID)
+	suite.NoError(err)
+
+	// zork's statuses should have been imported
+	statusesAfter := []*gtsmodel.Status{}
+	if err := newDB.GetWhere(ctx, whereAuthor, &statusesAfter); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(statusesAfter, len(statusesBefore))
+
+	// follows with the existing account should be imported...
+	following, err := newDB.IsFollowing(ctx, zork.ID, localAcct2.ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	followed, err := newDB.IsFollowing(ctx, localAcct2.ID, zork.ID)
+	suite.NoError(err)
+	suite.True(followed)
+
+	// ...but not with the local account missing from here
+	_, err = newDB.GetAccountByID(ctx, adminAcct.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	following, err = newDB.IsFollowing(ctx, zork.ID, adminAcct.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	// importing a second time should fail as zork now exists
+	err = importer.ImportAccount(ctx, tempFilePath)
+	suite.ErrorContains(err, "local account the_mighty_zork already exists")
+}
+
+func TestExportAccountTestSuite(t *testing.T) {
+	suite.Run(t, &ExportAccountTestSuite{})
+}
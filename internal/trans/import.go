@@ -134,6 +134,16 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		}
 		log.Infof(ctx, "added instance with id %s", inst.ID)
 		return nil
+	case transmodel.TransStatus:
+		status, err := i.statusDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into status: %s", err)
+		}
+		if err := i.putInDB(ctx, status); err != nil {
+			return fmt.Errorf("inputEntry: error adding status to database: %s", err)
+		}
+		log.Infof(ctx, "added status with id %s", status.ID)
+		return nil
 	case transmodel.TransUser:
 		user, err := i.userDecode(entry)
 		if err != nil {
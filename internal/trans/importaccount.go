@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// accountIDKeys are the (lowercase) keys of entry
+// fields which may refer to an exported account ID.
+var accountIDKeys = []string{
+	"accountid",
+	"targetaccountid",
+	"inreplytoaccountid",
+}
+
+// accountImport tracks the state of an
+// ongoing import of a single local account.
+type accountImport struct {
+	// id of the local account being imported,
+	// always the first account in the file
+	accountID string
+
+	// accounts that already exist in the database
+	// will be reused, so track their old -> existing IDs
+	existing map[string]string
+
+	// local accounts of the exporting instance that
+	// don't exist here, so entries referring to them
+	// need to be skipped
+	skipped map[string]bool
+}
+
+func (i *importer) ImportAccount(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("ImportAccount: path empty")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ImportAccount: couldn't import from %s: %s", path, err)
+	}
+
+	imp := &accountImport{
+		existing: make(map[string]string),
+		skipped:  make(map[string]bool),
+	}
+
+	// input accounts first, as other
+	// entries may refer to them, then
+	// go back over everything else
+	for _, accounts := range []bool{true, false} {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("ImportAccount: error seeking file: %s", err)
+		}
+
+		decoder := json.NewDecoder(file)
+		decoder.UseNumber()
+
+		for {
+			entry := transmodel.Entry{}
+			err := decoder.Decode(&entry)
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return fmt.Errorf("ImportAccount: error decoding in readLoop: %s", err)
+			}
+
+			isAccount := entry[transmodel.TypeKey] == string(transmodel.TransAccount)
+			if isAccount != accounts {
+				continue
+			}
+
+			if err := i.inputAccountEntry(ctx, entry, imp); err != nil {
+				return fmt.Errorf("ImportAccount: error inputting entry: %s", err)
+			}
+		}
+	}
+
+	log.Infof(ctx, "reached end of file")
+	return neatClose(file)
+}
+
+func (i *importer) inputAccountEntry(ctx context.Context, entry transmodel.Entry, imp *accountImport) error {
+	t, ok := entry[transmodel.TypeKey].(string)
+	if !ok {
+		return errors.New("inputAccountEntry: could not derive entry type: missing or malformed 'type' key in json")
+	}
+
+	if transmodel.Type(t) == transmodel.TransAccount {
+		account, err := i.accountDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputAccountEntry: error decoding entry into account: %s", err)
+		}
+
+		if imp.accountID == "" {
+			// first account is the one being
+			// imported, ensure it can be served here
+			if account.Domain != "" {
+				return fmt.Errorf("inputAccountEntry: account %s is not a local account", account.URI)
+			}
+			if err := checkAccountImportable(ctx, i.db, account); err != nil {
+				return fmt.Errorf("inputAccountEntry: %w", err)
+			}
+			imp.accountID = account.ID
+			return i.inputEntry(ctx, entry)
+		}
+
+		// related account, reuse it if already known
+		existing, err := i.db.GetAccountByURI(gtscontext.SetBarebones(ctx), account.URI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("inputAccountEntry: error checking for existing account %s: %s", account.URI, err)
+		}
+
+		switch {
+		case existing != nil:
+			log.Infof(ctx, "reusing existing account %s", account.URI)
+			imp.existing[account.ID] = existing.ID
+			return nil
+		case account.Domain == "":
+			log.Infof(ctx, "skipping missing local account %s", account.URI)
+			imp.skipped[account.ID] = true
+			return nil
+		default:
+			return i.inputEntry(ctx, entry)
+		}
+	}
+
+	// point any references at reused accounts
+	for key, value := range entry {
+		if !isAccountIDKey(key) {
+			continue
+		}
+
+		id, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		if imp.skipped[id] {
+			log.Infof(ctx, "skipping %s referring to missing account %s", t, id)
+			return nil
+		}
+
+		if existing := imp.existing[id]; existing != "" {
+			entry[key] = existing
+		}
+	}
+
+	return i.inputEntry(ctx, entry)
+}
+
+// checkAccountImportable checks that the given local account,
+// exported from another instance, may be imported into this one.
+func checkAccountImportable(ctx context.Context, database db.DB, account *transmodel.Account) error {
+	uri, err := url.Parse(account.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing account uri %s: %s", account.URI, err)
+	}
+
+	// the account's uri and keys only
+	// work when served from the same host
+	if host := config.GetHost(); uri.Host != host {
+		return fmt.Errorf("account %s was exported from host %s, not %s", account.Username, uri.Host, host)
+	}
+
+	_, err = database.GetAccountByUsernameDomain(gtscontext.SetBarebones(ctx), account.Username, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("error checking for existing account %s: %s", account.Username, err)
+	}
+	if err == nil {
+		return fmt.Errorf("local account %s already exists", account.Username)
+	}
+
+	return nil
+}
+
+func isAccountIDKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range accountIDKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,7 @@ import (
 // Importer wraps functionality for importing entries from a file into the database.
 type Importer interface {
 	Import(ctx context.Context, path string) error
+	ImportAccount(ctx context.Context, path string) error
 }
 
 type importer struct {
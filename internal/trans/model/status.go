@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// Status represents a local account's status as serialized in an export file.
+type Status struct {
+	Type                Type       `json:"type" bun:"-"`
+	ID                  string     `json:"id" bun:",nullzero"`
+	CreatedAt           *time.Time `json:"createdAt" bun:",nullzero"`
+	UpdatedAt           *time.Time `json:"updatedAt,omitempty" bun:",nullzero"`
+	URI                 string     `json:"uri" bun:",nullzero"`
+	URL                 string     `json:"url,omitempty" bun:",nullzero"`
+	Content             string     `json:"content,omitempty" bun:""`
+	Text                string     `json:"text,omitempty" bun:""`
+	Local               *bool      `json:"local" bun:",nullzero,notnull,default:false"`
+	AccountID           string     `json:"accountId" bun:",nullzero"`
+	AccountURI          string     `json:"accountUri" bun:",nullzero"`
+	InReplyToID         string     `json:"inReplyToId,omitempty" bun:",nullzero"`
+	InReplyToURI        string     `json:"inReplyToUri,omitempty" bun:",nullzero"`
+	InReplyToAccountID  string     `json:"inReplyToAccountId,omitempty" bun:",nullzero"`
+	ContentWarning      string     `json:"contentWarning,omitempty" bun:",nullzero"`
+	Visibility          string     `json:"visibility" bun:",nullzero"`
+	Sensitive           *bool      `json:"sensitive" bun:",nullzero,notnull,default:false"`
+	Language            string     `json:"language,omitempty" bun:",nullzero"`
+	ActivityStreamsType string     `json:"activityStreamsType" bun:",nullzero"`
+	Federated           *bool      `json:"federated"`
+	Boostable           *bool      `json:"boostable"`
+	Replyable           *bool      `json:"replyable"`
+	Likeable            *bool      `json:"likeable"`
+}
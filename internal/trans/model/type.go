@@ -32,6 +32,7 @@ const (
 	TransFollow           Type = "follow"
 	TransFollowRequest    Type = "followRequest"
 	TransInstance         Type = "instance"
+	TransStatus           Type = "status"
 	TransUser             Type = "user"
 )
 
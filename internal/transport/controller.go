@@ -36,7 +36,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 )
 
 // Controller generates transports for use in making federation requests to other servers.
@@ -46,6 +50,11 @@ type Controller interface {
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+
+	// SignDelivery adds request signing to the given delivery, (re)deriving
+	// a transport from its public key ID. This is required for deliveries
+	// that have been deserialized, e.g. after popping from a retry queue.
+	SignDelivery(dlv *delivery.Delivery) error
 }
 
 type controller struct {
@@ -140,6 +149,64 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 	return transport, nil
 }
 
+func (c *controller) SignDelivery(dlv *delivery.Delivery) error {
+	if dlv.PubKeyID == "" {
+		return errors.New("delivery has no public key id")
+	}
+
+	// Get delivery request context.
+	ctx := dlv.Request.Context()
+
+	// Fetch the signing account by its public key ID.
+	account, err := c.state.DB.GetAccountByPubkeyID(ctx, dlv.PubKeyID)
+	if err != nil {
+		return gtserror.Newf("error getting account for %s: %w", dlv.PubKeyID, err)
+	}
+
+	if account.PrivateKey == nil {
+		return gtserror.Newf("account %s has no private key", account.ID)
+	}
+
+	// Get (cached) transport for account.
+	transp, err := c.NewTransport(
+		account.PublicKeyURI,
+		account.PrivateKey,
+	)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	var body []byte
+
+	if dlv.Request.GetBody != nil {
+		// Fetch a fresh copy of request body.
+		rbody, err := dlv.Request.GetBody()
+		if err != nil {
+			return gtserror.Newf("error getting request body: %w", err)
+		}
+
+		// Read body data for signing.
+		body, err = io.ReadAll(rbody)
+		_ = rbody.Close()
+		if err != nil {
+			return gtserror.Newf("error reading request body: %w", err)
+		}
+	}
+
+	// Prepare POST signer for body.
+	t := transp.(*transport)
+	sign := t.signPOST(body)
+
+	// Update request context with signing details.
+	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
+	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetIdempotent(ctx)
+	ctx = httpclient.SetClass(ctx, httpclient.ClassDelivery)
+	dlv.Request.Request = dlv.Request.Request.WithContext(ctx)
+
+	return nil
+}
+
 // dereferenceLocalFollowers is a shortcut to dereference followers of an
 // account on this instance, without making any external api/http calls.
 //
@@ -139,9 +139,12 @@ func (t *transport) prepare(
 	// (this handles necessary rewinding).
 	body := bytes.NewReader(data)
 
-	// Update to-be-used request context with signing details.
+	// Update to-be-used request context with signing details,
+	// marking as idempotent as recipients dedupe by activity ID.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetIdempotent(ctx)
+	ctx = httpclient.SetClass(ctx, httpclient.ClassDelivery)
 
 	// Prepare a new request with data body directed at URL.
 	r, err := http.NewRequestWithContext(ctx, "POST", to.String(), body)
@@ -162,6 +165,7 @@ func (t *transport) prepare(
 	}
 
 	return &delivery.Delivery{
+		PubKeyID: t.pubKeyID,
 		ActorID:  actorID,
 		ObjectID: objectID,
 		TargetID: targetID,
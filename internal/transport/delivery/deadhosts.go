@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// deadHostProbeFreq is the frequency at
+// which a single delivery will be let
+// through to a paused host as a probe.
+const deadHostProbeFreq = time.Hour
+
+// PausedHost contains details
+// of a paused (i.e. dead) host.
+type PausedHost struct {
+	Host         string
+	Failures     int
+	FailingSince time.Time
+	NextProbeAt  time.Time
+}
+
+// DeadHosts tracks consecutive delivery failures per destination host,
+// pausing deliveries to hosts that have consistently failed for longer
+// than a configured period. While paused, a single probe delivery is
+// let through periodically, and on any successful delivery the host
+// is resumed. The zero value is safe to use, and pauses no hosts.
+type DeadHosts struct {
+
+	// After is the length of time a host must
+	// be consistently failing deliveries for
+	// before it is paused. 0 disables pausing.
+	After time.Duration
+
+	// internal fields.
+	hosts map[string]*hostFailures
+	mutex sync.Mutex
+}
+
+// hostFailures contains
+// failure details for host.
+type hostFailures struct {
+	count  int
+	first  time.Time
+	probe  time.Time
+	paused bool
+}
+
+// Allow returns whether a delivery to host may be attempted. When false,
+// host is paused, and the returned time is when the next probe is due.
+func (d *DeadHosts) Allow(host string) (time.Time, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Look for failures for host.
+	f, ok := d.hosts[host]
+	if !ok {
+		return time.Time{}, true
+	}
+
+	if !f.paused {
+		// Failing, but
+		// not yet paused.
+		return time.Time{}, true
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	if now.Before(f.probe) {
+		// Paused, and a
+		// probe isn't due.
+		return f.probe, false
+	}
+
+	// Allow through a single
+	// probe, and schedule next.
+	f.probe = now.Add(deadHostProbeFreq)
+	return time.Time{}, true
+}
+
+// Failure marks a failed delivery to host.
+func (d *DeadHosts) Failure(ctx context.Context, host string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.After <= 0 {
+		// Pausing disabled,
+		// nothing to track.
+		return
+	}
+
+	if d.hosts == nil {
+		// Allocate hosts map.
+		d.hosts = make(map[string]*hostFailures)
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	f, ok := d.hosts[host]
+	if !ok {
+		// Track new failing host.
+		f = &hostFailures{first: now}
+		d.hosts[host] = f
+	}
+
+	f.count++
+
+	if !f.paused && now.Sub(f.first) >= d.After {
+		// Host has been failing for long enough, pause it and schedule first probe.
+		log.Warnf(ctx, "pausing deliveries to %s after %d failures since %s",
+			host, f.count, f.first.Format(time.RFC3339))
+		f.probe = now.Add(deadHostProbeFreq)
+		f.paused = true
+	}
+}
+
+// Success marks a successful delivery to host, resuming it if paused.
+func (d *DeadHosts) Success(ctx context.Context, host string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	f, ok := d.hosts[host]
+	if !ok {
+		return
+	}
+
+	if f.paused {
+		log.Infof(ctx, "resuming deliveries to %s", host)
+	}
+
+	delete(d.hosts, host)
+}
+
+// Paused returns details of all currently paused hosts, sorted by host.
+func (d *DeadHosts) Paused() []PausedHost {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var paused []PausedHost
+
+	for host, f := range d.hosts {
+		if !f.paused {
+			continue
+		}
+
+		paused = append(paused, PausedHost{
+			Host:         host,
+			Failures:     f.count,
+			FailingSince: f.first,
+			NextProbeAt:  f.probe,
+		})
+	}
+
+	slices.SortFunc(paused, func(a, b PausedHost) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+
+	return paused
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestDeadHosts(t *testing.T) {
+	ctx := context.Background()
+	hosts := delivery.DeadHosts{After: time.Millisecond}
+
+	// Fresh host should be allowed.
+	_, ok := hosts.Allow("dead.example.org")
+	assert.True(t, ok)
+
+	// Failing host should not be paused
+	// until it has failed for long enough.
+	hosts.Failure(ctx, "dead.example.org")
+	_, ok = hosts.Allow("dead.example.org")
+	assert.True(t, ok)
+	assert.Empty(t, hosts.Paused())
+
+	time.Sleep(2 * time.Millisecond)
+	hosts.Failure(ctx, "dead.example.org")
+
+	// Host should now be paused until next probe.
+	until, ok := hosts.Allow("dead.example.org")
+	assert.False(t, ok)
+	assert.True(t, until.After(time.Now()))
+
+	// Other hosts are unaffected.
+	_, ok = hosts.Allow("alive.example.org")
+	assert.True(t, ok)
+
+	paused := hosts.Paused()
+	if assert.Len(t, paused, 1) {
+		assert.Equal(t, "dead.example.org", paused[0].Host)
+		assert.Equal(t, 2, paused[0].Failures)
+		assert.Equal(t, until, paused[0].NextProbeAt)
+	}
+
+	// A success should resume host.
+	hosts.Success(ctx, "dead.example.org")
+	_, ok = hosts.Allow("dead.example.org")
+	assert.True(t, ok)
+	assert.Empty(t, hosts.Paused())
+}
+
+func TestDeadHostsDisabled(t *testing.T) {
+	ctx := context.Background()
+	var hosts delivery.DeadHosts
+
+	hosts.Failure(ctx, "dead.example.org")
+	time.Sleep(time.Millisecond)
+	hosts.Failure(ctx, "dead.example.org")
+
+	_, ok := hosts.Allow("dead.example.org")
+	assert.True(t, ok)
+	assert.Empty(t, hosts.Paused())
+}
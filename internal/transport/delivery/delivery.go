@@ -58,7 +58,8 @@ type Delivery struct {
 	Request httpclient.Request
 
 	// internal fields.
-	next time.Time
+	next     time.Time
+	attempts int // persisted retry attempts
 }
 
 // delivery is an internal type
@@ -143,6 +144,11 @@ func (dlv *Delivery) Deserialize(data []byte) error {
 		return err
 	}
 
+	if idlv.Header != nil {
+		// Restore request headers.
+		r.Header = idlv.Header
+	}
+
 	// Wrap request in httpclient type.
 	dlv.Request = httpclient.WrapRequest(r)
 
@@ -156,3 +162,12 @@ func (dlv *Delivery) backoff() time.Duration {
 	}
 	return time.Until(dlv.next)
 }
+
+// host returns the destination host of delivery.
+func (dlv *Delivery) host() string {
+	if dlv.Request.Request == nil ||
+		dlv.Request.URL == nil {
+		return ""
+	}
+	return dlv.Request.URL.Host
+}
@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"sync"
+
+	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/queue"
+)
+
+// HostQueue provides a Delivery{} queue sharded by destination
+// host, where each host has its own queue.StructQueue{}. Popping
+// from the queue drains each host in turn (round-robin), so a single
+// host with a large number of queued deliveries (e.g. a large instance
+// with many followers) cannot starve deliveries to other hosts.
+type HostQueue struct {
+
+	// Stats tracks deliveries enqueued
+	// to, and attempted from, this queue.
+	Stats queue.Stats
+
+	// internal fields.
+	config structr.QueueConfig[*Delivery]
+	hosts  map[string]*queue.StructQueue[*Delivery]
+	ring   []string // hosts with queued deliveries
+	next   int      // next index in ring to pop from
+	wait   chan struct{}
+	mutex  sync.Mutex
+}
+
+// Init initializes queue with structr.QueueConfig{},
+// which is used to initialize each per-host queue.
+func (q *HostQueue) Init(config structr.QueueConfig[*Delivery]) {
+	q.mutex.Lock()
+	q.config = config
+	q.hosts = make(map[string]*queue.StructQueue[*Delivery])
+	q.ring = q.ring[:0]
+	q.next = 0
+	q.mutex.Unlock()
+}
+
+// Pop will pop the next delivery, from the next host in the ring.
+func (q *HostQueue) Pop() (dlv *Delivery, ok bool) {
+	q.mutex.Lock()
+
+	for len(q.ring) > 0 {
+		if q.next >= len(q.ring) {
+			// Wrap around ring.
+			q.next = 0
+		}
+
+		// Get next host queue in ring.
+		host := q.ring[q.next]
+		hostq := q.hosts[host]
+
+		// Pop next delivery from host.
+		dlv, ok = hostq.Pop()
+
+		if !ok || hostq.Len() == 0 {
+			// Host queue drained, drop
+			// it from the ring. The next
+			// host now occupies q.next.
+			q.remove(q.next)
+
+			if !ok {
+				continue
+			}
+		} else {
+			// Move onto
+			// next host.
+			q.next++
+		}
+
+		break
+	}
+
+	q.mutex.Unlock()
+	return
+}
+
+// Push will push given deliveries to the queues of their destination hosts.
+func (q *HostQueue) Push(dlvs ...*Delivery) {
+	q.mutex.Lock()
+
+	for _, dlv := range dlvs {
+		// Get host for delivery.
+		host := dlv.host()
+
+		// Look for existing host queue.
+		hostq, ok := q.hosts[host]
+		if !ok {
+
+			// Allocate new host queue.
+			hostq = new(queue.StructQueue[*Delivery])
+			hostq.Init(q.config)
+
+			// Add host to the end of ring.
+			q.hosts[host] = hostq
+			q.ring = append(q.ring, host)
+		}
+
+		// Push to host queue.
+		hostq.Push(dlv)
+	}
+
+	q.Stats.Enqueue(len(dlvs))
+
+	if q.wait != nil {
+		// Notify any goroutines
+		// blocking on q.Wait().
+		close(q.wait)
+		q.wait = nil
+	}
+
+	q.mutex.Unlock()
+}
+
+// Delete pops (and drops!) all queued entries under index with key, in all host queues.
+func (q *HostQueue) Delete(index string, key ...any) {
+	q.mutex.Lock()
+
+	for i := 0; i < len(q.ring); {
+		hostq := q.hosts[q.ring[i]]
+
+		// Drop entries from host queue.
+		hostq.Delete(index, key...)
+
+		if hostq.Len() == 0 {
+			// Drop empty host, next
+			// host now occupies 'i'.
+			q.remove(i)
+			continue
+		}
+
+		i++
+	}
+
+	q.mutex.Unlock()
+}
+
+// Len returns the total number of queued deliveries.
+func (q *HostQueue) Len() int {
+	var n int
+	q.mutex.Lock()
+	for _, hostq := range q.hosts {
+		n += hostq.Len()
+	}
+	q.mutex.Unlock()
+	return n
+}
+
+// Wait returns current wait channel, which may be
+// blocked on to awaken when new value pushed to queue.
+func (q *HostQueue) Wait() <-chan struct{} {
+	q.mutex.Lock()
+	if q.wait == nil {
+		q.wait = make(chan struct{})
+	}
+	ch := q.wait
+	q.mutex.Unlock()
+	return ch
+}
+
+// remove drops host at index in ring. Must hold lock.
+func (q *HostQueue) remove(i int) {
+	delete(q.hosts, q.ring[i])
+	copy(q.ring[i:], q.ring[i+1:])
+	q.ring = q.ring[:len(q.ring)-1]
+	if i < q.next {
+		q.next--
+	}
+}
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"testing"
+
+	"codeberg.org/gruf/go-structr"
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestHostQueueRoundRobin(t *testing.T) {
+	var q delivery.HostQueue
+	q.Init(structr.QueueConfig[*delivery.Delivery]{
+		Indices: []structr.IndexConfig{
+			{Fields: "TargetID", Multiple: true},
+		},
+	})
+
+	// Queue up many deliveries to one big host,
+	// followed by a single one to some others.
+	for i := 0; i < 10; i++ {
+		q.Push(toDelivery("https://big.example.org/inbox", "big"))
+	}
+	q.Push(toDelivery("https://small.example.org/inbox", "small"))
+	q.Push(toDelivery("https://tiny.example.org/inbox", "tiny"))
+	assert.Equal(t, 12, q.Len())
+
+	// Smaller hosts should not have to
+	// wait for the big host to drain.
+	var hosts []string
+	for i := 0; i < 4; i++ {
+		dlv, ok := q.Pop()
+		assert.True(t, ok)
+		hosts = append(hosts, dlv.Request.URL.Host)
+	}
+	assert.Equal(t, []string{
+		"big.example.org",
+		"small.example.org",
+		"tiny.example.org",
+		"big.example.org",
+	}, hosts)
+
+	// Dropping by index should
+	// work across host queues.
+	q.Push(toDelivery("https://gone.example.org/inbox", "gone"))
+	q.Delete("TargetID", "gone")
+	assert.Equal(t, 8, q.Len())
+
+	// Remaining should all be for big host.
+	for i := 0; i < 8; i++ {
+		dlv, ok := q.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, "big.example.org", dlv.Request.URL.Host)
+	}
+
+	_, ok := q.Pop()
+	assert.False(t, ok)
+}
+
+// toDelivery creates a Delivery{} POSTing to URL with target ID.
+func toDelivery(url string, targetID string) *delivery.Delivery {
+	return &delivery.Delivery{
+		TargetID: targetID,
+		Request:  toRequest("POST", url, []byte("data!")),
+	}
+}
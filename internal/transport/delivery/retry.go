@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// starting backoff between
+	// persisted delivery attempts.
+	retryBaseBackoff = time.Minute
+
+	// maximum backoff between
+	// persisted delivery attempts.
+	retryMaxBackoff = 24 * time.Hour
+
+	// max retries to pop from
+	// the database in one go.
+	retryPopLimit = 100
+)
+
+// RetryQueue provides a database-backed queue of Delivery{}s which
+// failed all their in-memory attempts (or were still queued during
+// shutdown), to be re-attempted later with exponential backoff.
+type RetryQueue struct {
+
+	// DB is the database the retry queue is
+	// persisted to. If nil, queue is disabled
+	// and failed deliveries will be dropped.
+	DB db.DeliveryRetry
+
+	// Sign is used to add a signing function to
+	// deliveries popped from the retry queue, as
+	// these are lost on serialization. If nil,
+	// popped deliveries will be sent unsigned.
+	Sign func(*Delivery) error
+
+	// MaxAttempts is the maximum number of times
+	// a delivery will be pushed to the retry queue
+	// before it is dropped. 0 disables the queue.
+	MaxAttempts int
+}
+
+// Enabled returns whether the retry queue is enabled, i.e. whether
+// pushed deliveries are persisted rather than simply dropped.
+func (q *RetryQueue) Enabled() bool {
+	return q.DB != nil && q.MaxAttempts > 0
+}
+
+// Push pushes the given failed delivery to the retry queue, to be re-attempted after backoff
+// according to its number of previous attempts. Deliveries that have already reached max
+// attempts are dropped. The 'failed' flag indicates whether this counts as a failed attempt,
+// (as opposed to e.g. an unfinished delivery being persisted on shutdown), and err is any
+// error that caused the last attempt to fail.
+func (q *RetryQueue) Push(ctx context.Context, dlv *Delivery, failed bool, err error) {
+	if !q.Enabled() {
+		// Retry queue disabled.
+		return
+	}
+
+	// Get current attempts.
+	attempts := dlv.attempts
+
+	// Determine next attempt time.
+	next := time.Now()
+	if failed {
+		if attempts >= q.MaxAttempts {
+			log.Warnf(ctx, "dropping delivery to %s after max (%d) attempts: %v", dlv.Request.URL, attempts, err)
+			return
+		}
+
+		// Calculate backoff according to no.
+		// attempts (2^n), clamped to max. The shift
+		// is capped to prevent it overflowing.
+		backoff := retryMaxBackoff
+		if attempts < 16 {
+			backoff = min(retryBaseBackoff<<attempts, backoff)
+		}
+
+		next = next.Add(backoff)
+		attempts++
+	}
+
+	q.push(ctx, dlv, attempts, next, err)
+}
+
+// PushAt pushes the given delivery to the retry queue, to be re-attempted at the given time.
+// This does not count as a failed attempt, e.g. for deliveries to a currently paused host.
+func (q *RetryQueue) PushAt(ctx context.Context, dlv *Delivery, at time.Time) {
+	if !q.Enabled() {
+		// Retry queue disabled.
+		return
+	}
+	q.push(ctx, dlv, dlv.attempts, at, nil)
+}
+
+// push serializes and inserts given delivery into the retry queue.
+func (q *RetryQueue) push(ctx context.Context, dlv *Delivery, attempts int, next time.Time, err error) {
+	// Serialize delivery for storage.
+	data, serr := dlv.Serialize()
+	if serr != nil {
+		log.Errorf(ctx, "error serializing delivery: %v", serr)
+		return
+	}
+
+	retry := &gtsmodel.DeliveryRetry{
+		ID:            id.NewULID(),
+		Host:          dlv.Request.URL.Host,
+		Attempts:      attempts,
+		NextAttemptAt: next,
+		Data:          data,
+	}
+
+	if err != nil {
+		retry.LastError = err.Error()
+	}
+
+	// Insert the delivery retry into the database.
+	if err := q.DB.PutDeliveryRetry(ctx, retry); err != nil {
+		log.Errorf(ctx, "error persisting delivery to %s: %v", dlv.Request.URL, err)
+	}
+}
+
+// Pop pops all deliveries currently due to be re-attempted from the retry queue.
+func (q *RetryQueue) Pop(ctx context.Context) ([]*Delivery, error) {
+	if q.DB == nil {
+		// Retry queue disabled.
+		return nil, nil
+	}
+
+	var dlvs []*Delivery
+
+	for {
+		// Pop next batch of due retries from the database.
+		retries, err := q.DB.PopDueDeliveryRetries(ctx,
+			time.Now(),
+			retryPopLimit,
+		)
+		if err != nil {
+			return dlvs, gtserror.Newf("error popping delivery retries: %w", err)
+		}
+
+		for _, retry := range retries {
+			dlv := new(Delivery)
+
+			// Deserialize delivery from stored data.
+			if err := dlv.Deserialize(retry.Data); err != nil {
+				log.Errorf(ctx, "error deserializing delivery retry %s: %v", retry.ID, err)
+				continue
+			}
+
+			// Set persisted attempts.
+			dlv.attempts = retry.Attempts
+
+			if q.Sign != nil {
+				// Re-add signing func to request.
+				if err := q.Sign(dlv); err != nil {
+					log.Errorf(ctx, "error signing delivery retry %s: %v", retry.ID, err)
+					continue
+				}
+			}
+
+			dlvs = append(dlvs, dlv)
+		}
+
+		if len(retries) < retryPopLimit {
+			// Reached end.
+			return dlvs, nil
+		}
+	}
+}
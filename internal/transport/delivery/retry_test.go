@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestRetryQueue(t *testing.T) {
+	ctx := context.Background()
+	db := new(testRetryDB)
+
+	var signed int
+	queue := delivery.RetryQueue{
+		DB:          db,
+		Sign:        func(*delivery.Delivery) error { signed++; return nil },
+		MaxAttempts: 2,
+	}
+
+	dlv := &delivery.Delivery{
+		ActorID: "https://google.com/users/bigboy",
+		Request: toRequest("POST", "https://askjeeves.com/users/smallboy/inbox", []byte("data!")),
+	}
+
+	// Persisting an unfinished delivery
+	// should make it immediately due.
+	queue.Push(ctx, dlv, false, nil)
+	assert.Len(t, db.retries, 1)
+	assert.Equal(t, 0, db.retries[0].Attempts)
+	assert.Equal(t, "askjeeves.com", db.retries[0].Host)
+
+	dlvs, err := queue.Pop(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, dlvs, 1)
+	assert.Equal(t, 1, signed)
+	assert.Equal(t, dlv.ActorID, dlvs[0].ActorID)
+	assert.Equal(t, dlv.Request.URL.String(), dlvs[0].Request.URL.String())
+	assert.Empty(t, db.retries)
+
+	// A failed delivery should be
+	// backed off, and not yet due.
+	queue.Push(ctx, dlvs[0], true, errors.New("oh no"))
+	assert.Len(t, db.retries, 1)
+	assert.Equal(t, 1, db.retries[0].Attempts)
+	assert.Equal(t, "oh no", db.retries[0].LastError)
+	assert.True(t, db.retries[0].NextAttemptAt.After(time.Now()))
+
+	dlvs, err = queue.Pop(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, dlvs)
+
+	// Force due and pop again,
+	// then fail until max attempts.
+	db.retries[0].NextAttemptAt = time.Now()
+	dlvs, err = queue.Pop(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, dlvs, 1)
+
+	queue.Push(ctx, dlvs[0], true, nil)
+	assert.Len(t, db.retries, 1)
+	assert.Equal(t, 2, db.retries[0].Attempts)
+
+	db.retries[0].NextAttemptAt = time.Now()
+	dlvs, err = queue.Pop(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, dlvs, 1)
+
+	// Delivery at max attempts
+	// should now be dropped.
+	queue.Push(ctx, dlvs[0], true, nil)
+	assert.Empty(t, db.retries)
+}
+
+// testRetryDB is a simple in-memory db.DeliveryRetry{} implementation.
+type testRetryDB struct{ retries []*gtsmodel.DeliveryRetry }
+
+func (db *testRetryDB) PutDeliveryRetry(_ context.Context, retry *gtsmodel.DeliveryRetry) error {
+	db.retries = append(db.retries, retry)
+	return nil
+}
+
+func (db *testRetryDB) PopDueDeliveryRetries(_ context.Context, now time.Time, limit int) ([]*gtsmodel.DeliveryRetry, error) {
+	var due []*gtsmodel.DeliveryRetry
+	db.retries = slices.DeleteFunc(db.retries, func(retry *gtsmodel.DeliveryRetry) bool {
+		if len(due) >= limit || retry.NextAttemptAt.After(now) {
+			return false
+		}
+		due = append(due, retry)
+		return true
+	})
+	return due, nil
+}
+
+func (db *testRetryDB) GetDeliveryRetries(_ context.Context, host string) ([]*gtsmodel.DeliveryRetry, error) {
+	var retries []*gtsmodel.DeliveryRetry
+	for _, retry := range db.retries {
+		if host == "" || retry.Host == host {
+			retries = append(retries, retry)
+		}
+	}
+	return retries, nil
+}
+
+func (db *testRetryDB) UpdateDeliveryRetry(_ context.Context, retry *gtsmodel.DeliveryRetry, _ ...string) error {
+	for i := range db.retries {
+		if db.retries[i].ID == retry.ID {
+			db.retries[i] = retry
+		}
+	}
+	return nil
+}
+
+func (db *testRetryDB) DeleteDeliveryRetryByID(_ context.Context, id string) error {
+	db.retries = slices.DeleteFunc(db.retries, func(retry *gtsmodel.DeliveryRetry) bool {
+		return retry.ID == id
+	})
+	return nil
+}
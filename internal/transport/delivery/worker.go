@@ -19,15 +19,17 @@ package delivery
 
 import (
 	"context"
+	"net/http"
 	"slices"
 	"time"
 
+	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-structr"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -39,12 +41,23 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Client *httpclient.Client
 
-	// Queue is the embedded queue.StructQueue{}
+	// Queue is the embedded per-host HostQueue{}
 	// passed to each of delivery pool Worker{}s.
-	Queue queue.StructQueue[*Delivery]
+	Queue HostQueue
+
+	// Retries is the persistent RetryQueue{} that
+	// is periodically drained into Queue, and is
+	// passed to each of delivery pool Worker{}s.
+	Retries RetryQueue
+
+	// DeadHosts tracks delivery failures per host,
+	// pausing deliveries to hosts considered dead.
+	// It is passed to each delivery pool Worker{}s.
+	DeadHosts DeadHosts
 
 	// internal fields.
 	workers []*Worker
+	drainer runners.Service
 }
 
 // Init will initialize the Worker{} pool
@@ -78,6 +91,8 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].Retries = &p.Retries
+		p.workers[i].DeadHosts = &p.DeadHosts
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -85,6 +100,10 @@ func (p *WorkerPool) Start(n int) {
 		// false = already running.
 		_ = p.workers[i].Start()
 	}
+
+	// Start draining due
+	// retries into queue.
+	_ = p.drainer.GoRun(p.drain)
 }
 
 // Stop will attempt to stop contained Worker{}s.
@@ -96,6 +115,9 @@ func (p *WorkerPool) Stop() {
 		return
 	}
 
+	// Stop retry drainer.
+	_ = p.drainer.Stop()
+
 	// Stop all running workers.
 	for i := range p.workers {
 
@@ -105,12 +127,74 @@ func (p *WorkerPool) Stop() {
 		_ = p.workers[i].Stop()
 	}
 
+	// Persist any deliveries awaiting
+	// (re)attempt so they aren't lost.
+	p.persist(context.Background())
+
 	// Unset workers slice.
 	p.workers = p.workers[:0]
 }
 
+// drain is the main retry drainer routine, which periodically
+// pops due deliveries from the retry queue into the main queue.
+func (p *WorkerPool) drain(ctx context.Context) {
+	const freq = time.Minute
+
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+
+	for {
+		// Pop all currently due retries.
+		dlvs, err := p.Retries.Pop(ctx)
+		if err != nil {
+			log.Errorf(ctx, "error draining retry queue: %v", err)
+		}
+
+		if len(dlvs) > 0 {
+			log.Infof(ctx, "re-attempting %d deliveries from retry queue", len(dlvs))
+			p.Queue.Push(dlvs...)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// persist pushes all deliveries still in memory, i.e.
+// in worker backlogs or not yet dequeued, to the retry
+// queue. This should only be called after workers stop.
+func (p *WorkerPool) persist(ctx context.Context) {
+	var n int
+
+	for _, w := range p.workers {
+		// Persist worker backlog.
+		for _, dlv := range w.backlog {
+			p.Retries.Push(ctx, dlv, false, nil)
+			n++
+		}
+		w.backlog = nil
+	}
+
+	for {
+		// Persist remaining queue.
+		dlv, ok := p.Queue.Pop()
+		if !ok {
+			break
+		}
+		p.Retries.Push(ctx, dlv, false, nil)
+		n++
+	}
+
+	if n > 0 && p.Retries.DB != nil {
+		log.Infof(ctx, "persisted %d pending deliveries to retry queue", n)
+	}
+}
+
 // Worker wraps an httpclient.Client{} to feed
-// from queue.StructQueue{} for ActivityPub reqs
+// from HostQueue{} for ActivityPub reqs
 // to deliver. It does so while prioritizing new
 // queued requests over backlogged retries.
 type Worker struct {
@@ -121,7 +205,19 @@ type Worker struct {
 
 	// Queue is the Delivery{} message queue
 	// that delivery worker will feed from.
-	Queue *queue.StructQueue[*Delivery]
+	Queue *HostQueue
+
+	// Retries is the RetryQueue{} that deliveries
+	// will be pushed to on exhausting attempts.
+	// This may be nil, in which case failed
+	// deliveries are simply dropped.
+	Retries *RetryQueue
+
+	// DeadHosts is used to track delivery
+	// failures per host, and check whether
+	// host is paused before each delivery.
+	// This may be nil, to pause no hosts.
+	DeadHosts *DeadHosts
 
 	// internal fields.
 	backlog []*Delivery
@@ -175,8 +271,10 @@ loop:
 
 			select {
 			case <-ctx.Done():
-				// Main ctx
-				// cancelled.
+				// Main ctx cancelled,
+				// re-add to backlog so
+				// it can be persisted.
+				w.pushBacklog(dlv)
 				backoff.Stop()
 				return true
 
@@ -193,18 +291,56 @@ loop:
 			}
 		}
 
+		// Get delivery host.
+		host := dlv.host()
+
+		if w.DeadHosts != nil {
+			// Check delivery host isn't currently paused.
+			if until, ok := w.DeadHosts.Allow(host); !ok {
+
+				// Defer until next probe.
+				w.deferUntil(dlv, until)
+				continue loop
+			}
+		}
+
 		// Attempt delivery of AP request.
+		start := time.Now()
 		rsp, retry, err := w.Client.DoOnce(
 			&dlv.Request,
 		)
+		w.Queue.Stats.Process(time.Since(start))
 
 		if err == nil {
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+
+			if w.DeadHosts != nil {
+				// Mark host as alive.
+				w.DeadHosts.Success(ctx, host)
+			}
+
 			continue loop
 		}
 
+		if w.DeadHosts != nil && ctx.Err() == nil && hostFailed(err) {
+			// Mark host delivery failure.
+			w.DeadHosts.Failure(ctx, host)
+		}
+
 		if !retry {
+			switch {
+			case ctx.Err() != nil:
+				// Worker stopped during delivery,
+				// persist to retry on next start.
+				w.pushRetry(dlv, false, err)
+
+			case dlv.Request.Exhausted():
+				// Delivery reached max in-memory
+				// attempts, persist to retry later.
+				w.pushRetry(dlv, true, err)
+			}
+
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
@@ -260,6 +396,55 @@ loop:
 	}
 }
 
+// pushRetry pushes the given delivery to the retry queue, if set.
+func (w *Worker) pushRetry(dlv *Delivery, failed bool, err error) {
+	if w.Retries != nil {
+		// Use a fresh context, that of request
+		// is likely to have been cancelled.
+		ctx := gtscontext.WithValues(
+			context.Background(),
+			dlv.Request.Context(),
+		)
+		w.Retries.Push(ctx, dlv, failed, err)
+	}
+}
+
+// deferUntil defers the given delivery to be re-attempted at time, via the
+// retry queue if enabled, else by keeping it in the backlog until then.
+func (w *Worker) deferUntil(dlv *Delivery, at time.Time) {
+	if w.Retries != nil && w.Retries.Enabled() {
+		ctx := gtscontext.WithValues(
+			context.Background(),
+			dlv.Request.Context(),
+		)
+		w.Retries.PushAt(ctx, dlv, at)
+		return
+	}
+
+	// No retry queue to persist it
+	// to, keep it in memory instead.
+	dlv.next = at
+	w.pushBacklog(dlv)
+}
+
+// hostFailed returns whether delivery error indicates the host is failing,
+// i.e. a transport error, or a 5xx / 429 response. Other responses show the
+// host is alive, and requests we refused or held back ourselves (e.g. due
+// to host rate limits or open circuit breakers) say nothing about the host.
+func hostFailed(err error) bool {
+	if code := gtserror.StatusCode(err); code != 0 {
+		return code >= 500 || code == http.StatusTooManyRequests
+	}
+	return !errorsv2.IsV2(err,
+		context.Canceled,
+		httpclient.ErrHostRateLimited,
+		httpclient.ErrCircuitOpen,
+		httpclient.ErrReservedAddr,
+		httpclient.ErrHiddenService,
+		httpclient.ErrBodyTooLarge,
+	)
+}
+
 // popBacklog pops next available from the backlog.
 func (w *Worker) popBacklog() *Delivery {
 	if len(w.backlog) == 0 {
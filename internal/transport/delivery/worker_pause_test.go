@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+)
+
+func TestDeferUntilNoRetries(t *testing.T) {
+	w := &Worker{Retries: new(RetryQueue)}
+	dlv := new(Delivery)
+	until := time.Now().Add(time.Hour)
+
+	// With the retry queue disabled, a delivery
+	// to a paused host must be kept in memory.
+	w.deferUntil(dlv, until)
+	if assert.Len(t, w.backlog, 1) {
+		assert.Same(t, dlv, w.backlog[0])
+		assert.Equal(t, until, dlv.next)
+	}
+}
+
+func TestHostFailed(t *testing.T) {
+	statusErr := func(code int) error {
+		err := fmt.Errorf("http response: %d", code)
+		return gtserror.WithStatusCode(err, code)
+	}
+
+	for _, test := range []struct {
+		err    error
+		failed bool
+	}{
+		{err: errors.New("dial tcp: connection refused"), failed: true},
+		{err: context.DeadlineExceeded, failed: true},
+		{err: statusErr(http.StatusBadGateway), failed: true},
+		{err: statusErr(http.StatusTooManyRequests), failed: true},
+		{err: statusErr(http.StatusNotFound), failed: false},
+		{err: statusErr(http.StatusGone), failed: false},
+		{err: fmt.Errorf("%w: example.org", httpclient.ErrHostRateLimited), failed: false},
+		{err: fmt.Errorf("%w: example.org", httpclient.ErrCircuitOpen), failed: false},
+		{err: fmt.Errorf("%w: 127.0.0.1", httpclient.ErrReservedAddr), failed: false},
+		{err: context.Canceled, failed: false},
+	} {
+		assert.Equal(t, test.failed, hostFailed(test.err), test.err.Error())
+	}
+}
@@ -30,7 +30,6 @@ import (
 	"codeberg.org/gruf/go-byteutil"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
-	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 )
 
@@ -56,7 +55,7 @@ func testDeliveryWorkerPool(t *testing.T, sz int, input []*testrequest) {
 
 func test(
 	t *testing.T,
-	queue *queue.StructQueue[*delivery.Delivery],
+	queue *delivery.HostQueue,
 	input []*testrequest,
 ) {
 	expect := make(chan *testrequest)
@@ -25,10 +25,15 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 func (t *transport) Dereference(ctx context.Context, iri *url.URL) (*http.Response, error) {
+	return t.DereferenceConditional(ctx, iri, "", "")
+}
+
+func (t *transport) DereferenceConditional(ctx context.Context, iri *url.URL, etag string, lastModified string) (*http.Response, error) {
 	// if the request is to us, we can shortcut for certain URIs rather than going through
 	// the normal request flow, thereby saving time and energy
 	if iri.Host == config.GetHost() {
@@ -47,6 +52,7 @@ func (t *transport) Dereference(ctx context.Context, iri *url.URL) (*http.Respon
 	iriStr := iri.String()
 
 	// Prepare new HTTP request to endpoint
+	ctx = httpclient.SetClass(ctx, httpclient.ClassDereference)
 	req, err := http.NewRequestWithContext(ctx, "GET", iriStr, nil)
 	if err != nil {
 		return nil, err
@@ -55,13 +61,23 @@ func (t *transport) Dereference(ctx context.Context, iri *url.URL) (*http.Respon
 	req.Header.Add("Accept", string(apiutil.AppActivityLDJSON)+","+string(apiutil.AppActivityJSON))
 	req.Header.Add("Accept-Charset", "utf-8")
 
+	// Set any cache validators
+	// for conditional request.
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
 	// Perform the HTTP request
 	rsp, err := t.GET(req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Ensure a non-error status response.
+	// Ensure a non-error status response,
+	// (304 Not Modified is returned as err).
 	if rsp.StatusCode != http.StatusOK {
 		err := gtserror.NewFromResponse(rsp)
 		_ = rsp.Body.Close() // done with body
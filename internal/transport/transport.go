@@ -67,6 +67,11 @@ type Transport interface {
 	// Dereference fetches the ActivityStreams object located at this IRI with a GET request.
 	Dereference(ctx context.Context, iri *url.URL) (*http.Response, error)
 
+	// DereferenceConditional is Dereference(), but making a conditional request with given ETag
+	// and / or Last-Modified values from a previous fetch of the object. If the remote responds
+	// that the object is unchanged, an error with status code 304 Not Modified is returned.
+	DereferenceConditional(ctx context.Context, iri *url.URL, etag string, lastModified string) (*http.Response, error)
+
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
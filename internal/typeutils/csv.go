@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package typeutils
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// CSVToBookmarks parses the status URIs of a bookmarks
+// csv, as written by the bookmarks export, from the first
+// column of each record. Empty records are skipped.
+func CSVToBookmarks(r io.Reader) ([]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var uris []string
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return uris, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(record) == 0 {
+			continue
+		}
+
+		uri := strings.TrimSpace(record[0])
+		if uri == "" {
+			continue
+		}
+
+		uris = append(uris, uri)
+	}
+}
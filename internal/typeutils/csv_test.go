@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package typeutils_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+func TestCSVToBookmarks(t *testing.T) {
+	const data = "https://example.org/users/a/statuses/1\n" +
+		"\n" +
+		"  https://example.org/users/b/statuses/2 ,extra\n" +
+		"\"\"\n"
+
+	uris, err := typeutils.CSVToBookmarks(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []string{
+		"https://example.org/users/a/statuses/1",
+		"https://example.org/users/b/statuses/2",
+	}
+	if !slices.Equal(uris, expect) {
+		t.Fatalf("wanted %v, got %v", expect, uris)
+	}
+}
+
+func TestCSVToBookmarksMalformed(t *testing.T) {
+	if _, err := typeutils.CSVToBookmarks(strings.NewReader("\"unterminated\n")); err == nil {
+		t.Fatal("expected error parsing malformed csv")
+	}
+}
@@ -41,6 +41,7 @@ func (c *Converter) FollowRequestToFollow(
 		ShowReblogs:     util.Ptr(*fr.ShowReblogs),
 		URI:             fr.URI,
 		Notify:          util.Ptr(*fr.Notify),
+		Languages:       fr.Languages,
 	}
 }
 
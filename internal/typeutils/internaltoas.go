@@ -1087,7 +1087,9 @@ func (c *Converter) AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachm
 	doc.SetTootBlurhash(blurProp)
 
 	// focalpoint
-	// TODO
+	if x, y := a.FileMeta.Focus.X, a.FileMeta.Focus.Y; x != 0 || y != 0 {
+		ap.SetFocalPoint(doc, x, y)
+	}
 
 	return doc, nil
 }
@@ -833,6 +833,46 @@ func (suite *InternalToASTestSuite) TestReportToAS() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestAttachmentToASWithFocalPoint() {
+	ctx := context.Background()
+
+	testAttachment := &gtsmodel.MediaAttachment{}
+	*testAttachment = *suite.testAttachments["admin_account_status_1_attachment_1"]
+	testAttachment.FileMeta.Focus = gtsmodel.Focus{X: -0.5, Y: 0.25}
+
+	doc, err := suite.typeconverter.AttachmentToAS(ctx, testAttachment)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(doc)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://joinmastodon.org/ns",
+    {
+      "focalPoint": {
+        "@container": "@list",
+        "@id": "toot:focalPoint"
+      },
+      "toot": "http://joinmastodon.org/ns#"
+    }
+  ],
+  "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+  "focalPoint": [
+    -0.5,
+    0.25
+  ],
+  "mediaType": "image/jpeg",
+  "name": "Black and white image of some 50's style text saying: Welcome On Board",
+  "type": "Document",
+  "url": "http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestPinnedStatusesToASSomeItems() {
 	ctx := context.Background()
 
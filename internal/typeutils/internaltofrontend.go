@@ -37,7 +37,6 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
-	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -53,6 +52,7 @@ const (
 	instanceAccountsMaxProfileFields            = 6 // FIXME: https://github.com/superseriousbusiness/gotosocial/issues/1876
 	instanceSourceURL                           = "https://github.com/superseriousbusiness/gotosocial"
 	instanceMastodonVersion                     = "3.5.3"
+	instanceUsageActivePeriod                   = 4 * 7 * 24 * time.Hour // 4 weeks
 )
 
 var instanceStatusesSupportedMimeTypes = []string{
@@ -60,6 +60,16 @@ var instanceStatusesSupportedMimeTypes = []string{
 	string(apimodel.StatusContentTypeMarkdown),
 }
 
+// instanceStreamingURL returns the websockets
+// streaming address for the given instance domain,
+// using the scheme appropriate to configured protocol.
+func instanceStreamingURL(domain string) string {
+	if config.GetProtocol() == "http" {
+		return "ws://" + domain
+	}
+	return "wss://" + domain
+}
+
 func toMastodonVersion(in string) string {
 	return instanceMastodonVersion + "+" + strings.ReplaceAll(in, " ", "-")
 }
@@ -134,6 +144,12 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		Sensitive:           *a.Settings.Sensitive,
 		Language:            a.Settings.Language,
 		StatusContentType:   statusContentType,
+		Boostable:           util.PtrValueOr(a.Settings.Boostable, true),
+		Replyable:           util.PtrValueOr(a.Settings.Replyable, true),
+		Likeable:            util.PtrValueOr(a.Settings.Likeable, true),
+		ExpiryDays:          a.Settings.ExpiryDays,
+		ExpiryKeepPinned:    util.PtrValueOr(a.Settings.ExpiryKeepPinned, true),
+		ExpiryKeepFaved:     util.PtrValueOr(a.Settings.ExpiryKeepFaved, true),
 		Note:                a.NoteRaw,
 		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
 		FollowRequestsCount: *a.Stats.FollowRequestsCount,
@@ -428,6 +444,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -476,6 +493,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User signed up with an invite,
+			// show who created the invite.
+			invite, err := c.state.DB.GetInviteByID(ctx, user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite from database for account id %s: %w", a.ID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.CreatedByAccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -501,7 +531,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -599,6 +629,15 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			apiAttachment.Meta.Original.FrameRate = fr + "/1"
 		}
 
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
@@ -827,116 +866,33 @@ func (c *Converter) statusToAPIFilterResults(
 
 	// At this point, the status isn't muted, but might still be filtered.
 	// Record all matching warn filters and the reasons they matched.
-	filterResults := make([]apimodel.FilterResult, 0, len(filters))
-	for _, filter := range filters {
-		if !filterAppliesInContext(filter, filterContext) {
-			// Filter doesn't apply to this context.
-			continue
-		}
-		if filter.Expired(now) {
-			continue
-		}
-
-		// List all matching keywords.
-		keywordMatches := make([]string, 0, len(filter.Keywords))
-		fields := filterableTextFields(s)
-		for _, filterKeyword := range filter.Keywords {
-			var isMatch bool
-			for _, field := range fields {
-				if filterKeyword.Regexp.MatchString(field) {
-					isMatch = true
-					break
-				}
-			}
-			if isMatch {
-				keywordMatches = append(keywordMatches, filterKeyword.Keyword)
-			}
+	matches := statusfilter.MatchStatus(s, filters, filterContext, now)
+	filterResults := make([]apimodel.FilterResult, 0, len(matches))
+	for _, match := range matches {
+		if match.Hide() {
+			// Don't show this status. Immediate return.
+			return nil, statusfilter.ErrHideStatus
 		}
 
-		// A status has only one ID. Not clear why this is a list in the Mastodon API.
-		statusMatches := make([]string, 0, 1)
-		for _, filterStatus := range filter.Statuses {
-			if s.ID == filterStatus.StatusID {
-				statusMatches = append(statusMatches, filterStatus.StatusID)
-				break
-			}
+		if match.Filter.Action != gtsmodel.FilterActionWarn {
+			continue
 		}
 
-		if len(keywordMatches) > 0 || len(statusMatches) > 0 {
-			switch filter.Action {
-			case gtsmodel.FilterActionWarn:
-				// Record what matched.
-				apiFilter, err := c.FilterToAPIFilterV2(ctx, filter)
-				if err != nil {
-					return nil, err
-				}
-				filterResults = append(filterResults, apimodel.FilterResult{
-					Filter:         *apiFilter,
-					KeywordMatches: keywordMatches,
-					StatusMatches:  statusMatches,
-				})
-
-			case gtsmodel.FilterActionHide:
-				// Don't show this status. Immediate return.
-				return nil, statusfilter.ErrHideStatus
-			}
+		// Record what matched.
+		apiFilter, err := c.FilterToAPIFilterV2(ctx, match.Filter)
+		if err != nil {
+			return nil, err
 		}
+		filterResults = append(filterResults, apimodel.FilterResult{
+			Filter:         *apiFilter,
+			KeywordMatches: match.KeywordMatches,
+			StatusMatches:  match.StatusMatches,
+		})
 	}
 
 	return filterResults, nil
 }
 
-// filterableTextFields returns all text from a status that we might want to filter on:
-// - content
-// - content warning
-// - media descriptions
-// - poll options
-func filterableTextFields(s *gtsmodel.Status) []string {
-	fieldCount := 2 + len(s.Attachments)
-	if s.Poll != nil {
-		fieldCount += len(s.Poll.Options)
-	}
-	fields := make([]string, 0, fieldCount)
-
-	if s.Content != "" {
-		fields = append(fields, text.SanitizeToPlaintext(s.Content))
-	}
-	if s.ContentWarning != "" {
-		fields = append(fields, s.ContentWarning)
-	}
-	for _, attachment := range s.Attachments {
-		if attachment.Description != "" {
-			fields = append(fields, attachment.Description)
-		}
-	}
-	if s.Poll != nil {
-		for _, option := range s.Poll.Options {
-			if option != "" {
-				fields = append(fields, option)
-			}
-		}
-	}
-
-	return fields
-}
-
-// filterAppliesInContext returns whether a given filter applies in a given context.
-func filterAppliesInContext(filter *gtsmodel.Filter, filterContext statusfilter.FilterContext) bool {
-	switch filterContext {
-	case statusfilter.FilterContextHome:
-		return util.PtrValueOr(filter.ContextHome, false)
-	case statusfilter.FilterContextNotifications:
-		return util.PtrValueOr(filter.ContextNotifications, false)
-	case statusfilter.FilterContextPublic:
-		return util.PtrValueOr(filter.ContextPublic, false)
-	case statusfilter.FilterContextThread:
-		return util.PtrValueOr(filter.ContextThread, false)
-	case statusfilter.FilterContextAccount:
-		return util.PtrValueOr(filter.ContextAccount, false)
-	}
-	return false
-}
-
 // StatusToWebStatus converts a gts model status into an
 // api representation suitable for serving into a web template.
 //
@@ -1311,6 +1267,193 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// EmailDomainBlockToAdminAPIEmailDomainBlock converts an email domain block into its api equivalent for serving at /api/v1/admin/email_domain_blocks/:id
+func (c *Converter) EmailDomainBlockToAdminAPIEmailDomainBlock(b *gtsmodel.EmailDomainBlock) (*apimodel.AdminEmailDomainBlock, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	domain, err := util.DePunify(b.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error de-punifying domain %s: %w", b.Domain, err)
+	}
+
+	return &apimodel.AdminEmailDomainBlock{
+		ID:        b.ID,
+		Domain:    domain,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		CreatedBy: b.CreatedByAccountID,
+	}, nil
+}
+
+// CanonicalEmailBlockToAdminAPICanonicalEmailBlock converts a gts model canonical
+// email block into its admin api model representation. If export is true, only
+// the hash is included, making the result suitable for sharing with other instances.
+func (c *Converter) CanonicalEmailBlockToAdminAPICanonicalEmailBlock(b *gtsmodel.CanonicalEmailBlock, export bool) *apimodel.AdminCanonicalEmailBlock {
+	apiBlock := &apimodel.AdminCanonicalEmailBlock{
+		CanonicalEmailHash: b.CanonicalEmailHash,
+	}
+
+	// If we're exporting, provide
+	// only bare minimum detail.
+	if export {
+		return apiBlock
+	}
+
+	apiBlock.ID = b.ID
+	apiBlock.CreatedAt = util.FormatISO8601(b.CreatedAt)
+	apiBlock.CreatedBy = b.CreatedByAccountID
+	return apiBlock
+}
+
+// AnnouncementToAPIAnnouncement converts a gts model announcement into its api model
+// representation. If requester is set, whether they have read the announcement, and
+// which reactions are theirs, will also be set. Requester may be nil when serializing
+// an announcement for all users at once, eg., for streaming.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	a *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, error) {
+	apiAnnouncement := &apimodel.Announcement{
+		ID:          a.ID,
+		Content:     a.Content,
+		AllDay:      *a.AllDay,
+		PublishedAt: util.FormatISO8601(a.CreatedAt),
+		UpdatedAt:   util.FormatISO8601(a.UpdatedAt),
+		Published:   true,
+		Mentions:    []apimodel.Mention{},
+		Statuses:    []apimodel.Status{},
+		Tags:        []apimodel.Tag{},
+	}
+
+	if !a.StartsAt.IsZero() {
+		apiAnnouncement.StartsAt = util.FormatISO8601(a.StartsAt)
+	}
+
+	if !a.EndsAt.IsZero() {
+		apiAnnouncement.EndsAt = util.FormatISO8601(a.EndsAt)
+	}
+
+	emojis, err := c.convertEmojisToAPIEmojis(ctx, a.Emojis, a.EmojiIDs)
+	if err != nil {
+		return nil, gtserror.Newf("error converting announcement emojis: %w", err)
+	}
+	apiAnnouncement.Emojis = emojis
+
+	if requester != nil {
+		apiAnnouncement.Read, err = c.state.DB.IsAnnouncementRead(ctx, a.ID, requester.ID)
+		if err != nil {
+			return nil, gtserror.Newf("db error checking announcement read: %w", err)
+		}
+	}
+
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, a.ID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting announcement reactions: %w", err)
+	}
+
+	apiAnnouncement.Reactions, err = c.AnnouncementReactionsToAPIAnnouncementReactions(ctx, reactions, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiAnnouncement, nil
+}
+
+// AnnouncementReactionsToAPIAnnouncementReactions groups the given gts model
+// announcement reactions by name into their api model representation, in order
+// of first use. If requester is set, reactions they made will be marked as such.
+func (c *Converter) AnnouncementReactionsToAPIAnnouncementReactions(
+	ctx context.Context,
+	reactions []*gtsmodel.AnnouncementReaction,
+	requester *gtsmodel.Account,
+) ([]apimodel.AnnouncementReaction, error) {
+	apiReactions := make([]apimodel.AnnouncementReaction, 0, len(reactions))
+	indices := make(map[string]int, len(reactions))
+
+	for _, r := range reactions {
+		i, ok := indices[r.Name]
+		if !ok {
+			apiReaction := apimodel.AnnouncementReaction{Name: r.Name}
+
+			if r.EmojiID != "" {
+				if r.Emoji == nil {
+					emoji, err := c.state.DB.GetEmojiByID(ctx, r.EmojiID)
+					if err != nil && !errors.Is(err, db.ErrNoEntries) {
+						return nil, gtserror.Newf("db error getting reaction emoji %s: %w", r.EmojiID, err)
+					}
+					r.Emoji = emoji
+				}
+
+				// Emoji may have since been
+				// deleted, in which case we're
+				// left with only the shortcode.
+				if r.Emoji != nil {
+					apiReaction.URL = r.Emoji.ImageURL
+					apiReaction.StaticURL = r.Emoji.ImageStaticURL
+				}
+			}
+
+			i = len(apiReactions)
+			indices[r.Name] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[i].Count++
+		if requester != nil && r.AccountID == requester.ID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions, nil
+}
+
+// InviteToAPIInvite converts a gts model invite into its api model representation.
+func (c *Converter) InviteToAPIInvite(i *gtsmodel.Invite) *apimodel.Invite {
+	apiInvite := &apimodel.Invite{
+		ID:        i.ID,
+		Code:      i.Code,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		CreatedBy: i.CreatedByAccountID,
+		Uses:      i.Uses,
+		Expired:   !i.Usable(time.Now()),
+	}
+
+	if i.MaxUses > 0 {
+		apiInvite.MaxUses = util.Ptr(i.MaxUses)
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		apiInvite.ExpiresAt = util.Ptr(util.FormatISO8601(i.ExpiresAt))
+	}
+
+	return apiInvite
+}
+
+// IPBlockToAdminAPIIPBlock converts a gts model IP block into its admin api model representation.
+func (c *Converter) IPBlockToAdminAPIIPBlock(b *gtsmodel.IPBlock) *apimodel.AdminIPBlock {
+	return &apimodel.AdminIPBlock{
+		ID:        b.ID,
+		IP:        b.IP,
+		Severity:  string(b.Severity),
+		Comment:   b.Comment,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		CreatedBy: b.CreatedByAccountID,
+	}
+}
+
+// DomainMediaPolicyToAdminAPIDomainMediaPolicy converts a gts model domain media policy into its admin api representation.
+func (c *Converter) DomainMediaPolicyToAdminAPIDomainMediaPolicy(p *gtsmodel.DomainMediaPolicy) *apimodel.AdminDomainMediaPolicy {
+	return &apimodel.AdminDomainMediaPolicy{
+		ID:              p.ID,
+		Domain:          p.Domain,
+		NeverCache:      *p.NeverCache,
+		RemoteCacheDays: p.RemoteCacheDays,
+		PrivateComment:  p.PrivateComment,
+		CreatedAt:       util.FormatISO8601(p.CreatedAt),
+		CreatedBy:       p.CreatedByAccountID,
+	}
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -1325,8 +1468,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,  // approval always required
-		InvitesEnabled:       false, // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsAllowUserInvites(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1363,7 +1506,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.OIDCEnabled = config.GetOIDCEnabled()
 
 	// URLs
-	instance.URLs.StreamingAPI = "wss://" + i.Domain
+	instance.URLs.StreamingAPI = instanceStreamingURL(i.Domain)
 
 	// statistics
 	stats := make(map[string]*int, 3)
@@ -1438,7 +1581,6 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		SourceURL:       instanceSourceURL,
 		Description:     i.Description,
 		DescriptionText: i.DescriptionText,
-		Usage:           apimodel.InstanceV2Usage{}, // todo: not implemented
 		Languages:       config.GetInstanceLanguages().TagStrs(),
 		Rules:           c.InstanceRulesToAPIRules(i.Rules),
 		Terms:           i.Terms,
@@ -1453,6 +1595,13 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		instance.Debug = util.Ptr(true)
 	}
 
+	// usage
+	activeMonth, err := c.state.DB.CountInstanceActiveUsers(ctx, i.Domain, time.Now().Add(-instanceUsageActivePeriod))
+	if err != nil {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error counting instance active users: %w", err)
+	}
+	instance.Usage.Users.ActiveMonth = activeMonth
+
 	// thumbnail
 	thumbnail := apimodel.InstanceV2Thumbnail{}
 
@@ -1474,6 +1623,10 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		thumbnail.Type = iAccount.AvatarMediaAttachment.File.ContentType
 		thumbnail.Description = iAccount.AvatarMediaAttachment.Description
 		thumbnail.Blurhash = iAccount.AvatarMediaAttachment.Blurhash
+		thumbnail.Versions = &apimodel.InstanceV2ThumbnailVersions{
+			Size1URL: iAccount.AvatarMediaAttachment.Thumbnail.URL,
+			Size2URL: iAccount.AvatarMediaAttachment.URL,
+		}
 	} else {
 		thumbnail.URL = config.GetProtocol() + "://" + i.Domain + "/assets/logo.png" // default thumb
 	}
@@ -1481,7 +1634,7 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Thumbnail = thumbnail
 
 	// configuration
-	instance.Configuration.URLs.Streaming = "wss://" + i.Domain
+	instance.Configuration.URLs.Streaming = instanceStreamingURL(i.Domain)
 	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
@@ -1535,6 +1688,7 @@ func (c *Converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmod
 		Following:           r.Following,
 		ShowingReblogs:      r.ShowingReblogs,
 		Notifying:           r.Notifying,
+		Languages:           r.Languages,
 		FollowedBy:          r.FollowedBy,
 		Blocking:            r.Blocking,
 		BlockedBy:           r.BlockedBy,
@@ -1638,6 +1792,15 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 		},
 	}
 
+	if block, ok := d.(*gtsmodel.DomainBlock); ok {
+		// Only blocks have a severity,
+		// which is suspend by default.
+		domainPerm.Severity = string(gtsmodel.DomainBlockSeveritySuspend)
+		if block.IsSilence() {
+			domainPerm.Severity = string(gtsmodel.DomainBlockSeveritySilence)
+		}
+	}
+
 	// If we're exporting, provide
 	// only bare minimum detail.
 	if export {
@@ -1700,6 +1863,8 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenAt        *string
 		actionTakenComment   *string
 		actionTakenByAccount *apimodel.AdminAccountInfo
+		assignedAccount      *apimodel.AdminAccountInfo
+		resolutionCategory   *string
 	)
 
 	if !r.ActionTakenAt.IsZero() {
@@ -1743,6 +1908,20 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 
+	if r.AssignedAccountID != "" {
+		if r.AssignedAccount == nil {
+			r.AssignedAccount, err = c.state.DB.GetAccountByID(ctx, r.AssignedAccountID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting assigned account with id %s from the db: %w", r.AssignedAccountID, err)
+			}
+		}
+
+		assignedAccount, err = c.AccountToAdminAPIAccount(ctx, r.AssignedAccount)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting assigned account with id %s to adminAPIAccount: %w", r.AssignedAccountID, err)
+		}
+	}
+
 	statuses := make([]*apimodel.Status, 0, len(r.StatusIDs))
 	if len(r.StatusIDs) != 0 && len(r.Statuses) == 0 {
 		r.Statuses, err = c.state.DB.GetStatusesByIDs(ctx, r.StatusIDs)
@@ -1776,6 +1955,10 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenComment = &ac
 	}
 
+	if rc := string(r.ResolutionCategory); rc != "" {
+		resolutionCategory = &rc
+	}
+
 	return &apimodel.AdminReport{
 		ID:                   r.ID,
 		ActionTaken:          !r.ActionTakenAt.IsZero(),
@@ -1787,9 +1970,10 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		UpdatedAt:            util.FormatISO8601(r.UpdatedAt),
 		Account:              account,
 		TargetAccount:        targetAccount,
-		AssignedAccount:      actionTakenByAccount,
+		AssignedAccount:      assignedAccount,
 		ActionTakenByAccount: actionTakenByAccount,
 		ActionTakenComment:   actionTakenComment,
+		ResolutionCategory:   resolutionCategory,
 		Statuses:             statuses,
 		Rules:                rules,
 	}, nil
@@ -1931,6 +2115,7 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 		ExpiresAt:   expiresAt,
 		Expired:     poll.Closed(),
 		Multiple:    (*poll.Multiple),
+		VoteChange:  util.PtrValueOr(poll.VoteChange, false),
 		VotesCount:  totalVotes,
 		VotersCount: totalVoters,
 		Voted:       hasVoted,
@@ -2016,16 +2201,75 @@ func (c *Converter) FilterToAPIFilterV2(ctx context.Context, filter *gtsmodel.Fi
 	}
 
 	return &apimodel.FilterV2{
-		ID:           filter.ID,
-		Title:        filter.Title,
-		Context:      filterToAPIFilterContexts(filter),
-		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
-		FilterAction: filterActionToAPIFilterAction(filter.Action),
-		Keywords:     apiFilterKeywords,
-		Statuses:     apiFilterStatuses,
+		ID:             filter.ID,
+		Title:          filter.Title,
+		Context:        filterToAPIFilterContexts(filter),
+		ExpiresAt:      filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		FilterAction:   filterActionToAPIFilterAction(filter.Action),
+		Keywords:       apiFilterKeywords,
+		Statuses:       apiFilterStatuses,
+		SubscriptionID: filter.SubscriptionID,
 	}, nil
 }
 
+// FiltersToAPIFilterList converts GTS model filters into a portable API filter list document.
+func (c *Converter) FiltersToAPIFilterList(ctx context.Context, title string, filters []*gtsmodel.Filter) *apimodel.FilterList {
+	apiFilters := make([]apimodel.FilterListFilter, 0, len(filters))
+	for _, filter := range filters {
+		apiKeywords := make([]apimodel.FilterListKeyword, 0, len(filter.Keywords))
+		for _, filterKeyword := range filter.Keywords {
+			apiKeywords = append(apiKeywords, apimodel.FilterListKeyword{
+				Keyword:   filterKeyword.Keyword,
+				WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+				Regex:     util.PtrValueOr(filterKeyword.Regex, false),
+			})
+		}
+
+		apiFilters = append(apiFilters, apimodel.FilterListFilter{
+			Title:        filter.Title,
+			Context:      filterToAPIFilterContexts(filter),
+			FilterAction: filterActionToAPIFilterAction(filter.Action),
+			Keywords:     apiKeywords,
+		})
+	}
+
+	return &apimodel.FilterList{
+		Version: apimodel.FilterListVersion,
+		Title:   title,
+		Filters: apiFilters,
+	}
+}
+
+// FilterSubscriptionToAPIFilterSubscription converts a GTS model filter subscription into an API filter subscription,
+// listing the IDs of the given filters which are managed by the subscription.
+func (c *Converter) FilterSubscriptionToAPIFilterSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.FilterSubscription,
+	filters []*gtsmodel.Filter,
+) *apimodel.FilterSubscription {
+	filterIDs := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if filter.SubscriptionID == subscription.ID {
+			filterIDs = append(filterIDs, filter.ID)
+		}
+	}
+
+	var syncedAt *string
+	if !subscription.SyncedAt.IsZero() {
+		syncedAt = util.Ptr(util.FormatISO8601(subscription.SyncedAt))
+	}
+
+	return &apimodel.FilterSubscription{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Title:     subscription.Title,
+		CreatedAt: util.FormatISO8601(subscription.CreatedAt),
+		SyncedAt:  syncedAt,
+		Error:     subscription.Error,
+		FilterIDs: filterIDs,
+	}
+}
+
 func filterExpiresAtToAPIFilterExpiresAt(expiresAt time.Time) *string {
 	if expiresAt.IsZero() {
 		return nil
@@ -2069,6 +2313,7 @@ func (c *Converter) FilterKeywordToAPIFilterKeyword(ctx context.Context, filterK
 		ID:        filterKeyword.ID,
 		Keyword:   filterKeyword.Keyword,
 		WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+		Regex:     util.PtrValueOr(filterKeyword.Regex, false),
 	}
 }
 
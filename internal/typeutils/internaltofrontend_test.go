@@ -121,6 +121,12 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
+    "boostable": true,
+    "replyable": true,
+    "likeable": true,
+    "status_expiry_days": 0,
+    "status_expiry_keep_pinned": true,
+    "status_expiry_keep_faved": true,
     "note": "hey yo this is my profile!",
     "fields": [],
     "follow_requests_count": 0,
@@ -304,6 +310,12 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
+    "boostable": true,
+    "replyable": true,
+    "likeable": true,
+    "status_expiry_days": 0,
+    "status_expiry_keep_pinned": true,
+    "status_expiry_keep_faved": true,
     "note": "hey yo this is my profile!",
     "fields": [],
     "follow_requests_count": 0
@@ -681,7 +693,8 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
           {
             "id": "01HN272TAVWAXX72ZX4M8JZ0PS",
             "keyword": "fnord",
-            "whole_word": true
+            "whole_word": true,
+            "regex": false
           }
         ],
         "statuses": []
@@ -1205,7 +1218,10 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -1229,7 +1245,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
     }
   },
   "urls": {
-    "streaming_api": "wss://localhost:8080"
+    "streaming_api": "ws://localhost:8080"
   },
   "stats": {
     "domain_count": 2,
@@ -1308,7 +1324,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
   ],
   "configuration": {
     "urls": {
-      "streaming": "wss://localhost:8080"
+      "streaming": "ws://localhost:8080"
     },
     "accounts": {
       "allow_custom_css": true,
@@ -1330,7 +1346,10 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/flac"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -1766,7 +1785,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
   },
   "statuses": [],
   "rules": [],
-  "action_taken_comment": "user was warned not to be a turtle anymore"
+  "action_taken_comment": "user was warned not to be a turtle anymore",
+  "resolution_category": "actioned"
 }`, string(b))
 }
 
@@ -1979,7 +1999,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "text": "Do crime"
     }
   ],
-  "action_taken_comment": null
+  "action_taken_comment": null,
+  "resolution_category": null
 }`, string(b))
 }
 
@@ -2203,7 +2224,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
   },
   "statuses": [],
   "rules": [],
-  "action_taken_comment": "user was warned not to be a turtle anymore"
+  "action_taken_comment": "user was warned not to be a turtle anymore",
+  "resolution_category": "actioned"
 }`, string(b))
 }
 
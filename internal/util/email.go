@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// CanonicalEmail returns the canonical form of the given
+// email address, as used for canonical email blocks. This
+// lowercases the address, and strips any dots or "+tag"
+// suffix from the local part, so that common aliases of
+// one mailbox all map to the same canonical address.
+//
+// This follows the same canonicalization as Mastodon, so
+// that canonical email hashes are compatible between them.
+func CanonicalEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		// Not an email address,
+		// nothing to canonicalize.
+		return email
+	}
+
+	local = strings.ReplaceAll(local, ".", "")
+	local, _, _ = strings.Cut(local, "+")
+
+	return local + "@" + domain
+}
+
+// CanonicalEmailHash returns the hex-encoded
+// SHA256 hash of the canonical email address.
+func CanonicalEmailHash(email string) string {
+	sum := sha256.Sum256([]byte(CanonicalEmail(email)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type EmailSuite struct {
+	suite.Suite
+}
+
+func (suite *EmailSuite) TestCanonicalEmail() {
+	tests := []struct {
+		in, out string
+	}{
+		{in: "someone@example.org", out: "someone@example.org"},
+		{in: "SomeOne@Example.org", out: "someone@example.org"},
+		{in: "some.one@example.org", out: "someone@example.org"},
+		{in: "some.one+spam@example.org", out: "someone@example.org"},
+		{in: "someone+spam+more@example.org", out: "someone@example.org"},
+		{in: " someone@example.org ", out: "someone@example.org"},
+		{in: "someone@mail.example.org", out: "someone@mail.example.org"},
+		{in: "not an email", out: "not an email"},
+	}
+
+	for _, test := range tests {
+		suite.Equal(test.out, util.CanonicalEmail(test.in), test.in)
+	}
+}
+
+func (suite *EmailSuite) TestCanonicalEmailHash() {
+	const hash = "79a6123c2db3b110c92f2872d217545dfc5ff5147bbdd47e67e72f223747a538"
+	suite.Equal(hash, util.CanonicalEmailHash("someone@example.org"))
+	suite.Equal(hash, util.CanonicalEmailHash("Some.One+spam@example.org"))
+	suite.NotEqual(hash, util.CanonicalEmailHash("someone.else@example.org"))
+}
+
+func TestEmailSuite(t *testing.T) {
+	suite.Run(t, &EmailSuite{})
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"regexp"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -329,6 +330,16 @@ func FilterKeyword(keyword string) error {
 	return nil
 }
 
+// FilterKeywordRegex validates that a filter keyword
+// is a valid regular expression, for regex keywords.
+func FilterKeywordRegex(keyword string) error {
+	if _, err := regexp.Compile(keyword); err != nil {
+		return fmt.Errorf("filter keyword is not a valid regular expression: %w", err)
+	}
+
+	return nil
+}
+
 // FilterTitle validates the title of a new or updated filter.
 func FilterTitle(title string) error {
 	if title == "" {
@@ -394,7 +405,9 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// Invite codes allow sign-up while registration
+	// is closed; the code itself is checked later on.
+	if !config.GetAccountsRegistrationOpen() && form.InviteCode == "" {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -30,6 +30,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+// inviteCodeKey is the query key used to pass an
+// invite code through to the sign-up form, so that
+// invite links can be shared as /signup?invite=code.
+const inviteCodeKey = "invite"
+
 func (m *Module) signupGETHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -60,6 +65,7 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"reasonRequired":   config.GetAccountsReasonRequired(),
 			"registrationOpen": config.GetAccountsRegistrationOpen(),
+			"inviteCode":       c.Query(inviteCodeKey),
 		},
 	}
 
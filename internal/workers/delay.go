@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// wheelTick is the resolution of the delayed message
+	// timer wheel, i.e. a delayed message may be pushed
+	// to its queue up to this long after its due time.
+	wheelTick = time.Second
+
+	// wheelSize is the number of slots in the timer
+	// wheel, i.e. one full rotation is wheelSize ticks.
+	// Messages due further out than one rotation remain
+	// in their slot until the required no. rotations.
+	wheelSize = 512
+)
+
+// timerWheel holds delayed messages in slots by due
+// tick, so that advancing each tick only needs to
+// visit the messages in the one slot now current,
+// rather than checking every delayed message.
+type timerWheel[Msg any] struct {
+	slots [wheelSize][]delayed[Msg]
+	pos   int // current slot
+	len   int // total delayed
+	mu    sync.Mutex
+}
+
+// delayed is a message not
+// to be pushed before 'at'.
+type delayed[Msg any] struct {
+	at     time.Time
+	rounds int // remaining rotations
+	value  Msg
+}
+
+// add adds values to the timer wheel, due at given time.
+func (w *timerWheel[T]) add(at time.Time, values ...T) {
+	// Calculate no. ticks until due,
+	// rounding up so never early.
+	d := time.Until(at)
+	ticks := int((d + wheelTick - 1) / wheelTick)
+	ticks = max(ticks, 1)
+
+	w.mu.Lock()
+	slot := (w.pos + ticks) % wheelSize
+	rounds := (ticks - 1) / wheelSize
+	for _, value := range values {
+		w.slots[slot] = append(w.slots[slot], delayed[T]{
+			at:     at,
+			rounds: rounds,
+			value:  value,
+		})
+	}
+	w.len += len(values)
+	w.mu.Unlock()
+}
+
+// advance moves the wheel onto the next slot,
+// returning any messages in it that are now due.
+func (w *timerWheel[T]) advance(now time.Time) (due []T) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pos = (w.pos + 1) % wheelSize
+	slot := w.slots[w.pos]
+	if len(slot) == 0 {
+		return nil
+	}
+
+	// Filter slot in-place
+	// keeping those not due.
+	keep := slot[:0]
+	var late []delayed[T]
+
+	for _, d := range slot {
+		switch {
+		case d.rounds > 0:
+			// Due on a later
+			// wheel rotation.
+			d.rounds--
+			keep = append(keep, d)
+
+		case d.at.After(now):
+			// Wheel ticked slightly ahead
+			// of due time, so move onto
+			// the next slot to never be
+			// pushed before 'at'.
+			late = append(late, d)
+
+		default:
+			due = append(due, d.value)
+		}
+	}
+
+	// Zero out dropped entries
+	// so values can be collected.
+	clear(slot[len(keep):])
+	w.slots[w.pos] = keep
+
+	if len(late) > 0 {
+		next := (w.pos + 1) % wheelSize
+		w.slots[next] = append(w.slots[next], late...)
+	}
+
+	w.len -= len(due)
+	return due
+}
+
+// Len returns the no. delayed messages.
+func (w *timerWheel[T]) Len() int {
+	w.mu.Lock()
+	n := w.len
+	w.mu.Unlock()
+	return n
+}
+
+// PushAt pushes messages to the queue (as with Push()) not before
+// given time, holding them in a timer wheel until then. Delayed
+// messages are only moved onto the queue while the queue's worker
+// pool is running, and are not included in the queue's Len().
+func (q *MsgQueue[T]) PushAt(at time.Time, values ...T) {
+	if !at.After(time.Now()) {
+		// Already due.
+		q.Push(values...)
+		return
+	}
+	q.delay.add(at, values...)
+}
+
+// PushAfter is equivalent to PushAt(time.Now().Add(d), values...).
+func (q *MsgQueue[T]) PushAfter(d time.Duration, values ...T) {
+	q.PushAt(time.Now().Add(d), values...)
+}
+
+// DelayedLen returns the number of messages pushed with
+// PushAt() / PushAfter() that are not yet due to be queued.
+func (q *MsgQueue[T]) DelayedLen() int {
+	return q.delay.Len()
+}
+
+// runDelayed is the main delayed message routine,
+// ticking the timer wheel and pushing any messages
+// now due onto the queue, until context cancelled.
+func (q *MsgQueue[T]) runDelayed(ctx context.Context) {
+	ticker := time.NewTicker(wheelTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if due := q.delay.advance(now); len(due) > 0 {
+				q.Push(due...)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerWheel(t *testing.T) {
+	var w timerWheel[string]
+
+	now := time.Now()
+	w.add(now.Add(2*wheelTick), "soon")
+	w.add(now.Add((wheelSize+2)*wheelTick), "later")
+
+	if n := w.Len(); n != 2 {
+		t.Fatalf("expected 2 delayed messages, got %d", n)
+	}
+
+	// Advance the wheel one tick at a time,
+	// noting at which tick messages are due.
+	dueAt := make(map[string]int)
+	for tick := 1; tick <= 2*wheelSize; tick++ {
+		for _, msg := range w.advance(now.Add(time.Duration(tick) * wheelTick)) {
+			dueAt[msg] = tick
+		}
+	}
+
+	// Messages should be due at (or up to a tick after) their time,
+	// even once the wheel has had to wrap around for the later one.
+	for msg, expect := range map[string]int{
+		"soon":  2,
+		"later": wheelSize + 2,
+	} {
+		if tick := dueAt[msg]; tick < expect || tick > expect+1 {
+			t.Fatalf("expected %q due at tick %d, got %d", msg, expect, tick)
+		}
+	}
+
+	if n := w.Len(); n != 0 {
+		t.Fatalf("expected no delayed messages, got %d", n)
+	}
+}
+
+func TestTimerWheelNeverEarly(t *testing.T) {
+	var w timerWheel[string]
+
+	now := time.Now()
+	w.add(now.Add(wheelTick), "msg")
+
+	// Wheel ticks ahead of due time,
+	// message should not yet be due.
+	if due := w.advance(now); len(due) != 0 {
+		t.Fatalf("expected no messages due, got %v", due)
+	}
+
+	// Message should then be due next tick.
+	if due := w.advance(now.Add(2 * wheelTick)); len(due) != 1 {
+		t.Fatalf("expected 1 message due, got %v", due)
+	}
+}
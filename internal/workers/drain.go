@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// inflight tracks the in-flight processing of a pool's
+// workers, providing them a processing context that is
+// only cancelled on abort, instead of on worker stop.
+// This allows workers to be stopped from taking any
+// further work, while letting in-flight work finish.
+type inflight struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	count  atomic.Int64
+}
+
+// init (re)initializes the processing context.
+func (f *inflight) init() {
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+}
+
+// do calls fn with processing context, tracked as in-flight.
+func (f *inflight) do(fn func(context.Context)) {
+	f.count.Add(1)
+	defer f.count.Add(-1)
+	fn(f.ctx)
+}
+
+// drain concurrently calls each of given worker stop functions, waiting
+// for in-flight processing to finish. If context is cancelled first, the
+// in-flight processing is aborted, returning the number abandoned.
+func (f *inflight) drain(ctx context.Context, stops []func() bool) (abandoned int) {
+	done := make(chan struct{})
+
+	go func() {
+		var wg sync.WaitGroup
+		for _, stop := range stops {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// return bool not useful
+				// here, as true = stopped,
+				// false = never running.
+				_ = stop()
+			}()
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Timed out, abort and wait
+		// on any in-flight processing.
+		abandoned = int(f.count.Load())
+		f.cancel()
+		<-done
+	}
+
+	// Release ctx.
+	f.cancel()
+
+	return abandoned
+}
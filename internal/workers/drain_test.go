@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+func TestMsgWorkerPoolDrain(t *testing.T) {
+	var (
+		pool    workers.MsgWorkerPool[*messages.FromClientAPI]
+		started = make(chan struct{}, 2)
+		finish  = make(chan struct{})
+		result  = make(chan error, 2)
+	)
+
+	pool.Init(messages.ClientMsgIndices(), nil)
+	pool.Process = func(ctx context.Context, msg *messages.FromClientAPI) error {
+		started <- struct{}{}
+		select {
+		case <-finish:
+			result <- nil
+		case <-ctx.Done():
+			result <- ctx.Err()
+		}
+		return nil
+	}
+
+	pool.Start(1)
+	pool.Queue.Push(
+		&messages.FromClientAPI{TargetURI: "1"},
+		&messages.FromClientAPI{TargetURI: "2"},
+	)
+	<-started
+
+	// Drain with in-flight message finishing before timeout.
+	go func() { time.Sleep(10 * time.Millisecond); close(finish) }()
+	ctx, cncl := context.WithTimeout(context.Background(), time.Second)
+	defer cncl()
+
+	if n := pool.Drain(ctx); n != 0 {
+		t.Fatalf("expected no abandoned messages, got %d", n)
+	}
+
+	// In-flight message should have finished, not been cancelled.
+	if err := <-result; err != nil {
+		t.Fatalf("expected in-flight message to finish, got %v", err)
+	}
+
+	// Second message should be left queued.
+	if n := pool.Queue.Len(); n != 1 {
+		t.Fatalf("expected 1 queued message, got %d", n)
+	}
+
+	// Now drain with in-flight message never finishing.
+	finish = make(chan struct{})
+	pool.Start(1)
+	<-started
+
+	ctx, cncl = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cncl()
+
+	if n := pool.Drain(ctx); n != 1 {
+		t.Fatalf("expected 1 abandoned message, got %d", n)
+	}
+
+	// In-flight message should have been cancelled.
+	if err := <-result; err == nil {
+		t.Fatal("expected in-flight message to be cancelled")
+	}
+}
@@ -0,0 +1,340 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers
+
+import (
+	"context"
+	"sync"
+
+	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/queue"
+)
+
+// Lane is the priority class of a queued worker
+// message, where messages in lower lanes are
+// always popped before those in higher lanes.
+type Lane uint8
+
+const (
+	// LaneInteractive is for messages whose side
+	// effects a user is directly waiting on, e.g.
+	// a new local status, or an accepted follow.
+	LaneInteractive Lane = iota
+
+	// LaneDefault is for messages
+	// not of any other lane.
+	LaneDefault
+
+	// LaneBulk is for messages of potentially large
+	// amounts of background work, that can wait, e.g.
+	// account deletions / moves, or status expiry.
+	LaneBulk
+
+	// number of lanes.
+	numLanes
+)
+
+// String returns a name for the lane, e.g. for logging / metrics.
+func (l Lane) String() string {
+	switch l {
+	case LaneInteractive:
+		return "interactive"
+	case LaneDefault:
+		return "default"
+	case LaneBulk:
+		return "bulk"
+	default:
+		return "unknown"
+	}
+}
+
+// Lanes returns all of the queue lanes, in priority order.
+func Lanes() []Lane {
+	return []Lane{LaneInteractive, LaneDefault, LaneBulk}
+}
+
+// MsgQueue wraps a queue.StructQueue{} per Lane, providing
+// the same API as a single queue, with messages popped in
+// order of lane priority. Pushed messages are sorted into
+// lanes by the queue's lane func, but this can be bypassed
+// by pushing into a particular lane with PushLane().
+type MsgQueue[Msg any] struct {
+
+	// Stats tracks messages enqueued to,
+	// and processed from, this queue.
+	Stats queue.Stats
+
+	// internal fields.
+	lane  func(Msg) Lane
+	lanes [numLanes]queue.StructQueue[Msg]
+	delay timerWheel[Msg]
+	wait  chan struct{}
+	mu    sync.Mutex
+
+	// overflow fields, see SetOverflow().
+	spill     func([]Msg) bool
+	threshold int
+	spilling  bool
+	spillMu   sync.Mutex
+}
+
+// Init initializes each of the queue's lanes with given struct indices, and sets
+// the func used to sort pushed messages into lanes (nil = always LaneDefault).
+func (q *MsgQueue[T]) Init(indices []structr.IndexConfig, lane func(T) Lane) {
+	q.lane = lane
+	for i := range q.lanes {
+		q.lanes[i].Init(structr.QueueConfig[T]{Indices: indices})
+	}
+}
+
+// SetOverflow sets a func to be passed messages pushed via Push() while the
+// queue length is at or above threshold, which should spill them elsewhere
+// (e.g. to the database) and return true, instead of them being queued in
+// memory. Returning false queues them as usual. A nil func unsets it.
+//
+// Once messages have been spilled, all further pushed messages are spilled
+// regardless of queue length, so they can't overtake those spilled before
+// them, until EndSpill() confirms that no spilled messages remain. Passing
+// spilling = true starts out this way, e.g. when spilled messages remain
+// from before a restart.
+func (q *MsgQueue[T]) SetOverflow(threshold int, spilling bool, spill func([]T) bool) {
+	q.spillMu.Lock()
+	q.threshold = threshold
+	q.spill = spill
+	q.spilling = spilling && spill != nil
+	q.spillMu.Unlock()
+}
+
+// EndSpill stops spilling every pushed message (see SetOverflow()) if drained
+// returns true. Pushed messages are held from spilling while drained is called,
+// so it can requeue any remaining spilled messages and report whether none remain.
+func (q *MsgQueue[T]) EndSpill(drained func() bool) {
+	q.spillMu.Lock()
+	defer q.spillMu.Unlock()
+	if q.spilling && drained() {
+		q.spilling = false
+	}
+}
+
+// Push pushes messages to the queue, in lanes according to the
+// lane func, or passes them to the overflow func if set and the
+// queue is at or above its threshold length. See SetOverflow().
+func (q *MsgQueue[T]) Push(values ...T) {
+	if q.trySpill(values) {
+		// Spilled elsewhere.
+		return
+	}
+
+	q.Requeue(values...)
+}
+
+// trySpill passes values to the overflow func if set and either
+// already spilling, or queue is at or above threshold length.
+// Returns whether values were spilled (i.e. not to be queued).
+func (q *MsgQueue[T]) trySpill(values []T) bool {
+	q.spillMu.Lock()
+	defer q.spillMu.Unlock()
+
+	if q.spill == nil {
+		return false
+	}
+
+	if !q.spilling && q.Len() < q.threshold {
+		return false
+	}
+
+	if !q.spill(values) {
+		return false
+	}
+
+	q.spilling = true
+	return true
+}
+
+// Requeue pushes messages to the queue, in lanes according to the lane func,
+// bypassing any overflow func, e.g. when restoring previously spilled messages.
+func (q *MsgQueue[T]) Requeue(values ...T) {
+	if q.lane == nil {
+		q.PushLane(LaneDefault, values...)
+		return
+	}
+	for _, value := range values {
+		q.PushLane(q.lane(value), value)
+	}
+}
+
+// PushLane pushes messages to the queue in given lane.
+func (q *MsgQueue[T]) PushLane(lane Lane, values ...T) {
+	if lane >= numLanes {
+		lane = LaneBulk
+	}
+	q.lanes[lane].Push(values...)
+	q.Stats.Enqueue(len(values))
+
+	// Wake any waiting poppers.
+	q.mu.Lock()
+	if q.wait != nil {
+		close(q.wait)
+		q.wait = nil
+	}
+	q.mu.Unlock()
+}
+
+// Pop pops the next message from the queue, by lane priority.
+func (q *MsgQueue[T]) Pop() (value T, ok bool) {
+	return q.pop(LaneBulk)
+}
+
+// PopCtx blocks until it can pop the next message from
+// the queue by lane priority, or context is cancelled.
+func (q *MsgQueue[T]) PopCtx(ctx context.Context) (value T, ok bool) {
+	return q.PopCtxLane(ctx, LaneBulk)
+}
+
+// PopCtxLane is as PopCtx(), but only popping from
+// lanes with priority up to and including given lane.
+func (q *MsgQueue[T]) PopCtxLane(ctx context.Context, lane Lane) (value T, ok bool) {
+	for {
+		if ctx.Err() != nil {
+			// Don't pop any further
+			// once context cancelled.
+			return
+		}
+
+		// Get wait channel before popping,
+		// so a push in-between isn't missed.
+		wait := q.waitCh()
+
+		if value, ok = q.pop(lane); ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wait:
+		}
+	}
+}
+
+// Delete pops (and drops!) all queued entries
+// under index with key, from all queue lanes.
+func (q *MsgQueue[T]) Delete(index string, key ...any) {
+	for i := range q.lanes {
+		q.lanes[i].Delete(index, key...)
+	}
+}
+
+// Len returns the total length of all queue lanes.
+func (q *MsgQueue[T]) Len() (n int) {
+	for i := range q.lanes {
+		n += q.lanes[i].Len()
+	}
+	return
+}
+
+// LaneLen returns the length of given queue lane.
+func (q *MsgQueue[T]) LaneLen(lane Lane) int {
+	if lane >= numLanes {
+		return 0
+	}
+	return q.lanes[lane].Len()
+}
+
+// pop pops next message in lanes up to and including given lane.
+func (q *MsgQueue[T]) pop(lane Lane) (value T, ok bool) {
+	for i := Lane(0); i <= lane && i < numLanes; i++ {
+		if value, ok = q.lanes[i].Pop(); ok {
+			return
+		}
+	}
+	return
+}
+
+// waitCh returns current wait channel, which will
+// be closed to awaken when new value is pushed.
+func (q *MsgQueue[T]) waitCh() <-chan struct{} {
+	q.mu.Lock()
+	if q.wait == nil {
+		q.wait = make(chan struct{})
+	}
+	wait := q.wait
+	q.mu.Unlock()
+	return wait
+}
+
+// laneWorkers returns the number of workers to start for
+// each lane of an n sized pool, where a lane's workers
+// will also process messages from higher priority lanes.
+// This reserves a quarter of workers for interactive
+// messages only, and limits bulk messages to a quarter.
+func laneWorkers(n int) (counts [numLanes]int) {
+	counts[LaneBulk] = max(1, n/4)
+	counts[LaneInteractive] = n / 4
+	counts[LaneDefault] = max(0, n-counts[LaneBulk]-counts[LaneInteractive])
+	return
+}
+
+// ClientMsgLane returns the queue Lane for given client API message.
+func ClientMsgLane(msg *messages.FromClientAPI) Lane {
+	switch msg.APActivityType {
+	case ap.ActivityCreate:
+		switch msg.APObjectType {
+		case ap.ObjectNote,
+			ap.ActivityQuestion,
+			ap.ActivityFollow,
+			ap.ActivityLike,
+			ap.ActivityAnnounce,
+			ap.ActivityBlock:
+			return LaneInteractive
+		}
+
+	case ap.ActivityAccept, ap.ActivityReject, ap.ActivityUndo:
+		return LaneInteractive
+
+	case ap.ActivityDelete:
+		switch msg.APObjectType {
+		case ap.ActorPerson, ap.ObjectProfile:
+			return LaneBulk
+		}
+
+	case ap.ActivityMove:
+		return LaneBulk
+	}
+
+	return LaneDefault
+}
+
+// FederatorMsgLane returns the queue Lane for given federator API message.
+func FederatorMsgLane(msg *messages.FromFediAPI) Lane {
+	switch msg.APActivityType {
+	case ap.ActivityAccept:
+		return LaneInteractive
+
+	case ap.ActivityDelete:
+		if msg.APObjectType == ap.ActorPerson {
+			return LaneBulk
+		}
+
+	case ap.ActivityMove:
+		return LaneBulk
+	}
+
+	return LaneDefault
+}
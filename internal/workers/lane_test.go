@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+func TestMsgQueueLanes(t *testing.T) {
+	var q workers.MsgQueue[*messages.FromClientAPI]
+	q.Init(messages.ClientMsgIndices(), workers.ClientMsgLane)
+
+	var (
+		bulk = &messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			TargetURI:      "bulk",
+		}
+		deflt = &messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			TargetURI:      "default",
+		}
+		interactive = &messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			TargetURI:      "interactive",
+		}
+	)
+
+	// Push in reverse priority order.
+	q.Push(bulk, deflt, interactive)
+
+	if n := q.Len(); n != 3 {
+		t.Fatalf("expected queue length 3, got %d", n)
+	}
+
+	for _, lane := range []workers.Lane{
+		workers.LaneInteractive,
+		workers.LaneDefault,
+		workers.LaneBulk,
+	} {
+		if n := q.LaneLen(lane); n != 1 {
+			t.Fatalf("expected lane %d length 1, got %d", lane, n)
+		}
+	}
+
+	ctx, cncl := context.WithTimeout(context.Background(), time.Second)
+	defer cncl()
+
+	// Messages should be popped in priority order.
+	for _, expect := range []*messages.FromClientAPI{
+		interactive,
+		deflt,
+	} {
+		msg, ok := q.PopCtxLane(ctx, workers.LaneDefault)
+		if !ok || msg != expect {
+			t.Fatalf("expected %s message, got %v", expect.TargetURI, msg)
+		}
+	}
+
+	// Bulk message should not be popped
+	// by a worker of a higher priority lane.
+	shortCtx, shortCncl := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCncl()
+	if msg, ok := q.PopCtxLane(shortCtx, workers.LaneDefault); ok {
+		t.Fatalf("unexpected message popped: %s", msg.TargetURI)
+	}
+
+	// Block on interactive lane, and
+	// check awoken on push to it.
+	popped := make(chan *messages.FromClientAPI)
+	go func() {
+		msg, _ := q.PopCtxLane(ctx, workers.LaneInteractive)
+		popped <- msg
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.PushLane(workers.LaneInteractive, deflt)
+
+	if msg := <-popped; msg != deflt {
+		t.Fatalf("expected pushed message, got %v", msg)
+	}
+
+	// Finally the bulk message.
+	if msg, ok := q.Pop(); !ok || msg != bulk {
+		t.Fatalf("expected bulk message, got %v", msg)
+	}
+}
+
+func TestMsgQueuePushAfter(t *testing.T) {
+	var pool workers.MsgWorkerPool[*messages.FromClientAPI]
+	pool.Init(messages.ClientMsgIndices(), nil)
+
+	processed := make(chan time.Time, 1)
+	pool.Process = func(ctx context.Context, msg *messages.FromClientAPI) error {
+		processed <- time.Now()
+		return nil
+	}
+
+	pool.Start(1)
+	defer pool.Stop()
+
+	start := time.Now()
+	pool.Queue.PushAfter(time.Second, &messages.FromClientAPI{TargetURI: "delayed"})
+
+	if n := pool.Queue.DelayedLen(); n != 1 {
+		t.Fatalf("expected 1 delayed message, got %d", n)
+	}
+
+	select {
+	case at := <-processed:
+		if at.Sub(start) < time.Second {
+			t.Fatalf("expected message processed after 1s, got %s", at.Sub(start))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting on delayed message")
+	}
+}
@@ -35,7 +35,8 @@ type FnWorkerPool struct {
 	Queue queue.SimpleQueue[func(context.Context)]
 
 	// internal fields.
-	workers []*FnWorker
+	workers  []*FnWorker
+	inflight inflight
 }
 
 // Start will attempt to start 'n' FnWorker{}s.
@@ -47,6 +48,9 @@ func (p *FnWorkerPool) Start(n int) {
 		return
 	}
 
+	// Prepare in-flight tracking.
+	p.inflight.init()
+
 	// Allocate new workers slice.
 	p.workers = make([]*FnWorker, n)
 	for i := range p.workers {
@@ -54,6 +58,7 @@ func (p *FnWorkerPool) Start(n int) {
 		// Allocate new FnWorker{}.
 		p.workers[i] = new(FnWorker)
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].inflight = &p.inflight
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -63,26 +68,39 @@ func (p *FnWorkerPool) Start(n int) {
 	}
 }
 
-// Stop will attempt to stop contained FnWorker{}s.
+// Stop will attempt to stop contained FnWorker{}s,
+// cancelling any in-flight function tasks.
 func (p *FnWorkerPool) Stop() {
+	ctx, cncl := context.WithCancel(context.Background())
+	cncl() // i.e. don't wait
+	_ = p.Drain(ctx)
+}
+
+// Drain will attempt to stop contained FnWorker{}s, such that they
+// pop no further queued functions, waiting until any in-flight tasks
+// finish, or context is cancelled. In the latter case, in-flight
+// tasks are cancelled, and the number abandoned is returned.
+func (p *FnWorkerPool) Drain(ctx context.Context) (abandoned int) {
 	// Check whether workers are
 	// set (is currently running).
 	ok := (len(p.workers) == 0)
 	if ok {
-		return
+		return 0
 	}
 
-	// Stop all running workers.
+	// Gather stop funcs of all running workers.
+	stops := make([]func() bool, len(p.workers))
 	for i := range p.workers {
-
-		// return bool not useful
-		// here, as true = stopped,
-		// false = never running.
-		_ = p.workers[i].Stop()
+		stops[i] = p.workers[i].Stop
 	}
 
+	// Stop workers, waiting on in-flight.
+	abandoned = p.inflight.drain(ctx, stops)
+
 	// Unset workers slice.
 	p.workers = p.workers[:0]
+
+	return abandoned
 }
 
 // FnWorker wraps a queue.SimpleQueue{} which
@@ -96,7 +114,8 @@ type FnWorker struct {
 	Queue *queue.SimpleQueue[func(context.Context)]
 
 	// internal fields.
-	service runners.Service
+	service  runners.Service
+	inflight *inflight
 }
 
 // Start will attempt to start the Worker{}.
@@ -130,13 +149,28 @@ func (w *FnWorker) process(ctx context.Context) {
 	}
 
 	for {
+		if ctx.Err() != nil {
+			// Don't pop any further
+			// once context cancelled.
+			return
+		}
+
 		// Block until pop next func.
 		fn, ok := w.Queue.PopCtx(ctx)
 		if !ok {
 			return
 		}
 
-		// run!
-		fn(ctx)
+		if w.inflight == nil {
+			// Not part of a pool,
+			// run with own context.
+			fn(ctx)
+			continue
+		}
+
+		// run! with pool's processing context, so
+		// that stopping the worker (i.e. cancelling its
+		// context) doesn't cut off in-flight functions.
+		w.inflight.do(fn)
 	}
 }
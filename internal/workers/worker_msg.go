@@ -19,11 +19,11 @@ package workers
 
 import (
 	"context"
+	"time"
 
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-structr"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -34,20 +34,24 @@ type MsgWorkerPool[Msg any] struct {
 	// Process handles queued message types.
 	Process func(context.Context, Msg) error
 
-	// Queue is embedded queue.StructQueue{}
-	// passed to each of the pool Worker{}s.
-	Queue queue.StructQueue[Msg]
+	// Queue is embedded MsgQueue{} passed
+	// to each of the pool Worker{}s.
+	Queue MsgQueue[Msg]
 
 	// internal fields.
-	workers []*MsgWorker[Msg]
+	workers  []*MsgWorker[Msg]
+	inflight inflight
+	delayer  runners.Service
 }
 
-// Init will initialize the worker pool queue with given struct indices.
-func (p *MsgWorkerPool[T]) Init(indices []structr.IndexConfig) {
-	p.Queue.Init(structr.QueueConfig[T]{Indices: indices})
+// Init will initialize the worker pool queue with given
+// struct indices, and func to sort messages into lanes.
+func (p *MsgWorkerPool[T]) Init(indices []structr.IndexConfig, lane func(T) Lane) {
+	p.Queue.Init(indices, lane)
 }
 
-// Start will attempt to start 'n' Worker{}s.
+// Start will attempt to start 'n' Worker{}s, split
+// between queue lanes according to laneWorkers().
 func (p *MsgWorkerPool[T]) Start(n int) {
 	// Check whether workers are
 	// set (is already running).
@@ -56,43 +60,73 @@ func (p *MsgWorkerPool[T]) Start(n int) {
 		return
 	}
 
-	// Allocate new msg workers slice.
-	p.workers = make([]*MsgWorker[T], n)
-	for i := range p.workers {
+	// Prepare in-flight tracking.
+	p.inflight.init()
 
-		// Allocate new MsgWorker[T]{}.
-		p.workers[i] = new(MsgWorker[T])
-		p.workers[i].Process = p.Process
-		p.workers[i].Queue = &p.Queue
+	// Start pushing delayed
+	// messages to queue when due.
+	_ = p.delayer.GoRun(p.Queue.runDelayed)
 
-		// Attempt to start worker.
-		// Return bool not useful
-		// here, as true = started,
-		// false = already running.
-		_ = p.workers[i].Start()
+	// Allocate new msg workers slice.
+	p.workers = make([]*MsgWorker[T], 0, n)
+	for lane, count := range laneWorkers(n) {
+		for i := 0; i < count; i++ {
+
+			// Allocate new MsgWorker[T]{}.
+			w := new(MsgWorker[T])
+			w.Process = p.Process
+			w.Queue = &p.Queue
+			w.Lane = Lane(lane) // #nosec G115 -- lane < numLanes
+			w.inflight = &p.inflight
+
+			// Attempt to start worker.
+			// Return bool not useful
+			// here, as true = started,
+			// false = already running.
+			_ = w.Start()
+
+			p.workers = append(p.workers, w)
+		}
 	}
 }
 
-// Stop will attempt to stop contained Worker{}s.
+// Stop will attempt to stop contained Worker{}s,
+// cancelling any in-flight message processing.
 func (p *MsgWorkerPool[T]) Stop() {
+	ctx, cncl := context.WithCancel(context.Background())
+	cncl() // i.e. don't wait
+	_ = p.Drain(ctx)
+}
+
+// Drain will attempt to stop contained Worker{}s, such that they
+// pop no further queued messages, waiting until any in-flight message
+// processing finishes, or context is cancelled. In the latter case,
+// in-flight processing is cancelled, and the number of messages
+// abandoned mid-processing is returned. Queued messages are kept.
+func (p *MsgWorkerPool[T]) Drain(ctx context.Context) (abandoned int) {
 	// Check whether workers are
 	// set (is currently running).
 	ok := (len(p.workers) == 0)
 	if ok {
-		return
+		return 0
 	}
 
-	// Stop all running workers.
-	for i := range p.workers {
+	// Stop pushing delayed messages.
+	_ = p.delayer.Stop()
 
-		// return bool not useful
-		// here, as true = stopped,
-		// false = never running.
-		_ = p.workers[i].Stop()
+	// Gather stop funcs of all running workers.
+	stops := make([]func() bool, len(p.workers))
+	for i := range p.workers {
+		stops[i] = p.workers[i].Stop
 	}
 
+	// Stop workers, waiting on in-flight.
+	abandoned = p.inflight.drain(ctx, stops)
+
 	// Unset workers slice.
 	p.workers = p.workers[:0]
+
+	return abandoned
 }
 
 // MsgWorker wraps a processing function to
@@ -104,12 +138,18 @@ type MsgWorker[Msg any] struct {
 	// Process handles queued message types.
 	Process func(context.Context, Msg) error
 
-	// Queue is the Delivery{} message queue
-	// that delivery worker will feed from.
-	Queue *queue.StructQueue[Msg]
+	// Queue is the MsgQueue{} that
+	// the worker will feed from.
+	Queue *MsgQueue[Msg]
+
+	// Lane is the lowest priority queue lane the
+	// worker will pop from, i.e. it will also pop
+	// messages from any higher priority lanes.
+	Lane Lane
 
 	// internal fields.
-	service runners.Service
+	service  runners.Service
+	inflight *inflight
 }
 
 // Start will attempt to start the Worker{}.
@@ -142,14 +182,32 @@ func (w *MsgWorker[T]) process(ctx context.Context) {
 
 	for {
 		// Block until pop next message.
-		msg, ok := w.Queue.PopCtx(ctx)
+		msg, ok := w.Queue.PopCtxLane(ctx, w.Lane)
 		if !ok {
 			return
 		}
 
-		// Attempt to process popped message type.
-		if err := w.Process(ctx, msg); err != nil {
-			log.Errorf(ctx, "%p: error processing: %v", w, err)
+		if w.inflight == nil {
+			// Not part of a pool, process
+			// using the worker's own context.
+			w.processMsg(ctx, msg)
+			continue
 		}
+
+		// Process with pool's processing context, so
+		// that stopping the worker (i.e. cancelling its
+		// context) doesn't cut off in-flight processing.
+		w.inflight.do(func(ctx context.Context) {
+			w.processMsg(ctx, msg)
+		})
+	}
+}
+
+// processMsg attempts to process popped message type.
+func (w *MsgWorker[T]) processMsg(ctx context.Context, msg T) {
+	start := time.Now()
+	if err := w.Process(ctx, msg); err != nil {
+		log.Errorf(ctx, "%p: error processing: %v", w, err)
 	}
+	w.Queue.Stats.Process(time.Since(start))
 }
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+func TestMsgWorkerPoolStats(t *testing.T) {
+	var (
+		pool     workers.MsgWorkerPool[*messages.FromClientAPI]
+		observed = make(chan time.Duration, 2)
+	)
+
+	pool.Init(messages.ClientMsgIndices(), nil)
+	pool.Process = func(ctx context.Context, msg *messages.FromClientAPI) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+	pool.Queue.Stats.Observe(func(d time.Duration) {
+		observed <- d
+	})
+
+	pool.Queue.Push(
+		&messages.FromClientAPI{TargetURI: "1"},
+		&messages.FromClientAPI{TargetURI: "2"},
+	)
+
+	if n := pool.Queue.Stats.Enqueued(); n != 2 {
+		t.Fatalf("expected 2 enqueued messages, got %d", n)
+	}
+
+	pool.Start(1)
+	defer pool.Stop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case d := <-observed:
+			if d < time.Millisecond {
+				t.Fatalf("expected processing duration >= 1ms, got %s", d)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting on processed message")
+		}
+	}
+
+	if n := pool.Queue.Stats.Processed(); n != 2 {
+		t.Fatalf("expected 2 processed messages, got %d", n)
+	}
+
+	if d := pool.Queue.Stats.Latency(); d < 2*time.Millisecond {
+		t.Fatalf("expected total latency >= 2ms, got %s", d)
+	}
+}
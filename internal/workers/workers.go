@@ -18,7 +18,9 @@
 package workers
 
 import (
+	"context"
 	"runtime"
+	"sync"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -49,6 +51,11 @@ type Workers struct {
 	// for asynchronous dereferencer jobs.
 	Dereference FnWorkerPool
 
+	// Processing provides a worker pool for
+	// long-running asynchronous processing
+	// jobs, e.g. generating account exports.
+	Processing FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -80,23 +87,62 @@ func (w *Workers) Start() {
 	n = 4 * maxprocs
 	w.Dereference.Start(n)
 	log.Infof(nil, "started %d dereference workers", n)
+
+	n = maxprocs
+	w.Processing.Start(n)
+	log.Infof(nil, "started %d processing workers", n)
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
+// The client, federator, dereference and processing worker pools are first drained,
+// i.e. stopped from taking further queued work while in-flight work is
+// given up to the configured drain timeout to finish before cancelling.
 func (w *Workers) Stop() {
 	_ = w.Scheduler.Stop() // false = not running
 
 	w.Delivery.Stop()
 	log.Info(nil, "stopped delivery workers")
 
-	w.Client.Stop()
-	log.Info(nil, "stopped client workers")
+	timeout := config.GetAdvancedWorkerDrainTimeout()
+	ctx, cncl := context.WithTimeout(context.Background(), timeout)
+	defer cncl()
 
-	w.Federator.Stop()
-	log.Info(nil, "stopped federator workers")
+	if timeout > 0 {
+		log.Infof(nil, "draining workers, waiting up to %s for in-flight work", timeout)
+	}
 
-	w.Dereference.Stop()
-	log.Info(nil, "stopped dereference workers")
+	var (
+		wg sync.WaitGroup
+
+		// no. in-flight abandoned per pool.
+		client, federator, dereference, processing int
+	)
+
+	// Drain all pools concurrently,
+	// so they share the one timeout.
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		client = w.Client.Drain(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		federator = w.Federator.Drain(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		dereference = w.Dereference.Drain(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		processing = w.Processing.Drain(ctx)
+	}()
+	wg.Wait()
+
+	log.Infof(nil, "stopped client workers: abandoned=%d queued=%d delayed=%d", client, w.Client.Queue.Len(), w.Client.Queue.DelayedLen())
+	log.Infof(nil, "stopped federator workers: abandoned=%d queued=%d delayed=%d", federator, w.Federator.Queue.Len(), w.Federator.Queue.DelayedLen())
+	log.Infof(nil, "stopped dereference workers: abandoned=%d queued=%d", dereference, w.Dereference.Queue.Len())
+	log.Infof(nil, "stopped processing workers: abandoned=%d queued=%d", processing, w.Processing.Queue.Len())
 }
 
 // nocopy when embedded will signal linter to
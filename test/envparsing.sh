@@ -6,20 +6,34 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-allow-user-invites": false,
     "accounts-custom-css-length": 5000,
+    "accounts-export-path": "",
     "accounts-reason-required": false,
     "accounts-registration-open": true,
+    "activity-type": "",
+    "advanced-activity-concurrency": [],
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
+    "advanced-delivery-dead-host-after": 86400000000000,
+    "advanced-delivery-max-attempts": 12,
+    "advanced-federator-spool-threshold": 0,
     "advanced-header-filter-mode": "",
+    "advanced-persist-worker-queues": false,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
     "advanced-rate-limit-requests": 6969,
     "advanced-sender-multiplier": -1,
+    "advanced-streaming-buffer-size": 50,
+    "advanced-streaming-idle-timeout": 90000000000,
+    "advanced-streaming-max-per-account": 20,
+    "advanced-streaming-max-total": 0,
+    "advanced-streaming-ping-interval": 30000000000,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
+    "advanced-worker-drain-timeout": 30000000000,
     "application-name": "gts",
     "bind-address": "127.0.0.1",
     "cache": {
@@ -42,6 +56,7 @@ EXPECT=$(cat << "EOF"
         "follow-request-ids-mem-ratio": 2,
         "follow-request-mem-ratio": 2,
         "in-reply-to-ids-mem-ratio": 3,
+        "inbox-activity-mem-ratio": 0.1,
         "instance-mem-ratio": 1,
         "list-entry-mem-ratio": 2,
         "list-mem-ratio": 1,
@@ -64,12 +79,14 @@ EXPECT=$(cat << "EOF"
         "thread-mute-mem-ratio": 0.2,
         "token-mem-ratio": 0.75,
         "tombstone-mem-ratio": 0.5,
+        "unretrievable-mem-ratio": 0.1,
         "user-mem-ratio": 0.25,
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
         "webfinger-mem-ratio": 0.1
     },
+    "code": "",
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
@@ -84,12 +101,28 @@ EXPECT=$(cat << "EOF"
     "db-tls-mode": "disable",
     "db-type": "sqlite",
     "db-user": "sex-haver",
+    "domain": "",
     "dry-run": true,
     "email": "",
+    "expires-in": 0,
+    "federate": false,
     "host": "example.com",
     "http-client": {
         "allow-ips": [],
         "block-ips": [],
+        "circuit-breaker-cooldown": 60000000000,
+        "circuit-breaker-failures": 10,
+        "delivery-max-body-size": 0,
+        "delivery-timeout": 0,
+        "dereference-max-body-size": 0,
+        "dereference-timeout": 0,
+        "host-request-budget": 0,
+        "host-request-window": 300000000000,
+        "i2p-proxy": "",
+        "media-max-body-size": 0,
+        "media-timeout": 0,
+        "onion-proxy": "",
+        "proxy": "",
         "timeout": 10000000000,
         "tls-insecure-skip-verify": false
     },
@@ -99,6 +132,9 @@ EXPECT=$(cat << "EOF"
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
     "instance-federation-mode": "allowlist",
+    "instance-federation-outbox-backfill-count": 0,
+    "instance-federation-replies-max-count": 512,
+    "instance-federation-replies-max-depth": 16,
     "instance-federation-spam-filter": true,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
@@ -115,13 +151,18 @@ EXPECT=$(cat << "EOF"
     "log-db-queries": true,
     "log-level": "info",
     "log-timestamp-format": "banana",
+    "max-uses": 0,
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
+    "media-derived-format": "webp",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
+    "media-metadata-keep-icc": false,
+    "media-metadata-keep-orientation": false,
+    "media-metadata-strip-all": true,
     "media-remote-cache-days": 30,
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
@@ -149,6 +190,7 @@ EXPECT=$(cat << "EOF"
     "path": "",
     "port": 6969,
     "protocol": "http",
+    "redis-url": "redis://localhost:6379",
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
     "smtp-disclose-recipients": true,
@@ -168,6 +210,7 @@ EXPECT=$(cat << "EOF"
     "storage-s3-bucket": "gts",
     "storage-s3-endpoint": "localhost:9000",
     "storage-s3-proxy": true,
+    "storage-s3-redirect-url-expiry": 3600000000000,
     "storage-s3-secret-key": "miniostorage",
     "storage-s3-use-ssl": false,
     "syslog-address": "127.0.0.1:6969",
@@ -175,6 +218,7 @@ EXPECT=$(cat << "EOF"
     "syslog-protocol": "udp",
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
+    "to": "",
     "tracing-enabled": false,
     "tracing-endpoint": "localhost:4317",
     "tracing-insecure-transport": true,
@@ -184,6 +228,7 @@ EXPECT=$(cat << "EOF"
         "docker.host.local"
     ],
     "username": "",
+    "users": false,
     "web-asset-base-dir": "/root",
     "web-template-base-dir": "/root"
 }
@@ -215,6 +260,7 @@ GTS_DB_SQLITE_JOURNAL_MODE='DELETE' \
 GTS_DB_SQLITE_SYNCHRONOUS='FULL' \
 GTS_DB_SQLITE_CACHE_SIZE=0 \
 GTS_DB_SQLITE_BUSY_TIMEOUT='1s' \
+GTS_REDIS_URL='redis://localhost:6379' \
 GTS_TLS_MODE='' \
 GTS_DB_TLS_CA_CERT='' \
 GTS_WEB_TEMPLATE_BASE_DIR='/root' \
@@ -239,6 +285,10 @@ GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_METADATA_KEEP_ORIENTATION=false \
+GTS_MEDIA_METADATA_KEEP_ICC=false \
+GTS_MEDIA_METADATA_STRIP_ALL=true \
+GTS_MEDIA_DERIVED_FORMAT=webp \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -248,6 +298,7 @@ GTS_STORAGE_S3_SECRET_KEY='miniostorage' \
 GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
+GTS_STORAGE_S3_REDIRECT_URL_EXPIRY='1h' \
 GTS_STORAGE_S3_BUCKET='gts' \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
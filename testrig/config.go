@@ -66,12 +66,14 @@ func testDefaults() config.Configuration {
 		WebTemplateBaseDir: "./web/template/",
 		WebAssetBaseDir:    "./web/assets/",
 
-		InstanceFederationMode:         config.InstanceFederationModeDefault,
-		InstanceFederationSpamFilter:   true,
-		InstanceExposePeers:            true,
-		InstanceExposeSuspended:        true,
-		InstanceExposeSuspendedWeb:     true,
-		InstanceDeliverToSharedInboxes: true,
+		InstanceFederationMode:            config.InstanceFederationModeDefault,
+		InstanceFederationSpamFilter:      true,
+		InstanceFederationRepliesMaxDepth: 16,
+		InstanceFederationRepliesMaxCount: 512,
+		InstanceExposePeers:               true,
+		InstanceExposeSuspended:           true,
+		InstanceExposeSuspendedWeb:        true,
+		InstanceDeliverToSharedInboxes:    true,
 		InstanceLanguages: language.Languages{
 			{
 				TagStr: "nl",
@@ -85,16 +87,22 @@ func testDefaults() config.Configuration {
 		AccountsReasonRequired:   true,
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
-
-		MediaImageMaxSize:        10485760, // 10MiB
-		MediaVideoMaxSize:        41943040, // 40MiB
-		MediaDescriptionMinChars: 0,
-		MediaDescriptionMaxChars: 500,
-		MediaRemoteCacheDays:     7,
-		MediaEmojiLocalMaxSize:   51200,          // 50KiB
-		MediaEmojiRemoteMaxSize:  102400,         // 100KiB
-		MediaCleanupFrom:         "00:00",        // midnight.
-		MediaCleanupEvery:        24 * time.Hour, // 1/day.
+		AccountsAllowUserInvites: false,
+		AccountsExportPath:       "",
+
+		MediaImageMaxSize:            10485760, // 10MiB
+		MediaVideoMaxSize:            41943040, // 40MiB
+		MediaDescriptionMinChars:     0,
+		MediaDescriptionMaxChars:     500,
+		MediaRemoteCacheDays:         7,
+		MediaEmojiLocalMaxSize:       51200,  // 50KiB
+		MediaEmojiRemoteMaxSize:      102400, // 100KiB
+		MediaMetadataStripAll:        false,
+		MediaMetadataKeepOrientation: true,
+		MediaMetadataKeepICC:         true,
+		MediaDerivedFormat:           "",
+		MediaCleanupFrom:             "00:00",        // midnight.
+		MediaCleanupEvery:            24 * time.Hour, // 1/day.
 
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
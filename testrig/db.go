@@ -29,14 +29,20 @@ import (
 
 var testModels = []interface{}{
 	&gtsmodel.Account{},
+	&gtsmodel.AccountExport{},
 	&gtsmodel.AccountToEmoji{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.CanonicalEmailBlock{},
+	&gtsmodel.DeliveryRetry{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainMediaPolicy{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.IPBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
 	&gtsmodel.FilterStatus{},
+	&gtsmodel.FilterSubscription{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
 	&gtsmodel.List{},
@@ -59,6 +65,10 @@ var testModels = []interface{}{
 	&gtsmodel.UserMute{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
+	&gtsmodel.Invite{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementRead{},
+	&gtsmodel.AnnouncementReaction{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
@@ -67,8 +77,10 @@ var testModels = []interface{}{
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
+	&gtsmodel.SchedulerLock{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
+	&gtsmodel.WorkerTask{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -339,6 +351,30 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestInvites() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncements() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncementReads() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestAnnouncementReactions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestUserMutes() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
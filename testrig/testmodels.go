@@ -650,44 +650,64 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 	return map[string]*gtsmodel.AccountSettings{
 		"unconfirmed_account": {
-			AccountID:       "01F8MH0BBE4FHXPH513MBVFHB0",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(false),
+			AccountID:        "01F8MH0BBE4FHXPH513MBVFHB0",
+			CreatedAt:        TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:        TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:          gtsmodel.VisibilityPublic,
+			Sensitive:        util.Ptr(false),
+			Language:         "en",
+			EnableRSS:        util.Ptr(false),
+			HideCollections:  util.Ptr(false),
+			Boostable:        util.Ptr(true),
+			Replyable:        util.Ptr(true),
+			Likeable:         util.Ptr(true),
+			ExpiryKeepPinned: util.Ptr(true),
+			ExpiryKeepFaved:  util.Ptr(true),
 		},
 		"admin_account": {
-			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
-			CreatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			UpdatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:        "01F8MH17FWEB39HZJ76B6VXSKF",
+			CreatedAt:        TimeMustParse("2022-05-17T13:10:59Z"),
+			UpdatedAt:        TimeMustParse("2022-05-17T13:10:59Z"),
+			Privacy:          gtsmodel.VisibilityPublic,
+			Sensitive:        util.Ptr(false),
+			Language:         "en",
+			EnableRSS:        util.Ptr(true),
+			HideCollections:  util.Ptr(false),
+			Boostable:        util.Ptr(true),
+			Replyable:        util.Ptr(true),
+			Likeable:         util.Ptr(true),
+			ExpiryKeepPinned: util.Ptr(true),
+			ExpiryKeepFaved:  util.Ptr(true),
 		},
 		"local_account_1": {
-			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
-			CreatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			UpdatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:        "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedAt:        TimeMustParse("2022-05-20T11:09:18Z"),
+			UpdatedAt:        TimeMustParse("2022-05-20T11:09:18Z"),
+			Privacy:          gtsmodel.VisibilityPublic,
+			Sensitive:        util.Ptr(false),
+			Language:         "en",
+			EnableRSS:        util.Ptr(true),
+			HideCollections:  util.Ptr(false),
+			Boostable:        util.Ptr(true),
+			Replyable:        util.Ptr(true),
+			Likeable:         util.Ptr(true),
+			ExpiryKeepPinned: util.Ptr(true),
+			ExpiryKeepFaved:  util.Ptr(true),
 		},
 		"local_account_2": {
-			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityFollowersOnly,
-			Sensitive:       util.Ptr(true),
-			Language:        "fr",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(true),
+			AccountID:        "01F8MH5NBDF2MV7CTC4Q5128HF",
+			CreatedAt:        TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:        TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:          gtsmodel.VisibilityFollowersOnly,
+			Sensitive:        util.Ptr(true),
+			Language:         "fr",
+			EnableRSS:        util.Ptr(false),
+			HideCollections:  util.Ptr(true),
+			Boostable:        util.Ptr(true),
+			Replyable:        util.Ptr(true),
+			Likeable:         util.Ptr(true),
+			ExpiryKeepPinned: util.Ptr(true),
+			ExpiryKeepFaved:  util.Ptr(true),
 		},
 	}
 }
@@ -1994,6 +2014,7 @@ func NewTestPolls() map[string]*gtsmodel.Poll {
 			ID:         "01HEN2RKT1YTEZ80SA8HGP105F",
 			Multiple:   util.Ptr(false),
 			HideCounts: util.Ptr(true),
+			VoteChange: util.Ptr(false),
 			Options:    []string{"good", "bad", "meh"},
 			Votes:      []int{2, 0, 0}, // needs to match stored poll votes
 			Voters:     util.Ptr(2),    // needs to match stored poll votes
@@ -2007,6 +2028,7 @@ func NewTestPolls() map[string]*gtsmodel.Poll {
 			ID:         "01HEN2QB5NR4NCEHGYC3HN84K6",
 			Multiple:   util.Ptr(false),
 			HideCounts: util.Ptr(false),
+			VoteChange: util.Ptr(false),
 			Options:    []string{"50:50", "phone a friend", "ask the audience"},
 			Votes:      []int{0, 1, 1}, // needs to match stored poll votes
 			Voters:     util.Ptr(2),    // needs to match stored poll votes
@@ -2020,6 +2042,7 @@ func NewTestPolls() map[string]*gtsmodel.Poll {
 			ID:         "01HEN2R65468ZG657C4ZPHJ4EX",
 			Multiple:   util.Ptr(true),
 			HideCounts: util.Ptr(false),
+			VoteChange: util.Ptr(false),
 			Options:    []string{"vaseline", "tissues", "financial times"},
 			Votes:      []int{3, 2, 18},
 			Voters:     util.Ptr(6),
@@ -2033,6 +2056,7 @@ func NewTestPolls() map[string]*gtsmodel.Poll {
 			ID:         "01HEWV1GW2D49R919NPEDXPTZ5",
 			Multiple:   util.Ptr(true),
 			HideCounts: util.Ptr(false),
+			VoteChange: util.Ptr(false),
 			Options:    []string{"vaseline", "tissues", "financial times"},
 			Votes:      []int{0, 0, 0},
 			Voters:     util.Ptr(0),
@@ -2539,6 +2563,8 @@ func NewTestReports() map[string]*gtsmodel.Report {
 			ActionTaken:            "user was warned not to be a turtle anymore",
 			ActionTakenAt:          TimeMustParse("2022-05-15T17:01:56+02:00"),
 			ActionTakenByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			AssignedAccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
+			ResolutionCategory:     gtsmodel.ReportResolutionActioned,
 		},
 	}
 }
@@ -3373,6 +3399,101 @@ func NewTestUserMutes() map[string]*gtsmodel.UserMute {
 	return map[string]*gtsmodel.UserMute{}
 }
 
+func NewTestInvites() map[string]*gtsmodel.Invite {
+	return map[string]*gtsmodel.Invite{
+		"admin_account_invite_1": {
+			ID:                 "01J1XW0YB6J5S3ZVG0Y57NMQTK",
+			CreatedAt:          TimeMustParse("2024-06-30T16:02:11+02:00"),
+			UpdatedAt:          TimeMustParse("2024-06-30T16:02:11+02:00"),
+			Code:               "SZnV7r2Q",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+		"admin_account_invite_expired": {
+			ID:                 "01J1XW3N8WZ0AE8EJ2H1Q7GZ5F",
+			CreatedAt:          TimeMustParse("2024-06-30T16:03:40+02:00"),
+			UpdatedAt:          TimeMustParse("2024-06-30T16:03:40+02:00"),
+			Code:               "8Xwq3KtB",
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			MaxUses:            10,
+			ExpiresAt:          TimeMustParse("2024-07-01T16:03:40+02:00"),
+		},
+		"local_account_1_invite_used": {
+			ID:                 "01J1XW6CVGY3DQ8P3N06ZRN2XH",
+			CreatedAt:          TimeMustParse("2024-06-30T16:05:09+02:00"),
+			UpdatedAt:          TimeMustParse("2024-06-30T16:09:51+02:00"),
+			Code:               "mN4ha9Ue",
+			CreatedByAccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			MaxUses:            1,
+			Uses:               1,
+		},
+	}
+}
+
+func NewTestAnnouncements() map[string]*gtsmodel.Announcement {
+	return map[string]*gtsmodel.Announcement{
+		"admin_account_announcement_1": {
+			ID:                 "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+			CreatedAt:          TimeMustParse("2024-07-05T10:31:12+02:00"),
+			UpdatedAt:          TimeMustParse("2024-07-05T10:31:12+02:00"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Content:            "<p>Scheduled maintenance this weekend, expect some downtime :rainbow:</p>",
+			Text:               "Scheduled maintenance this weekend, expect some downtime :rainbow:",
+			EmojiIDs:           []string{"01F8MH9H8E4VG3KDYJR9EGPXCQ"},
+			AllDay:             util.Ptr(false),
+		},
+		"admin_account_announcement_ended": {
+			ID:                 "01J23QH3T0G0Q4V2BCRDT4X1MR",
+			CreatedAt:          TimeMustParse("2024-07-05T10:33:06+02:00"),
+			UpdatedAt:          TimeMustParse("2024-07-05T10:33:06+02:00"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Content:            "<p>Happy new year everyone!</p>",
+			Text:               "Happy new year everyone!",
+			StartsAt:           TimeMustParse("2024-01-01T00:00:00+00:00"),
+			EndsAt:             TimeMustParse("2024-01-02T00:00:00+00:00"),
+			AllDay:             util.Ptr(true),
+		},
+	}
+}
+
+func NewTestAnnouncementReads() map[string]*gtsmodel.AnnouncementRead {
+	return map[string]*gtsmodel.AnnouncementRead{
+		"admin_account_read_announcement_1": {
+			ID:             "01J23QNA4C9Z3GSR8V6J5KQ0HB",
+			CreatedAt:      TimeMustParse("2024-07-05T10:35:24+02:00"),
+			AnnouncementID: "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+			AccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
+		},
+	}
+}
+
+func NewTestAnnouncementReactions() map[string]*gtsmodel.AnnouncementReaction {
+	return map[string]*gtsmodel.AnnouncementReaction{
+		"admin_account_announcement_1_reaction_rainbow": {
+			ID:             "01J23QR8WBY4SCTR5RCZ3WQ6AF",
+			CreatedAt:      TimeMustParse("2024-07-05T10:37:00+02:00"),
+			AnnouncementID: "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+			AccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
+			Name:           "rainbow",
+			EmojiID:        "01F8MH9H8E4VG3KDYJR9EGPXCQ",
+		},
+		"local_account_1_announcement_1_reaction_rainbow": {
+			ID:             "01J23QS2R0M3E9JC1Z8X8GKXN4",
+			CreatedAt:      TimeMustParse("2024-07-05T10:37:27+02:00"),
+			AnnouncementID: "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+			AccountID:      "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Name:           "rainbow",
+			EmojiID:        "01F8MH9H8E4VG3KDYJR9EGPXCQ",
+		},
+		"local_account_1_announcement_1_reaction_turtle": {
+			ID:             "01J23QSW1N4X2P2W9VGQ0S3J6D",
+			CreatedAt:      TimeMustParse("2024-07-05T10:37:53+02:00"),
+			AnnouncementID: "01J23QDKXNW5Z9SB6A9BJ9T4YV",
+			AccountID:      "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Name:           "🐢",
+		},
+	}
+}
+
 // GetSignatureForActivity prepares a mock HTTP request as if it were going to deliver activity to destination signed for privkey and pubKeyID, signs the request and returns the header values.
 func GetSignatureForActivity(activity pub.Activity, pubKeyID string, privkey *rsa.PrivateKey, destination *url.URL) (signatureHeader string, digestHeader string, dateHeader string) {
 	// convert the activity into json bytes
@@ -19,6 +19,8 @@ package testrig
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"io"
@@ -238,6 +240,23 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			}
 		}
 
+		header := http.Header{"Content-Type": {responseContentType}}
+
+		if responseCode == http.StatusOK &&
+			responseContentType == applicationActivityJSON {
+			// Set an ETag on AP objects, and support
+			// conditional requests made using it.
+			sum := sha256.Sum256(responseBytes)
+			etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+			header.Set("ETag", etag)
+
+			if req.Header.Get("If-None-Match") == etag {
+				responseCode = http.StatusNotModified
+				responseBytes = []byte{}
+				responseContentLength = 0
+			}
+		}
+
 		log.Debugf(nil, "returning response %s", string(responseBytes))
 		reader := bytes.NewReader(responseBytes)
 		readCloser := io.NopCloser(reader)
@@ -246,7 +265,7 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			StatusCode:    responseCode,
 			Body:          readCloser,
 			ContentLength: int64(responseContentLength),
-			Header:        http.Header{"Content-Type": {responseContentType}},
+			Header:        header,
 		}, nil
 	}
 
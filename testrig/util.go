@@ -37,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	gtsworkers "github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
 // Starts workers on the provided state using noop processing functions.
@@ -45,8 +46,8 @@ func StartNoopWorkers(state *state.State) {
 	state.Workers.Client.Process = func(ctx context.Context, msg *messages.FromClientAPI) error { return nil }
 	state.Workers.Federator.Process = func(ctx context.Context, msg *messages.FromFediAPI) error { return nil }
 
-	state.Workers.Client.Init(messages.ClientMsgIndices())
-	state.Workers.Federator.Init(messages.FederatorMsgIndices())
+	state.Workers.Client.Init(messages.ClientMsgIndices(), gtsworkers.ClientMsgLane)
+	state.Workers.Federator.Init(messages.FederatorMsgIndices(), gtsworkers.FederatorMsgLane)
 	state.Workers.Delivery.Init(nil)
 
 	// Specifically do NOT start the workers
@@ -55,6 +56,7 @@ func StartNoopWorkers(state *state.State) {
 	// _ = state.Workers.Client.Start(1)
 	// _ = state.Workers.Federator.Start(1)
 	// _ = state.Workers.Dereference.Start(1)
+	// _ = state.Workers.Processing.Start(1)
 	// _ = state.Workers.Media.Start(1)
 	//
 	// (except for the scheduler, that's fine)
@@ -74,14 +76,15 @@ func StartWorkers(state *state.State, processor *workers.Processor) {
 		return processor.ProcessFromFediAPI(ctx, msg)
 	}
 
-	state.Workers.Client.Init(messages.ClientMsgIndices())
-	state.Workers.Federator.Init(messages.FederatorMsgIndices())
+	state.Workers.Client.Init(messages.ClientMsgIndices(), gtsworkers.ClientMsgLane)
+	state.Workers.Federator.Init(messages.FederatorMsgIndices(), gtsworkers.FederatorMsgLane)
 	state.Workers.Delivery.Init(nil)
 
 	_ = state.Workers.Scheduler.Start()
 	state.Workers.Client.Start(1)
 	state.Workers.Federator.Start(1)
 	state.Workers.Dereference.Start(1)
+	state.Workers.Processing.Start(1)
 }
 
 func StopWorkers(state *state.State) {
@@ -89,6 +92,7 @@ func StopWorkers(state *state.State) {
 	state.Workers.Client.Stop()
 	state.Workers.Federator.Stop()
 	state.Workers.Dereference.Stop()
+	state.Workers.Processing.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {